@@ -0,0 +1,79 @@
+package archiver
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/sirupsen/logrus"
+)
+
+// Retention policy of an archived dataset.
+type Retention struct {
+	// Epochs is the number of most recent epochs kept. Every epoch is kept if 0.
+	Epochs uint64
+	// KeepEvery keeps the epochs which are a multiple of it forever, regardless of Epochs.
+	// Disabled if 0.
+	KeepEvery uint64
+}
+
+// pruneBefore returns the first epoch kept by the retention policy at the current epoch, or
+// false if no epoch needs to be pruned.
+func (r Retention) pruneBefore(currentEpoch uint64) (uint64, bool) {
+	if r.Epochs == 0 || currentEpoch < r.Epochs {
+		return 0, false
+	}
+	return currentEpoch - r.Epochs + 1, true
+}
+
+// runPruner prunes the archived datasets according to their retention policy once per epoch.
+func (s *Service) runPruner(ctx context.Context) {
+	if s.balancesRetention.Epochs == 0 && s.committeesRetention.Epochs == 0 && s.participationRetention.Epochs == 0 {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"balancesRetention":      s.balancesRetention.Epochs,
+		"committeesRetention":    s.committeesRetention.Epochs,
+		"participationRetention": s.participationRetention.Epochs,
+	}).Info("Pruning archived data")
+	secondsPerEpoch := params.BeaconConfig().SecondsPerSlot * params.BeaconConfig().SlotsPerEpoch
+	runutil.RunEvery(ctx, time.Duration(secondsPerEpoch)*time.Second, func() {
+		if err := s.scheduler.Wait(ctx, "archive-prune"); err != nil {
+			return
+		}
+		s.prune(ctx, helpers.SlotToEpoch(s.headFetcher.HeadSlot()))
+	})
+}
+
+// prune the archived datasets at the current epoch.
+func (s *Service) prune(ctx context.Context, currentEpoch uint64) {
+	datasets := []struct {
+		name      string
+		retention Retention
+		prune     func(context.Context, uint64, uint64) (int, error)
+	}{
+		{name: "balances", retention: s.balancesRetention, prune: s.beaconDB.PruneArchivedBalances},
+		{name: "committees", retention: s.committeesRetention, prune: s.beaconDB.PruneArchivedCommitteeInfo},
+		{name: "participation", retention: s.participationRetention, prune: s.beaconDB.PruneArchivedValidatorParticipation},
+	}
+	for _, dataset := range datasets {
+		before, ok := dataset.retention.pruneBefore(currentEpoch)
+		if !ok {
+			continue
+		}
+		pruned, err := dataset.prune(ctx, before, dataset.retention.KeepEvery)
+		if err != nil {
+			log.WithError(err).WithField("dataset", dataset.name).Error("Could not prune archived data")
+			continue
+		}
+		if pruned > 0 {
+			log.WithFields(logrus.Fields{
+				"dataset": dataset.name,
+				"epochs":  pruned,
+				"before":  before,
+			}).Debug("Pruned archived data")
+		}
+	}
+}
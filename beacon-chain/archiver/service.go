@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/validators"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/scheduler"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -23,13 +24,17 @@ var log = logrus.WithField("prefix", "archiver")
 // Service defining archiver functionality for persisting checkpointed
 // beacon chain information to a database backend for historical purposes.
 type Service struct {
-	ctx                  context.Context
-	cancel               context.CancelFunc
-	beaconDB             db.NoHeadAccessDatabase
-	headFetcher          blockchain.HeadFetcher
-	participationFetcher blockchain.ParticipationFetcher
-	stateNotifier        statefeed.Notifier
-	lastArchivedEpoch    uint64
+	ctx                    context.Context
+	cancel                 context.CancelFunc
+	beaconDB               db.NoHeadAccessDatabase
+	headFetcher            blockchain.HeadFetcher
+	participationFetcher   blockchain.ParticipationFetcher
+	stateNotifier          statefeed.Notifier
+	scheduler              *scheduler.Service
+	lastArchivedEpoch      uint64
+	balancesRetention      Retention
+	committeesRetention    Retention
+	participationRetention Retention
 }
 
 // Config options for the archiver service.
@@ -38,24 +43,34 @@ type Config struct {
 	HeadFetcher          blockchain.HeadFetcher
 	ParticipationFetcher blockchain.ParticipationFetcher
 	StateNotifier        statefeed.Notifier
+	Scheduler            *scheduler.Service
+	// Retention policies of the archived balances, committee info and validator participation.
+	BalancesRetention      Retention
+	CommitteesRetention    Retention
+	ParticipationRetention Retention
 }
 
 // NewArchiverService initializes the service from configuration options.
 func NewArchiverService(ctx context.Context, cfg *Config) *Service {
 	ctx, cancel := context.WithCancel(ctx)
 	return &Service{
-		ctx:                  ctx,
-		cancel:               cancel,
-		beaconDB:             cfg.BeaconDB,
-		headFetcher:          cfg.HeadFetcher,
-		participationFetcher: cfg.ParticipationFetcher,
-		stateNotifier:        cfg.StateNotifier,
+		ctx:                    ctx,
+		cancel:                 cancel,
+		beaconDB:               cfg.BeaconDB,
+		headFetcher:            cfg.HeadFetcher,
+		participationFetcher:   cfg.ParticipationFetcher,
+		stateNotifier:          cfg.StateNotifier,
+		scheduler:              cfg.Scheduler,
+		balancesRetention:      cfg.BalancesRetention,
+		committeesRetention:    cfg.CommitteesRetention,
+		participationRetention: cfg.ParticipationRetention,
 	}
 }
 
-// Start the archiver service event loop.
+// Start the archiver service event loop, and the pruner of the archived data.
 func (s *Service) Start() {
 	go s.run(s.ctx)
+	s.runPruner(s.ctx)
 }
 
 // Stop the archiver service event loop.
@@ -80,10 +95,15 @@ func (s *Service) archiveCommitteeInfo(ctx context.Context, headState *state.Bea
 	if err != nil {
 		return errors.Wrap(err, "could not generate seed")
 	}
+	activeIndices, err := helpers.ActiveValidatorIndices(headState, epoch)
+	if err != nil {
+		return errors.Wrap(err, "could not get active indices")
+	}
 
 	info := &pb.ArchivedCommitteeInfo{
-		ProposerSeed: proposerSeed[:],
-		AttesterSeed: attesterSeed[:],
+		ProposerSeed:  proposerSeed[:],
+		AttesterSeed:  attesterSeed[:],
+		ActiveIndices: activeIndices,
 	}
 	if err := s.beaconDB.SaveArchivedCommitteeInfo(ctx, epoch, info); err != nil {
 		return errors.Wrap(err, "could not archive committee info")
@@ -120,13 +140,18 @@ func (s *Service) archiveActiveSetChanges(ctx context.Context, headState *state.
 // matching validator attestations during the epoch.
 func (s *Service) archiveParticipation(ctx context.Context, epoch uint64) error {
 	pBal := s.participationFetcher.Participation(epoch)
-	participation := &ethpb.ValidatorParticipation{}
-	if pBal != nil {
-		participation = &ethpb.ValidatorParticipation{
-			EligibleEther:           pBal.ActivePrevEpoch,
-			VotedEther:              pBal.PrevEpochTargetAttested,
-			GlobalParticipationRate: float32(pBal.PrevEpochTargetAttested) / float32(pBal.ActivePrevEpoch),
-		}
+	if pBal == nil {
+		// Participation is not computed yet, e.g. right after startup. An empty record would be
+		// served as the participation of the epoch, so nothing is archived.
+		log.WithField("epoch", epoch).Debug("Participation is not available, skipping archival")
+		return nil
+	}
+	participation := &ethpb.ValidatorParticipation{
+		EligibleEther: pBal.ActivePrevEpoch,
+		VotedEther:    pBal.PrevEpochTargetAttested,
+	}
+	if pBal.ActivePrevEpoch > 0 {
+		participation.GlobalParticipationRate = float32(pBal.PrevEpochTargetAttested) / float32(pBal.ActivePrevEpoch)
 	}
 	return s.beaconDB.SaveArchivedValidatorParticipation(ctx, epoch, participation)
 }
@@ -167,6 +192,10 @@ func (s *Service) run(ctx context.Context) {
 				if !helpers.IsEpochEnd(slot) {
 					epochToArchive--
 				}
+				if err := s.scheduler.Wait(ctx, "archive"); err != nil {
+					log.Debug("Context closed, exiting goroutine")
+					return
+				}
 				if err := s.archiveCommitteeInfo(ctx, headState, epochToArchive); err != nil {
 					log.WithError(err).Error("Could not archive committee info")
 					continue
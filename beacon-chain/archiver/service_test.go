@@ -246,9 +246,14 @@ func TestArchiverService_SavesCommitteeInfo(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	activeIndices, err := helpers.ActiveValidatorIndices(headState, currentEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
 	wanted := &pb.ArchivedCommitteeInfo{
-		ProposerSeed: proposerSeed[:],
-		AttesterSeed: attesterSeed[:],
+		ProposerSeed:  proposerSeed[:],
+		AttesterSeed:  attesterSeed[:],
+		ActiveIndices: activeIndices,
 	}
 
 	retrieved, err := svc.beaconDB.ArchivedCommitteeInfo(svc.ctx, helpers.CurrentEpoch(headState))
@@ -444,6 +449,85 @@ func setupState(validatorCount uint64) (*stateTrie.BeaconState, error) {
 	return st, nil
 }
 
+func TestArchiverService_PrunesWithRetention(t *testing.T) {
+	svc, beaconDB := setupService(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	svc.balancesRetention = Retention{Epochs: 4, KeepEvery: 3}
+	ctx := context.Background()
+	for epoch := uint64(0); epoch < 10; epoch++ {
+		if err := beaconDB.SaveArchivedBalances(ctx, epoch, []uint64{epoch}); err != nil {
+			t.Fatal(err)
+		}
+		if err := beaconDB.SaveArchivedValidatorParticipation(ctx, epoch, &ethpb.ValidatorParticipation{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc.prune(ctx, 9)
+	for epoch := uint64(0); epoch < 10; epoch++ {
+		balances, err := beaconDB.ArchivedBalances(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The last 4 epochs are kept, along with every third epoch.
+		kept := epoch >= 6 || epoch%3 == 0
+		if kept != (balances != nil) {
+			t.Errorf("Expected balances of epoch %d kept: %v, received %v", epoch, kept, balances)
+		}
+		participation, err := beaconDB.ArchivedValidatorParticipation(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if participation == nil {
+			t.Errorf("Expected participation of epoch %d to be kept without retention policy", epoch)
+		}
+	}
+}
+
+func TestRetention_PruneBefore(t *testing.T) {
+	if _, ok := (Retention{}).pruneBefore(100); ok {
+		t.Error("Expected every epoch to be kept without retention")
+	}
+	if _, ok := (Retention{Epochs: 10}).pruneBefore(5); ok {
+		t.Error("Expected no epoch to be pruned before the retention period")
+	}
+	if before, ok := (Retention{Epochs: 10}).pruneBefore(100); !ok || before != 91 {
+		t.Errorf("Expected epochs before 91 to be pruned, received %d", before)
+	}
+}
+
+func TestArchiverService_SkipsUnavailableParticipation(t *testing.T) {
+	hook := logTest.NewGlobal()
+	headState, err := setupState(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, beaconDB := setupService(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	svc.headFetcher = &mock.ChainService{
+		State: headState,
+	}
+	svc.participationFetcher = &mock.ChainService{}
+	event := &feed.Event{
+		Type: statefeed.BlockProcessed,
+		Data: &statefeed.BlockProcessedData{
+			BlockRoot: [32]byte{1, 2, 3},
+			Verified:  true,
+		},
+	}
+	triggerStateEvent(t, svc, event)
+
+	currentEpoch := helpers.CurrentEpoch(headState)
+	retrieved, err := svc.beaconDB.ArchivedValidatorParticipation(svc.ctx, currentEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved != nil {
+		t.Errorf("Expected no participation to be archived, received %v", retrieved)
+	}
+	testutil.AssertLogsContain(t, hook, "Participation is not available")
+}
+
 func setupService(t *testing.T) (*Service, db.Database) {
 	beaconDB := dbutil.SetupDB(t)
 	ctx, cancel := context.WithCancel(context.Background())
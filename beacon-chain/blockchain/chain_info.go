@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	f "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -44,6 +45,7 @@ type HeadFetcher interface {
 	HeadValidatorsIndices(epoch uint64) ([]uint64, error)
 	HeadSeed(epoch uint64) ([32]byte, error)
 	HeadGenesisValidatorRoot() [32]byte
+	HeadValidatorIndex(pubKey [48]byte) (uint64, bool)
 }
 
 // ForkFetcher retrieves the current fork information of the Ethereum beacon chain.
@@ -59,6 +61,12 @@ type FinalizationFetcher interface {
 	PreviousJustifiedCheckpt() *ethpb.Checkpoint
 }
 
+// ForkChoiceFetcher defines a common interface for methods in blockchain service which
+// directly retrieves fork choice related data.
+type ForkChoiceFetcher interface {
+	ForkChoiceStore() f.ForkChoicer
+}
+
 // ParticipationFetcher defines a common interface for methods in blockchain service which
 // directly retrieves validator participation related data.
 type ParticipationFetcher interface {
@@ -181,6 +189,20 @@ func (s *Service) HeadSeed(epoch uint64) ([32]byte, error) {
 	return helpers.Seed(s.headState(), epoch, params.BeaconConfig().DomainBeaconAttester)
 }
 
+// HeadValidatorIndex returns the index of the validator with the given public key in the head state,
+// without copying the head state.
+func (s *Service) HeadValidatorIndex(pubKey [48]byte) (uint64, bool) {
+	if !s.hasHeadState() || s.valIndexCache == nil {
+		return 0, false
+	}
+	idx, ok := s.valIndexCache.Index(pubKey)
+	// The cache may know validators which are only in states of other forks.
+	if !ok || idx >= uint64(s.headNumValidators()) {
+		return 0, false
+	}
+	return idx, true
+}
+
 // HeadGenesisValidatorRoot returns genesis validator root of the head state.
 func (s *Service) HeadGenesisValidatorRoot() [32]byte {
 	if !s.hasHeadState() {
@@ -222,3 +244,8 @@ func (s *Service) Participation(epoch uint64) *precompute.Balance {
 
 	return s.epochParticipation[epoch]
 }
+
+// ForkChoiceStore returns the fork choice store of the blockchain service.
+func (s *Service) ForkChoiceStore() f.ForkChoicer {
+	return s.forkChoiceStore
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -218,3 +219,28 @@ func TestGenesisValidatorRoot_CanRetrieve(t *testing.T) {
 		t.Error("Did not get correct genesis validator root")
 	}
 }
+
+func TestHeadValidatorIndex_CanRetrieve(t *testing.T) {
+	pubKey := [48]byte{'a'}
+	s, err := state.InitializeFromProto(&pb.BeaconState{
+		Validators: []*ethpb.Validator{{PublicKey: []byte{'b'}}, {PublicKey: pubKey[:]}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Service{valIndexCache: cache.NewValidatorIndexCache()}
+	if _, ok := c.HeadValidatorIndex(pubKey); ok {
+		t.Error("Expected no index without a head state")
+	}
+	c.setHead([32]byte{}, &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}, s)
+	idx, ok := c.HeadValidatorIndex(pubKey)
+	if !ok {
+		t.Fatal("Expected to find the validator index")
+	}
+	if idx != 1 {
+		t.Errorf("Wanted index 1, received %d", idx)
+	}
+	if _, ok := c.HeadValidatorIndex([48]byte{'c'}); ok {
+		t.Error("Expected no index for an unknown public key")
+	}
+}
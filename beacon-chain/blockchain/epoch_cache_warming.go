@@ -0,0 +1,38 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"go.opencensus.io/trace"
+)
+
+// warmNextEpochCaches advances a copy of the state to the start of the next epoch and caches
+// that epoch's committee shuffling and proposer indices, so that the first slots of the epoch
+// do not pay the shuffling cost during block production and attestation validation. It is
+// meant to run in the background right after the state crossed an epoch boundary.
+func (s *Service) warmNextEpochCaches(ctx context.Context, st *stateTrie.BeaconState) {
+	ctx, span := trace.StartSpan(ctx, "blockchain.warmNextEpochCaches")
+	defer span.End()
+
+	start := roughtime.Now()
+	nextEpoch := helpers.NextEpoch(st)
+	nextState, err := state.ProcessSlots(ctx, st, helpers.StartSlot(nextEpoch))
+	if err != nil {
+		log.WithError(err).Warn("Could not advance state to warm next epoch caches")
+		return
+	}
+	if err := helpers.UpdateCommitteeCache(nextState, nextEpoch); err != nil {
+		log.WithError(err).Warn("Could not warm committee cache for next epoch")
+		return
+	}
+	if err := helpers.UpdateProposerIndicesInCache(nextState, nextEpoch); err != nil {
+		log.WithError(err).Warn("Could not warm proposer indices cache for next epoch")
+		return
+	}
+	log.WithField("epoch", nextEpoch).WithField("duration", time.Since(start)).Debug("Warmed committee and proposer caches for next epoch")
+}
@@ -0,0 +1,59 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"go.opencensus.io/trace"
+)
+
+// saveForkChoiceCheckpointRoutine saves the fork choice store to the DB at the start of every
+// epoch, so a restarted node restores it instead of rebuilding it from the unfinalized blocks.
+func (s *Service) saveForkChoiceCheckpointRoutine() {
+	ticker := slotutil.GetSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case slot := <-ticker.C():
+			if slot%params.BeaconConfig().SlotsPerEpoch != 0 {
+				continue
+			}
+			if err := s.saveForkChoiceCheckpoint(s.ctx); err != nil {
+				log.WithError(err).Warn("Could not save fork choice checkpoint")
+			}
+		}
+	}
+}
+
+// saveForkChoiceCheckpoint saves a snapshot of the fork choice store to the DB.
+func (s *Service) saveForkChoiceCheckpoint(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "blockchain.saveForkChoiceCheckpoint")
+	defer span.End()
+
+	if s.forkChoiceStore == nil {
+		return nil
+	}
+	return s.beaconDB.SaveForkChoiceCheckpoint(ctx, s.forkChoiceStore.Checkpoint())
+}
+
+// restoreForkChoice returns the fork choice store saved in the DB, or nil if there is none or it
+// was saved at a different finalized checkpoint than the given one.
+func (s *Service) restoreForkChoice(ctx context.Context, finalizedCheckpoint *ethpb.Checkpoint) (*protoarray.ForkChoice, error) {
+	ctx, span := trace.StartSpan(ctx, "blockchain.restoreForkChoice")
+	defer span.End()
+
+	cp, err := s.beaconDB.ForkChoiceCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cp == nil || cp.FinalizedEpoch != finalizedCheckpoint.Epoch || !bytes.Equal(cp.FinalizedRoot, finalizedCheckpoint.Root) {
+		return nil, nil
+	}
+	return protoarray.NewFromCheckpoint(ctx, cp)
+}
@@ -0,0 +1,52 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+)
+
+func TestResumeForkChoice_RestoresCheckpoint(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+
+	finalizedRoot := [32]byte{'a'}
+	blockRoot := [32]byte{'b'}
+	store := protoarray.New(1, 1, finalizedRoot)
+	if err := store.ProcessBlock(ctx, 32, finalizedRoot, [32]byte{}, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ProcessBlock(ctx, 33, blockRoot, finalizedRoot, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	service := &Service{beaconDB: db, forkChoiceStore: store}
+	if err := service.saveForkChoiceCheckpoint(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	justified := &ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}
+	finalized := &ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}
+	restarted := &Service{beaconDB: db}
+	restarted.resumeForkChoice(ctx, justified, finalized)
+	if !restarted.forkChoiceStore.HasNode(blockRoot) {
+		t.Error("Expected the unfinalized block to be restored in fork choice")
+	}
+	if len(restarted.forkChoiceStore.Nodes()) != 2 {
+		t.Errorf("Wanted 2 nodes, received %d", len(restarted.forkChoiceStore.Nodes()))
+	}
+
+	// A checkpoint saved at another finalized checkpoint is ignored.
+	newFinalized := &ethpb.Checkpoint{Epoch: 2, Root: blockRoot[:]}
+	restarted = &Service{beaconDB: db}
+	restarted.resumeForkChoice(ctx, newFinalized, newFinalized)
+	if len(restarted.forkChoiceStore.Nodes()) != 0 {
+		t.Errorf("Wanted an empty fork choice store, received %d nodes", len(restarted.forkChoiceStore.Nodes()))
+	}
+	if finalizedEpoch := restarted.forkChoiceStore.Snapshot().FinalizedEpoch; finalizedEpoch != 2 {
+		t.Errorf("Wanted finalized epoch 2, received %d", finalizedEpoch)
+	}
+}
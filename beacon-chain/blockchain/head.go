@@ -53,7 +53,8 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 	defer span.End()
 
 	// Do nothing if head hasn't changed.
-	if headRoot == s.headRoot() {
+	oldHeadRoot := s.headRoot()
+	if headRoot == oldHeadRoot {
 		return nil
 	}
 
@@ -103,6 +104,20 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 	// Cache the new head info.
 	s.setHead(headRoot, newHeadBlock, newHeadState)
 
+	// A new head which does not build on the old head may orphan blocks, which are reported
+	// and whose operations are returned to the pools.
+	if oldHeadRoot != params.BeaconConfig().ZeroHash && bytesutil.ToBytes32(newHeadBlock.Block.ParentRoot) != oldHeadRoot {
+		orphaned, canonical, err := s.reorgBranches(ctx, oldHeadRoot, headRoot)
+		if err != nil {
+			log.WithError(err).Warn("Could not find the blocks orphaned by the new head")
+		} else if len(orphaned) > 0 {
+			s.reportReorg(oldHeadRoot, headRoot, newHeadBlock.Block.Slot, orphaned)
+			if err := s.reinsertOrphanedOperations(ctx, orphaned, canonical, newHeadState); err != nil {
+				log.WithError(err).Warn("Could not return operations of orphaned blocks to the pools")
+			}
+		}
+	}
+
 	// Save the new head root to DB.
 	if err := s.beaconDB.SaveHeadBlockRoot(ctx, headRoot); err != nil {
 		return errors.Wrap(err, "could not save head root in DB")
@@ -161,6 +176,9 @@ func (s *Service) setHead(root [32]byte, block *ethpb.SignedBeaconBlock, state *
 		block: stateTrie.CopySignedBeaconBlock(block),
 		state: state.Copy(),
 	}
+	if s.valIndexCache != nil {
+		s.valIndexCache.UpdateFromState(state)
+	}
 }
 
 // This returns the head slot.
@@ -202,6 +220,14 @@ func (s *Service) headState() *state.BeaconState {
 	return s.head.state.Copy()
 }
 
+// This returns the number of validators in the head state.
+func (s *Service) headNumValidators() int {
+	s.headLock.RLock()
+	defer s.headLock.RUnlock()
+
+	return s.head.state.NumValidators()
+}
+
 // This returns the genesis validator root of the head state.
 func (s *Service) headGenesisValidatorRoot() [32]byte {
 	s.headLock.RLock()
@@ -0,0 +1,53 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"go.opencensus.io/trace"
+)
+
+// advanceHeadStateRoutine advances the head state through the next slot late in every slot,
+// once the block of the slot had time to arrive. The advanced state lands in the skip slot
+// cache, so a proposer building on a head several slots old does not have to process the
+// empty slots under the proposal deadline.
+func (s *Service) advanceHeadStateRoutine() {
+	ticker := slotutil.GetSlotTicker(s.genesisTime, params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	advanceDelay := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second * 2 / 3
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case slot := <-ticker.C():
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(advanceDelay):
+			}
+			s.advanceHeadState(s.ctx, slot+1)
+		}
+	}
+}
+
+// advanceHeadState processes the empty slots between the head state and the given slot. Heads
+// more than an epoch behind, such as while syncing, are left alone as the next blocks are about
+// to replace them.
+func (s *Service) advanceHeadState(ctx context.Context, slot uint64) {
+	ctx, span := trace.StartSpan(ctx, "blockchain.advanceHeadState")
+	defer span.End()
+
+	if !s.hasHeadState() {
+		return
+	}
+	headState := s.headState()
+	if headState.Slot() >= slot || slot-headState.Slot() > params.BeaconConfig().SlotsPerEpoch {
+		return
+	}
+	if _, err := state.ProcessSlots(ctx, headState, slot); err != nil {
+		log.WithError(err).Debug("Could not advance head state")
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/go-ssz"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -84,3 +86,74 @@ func TestSaveHead_Different(t *testing.T) {
 		t.Error("Head did not change")
 	}
 }
+
+func TestSaveHead_ReorgReinsertsOrphanedAttestations(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	service := setupBeaconChain(t, db)
+	ctx := context.Background()
+
+	genesis := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 0}}
+	if err := db.SaveBlock(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orphanedAtt := &ethpb.Attestation{
+		Data:            &ethpb.AttestationData{Slot: 0, BeaconBlockRoot: genesisRoot[:]},
+		AggregationBits: bitfield.Bitlist{0b101},
+	}
+	includedAtt := &ethpb.Attestation{
+		Data:            &ethpb.AttestationData{Slot: 0, BeaconBlockRoot: genesisRoot[:]},
+		AggregationBits: bitfield.Bitlist{0b110},
+	}
+	oldHead := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{
+		Slot:       1,
+		ParentRoot: genesisRoot[:],
+		Body:       &ethpb.BeaconBlockBody{Attestations: []*ethpb.Attestation{orphanedAtt, includedAtt}},
+	}}
+	newHead := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{
+		Slot:       2,
+		ParentRoot: genesisRoot[:],
+		Body:       &ethpb.BeaconBlockBody{Attestations: []*ethpb.Attestation{includedAtt}},
+	}}
+	for _, b := range []*ethpb.SignedBeaconBlock{oldHead, newHead} {
+		if err := db.SaveBlock(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldRoot, err := ssz.HashTreeRoot(oldHead.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newRoot, err := ssz.HashTreeRoot(newHead.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.head = &head{slot: 1, root: oldRoot, block: oldHead}
+
+	headState := testutil.NewBeaconState()
+	if err := headState.SetSlot(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveStateSummary(ctx, &pb.StateSummary{Slot: 2, Root: newRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, headState, newRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.saveHead(ctx, newRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	atts := service.attPool.UnaggregatedAttestations()
+	if len(atts) != 1 {
+		t.Fatalf("Wanted 1 reinserted attestation, got %d", len(atts))
+	}
+	if !proto.Equal(atts[0], orphanedAtt) {
+		t.Errorf("Wanted reinserted attestation %v, got %v", orphanedAtt, atts[0])
+	}
+}
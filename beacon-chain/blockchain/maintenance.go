@@ -0,0 +1,62 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"go.opencensus.io/trace"
+)
+
+// errBlockProcessingPaused is returned when receiving a block while block processing is paused
+// for maintenance.
+var errBlockProcessingPaused = errors.New("block processing is paused for maintenance")
+
+// PauseBlockProcessing waits for the blocks being processed to finish, and rejects any further
+// blocks until ResumeBlockProcessing is called.
+func (s *Service) PauseBlockProcessing() {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.processingPaused = true
+}
+
+// ResumeBlockProcessing accepts blocks again after PauseBlockProcessing.
+func (s *Service) ResumeBlockProcessing() {
+	s.processingLock.Lock()
+	defer s.processingLock.Unlock()
+	s.processingPaused = false
+}
+
+// FlushCaches saves the blocks, state summaries, head and fork choice store which are only
+// kept in memory to the DB, so that a restarted node resumes from the current head.
+func (s *Service) FlushCaches(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "blockchain.FlushCaches")
+	defer span.End()
+
+	if err := s.beaconDB.SaveBlocks(ctx, s.getInitSyncBlocks()); err != nil {
+		return errors.Wrap(err, "could not save initial sync blocks")
+	}
+	s.clearInitSyncBlocks()
+
+	headRoot := s.headRoot()
+	if headRoot != params.BeaconConfig().ZeroHash {
+		if featureconfig.Get().NewStateMgmt {
+			if err := s.stateGen.SaveStateSummariesToDB(ctx); err != nil {
+				return errors.Wrap(err, "could not save state summaries")
+			}
+		} else if !s.beaconDB.HasState(ctx, headRoot) {
+			if err := s.beaconDB.SaveState(ctx, s.headState(), headRoot); err != nil {
+				return errors.Wrap(err, "could not save head state")
+			}
+		}
+		if err := s.beaconDB.SaveHeadBlockRoot(ctx, headRoot); err != nil {
+			return errors.Wrap(err, "could not save head block root")
+		}
+	}
+
+	if err := s.saveForkChoiceCheckpoint(ctx); err != nil {
+		return errors.Wrap(err, "could not save fork choice checkpoint")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+)
+
+func TestPauseBlockProcessing_RejectsBlocks(t *testing.T) {
+	ctx := context.Background()
+	service := &Service{}
+	service.PauseBlockProcessing()
+
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1, Body: &ethpb.BeaconBlockBody{}}}
+	if err := service.ReceiveBlockNoPubsub(ctx, blk); err != errBlockProcessingPaused {
+		t.Errorf("Wanted %v, received %v", errBlockProcessingPaused, err)
+	}
+	if err := service.ReceiveBlockNoPubsubForkchoice(ctx, blk); err != errBlockProcessingPaused {
+		t.Errorf("Wanted %v, received %v", errBlockProcessingPaused, err)
+	}
+	if err := service.ReceiveBlockNoVerify(ctx, blk); err != errBlockProcessingPaused {
+		t.Errorf("Wanted %v, received %v", errBlockProcessingPaused, err)
+	}
+
+	service.ResumeBlockProcessing()
+	if service.processingPaused {
+		t.Error("Expected block processing to be resumed")
+	}
+}
+
+func TestFlushCaches_SavesInitSyncBlocks(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+
+	service := &Service{
+		beaconDB:       db,
+		initSyncBlocks: make(map[[32]byte]*ethpb.SignedBeaconBlock),
+	}
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1, Body: &ethpb.BeaconBlockBody{}}}
+	root, err := stateutil.BlockRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.saveInitSyncBlock(root, blk)
+
+	if err := service.FlushCaches(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !db.HasBlock(ctx, root) {
+		t.Error("Expected the initial sync block to be saved in the DB")
+	}
+	if service.hasInitSyncBlock(root) {
+		t.Error("Expected the initial sync block cache to be cleared")
+	}
+}
@@ -27,6 +27,31 @@ var (
 		Name: "competing_blocks",
 		Help: "The # of blocks received and processed from a competing chain",
 	})
+	reorgCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_reorg_total",
+		Help: "Count the number of times the head changed to a block not descending from the previous head",
+	})
+	reorgDepth = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "beacon_reorg_depth",
+		Help:    "The number of blocks orphaned by a chain reorg",
+		Buckets: []float64{1, 2, 3, 4, 6, 8, 16, 32, 64},
+	})
+	reorgOrphanedBlocks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_reorg_orphaned_blocks_total",
+		Help: "Count the number of blocks orphaned by chain reorgs",
+	})
+	deepReorgCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_deep_reorg_total",
+		Help: "Count the number of chain reorgs orphaning at least the deep reorg threshold of blocks",
+	})
+	lastReorgDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_last_reorg_depth",
+		Help: "The number of blocks orphaned by the last chain reorg",
+	})
+	lastReorgSlot = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_last_reorg_slot",
+		Help: "The slot of the new head of the last chain reorg",
+	})
 	headFinalizedEpoch = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "head_finalized_epoch",
 		Help: "Last finalized epoch of the head state",
@@ -83,6 +108,18 @@ var (
 		Name: "total_voted_target_balances",
 		Help: "The total amount of ether, in gwei, that is eligible for voting of previous epoch",
 	})
+	totalVotedSourceBalances = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "total_voted_source_balances",
+		Help: "The total amount of ether, in gwei, that has been used in voting attestation source of previous epoch",
+	})
+	totalVotedHeadBalances = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "total_voted_head_balances",
+		Help: "The total amount of ether, in gwei, that has been used in voting attestation head of previous epoch",
+	})
+	prevEpochParticipation = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "beacon_prev_epoch_participation_percentage",
+		Help: "The percentage of eligible balance that voted for the correct vote of previous epoch",
+	}, []string{"vote"})
 )
 
 // reportSlotMetrics reports slot related metrics.
@@ -181,5 +218,12 @@ func reportEpochMetrics(state *stateTrie.BeaconState) {
 	if precompute.Balances != nil {
 		totalEligibleBalances.Set(float64(precompute.Balances.ActivePrevEpoch))
 		totalVotedTargetBalances.Set(float64(precompute.Balances.PrevEpochTargetAttested))
+		totalVotedSourceBalances.Set(float64(precompute.Balances.PrevEpochAttested))
+		totalVotedHeadBalances.Set(float64(precompute.Balances.PrevEpochHeadAttested))
+		if eligible := float64(precompute.Balances.ActivePrevEpoch); eligible > 0 {
+			prevEpochParticipation.WithLabelValues("Source").Set(100 * float64(precompute.Balances.PrevEpochAttested) / eligible)
+			prevEpochParticipation.WithLabelValues("Target").Set(100 * float64(precompute.Balances.PrevEpochTargetAttested) / eligible)
+			prevEpochParticipation.WithLabelValues("Head").Set(100 * float64(precompute.Balances.PrevEpochHeadAttested) / eligible)
+		}
 	}
 }
@@ -143,6 +143,10 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 		s.prevFinalizedCheckpt = s.finalizedCheckpt
 		s.finalizedCheckpt = postState.FinalizedCheckpoint()
 
+		if err := s.verifyWeakSubjectivityRoot(ctx); err != nil {
+			log.Fatalf("Could not verify weak subjectivity checkpoint: %v", err)
+		}
+
 		if err := s.finalizedImpliesNewJustified(ctx, postState); err != nil {
 			return nil, errors.Wrap(err, "could not save new justified")
 		}
@@ -171,6 +175,16 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 		if err := helpers.UpdateProposerIndicesInCache(postState, helpers.CurrentEpoch(postState)); err != nil {
 			return nil, err
 		}
+		// Precompute the next epoch's shuffling off the block processing path.
+		go s.warmNextEpochCaches(s.ctx, postState.Copy())
+
+		// Drop pending exits and slashings which can no longer be included in a block.
+		if s.exitPool != nil {
+			s.exitPool.PruneExpired(ctx, postState)
+		}
+		if s.slashingPool != nil {
+			s.slashingPool.PruneExpired(ctx, postState)
+		}
 
 		s.nextEpochBoundarySlot = helpers.StartSlot(helpers.NextEpoch(postState))
 	}
@@ -180,10 +194,13 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 		return nil, err
 	}
 
-	// Delete the processed block attester slashings from slashings pool.
+	// Delete the processed block attester and proposer slashings from slashings pool.
 	for i := 0; i < len(b.Body.AttesterSlashings); i++ {
 		s.slashingPool.MarkIncludedAttesterSlashing(b.Body.AttesterSlashings[i])
 	}
+	for i := 0; i < len(b.Body.ProposerSlashings); i++ {
+		s.slashingPool.MarkIncludedProposerSlashing(b.Body.ProposerSlashings[i])
+	}
 
 	return postState, nil
 }
@@ -297,6 +314,10 @@ func (s *Service) onBlockInitialSyncStateTransition(ctx context.Context, signed
 		s.prevFinalizedCheckpt = s.finalizedCheckpt
 		s.finalizedCheckpt = postState.FinalizedCheckpoint()
 
+		if err := s.verifyWeakSubjectivityRoot(ctx); err != nil {
+			log.Fatalf("Could not verify weak subjectivity checkpoint: %v", err)
+		}
+
 		if err := s.finalizedImpliesNewJustified(ctx, postState); err != nil {
 			return errors.Wrap(err, "could not save new justified")
 		}
@@ -22,6 +22,7 @@ import (
 type AttestationReceiver interface {
 	ReceiveAttestationNoPubsub(ctx context.Context, att *ethpb.Attestation) error
 	IsValidAttestation(ctx context.Context, att *ethpb.Attestation) bool
+	IsValidAttestationBatch(ctx context.Context, atts []*ethpb.Attestation) bool
 }
 
 // ReceiveAttestationNoPubsub is a function that defines the operations that are preformed on
@@ -72,6 +73,29 @@ func (s *Service) IsValidAttestation(ctx context.Context, att *ethpb.Attestation
 	return true
 }
 
+// IsValidAttestationBatch returns true if the signatures of the attestations, which must all
+// share the same attestation data, verify as a single aggregate against their pre-state.
+func (s *Service) IsValidAttestationBatch(ctx context.Context, atts []*ethpb.Attestation) bool {
+	if len(atts) == 0 {
+		return true
+	}
+	if atts[0].Data == nil {
+		return false
+	}
+	baseState, err := s.getAttPreState(ctx, atts[0].Data.Target)
+	if err != nil {
+		log.WithError(err).Error("Failed to get attestation pre state")
+		return false
+	}
+
+	if err := blocks.VerifyAttestationsBatch(ctx, baseState, atts); err != nil {
+		log.WithError(err).Debug("Failed to validate attestation batch")
+		return false
+	}
+
+	return true
+}
+
 // This processes attestations from the attestation pool to account for validator votes and fork choice.
 func (s *Service) processAttestation(subscribedToStateEvents chan struct{}) {
 	// Wait for state to be initialized.
@@ -0,0 +1,201 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// reportReorg records the metrics of a reorg from the old head to the new head orphaning the
+// blocks, and logs it as a warning if it orphans at least the deep reorg threshold of blocks.
+// The orphaned blocks are ordered from the old head down to the common ancestor.
+func (s *Service) reportReorg(
+	oldHeadRoot [32]byte,
+	newHeadRoot [32]byte,
+	newHeadSlot uint64,
+	orphaned []*ethpb.SignedBeaconBlock,
+) {
+	depth := uint64(len(orphaned))
+	reorgCount.Inc()
+	reorgDepth.Observe(float64(depth))
+	reorgOrphanedBlocks.Add(float64(depth))
+	lastReorgDepth.Set(float64(depth))
+	lastReorgSlot.Set(float64(newHeadSlot))
+
+	orphanedSlots := make([]uint64, len(orphaned))
+	for i, b := range orphaned {
+		orphanedSlots[len(orphaned)-1-i] = b.Block.Slot
+	}
+	logger := log.WithFields(logrus.Fields{
+		"depth":         depth,
+		"oldHeadRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(oldHeadRoot[:])),
+		"oldHeadSlot":   orphaned[0].Block.Slot,
+		"newHeadRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(newHeadRoot[:])),
+		"newHeadSlot":   newHeadSlot,
+		"orphanedSlots": orphanedSlots,
+	})
+	if s.deepReorgDepth > 0 && depth >= s.deepReorgDepth {
+		deepReorgCount.Inc()
+		logger.Warn("Deep chain reorg occurred")
+		return
+	}
+	logger.Info("Chain reorg occurred")
+}
+
+// reinsertOrphanedOperations returns the attestations, exits and slashings of the blocks orphaned
+// by a reorg to their pools, so that they can be included in later blocks instead of being lost.
+// Operations which the blocks of the new canonical branch also include are left out.
+func (s *Service) reinsertOrphanedOperations(
+	ctx context.Context,
+	orphaned []*ethpb.SignedBeaconBlock,
+	canonical []*ethpb.SignedBeaconBlock,
+	newHeadState *stateTrie.BeaconState,
+) error {
+	ctx, span := trace.StartSpan(ctx, "blockchain.reinsertOrphanedOperations")
+	defer span.End()
+
+	included := make(map[[32]byte]bool)
+	markIncluded := func(op interface{}) {
+		if root, err := ssz.HashTreeRoot(op); err == nil {
+			included[root] = true
+		}
+	}
+	isIncluded := func(op interface{}) bool {
+		root, err := ssz.HashTreeRoot(op)
+		return err == nil && included[root]
+	}
+	for _, b := range canonical {
+		for _, att := range b.Block.Body.Attestations {
+			markIncluded(att)
+		}
+		for _, exit := range b.Block.Body.VoluntaryExits {
+			markIncluded(exit)
+		}
+		for _, slashing := range b.Block.Body.ProposerSlashings {
+			markIncluded(slashing)
+		}
+		for _, slashing := range b.Block.Body.AttesterSlashings {
+			markIncluded(slashing)
+		}
+	}
+
+	reinserted := 0
+	for _, b := range orphaned {
+		for _, att := range b.Block.Body.Attestations {
+			// Attestations can only be included within an epoch of their slot.
+			if isIncluded(att) || att.Data.Slot+params.BeaconConfig().SlotsPerEpoch < newHeadState.Slot() {
+				continue
+			}
+			if err := s.reinsertAttestation(att); err != nil {
+				return err
+			}
+			reinserted++
+		}
+		if s.exitPool != nil {
+			for _, exit := range b.Block.Body.VoluntaryExits {
+				if isIncluded(exit) {
+					continue
+				}
+				s.exitPool.MarkNotIncluded(exit)
+				s.exitPool.InsertVoluntaryExit(ctx, newHeadState, exit)
+				reinserted++
+			}
+		}
+		if s.slashingPool != nil {
+			for _, slashing := range b.Block.Body.ProposerSlashings {
+				if isIncluded(slashing) {
+					continue
+				}
+				s.slashingPool.MarkNotIncludedProposerSlashing(slashing)
+				if err := s.slashingPool.InsertProposerSlashing(ctx, newHeadState, slashing); err != nil {
+					log.WithError(err).Debug("Could not reinsert orphaned proposer slashing")
+					continue
+				}
+				reinserted++
+			}
+			for _, slashing := range b.Block.Body.AttesterSlashings {
+				if isIncluded(slashing) {
+					continue
+				}
+				s.slashingPool.MarkNotIncludedAttesterSlashing(slashing)
+				if err := s.slashingPool.InsertAttesterSlashing(ctx, newHeadState, slashing); err != nil {
+					log.WithError(err).Debug("Could not reinsert orphaned attester slashing")
+					continue
+				}
+				reinserted++
+			}
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"orphanedBlocks": len(orphaned),
+		"reinsertedOps":  reinserted,
+	}).Debug("Returned operations of orphaned blocks to the pools")
+	return nil
+}
+
+func (s *Service) reinsertAttestation(att *ethpb.Attestation) error {
+	if s.attPool == nil {
+		return nil
+	}
+	if helpers.IsAggregated(att) {
+		return s.attPool.SaveAggregatedAttestation(att)
+	}
+	return s.attPool.SaveUnaggregatedAttestation(att)
+}
+
+// reorgBranches walks back from the old and the new head to their common ancestor, returning the
+// blocks of the orphaned branch and of the new canonical branch. Nothing is returned if the
+// ancestor cannot be found above the finalized checkpoint.
+func (s *Service) reorgBranches(
+	ctx context.Context,
+	oldRoot [32]byte,
+	newRoot [32]byte,
+) ([]*ethpb.SignedBeaconBlock, []*ethpb.SignedBeaconBlock, error) {
+	oldBlk, err := s.beaconDB.Block(ctx, oldRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	newBlk, err := s.beaconDB.Block(ctx, newRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	var finalizedSlot uint64
+	if s.finalizedCheckpt != nil {
+		finalizedSlot = helpers.StartSlot(s.finalizedCheckpt.Epoch)
+	}
+
+	var orphaned, canonical []*ethpb.SignedBeaconBlock
+	for oldRoot != newRoot {
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		if oldBlk == nil || oldBlk.Block == nil || newBlk == nil || newBlk.Block == nil {
+			return nil, nil, nil
+		}
+		if oldBlk.Block.Slot >= newBlk.Block.Slot {
+			if oldBlk.Block.Slot <= finalizedSlot {
+				return nil, nil, nil
+			}
+			orphaned = append(orphaned, oldBlk)
+			oldRoot = bytesutil.ToBytes32(oldBlk.Block.ParentRoot)
+			oldBlk, err = s.beaconDB.Block(ctx, oldRoot)
+		} else {
+			canonical = append(canonical, newBlk)
+			newRoot = bytesutil.ToBytes32(newBlk.Block.ParentRoot)
+			newBlk, err = s.beaconDB.Block(ctx, newRoot)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return orphaned, canonical, nil
+}
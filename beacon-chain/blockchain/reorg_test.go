@@ -0,0 +1,34 @@
+package blockchain
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestReportReorg_WarnsOnDeepReorg(t *testing.T) {
+	hook := logTest.NewGlobal()
+	service := &Service{deepReorgDepth: 2}
+	orphaned := []*ethpb.SignedBeaconBlock{
+		{Block: &ethpb.BeaconBlock{Slot: 5}},
+	}
+
+	service.reportReorg([32]byte{'a'}, [32]byte{'b'}, 6, orphaned)
+	testutil.AssertLogsContain(t, hook, "Chain reorg occurred")
+	testutil.AssertLogsDoNotContain(t, hook, "Deep chain reorg occurred")
+
+	hook.Reset()
+	orphaned = append(orphaned, &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 4}})
+	service.reportReorg([32]byte{'a'}, [32]byte{'b'}, 6, orphaned)
+	testutil.AssertLogsContain(t, hook, "Deep chain reorg occurred")
+	entry := hook.LastEntry()
+	if entry.Data["depth"] != uint64(2) {
+		t.Errorf("Wanted depth 2, received %v", entry.Data["depth"])
+	}
+	slots, ok := entry.Data["orphanedSlots"].([]uint64)
+	if !ok || len(slots) != 2 || slots[0] != 4 || slots[1] != 5 {
+		t.Errorf("Wanted orphaned slots [4 5], received %v", entry.Data["orphanedSlots"])
+	}
+}
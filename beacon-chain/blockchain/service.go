@@ -36,6 +36,7 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
 )
@@ -73,10 +74,17 @@ type Service struct {
 	initSyncStateLock      sync.RWMutex
 	checkpointState        *cache.CheckpointStateCache
 	checkpointStateLock    sync.Mutex
+	valIndexCache          *cache.ValidatorIndexCache
 	stateGen               *stategen.State
 	opsService             *attestations.Service
 	initSyncBlocks         map[[32]byte]*ethpb.SignedBeaconBlock
 	initSyncBlocksLock     sync.RWMutex
+	wsCheckpt              *ethpb.Checkpoint
+	wsVerified             bool
+	wsLock                 sync.Mutex
+	processingLock         sync.RWMutex
+	processingPaused       bool
+	deepReorgDepth         uint64
 }
 
 // Config options for the service.
@@ -94,6 +102,8 @@ type Config struct {
 	ForkChoiceStore   f.ForkChoicer
 	OpsService        *attestations.Service
 	StateGen          *stategen.State
+	WsCheckpt         *ethpb.Checkpoint
+	DeepReorgDepth    uint64
 }
 
 // NewService instantiates a new block service instance that will
@@ -117,9 +127,12 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		initSyncState:      make(map[[32]byte]*stateTrie.BeaconState),
 		boundaryRoots:      [][32]byte{},
 		checkpointState:    cache.NewCheckpointStateCache(),
+		valIndexCache:      cache.NewValidatorIndexCache(),
 		opsService:         cfg.OpsService,
 		stateGen:           cfg.StateGen,
 		initSyncBlocks:     make(map[[32]byte]*ethpb.SignedBeaconBlock),
+		wsCheckpt:          cfg.WsCheckpt,
+		deepReorgDepth:     cfg.DeepReorgDepth,
 	}, nil
 }
 
@@ -160,6 +173,7 @@ func (s *Service) Start() {
 	if beaconState != nil {
 		log.Info("Blockchain data already exists in DB, initializing...")
 		s.genesisTime = time.Unix(int64(beaconState.GenesisTime()), 0)
+		logutil.SetGenesisTime(s.genesisTime)
 		s.opsService.SetGenesisTime(beaconState.GenesisTime())
 		if err := s.initializeChainInfo(ctx); err != nil {
 			log.Fatalf("Could not set up chain info: %v", err)
@@ -179,7 +193,14 @@ func (s *Service) Start() {
 		s.bestJustifiedCheckpt = stateTrie.CopyCheckpoint(justifiedCheckpoint)
 		s.finalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
 		s.prevFinalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
-		s.resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint)
+		s.resumeForkChoice(ctx, justifiedCheckpoint, finalizedCheckpoint)
+		// Walking back from the finalized block to the weak subjectivity checkpoint may read
+		// many blocks, which must not delay startup.
+		go func() {
+			if err := s.verifyWeakSubjectivityRoot(s.ctx); err != nil {
+				log.Fatalf("Could not verify weak subjectivity checkpoint: %v", err)
+			}
+		}()
 
 		if !featureconfig.Get().NewStateMgmt {
 			if finalizedCheckpoint.Epoch > 1 {
@@ -196,6 +217,8 @@ func (s *Service) Start() {
 				GenesisValidatorsRoot: beaconState.GenesisValidatorRoot(),
 			},
 		})
+		go s.advanceHeadStateRoutine()
+		go s.saveForkChoiceCheckpointRoutine()
 	} else {
 		log.Info("Waiting to reach the validator deposit threshold to start the beacon chain...")
 		if s.chainStartFetcher == nil {
@@ -249,6 +272,8 @@ func (s *Service) processChainStartTime(ctx context.Context, genesisTime time.Ti
 			GenesisValidatorsRoot: initializedState.GenesisValidatorRoot(),
 		},
 	})
+	go s.advanceHeadStateRoutine()
+	go s.saveForkChoiceCheckpointRoutine()
 }
 
 // initializes the state and genesis block of the beacon chain to persistent storage
@@ -262,6 +287,7 @@ func (s *Service) initializeBeaconChain(
 	_, span := trace.StartSpan(context.Background(), "beacon-chain.Service.initializeBeaconChain")
 	defer span.End()
 	s.genesisTime = genesisTime
+	logutil.SetGenesisTime(genesisTime)
 	unixTime := uint64(genesisTime.Unix())
 
 	genesisState, err := state.OptimizedGenesisBeaconState(unixTime, preGenesisState, eth1data)
@@ -294,6 +320,10 @@ func (s *Service) initializeBeaconChain(
 // Stop the blockchain service's main event loop and associated goroutines.
 func (s *Service) Stop() error {
 	defer s.cancel()
+
+	if err := s.saveForkChoiceCheckpoint(s.ctx); err != nil {
+		log.WithError(err).Warn("Could not save fork choice checkpoint")
+	}
 	return nil
 }
 
@@ -469,7 +499,17 @@ func (s *Service) pruneGarbageState(ctx context.Context, slot uint64) error {
 
 // This is called when a client starts from non-genesis slot. This passes last justified and finalized
 // information to fork choice service to initializes fork choice store.
-func (s *Service) resumeForkChoice(justifiedCheckpoint *ethpb.Checkpoint, finalizedCheckpoint *ethpb.Checkpoint) {
+// A fork choice store saved at the same finalized checkpoint is restored from the DB when available.
+func (s *Service) resumeForkChoice(ctx context.Context, justifiedCheckpoint *ethpb.Checkpoint, finalizedCheckpoint *ethpb.Checkpoint) {
+	restored, err := s.restoreForkChoice(ctx, finalizedCheckpoint)
+	if err != nil {
+		log.WithError(err).Warn("Could not restore fork choice checkpoint")
+	}
+	if restored != nil {
+		log.WithField("nodes", len(restored.Nodes())).Info("Restored fork choice from checkpoint")
+		s.forkChoiceStore = restored
+		return
+	}
 	store := protoarray.New(justifiedCheckpoint.Epoch, finalizedCheckpoint.Epoch, bytesutil.ToBytes32(finalizedCheckpoint.Root))
 	s.forkChoiceStore = store
 }
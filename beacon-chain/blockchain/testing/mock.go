@@ -16,6 +16,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/event"
@@ -41,6 +42,7 @@ type ChainService struct {
 	blockNotifier               blockfeed.Notifier
 	opNotifier                  opfeed.Notifier
 	ValidAttestation            bool
+	ForkChoice                  forkchoice.ForkChoicer
 }
 
 // StateNotifier mocks the same method in the chain service.
@@ -235,11 +237,21 @@ func (ms *ChainService) Participation(epoch uint64) *precompute.Balance {
 	return ms.Balance
 }
 
+// ForkChoiceStore mocks the same method in the chain service.
+func (ms *ChainService) ForkChoiceStore() forkchoice.ForkChoicer {
+	return ms.ForkChoice
+}
+
 // IsValidAttestation always returns true.
 func (ms *ChainService) IsValidAttestation(ctx context.Context, att *ethpb.Attestation) bool {
 	return ms.ValidAttestation
 }
 
+// IsValidAttestationBatch always returns true.
+func (ms *ChainService) IsValidAttestationBatch(ctx context.Context, atts []*ethpb.Attestation) bool {
+	return ms.ValidAttestation
+}
+
 // ClearCachedStates does nothing.
 func (ms *ChainService) ClearCachedStates() {}
 
@@ -252,3 +264,11 @@ func (ms *ChainService) HasInitSyncBlock(root [32]byte) bool {
 func (ms *ChainService) HeadGenesisValidatorRoot() [32]byte {
 	return [32]byte{}
 }
+
+// HeadValidatorIndex mocks HeadValidatorIndex method in chain service.
+func (ms *ChainService) HeadValidatorIndex(pubKey [48]byte) (uint64, bool) {
+	if ms.State == nil {
+		return 0, false
+	}
+	return ms.State.ValidatorIndexByPubkey(pubKey)
+}
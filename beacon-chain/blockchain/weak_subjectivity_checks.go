@@ -0,0 +1,77 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"go.opencensus.io/trace"
+)
+
+// verifyWeakSubjectivityRoot verifies the weak subjectivity checkpoint provided at startup
+// is the canonical checkpoint at its epoch, once the node has finalized that epoch. A node
+// syncing a chain which does not contain the checkpoint may be the target of a long range
+// attack, so it must not continue. The verified checkpoint is saved to the DB so it is not
+// verified again after a restart.
+func (s *Service) verifyWeakSubjectivityRoot(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "blockchain.verifyWeakSubjectivityRoot")
+	defer span.End()
+
+	s.wsLock.Lock()
+	defer s.wsLock.Unlock()
+	if s.wsCheckpt == nil || s.wsVerified {
+		return nil
+	}
+	finalized := s.finalizedCheckpt
+	if finalized == nil || finalized.Epoch < s.wsCheckpt.Epoch {
+		return nil
+	}
+
+	verified, err := s.beaconDB.VerifiedWeakSubjectivityCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get verified weak subjectivity checkpoint")
+	}
+	if verified != nil && verified.Epoch == s.wsCheckpt.Epoch && bytes.Equal(verified.Root, s.wsCheckpt.Root) {
+		s.wsVerified = true
+		return nil
+	}
+
+	// The checkpoint root of an epoch is the latest block at or before the epoch's start slot,
+	// which is found by walking back from the finalized block.
+	startSlot := helpers.StartSlot(s.wsCheckpt.Epoch)
+	root := bytesutil.ToBytes32(finalized.Root)
+	for {
+		signed, err := s.beaconDB.Block(ctx, root)
+		if err != nil {
+			return errors.Wrap(err, "could not get block")
+		}
+		if !featureconfig.Get().NoInitSyncBatchSaveBlocks && s.hasInitSyncBlock(root) {
+			signed = s.getInitSyncBlock(root)
+		}
+		if signed == nil || signed.Block == nil {
+			return fmt.Errorf("could not find block %#x while looking up checkpoint at epoch %d", root, s.wsCheckpt.Epoch)
+		}
+		if signed.Block.Slot <= startSlot {
+			break
+		}
+		root = bytesutil.ToBytes32(signed.Block.ParentRoot)
+	}
+	if !bytes.Equal(root[:], s.wsCheckpt.Root) {
+		return fmt.Errorf(
+			"weak subjectivity checkpoint root %#x is not canonical at epoch %d, the canonical checkpoint root is %#x",
+			s.wsCheckpt.Root,
+			s.wsCheckpt.Epoch,
+			root,
+		)
+	}
+	if err := s.beaconDB.SaveVerifiedWeakSubjectivityCheckpoint(ctx, s.wsCheckpt); err != nil {
+		return errors.Wrap(err, "could not save verified weak subjectivity checkpoint")
+	}
+	s.wsVerified = true
+	log.WithField("epoch", s.wsCheckpt.Epoch).Info("Verified weak subjectivity checkpoint")
+	return nil
+}
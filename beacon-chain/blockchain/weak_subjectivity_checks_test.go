@@ -0,0 +1,130 @@
+package blockchain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestService_VerifyWeakSubjectivityRoot(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	params.UseMinimalConfig()
+	defer params.UseMainnetConfig()
+
+	// Blocks at slots 0, 6 and 17, the checkpoint of epoch 1 is the block at slot 6.
+	roots := make([][32]byte, 0)
+	parentRoot := []byte{'G'}
+	for _, slot := range []uint64{0, 6, 17} {
+		blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot}}
+		if err := db.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, root)
+		parentRoot = root[:]
+	}
+
+	tests := []struct {
+		name      string
+		wsCheckpt *ethpb.Checkpoint
+		finalized *ethpb.Checkpoint
+		verified  bool
+		wantErr   string
+	}{
+		{
+			name: "no checkpoint",
+		},
+		{
+			name:      "checkpoint not finalized yet",
+			wsCheckpt: &ethpb.Checkpoint{Epoch: 1, Root: []byte{'a'}},
+			finalized: &ethpb.Checkpoint{Epoch: 0, Root: roots[0][:]},
+		},
+		{
+			name:      "canonical checkpoint",
+			wsCheckpt: &ethpb.Checkpoint{Epoch: 1, Root: roots[1][:]},
+			finalized: &ethpb.Checkpoint{Epoch: 3, Root: roots[2][:]},
+			verified:  true,
+		},
+		{
+			name:      "non canonical checkpoint",
+			wsCheckpt: &ethpb.Checkpoint{Epoch: 1, Root: roots[0][:]},
+			finalized: &ethpb.Checkpoint{Epoch: 3, Root: roots[2][:]},
+			wantErr:   "is not canonical at epoch 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := NewService(ctx, &Config{BeaconDB: db, WsCheckpt: tt.wsCheckpt})
+			if err != nil {
+				t.Fatal(err)
+			}
+			service.finalizedCheckpt = tt.finalized
+			err = service.verifyWeakSubjectivityRoot(ctx)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, received %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if service.wsVerified != tt.verified {
+				t.Errorf("Wanted verified %v, received %v", tt.verified, service.wsVerified)
+			}
+			saved, err := db.VerifiedWeakSubjectivityCheckpoint(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.verified && !proto.Equal(saved, tt.wsCheckpt) {
+				t.Errorf("Expected verified checkpoint to be saved, received %v", saved)
+			}
+		})
+	}
+}
+
+func TestService_VerifyWeakSubjectivityRoot_PersistedCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	// The blocks of the chain are not in the DB, the checkpoint is only verified as it was
+	// verified before a restart.
+	wsCheckpt := &ethpb.Checkpoint{Epoch: 1, Root: bytesutil.PadTo([]byte{'a'}, 32)}
+	if err := db.SaveVerifiedWeakSubjectivityCheckpoint(ctx, wsCheckpt); err != nil {
+		t.Fatal(err)
+	}
+	service, err := NewService(ctx, &Config{BeaconDB: db, WsCheckpt: wsCheckpt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.finalizedCheckpt = &ethpb.Checkpoint{Epoch: 3, Root: bytesutil.PadTo([]byte{'b'}, 32)}
+	if err := service.verifyWeakSubjectivityRoot(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !service.wsVerified {
+		t.Error("Expected persisted checkpoint to be verified")
+	}
+
+	// A different checkpoint is verified again.
+	service, err = NewService(ctx, &Config{BeaconDB: db, WsCheckpt: &ethpb.Checkpoint{Epoch: 2, Root: wsCheckpt.Root}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.finalizedCheckpt = &ethpb.Checkpoint{Epoch: 3, Root: bytesutil.PadTo([]byte{'b'}, 32)}
+	if err := service.verifyWeakSubjectivityRoot(ctx); err == nil {
+		t.Error("Expected checkpoint which was not persisted to be verified against the chain")
+	}
+}
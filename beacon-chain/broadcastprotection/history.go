@@ -0,0 +1,99 @@
+package broadcastprotection
+
+import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+)
+
+// historyEpochs is the number of epochs of blocks and attestations kept for the
+// protected validators. Conflicts with older messages are not detected.
+const historyEpochs = 4096
+
+// vote is the attestation of a validator, identified by the root of its data.
+type vote struct {
+	source uint64
+	target uint64
+	root   [32]byte
+}
+
+// history of the block proposals and attestations of the protected validators.
+type history struct {
+	proposals    map[uint64]map[uint64][32]byte
+	votes        map[uint64][]vote
+	highestEpoch uint64
+}
+
+func newHistory() *history {
+	return &history{
+		proposals: make(map[uint64]map[uint64][32]byte),
+		votes:     make(map[uint64][]vote),
+	}
+}
+
+// conflictingProposal returns the root of the block already proposed by the validator
+// at the slot if it differs from the given root.
+func (h *history) conflictingProposal(proposer uint64, slot uint64, root [32]byte) ([32]byte, bool) {
+	seen, ok := h.proposals[slot][proposer]
+	return seen, ok && seen != root
+}
+
+func (h *history) recordProposal(proposer uint64, slot uint64, root [32]byte) {
+	if _, ok := h.proposals[slot]; !ok {
+		h.proposals[slot] = make(map[uint64][32]byte)
+	}
+	h.proposals[slot][proposer] = root
+	h.advance(helpers.SlotToEpoch(slot))
+}
+
+// conflictingVote returns an attestation of the validator which forms a double vote or
+// a surround vote with the given attestation.
+func (h *history) conflictingVote(validator uint64, v vote) (vote, bool) {
+	for _, seen := range h.votes[validator] {
+		if seen.target == v.target && seen.root != v.root {
+			return seen, true
+		}
+		if seen.source < v.source && seen.target > v.target || v.source < seen.source && v.target > seen.target {
+			return seen, true
+		}
+	}
+	return vote{}, false
+}
+
+func (h *history) recordVote(validator uint64, v vote) {
+	for _, seen := range h.votes[validator] {
+		if seen == v {
+			return
+		}
+	}
+	h.votes[validator] = append(h.votes[validator], v)
+	h.advance(v.target)
+}
+
+// advance prunes the messages older than the history period once a newer epoch is seen.
+func (h *history) advance(epoch uint64) {
+	if epoch <= h.highestEpoch {
+		return
+	}
+	h.highestEpoch = epoch
+	if epoch < historyEpochs {
+		return
+	}
+	oldest := epoch - historyEpochs
+	for slot := range h.proposals {
+		if helpers.SlotToEpoch(slot) < oldest {
+			delete(h.proposals, slot)
+		}
+	}
+	for validator, votes := range h.votes {
+		kept := votes[:0]
+		for _, v := range votes {
+			if v.target >= oldest {
+				kept = append(kept, v)
+			}
+		}
+		if len(kept) == 0 {
+			delete(h.votes, validator)
+			continue
+		}
+		h.votes[validator] = kept
+	}
+}
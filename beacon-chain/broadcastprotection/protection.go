@@ -0,0 +1,217 @@
+package broadcastprotection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// CheckBlock returns ErrSlashable if the block is proposed by a protected validator
+// which already proposed a different block at the same slot. Otherwise the block is
+// recorded as proposed by the validator.
+func (s *Service) CheckBlock(ctx context.Context, blk *ethpb.BeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "broadcastprotection.CheckBlock")
+	defer span.End()
+	root, err := ssz.HashTreeRoot(blk)
+	if err != nil {
+		return errors.Wrap(err, "could not tree hash block")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.resolveKeys()
+	if !s.protected[blk.ProposerIndex] {
+		return nil
+	}
+	if seen, ok := s.history.conflictingProposal(blk.ProposerIndex, blk.Slot, root); ok {
+		refusedBroadcasts.WithLabelValues("block").Inc()
+		log.WithFields(logrus.Fields{
+			"slot":          blk.Slot,
+			"proposerIndex": blk.ProposerIndex,
+			"seenRoot":      fmt.Sprintf("%#x", seen),
+		}).Error("Refusing to broadcast a double proposal of a protected validator")
+		return errors.Wrapf(ErrSlashable, "validator %d already proposed block %#x at slot %d", blk.ProposerIndex, seen, blk.Slot)
+	}
+	s.history.recordProposal(blk.ProposerIndex, blk.Slot, root)
+	return nil
+}
+
+// CheckAttestation returns ErrSlashable if the attestation is signed by a protected
+// validator and forms a double or surround vote with an attestation already seen for
+// the validator. Otherwise the attestation is recorded for its protected validators.
+func (s *Service) CheckAttestation(ctx context.Context, att *ethpb.Attestation) error {
+	ctx, span := trace.StartSpan(ctx, "broadcastprotection.CheckAttestation")
+	defer span.End()
+	indices, v, err := s.attestingVote(ctx, att)
+	if err != nil {
+		return err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.resolveKeys()
+	for _, index := range indices {
+		if !s.protected[index] {
+			continue
+		}
+		if seen, ok := s.history.conflictingVote(index, v); ok {
+			refusedBroadcasts.WithLabelValues("attestation").Inc()
+			log.WithFields(logrus.Fields{
+				"validatorIndex": index,
+				"sourceEpoch":    v.source,
+				"targetEpoch":    v.target,
+				"seenSource":     seen.source,
+				"seenTarget":     seen.target,
+			}).Error("Refusing to broadcast a double or surround vote of a protected validator")
+			return errors.Wrapf(
+				ErrSlashable,
+				"validator %d already attested with source epoch %d and target epoch %d",
+				index,
+				seen.source,
+				seen.target,
+			)
+		}
+	}
+	for _, index := range indices {
+		if s.protected[index] {
+			s.history.recordVote(index, v)
+		}
+	}
+	return nil
+}
+
+// attestingVote returns the indices of the validators attesting with the attestation,
+// along with their vote.
+func (s *Service) attestingVote(ctx context.Context, att *ethpb.Attestation) ([]uint64, vote, error) {
+	if att.Data == nil || att.Data.Source == nil || att.Data.Target == nil {
+		return nil, vote{}, errors.New("attestation has no data")
+	}
+	root, err := ssz.HashTreeRoot(att.Data)
+	if err != nil {
+		return nil, vote{}, errors.Wrap(err, "could not tree hash attestation data")
+	}
+	headState, err := s.headFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, vote{}, errors.Wrap(err, "could not get head state")
+	}
+	if headState == nil {
+		return nil, vote{}, errors.New("head state is not available")
+	}
+	committee, err := helpers.BeaconCommitteeFromState(headState, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		return nil, vote{}, errors.Wrap(err, "could not retrieve committee for attestation")
+	}
+	v := vote{source: att.Data.Source.Epoch, target: att.Data.Target.Epoch, root: root}
+	return attestationutil.AttestingIndices(att.AggregationBits, committee), v, nil
+}
+
+// observeAttestation records an attestation seen by the beacon node for its protected
+// validators, so protected validators are not allowed to broadcast conflicting ones.
+func (s *Service) observeAttestation(ctx context.Context, att *ethpb.Attestation) {
+	indices, v, err := s.attestingVote(ctx, att)
+	if err != nil {
+		log.WithError(err).Debug("Could not record attestation")
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.resolveKeys()
+	for _, index := range indices {
+		if !s.protected[index] {
+			continue
+		}
+		if seen, ok := s.history.conflictingVote(index, v); ok {
+			log.WithFields(logrus.Fields{
+				"validatorIndex": index,
+				"targetEpoch":    v.target,
+				"seenTarget":     seen.target,
+			}).Warn("Observed a conflicting attestation of a protected validator")
+		}
+		s.history.recordVote(index, v)
+	}
+}
+
+// observeBlock records the proposal of a block seen by the beacon node, along with the
+// attestations it contains.
+func (s *Service) observeBlock(ctx context.Context, blk *ethpb.BeaconBlock) {
+	root, err := ssz.HashTreeRoot(blk)
+	if err != nil {
+		log.WithError(err).Debug("Could not record block")
+		return
+	}
+	s.lock.Lock()
+	s.resolveKeys()
+	if s.protected[blk.ProposerIndex] {
+		if _, ok := s.history.conflictingProposal(blk.ProposerIndex, blk.Slot, root); ok {
+			log.WithFields(logrus.Fields{
+				"slot":          blk.Slot,
+				"proposerIndex": blk.ProposerIndex,
+			}).Warn("Observed a conflicting block of a protected validator")
+		} else {
+			s.history.recordProposal(blk.ProposerIndex, blk.Slot, root)
+		}
+	}
+	s.lock.Unlock()
+	if blk.Body == nil {
+		return
+	}
+	for _, att := range blk.Body.Attestations {
+		s.observeAttestation(ctx, att)
+	}
+}
+
+// run records the blocks and attestations received by the beacon node, whether over
+// gossip, initial sync or RPC.
+func (s *Service) run(ctx context.Context) {
+	opChannel := make(chan *feed.Event, 1)
+	opSub := s.opNotifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+	blockChannel := make(chan *feed.Event, 1)
+	blockSub := s.blockNotifier.BlockFeed().Subscribe(blockChannel)
+	defer blockSub.Unsubscribe()
+	for {
+		select {
+		case event := <-opChannel:
+			switch event.Type {
+			case opfeed.UnaggregatedAttReceived:
+				data, ok := event.Data.(*opfeed.UnAggregatedAttReceivedData)
+				if !ok || data.Attestation == nil {
+					continue
+				}
+				s.observeAttestation(ctx, data.Attestation)
+			case opfeed.AggregatedAttReceived:
+				data, ok := event.Data.(*opfeed.AggregatedAttReceivedData)
+				if !ok || data.Attestation == nil || data.Attestation.Aggregate == nil {
+					continue
+				}
+				s.observeAttestation(ctx, data.Attestation.Aggregate)
+			}
+		case event := <-blockChannel:
+			if event.Type != blockfeed.ReceivedBlock {
+				continue
+			}
+			data, ok := event.Data.(*blockfeed.ReceivedBlockData)
+			if !ok || data.SignedBlock == nil || data.SignedBlock.Block == nil {
+				continue
+			}
+			s.observeBlock(ctx, data.SignedBlock.Block)
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-opSub.Err():
+			log.WithError(err).Error("Subscription to operation feed notifier failed")
+			return
+		case err := <-blockSub.Err():
+			log.WithError(err).Error("Subscription to block feed notifier failed")
+			return
+		}
+	}
+}
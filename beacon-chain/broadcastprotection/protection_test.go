@@ -0,0 +1,151 @@
+package broadcastprotection
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func setupService(t *testing.T, validators ...string) *Service {
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	s, err := NewService(context.Background(), &Config{
+		Validators:  validators,
+		HeadFetcher: &mock.ChainService{State: st},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// attestation returns an attestation of the first member of the committee 0 at the slot.
+func attestation(t *testing.T, s *Service, slot uint64, source uint64, target uint64, blockRoot byte) (*ethpb.Attestation, uint64) {
+	headState, err := s.headFetcher.HeadState(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	committee, err := helpers.BeaconCommitteeFromState(headState, slot, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(0, true)
+	return &ethpb.Attestation{
+		AggregationBits: bits,
+		Data: &ethpb.AttestationData{
+			Slot:            slot,
+			BeaconBlockRoot: bytesutil.PadTo([]byte{blockRoot}, 32),
+			Source:          &ethpb.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}, committee[0]
+}
+
+func TestNewService_InvalidValidators(t *testing.T) {
+	if _, err := NewService(context.Background(), &Config{Validators: []string{"abc"}}); err == nil {
+		t.Error("Expected error for invalid validator index")
+	}
+	if _, err := NewService(context.Background(), &Config{Validators: []string{"0x1234"}}); err == nil {
+		t.Error("Expected error for public key of invalid length")
+	}
+}
+
+func TestCheckBlock_DoubleProposal(t *testing.T) {
+	s := setupService(t, "3")
+	ctx := context.Background()
+	blk := &ethpb.BeaconBlock{Slot: 5, ProposerIndex: 3, ParentRoot: bytesutil.PadTo([]byte{'A'}, 32)}
+	if err := s.CheckBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	// Broadcasting the same block again is not slashable.
+	if err := s.CheckBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	conflicting := &ethpb.BeaconBlock{Slot: 5, ProposerIndex: 3, ParentRoot: bytesutil.PadTo([]byte{'B'}, 32)}
+	if err := s.CheckBlock(ctx, conflicting); errors.Cause(err) != ErrSlashable {
+		t.Errorf("Expected slashable error, received %v", err)
+	}
+	// Blocks of validators which are not protected are not checked.
+	if err := s.CheckBlock(ctx, &ethpb.BeaconBlock{Slot: 5, ProposerIndex: 4}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CheckBlock(ctx, &ethpb.BeaconBlock{Slot: 5, ProposerIndex: 4, ParentRoot: bytesutil.PadTo([]byte{'B'}, 32)}); err != nil {
+		t.Errorf("Expected block of unprotected validator to be allowed, received %v", err)
+	}
+}
+
+func TestCheckBlock_ObservedBlock(t *testing.T) {
+	st, keys := testutil.DeterministicGenesisState(t, 64)
+	s, err := NewService(context.Background(), &Config{
+		Validators:  []string{fmt.Sprintf("%#x", keys[7].PublicKey().Marshal())},
+		HeadFetcher: &mock.ChainService{State: st},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	s.observeBlock(ctx, &ethpb.BeaconBlock{Slot: 9, ProposerIndex: 7, ParentRoot: bytesutil.PadTo([]byte{'A'}, 32)})
+	if !s.protected[7] {
+		t.Fatal("Expected public key to be resolved to validator 7")
+	}
+	conflicting := &ethpb.BeaconBlock{Slot: 9, ProposerIndex: 7, ParentRoot: bytesutil.PadTo([]byte{'B'}, 32)}
+	if err := s.CheckBlock(ctx, conflicting); errors.Cause(err) != ErrSlashable {
+		t.Errorf("Expected slashable error for block conflicting with observed block, received %v", err)
+	}
+}
+
+func TestCheckAttestation_DoubleVote(t *testing.T) {
+	s := setupService(t)
+	ctx := context.Background()
+	att1, index := attestation(t, s, 0, 0, 0, 'A')
+	s.protected[index] = true
+	att2, _ := attestation(t, s, 0, 0, 0, 'B')
+
+	s.observeAttestation(ctx, att1)
+	if err := s.CheckAttestation(ctx, att1); err != nil {
+		t.Errorf("Expected attestation already seen to be allowed, received %v", err)
+	}
+	if err := s.CheckAttestation(ctx, att2); errors.Cause(err) != ErrSlashable {
+		t.Errorf("Expected slashable error for double vote, received %v", err)
+	}
+}
+
+func TestCheckAttestation_SurroundVote(t *testing.T) {
+	s := setupService(t)
+	ctx := context.Background()
+	surrounded, index := attestation(t, s, 0, 2, 3, 'A')
+	s.protected[index] = true
+	if err := s.CheckAttestation(ctx, surrounded); err != nil {
+		t.Fatal(err)
+	}
+	surrounding, _ := attestation(t, s, 0, 1, 4, 'A')
+	if err := s.CheckAttestation(ctx, surrounding); errors.Cause(err) != ErrSlashable {
+		t.Errorf("Expected slashable error for surround vote, received %v", err)
+	}
+	next, _ := attestation(t, s, 0, 3, 4, 'A')
+	if err := s.CheckAttestation(ctx, next); err != nil {
+		t.Errorf("Expected attestation to the next target to be allowed, received %v", err)
+	}
+}
+
+func TestHistory_Prunes(t *testing.T) {
+	h := newHistory()
+	h.recordVote(1, vote{source: 0, target: 1})
+	h.recordProposal(1, 1, [32]byte{'A'})
+	h.recordVote(1, vote{source: 1, target: historyEpochs + 2})
+	if len(h.votes[1]) != 1 || h.votes[1][0].target != historyEpochs+2 {
+		t.Errorf("Expected votes older than the history period to be pruned, received %v", h.votes[1])
+	}
+	if len(h.proposals) != 0 {
+		t.Errorf("Expected proposals older than the history period to be pruned, received %v", h.proposals)
+	}
+}
@@ -0,0 +1,129 @@
+// Package broadcastprotection defines a service which keeps track of the blocks and
+// attestations of a configured set of local validators seen by the beacon node, and
+// refuses to broadcast blocks and attestations of those validators which would be
+// slashable given the messages already seen. It is a last line of protection on the
+// network side, for instance against a validator key run by two validator clients.
+package broadcastprotection
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "broadcastprotection")
+
+var refusedBroadcasts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "broadcast_protection_refused_total",
+	Help: "The number of blocks and attestations of protected validators refused for broadcast",
+}, []string{"type"})
+
+// ErrSlashable is returned when broadcasting a block or attestation would get a
+// protected validator slashed.
+var ErrSlashable = errors.New("message conflicts with a message already seen for the validator")
+
+// Service keeping the history of blocks and attestations of the protected validators.
+type Service struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	headFetcher    blockchain.HeadFetcher
+	opNotifier     opfeed.Notifier
+	blockNotifier  blockfeed.Notifier
+	lock           sync.Mutex
+	protected      map[uint64]bool
+	unresolvedKeys [][48]byte
+	history        *history
+}
+
+// Config options for the broadcast protection service.
+type Config struct {
+	// Validators are the protected validators, each either a validator index or a hex
+	// encoded public key.
+	Validators        []string
+	HeadFetcher       blockchain.HeadFetcher
+	OperationNotifier opfeed.Notifier
+	BlockNotifier     blockfeed.Notifier
+}
+
+// NewService initializes the service protecting the configured validators.
+func NewService(ctx context.Context, cfg *Config) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		headFetcher:   cfg.HeadFetcher,
+		opNotifier:    cfg.OperationNotifier,
+		blockNotifier: cfg.BlockNotifier,
+		protected:     make(map[uint64]bool),
+		history:       newHistory(),
+	}
+	for _, v := range cfg.Validators {
+		v = strings.TrimSpace(v)
+		if strings.HasPrefix(v, "0x") {
+			pubKey, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+			if err != nil || len(pubKey) != 48 {
+				cancel()
+				return nil, fmt.Errorf("invalid public key %s, expected 48 hex encoded bytes", v)
+			}
+			var key [48]byte
+			copy(key[:], pubKey)
+			s.unresolvedKeys = append(s.unresolvedKeys, key)
+			continue
+		}
+		index, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			cancel()
+			return nil, errors.Wrapf(err, "invalid validator index %s", v)
+		}
+		s.protected[index] = true
+	}
+	return s, nil
+}
+
+// Start the broadcast protection event loop.
+func (s *Service) Start() {
+	log.WithField("validators", len(s.protected)+len(s.unresolvedKeys)).Info("Protecting validators from broadcasting slashable messages")
+	go s.run(s.ctx)
+}
+
+// Stop the broadcast protection event loop.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the service. Returning nil means service
+// is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+// resolveKeys finds the indices of the protected public keys in the head state. Public
+// keys of validators whose deposits were not processed yet are resolved later. The
+// caller must hold the lock.
+func (s *Service) resolveKeys() {
+	if len(s.unresolvedKeys) == 0 {
+		return
+	}
+	var unresolved [][48]byte
+	for _, key := range s.unresolvedKeys {
+		index, ok := s.headFetcher.HeadValidatorIndex(key)
+		if !ok {
+			unresolved = append(unresolved, key)
+			continue
+		}
+		s.protected[index] = true
+	}
+	s.unresolvedKeys = unresolved
+}
@@ -141,7 +141,7 @@ func (c *AttestationCache) Put(ctx context.Context, req *ethpb.AttestationDataRe
 	if err := c.cache.AddIfNotPresent(data); err != nil {
 		return err
 	}
-	trim(c.cache, maxCacheSize)
+	trim(c.cache, attestationDataCacheName)
 
 	attestationCacheSize.Set(float64(len(c.cache.List())))
 	return nil
@@ -109,7 +109,7 @@ func (c *CheckpointStateCache) AddCheckpointState(cp *CheckpointState) error {
 		return err
 	}
 
-	trim(c.cache, maxCheckpointStateSize)
+	trim(c.cache, checkpointStateCacheName)
 	return nil
 }
 
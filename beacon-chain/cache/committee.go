@@ -112,7 +112,7 @@ func (c *CommitteeCache) AddCommitteeShuffledList(committees *Committees) error
 	if err := c.CommitteeCache.AddIfNotPresent(committees); err != nil {
 		return err
 	}
-	trim(c.CommitteeCache, maxCommitteesCacheSize)
+	trim(c.CommitteeCache, committeeCacheName)
 	return nil
 }
 
@@ -141,7 +141,7 @@ func (c *CommitteeCache) AddProposerIndicesList(seed [32]byte, indices []uint64)
 		}
 	}
 
-	trim(c.CommitteeCache, maxCommitteesCacheSize)
+	trim(c.CommitteeCache, committeeCacheName)
 	return nil
 }
 
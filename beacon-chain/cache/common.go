@@ -11,15 +11,17 @@ var (
 	maxCacheSize = int(4 * params.BeaconConfig().SlotsPerEpoch)
 )
 
-// trim the FIFO queue to the maxSize.
-func trim(queue *cache.FIFO, maxSize int) {
-	for s := len(queue.ListKeys()); s > maxSize; s-- {
+// trim the FIFO queue of the named cache to its configured max size.
+func trim(queue *cache.FIFO, name string) {
+	size := maxSize(name)
+	for s := len(queue.ListKeys()); s > size; s-- {
 		_, err := queue.Pop(popProcessNoopFunc)
 		if err != nil {
 			// popProcessNoopFunc never returns an error, but we handle this anyway to make linter
 			// happy.
 			return
 		}
+		cacheEvictions.WithLabelValues(name).Inc()
 	}
 }
 
@@ -102,7 +102,7 @@ func (c *Eth1DataVoteCache) AddEth1DataVote(eth1DataVote *Eth1DataVote) error {
 		return err
 	}
 
-	trim(c.eth1DataVoteCache, maxEth1DataVoteSize)
+	trim(c.eth1DataVoteCache, eth1DataVoteCacheName)
 	return nil
 }
 
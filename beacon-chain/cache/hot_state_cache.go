@@ -8,8 +8,6 @@ import (
 )
 
 var (
-	// hotStateCacheSize defines the max number of hot state this can cache.
-	hotStateCacheSize = 16
 	// Metrics
 	hotStateCacheHit = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "hot_state_cache_hit",
@@ -26,12 +24,13 @@ type HotStateCache struct {
 	cache *lru.Cache
 }
 
-// NewHotStateCache initializes the map and underlying cache.
-func NewHotStateCache() *HotStateCache {
-	cache, err := lru.New(hotStateCacheSize)
+// NewHotStateCache initializes the map and underlying cache, holding at most size states.
+func NewHotStateCache(size int) *HotStateCache {
+	cache, err := lru.NewWithEvict(size, onEvicted(hotStateCacheName))
 	if err != nil {
 		panic(err)
 	}
+	cacheMaxSize.WithLabelValues(hotStateCacheName).Set(float64(size))
 	return &HotStateCache{
 		cache: cache,
 	}
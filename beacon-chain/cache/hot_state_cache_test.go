@@ -10,7 +10,7 @@ import (
 )
 
 func TestHotStateCache_RoundTrip(t *testing.T) {
-	c := cache.NewHotStateCache()
+	c := cache.NewHotStateCache(16)
 	root := [32]byte{'A'}
 	state := c.Get(root)
 	if state != nil {
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Names of the bounded caches, used as the label of the cache metrics.
+const (
+	attestationDataCacheName = "attestation_data"
+	checkpointStateCacheName = "checkpoint_state"
+	committeeCacheName       = "committee"
+	eth1DataVoteCacheName    = "eth1_data_vote"
+	hotStateCacheName        = "hot_state"
+	skipSlotCacheName        = "skip_slot"
+)
+
+var (
+	cacheSizesLock sync.RWMutex
+	// cacheSizes is the registry of the max number of entries each FIFO cache can contain.
+	cacheSizes = map[string]int{
+		attestationDataCacheName: maxCacheSize,
+		checkpointStateCacheName: maxCheckpointStateSize,
+		committeeCacheName:       maxCommitteesCacheSize,
+		eth1DataVoteCacheName:    maxEth1DataVoteSize,
+	}
+
+	// Metrics.
+	cacheEvictions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_evictions_total",
+		Help: "The number of entries evicted from a cache because it reached its max size.",
+	}, []string{"cache"})
+	cacheMaxSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "cache_max_size",
+		Help: "The configured max number of entries a cache can contain.",
+	}, []string{"cache"})
+)
+
+// Config defines the configurable max sizes of the beacon node caches. A zero value
+// keeps the default size of the cache.
+type Config struct {
+	CommitteeCacheSize       int
+	CheckpointStateCacheSize int
+}
+
+func init() {
+	for name, size := range cacheSizes {
+		cacheMaxSize.WithLabelValues(name).Set(float64(size))
+	}
+}
+
+// Configure sets the max sizes of the caches. Caches larger than their new size are
+// trimmed on their next insertion.
+func Configure(cfg *Config) {
+	setMaxSize(committeeCacheName, cfg.CommitteeCacheSize)
+	setMaxSize(checkpointStateCacheName, cfg.CheckpointStateCacheSize)
+}
+
+func setMaxSize(name string, size int) {
+	if size <= 0 {
+		return
+	}
+	cacheSizesLock.Lock()
+	defer cacheSizesLock.Unlock()
+	cacheSizes[name] = size
+	cacheMaxSize.WithLabelValues(name).Set(float64(size))
+}
+
+func maxSize(name string) int {
+	cacheSizesLock.RLock()
+	defer cacheSizesLock.RUnlock()
+	return cacheSizes[name]
+}
+
+// onEvicted returns an eviction callback for LRU caches which records the eviction
+// in the metrics of the named cache.
+func onEvicted(name string) func(key interface{}, value interface{}) {
+	return func(_ interface{}, _ interface{}) {
+		cacheEvictions.WithLabelValues(name).Inc()
+	}
+}
@@ -0,0 +1,31 @@
+package cache
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+func TestConfigure_CommitteeCacheSize(t *testing.T) {
+	Configure(&Config{CommitteeCacheSize: 3})
+	defer Configure(&Config{CommitteeCacheSize: maxCommitteesCacheSize})
+
+	cache := NewCommitteesCache()
+	for i := 0; i < 5; i++ {
+		item := &Committees{Seed: bytesutil.ToBytes32([]byte(strconv.Itoa(i)))}
+		if err := cache.AddCommitteeShuffledList(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if k := cache.CommitteeCache.ListKeys(); len(k) != 3 {
+		t.Errorf("wanted: %d, got: %d", 3, len(k))
+	}
+}
+
+func TestConfigure_ZeroKeepsDefault(t *testing.T) {
+	Configure(&Config{})
+	if size := maxSize(checkpointStateCacheName); size != maxCheckpointStateSize {
+		t.Errorf("wanted: %d, got: %d", maxCheckpointStateSize, size)
+	}
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	gocache "github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// chainHeadCacheTTL is how long a chain head response is served from the cache. It only needs to
+// cover the burst of identical requests made by validator clients at the start of a slot.
+const chainHeadCacheTTL = time.Second
+
+var (
+	chainHeadCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "chain_head_cache_hit",
+		Help: "The number of chain head requests that are present in the cache.",
+	})
+	chainHeadCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "chain_head_cache_miss",
+		Help: "The number of chain head requests that aren't present in the cache.",
+	})
+	domainDataCacheHit = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "domain_data_cache_hit",
+		Help: "The number of domain data requests that are present in the cache.",
+	})
+	domainDataCacheMiss = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "domain_data_cache_miss",
+		Help: "The number of domain data requests that aren't present in the cache.",
+	})
+)
+
+// ChainHeadCache is used to store the chain head response computed for a head block for a short
+// time, so it is not recomputed for every validator client requesting it.
+type ChainHeadCache struct {
+	cache *gocache.Cache
+}
+
+// NewChainHeadCache initializes the underlying cache.
+func NewChainHeadCache() *ChainHeadCache {
+	return &ChainHeadCache{
+		cache: gocache.New(chainHeadCacheTTL, 2*chainHeadCacheTTL),
+	}
+}
+
+// Get returns the cached chain head response for the head block root, if any.
+func (c *ChainHeadCache) Get(headRoot []byte) *ethpb.ChainHead {
+	item, exists := c.cache.Get(string(headRoot))
+	if exists && item != nil {
+		chainHeadCacheHit.Inc()
+		return item.(*ethpb.ChainHead)
+	}
+	chainHeadCacheMiss.Inc()
+	return nil
+}
+
+// Put the chain head response in the cache, keyed by its head block root.
+func (c *ChainHeadCache) Put(res *ethpb.ChainHead) {
+	c.cache.SetDefault(string(res.HeadBlockRoot), res)
+}
+
+// DomainDataCache is used to store the cached results of a DomainData request. The signature
+// domain of an epoch does not change, so responses are kept for an epoch.
+type DomainDataCache struct {
+	cache *gocache.Cache
+}
+
+// NewDomainDataCache initializes the underlying cache.
+func NewDomainDataCache() *DomainDataCache {
+	epochDuration := time.Duration(params.BeaconConfig().SecondsPerSlot*params.BeaconConfig().SlotsPerEpoch) * time.Second
+	return &DomainDataCache{
+		cache: gocache.New(epochDuration, 2*epochDuration),
+	}
+}
+
+// Get returns the cached response of the domain data request, if any.
+func (c *DomainDataCache) Get(req *ethpb.DomainRequest) *ethpb.DomainResponse {
+	item, exists := c.cache.Get(domainReqToKey(req))
+	if exists && item != nil {
+		domainDataCacheHit.Inc()
+		return item.(*ethpb.DomainResponse)
+	}
+	domainDataCacheMiss.Inc()
+	return nil
+}
+
+// Put the response of the domain data request in the cache.
+func (c *DomainDataCache) Put(req *ethpb.DomainRequest, res *ethpb.DomainResponse) {
+	c.cache.SetDefault(domainReqToKey(req), res)
+}
+
+func domainReqToKey(req *ethpb.DomainRequest) string {
+	return fmt.Sprintf("%d-%#x", req.Epoch, req.Domain)
+}
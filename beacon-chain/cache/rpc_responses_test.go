@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+func TestChainHeadCache_GetPut(t *testing.T) {
+	c := NewChainHeadCache()
+	headRoot := []byte{'a'}
+	if res := c.Get(headRoot); res != nil {
+		t.Fatalf("Wanted no cached response, received %v", res)
+	}
+	res := &ethpb.ChainHead{HeadSlot: 10, HeadBlockRoot: headRoot}
+	c.Put(res)
+	if cached := c.Get(headRoot); !proto.Equal(res, cached) {
+		t.Errorf("Wanted %v, received %v", res, cached)
+	}
+	if cached := c.Get([]byte{'b'}); cached != nil {
+		t.Errorf("Wanted no cached response for another head, received %v", cached)
+	}
+}
+
+func TestDomainDataCache_GetPut(t *testing.T) {
+	c := NewDomainDataCache()
+	req := &ethpb.DomainRequest{Epoch: 1, Domain: []byte{1, 0, 0, 0}}
+	if res := c.Get(req); res != nil {
+		t.Fatalf("Wanted no cached response, received %v", res)
+	}
+	res := &ethpb.DomainResponse{SignatureDomain: []byte{'a'}}
+	c.Put(req, res)
+	if cached := c.Get(&ethpb.DomainRequest{Epoch: 1, Domain: []byte{1, 0, 0, 0}}); !proto.Equal(res, cached) {
+		t.Errorf("Wanted %v, received %v", res, cached)
+	}
+	if cached := c.Get(&ethpb.DomainRequest{Epoch: 2, Domain: []byte{1, 0, 0, 0}}); cached != nil {
+		t.Errorf("Wanted no cached response for another epoch, received %v", cached)
+	}
+}
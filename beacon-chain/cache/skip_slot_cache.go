@@ -25,24 +25,42 @@ var (
 	})
 )
 
+// defaultSkipSlotCacheSize is the number of skip slot states cached when no size is configured.
+const defaultSkipSlotCacheSize = 8
+
 // SkipSlotCache is used to store the cached results of processing skip slots in state.ProcessSlots.
+// Entries are keyed by a root identifying the state the slots were processed from, so that states
+// of different forks at the same slot do not collide.
 type SkipSlotCache struct {
 	cache      *lru.Cache
 	lock       sync.RWMutex
 	disabled   bool // Allow for programmatic toggling of the cache, useful during initial sync.
-	inProgress map[uint64]bool
+	inProgress map[[32]byte]bool
 }
 
 // NewSkipSlotCache initializes the map and underlying cache.
 func NewSkipSlotCache() *SkipSlotCache {
-	cache, err := lru.New(8)
+	cache, err := lru.NewWithEvict(defaultSkipSlotCacheSize, onEvicted(skipSlotCacheName))
 	if err != nil {
 		panic(err)
 	}
+	cacheMaxSize.WithLabelValues(skipSlotCacheName).Set(float64(defaultSkipSlotCacheSize))
 	return &SkipSlotCache{
 		cache:      cache,
-		inProgress: make(map[uint64]bool),
+		inProgress: make(map[[32]byte]bool),
+	}
+}
+
+// Resize changes the number of states the skip slot cache can contain, evicting the
+// least recently used states if the cache shrinks.
+func (c *SkipSlotCache) Resize(size int) {
+	if size <= 0 {
+		return
 	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cache.Resize(size)
+	cacheMaxSize.WithLabelValues(skipSlotCacheName).Set(float64(size))
 }
 
 // Enable the skip slot cache.
@@ -57,7 +75,7 @@ func (c *SkipSlotCache) Disable() {
 
 // Get waits for any in progress calculation to complete before returning a
 // cached response, if any.
-func (c *SkipSlotCache) Get(ctx context.Context, slot uint64) (*stateTrie.BeaconState, error) {
+func (c *SkipSlotCache) Get(ctx context.Context, key [32]byte) (*stateTrie.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "skipSlotCache.Get")
 	defer span.End()
 	if c.disabled {
@@ -77,7 +95,7 @@ func (c *SkipSlotCache) Get(ctx context.Context, slot uint64) (*stateTrie.Beacon
 		}
 
 		c.lock.RLock()
-		if !c.inProgress[slot] {
+		if !c.inProgress[key] {
 			c.lock.RUnlock()
 			break
 		}
@@ -92,7 +110,7 @@ func (c *SkipSlotCache) Get(ctx context.Context, slot uint64) (*stateTrie.Beacon
 	}
 	span.AddAttributes(trace.BoolAttribute("inProgress", inProgress))
 
-	item, exists := c.cache.Get(slot)
+	item, exists := c.cache.Get(key)
 
 	if exists && item != nil {
 		skipSlotCacheHit.Inc()
@@ -106,7 +124,7 @@ func (c *SkipSlotCache) Get(ctx context.Context, slot uint64) (*stateTrie.Beacon
 
 // MarkInProgress a request so that any other similar requests will block on
 // Get until MarkNotInProgress is called.
-func (c *SkipSlotCache) MarkInProgress(slot uint64) error {
+func (c *SkipSlotCache) MarkInProgress(key [32]byte) error {
 	if c.disabled {
 		return nil
 	}
@@ -114,16 +132,16 @@ func (c *SkipSlotCache) MarkInProgress(slot uint64) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if c.inProgress[slot] {
+	if c.inProgress[key] {
 		return ErrAlreadyInProgress
 	}
-	c.inProgress[slot] = true
+	c.inProgress[key] = true
 	return nil
 }
 
 // MarkNotInProgress will release the lock on a given request. This should be
 // called after put.
-func (c *SkipSlotCache) MarkNotInProgress(slot uint64) error {
+func (c *SkipSlotCache) MarkNotInProgress(key [32]byte) error {
 	if c.disabled {
 		return nil
 	}
@@ -131,18 +149,18 @@ func (c *SkipSlotCache) MarkNotInProgress(slot uint64) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	delete(c.inProgress, slot)
+	delete(c.inProgress, key)
 	return nil
 }
 
 // Put the response in the cache.
-func (c *SkipSlotCache) Put(ctx context.Context, slot uint64, state *stateTrie.BeaconState) error {
+func (c *SkipSlotCache) Put(ctx context.Context, key [32]byte, state *stateTrie.BeaconState) error {
 	if c.disabled {
 		return nil
 	}
 
 	// Copy state so cached value is not mutated.
-	c.cache.Add(slot, state.Copy())
+	c.cache.Add(key, state.Copy())
 
 	return nil
 }
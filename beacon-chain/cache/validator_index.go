@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"sync"
+
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// ValidatorIndexCache maps the public keys of validators to their indices in the validator
+// registry. The registry is append only and deposits are processed in the order of the deposit
+// contract, so a validator has the same index in every state containing it, and the cache only
+// needs to be extended with the validators appended since the last update.
+type ValidatorIndexCache struct {
+	indices map[[48]byte]uint64
+	count   uint64
+	lock    sync.RWMutex
+}
+
+// NewValidatorIndexCache creates a new, empty validator index cache.
+func NewValidatorIndexCache() *ValidatorIndexCache {
+	return &ValidatorIndexCache{
+		indices: make(map[[48]byte]uint64),
+	}
+}
+
+// Index returns the index of the validator with the given public key, and whether the
+// validator is in the cache.
+func (c *ValidatorIndexCache) Index(pubKey [48]byte) (uint64, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	idx, ok := c.indices[pubKey]
+	return idx, ok
+}
+
+// Count returns the number of validators in the cache.
+func (c *ValidatorIndexCache) Count() uint64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.count
+}
+
+// UpdateFromState adds the validators of the state which are not in the cache yet.
+func (c *ValidatorIndexCache) UpdateFromState(st *stateTrie.BeaconState) {
+	if st == nil {
+		return
+	}
+	numValidators := uint64(st.NumValidators())
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i := c.count; i < numValidators; i++ {
+		c.indices[st.PubkeyAtIndex(i)] = i
+	}
+	if numValidators > c.count {
+		c.count = numValidators
+	}
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestValidatorIndexCache_UpdateFromState(t *testing.T) {
+	validators := make([]*ethpb.Validator, 4)
+	for i := range validators {
+		pubKey := [48]byte{byte(i + 1)}
+		validators[i] = &ethpb.Validator{PublicKey: pubKey[:]}
+	}
+	st, err := stateTrie.InitializeFromProto(&pb.BeaconState{Validators: validators[:2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewValidatorIndexCache()
+	if _, ok := c.Index([48]byte{1}); ok {
+		t.Error("Expected an empty cache")
+	}
+
+	c.UpdateFromState(st)
+	if c.Count() != 2 {
+		t.Errorf("Wanted 2 validators, received %d", c.Count())
+	}
+	if idx, ok := c.Index([48]byte{2}); !ok || idx != 1 {
+		t.Errorf("Wanted index 1, received %d (found %t)", idx, ok)
+	}
+	if _, ok := c.Index([48]byte{3}); ok {
+		t.Error("Did not expect a validator missing from the state")
+	}
+
+	if err := st.AppendValidator(validators[2]); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.AppendValidator(validators[3]); err != nil {
+		t.Fatal(err)
+	}
+	c.UpdateFromState(st)
+	if idx, ok := c.Index([48]byte{4}); !ok || idx != 3 {
+		t.Errorf("Wanted index 3, received %d (found %t)", idx, ok)
+	}
+
+	// A state with fewer validators, e.g. from an older fork, keeps the known validators.
+	older, err := stateTrie.InitializeFromProto(&pb.BeaconState{Validators: validators[:1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.UpdateFromState(older)
+	if c.Count() != 4 {
+		t.Errorf("Wanted 4 validators, received %d", c.Count())
+	}
+}
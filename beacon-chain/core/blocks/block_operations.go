@@ -857,6 +857,82 @@ func VerifyAttestation(ctx context.Context, beaconState *stateTrie.BeaconState,
 	return VerifyIndexedAttestation(ctx, beaconState, indexedAtt)
 }
 
+// VerifyAttestationsBatch verifies the signatures of attestations sharing the same attestation
+// data in a single batch. Each signature is weighted with a random scalar, so invalid signatures
+// of different attestations cannot cancel each other out. An error is returned if any attestation
+// in the batch is invalid, without identifying which one, so callers should fall back to
+// verifying the attestations individually.
+func VerifyAttestationsBatch(ctx context.Context, beaconState *stateTrie.BeaconState, atts []*ethpb.Attestation) error {
+	ctx, span := trace.StartSpan(ctx, "core.VerifyAttestationsBatch")
+	defer span.End()
+	if len(atts) == 0 {
+		return nil
+	}
+	data := atts[0].Data
+	if data == nil || data.Target == nil {
+		return errors.New("nil or missing attestation data")
+	}
+	committee, err := helpers.BeaconCommitteeFromState(beaconState, data.Slot, data.CommitteeIndex)
+	if err != nil {
+		return err
+	}
+
+	domain, err := helpers.Domain(beaconState.Fork(), data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester, beaconState.GenesisValidatorRoot())
+	if err != nil {
+		return err
+	}
+	messageHash, err := helpers.ComputeSigningRoot(data, domain)
+	if err != nil {
+		return errors.Wrap(err, "could not get signing root of object")
+	}
+
+	pubkeys := make([]*bls.PublicKey, 0, len(atts))
+	sigs := make([]*bls.Signature, 0, len(atts))
+	msgs := make([][32]byte, 0, len(atts))
+	for _, att := range atts {
+		if att == nil || !proto.Equal(att.Data, data) {
+			return errors.New("attestations in batch do not share the same data")
+		}
+		indexedAtt := attestationutil.ConvertToIndexed(ctx, att, committee)
+		if len(indexedAtt.AttestingIndices) == 0 {
+			return errors.New("attestation in batch has no attesting indices")
+		}
+		// Aggregate the keys of the attesters into a new key, as deserialized keys may be cached.
+		var aggPubkey *bls.PublicKey
+		for j, i := range indexedAtt.AttestingIndices {
+			pubkeyAtIdx := beaconState.PubkeyAtIndex(i)
+			pk, err := bls.PublicKeyFromBytes(pubkeyAtIdx[:])
+			if err != nil {
+				return errors.Wrap(err, "could not deserialize validator public key")
+			}
+			if j == 0 {
+				aggPubkey, err = pk.Copy()
+				if err != nil {
+					return errors.Wrap(err, "could not copy validator public key")
+				}
+				continue
+			}
+			aggPubkey = aggPubkey.Aggregate(pk)
+		}
+		sig, err := bls.SignatureFromBytes(att.Signature)
+		if err != nil {
+			return errors.Wrap(err, "could not convert bytes to signature")
+		}
+		pubkeys = append(pubkeys, aggPubkey)
+		sigs = append(sigs, sig)
+		msgs = append(msgs, messageHash)
+	}
+
+	verified, err := bls.VerifyMultipleSignatures(sigs, msgs, pubkeys)
+	if err != nil {
+		return errors.Wrap(err, "could not verify attestation signatures")
+	}
+	if !verified {
+		return helpers.ErrSigFailedToVerify
+	}
+	return nil
+}
+
 // ProcessDeposits is one of the operations performed on each processed
 // beacon block to verify queued validators from the Ethereum 1.0 Deposit Contract
 // into the beacon chain.
@@ -1467,6 +1467,65 @@ func TestVerifyIndexedAttestation_OK(t *testing.T) {
 	}
 }
 
+func TestVerifyAttestationsBatch(t *testing.T) {
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
+
+	var mockRoot [32]byte
+	copy(mockRoot[:], "hello-world")
+	data := &ethpb.AttestationData{
+		BeaconBlockRoot: mockRoot[:],
+		Source:          &ethpb.Checkpoint{Epoch: 0, Root: mockRoot[:]},
+		Target:          &ethpb.Checkpoint{Epoch: 0, Root: mockRoot[:]},
+	}
+	committee, err := helpers.BeaconCommitteeFromState(beaconState, data.Slot, data.CommitteeIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, err := helpers.Domain(beaconState.Fork(), 0, params.BeaconConfig().DomainBeaconAttester, beaconState.GenesisValidatorRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := helpers.ComputeSigningRoot(data, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	atts := make([]*ethpb.Attestation, len(committee))
+	for i, index := range committee {
+		aggBits := bitfield.NewBitlist(uint64(len(committee)))
+		aggBits.SetBitAt(uint64(i), true)
+		atts[i] = &ethpb.Attestation{
+			Data:            data,
+			AggregationBits: aggBits,
+			Signature:       privKeys[index].Sign(root[:]).Marshal(),
+		}
+	}
+	if err := blocks.VerifyAttestationsBatch(context.Background(), beaconState, atts); err != nil {
+		t.Errorf("Could not verify attestation batch: %v", err)
+	}
+
+	// Swapped signatures add up to a valid aggregate, but each attestation is invalid on its own.
+	atts[0].Signature, atts[1].Signature = atts[1].Signature, atts[0].Signature
+	if err := blocks.VerifyAttestationsBatch(context.Background(), beaconState, atts); err == nil {
+		t.Error("Expected batch with swapped signatures to fail verification")
+	}
+	atts[0].Signature, atts[1].Signature = atts[1].Signature, atts[0].Signature
+
+	// A single attestation signed over the wrong message fails the whole batch.
+	atts[0].Signature = privKeys[committee[0]].Sign([]byte("wrong message")).Marshal()
+	if err := blocks.VerifyAttestationsBatch(context.Background(), beaconState, atts); err == nil {
+		t.Error("Expected batch with an invalid signature to fail verification")
+	}
+
+	// Attestations must share the same data.
+	otherData := proto.Clone(data).(*ethpb.AttestationData)
+	otherData.Slot = 1
+	atts[1] = &ethpb.Attestation{Data: otherData, AggregationBits: atts[1].AggregationBits, Signature: atts[1].Signature}
+	if err := blocks.VerifyAttestationsBatch(context.Background(), beaconState, atts); err == nil {
+		t.Error("Expected batch with differing attestation data to fail verification")
+	}
+}
+
 func TestValidateIndexedAttestation_AboveMaxLength(t *testing.T) {
 	indexedAtt1 := &ethpb.IndexedAttestation{
 		AttestingIndices: make([]uint64, params.BeaconConfig().MaxValidatorsPerCommittee+5),
@@ -7,6 +7,7 @@ package epoch
 import (
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -17,6 +18,7 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/attestationutil"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/mputil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
@@ -79,37 +81,32 @@ func AttestingBalance(state *stateTrie.BeaconState, atts []*pb.PendingAttestatio
 func ProcessRegistryUpdates(state *stateTrie.BeaconState) (*stateTrie.BeaconState, error) {
 	currentEpoch := helpers.CurrentEpoch(state)
 	vals := state.Validators()
-	var err error
-	ejectionBal := params.BeaconConfig().EjectionBalance
 	activationEligibilityEpoch := helpers.CurrentEpoch(state) + 1
-	for idx, validator := range vals {
-		// Process the validators for activation eligibility.
-		if helpers.IsEligibleForActivationQueue(validator) {
-			validator.ActivationEligibilityEpoch = activationEligibilityEpoch
-			if err := state.UpdateValidatorAtIndex(uint64(idx), validator); err != nil {
-				return nil, err
-			}
-		}
+	updates, err := scanRegistry(state, vals, currentEpoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not scan validator registry")
+	}
 
-		// Process the validators for ejection.
-		isActive := helpers.IsActiveValidator(validator, currentEpoch)
-		belowEjectionBalance := validator.EffectiveBalance <= ejectionBal
-		if isActive && belowEjectionBalance {
-			state, err = validators.InitiateValidatorExit(state, uint64(idx))
-			if err != nil {
-				return nil, errors.Wrapf(err, "could not initiate exit for validator %d", idx)
-			}
+	// Process the validators for activation eligibility.
+	for _, idx := range updates.eligibleForQueue {
+		validator := vals[idx]
+		validator.ActivationEligibilityEpoch = activationEligibilityEpoch
+		if err := state.UpdateValidatorAtIndex(idx, validator); err != nil {
+			return nil, err
 		}
 	}
 
-	// Queue validators eligible for activation and not yet dequeued for activation.
-	var activationQ []uint64
-	for idx, validator := range vals {
-		if helpers.IsEligibleForActivation(state, validator) {
-			activationQ = append(activationQ, uint64(idx))
+	// Process the validators for ejection. Exits are initiated in index
+	// order as each exit depends on the exit queue left by the previous one.
+	for _, idx := range updates.ejected {
+		state, err = validators.InitiateValidatorExit(state, idx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not initiate exit for validator %d", idx)
 		}
 	}
 
+	// Queue validators eligible for activation and not yet dequeued for activation.
+	activationQ := updates.activationQ
 	sort.Sort(sortableIndices{indices: activationQ, validators: vals})
 
 	// Only activate just enough validators according to the activation churn limit.
@@ -143,6 +140,60 @@ func ProcessRegistryUpdates(state *stateTrie.BeaconState) (*stateTrie.BeaconStat
 	return state, nil
 }
 
+// registryScan holds the validator indices which need a registry update, in
+// ascending index order.
+type registryScan struct {
+	eligibleForQueue []uint64
+	ejected          []uint64
+	activationQ      []uint64
+}
+
+// scanRegistry walks the validator registry in parallel shards and collects the
+// indices eligible for the activation queue, the indices to eject and the
+// indices eligible for activation. None of these checks depend on the
+// updates applied for another validator, so the registry can be scanned
+// before any of them are applied.
+func scanRegistry(state *stateTrie.BeaconState, vals []*ethpb.Validator, currentEpoch uint64) (*registryScan, error) {
+	scan := &registryScan{}
+	if len(vals) == 0 {
+		return scan, nil
+	}
+	ejectionBal := params.BeaconConfig().EjectionBalance
+	results, err := mputil.Scatter(len(vals), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		extent := &registryScan{}
+		for i, validator := range vals[offset : offset+entries] {
+			idx := uint64(offset + i)
+			if helpers.IsEligibleForActivationQueue(validator) {
+				extent.eligibleForQueue = append(extent.eligibleForQueue, idx)
+			}
+			isActive := helpers.IsActiveValidator(validator, currentEpoch)
+			if isActive && validator.EffectiveBalance <= ejectionBal {
+				extent.ejected = append(extent.ejected, idx)
+			}
+			if helpers.IsEligibleForActivation(state, validator) {
+				extent.activationQ = append(extent.activationQ, idx)
+			}
+		}
+		return extent, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Offset < results[j].Offset
+	})
+	for _, result := range results {
+		extent, ok := result.Extent.(*registryScan)
+		if !ok {
+			return nil, errors.New("extent not of expected type")
+		}
+		scan.eligibleForQueue = append(scan.eligibleForQueue, extent.eligibleForQueue...)
+		scan.ejected = append(scan.ejected, extent.ejected...)
+		scan.activationQ = append(scan.activationQ, extent.activationQ...)
+	}
+	return scan, nil
+}
+
 // ProcessSlashings processes the slashed validators during epoch processing,
 //
 //  def process_slashings(state: BeaconState) -> None:
@@ -1,11 +1,14 @@
 package precompute
 
 import (
+	"sync"
+
 	"github.com/pkg/errors"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/mputil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
@@ -26,6 +29,9 @@ func ProcessRewardsAndPenaltiesPrecompute(
 	if len(vp) != numOfVals || len(vp) != state.BalancesLength() {
 		return state, errors.New("precomputed registries not the same length as state registries")
 	}
+	if numOfVals == 0 {
+		return state, nil
+	}
 
 	attsRewards, attsPenalties, err := attestationDeltas(state, pBal, vp)
 	if err != nil {
@@ -35,37 +41,47 @@ func ProcessRewardsAndPenaltiesPrecompute(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get attestation delta")
 	}
-	for i := 0; i < numOfVals; i++ {
-		vp[i].BeforeEpochTransitionBalance, err = state.BalanceAtIndex(uint64(i))
-		if err != nil {
-			return nil, errors.Wrap(err, "could not get validator balance before epoch")
-		}
-
-		if err := helpers.IncreaseBalance(state, uint64(i), attsRewards[i]+proposerRewards[i]); err != nil {
-			return nil, err
-		}
-		if err := helpers.DecreaseBalance(state, uint64(i), attsPenalties[i]); err != nil {
-			return nil, err
-		}
-
-		vp[i].AfterEpochTransitionBalance, err = state.BalanceAtIndex(uint64(i))
-		if err != nil {
-			return nil, errors.Wrap(err, "could not get validator balance after epoch")
+	balances := state.Balances()
+	if _, err := mputil.Scatter(numOfVals, func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		for i := offset; i < offset+entries; i++ {
+			vp[i].BeforeEpochTransitionBalance = balances[i]
+			balances[i] += attsRewards[i] + proposerRewards[i]
+			if attsPenalties[i] > balances[i] {
+				balances[i] = 0
+			} else {
+				balances[i] -= attsPenalties[i]
+			}
+			vp[i].AfterEpochTransitionBalance = balances[i]
 		}
+		return nil, nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not apply attestation and proposer deltas")
+	}
+	if err := state.SetBalances(balances); err != nil {
+		return nil, errors.Wrap(err, "could not set validator balances")
 	}
 
 	return state, nil
 }
 
 // This computes the rewards and penalties differences for individual validators based on the
-// voting records.
+// voting records. The validators are split into shards which are processed in parallel, each
+// shard writing only to its own range of the reward and penalty lists.
 func attestationDeltas(state *stateTrie.BeaconState, pBal *Balance, vp []*Validator) ([]uint64, []uint64, error) {
 	numOfVals := state.NumValidators()
 	rewards := make([]uint64, numOfVals)
 	penalties := make([]uint64, numOfVals)
+	if len(vp) == 0 {
+		return rewards, penalties, nil
+	}
 
-	for i, v := range vp {
-		rewards[i], penalties[i] = attestationDelta(state, pBal, v)
+	if _, err := mputil.Scatter(len(vp), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		for i := offset; i < offset+entries; i++ {
+			rewards[i], penalties[i] = attestationDelta(state, pBal, vp[i])
+		}
+		return nil, nil
+	}); err != nil {
+		return nil, nil, err
 	}
 	return rewards, penalties, nil
 }
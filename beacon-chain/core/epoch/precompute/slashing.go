@@ -1,15 +1,19 @@
 package precompute
 
 import (
-	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"sync"
+
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/mputil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 // ProcessSlashingsPrecompute processes the slashed validators during epoch processing.
-// This is an optimized version by passing in precomputed total epoch balances.
+// This is an optimized version by passing in precomputed total epoch balances. The penalties
+// are computed in parallel shards of the validator registry.
 func ProcessSlashingsPrecompute(state *stateTrie.BeaconState, pBal *Balance) error {
 	currentEpoch := helpers.CurrentEpoch(state)
 	exitLength := params.BeaconConfig().EpochsPerSlashingsVector
@@ -24,18 +28,41 @@ func ProcessSlashingsPrecompute(state *stateTrie.BeaconState, pBal *Balance) err
 	minSlashing := mathutil.Min(totalSlashing*3, pBal.ActiveCurrentEpoch)
 	epochToWithdraw := currentEpoch + exitLength/2
 	increment := params.BeaconConfig().EffectiveBalanceIncrement
-	validatorFunc := func(idx int, val *ethpb.Validator) (bool, error) {
-		correctEpoch := epochToWithdraw == val.WithdrawableEpoch
-		if val.Slashed && correctEpoch {
-			penaltyNumerator := val.EffectiveBalance / increment * minSlashing
+	numOfVals := state.NumValidators()
+	if numOfVals == 0 {
+		return nil
+	}
+	balances := state.Balances()
+	if len(balances) != numOfVals {
+		return errors.New("validator registry and balances are not the same length")
+	}
+	slashed := false
+	if _, err := mputil.Scatter(numOfVals, func(offset int, entries int, mu *sync.RWMutex) (interface{}, error) {
+		for i := offset; i < offset+entries; i++ {
+			val, err := state.ValidatorAtIndexReadOnly(uint64(i))
+			if err != nil {
+				return nil, err
+			}
+			if !val.Slashed() || epochToWithdraw != val.WithdrawableEpoch() {
+				continue
+			}
+			penaltyNumerator := val.EffectiveBalance() / increment * minSlashing
 			penalty := penaltyNumerator / pBal.ActiveCurrentEpoch * increment
-			if err := helpers.DecreaseBalance(state, uint64(idx), penalty); err != nil {
-				return false, err
+			if penalty > balances[i] {
+				balances[i] = 0
+			} else {
+				balances[i] -= penalty
 			}
-			return true, nil
+			mu.Lock()
+			slashed = true
+			mu.Unlock()
 		}
-		return false, nil
+		return nil, nil
+	}); err != nil {
+		return err
 	}
-
-	return state.ApplyToEveryValidator(validatorFunc)
+	if !slashed {
+		return nil
+	}
+	return state.SetBalances(balances)
 }
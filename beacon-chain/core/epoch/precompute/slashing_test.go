@@ -132,3 +132,44 @@ func TestProcessSlashingsPrecompute_SlashedLess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessSlashingsPrecompute_SlashedAcrossShards(t *testing.T) {
+	numVals := 1024
+	vals := make([]*ethpb.Validator, numVals)
+	bals := make([]uint64, numVals)
+	for i := 0; i < numVals; i++ {
+		vals[i] = &ethpb.Validator{
+			ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+		}
+		// Slash every 100th validator so penalties land in several shards.
+		if i%100 == 0 {
+			vals[i].Slashed = true
+			vals[i].WithdrawableEpoch = params.BeaconConfig().EpochsPerSlashingsVector / 2
+		}
+		bals[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	s, err := beaconstate.InitializeFromProto(&pb.BeaconState{
+		Validators: vals,
+		Balances:   bals,
+		Slashings:  []uint64{0, 1e9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pBal := &precompute.Balance{ActiveCurrentEpoch: params.BeaconConfig().MaxEffectiveBalance}
+	if err := precompute.ProcessSlashingsPrecompute(s, pBal); err != nil {
+		t.Fatal(err)
+	}
+
+	// penalty = 32 * 1e9 / 1e9 * min(3 * 1e9, 32 * 1e9) / (32 * 1e9) * 1e9 = 3 * 1e9
+	for i, bal := range s.Balances() {
+		want := params.BeaconConfig().MaxEffectiveBalance
+		if i%100 == 0 {
+			want -= 3 * 1e9
+		}
+		if bal != want {
+			t.Errorf("Wanted balance %d for validator %d, got %d", want, i, bal)
+		}
+	}
+}
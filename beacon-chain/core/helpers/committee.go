@@ -310,7 +310,7 @@ func UpdateCommitteeCache(state *stateTrie.BeaconState, epoch uint64) error {
 			return err
 		}
 		if _, exists, err := committeeCache.CommitteeCache.GetByKey(string(seed[:])); err == nil && exists {
-			return nil
+			continue
 		}
 
 		// Store the sorted indices as well as shuffled indices. In current spec,
@@ -470,6 +470,45 @@ func TestUpdateCommitteeCache_CanUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateCommitteeCache_CachesNextEpochWhenCurrentCached(t *testing.T) {
+	ClearCache()
+	validatorCount := int(params.BeaconConfig().MinGenesisActiveValidatorCount)
+	validators := make([]*ethpb.Validator, validatorCount)
+	for i := 0; i < validatorCount; i++ {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch: params.BeaconConfig().FarFutureEpoch,
+		}
+	}
+	state, err := beaconstate.InitializeFromProto(&pb.BeaconState{
+		Validators:  validators,
+		RandaoMixes: make([][]byte, params.BeaconConfig().EpochsPerHistoricalVector),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Caches epochs 0 and 1, so only epoch 2 is missing when updating from epoch 1.
+	if err := UpdateCommitteeCache(state, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateCommitteeCache(state, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	epoch := uint64(2)
+	seed, err := Seed(state, epoch, params.BeaconConfig().DomainBeaconAttester)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indices, err := committeeCache.Committee(StartSlot(epoch), seed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(indices) != int(params.BeaconConfig().TargetCommitteeSize) {
+		t.Errorf("Did not cache next epoch committee, got %d indices wanted %d", len(indices), params.BeaconConfig().TargetCommitteeSize)
+	}
+}
+
 func BenchmarkComputeCommittee300000_WithPreCache(b *testing.B) {
 	validators := make([]*ethpb.Validator, 300000)
 	for i := 0; i < len(validators); i++ {
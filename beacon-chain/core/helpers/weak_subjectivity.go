@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// ParseWeakSubjectivityInputString parses a weak subjectivity checkpoint of the form
+// block_root:epoch, where the block root is a 32 byte hex string with an optional 0x prefix.
+func ParseWeakSubjectivityInputString(wsCheckpointString string) (*ethpb.Checkpoint, error) {
+	s := strings.Split(wsCheckpointString, ":")
+	if len(s) != 2 {
+		return nil, errors.Errorf("%s did not contain a block root and epoch separated by a colon", wsCheckpointString)
+	}
+	bRoot, err := hex.DecodeString(strings.TrimPrefix(s[0], "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode weak subjectivity block root")
+	}
+	if len(bRoot) != 32 {
+		return nil, errors.Errorf("weak subjectivity block root must be 32 bytes, received %d", len(bRoot))
+	}
+	epoch, err := strconv.ParseUint(s[1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse weak subjectivity epoch")
+	}
+	return &ethpb.Checkpoint{
+		Epoch: epoch,
+		Root:  bRoot,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseWeakSubjectivityInputString(t *testing.T) {
+	root := bytes.Repeat([]byte{'a'}, 32)
+	tests := []struct {
+		name    string
+		input   string
+		epoch   uint64
+		wantErr bool
+	}{
+		{
+			name:  "valid with prefix",
+			input: "0x6161616161616161616161616161616161616161616161616161616161616161:100",
+			epoch: 100,
+		},
+		{
+			name:  "valid without prefix",
+			input: "6161616161616161616161616161616161616161616161616161616161616161:0",
+			epoch: 0,
+		},
+		{
+			name:    "missing epoch",
+			input:   "0x6161616161616161616161616161616161616161616161616161616161616161",
+			wantErr: true,
+		},
+		{
+			name:    "short root",
+			input:   "0x6161:100",
+			wantErr: true,
+		},
+		{
+			name:    "invalid hex",
+			input:   "0xzz61616161616161616161616161616161616161616161616161616161616161:100",
+			wantErr: true,
+		},
+		{
+			name:    "invalid epoch",
+			input:   "0x6161616161616161616161616161616161616161616161616161616161616161:epoch",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkpoint, err := ParseWeakSubjectivityInputString(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, received nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if checkpoint.Epoch != tt.epoch {
+				t.Errorf("Wanted epoch %d, received %d", tt.epoch, checkpoint.Epoch)
+			}
+			if !bytes.Equal(checkpoint.Root, root) {
+				t.Errorf("Wanted root %#x, received %#x", root, checkpoint.Root)
+			}
+		})
+	}
+}
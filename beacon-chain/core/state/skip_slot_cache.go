@@ -2,10 +2,30 @@ package state
 
 import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 )
 
 // SkipSlotCache exists for the unlikely scenario that is a large gap between the head state and
 // the current slot. If the beacon chain were ever to be stalled for several epochs, it may be
 // difficult or impossible to compute the appropriate beacon state for assignments within a
-// reasonable amount of time.
+// reasonable amount of time. It also lets block production resume from a head state which was
+// already advanced through empty slots rather than recomputing them under the proposal deadline.
 var SkipSlotCache = cache.NewSkipSlotCache()
+
+// skipSlotCacheKey identifies the state slots are processed from. Processing empty slots is
+// deterministic, so a state is fully determined by its latest block and its slot. The state
+// root of the latest block header is cleared as it is only filled in by the next processed slot.
+func skipSlotCacheKey(state *stateTrie.BeaconState) ([32]byte, error) {
+	header := state.LatestBlockHeader()
+	if header != nil {
+		header.StateRoot = nil
+	}
+	headerRoot, err := stateutil.BlockHeaderRoot(header)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return hashutil.Hash(append(headerRoot[:], bytesutil.Bytes8(state.Slot())...)), nil
+}
@@ -43,3 +43,38 @@ func TestSkipSlotCache_OK(t *testing.T) {
 		t.Fatal("Skipped slots cache leads to different states")
 	}
 }
+
+func TestSkipSlotCache_ForkedStatesDoNotCollide(t *testing.T) {
+	state.SkipSlotCache.Enable()
+	defer state.SkipSlotCache.Disable()
+	bState, _ := testutil.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+
+	forkedState := bState.Copy()
+	header := forkedState.LatestBlockHeader()
+	header.ParentRoot = []byte{'f', 'o', 'r', 'k'}
+	if err := forkedState.SetLatestBlockHeader(header); err != nil {
+		t.Fatal(err)
+	}
+	expectedState, err := beaconstate.InitializeFromProto(forkedState.CloneInnerState())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Populate the cache with the advanced state of the other fork at the same slot.
+	if _, err := state.ProcessSlots(context.Background(), bState, bState.Slot()+3); err != nil {
+		t.Fatal(err)
+	}
+	forkedState, err = state.ProcessSlots(context.Background(), forkedState, forkedState.Slot()+3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state.SkipSlotCache.Disable()
+	expectedState, err = state.ProcessSlots(context.Background(), expectedState, expectedState.Slot()+3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ssz.DeepEqual(expectedState.CloneInnerState(), forkedState.CloneInnerState()) {
+		t.Fatal("Skipped slots cache returned the state of a different fork")
+	}
+}
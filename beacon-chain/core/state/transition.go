@@ -272,7 +272,11 @@ func ProcessSlots(ctx context.Context, state *stateTrie.BeaconState, slot uint64
 	}
 
 	highestSlot := state.Slot()
-	key := state.Slot()
+	key, err := skipSlotCacheKey(state)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, errors.Wrap(err, "could not compute skip slot cache key")
+	}
 
 	// Restart from cached value, if one exists.
 	cachedState, err := SkipSlotCache.Get(ctx, key)
@@ -280,7 +284,7 @@ func ProcessSlots(ctx context.Context, state *stateTrie.BeaconState, slot uint64
 		return nil, err
 	}
 
-	if cachedState != nil && cachedState.Slot() < slot {
+	if cachedState != nil && cachedState.Slot() <= slot {
 		highestSlot = cachedState.Slot()
 		state = cachedState
 	}
@@ -289,7 +293,7 @@ func ProcessSlots(ctx context.Context, state *stateTrie.BeaconState, slot uint64
 		if err != nil {
 			return nil, err
 		}
-		if cachedState != nil && cachedState.Slot() < slot {
+		if cachedState != nil && cachedState.Slot() <= slot {
 			highestSlot = cachedState.Slot()
 			state = cachedState
 		}
@@ -18,6 +18,13 @@ type NoHeadAccessDatabase = iface.NoHeadAccessDatabase
 // See github.com/prysmaticlabs/prysm/blockchain.HeadFetcher
 type HeadAccessDatabase = iface.HeadAccessDatabase
 
+// Backuper exposes the ability to write a backup of Prysm's eth2 data backend while it is in use.
+type Backuper = iface.Backuper
+
+// Compacter exposes the ability to reclaim the unused space of Prysm's eth2 data backend while
+// it is in use.
+type Compacter = iface.Compacter
+
 // Database defines the necessary methods for Prysm's eth2 backend which may be implemented by any
 // key-value or relational database in practice. This is the full database interface which should
 // not be used often. Prefer a more restrictive interface in this package.
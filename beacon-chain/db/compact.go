@@ -0,0 +1,30 @@
+package db
+
+import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+	"github.com/sirupsen/logrus"
+)
+
+// Compact rewrites the database in the directory path specified to reclaim the space
+// of deleted data, logging the file and bucket sizes before and after compaction.
+func Compact(dirPath string) error {
+	log := logrus.WithField("prefix", "db")
+
+	log.WithField("path", dirPath).Info("Compacting database, this may take a while")
+	report, err := kv.Compact(dirPath)
+	if err != nil {
+		return err
+	}
+	for _, b := range report.Buckets {
+		log.WithFields(logrus.Fields{
+			"bucket": b.Name,
+			"before": b.Before,
+			"after":  b.After,
+		}).Debug("Compacted bucket")
+	}
+	log.WithFields(logrus.Fields{
+		"sizeBefore": report.FileSizeBefore,
+		"sizeAfter":  report.FileSizeAfter,
+	}).Info("Compacted database")
+	return nil
+}
@@ -29,6 +29,8 @@ type ReadOnlyDatabase interface {
 	HasBlock(ctx context.Context, blockRoot [32]byte) bool
 	GenesisBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, error)
 	IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
+	FinalizedBlockRootsInRange(ctx context.Context, startSlot, endSlot uint64) ([][32]byte, error)
+	NextFinalizedBlockSlot(ctx context.Context, slot uint64) (uint64, bool, error)
 	HighestSlotBlocks(ctx context.Context) ([]*ethpb.SignedBeaconBlock, error)
 	HighestSlotBlocksBelow(ctx context.Context, slot uint64) ([]*ethpb.SignedBeaconBlock, error)
 	// State related methods.
@@ -44,9 +46,12 @@ type ReadOnlyDatabase interface {
 	AttesterSlashing(ctx context.Context, slashingRoot [32]byte) (*eth.AttesterSlashing, error)
 	HasProposerSlashing(ctx context.Context, slashingRoot [32]byte) bool
 	HasAttesterSlashing(ctx context.Context, slashingRoot [32]byte) bool
+	ProposerSlashings(ctx context.Context) ([]*eth.ProposerSlashing, error)
+	AttesterSlashings(ctx context.Context) ([]*eth.AttesterSlashing, error)
 	// Block operations.
 	VoluntaryExit(ctx context.Context, exitRoot [32]byte) (*eth.VoluntaryExit, error)
 	HasVoluntaryExit(ctx context.Context, exitRoot [32]byte) bool
+	PendingVoluntaryExits(ctx context.Context) ([]*eth.SignedVoluntaryExit, error)
 	// Checkpoint operations.
 	JustifiedCheckpoint(ctx context.Context) (*eth.Checkpoint, error)
 	FinalizedCheckpoint(ctx context.Context) (*eth.Checkpoint, error)
@@ -59,10 +64,23 @@ type ReadOnlyDatabase interface {
 	HasArchivedPoint(ctx context.Context, index uint64) bool
 	LastArchivedIndexRoot(ctx context.Context) [32]byte
 	LastArchivedIndex(ctx context.Context) (uint64, error)
+	SlotsPerArchivedPoint(ctx context.Context) (uint64, error)
 	// Deposit contract related handlers.
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// Powchain operations.
 	PowchainData(ctx context.Context) (*db.ETH1ChainData, error)
+	// Peer operations.
+	Peers(ctx context.Context) ([]*db.PeerRecord, error)
+	// Fork choice operations.
+	ForkChoiceCheckpoint(ctx context.Context) (*db.ForkChoiceCheckpoint, error)
+	// Weak subjectivity operations.
+	VerifiedWeakSubjectivityCheckpoint(ctx context.Context) (*eth.Checkpoint, error)
+	// Light client operations.
+	LatestLightClientFinalityUpdate(ctx context.Context) (*ethereum_beacon_p2p_v1.LightClientFinalityUpdate, error)
+	LightClientFinalityUpdates(ctx context.Context, startEpoch uint64, endEpoch uint64) ([]*ethereum_beacon_p2p_v1.LightClientFinalityUpdate, error)
+	// State diff operations.
+	StateDiff(ctx context.Context, blockRoot [32]byte) (*ethereum_beacon_p2p_v1.StateDiff, error)
+	HasStateDiff(ctx context.Context, blockRoot [32]byte) bool
 }
 
 // NoHeadAccessDatabase -- See github.com/prysmaticlabs/prysm/beacon-chain/db.NoHeadAccessDatabase
@@ -95,6 +113,8 @@ type NoHeadAccessDatabase interface {
 	// Block operations.
 	SaveVoluntaryExit(ctx context.Context, exit *eth.VoluntaryExit) error
 	DeleteVoluntaryExit(ctx context.Context, exitRoot [32]byte) error
+	SavePendingVoluntaryExit(ctx context.Context, exit *eth.SignedVoluntaryExit) error
+	DeletePendingVoluntaryExit(ctx context.Context, validatorIndex uint64) error
 	// Checkpoint operations.
 	SaveJustifiedCheckpoint(ctx context.Context, checkpoint *eth.Checkpoint) error
 	SaveFinalizedCheckpoint(ctx context.Context, checkpoint *eth.Checkpoint) error
@@ -103,12 +123,25 @@ type NoHeadAccessDatabase interface {
 	SaveArchivedCommitteeInfo(ctx context.Context, epoch uint64, info *ethereum_beacon_p2p_v1.ArchivedCommitteeInfo) error
 	SaveArchivedBalances(ctx context.Context, epoch uint64, balances []uint64) error
 	SaveArchivedValidatorParticipation(ctx context.Context, epoch uint64, part *eth.ValidatorParticipation) error
+	PruneArchivedCommitteeInfo(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error)
+	PruneArchivedBalances(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error)
+	PruneArchivedValidatorParticipation(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error)
 	SaveArchivedPointRoot(ctx context.Context, blockRoot [32]byte, index uint64) error
 	SaveLastArchivedIndex(ctx context.Context, index uint64) error
+	SaveSlotsPerArchivedPoint(ctx context.Context, slots uint64) error
 	// Deposit contract related handlers.
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// Powchain operations.
 	SavePowchainData(ctx context.Context, data *db.ETH1ChainData) error
+	// Peer operations.
+	SavePeers(ctx context.Context, peers []*db.PeerRecord) error
+	// Fork choice operations.
+	SaveForkChoiceCheckpoint(ctx context.Context, checkpoint *db.ForkChoiceCheckpoint) error
+	// Weak subjectivity operations.
+	SaveVerifiedWeakSubjectivityCheckpoint(ctx context.Context, checkpoint *eth.Checkpoint) error
+	// Light client operations.
+	SaveLightClientFinalityUpdate(ctx context.Context, update *ethereum_beacon_p2p_v1.LightClientFinalityUpdate) error
+	SaveStateDiff(ctx context.Context, blockRoot [32]byte, diff *ethereum_beacon_p2p_v1.StateDiff) error
 }
 
 // HeadAccessDatabase -- See github.com/prysmaticlabs/prysm/beacon-chain/db.HeadAccessDatabase
@@ -122,6 +155,16 @@ type HeadAccessDatabase interface {
 	HeadState(ctx context.Context) (*state.BeaconState, error)
 }
 
+// Backuper -- See github.com/prysmaticlabs/prysm/beacon-chain/db.Backuper
+type Backuper interface {
+	Backup(ctx context.Context, outputDir string) (string, error)
+}
+
+// Compacter -- See github.com/prysmaticlabs/prysm/beacon-chain/db.Compacter
+type Compacter interface {
+	Compact(ctx context.Context) (sizeBefore int64, sizeAfter int64, err error)
+}
+
 // Database -- See github.com/prysmaticlabs/prysm/beacon-chain/db.Database
 type Database interface {
 	io.Closer
@@ -131,7 +174,12 @@ type Database interface {
 	ClearDB() error
 
 	// Backup and restore methods
-	Backup(ctx context.Context) error
+	Backuper
+	Compacter
+
+	// Genesis related methods.
+	SaveGenesisData(ctx context.Context, state *state.BeaconState) error
+	LoadGenesis(ctx context.Context, r io.Reader) error
 
 	// HistoricalStatesDeleted verifies historical states exist in DB.
 	HistoricalStatesDeleted(ctx context.Context) error
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/db/kv"
+)
+
+// maxDumpedValueBytes is the number of bytes of each value written by DumpKeys, as
+// values such as states can be many megabytes large.
+const maxDumpedValueBytes = 64
+
+// Inspect writes the size and schema version of the database in the directory path
+// specified to w, followed by a table of the number of keys and bytes used by each bucket.
+func Inspect(dirPath string, w io.Writer) error {
+	report, err := kv.Inspect(dirPath)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "File size: %d bytes\n", report.FileSize)
+	fmt.Fprintf(w, "Schema version: %d\n\n", report.SchemaVersion)
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "BUCKET\tKEYS\tIN USE\tALLOCATED\t")
+	for _, b := range report.Buckets {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t\n", b.Name, b.Keys, b.InUse, b.Allocated)
+	}
+	return tw.Flush()
+}
+
+// DumpKeys writes the keys with the given prefix in a bucket of the database in the
+// directory path specified to w, with the size and the first bytes of their values.
+// At most limit keys are written if limit is positive.
+func DumpKeys(dirPath string, bucket string, prefix []byte, limit int, w io.Writer) error {
+	return kv.DumpKeys(dirPath, bucket, prefix, limit, func(k, v []byte) error {
+		if v == nil {
+			_, err := fmt.Fprintf(w, "%#x: nested bucket\n", k)
+			return err
+		}
+		truncated := v
+		if len(truncated) > maxDumpedValueBytes {
+			truncated = truncated[:maxDumpedValueBytes]
+		}
+		_, err := fmt.Fprintf(w, "%#x: %d bytes %#x\n", k, len(v), truncated)
+		return err
+	})
+}
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"io"
 
 	"github.com/ethereum/go-ethereum/common"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -24,8 +25,23 @@ func (e Exporter) ClearDB() error {
 }
 
 // Backup -- passthrough.
-func (e Exporter) Backup(ctx context.Context) error {
-	return e.db.Backup(ctx)
+func (e Exporter) Backup(ctx context.Context, outputDir string) (string, error) {
+	return e.db.Backup(ctx, outputDir)
+}
+
+// Compact -- passthrough.
+func (e Exporter) Compact(ctx context.Context) (int64, int64, error) {
+	return e.db.Compact(ctx)
+}
+
+// SaveGenesisData -- passthrough.
+func (e Exporter) SaveGenesisData(ctx context.Context, state *state.BeaconState) error {
+	return e.db.SaveGenesisData(ctx, state)
+}
+
+// LoadGenesis -- passthrough.
+func (e Exporter) LoadGenesis(ctx context.Context, r io.Reader) error {
+	return e.db.LoadGenesis(ctx, r)
 }
 
 // AttestationsByDataRoot -- passthrough.
@@ -128,6 +144,16 @@ func (e Exporter) HasAttesterSlashing(ctx context.Context, slashingRoot [32]byte
 	return e.db.HasAttesterSlashing(ctx, slashingRoot)
 }
 
+// ProposerSlashings -- passthrough.
+func (e Exporter) ProposerSlashings(ctx context.Context) ([]*eth.ProposerSlashing, error) {
+	return e.db.ProposerSlashings(ctx)
+}
+
+// AttesterSlashings -- passthrough.
+func (e Exporter) AttesterSlashings(ctx context.Context) ([]*eth.AttesterSlashing, error) {
+	return e.db.AttesterSlashings(ctx)
+}
+
 // DeleteProposerSlashing -- passthrough.
 func (e Exporter) DeleteProposerSlashing(ctx context.Context, slashingRoot [32]byte) error {
 	return e.db.DeleteProposerSlashing(ctx, slashingRoot)
@@ -148,6 +174,21 @@ func (e Exporter) HasVoluntaryExit(ctx context.Context, exitRoot [32]byte) bool
 	return e.db.HasVoluntaryExit(ctx, exitRoot)
 }
 
+// PendingVoluntaryExits -- passthrough.
+func (e Exporter) PendingVoluntaryExits(ctx context.Context) ([]*eth.SignedVoluntaryExit, error) {
+	return e.db.PendingVoluntaryExits(ctx)
+}
+
+// SavePendingVoluntaryExit -- passthrough.
+func (e Exporter) SavePendingVoluntaryExit(ctx context.Context, exit *eth.SignedVoluntaryExit) error {
+	return e.db.SavePendingVoluntaryExit(ctx, exit)
+}
+
+// DeletePendingVoluntaryExit -- passthrough.
+func (e Exporter) DeletePendingVoluntaryExit(ctx context.Context, validatorIndex uint64) error {
+	return e.db.DeletePendingVoluntaryExit(ctx, validatorIndex)
+}
+
 // DeleteVoluntaryExit -- passthrough.
 func (e Exporter) DeleteVoluntaryExit(ctx context.Context, exitRoot [32]byte) error {
 	return e.db.DeleteVoluntaryExit(ctx, exitRoot)
@@ -268,6 +309,21 @@ func (e Exporter) SaveArchivedValidatorParticipation(ctx context.Context, epoch
 	return e.db.SaveArchivedValidatorParticipation(ctx, epoch, part)
 }
 
+// PruneArchivedCommitteeInfo -- passthrough.
+func (e Exporter) PruneArchivedCommitteeInfo(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error) {
+	return e.db.PruneArchivedCommitteeInfo(ctx, beforeEpoch, keepEvery)
+}
+
+// PruneArchivedBalances -- passthrough.
+func (e Exporter) PruneArchivedBalances(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error) {
+	return e.db.PruneArchivedBalances(ctx, beforeEpoch, keepEvery)
+}
+
+// PruneArchivedValidatorParticipation -- passthrough.
+func (e Exporter) PruneArchivedValidatorParticipation(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error) {
+	return e.db.PruneArchivedValidatorParticipation(ctx, beforeEpoch, keepEvery)
+}
+
 // SaveDepositContractAddress -- passthrough.
 func (e Exporter) SaveDepositContractAddress(ctx context.Context, addr common.Address) error {
 	return e.db.SaveDepositContractAddress(ctx, addr)
@@ -298,16 +354,86 @@ func (e Exporter) IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool
 	return e.db.IsFinalizedBlock(ctx, blockRoot)
 }
 
+// FinalizedBlockRootsInRange -- passthrough.
+func (e Exporter) FinalizedBlockRootsInRange(ctx context.Context, startSlot, endSlot uint64) ([][32]byte, error) {
+	return e.db.FinalizedBlockRootsInRange(ctx, startSlot, endSlot)
+}
+
+// NextFinalizedBlockSlot -- passthrough.
+func (e Exporter) NextFinalizedBlockSlot(ctx context.Context, slot uint64) (uint64, bool, error) {
+	return e.db.NextFinalizedBlockSlot(ctx, slot)
+}
+
 // PowchainData -- passthrough
 func (e Exporter) PowchainData(ctx context.Context) (*db.ETH1ChainData, error) {
 	return e.db.PowchainData(ctx)
 }
 
+// Peers -- passthrough
+func (e Exporter) Peers(ctx context.Context) ([]*db.PeerRecord, error) {
+	return e.db.Peers(ctx)
+}
+
+// SavePeers -- passthrough
+func (e Exporter) SavePeers(ctx context.Context, peers []*db.PeerRecord) error {
+	return e.db.SavePeers(ctx, peers)
+}
+
 // SavePowchainData -- passthrough
 func (e Exporter) SavePowchainData(ctx context.Context, data *db.ETH1ChainData) error {
 	return e.db.SavePowchainData(ctx, data)
 }
 
+// ForkChoiceCheckpoint -- passthrough
+func (e Exporter) ForkChoiceCheckpoint(ctx context.Context) (*db.ForkChoiceCheckpoint, error) {
+	return e.db.ForkChoiceCheckpoint(ctx)
+}
+
+// SaveForkChoiceCheckpoint -- passthrough
+func (e Exporter) SaveForkChoiceCheckpoint(ctx context.Context, checkpoint *db.ForkChoiceCheckpoint) error {
+	return e.db.SaveForkChoiceCheckpoint(ctx, checkpoint)
+}
+
+// VerifiedWeakSubjectivityCheckpoint -- passthrough
+func (e Exporter) VerifiedWeakSubjectivityCheckpoint(ctx context.Context) (*eth.Checkpoint, error) {
+	return e.db.VerifiedWeakSubjectivityCheckpoint(ctx)
+}
+
+// SaveVerifiedWeakSubjectivityCheckpoint -- passthrough
+func (e Exporter) SaveVerifiedWeakSubjectivityCheckpoint(ctx context.Context, checkpoint *eth.Checkpoint) error {
+	return e.db.SaveVerifiedWeakSubjectivityCheckpoint(ctx, checkpoint)
+}
+
+// LatestLightClientFinalityUpdate -- passthrough
+func (e Exporter) LatestLightClientFinalityUpdate(ctx context.Context) (*pb.LightClientFinalityUpdate, error) {
+	return e.db.LatestLightClientFinalityUpdate(ctx)
+}
+
+// LightClientFinalityUpdates -- passthrough
+func (e Exporter) LightClientFinalityUpdates(ctx context.Context, startEpoch uint64, endEpoch uint64) ([]*pb.LightClientFinalityUpdate, error) {
+	return e.db.LightClientFinalityUpdates(ctx, startEpoch, endEpoch)
+}
+
+// SaveLightClientFinalityUpdate -- passthrough
+func (e Exporter) SaveLightClientFinalityUpdate(ctx context.Context, update *pb.LightClientFinalityUpdate) error {
+	return e.db.SaveLightClientFinalityUpdate(ctx, update)
+}
+
+// StateDiff -- passthrough
+func (e Exporter) StateDiff(ctx context.Context, blockRoot [32]byte) (*pb.StateDiff, error) {
+	return e.db.StateDiff(ctx, blockRoot)
+}
+
+// HasStateDiff -- passthrough
+func (e Exporter) HasStateDiff(ctx context.Context, blockRoot [32]byte) bool {
+	return e.db.HasStateDiff(ctx, blockRoot)
+}
+
+// SaveStateDiff -- passthrough
+func (e Exporter) SaveStateDiff(ctx context.Context, blockRoot [32]byte, diff *pb.StateDiff) error {
+	return e.db.SaveStateDiff(ctx, blockRoot, diff)
+}
+
 // SaveArchivedPointRoot -- passthrough
 func (e Exporter) SaveArchivedPointRoot(ctx context.Context, blockRoot [32]byte, index uint64) error {
 	return e.db.SaveArchivedPointRoot(ctx, blockRoot, index)
@@ -358,6 +484,16 @@ func (e Exporter) LastArchivedIndex(ctx context.Context) (uint64, error) {
 	return e.db.LastArchivedIndex(ctx)
 }
 
+// SlotsPerArchivedPoint -- passthrough
+func (e Exporter) SlotsPerArchivedPoint(ctx context.Context) (uint64, error) {
+	return e.db.SlotsPerArchivedPoint(ctx)
+}
+
+// SaveSlotsPerArchivedPoint -- passthrough
+func (e Exporter) SaveSlotsPerArchivedPoint(ctx context.Context, slots uint64) error {
+	return e.db.SaveSlotsPerArchivedPoint(ctx, slots)
+}
+
 // HistoricalStatesDeleted -- passthrough
 func (e Exporter) HistoricalStatesDeleted(ctx context.Context) error {
 	return e.db.HistoricalStatesDeleted(ctx)
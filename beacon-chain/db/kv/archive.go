@@ -7,7 +7,6 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -18,7 +17,7 @@ func (k *Store) ArchivedActiveValidatorChanges(ctx context.Context, epoch uint64
 
 	buf := bytesutil.Uint64ToBytes(epoch)
 	var target *pb.ArchivedActiveSetChanges
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(archivedValidatorSetChangesBucket)
 		enc := bkt.Get(buf)
 		if enc == nil {
@@ -39,7 +38,7 @@ func (k *Store) SaveArchivedActiveValidatorChanges(ctx context.Context, epoch ui
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedValidatorSetChangesBucket)
 		return bucket.Put(buf, enc)
 	})
@@ -52,7 +51,7 @@ func (k *Store) ArchivedCommitteeInfo(ctx context.Context, epoch uint64) (*pb.Ar
 
 	buf := bytesutil.Uint64ToBytes(epoch)
 	var target *pb.ArchivedCommitteeInfo
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(archivedCommitteeInfoBucket)
 		enc := bkt.Get(buf)
 		if enc == nil {
@@ -73,7 +72,7 @@ func (k *Store) SaveArchivedCommitteeInfo(ctx context.Context, epoch uint64, inf
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedCommitteeInfoBucket)
 		return bucket.Put(buf, enc)
 	})
@@ -86,7 +85,7 @@ func (k *Store) ArchivedBalances(ctx context.Context, epoch uint64) ([]uint64, e
 
 	buf := bytesutil.Uint64ToBytes(epoch)
 	var target []uint64
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(archivedBalancesBucket)
 		enc := bkt.Get(buf)
 		if enc == nil {
@@ -104,7 +103,7 @@ func (k *Store) SaveArchivedBalances(ctx context.Context, epoch uint64, balances
 	defer span.End()
 	buf := bytesutil.Uint64ToBytes(epoch)
 	enc := marshalBalances(balances)
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedBalancesBucket)
 		return bucket.Put(buf, enc)
 	})
@@ -117,7 +116,7 @@ func (k *Store) ArchivedValidatorParticipation(ctx context.Context, epoch uint64
 
 	buf := bytesutil.Uint64ToBytes(epoch)
 	var target *ethpb.ValidatorParticipation
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(archivedValidatorParticipationBucket)
 		enc := bkt.Get(buf)
 		if enc == nil {
@@ -138,12 +137,70 @@ func (k *Store) SaveArchivedValidatorParticipation(ctx context.Context, epoch ui
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedValidatorParticipationBucket)
 		return bucket.Put(buf, enc)
 	})
 }
 
+// PruneArchivedCommitteeInfo deletes the archived committee info of the epochs before the given
+// epoch, except for the epochs multiple of keepEvery if it is not 0. It returns the number of
+// deleted epochs.
+func (k *Store) PruneArchivedCommitteeInfo(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneArchivedCommitteeInfo")
+	defer span.End()
+	return k.pruneArchivedEpochs(archivedCommitteeInfoBucket, beforeEpoch, keepEvery)
+}
+
+// PruneArchivedBalances deletes the archived balances of the epochs before the given epoch,
+// except for the epochs multiple of keepEvery if it is not 0. It returns the number of deleted
+// epochs.
+func (k *Store) PruneArchivedBalances(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneArchivedBalances")
+	defer span.End()
+	return k.pruneArchivedEpochs(archivedBalancesBucket, beforeEpoch, keepEvery)
+}
+
+// PruneArchivedValidatorParticipation deletes the archived validator participation of the epochs
+// before the given epoch, except for the epochs multiple of keepEvery if it is not 0. It returns
+// the number of deleted epochs.
+func (k *Store) PruneArchivedValidatorParticipation(ctx context.Context, beforeEpoch uint64, keepEvery uint64) (int, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PruneArchivedValidatorParticipation")
+	defer span.End()
+	return k.pruneArchivedEpochs(archivedValidatorParticipationBucket, beforeEpoch, keepEvery)
+}
+
+// pruneArchivedEpochs deletes the entries of an archive bucket keyed by epoch. Epochs are little
+// endian encoded, so the whole bucket is scanned.
+func (k *Store) pruneArchivedEpochs(bucketName []byte, beforeEpoch uint64, keepEvery uint64) (int, error) {
+	var pruned int
+	err := k.db.Update(func(tx kvTx) error {
+		bucket := tx.Bucket(bucketName)
+		var keys [][]byte
+		if err := bucket.ForEach(func(key, _ []byte) error {
+			if len(key) != 8 {
+				return nil
+			}
+			epoch := binary.LittleEndian.Uint64(key)
+			if epoch >= beforeEpoch || (keepEvery != 0 && epoch%keepEvery == 0) {
+				return nil
+			}
+			keys = append(keys, append([]byte{}, key...))
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		pruned = len(keys)
+		return nil
+	})
+	return pruned, err
+}
+
 func marshalBalances(bals []uint64) []byte {
 	res := make([]byte, len(bals)*8)
 	offset := 0
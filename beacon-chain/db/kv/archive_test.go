@@ -165,3 +165,31 @@ func TestStore_ArchivedValidatorParticipation(t *testing.T) {
 		t.Errorf("Wanted %v, received %v", part, retrieved)
 	}
 }
+
+func TestStore_PruneArchivedBalances(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	for epoch := uint64(0); epoch < 12; epoch++ {
+		if err := db.SaveArchivedBalances(ctx, epoch, []uint64{epoch}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pruned, err := db.PruneArchivedBalances(ctx, 8 /* beforeEpoch */, 4 /* keepEvery */)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruned != 6 {
+		t.Errorf("Expected 6 pruned epochs, received %d", pruned)
+	}
+	for epoch := uint64(0); epoch < 12; epoch++ {
+		retrieved, err := db.ArchivedBalances(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kept := epoch >= 8 || epoch%4 == 0
+		if kept != (retrieved != nil) {
+			t.Errorf("Expected epoch %d kept: %v, received %v", epoch, kept, retrieved)
+		}
+	}
+}
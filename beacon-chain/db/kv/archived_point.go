@@ -3,9 +3,9 @@ package kv
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
 
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -14,7 +14,7 @@ func (k *Store) SaveArchivedPointRoot(ctx context.Context, blockRoot [32]byte, i
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveArchivedPointRoot")
 	defer span.End()
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedIndexRootBucket)
 		return bucket.Put(bytesutil.Uint64ToBytes(index), blockRoot[:])
 	})
@@ -24,7 +24,7 @@ func (k *Store) SaveArchivedPointRoot(ctx context.Context, blockRoot [32]byte, i
 func (k *Store) SaveLastArchivedIndex(ctx context.Context, index uint64) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveHeadBlockRoot")
 	defer span.End()
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedIndexRootBucket)
 		return bucket.Put(lastArchivedIndexKey, bytesutil.Uint64ToBytes(index))
 	})
@@ -35,7 +35,7 @@ func (k *Store) LastArchivedIndex(ctx context.Context) (uint64, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.LastArchivedIndex")
 	defer span.End()
 	var index uint64
-	err := k.db.Update(func(tx *bolt.Tx) error {
+	err := k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedIndexRootBucket)
 		b := bucket.Get(lastArchivedIndexKey)
 		if b == nil {
@@ -54,7 +54,7 @@ func (k *Store) LastArchivedIndexRoot(ctx context.Context) [32]byte {
 	defer span.End()
 
 	var blockRoot []byte
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedIndexRootBucket)
 		lastArchivedIndex := bucket.Get(lastArchivedIndexKey)
 		if lastArchivedIndex == nil {
@@ -76,7 +76,7 @@ func (k *Store) ArchivedPointRoot(ctx context.Context, index uint64) [32]byte {
 	defer span.End()
 
 	var blockRoot []byte
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bucket := tx.Bucket(archivedIndexRootBucket)
 		blockRoot = bucket.Get(bytesutil.Uint64ToBytes(index))
 		return nil
@@ -92,7 +92,7 @@ func (k *Store) HasArchivedPoint(ctx context.Context, index uint64) bool {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasArchivedPoint")
 	defer span.End()
 	var exists bool
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		iBucket := tx.Bucket(archivedIndexRootBucket)
 		exists = iBucket.Get(bytesutil.Uint64ToBytes(index)) != nil
 		return nil
@@ -101,3 +101,35 @@ func (k *Store) HasArchivedPoint(ctx context.Context, index uint64) bool {
 	}
 	return exists
 }
+
+// SlotsPerArchivedPoint returns the archived point frequency the database is written with,
+// or 0 if none has been saved.
+func (k *Store) SlotsPerArchivedPoint(ctx context.Context) (uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SlotsPerArchivedPoint")
+	defer span.End()
+	var slots uint64
+	err := k.db.View(func(tx kvTx) error {
+		b := tx.Bucket(chainMetadataBucket).Get(slotsPerArchivedPointKey)
+		if b == nil {
+			return nil
+		}
+		slots = binary.LittleEndian.Uint64(b)
+		return nil
+	})
+	return slots, err
+}
+
+// SaveSlotsPerArchivedPoint saves the archived point frequency the database is written with.
+// Archived points are indexed by slot over this frequency, so it returns an error if a
+// different frequency has been previously saved.
+func (k *Store) SaveSlotsPerArchivedPoint(ctx context.Context, slots uint64) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveSlotsPerArchivedPoint")
+	defer span.End()
+	return k.db.Update(func(tx kvTx) error {
+		bucket := tx.Bucket(chainMetadataBucket)
+		if b := bucket.Get(slotsPerArchivedPointKey); b != nil && binary.LittleEndian.Uint64(b) != slots {
+			return fmt.Errorf("cannot override slots per archived point %d", binary.LittleEndian.Uint64(b))
+		}
+		return bucket.Put(slotsPerArchivedPointKey, bytesutil.Uint64ToBytes(slots))
+	})
+}
@@ -68,3 +68,33 @@ func TestLastArchivedPoint_CanRetrieve(t *testing.T) {
 		t.Error("Did not get correct index")
 	}
 }
+
+func TestSlotsPerArchivedPoint_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	slots, err := db.SlotsPerArchivedPoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots != 0 {
+		t.Errorf("Expected no saved slots per archived point, received %d", slots)
+	}
+
+	if err := db.SaveSlotsPerArchivedPoint(ctx, 64); err != nil {
+		t.Fatal(err)
+	}
+	slots, err = db.SlotsPerArchivedPoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots != 64 {
+		t.Errorf("Expected 64 slots per archived point, received %d", slots)
+	}
+	if err := db.SaveSlotsPerArchivedPoint(ctx, 64); err != nil {
+		t.Errorf("Expected saving the same value again to succeed: %v", err)
+	}
+	if err := db.SaveSlotsPerArchivedPoint(ctx, 128); err == nil {
+		t.Error("Expected overriding the saved value to fail")
+	}
+}
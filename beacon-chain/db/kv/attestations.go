@@ -12,7 +12,6 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -21,7 +20,7 @@ func (k *Store) AttestationsByDataRoot(ctx context.Context, attDataRoot [32]byte
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.Attestation")
 	defer span.End()
 	var atts []*ethpb.Attestation
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(attestationsBucket)
 		enc := bkt.Get(attDataRoot[:])
 		if enc == nil {
@@ -45,7 +44,7 @@ func (k *Store) Attestations(ctx context.Context, f *filters.QueryFilter) ([]*et
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.Attestations")
 	defer span.End()
 	atts := make([]*ethpb.Attestation, 0)
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(attestationsBucket)
 
 		// If no filter criteria are specified, return an error.
@@ -83,7 +82,7 @@ func (k *Store) HasAttestation(ctx context.Context, attDataRoot [32]byte) bool {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasAttestation")
 	defer span.End()
 	exists := false
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(attestationsBucket)
 		exists = bkt.Get(attDataRoot[:]) != nil
 		return nil
@@ -97,7 +96,7 @@ func (k *Store) HasAttestation(ctx context.Context, attDataRoot [32]byte) bool {
 func (k *Store) DeleteAttestation(ctx context.Context, attDataRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteAttestation")
 	defer span.End()
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bkt := tx.Bucket(attestationsBucket)
 		enc := bkt.Get(attDataRoot[:])
 		if enc == nil {
@@ -120,7 +119,7 @@ func (k *Store) DeleteAttestations(ctx context.Context, attDataRoots [][32]byte)
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteAttestations")
 	defer span.End()
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bkt := tx.Bucket(attestationsBucket)
 		for _, attDataRoot := range attDataRoots {
 			enc := bkt.Get(attDataRoot[:])
@@ -153,7 +152,7 @@ func (k *Store) SaveAttestation(ctx context.Context, att *ethpb.Attestation) err
 		return err
 	}
 
-	err := k.db.Update(func(tx *bolt.Tx) error {
+	err := k.db.Update(func(tx kvTx) error {
 		attDataRoot, err := ssz.HashTreeRoot(att.Data)
 		if err != nil {
 			return err
@@ -194,7 +193,7 @@ func (k *Store) SaveAttestations(ctx context.Context, atts []*ethpb.Attestation)
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveAttestations")
 	defer span.End()
 
-	err := k.db.Update(func(tx *bolt.Tx) error {
+	err := k.db.Update(func(tx kvTx) error {
 		for _, att := range atts {
 			attDataRoot, err := ssz.HashTreeRoot(att.Data)
 			if err != nil {
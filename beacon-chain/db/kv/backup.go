@@ -8,55 +8,40 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
 const backupsDirectoryName = "backups"
 
-// Backup the database to the datadir backup directory.
+// Backup the database to the output directory, or to the datadir backup directory if
+// the output directory is empty, and return the path of the backup written. The backup
+// is copied from a consistent view of the database while the node keeps writing to it.
+// A BoltDB backup is a single file and a Pebble backup a database directory.
 // Example for backup at slot 345: $DATADIR/backups/prysm_beacondb_at_slot_0000345.backup
-func (k *Store) Backup(ctx context.Context) error {
+func (k *Store) Backup(ctx context.Context, outputDir string) (string, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.Backup")
 	defer span.End()
 
-	backupsDir := path.Join(k.databasePath, backupsDirectoryName)
+	backupsDir := outputDir
+	if backupsDir == "" {
+		backupsDir = path.Join(k.databasePath, backupsDirectoryName)
+	}
 	head, err := k.HeadBlock(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if head == nil {
-		return errors.New("no head block")
+		return "", errors.New("no head block")
 	}
 	// Ensure the backups directory exists.
 	if err := os.MkdirAll(backupsDir, os.ModePerm); err != nil {
-		return err
+		return "", err
 	}
 	backupPath := path.Join(backupsDir, fmt.Sprintf("prysm_beacondb_at_slot_%07d.backup", head.Block.Slot))
 	logrus.WithField("prefix", "db").WithField("backup", backupPath).Info("Writing backup database.")
 
-	copyDB, err := bolt.Open(backupPath, 0666, nil)
-	if err != nil {
-		panic(err)
+	if err := k.db.Backup(backupPath); err != nil {
+		return "", errors.Wrap(err, "could not write backup")
 	}
-	defer func() {
-		if err := copyDB.Close(); err != nil {
-			logrus.WithError(err).Error("Failed to close destination database")
-		}
-	}()
-
-	return k.db.View(func(tx *bolt.Tx) error {
-		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
-			logrus.Debugf("Copying bucket %s\n", name)
-			return copyDB.Update(func(tx2 *bolt.Tx) error {
-				b2, err := tx2.CreateBucketIfNotExists(name)
-				if err != nil {
-					return err
-				}
-				return b.ForEach(func(k []byte, v []byte) error {
-					return b2.Put(k, v)
-				})
-			})
-		})
-	})
+	return backupPath, nil
 }
@@ -3,12 +3,14 @@ package kv
 import (
 	"context"
 	"io/ioutil"
+	"os"
 	"path"
 	"testing"
 
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
+	bolt "go.etcd.io/bbolt"
 )
 
 func TestStore_Backup(t *testing.T) {
@@ -33,7 +35,7 @@ func TestStore_Backup(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := db.Backup(ctx); err != nil {
+	if _, err := db.Backup(ctx, ""); err != nil {
 		t.Fatal(err)
 	}
 
@@ -45,3 +47,56 @@ func TestStore_Backup(t *testing.T) {
 		t.Fatal("No backups created.")
 	}
 }
+
+func TestStore_Backup_OutputDirectory(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	head := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 5000}}
+	if err := db.SaveBlock(ctx, head); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ssz.HashTreeRoot(head.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), root); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveHeadBlockRoot(ctx, root); err != nil {
+		t.Fatal(err)
+	}
+
+	outputDir := path.Join(testutil.TempDir(), "backup_output")
+	defer func() {
+		if err := os.RemoveAll(outputDir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	backupPath, err := db.Backup(ctx, outputDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path.Dir(backupPath) != outputDir {
+		t.Errorf("Expected backup in %s, received %s", outputDir, backupPath)
+	}
+
+	backupDB, err := bolt.Open(backupPath, 0600, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := backupDB.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := backupDB.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(blocksBucket).Get(root[:]) == nil {
+			t.Error("Expected head block in backup")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -18,7 +18,6 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	log "github.com/sirupsen/logrus"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -31,7 +30,7 @@ func (k *Store) Block(ctx context.Context, blockRoot [32]byte) (*ethpb.SignedBea
 		return v.(*ethpb.SignedBeaconBlock), nil
 	}
 	var block *ethpb.SignedBeaconBlock
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		enc := bkt.Get(blockRoot[:])
 		if enc == nil {
@@ -48,7 +47,7 @@ func (k *Store) HeadBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, error)
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HeadBlock")
 	defer span.End()
 	var headBlock *ethpb.SignedBeaconBlock
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		headRoot := bkt.Get(headBlockRootKey)
 		if headRoot == nil {
@@ -69,7 +68,7 @@ func (k *Store) Blocks(ctx context.Context, f *filters.QueryFilter) ([]*ethpb.Si
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.Blocks")
 	defer span.End()
 	blocks := make([]*ethpb.SignedBeaconBlock, 0)
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 
 		keys, err := getBlockRootsByFilter(ctx, tx, f)
@@ -95,7 +94,7 @@ func (k *Store) BlockRoots(ctx context.Context, f *filters.QueryFilter) ([][32]b
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.BlockRoots")
 	defer span.End()
 	blockRoots := make([][32]byte, 0)
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		keys, err := getBlockRootsByFilter(ctx, tx, f)
 		if err != nil {
 			return err
@@ -120,7 +119,7 @@ func (k *Store) HasBlock(ctx context.Context, blockRoot [32]byte) bool {
 		return true
 	}
 	exists := false
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		exists = bkt.Get(blockRoot[:]) != nil
 		return nil
@@ -134,7 +133,7 @@ func (k *Store) HasBlock(ctx context.Context, blockRoot [32]byte) bool {
 func (k *Store) DeleteBlock(ctx context.Context, blockRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteBlock")
 	defer span.End()
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		enc := bkt.Get(blockRoot[:])
 		if enc == nil {
@@ -161,7 +160,7 @@ func (k *Store) DeleteBlocks(ctx context.Context, blockRoots [][32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteBlocks")
 	defer span.End()
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		for _, blockRoot := range blockRoots {
 			enc := bkt.Get(blockRoot[:])
@@ -199,7 +198,7 @@ func (k *Store) SaveBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 	if v, ok := k.blockCache.Get(string(blockRoot[:])); v != nil && ok {
 		return nil
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		if err := k.setBlockSlotBitField(ctx, tx, signed.Block.Slot); err != nil {
 			return err
 		}
@@ -226,7 +225,7 @@ func (k *Store) SaveBlocks(ctx context.Context, blocks []*ethpb.SignedBeaconBloc
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveBlocks")
 	defer span.End()
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		for _, block := range blocks {
 			if err := k.setBlockSlotBitField(ctx, tx, block.Block.Slot); err != nil {
@@ -262,7 +261,7 @@ func (k *Store) SaveBlocks(ctx context.Context, blocks []*ethpb.SignedBeaconBloc
 func (k *Store) SaveHeadBlockRoot(ctx context.Context, blockRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveHeadBlockRoot")
 	defer span.End()
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		if featureconfig.Get().NewStateMgmt {
 			hasStateSummaryInCache := k.stateSummaryCache.Has(blockRoot)
 			hasStateSummaryInDB := tx.Bucket(stateSummaryBucket).Get(blockRoot[:]) != nil
@@ -286,7 +285,7 @@ func (k *Store) GenesisBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, err
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.GenesisBlock")
 	defer span.End()
 	var block *ethpb.SignedBeaconBlock
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		root := bkt.Get(genesisBlockRootKey)
 		enc := bkt.Get(root)
@@ -303,7 +302,7 @@ func (k *Store) GenesisBlock(ctx context.Context) (*ethpb.SignedBeaconBlock, err
 func (k *Store) SaveGenesisBlockRoot(ctx context.Context, blockRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveGenesisBlockRoot")
 	defer span.End()
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(blocksBucket)
 		return bucket.Put(genesisBlockRootKey, blockRoot[:])
 	})
@@ -315,7 +314,7 @@ func (k *Store) HighestSlotBlocks(ctx context.Context) ([]*ethpb.SignedBeaconBlo
 	defer span.End()
 
 	blocks := make([]*ethpb.SignedBeaconBlock, 0)
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		sBkt := tx.Bucket(slotsHasObjectBucket)
 		savedSlots := sBkt.Get(savedBlockSlotsKey)
 		highestIndex, err := bytesutil.HighestBitIndex(savedSlots)
@@ -339,7 +338,7 @@ func (k *Store) HighestSlotBlocksBelow(ctx context.Context, slot uint64) ([]*eth
 	defer span.End()
 
 	blocks := make([]*ethpb.SignedBeaconBlock, 0)
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		sBkt := tx.Bucket(slotsHasObjectBucket)
 		savedSlots := sBkt.Get(savedBlockSlotsKey)
 		if len(savedSlots) == 0 {
@@ -361,7 +360,7 @@ func (k *Store) HighestSlotBlocksBelow(ctx context.Context, slot uint64) ([]*eth
 
 // blocksAtSlotBitfieldIndex retrieves the blocks in DB given the input index. The index represents
 // the position of the slot bitfield the saved block maps to.
-func (k *Store) blocksAtSlotBitfieldIndex(ctx context.Context, tx *bolt.Tx, index int) ([]*ethpb.SignedBeaconBlock, error) {
+func (k *Store) blocksAtSlotBitfieldIndex(ctx context.Context, tx kvTx, index int) ([]*ethpb.SignedBeaconBlock, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.blocksAtSlotBitfieldIndex")
 	defer span.End()
 
@@ -399,7 +398,7 @@ func (k *Store) blocksAtSlotBitfieldIndex(ctx context.Context, tx *bolt.Tx, inde
 
 // setBlockSlotBitField sets the block slot bit in DB.
 // This helps to track which slot has a saved block in db.
-func (k *Store) setBlockSlotBitField(ctx context.Context, tx *bolt.Tx, slot uint64) error {
+func (k *Store) setBlockSlotBitField(ctx context.Context, tx kvTx, slot uint64) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.setBlockSlotBitField")
 	defer span.End()
 
@@ -420,7 +419,7 @@ func (k *Store) setBlockSlotBitField(ctx context.Context, tx *bolt.Tx, slot uint
 
 // clearBlockSlotBitField clears the block slot bit in DB.
 // This helps to track which slot has a saved block in db.
-func (k *Store) clearBlockSlotBitField(ctx context.Context, tx *bolt.Tx, slot uint64) error {
+func (k *Store) clearBlockSlotBitField(ctx context.Context, tx kvTx, slot uint64) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.clearBlockSlotBitField")
 	defer span.End()
 
@@ -440,7 +439,7 @@ func (k *Store) clearBlockSlotBitField(ctx context.Context, tx *bolt.Tx, slot ui
 }
 
 // getBlockRootsByFilter retrieves the block roots given the filter criteria.
-func getBlockRootsByFilter(ctx context.Context, tx *bolt.Tx, f *filters.QueryFilter) ([][]byte, error) {
+func getBlockRootsByFilter(ctx context.Context, tx kvTx, f *filters.QueryFilter) ([][]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.getBlockRootsByFilter")
 	defer span.End()
 
@@ -496,7 +495,7 @@ func getBlockRootsByFilter(ctx context.Context, tx *bolt.Tx, f *filters.QueryFil
 // range scan using sorted left-padded byte keys using a start slot and an end slot.
 // If both the start and end slot are the same, and are 0, the function returns nil.
 func fetchBlockRootsBySlotRange(
-	bkt *bolt.Bucket,
+	bkt kvBucket,
 	startSlotEncoded interface{},
 	endSlotEncoded interface{},
 	startEpochEncoded interface{},
@@ -6,7 +6,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	log "github.com/sirupsen/logrus"
-	bolt "go.etcd.io/bbolt"
 )
 
 var historicalStateDeletedKey = []byte("historical-states-deleted")
@@ -14,14 +13,14 @@ var historicalStateDeletedKey = []byte("historical-states-deleted")
 // HistoricalStatesDeleted verifies historical states exist in DB.
 func (kv *Store) HistoricalStatesDeleted(ctx context.Context) error {
 	if !featureconfig.Get().NewStateMgmt {
-		return kv.db.Update(func(tx *bolt.Tx) error {
+		return kv.db.Update(func(tx kvTx) error {
 			bkt := tx.Bucket(newStateServiceCompatibleBucket)
 			return bkt.Put(historicalStateDeletedKey, []byte{0x01})
 		})
 	}
 
 	var historicalStateDeleted bool
-	if err := kv.db.View(func(tx *bolt.Tx) error {
+	if err := kv.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(newStateServiceCompatibleBucket)
 		v := bkt.Get(historicalStateDeletedKey)
 		historicalStateDeleted = len(v) == 1 && v[0] == 0x01
@@ -37,7 +36,7 @@ func (kv *Store) HistoricalStatesDeleted(ctx context.Context) error {
 		}
 	}
 
-	return kv.db.Update(func(tx *bolt.Tx) error {
+	return kv.db.Update(func(tx kvTx) error {
 		bkt := tx.Bucket(newStateServiceCompatibleBucket)
 		return bkt.Put(historicalStateDeletedKey, []byte{0x00})
 	})
@@ -8,7 +8,6 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -19,7 +18,7 @@ func (k *Store) JustifiedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, err
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.JustifiedCheckpoint")
 	defer span.End()
 	var checkpoint *ethpb.Checkpoint
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(checkpointBucket)
 		enc := bkt.Get(justifiedCheckpointKey)
 		if enc == nil {
@@ -39,7 +38,7 @@ func (k *Store) FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, err
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.FinalizedCheckpoint")
 	defer span.End()
 	var checkpoint *ethpb.Checkpoint
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(checkpointBucket)
 		enc := bkt.Get(finalizedCheckpointKey)
 		if enc == nil {
@@ -63,7 +62,7 @@ func (k *Store) SaveJustifiedCheckpoint(ctx context.Context, checkpoint *ethpb.C
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(checkpointBucket)
 		if featureconfig.Get().NewStateMgmt {
 			hasStateSummaryInDB := tx.Bucket(stateSummaryBucket).Get(checkpoint.Root) != nil
@@ -94,7 +93,7 @@ func (k *Store) SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.C
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(checkpointBucket)
 		if featureconfig.Get().NewStateMgmt {
 			hasStateSummaryInDB := tx.Bucket(stateSummaryBucket).Get(checkpoint.Root) != nil
@@ -120,3 +119,35 @@ func (k *Store) SaveFinalizedCheckpoint(ctx context.Context, checkpoint *ethpb.C
 		return k.updateFinalizedBlockRoots(ctx, tx, checkpoint)
 	})
 }
+
+// VerifiedWeakSubjectivityCheckpoint returns the weak subjectivity checkpoint which was verified
+// to be canonical, or nil if no checkpoint has been verified.
+func (k *Store) VerifiedWeakSubjectivityCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.VerifiedWeakSubjectivityCheckpoint")
+	defer span.End()
+	var checkpoint *ethpb.Checkpoint
+	err := k.db.View(func(tx kvTx) error {
+		enc := tx.Bucket(checkpointBucket).Get(verifiedWsCheckpointKey)
+		if enc == nil {
+			return nil
+		}
+		checkpoint = &ethpb.Checkpoint{}
+		return decode(enc, checkpoint)
+	})
+	return checkpoint, err
+}
+
+// SaveVerifiedWeakSubjectivityCheckpoint saves the weak subjectivity checkpoint verified to be
+// canonical, so it is not verified again on restart.
+func (k *Store) SaveVerifiedWeakSubjectivityCheckpoint(ctx context.Context, checkpoint *ethpb.Checkpoint) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveVerifiedWeakSubjectivityCheckpoint")
+	defer span.End()
+
+	enc, err := encode(checkpoint)
+	if err != nil {
+		return err
+	}
+	return k.db.Update(func(tx kvTx) error {
+		return tx.Bucket(checkpointBucket).Put(verifiedWsCheckpointKey, enc)
+	})
+}
@@ -149,3 +149,29 @@ func TestStore_FinalizedCheckpoint_StateMustExist(t *testing.T) {
 		t.Fatalf("wanted err %v, got %v", errMissingStateForCheckpoint, err)
 	}
 }
+
+func TestStore_VerifiedWeakSubjectivityCheckpoint_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	retrieved, err := db.VerifiedWeakSubjectivityCheckpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved != nil {
+		t.Errorf("Expected no verified checkpoint, received %v", retrieved)
+	}
+
+	cp := &ethpb.Checkpoint{Epoch: 10, Root: bytesutil.PadTo([]byte{'A'}, 32)}
+	if err := db.SaveVerifiedWeakSubjectivityCheckpoint(ctx, cp); err != nil {
+		t.Fatal(err)
+	}
+	retrieved, err = db.VerifiedWeakSubjectivityCheckpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(cp, retrieved) {
+		t.Errorf("Wanted %v, received %v", cp, retrieved)
+	}
+}
@@ -0,0 +1,262 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// compactTxMaxSize is the number of key and value bytes copied per transaction
+// while compacting, which bounds the memory used by a single write transaction.
+const compactTxMaxSize = 64 * 1024 * 1024
+
+// BucketSize describes the space used by a top level bucket before and after compaction.
+type BucketSize struct {
+	Name   string
+	Before int
+	After  int
+}
+
+// CompactionReport describes the database file and bucket sizes before and after compaction.
+type CompactionReport struct {
+	FileSizeBefore int64
+	FileSizeAfter  int64
+	Buckets        []*BucketSize
+}
+
+// Compact rewrites the beacon chain database in the directory path specified into a new
+// file, releasing the free pages bolt accumulates as data is deleted or overwritten, and
+// replaces the original file with it. A Pebble database is compacted in place instead.
+// The database must not be open by another process.
+func Compact(dirPath string) (*CompactionReport, error) {
+	if fileExists(path.Join(dirPath, pebbleDirName)) {
+		return compactPebble(dirPath)
+	}
+	datafile := path.Join(dirPath, databaseFileName)
+	info, err := os.Stat(datafile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat database file")
+	}
+	report := &CompactionReport{FileSizeBefore: info.Size()}
+
+	src, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	defer func() {
+		if src != nil {
+			_ = src.Close()
+		}
+	}()
+	tmpFile := datafile + ".compact"
+	if err := os.Remove(tmpFile); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	dst, err := bolt.Open(tmpFile, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	dst.AllocSize = boltAllocSize
+
+	if err := compactBolt(dst, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpFile)
+		return nil, errors.Wrap(err, "could not copy database")
+	}
+	before, err := bucketSizes(src)
+	if err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpFile)
+		return nil, err
+	}
+	after, err := bucketSizes(dst)
+	if err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpFile)
+		return nil, err
+	}
+	if err := dst.Close(); err != nil {
+		return nil, err
+	}
+	if err := src.Close(); err != nil {
+		return nil, err
+	}
+	src = nil
+	if err := os.Rename(tmpFile, datafile); err != nil {
+		return nil, errors.Wrap(err, "could not replace database file")
+	}
+
+	info, err = os.Stat(datafile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat compacted database file")
+	}
+	report.FileSizeAfter = info.Size()
+	for name, size := range before {
+		report.Buckets = append(report.Buckets, &BucketSize{Name: name, Before: size, After: after[name]})
+	}
+	sort.Slice(report.Buckets, func(i, j int) bool {
+		return report.Buckets[i].Name < report.Buckets[j].Name
+	})
+	return report, nil
+}
+
+// Compact reclaims the space of deleted and overwritten data of the open database and
+// returns the size of the database files before and after compaction. A BoltDB database
+// is rewritten into a new file, and reads and writes wait until it replaces the database
+// file. A Pebble database keeps serving reads and writes while it is compacted.
+func (k *Store) Compact(ctx context.Context) (int64, int64, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Compact")
+	defer span.End()
+
+	before, err := k.databaseSize()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not stat database")
+	}
+	log := logrus.WithField("prefix", "db").WithField("sizeBefore", before)
+	if _, ok := k.db.(*boltEngine); ok {
+		log.Warn("Compacting database, reads and writes are blocked until compaction completes")
+	} else {
+		log.Info("Compacting database")
+	}
+	if err := k.db.Compact(); err != nil {
+		return 0, 0, errors.Wrap(err, "could not compact database")
+	}
+	after, err := k.databaseSize()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "could not stat compacted database")
+	}
+	log.WithField("sizeAfter", after).Info("Compacted database")
+	return before, after, nil
+}
+
+// databaseSize returns the size of the files of the database.
+func (k *Store) databaseSize() (int64, error) {
+	if _, ok := k.db.(*pebbleEngine); ok {
+		return dirSize(path.Join(k.databasePath, pebbleDirName))
+	}
+	info, err := os.Stat(path.Join(k.databasePath, databaseFileName))
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// compactPebble compacts every key of the Pebble database in the directory path specified.
+// Bucket sizes are not reported, as the files of the database are shared by all buckets.
+func compactPebble(dirPath string) (*CompactionReport, error) {
+	dir := path.Join(dirPath, pebbleDirName)
+	before, err := dirSize(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat database directory")
+	}
+	db, err := openPebble(dirPath, false /* readOnly */)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Compact(); err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "could not compact database")
+	}
+	if err := db.Close(); err != nil {
+		return nil, err
+	}
+	after, err := dirSize(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat compacted database directory")
+	}
+	return &CompactionReport{FileSizeBefore: before, FileSizeAfter: after}, nil
+}
+
+// compactBolt copies every bucket, nested bucket and key of src into dst. Keys are
+// inserted in order, so buckets are filled completely rather than split in half.
+func compactBolt(dst, src *bolt.DB) error {
+	var size int64
+	tx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if err := src.View(func(srcTx *bolt.Tx) error {
+		return srcTx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			return walkBucket(b, nil, name, func(keys [][]byte, k, v []byte, seq uint64) error {
+				sz := int64(len(k) + len(v))
+				if size+sz > compactTxMaxSize {
+					if err := tx.Commit(); err != nil {
+						return err
+					}
+					tx, err = dst.Begin(true)
+					if err != nil {
+						return err
+					}
+					size = 0
+				}
+				size += sz
+
+				if len(keys) == 0 {
+					bkt, err := tx.CreateBucket(k)
+					if err != nil {
+						return err
+					}
+					return bkt.SetSequence(seq)
+				}
+				b := tx.Bucket(keys[0])
+				for _, key := range keys[1:] {
+					b = b.Bucket(key)
+				}
+				b.FillPercent = 1.0
+				if v == nil {
+					bkt, err := b.CreateBucket(k)
+					if err != nil {
+						return err
+					}
+					return bkt.SetSequence(seq)
+				}
+				return b.Put(k, v)
+			})
+		})
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// walkBucket calls fn for the bucket itself and recursively for every key and nested
+// bucket within it. Nested buckets are passed with a nil value.
+func walkBucket(b *bolt.Bucket, keys [][]byte, k []byte, fn func(keys [][]byte, k, v []byte, seq uint64) error) error {
+	if err := fn(keys, k, nil, b.Sequence()); err != nil {
+		return err
+	}
+	keys = append(keys, k)
+	return b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			return walkBucket(b.Bucket(k), keys, k, fn)
+		}
+		return fn(keys, k, v, 0)
+	})
+}
+
+// bucketSizes returns the bytes allocated to the pages of every top level bucket.
+func bucketSizes(db *bolt.DB) (map[string]int, error) {
+	sizes := make(map[string]int)
+	err := db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			stats := b.Stats()
+			sizes[string(name)] = stats.BranchAlloc + stats.LeafAlloc
+			return nil
+		})
+	})
+	return sizes, err
+}
@@ -0,0 +1,148 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+)
+
+// saveAndDeleteBlocks saves 500 blocks and deletes all but the first, returning the roots
+// of the blocks saved.
+func saveAndDeleteBlocks(t *testing.T, db *Store) [][32]byte {
+	ctx := context.Background()
+	roots := make([][32]byte, 0)
+	for i := uint64(1); i <= 500; i++ {
+		blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{
+			Slot:      i,
+			StateRoot: bytesutil.PadTo(bytesutil.Bytes8(i), 32),
+			Body: &eth.BeaconBlockBody{
+				Graffiti: make([]byte, 32),
+			},
+		}}
+		if err := db.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, root)
+	}
+	if err := db.DeleteBlocks(ctx, roots[1:]); err != nil {
+		t.Fatal(err)
+	}
+	return roots
+}
+
+func TestCompact_ReclaimsDeletedBlocks(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	roots := saveAndDeleteBlocks(t, db)
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Compact(db.DatabasePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.FileSizeAfter >= report.FileSizeBefore {
+		t.Errorf("Expected compacted file to shrink, before %d after %d", report.FileSizeBefore, report.FileSizeAfter)
+	}
+	found := false
+	for _, b := range report.Buckets {
+		if b.Name == string(blocksBucket) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected blocks bucket in compaction report")
+	}
+
+	db, err = NewKVStore(db.DatabasePath(), cache.NewStateSummaryCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer teardownDB(t, db)
+	if !db.HasBlock(ctx, roots[0]) {
+		t.Error("Expected block to be retained after compaction")
+	}
+	if db.HasBlock(ctx, roots[1]) {
+		t.Error("Expected deleted block to not be present after compaction")
+	}
+}
+
+func TestStore_Compact(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	roots := saveAndDeleteBlocks(t, db)
+	before, after, err := db.Compact(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after >= before {
+		t.Errorf("Expected compacted file to shrink, before %d after %d", before, after)
+	}
+	info, err := os.Stat(path.Join(db.DatabasePath(), databaseFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != after {
+		t.Errorf("Expected reported size %d to match the database file size %d", after, info.Size())
+	}
+
+	// The store keeps using the compacted file without being reopened.
+	db.blockCache.Clear()
+	if !db.HasBlock(ctx, roots[0]) {
+		t.Error("Expected block to be retained after compaction")
+	}
+	if db.HasBlock(ctx, roots[1]) {
+		t.Error("Expected deleted block to not be present after compaction")
+	}
+	blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 501}}
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.blockCache.Clear()
+	if !db.HasBlock(ctx, root) {
+		t.Error("Expected block saved after compaction to be present")
+	}
+}
+
+func TestStore_Compact_Pebble(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{EnablePebbleDB: true})
+	defer resetCfg()
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	roots := saveAndDeleteBlocks(t, db)
+	before, after, err := db.Compact(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before == 0 || after == 0 {
+		t.Errorf("Expected database sizes to be reported, before %d after %d", before, after)
+	}
+	db.blockCache.Clear()
+	if !db.HasBlock(ctx, roots[0]) {
+		t.Error("Expected block to be retained after compaction")
+	}
+	if db.HasBlock(ctx, roots[1]) {
+		t.Error("Expected deleted block to not be present after compaction")
+	}
+}
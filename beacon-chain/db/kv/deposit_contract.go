@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -15,7 +14,7 @@ func (k *Store) DepositContractAddress(ctx context.Context) ([]byte, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DepositContractAddress")
 	defer span.End()
 	var addr []byte
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		chainInfo := tx.Bucket(chainMetadataBucket)
 		addr = chainInfo.Get(depositContractAddressKey)
 		return nil
@@ -30,7 +29,7 @@ func (k *Store) SaveDepositContractAddress(ctx context.Context, addr common.Addr
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.VerifyContractAddress")
 	defer span.End()
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		chainInfo := tx.Bucket(chainMetadataBucket)
 		expectedAddress := chainInfo.Get(depositContractAddressKey)
 		if expectedAddress != nil {
@@ -11,6 +11,7 @@ import (
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 )
 
+// decode decompresses the snappy encoded value read from the database and unmarshals it into dst.
 func decode(data []byte, dst proto.Message) error {
 	data, err := snappy.Decode(nil, data)
 	if err != nil {
@@ -22,6 +23,9 @@ func decode(data []byte, dst proto.Message) error {
 	return proto.Unmarshal(data, dst)
 }
 
+// encode marshals the message and compresses it with snappy before it is written to the
+// database. Every value has been stored compressed since the first schema version, so no
+// migration is needed to read existing databases.
 func encode(msg proto.Message) ([]byte, error) {
 	if msg == nil || reflect.ValueOf(msg).IsNil() {
 		return nil, errors.New("cannot encode nil message")
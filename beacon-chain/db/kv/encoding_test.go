@@ -3,7 +3,10 @@ package kv
 import (
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	testpb "github.com/prysmaticlabs/prysm/proto/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
 )
 
 func Test_encode_handlesNilFromFunction(t *testing.T) {
@@ -15,3 +18,25 @@ func Test_encode_handlesNilFromFunction(t *testing.T) {
 		t.Fatalf("Wrong error %v", err)
 	}
 }
+
+func Test_encode_compressesStates(t *testing.T) {
+	st := testutil.NewBeaconState()
+	raw, err := st.InnerStateUnsafe().MarshalSSZ()
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := encode(st.InnerStateUnsafe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(enc) >= len(raw) {
+		t.Errorf("Wanted encoded state smaller than %d bytes, got %d bytes", len(raw), len(enc))
+	}
+	decoded := &pb.BeaconState{}
+	if err := decode(enc, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(decoded, st.InnerStateUnsafe()) {
+		t.Error("Decoded state does not match the encoded state")
+	}
+}
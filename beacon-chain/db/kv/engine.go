@@ -0,0 +1,256 @@
+package kv
+
+import (
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	prombolt "github.com/prysmaticlabs/prombbolt"
+	bolt "go.etcd.io/bbolt"
+)
+
+var errTxNotWritable = errors.New("transaction is not writable")
+
+// kvEngine is the key-value storage engine persisting the Store. Keys are kept sorted in
+// top level buckets, which are read in read-only transactions and modified in read-write
+// transactions. Read-write transactions are applied atomically, one at a time.
+type kvEngine interface {
+	// View runs fn in a read-only transaction over a consistent view of the database.
+	View(fn func(tx kvTx) error) error
+	// Update runs fn in a read-write transaction, which is committed if fn returns nil.
+	Update(fn func(tx kvTx) error) error
+	// Backup writes a consistent copy of the database to the given path.
+	Backup(backupPath string) error
+	// Compact reclaims the space of deleted and overwritten data while the database is open.
+	Compact() error
+	// Remove closes the database and deletes its files.
+	Remove() error
+	// Close closes the database.
+	Close() error
+}
+
+// kvTx is a transaction of a kvEngine. Byte slices returned within a transaction must
+// not be modified.
+type kvTx interface {
+	// Bucket returns the top level bucket with the given name, or nil if it does not exist.
+	Bucket(name []byte) kvBucket
+	CreateBucket(name []byte) (kvBucket, error)
+	CreateBucketIfNotExists(name []byte) (kvBucket, error)
+	DeleteBucket(name []byte) error
+}
+
+// kvBucket is a collection of sorted keys and their values.
+type kvBucket interface {
+	// Get returns the value of the key, or nil if the key is not in the bucket.
+	Get(key []byte) []byte
+	Put(key []byte, value []byte) error
+	Delete(key []byte) error
+	Cursor() kvCursor
+	// ForEach calls fn for every key in the bucket in order, stopping at the first error.
+	ForEach(fn func(k, v []byte) error) error
+}
+
+// kvCursor iterates over the keys of a bucket in order. Every method positioning the cursor
+// returns a nil key once it is moved past the first or the last key of the bucket.
+type kvCursor interface {
+	First() (key []byte, value []byte)
+	Last() (key []byte, value []byte)
+	Next() (key []byte, value []byte)
+	Prev() (key []byte, value []byte)
+	// Seek moves the cursor to the first key greater than or equal to seek.
+	Seek(seek []byte) (key []byte, value []byte)
+	// Delete removes the key the cursor is positioned at.
+	Delete() error
+}
+
+// boltEngine stores the database in a single BoltDB file, a copy-on-write B+tree.
+type boltEngine struct {
+	// swapLock is held for writing while the database file is replaced by a compacted copy,
+	// and for reading by every other use of the database.
+	swapLock  sync.RWMutex
+	db        *bolt.DB
+	collector prometheus.Collector
+}
+
+func openBolt(dirPath string) (*boltEngine, error) {
+	e := &boltEngine{}
+	if err := e.open(path.Join(dirPath, databaseFileName)); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// open opens the database file and registers its metrics.
+func (e *boltEngine) open(datafile string) error {
+	boltDB, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second, InitialMmapSize: 10e6})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return err
+	}
+	boltDB.AllocSize = boltAllocSize
+	collector := createBoltCollector(boltDB)
+	if err := prometheus.Register(collector); err != nil {
+		_ = boltDB.Close()
+		return err
+	}
+	e.db = boltDB
+	e.collector = collector
+	return nil
+}
+
+func (e *boltEngine) View(fn func(tx kvTx) error) error {
+	e.swapLock.RLock()
+	defer e.swapLock.RUnlock()
+	return e.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+func (e *boltEngine) Update(fn func(tx kvTx) error) error {
+	e.swapLock.RLock()
+	defer e.swapLock.RUnlock()
+	return e.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltTx{tx: tx})
+	})
+}
+
+// Backup copies the database file within a read transaction.
+func (e *boltEngine) Backup(backupPath string) error {
+	e.swapLock.RLock()
+	defer e.swapLock.RUnlock()
+	return e.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(backupPath, 0600)
+	})
+}
+
+// Compact copies the database into a new file, which is packed without the free pages
+// BoltDB keeps after deletes, and replaces the database file with it. Transactions wait
+// until the compacted database is opened, which may take a while on large databases.
+func (e *boltEngine) Compact() error {
+	e.swapLock.Lock()
+	defer e.swapLock.Unlock()
+
+	datafile := e.db.Path()
+	tmpFile := datafile + ".compact"
+	if err := os.Remove(tmpFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	dst, err := bolt.Open(tmpFile, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return err
+	}
+	dst.AllocSize = boltAllocSize
+	if err := compactBolt(dst, e.db); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpFile)
+		return errors.Wrap(err, "could not copy database")
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmpFile)
+		return err
+	}
+
+	e.unregisterCollector()
+	if err := e.db.Close(); err != nil {
+		_ = os.Remove(tmpFile)
+		return err
+	}
+	if err := os.Rename(tmpFile, datafile); err != nil {
+		_ = os.Remove(tmpFile)
+		if openErr := e.open(datafile); openErr != nil {
+			return errors.Wrapf(openErr, "could not reopen database after failing to replace it: %v", err)
+		}
+		return errors.Wrap(err, "could not replace database file")
+	}
+	return errors.Wrap(e.open(datafile), "could not open compacted database")
+}
+
+// Remove deletes the database file. The file stays readable through the open database
+// until it is closed.
+func (e *boltEngine) Remove() error {
+	e.swapLock.Lock()
+	defer e.swapLock.Unlock()
+	e.unregisterCollector()
+	return os.Remove(e.db.Path())
+}
+
+func (e *boltEngine) Close() error {
+	e.swapLock.Lock()
+	defer e.swapLock.Unlock()
+	e.unregisterCollector()
+	return e.db.Close()
+}
+
+// unregisterCollector removes the metrics of the database. Databases opened for inspection
+// do not report metrics.
+func (e *boltEngine) unregisterCollector() {
+	if e.collector != nil {
+		prometheus.Unregister(e.collector)
+	}
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t *boltTx) Bucket(name []byte) kvBucket {
+	b := t.tx.Bucket(name)
+	if b == nil {
+		return nil
+	}
+	return &boltBucket{b: b}
+}
+
+func (t *boltTx) CreateBucket(name []byte) (kvBucket, error) {
+	b, err := t.tx.CreateBucket(name)
+	if err != nil {
+		return nil, err
+	}
+	return &boltBucket{b: b}, nil
+}
+
+func (t *boltTx) CreateBucketIfNotExists(name []byte) (kvBucket, error) {
+	b, err := t.tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return &boltBucket{b: b}, nil
+}
+
+func (t *boltTx) DeleteBucket(name []byte) error {
+	return t.tx.DeleteBucket(name)
+}
+
+type boltBucket struct {
+	b *bolt.Bucket
+}
+
+func (b *boltBucket) Get(key []byte) []byte {
+	return b.b.Get(key)
+}
+
+func (b *boltBucket) Put(key []byte, value []byte) error {
+	return b.b.Put(key, value)
+}
+
+func (b *boltBucket) Delete(key []byte) error {
+	return b.b.Delete(key)
+}
+
+func (b *boltBucket) Cursor() kvCursor {
+	return b.b.Cursor()
+}
+
+func (b *boltBucket) ForEach(fn func(k, v []byte) error) error {
+	return b.b.ForEach(fn)
+}
+
+// createBoltCollector returns a prometheus collector specifically configured for boltdb.
+func createBoltCollector(db *bolt.DB) prometheus.Collector {
+	return prombolt.New("boltDB", db)
+}
@@ -0,0 +1,364 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+var testEngines = map[string]func(dirPath string) (kvEngine, error){
+	"bolt": func(dirPath string) (kvEngine, error) {
+		return openBolt(dirPath)
+	},
+	"pebble": func(dirPath string) (kvEngine, error) {
+		return openPebble(dirPath, false /* readOnly */)
+	},
+}
+
+func setupEngine(t *testing.T, open func(dirPath string) (kvEngine, error)) (kvEngine, string) {
+	dirPath := path.Join(testutil.TempDir(), fmt.Sprintf("engine-%s", t.Name()))
+	if err := os.RemoveAll(dirPath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dirPath, 0700); err != nil {
+		t.Fatal(err)
+	}
+	e, err := open(dirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e, dirPath
+}
+
+func TestEngine_BucketOperations(t *testing.T) {
+	for name, open := range testEngines {
+		t.Run(name, func(t *testing.T) {
+			e, dirPath := setupEngine(t, open)
+			defer func() {
+				if err := e.Close(); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.RemoveAll(dirPath); err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			err := e.Update(func(tx kvTx) error {
+				b, err := tx.CreateBucket([]byte("a"))
+				if err != nil {
+					return err
+				}
+				for _, k := range []string{"k3", "k1", "k2", "l1"} {
+					if err := b.Put([]byte(k), []byte("v"+k)); err != nil {
+						return err
+					}
+				}
+				if _, err := tx.CreateBucket([]byte("a")); err == nil {
+					t.Error("Expected creating an existing bucket to fail")
+				}
+				// Keys of other buckets must not be visible through bucket a.
+				other, err := tx.CreateBucketIfNotExists([]byte("b"))
+				if err != nil {
+					return err
+				}
+				return other.Put([]byte("k0"), []byte("other"))
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = e.View(func(tx kvTx) error {
+				if tx.Bucket([]byte("missing")) != nil {
+					t.Error("Expected no bucket")
+				}
+				b := tx.Bucket([]byte("a"))
+				if v := b.Get([]byte("k2")); !bytes.Equal(v, []byte("vk2")) {
+					t.Errorf("Unexpected value %q", v)
+				}
+				if v := b.Get([]byte("k0")); v != nil {
+					t.Errorf("Expected no value, received %q", v)
+				}
+				var keys []string
+				if err := b.ForEach(func(k, v []byte) error {
+					keys = append(keys, string(k))
+					return nil
+				}); err != nil {
+					return err
+				}
+				if fmt.Sprint(keys) != "[k1 k2 k3 l1]" {
+					t.Errorf("Unexpected keys %v", keys)
+				}
+
+				c := b.Cursor()
+				if k, _ := c.Seek([]byte("k15")); string(k) != "k2" {
+					t.Errorf("Expected seek to k2, received %q", k)
+				}
+				if k, _ := c.Prev(); string(k) != "k1" {
+					t.Errorf("Expected k1, received %q", k)
+				}
+				if k, _ := c.Prev(); k != nil {
+					t.Errorf("Expected no key before the first, received %q", k)
+				}
+				if k, v := c.Last(); string(k) != "l1" || string(v) != "vl1" {
+					t.Errorf("Expected last key l1, received %q", k)
+				}
+				if k, _ := c.Next(); k != nil {
+					t.Errorf("Expected no key after the last, received %q", k)
+				}
+				if k, _ := c.Seek([]byte("m")); k != nil {
+					t.Errorf("Expected no key, received %q", k)
+				}
+
+				if err := b.Put([]byte("k4"), nil); err == nil {
+					t.Error("Expected put in a read-only transaction to fail")
+				}
+				if _, err := tx.CreateBucketIfNotExists([]byte("c")); err == nil {
+					t.Error("Expected bucket creation in a read-only transaction to fail")
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete keys through a cursor and recreate a deleted bucket.
+			err = e.Update(func(tx kvTx) error {
+				b := tx.Bucket([]byte("a"))
+				c := b.Cursor()
+				if k, _ := c.Seek([]byte("k2")); string(k) != "k2" {
+					t.Errorf("Expected seek to k2, received %q", k)
+				}
+				if err := c.Delete(); err != nil {
+					return err
+				}
+				if err := b.Delete([]byte("k1")); err != nil {
+					return err
+				}
+				if err := b.Delete([]byte("k3")); err != nil {
+					return err
+				}
+				if err := tx.DeleteBucket([]byte("b")); err != nil {
+					return err
+				}
+				if tx.Bucket([]byte("b")) != nil {
+					t.Error("Expected deleted bucket to be gone")
+				}
+				_, err := tx.CreateBucket([]byte("b"))
+				return err
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = e.View(func(tx kvTx) error {
+				var keys []string
+				if err := tx.Bucket([]byte("a")).ForEach(func(k, v []byte) error {
+					keys = append(keys, string(k))
+					return nil
+				}); err != nil {
+					return err
+				}
+				if fmt.Sprint(keys) != "[l1]" {
+					t.Errorf("Unexpected keys %v", keys)
+				}
+				if v := tx.Bucket([]byte("b")).Get([]byte("k0")); v != nil {
+					t.Errorf("Expected keys of the deleted bucket to be gone, received %q", v)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEngine_GetEmptyValue(t *testing.T) {
+	for name, open := range testEngines {
+		t.Run(name, func(t *testing.T) {
+			e, dirPath := setupEngine(t, open)
+			defer func() {
+				if err := e.Close(); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.RemoveAll(dirPath); err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			err := e.Update(func(tx kvTx) error {
+				b, err := tx.CreateBucket([]byte("a"))
+				if err != nil {
+					return err
+				}
+				return b.Put([]byte("empty"), []byte{})
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = e.View(func(tx kvTx) error {
+				b := tx.Bucket([]byte("a"))
+				if v := b.Get([]byte("empty")); v == nil || len(v) != 0 {
+					t.Errorf("Expected a non-nil empty value, received %#v", v)
+				}
+				if v := b.Get([]byte("missing")); v != nil {
+					t.Errorf("Expected no value, received %q", v)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEngine_UpdateRollsBackOnError(t *testing.T) {
+	for name, open := range testEngines {
+		t.Run(name, func(t *testing.T) {
+			e, dirPath := setupEngine(t, open)
+			defer func() {
+				if err := e.Close(); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.RemoveAll(dirPath); err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			wantErr := fmt.Errorf("failed")
+			err := e.Update(func(tx kvTx) error {
+				b, err := tx.CreateBucket([]byte("a"))
+				if err != nil {
+					return err
+				}
+				if err := b.Put([]byte("k"), []byte("v")); err != nil {
+					return err
+				}
+				return wantErr
+			})
+			if err != wantErr {
+				t.Fatalf("Expected %v, received %v", wantErr, err)
+			}
+			if err := e.View(func(tx kvTx) error {
+				if tx.Bucket([]byte("a")) != nil {
+					t.Error("Expected the bucket of a failed transaction not to be created")
+				}
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEngine_Backup(t *testing.T) {
+	for name, open := range testEngines {
+		t.Run(name, func(t *testing.T) {
+			e, dirPath := setupEngine(t, open)
+			defer func() {
+				if err := e.Close(); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.RemoveAll(dirPath); err != nil {
+					t.Fatal(err)
+				}
+			}()
+			if err := e.Update(func(tx kvTx) error {
+				b, err := tx.CreateBucket([]byte("a"))
+				if err != nil {
+					return err
+				}
+				return b.Put([]byte("k"), []byte("v"))
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			backupDir := path.Join(dirPath, "backup")
+			if err := os.MkdirAll(backupDir, 0700); err != nil {
+				t.Fatal(err)
+			}
+			backupPath := path.Join(backupDir, databaseFileName)
+			if name == "pebble" {
+				backupPath = path.Join(backupDir, pebbleDirName)
+			}
+			if err := e.Backup(backupPath); err != nil {
+				t.Fatal(err)
+			}
+			backup, err := openReadOnlyEngine(backupDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if err := backup.Close(); err != nil {
+					t.Fatal(err)
+				}
+			}()
+			if err := backup.View(func(tx kvTx) error {
+				b := tx.Bucket([]byte("a"))
+				if b == nil {
+					t.Fatal("Expected bucket in backup")
+				}
+				if v := b.Get([]byte("k")); !bytes.Equal(v, []byte("v")) {
+					t.Errorf("Unexpected value %q in backup", v)
+				}
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestStore_Pebble(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{EnablePebbleDB: true})
+	defer resetCfg()
+	ctx := context.Background()
+
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	if _, err := os.Stat(path.Join(db.DatabasePath(), pebbleDirName)); err != nil {
+		t.Fatalf("Expected a pebble database: %v", err)
+	}
+	blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 20}}
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewKVStore(db.DatabasePath(), cache.NewStateSummaryCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := reopened.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	received, err := reopened.Block(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received == nil || received.Block.Slot != blk.Block.Slot {
+		t.Errorf("Expected block to persist, received %v", received)
+	}
+
+	resetCfg()
+	if _, err := NewKVStore(db.DatabasePath(), cache.NewStateSummaryCache()); err == nil {
+		t.Error("Expected opening a pebble database with bolt to fail")
+	}
+}
@@ -6,11 +6,11 @@ import (
 	"fmt"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -35,9 +35,12 @@ var containerFinalizedButNotCanonical = []byte("recent block needs reindexing to
 //     root until a parent is found in the index or the parent is genesis.
 //   - Add all block roots in the database where epoch(block.slot) == checkpoint.epoch.
 //
+// Every block of the canonical finalized chain walked above is also indexed by its slot, see
+// FinalizedBlockRootsInRange.
+//
 // This method ensures that all blocks from the current finalized epoch are considered "final" while
 // maintaining only canonical and finalized blocks older than the current finalized epoch.
-func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, checkpoint *ethpb.Checkpoint) error {
+func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx kvTx, checkpoint *ethpb.Checkpoint) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.updateFinalizedBlockRoots")
 	defer span.End()
 
@@ -70,11 +73,19 @@ func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 			return err
 		}
 	}
+	if err := deindexFinalizedSlotRoots(tx, helpers.StartSlot(previousFinalizedCheckpoint.Epoch)); err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
 
 	// Walk up the ancestry chain until we reach a block root present in the finalized block roots
 	// index bucket or genesis block root.
 	for {
 		if bytes.Equal(root, genesisRoot) {
+			if err := indexFinalizedSlotRoot(tx, 0, root); err != nil {
+				traceutil.AnnotateError(span, err)
+				return err
+			}
 			break
 		}
 
@@ -104,6 +115,10 @@ func (k *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 			traceutil.AnnotateError(span, err)
 			return err
 		}
+		if err := indexFinalizedSlotRoot(tx, block.Slot, root); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
 
 		// Found parent, loop exit condition.
 		if parentBytes := bkt.Get(block.ParentRoot); parentBytes != nil {
@@ -164,7 +179,7 @@ func (k *Store) IsFinalizedBlock(ctx context.Context, blockRoot [32]byte) bool {
 	defer span.End()
 
 	var exists bool
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		exists = tx.Bucket(finalizedBlockRootsIndexBucket).Get(blockRoot[:]) != nil
 		return nil
 	})
@@ -0,0 +1,146 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"go.opencensus.io/trace"
+)
+
+// Number of blocks indexed per transaction when building the slot index of an existing database.
+const finalizedSlotIndexBatchSize = 1000
+
+// The finalized slot roots index maps the slot of every block in the canonical finalized chain to
+// its block root. It is rebuilt from the previous finalized epoch onwards together with the finalized
+// block roots index, so ranges of finalized blocks can be served by scanning the index instead of
+// walking the ancestry of the finalized block.
+//
+// Slots are encoded as big endian so that the keys are sorted by slot.
+func finalizedSlotKey(slot uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, slot)
+	return key
+}
+
+func indexFinalizedSlotRoot(tx kvTx, slot uint64, root []byte) error {
+	return tx.Bucket(finalizedSlotRootsIndexBucket).Put(finalizedSlotKey(slot), root)
+}
+
+// deindexFinalizedSlotRoots removes the index entries of all slots from the given slot onwards.
+func deindexFinalizedSlotRoots(tx kvTx, fromSlot uint64) error {
+	c := tx.Bucket(finalizedSlotRootsIndexBucket).Cursor()
+	for k, _ := c.Seek(finalizedSlotKey(fromSlot)); k != nil; k, _ = c.Seek(finalizedSlotKey(fromSlot)) {
+		if err := c.Delete(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FinalizedBlockRootsInRange returns the roots of the canonical finalized blocks with a slot
+// between startSlot and endSlot inclusive, ordered by slot. Skipped slots have no entry, and
+// slots after the finalized checkpoint block are not indexed.
+func (k *Store) FinalizedBlockRootsInRange(ctx context.Context, startSlot, endSlot uint64) ([][32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.FinalizedBlockRootsInRange")
+	defer span.End()
+
+	roots := make([][32]byte, 0)
+	err := k.db.View(func(tx kvTx) error {
+		c := tx.Bucket(finalizedSlotRootsIndexBucket).Cursor()
+		for k, v := c.Seek(finalizedSlotKey(startSlot)); k != nil; k, v = c.Next() {
+			if binary.BigEndian.Uint64(k) > endSlot {
+				break
+			}
+			roots = append(roots, bytesutil.ToBytes32(v))
+		}
+		return nil
+	})
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, err
+	}
+	return roots, nil
+}
+
+// NextFinalizedBlockSlot returns the slot of the first canonical finalized block at or after the
+// given slot, skipping over any empty slots in between. It returns false if no finalized block is
+// indexed at or after the slot.
+func (k *Store) NextFinalizedBlockSlot(ctx context.Context, slot uint64) (uint64, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.NextFinalizedBlockSlot")
+	defer span.End()
+
+	var next uint64
+	var ok bool
+	err := k.db.View(func(tx kvTx) error {
+		key, _ := tx.Bucket(finalizedSlotRootsIndexBucket).Cursor().Seek(finalizedSlotKey(slot))
+		if key != nil {
+			next = binary.BigEndian.Uint64(key)
+			ok = true
+		}
+		return nil
+	})
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return 0, false, err
+	}
+	return next, ok, nil
+}
+
+// indexFinalizedSlotRoots builds the finalized slot roots index of a database created before the
+// index existed, by walking the ancestry of the finalized checkpoint down to genesis. Blocks are
+// indexed in batches so the migration makes progress even if interrupted, and entries already
+// present are rewritten with the same value when it is rerun.
+func indexFinalizedSlotRoots(ctx context.Context, db kvEngine) error {
+	var root []byte
+	if err := db.View(func(tx kvTx) error {
+		enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey)
+		if enc == nil {
+			return nil
+		}
+		checkpoint := &ethpb.Checkpoint{}
+		if err := decode(enc, checkpoint); err != nil {
+			return err
+		}
+		root = checkpoint.Root
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for len(root) != 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := db.Update(func(tx kvTx) error {
+			genesisRoot := tx.Bucket(blocksBucket).Get(genesisBlockRootKey)
+			for i := 0; i < finalizedSlotIndexBatchSize && len(root) != 0; i++ {
+				enc := tx.Bucket(blocksBucket).Get(root)
+				if enc == nil {
+					// The ancestry is not available past this block, nothing more to index.
+					root = nil
+					return nil
+				}
+				block := &ethpb.SignedBeaconBlock{}
+				if err := decode(enc, block); err != nil {
+					return err
+				}
+				if err := indexFinalizedSlotRoot(tx, block.Block.Slot, root); err != nil {
+					return err
+				}
+				if block.Block.Slot == 0 || bytes.Equal(root, genesisRoot) {
+					root = nil
+					return nil
+				}
+				root = block.Block.ParentRoot
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package kv
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestStore_FinalizedBlockRootsInRange(t *testing.T) {
+	slotsPerEpoch := int(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	if err := db.SaveGenesisBlockRoot(ctx, genesisBlockRoot); err != nil {
+		t.Fatal(err)
+	}
+	blks := makeBlocks(t, 0, slotsPerEpoch*3, genesisBlockRoot)
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	cp := &ethpb.Checkpoint{Epoch: 1, Root: sszRootOrDie(t, blks[slotsPerEpoch])}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), bytesutil.ToBytes32(cp.Root)); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		t.Fatal(err)
+	}
+
+	// Genesis and every block up to the finalized checkpoint block are indexed.
+	want := [][32]byte{genesisBlockRoot}
+	for i := 0; i <= slotsPerEpoch; i++ {
+		want = append(want, bytesutil.ToBytes32(sszRootOrDie(t, blks[i])))
+	}
+	roots, err := db.FinalizedBlockRootsInRange(ctx, 0, uint64(slotsPerEpoch*3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(roots, want) {
+		t.Errorf("Wanted %d finalized roots, received %d", len(want), len(roots))
+	}
+
+	roots, err = db.FinalizedBlockRootsInRange(ctx, 2, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(roots, want[2:5]) {
+		t.Errorf("Wanted roots of slots 2 to 4, received %#x", roots)
+	}
+}
+
+func TestStore_NextFinalizedBlockSlot(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	if err := db.SaveGenesisBlockRoot(ctx, genesisBlockRoot); err != nil {
+		t.Fatal(err)
+	}
+	// Blocks at slots 1 and 2, followed by empty slots up to the block at slot 3 * slotsPerEpoch.
+	blks := makeBlocks(t, 0, 2, genesisBlockRoot)
+	blks = append(blks, makeBlocks(t, int(3*slotsPerEpoch)-1, 1, bytesutil.ToBytes32(sszRootOrDie(t, blks[1])))...)
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	cp := &ethpb.Checkpoint{Epoch: 3, Root: sszRootOrDie(t, blks[2])}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), bytesutil.ToBytes32(cp.Root)); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		slot uint64
+		want uint64
+		ok   bool
+	}{
+		{slot: 2, want: 2, ok: true},
+		{slot: 3, want: 3 * slotsPerEpoch, ok: true},
+		{slot: 3*slotsPerEpoch + 1, ok: false},
+	}
+	for _, tt := range tests {
+		next, ok, err := db.NextFinalizedBlockSlot(ctx, tt.slot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != tt.ok || next != tt.want {
+			t.Errorf("Wanted next finalized block slot after %d to be %d (%v), received %d (%v)", tt.slot, tt.want, tt.ok, next, ok)
+		}
+	}
+}
+
+func TestStore_FinalizedBlockRootsInRange_ForkEdgeCase(t *testing.T) {
+	slotsPerEpoch := int(params.BeaconConfig().SlotsPerEpoch)
+	blocks0 := makeBlocks(t, 0, slotsPerEpoch, genesisBlockRoot)
+	// The first block of epoch 1 is finalized but orphaned by the rest of the epoch.
+	blocks1 := append(
+		makeBlocks(t, slotsPerEpoch, 1, bytesutil.ToBytes32(sszRootOrDie(t, blocks0[len(blocks0)-1]))),
+		makeBlocks(t, slotsPerEpoch+1, slotsPerEpoch-1, bytesutil.ToBytes32(sszRootOrDie(t, blocks0[len(blocks0)-1])))...,
+	)
+	blocks2 := makeBlocks(t, slotsPerEpoch*2, slotsPerEpoch, bytesutil.ToBytes32(sszRootOrDie(t, blocks1[len(blocks1)-1])))
+
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	if err := db.SaveGenesisBlockRoot(ctx, genesisBlockRoot); err != nil {
+		t.Fatal(err)
+	}
+	for _, blks := range [][]*ethpb.SignedBeaconBlock{blocks0, blocks1, blocks2} {
+		if err := db.SaveBlocks(ctx, blks); err != nil {
+			t.Fatal(err)
+		}
+	}
+	st := testutil.NewBeaconState()
+	for i, cp := range []*ethpb.Checkpoint{
+		{Epoch: 1, Root: sszRootOrDie(t, blocks1[0])},
+		{Epoch: 2, Root: sszRootOrDie(t, blocks2[0])},
+	} {
+		if err := db.SaveState(ctx, st, bytesutil.ToBytes32(cp.Root)); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+			t.Fatalf("Checkpoint %d: %v", i, err)
+		}
+	}
+
+	orphanedSlot := blocks1[0].Block.Slot
+	roots, err := db.FinalizedBlockRootsInRange(ctx, orphanedSlot, orphanedSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roots) != 0 {
+		t.Errorf("Expected orphaned block at slot %d to be removed from the index, received %#x", orphanedSlot, roots)
+	}
+	roots, err = db.FinalizedBlockRootsInRange(ctx, blocks1[1].Block.Slot, blocks2[0].Block.Slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roots) != slotsPerEpoch {
+		t.Errorf("Wanted %d canonical roots, received %d", slotsPerEpoch, len(roots))
+	}
+}
+
+func TestStore_IndexFinalizedSlotRoots_Migration(t *testing.T) {
+	slotsPerEpoch := int(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	if err := db.SaveGenesisBlockRoot(ctx, genesisBlockRoot); err != nil {
+		t.Fatal(err)
+	}
+	blks := makeBlocks(t, 0, slotsPerEpoch*2, genesisBlockRoot)
+	if err := db.SaveBlocks(ctx, blks); err != nil {
+		t.Fatal(err)
+	}
+	cp := &ethpb.Checkpoint{Epoch: 1, Root: sszRootOrDie(t, blks[slotsPerEpoch])}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), bytesutil.ToBytes32(cp.Root)); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		t.Fatal(err)
+	}
+	want, err := db.FinalizedBlockRootsInRange(ctx, 0, uint64(slotsPerEpoch*2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Drop the index, as in a database created before it existed.
+	if err := db.db.Update(func(tx kvTx) error {
+		return deindexFinalizedSlotRoots(tx, 0)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := indexFinalizedSlotRoots(ctx, db.db); err != nil {
+		t.Fatal(err)
+	}
+	roots, err := db.FinalizedBlockRootsInRange(ctx, 0, uint64(slotsPerEpoch*2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Genesis is not indexed by the migration as its block is not stored in this test.
+	if !reflect.DeepEqual(roots, want[1:]) {
+		t.Errorf("Wanted %d indexed roots after migration, received %d", len(want)-1, len(roots))
+	}
+}
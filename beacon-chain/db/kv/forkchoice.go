@@ -0,0 +1,43 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"go.opencensus.io/trace"
+)
+
+// SaveForkChoiceCheckpoint saves the snapshot of the fork choice store, replacing the previous one.
+func (k *Store) SaveForkChoiceCheckpoint(ctx context.Context, checkpoint *db.ForkChoiceCheckpoint) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveForkChoiceCheckpoint")
+	defer span.End()
+
+	enc, err := proto.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return k.db.Update(func(tx kvTx) error {
+		bkt := tx.Bucket(chainMetadataBucket)
+		return bkt.Put(forkChoiceCheckpointKey, enc)
+	})
+}
+
+// ForkChoiceCheckpoint retrieves the last saved snapshot of the fork choice store, or nil if none
+// has been saved.
+func (k *Store) ForkChoiceCheckpoint(ctx context.Context) (*db.ForkChoiceCheckpoint, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForkChoiceCheckpoint")
+	defer span.End()
+
+	var checkpoint *db.ForkChoiceCheckpoint
+	err := k.db.View(func(tx kvTx) error {
+		bkt := tx.Bucket(chainMetadataBucket)
+		enc := bkt.Get(forkChoiceCheckpointKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		checkpoint = &db.ForkChoiceCheckpoint{}
+		return proto.Unmarshal(enc, checkpoint)
+	})
+	return checkpoint, err
+}
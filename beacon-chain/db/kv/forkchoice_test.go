@@ -0,0 +1,44 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/proto/beacon/db"
+)
+
+func TestStore_ForkChoiceCheckpoint_CanSaveRetrieve(t *testing.T) {
+	store := setupDB(t)
+	defer teardownDB(t, store)
+	ctx := context.Background()
+
+	retrieved, err := store.ForkChoiceCheckpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved != nil {
+		t.Errorf("Expected nil checkpoint, received %v", retrieved)
+	}
+
+	checkpoint := &db.ForkChoiceCheckpoint{
+		JustifiedEpoch: 2,
+		FinalizedEpoch: 1,
+		FinalizedRoot:  []byte{'A'},
+		Nodes: []*db.ForkChoiceNode{
+			{Slot: 32, Root: []byte{'A'}, JustifiedEpoch: 1, FinalizedEpoch: 1},
+			{Slot: 33, Root: []byte{'B'}, ParentRoot: []byte{'A'}, JustifiedEpoch: 1, FinalizedEpoch: 1},
+		},
+		Votes: []*db.ForkChoiceVote{{Root: []byte{'B'}, Epoch: 1}},
+	}
+	if err := store.SaveForkChoiceCheckpoint(ctx, checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	retrieved, err = store.ForkChoiceCheckpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(checkpoint, retrieved) {
+		t.Errorf("Wanted %v, received %v", checkpoint, retrieved)
+	}
+}
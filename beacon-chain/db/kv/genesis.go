@@ -0,0 +1,91 @@
+package kv
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// SaveGenesisData saves the genesis state and the genesis block built on top of it, and
+// sets the genesis block as the head, justified and finalized block of the chain.
+func (k *Store) SaveGenesisData(ctx context.Context, genesisState *state.BeaconState) error {
+	stateRoot, err := genesisState.HashTreeRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis state root")
+	}
+	genesisBlk := blocks.NewGenesisBlock(stateRoot[:])
+	genesisBlkRoot, err := ssz.HashTreeRoot(genesisBlk.Block)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block root")
+	}
+	if err := k.SaveBlock(ctx, genesisBlk); err != nil {
+		return errors.Wrap(err, "could not save genesis block")
+	}
+	if err := k.SaveStateSummary(ctx, &pb.StateSummary{
+		Slot: 0,
+		Root: genesisBlkRoot[:],
+	}); err != nil {
+		return errors.Wrap(err, "could not save genesis state summary")
+	}
+	if err := k.SaveState(ctx, genesisState, genesisBlkRoot); err != nil {
+		return errors.Wrap(err, "could not save genesis state")
+	}
+	if err := k.SaveHeadBlockRoot(ctx, genesisBlkRoot); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	if err := k.SaveGenesisBlockRoot(ctx, genesisBlkRoot); err != nil {
+		return errors.Wrap(err, "could not save genesis block root")
+	}
+	genesisCheckpoint := &ethpb.Checkpoint{Root: genesisBlkRoot[:]}
+	if err := k.SaveJustifiedCheckpoint(ctx, genesisCheckpoint); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := k.SaveFinalizedCheckpoint(ctx, genesisCheckpoint); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	return nil
+}
+
+// LoadGenesis initializes the database with the SSZ encoded genesis state read from r. If
+// the database already has a genesis state, it must match the one being loaded.
+func (k *Store) LoadGenesis(ctx context.Context, r io.Reader) error {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Wrap(err, "could not read genesis state")
+	}
+	st := &pb.BeaconState{}
+	if err := ssz.Unmarshal(b, st); err != nil {
+		return errors.Wrap(err, "could not unmarshal genesis state")
+	}
+	genesisState, err := state.InitializeFromProtoUnsafe(st)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize genesis state")
+	}
+
+	existing, err := k.GenesisState(ctx)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		existingRoot, err := existing.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		genesisRoot, err := genesisState.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		if existingRoot != genesisRoot {
+			return errors.New("genesis state in database does not match the provided genesis state")
+		}
+		return nil
+	}
+	return k.SaveGenesisData(ctx, genesisState)
+}
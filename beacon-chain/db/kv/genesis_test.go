@@ -0,0 +1,92 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestStore_LoadGenesis(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	st := testutil.NewBeaconState()
+	if err := st.SetGenesisTime(1000); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := ssz.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.LoadGenesis(ctx, bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+
+	genesisState, err := db.GenesisState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if genesisState == nil || genesisState.GenesisTime() != 1000 {
+		t.Fatal("Expected genesis state to be saved")
+	}
+	headBlock, err := db.HeadBlock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisBlock, err := db.GenesisBlock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headBlock == nil || genesisBlock == nil || !proto.Equal(headBlock, genesisBlock) {
+		t.Error("Expected genesis block to be the head block")
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(genesisBlock.Block.StateRoot, stateRoot[:]) {
+		t.Errorf("Wanted genesis block state root %#x, received %#x", stateRoot, genesisBlock.Block.StateRoot)
+	}
+	finalized, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesisBlock.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(finalized.Root, genesisRoot[:]) {
+		t.Errorf("Wanted finalized root %#x, received %#x", genesisRoot, finalized.Root)
+	}
+
+	// Loading the same genesis state again is a no-op.
+	if err := db.LoadGenesis(ctx, bytes.NewReader(enc)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStore_LoadGenesis_MismatchedState(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	st := testutil.NewBeaconState()
+	if err := db.SaveGenesisData(ctx, st); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SetGenesisTime(1000); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := ssz.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.LoadGenesis(ctx, bytes.NewReader(enc)); err == nil {
+		t.Error("Expected error loading a genesis state which does not match the database")
+	}
+}
@@ -0,0 +1,183 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// BucketStats describes the number of keys and the space used by a top level bucket.
+// Keys includes the keys of nested buckets, InUse is the number of bytes used by keys
+// and values and Allocated is the number of bytes of the pages allocated to the bucket.
+type BucketStats struct {
+	Name      string
+	Keys      int
+	InUse     int
+	Allocated int
+}
+
+// InspectionReport describes the database file, its schema version and its buckets.
+type InspectionReport struct {
+	FileSize      int64
+	SchemaVersion uint64
+	Buckets       []*BucketStats
+}
+
+// Inspect reports the size of the beacon chain database in the directory path specified
+// and the number of keys and space used by each of its buckets, sorted by name. The
+// database is opened read only. No space is reported as allocated to the buckets of a
+// Pebble database, whose files are shared by all buckets.
+func Inspect(dirPath string) (*InspectionReport, error) {
+	if fileExists(path.Join(dirPath, pebbleDirName)) {
+		return inspectPebble(dirPath)
+	}
+	datafile := path.Join(dirPath, databaseFileName)
+	info, err := os.Stat(datafile)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat database file")
+	}
+	report := &InspectionReport{FileSize: info.Size()}
+
+	db, err := openReadOnly(datafile)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	err = db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(schemaVersionBucket); b != nil {
+			if enc := b.Get(schemaVersionKey); enc != nil {
+				report.SchemaVersion = binary.LittleEndian.Uint64(enc)
+			}
+		}
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			stats := b.Stats()
+			report.Buckets = append(report.Buckets, &BucketStats{
+				Name:      string(name),
+				Keys:      stats.KeyN,
+				InUse:     stats.BranchInuse + stats.LeafInuse,
+				Allocated: stats.BranchAlloc + stats.LeafAlloc,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(report.Buckets, func(i, j int) bool {
+		return report.Buckets[i].Name < report.Buckets[j].Name
+	})
+	return report, nil
+}
+
+// DumpKeys calls fn for the keys with the given prefix in the top level bucket of the
+// beacon chain database in the directory path specified, in key order, stopping after
+// limit keys if limit is positive. Nested buckets are passed with a nil value. The
+// database is opened read only.
+func DumpKeys(dirPath string, bucket string, prefix []byte, limit int, fn func(k, v []byte) error) error {
+	db, err := openReadOnlyEngine(dirPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	return db.View(func(tx kvTx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return fmt.Errorf("no bucket named %q", bucket)
+		}
+		c := b.Cursor()
+		count := 0
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if limit > 0 && count >= limit {
+				return nil
+			}
+			if err := fn(k, v); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+}
+
+func inspectPebble(dirPath string) (*InspectionReport, error) {
+	size, err := dirSize(path.Join(dirPath, pebbleDirName))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not stat database directory")
+	}
+	report := &InspectionReport{FileSize: size}
+
+	db, err := openPebble(dirPath, true /* readOnly */)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+	err = db.View(func(tx kvTx) error {
+		if b := tx.Bucket(schemaVersionBucket); b != nil {
+			if enc := b.Get(schemaVersionKey); enc != nil {
+				report.SchemaVersion = binary.LittleEndian.Uint64(enc)
+			}
+		}
+		names, err := tx.(*pebbleTx).bucketNames()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			stats := &BucketStats{Name: name}
+			if err := tx.Bucket([]byte(name)).ForEach(func(k, v []byte) error {
+				stats.Keys++
+				stats.InUse += len(k) + len(v)
+				return nil
+			}); err != nil {
+				return err
+			}
+			report.Buckets = append(report.Buckets, stats)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// openReadOnlyEngine opens the database in the directory path specified for reading with
+// the engine it was created with.
+func openReadOnlyEngine(dirPath string) (kvEngine, error) {
+	if fileExists(path.Join(dirPath, pebbleDirName)) {
+		return openPebble(dirPath, true /* readOnly */)
+	}
+	db, err := openReadOnly(path.Join(dirPath, databaseFileName))
+	if err != nil {
+		return nil, err
+	}
+	return &boltEngine{db: db}, nil
+}
+
+// openReadOnly opens the bolt database file for reading, failing if another process
+// holds the lock on the file.
+func openReadOnly(datafile string) (*bolt.DB, error) {
+	if _, err := os.Stat(datafile); err != nil {
+		return nil, errors.Wrap(err, "could not stat database file")
+	}
+	db, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return nil, err
+	}
+	return db, nil
+}
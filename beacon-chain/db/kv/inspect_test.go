@@ -0,0 +1,100 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+)
+
+func TestInspect_ReportsBuckets(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	for i := uint64(1); i <= 10; i++ {
+		blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: i}}
+		if err := db.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	version, err := db.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Inspect(db.DatabasePath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.SchemaVersion != version {
+		t.Errorf("Wanted schema version %d, received %d", version, report.SchemaVersion)
+	}
+	if report.FileSize == 0 {
+		t.Error("Expected non-zero file size")
+	}
+	var blocks *BucketStats
+	for _, b := range report.Buckets {
+		if b.Name == string(blocksBucket) {
+			blocks = b
+		}
+	}
+	if blocks == nil {
+		t.Fatal("Expected blocks bucket in inspection report")
+	}
+	if blocks.Keys != 10 {
+		t.Errorf("Wanted 10 keys in blocks bucket, received %d", blocks.Keys)
+	}
+	if blocks.InUse == 0 || blocks.Allocated < blocks.InUse {
+		t.Errorf("Unexpected blocks bucket sizes, in use %d allocated %d", blocks.InUse, blocks.Allocated)
+	}
+
+	db, err = NewKVStore(db.DatabasePath(), cache.NewStateSummaryCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	teardownDB(t, db)
+}
+
+func TestDumpKeys_Prefix(t *testing.T) {
+	db := setupDB(t)
+	if err := db.db.Update(func(tx kvTx) error {
+		b := tx.Bucket(chainMetadataBucket)
+		for _, k := range []string{"aa1", "aa2", "aa3", "ab1"} {
+			if err := b.Put([]byte(k), []byte("value")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys [][]byte
+	collect := func(k, v []byte) error {
+		keys = append(keys, append([]byte{}, k...))
+		return nil
+	}
+	if err := DumpKeys(db.DatabasePath(), string(chainMetadataBucket), []byte("aa"), 2, collect); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 || !bytes.Equal(keys[0], []byte("aa1")) || !bytes.Equal(keys[1], []byte("aa2")) {
+		t.Errorf("Unexpected keys %q", keys)
+	}
+	if err := DumpKeys(db.DatabasePath(), "missing", nil, 0, collect); err == nil {
+		t.Error("Expected error for missing bucket")
+	}
+
+	db, err := NewKVStore(db.DatabasePath(), cache.NewStateSummaryCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+	teardownDB(t, db)
+}
@@ -1,20 +1,18 @@
-// Package kv defines a bolt-db, key-value store implementation
-// of the Database interface defined by a Prysm beacon node.
+// Package kv defines a key-value store implementation of the Database
+// interface defined by a Prysm beacon node, backed by BoltDB or Pebble.
 package kv
 
 import (
+	"context"
 	"os"
 	"path"
 	"sync"
-	"time"
 
 	"github.com/dgraph-io/ristretto"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus"
-	prombolt "github.com/prysmaticlabs/prombbolt"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/iface"
-	bolt "go.etcd.io/bbolt"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 )
 
 var _ = iface.Database(&Store{})
@@ -25,6 +23,7 @@ const (
 	// NumOfVotes specifies the vote cache size.
 	NumOfVotes       = 1 << 20
 	databaseFileName = "beaconchain.db"
+	pebbleDirName    = "beaconchain.pebble"
 	boltAllocSize    = 8 * 1024 * 1024
 )
 
@@ -33,9 +32,9 @@ const (
 var BlockCacheSize = int64(1 << 21)
 
 // Store defines an implementation of the Prysm Database interface
-// using BoltDB as the underlying persistent kv-store for eth2.
+// using BoltDB, or Pebble if enabled, as the underlying persistent kv-store for eth2.
 type Store struct {
-	db                  *bolt.DB
+	db                  kvEngine
 	databasePath        string
 	blockCache          *ristretto.Cache
 	validatorIndexCache *ristretto.Cache
@@ -44,22 +43,19 @@ type Store struct {
 	stateSummaryCache   *cache.StateSummaryCache
 }
 
-// NewKVStore initializes a new boltDB key-value store at the directory
-// path specified, creates the kv-buckets based on the schema, and stores
+// NewKVStore initializes a new key-value store at the directory path
+// specified, creates the kv-buckets based on the schema, and stores
 // an open connection db object as a property of the Store struct.
+// The store is kept in a BoltDB file, or in a Pebble database if the
+// --enable-pebble-db feature flag is set.
 func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*Store, error) {
 	if err := os.MkdirAll(dirPath, 0700); err != nil {
 		return nil, err
 	}
-	datafile := path.Join(dirPath, databaseFileName)
-	boltDB, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second, InitialMmapSize: 10e6})
+	db, err := openEngine(dirPath)
 	if err != nil {
-		if err == bolt.ErrTimeout {
-			return nil, errors.New("cannot obtain database lock, database may be in use by another process")
-		}
 		return nil, err
 	}
-	boltDB.AllocSize = boltAllocSize
 	blockCache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1000,           // number of keys to track frequency of (1000).
 		MaxCost:     BlockCacheSize, // maximum cost of cache (1000 Blocks).
@@ -79,14 +75,14 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 	}
 
 	kv := &Store{
-		db:                  boltDB,
+		db:                  db,
 		databasePath:        dirPath,
 		blockCache:          blockCache,
 		validatorIndexCache: validatorCache,
 		stateSummaryCache:   stateSummaryCache,
 	}
 
-	if err := kv.db.Update(func(tx *bolt.Tx) error {
+	if err := kv.db.Update(func(tx kvTx) error {
 		return createBuckets(
 			tx,
 			attestationsBucket,
@@ -95,6 +91,7 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 			proposerSlashingsBucket,
 			attesterSlashingsBucket,
 			voluntaryExitsBucket,
+			pendingVoluntaryExitsBucket,
 			chainMetadataBucket,
 			checkpointBucket,
 			archivedValidatorSetChangesBucket,
@@ -105,6 +102,10 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 			stateSummaryBucket,
 			archivedIndexRootBucket,
 			slotsHasObjectBucket,
+			schemaVersionBucket,
+			lightClientFinalityUpdatesBucket,
+			stateDiffsBucket,
+			peersBucket,
 			// Indices buckets.
 			attestationHeadBlockRootBucket,
 			attestationSourceRootIndicesBucket,
@@ -114,6 +115,7 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 			blockSlotIndicesBucket,
 			blockParentRootIndicesBucket,
 			finalizedBlockRootsIndexBucket,
+			finalizedSlotRootsIndexBucket,
 			// New State Management service bucket.
 			newStateServiceCompatibleBucket,
 		)
@@ -121,9 +123,34 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 		return nil, err
 	}
 
-	err = prometheus.Register(createBoltCollector(kv.db))
+	if err := kv.runMigrations(context.Background(), migrations); err != nil {
+		return nil, err
+	}
+
+	return kv, nil
+}
+
+// openEngine opens the storage engine selected by the feature flags. A database created
+// with the other engine is not converted, and an error is returned instead so the node
+// does not silently start from an empty database.
+func openEngine(dirPath string) (kvEngine, error) {
+	boltExists := fileExists(path.Join(dirPath, databaseFileName))
+	pebbleExists := fileExists(path.Join(dirPath, pebbleDirName))
+	if featureconfig.Get().EnablePebbleDB {
+		if boltExists {
+			return nil, errors.Errorf("database in %s was created with BoltDB, run without --enable-pebble-db or clear the database", dirPath)
+		}
+		return openPebble(dirPath, false /* readOnly */)
+	}
+	if pebbleExists {
+		return nil, errors.Errorf("database in %s was created with Pebble, run with --enable-pebble-db or clear the database", dirPath)
+	}
+	return openBolt(dirPath)
+}
 
-	return kv, err
+func fileExists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
 }
 
 // ClearDB removes the previously stored database in the data directory.
@@ -131,13 +158,11 @@ func (k *Store) ClearDB() error {
 	if _, err := os.Stat(k.databasePath); os.IsNotExist(err) {
 		return nil
 	}
-	prometheus.Unregister(createBoltCollector(k.db))
-	return os.Remove(path.Join(k.databasePath, databaseFileName))
+	return k.db.Remove()
 }
 
-// Close closes the underlying BoltDB database.
+// Close closes the underlying database.
 func (k *Store) Close() error {
-	prometheus.Unregister(createBoltCollector(k.db))
 	return k.db.Close()
 }
 
@@ -146,7 +171,7 @@ func (k *Store) DatabasePath() string {
 	return k.databasePath
 }
 
-func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
+func createBuckets(tx kvTx, buckets ...[]byte) error {
 	for _, bucket := range buckets {
 		if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
 			return err
@@ -154,8 +179,3 @@ func createBuckets(tx *bolt.Tx, buckets ...[]byte) error {
 	}
 	return nil
 }
-
-// createBoltCollector returns a prometheus collector specifically configured for boltdb.
-func createBoltCollector(db *bolt.DB) prometheus.Collector {
-	return prombolt.New("boltDB", db)
-}
@@ -0,0 +1,73 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"go.opencensus.io/trace"
+)
+
+// lightClientUpdateKey encodes the epoch big endian so updates are iterated in epoch order.
+func lightClientUpdateKey(epoch uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, epoch)
+	return key
+}
+
+// SaveLightClientFinalityUpdate saves the light client finality update of its finalized epoch.
+func (k *Store) SaveLightClientFinalityUpdate(ctx context.Context, update *pb.LightClientFinalityUpdate) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientFinalityUpdate")
+	defer span.End()
+
+	enc, err := encode(update)
+	if err != nil {
+		return err
+	}
+	return k.db.Update(func(tx kvTx) error {
+		bkt := tx.Bucket(lightClientFinalityUpdatesBucket)
+		return bkt.Put(lightClientUpdateKey(update.FinalizedEpoch), enc)
+	})
+}
+
+// LatestLightClientFinalityUpdate retrieves the light client finality update with the highest
+// finalized epoch, or nil if none has been saved.
+func (k *Store) LatestLightClientFinalityUpdate(ctx context.Context) (*pb.LightClientFinalityUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LatestLightClientFinalityUpdate")
+	defer span.End()
+
+	var update *pb.LightClientFinalityUpdate
+	err := k.db.View(func(tx kvTx) error {
+		_, enc := tx.Bucket(lightClientFinalityUpdatesBucket).Cursor().Last()
+		if enc == nil {
+			return nil
+		}
+		update = &pb.LightClientFinalityUpdate{}
+		return decode(enc, update)
+	})
+	return update, err
+}
+
+// LightClientFinalityUpdates retrieves the light client finality updates with finalized epochs
+// between the start and end epochs, inclusive, in epoch order.
+func (k *Store) LightClientFinalityUpdates(ctx context.Context, startEpoch uint64, endEpoch uint64) ([]*pb.LightClientFinalityUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientFinalityUpdates")
+	defer span.End()
+
+	updates := make([]*pb.LightClientFinalityUpdate, 0)
+	err := k.db.View(func(tx kvTx) error {
+		c := tx.Bucket(lightClientFinalityUpdatesBucket).Cursor()
+		for key, enc := c.Seek(lightClientUpdateKey(startEpoch)); key != nil; key, enc = c.Next() {
+			if binary.BigEndian.Uint64(key) > endEpoch {
+				break
+			}
+			update := &pb.LightClientFinalityUpdate{}
+			if err := decode(enc, update); err != nil {
+				return err
+			}
+			updates = append(updates, update)
+		}
+		return nil
+	})
+	return updates, err
+}
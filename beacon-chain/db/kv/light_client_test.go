@@ -0,0 +1,57 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestStore_LightClientFinalityUpdates_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	latest, err := db.LatestLightClientFinalityUpdate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest != nil {
+		t.Errorf("Expected no update, received %v", latest)
+	}
+
+	updates := make([]*pb.LightClientFinalityUpdate, 0)
+	for _, epoch := range []uint64{3, 1, 256, 2} {
+		update := &pb.LightClientFinalityUpdate{
+			AttestedHeader:  &ethpb.BeaconBlockHeader{Slot: (epoch + 2) * 32},
+			FinalizedHeader: &ethpb.BeaconBlockHeader{Slot: epoch * 32},
+			FinalityBranch:  [][]byte{{'a'}, {'b'}},
+			FinalizedEpoch:  epoch,
+		}
+		if err := db.SaveLightClientFinalityUpdate(ctx, update); err != nil {
+			t.Fatal(err)
+		}
+		updates = append(updates, update)
+	}
+
+	latest, err = db.LatestLightClientFinalityUpdate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(latest, updates[2]) {
+		t.Errorf("Wanted latest update %v, received %v", updates[2], latest)
+	}
+
+	retrieved, err := db.LightClientFinalityUpdates(ctx, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(retrieved) != 2 {
+		t.Fatalf("Wanted 2 updates, received %d", len(retrieved))
+	}
+	if !proto.Equal(retrieved[0], updates[3]) || !proto.Equal(retrieved[1], updates[0]) {
+		t.Errorf("Received updates out of epoch order: %v", retrieved)
+	}
+}
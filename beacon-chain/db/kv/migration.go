@@ -0,0 +1,97 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// migration changes the way data is stored in the database. Migrations are applied in
+// order at startup and the schema version is advanced after each one completes, so an
+// interrupted migration is run again on the next startup. Migrations iterating over
+// large buckets should commit their progress in batches and must be safe to rerun.
+type migration struct {
+	name string
+	fn   func(ctx context.Context, db kvEngine) error
+}
+
+// migrations applied to the database, in order. The schema version of a database is the
+// number of migrations applied to it. Never reorder or remove entries, only append.
+var migrations = []migration{
+	{name: "index finalized block roots by slot", fn: indexFinalizedSlotRoots},
+}
+
+// SchemaVersion returns the number of migrations applied to the database.
+func (k *Store) SchemaVersion(ctx context.Context) (uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SchemaVersion")
+	defer span.End()
+
+	var version uint64
+	err := k.db.View(func(tx kvTx) error {
+		enc := tx.Bucket(schemaVersionBucket).Get(schemaVersionKey)
+		if enc != nil {
+			version = binary.LittleEndian.Uint64(enc)
+		}
+		return nil
+	})
+	return version, err
+}
+
+func (k *Store) saveSchemaVersion(version uint64) error {
+	return k.db.Update(func(tx kvTx) error {
+		return tx.Bucket(schemaVersionBucket).Put(schemaVersionKey, bytesutil.Uint64ToBytes(version))
+	})
+}
+
+// runMigrations applies the migrations which have not been applied to the database yet.
+func (k *Store) runMigrations(ctx context.Context, migrations []migration) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.runMigrations")
+	defer span.End()
+
+	version, err := k.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	latest := uint64(len(migrations))
+	if version > latest {
+		return fmt.Errorf(
+			"database schema version %d is newer than the latest version %d supported by this release, please upgrade",
+			version,
+			latest,
+		)
+	}
+	if version == latest {
+		return nil
+	}
+
+	log := logrus.WithField("prefix", "db")
+	log.WithFields(logrus.Fields{
+		"currentVersion": version,
+		"latestVersion":  latest,
+	}).Info("Migrating database, this may take a while")
+	for i := version; i < latest; i++ {
+		m := migrations[i]
+		start := time.Now()
+		log.WithFields(logrus.Fields{
+			"migration": m.name,
+			"step":      fmt.Sprintf("%d/%d", i+1, latest),
+		}).Info("Applying database migration")
+		if err := m.fn(ctx, k.db); err != nil {
+			return errors.Wrapf(err, "could not apply database migration %s, it will be retried on restart", m.name)
+		}
+		if err := k.saveSchemaVersion(i + 1); err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"migration": m.name,
+			"duration":  time.Since(start),
+		}).Info("Applied database migration")
+	}
+	return nil
+}
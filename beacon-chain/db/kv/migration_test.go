@@ -0,0 +1,109 @@
+package kv
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStore_RunMigrations(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	// Start from a database which has no migrations applied.
+	if err := db.saveSchemaVersion(0); err != nil {
+		t.Fatal(err)
+	}
+
+	applied := make([]string, 0)
+	newMigration := func(name string) migration {
+		return migration{name: name, fn: func(_ context.Context, _ kvEngine) error {
+			applied = append(applied, name)
+			return nil
+		}}
+	}
+	ms := []migration{newMigration("a"), newMigration("b")}
+	if err := db.runMigrations(ctx, ms); err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 || applied[0] != "a" || applied[1] != "b" {
+		t.Errorf("Expected migrations to be applied in order, applied %v", applied)
+	}
+	version, err := db.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 2 {
+		t.Errorf("Wanted schema version 2, received %d", version)
+	}
+
+	// Only the newly appended migration is applied.
+	applied = applied[:0]
+	ms = append(ms, newMigration("c"))
+	if err := db.runMigrations(ctx, ms); err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0] != "c" {
+		t.Errorf("Expected only new migration to be applied, applied %v", applied)
+	}
+}
+
+func TestStore_RunMigrations_ResumesAfterFailure(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	// Start from a database which has no migrations applied.
+	if err := db.saveSchemaVersion(0); err != nil {
+		t.Fatal(err)
+	}
+
+	fail := true
+	runs := 0
+	ms := []migration{
+		{name: "ok", fn: func(_ context.Context, _ kvEngine) error { return nil }},
+		{name: "flaky", fn: func(_ context.Context, _ kvEngine) error {
+			runs++
+			if fail {
+				return errors.New("interrupted")
+			}
+			return nil
+		}},
+	}
+	if err := db.runMigrations(ctx, ms); err == nil {
+		t.Fatal("Expected migration failure")
+	}
+	version, err := db.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 1 {
+		t.Errorf("Wanted schema version 1 after failed migration, received %d", version)
+	}
+
+	fail = false
+	if err := db.runMigrations(ctx, ms); err != nil {
+		t.Fatal(err)
+	}
+	if runs != 2 {
+		t.Errorf("Expected failed migration to be retried, ran %d times", runs)
+	}
+	version, err = db.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 2 {
+		t.Errorf("Wanted schema version 2, received %d", version)
+	}
+}
+
+func TestStore_RunMigrations_NewerSchemaVersion(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+
+	if err := db.saveSchemaVersion(uint64(len(migrations) + 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.runMigrations(context.Background(), migrations); err == nil {
+		t.Error("Expected error running an older release against a newer database")
+	}
+}
@@ -5,7 +5,7 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
-	bolt "go.etcd.io/bbolt"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"go.opencensus.io/trace"
 )
 
@@ -14,7 +14,7 @@ func (k *Store) VoluntaryExit(ctx context.Context, exitRoot [32]byte) (*ethpb.Vo
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.VoluntaryExit")
 	defer span.End()
 	var exit *ethpb.VoluntaryExit
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(voluntaryExitsBucket)
 		enc := bkt.Get(exitRoot[:])
 		if enc == nil {
@@ -31,7 +31,7 @@ func (k *Store) HasVoluntaryExit(ctx context.Context, exitRoot [32]byte) bool {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasVoluntaryExit")
 	defer span.End()
 	exists := false
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(voluntaryExitsBucket)
 		exists = bkt.Get(exitRoot[:]) != nil
 		return nil
@@ -53,7 +53,7 @@ func (k *Store) SaveVoluntaryExit(ctx context.Context, exit *ethpb.VoluntaryExit
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(voluntaryExitsBucket)
 		return bucket.Put(exitRoot[:], enc)
 	})
@@ -63,8 +63,51 @@ func (k *Store) SaveVoluntaryExit(ctx context.Context, exit *ethpb.VoluntaryExit
 func (k *Store) DeleteVoluntaryExit(ctx context.Context, exitRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteVoluntaryExit")
 	defer span.End()
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(voluntaryExitsBucket)
 		return bucket.Delete(exitRoot[:])
 	})
 }
+
+// PendingVoluntaryExits retrieves the signed voluntary exits waiting to be included in a block.
+func (k *Store) PendingVoluntaryExits(ctx context.Context) ([]*ethpb.SignedVoluntaryExit, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.PendingVoluntaryExits")
+	defer span.End()
+	exits := make([]*ethpb.SignedVoluntaryExit, 0)
+	err := k.db.View(func(tx kvTx) error {
+		return tx.Bucket(pendingVoluntaryExitsBucket).ForEach(func(k, enc []byte) error {
+			exit := &ethpb.SignedVoluntaryExit{}
+			if err := decode(enc, exit); err != nil {
+				return err
+			}
+			exits = append(exits, exit)
+			return nil
+		})
+	})
+	return exits, err
+}
+
+// SavePendingVoluntaryExit saves a signed voluntary exit waiting to be included in a block,
+// keyed by the index of the exiting validator.
+func (k *Store) SavePendingVoluntaryExit(ctx context.Context, exit *ethpb.SignedVoluntaryExit) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePendingVoluntaryExit")
+	defer span.End()
+	enc, err := encode(exit)
+	if err != nil {
+		return err
+	}
+	return k.db.Update(func(tx kvTx) error {
+		bucket := tx.Bucket(pendingVoluntaryExitsBucket)
+		return bucket.Put(bytesutil.Uint64ToBytes(exit.Exit.ValidatorIndex), enc)
+	})
+}
+
+// DeletePendingVoluntaryExit clears the pending voluntary exit of a validator from the db.
+func (k *Store) DeletePendingVoluntaryExit(ctx context.Context, validatorIndex uint64) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeletePendingVoluntaryExit")
+	defer span.End()
+	return k.db.Update(func(tx kvTx) error {
+		bucket := tx.Bucket(pendingVoluntaryExitsBucket)
+		return bucket.Delete(bytesutil.Uint64ToBytes(validatorIndex))
+	})
+}
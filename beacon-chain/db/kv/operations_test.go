@@ -47,3 +47,36 @@ func TestStore_VoluntaryExits_CRUD(t *testing.T) {
 		t.Error("Expected voluntary exit to have been deleted from the db")
 	}
 }
+
+func TestStore_PendingVoluntaryExits_CRUD(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	exit := &ethpb.SignedVoluntaryExit{
+		Exit: &ethpb.VoluntaryExit{
+			Epoch:          5,
+			ValidatorIndex: 10,
+		},
+		Signature: make([]byte, 96),
+	}
+	if err := db.SavePendingVoluntaryExit(ctx, exit); err != nil {
+		t.Fatal(err)
+	}
+	exits, err := db.PendingVoluntaryExits(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exits) != 1 || !proto.Equal(exit, exits[0]) {
+		t.Errorf("Wanted [%v], received %v", exit, exits)
+	}
+	if err := db.DeletePendingVoluntaryExit(ctx, exit.Exit.ValidatorIndex); err != nil {
+		t.Fatal(err)
+	}
+	exits, err = db.PendingVoluntaryExits(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exits) != 0 {
+		t.Errorf("Expected pending voluntary exit to have been deleted from the db, received %v", exits)
+	}
+}
@@ -0,0 +1,425 @@
+package kv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+)
+
+// pebbleBackupBatchSize is the number of key and value bytes written per batch while
+// copying a Pebble database to a backup.
+const pebbleBackupBatchSize = 4 * 1024 * 1024
+
+// Keys of the Pebble database start with one of these tags. Buckets are flattened into
+// a single keyspace: the existence of a bucket is recorded under its bucket key, and
+// the keys of the bucket are stored after a prefix holding the length and name of the
+// bucket, so keys of a bucket are contiguous and sorted as they are in BoltDB.
+const (
+	pebbleBucketTag byte = iota
+	pebbleEntryTag
+)
+
+// pebbleEngine stores the database in Pebble, a log-structured merge tree, which writes
+// large databases with less amplification and compacts them in the background rather
+// than by rewriting the whole file.
+type pebbleEngine struct {
+	db  *pebble.DB
+	dir string
+	// updateLock serializes read-write transactions. Each reads its own writes through an
+	// indexed batch, and would not see the writes of concurrent transactions otherwise.
+	updateLock sync.Mutex
+	closeOnce  sync.Once
+	closeErr   error
+}
+
+func openPebble(dirPath string, readOnly bool) (*pebbleEngine, error) {
+	dir := path.Join(dirPath, pebbleDirName)
+	db, err := pebble.Open(dir, &pebble.Options{ReadOnly: readOnly})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open pebble database")
+	}
+	return &pebbleEngine{db: db, dir: dir}, nil
+}
+
+func (e *pebbleEngine) View(fn func(tx kvTx) error) error {
+	snap := e.db.NewSnapshot()
+	tx := &pebbleTx{reader: snap}
+	err := fn(tx)
+	if closeErr := tx.close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := snap.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (e *pebbleEngine) Update(fn func(tx kvTx) error) error {
+	e.updateLock.Lock()
+	defer e.updateLock.Unlock()
+
+	batch := e.db.NewIndexedBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	tx := &pebbleTx{reader: batch, batch: batch}
+	err := fn(tx)
+	if closeErr := tx.close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return batch.Commit(pebble.Sync)
+}
+
+// Backup copies every key of a snapshot of the database into a new Pebble database at the
+// given path.
+func (e *pebbleEngine) Backup(backupPath string) error {
+	if _, err := os.Stat(backupPath); err == nil {
+		return errors.Errorf("backup %s already exists", backupPath)
+	}
+	dst, err := pebble.Open(backupPath, &pebble.Options{})
+	if err != nil {
+		return errors.Wrap(err, "could not create backup database")
+	}
+	snap := e.db.NewSnapshot()
+	iter := snap.NewIter(nil)
+	copyErr := func() error {
+		batch := dst.NewBatch()
+		for valid := iter.First(); valid; valid = iter.Next() {
+			if err := batch.Set(iter.Key(), iter.Value(), nil); err != nil {
+				return err
+			}
+			if batch.Len() >= pebbleBackupBatchSize {
+				if err := batch.Commit(pebble.NoSync); err != nil {
+					return err
+				}
+				batch = dst.NewBatch()
+			}
+		}
+		if err := iter.Error(); err != nil {
+			return err
+		}
+		return batch.Commit(pebble.Sync)
+	}()
+	if err := iter.Close(); copyErr == nil {
+		copyErr = err
+	}
+	if err := snap.Close(); copyErr == nil {
+		copyErr = err
+	}
+	if err := dst.Close(); copyErr == nil {
+		copyErr = err
+	}
+	return copyErr
+}
+
+// Remove closes the database and deletes its directory.
+func (e *pebbleEngine) Remove() error {
+	if err := e.Close(); err != nil {
+		return err
+	}
+	return os.RemoveAll(e.dir)
+}
+
+func (e *pebbleEngine) Close() error {
+	e.closeOnce.Do(func() {
+		e.closeErr = e.db.Close()
+	})
+	return e.closeErr
+}
+
+// Compact compacts every key of the database, dropping deleted and overwritten entries
+// from the files of the database. Pebble keeps serving reads and writes meanwhile.
+func (e *pebbleEngine) Compact() error {
+	return e.db.Compact([]byte{pebbleBucketTag}, []byte{pebbleEntryTag + 1})
+}
+
+// pebbleTx reads from a snapshot of the database, or for read-write transactions from an
+// indexed batch, which holds the writes of the transaction until it is committed.
+type pebbleTx struct {
+	reader pebble.Reader
+	batch  *pebble.Batch
+	iters  []*pebble.Iterator
+	// readErr is the first error of a read which cannot return an error, such as Bucket
+	// or Get. It fails the transaction once it is closed.
+	readErr error
+}
+
+func (t *pebbleTx) Bucket(name []byte) kvBucket {
+	exists, err := t.bucketExists(name)
+	if err != nil {
+		t.setReadErr(err)
+		return nil
+	}
+	if !exists {
+		return nil
+	}
+	return t.bucket(name)
+}
+
+func (t *pebbleTx) CreateBucket(name []byte) (kvBucket, error) {
+	exists, err := t.bucketExists(name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.Errorf("bucket %s already exists", name)
+	}
+	return t.CreateBucketIfNotExists(name)
+}
+
+func (t *pebbleTx) CreateBucketIfNotExists(name []byte) (kvBucket, error) {
+	if t.batch == nil {
+		return nil, errTxNotWritable
+	}
+	if len(name) == 0 {
+		return nil, errors.New("bucket name required")
+	}
+	exists, err := t.bucketExists(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err := t.batch.Set(pebbleBucketKey(name), nil, nil); err != nil {
+			return nil, err
+		}
+	}
+	return t.bucket(name), nil
+}
+
+func (t *pebbleTx) DeleteBucket(name []byte) error {
+	if t.batch == nil {
+		return errTxNotWritable
+	}
+	exists, err := t.bucketExists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("bucket %s not found", name)
+	}
+	b := t.bucket(name)
+	if err := t.batch.DeleteRange(b.prefix, prefixEnd(b.prefix), nil); err != nil {
+		return err
+	}
+	return t.batch.Delete(pebbleBucketKey(name), nil)
+}
+
+// bucketNames returns the names of the buckets of the transaction in order.
+func (t *pebbleTx) bucketNames() ([]string, error) {
+	iter := t.reader.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{pebbleBucketTag},
+		UpperBound: []byte{pebbleEntryTag},
+	})
+	var names []string
+	for valid := iter.First(); valid; valid = iter.Next() {
+		names = append(names, string(iter.Key()[1:]))
+	}
+	if err := iter.Error(); err != nil {
+		_ = iter.Close()
+		return nil, err
+	}
+	return names, iter.Close()
+}
+
+// bucketExists returns whether the bucket exists. Only a missing bucket key means the
+// bucket does not exist, any other error of the read is returned.
+func (t *pebbleTx) bucketExists(name []byte) (bool, error) {
+	_, closer, err := t.reader.Get(pebbleBucketKey(name))
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "could not read bucket %s", name)
+	}
+	return true, closer.Close()
+}
+
+func (t *pebbleTx) setReadErr(err error) {
+	if t.readErr == nil {
+		t.readErr = err
+	}
+}
+
+func (t *pebbleTx) bucket(name []byte) *pebbleBucket {
+	prefix := make([]byte, 1+binary.MaxVarintLen64+len(name))
+	prefix[0] = pebbleEntryTag
+	n := binary.PutUvarint(prefix[1:], uint64(len(name)))
+	prefix = append(prefix[:1+n], name...)
+	return &pebbleBucket{tx: t, prefix: prefix}
+}
+
+func (t *pebbleTx) newIter(o *pebble.IterOptions) *pebble.Iterator {
+	iter := t.reader.NewIter(o)
+	t.iters = append(t.iters, iter)
+	return iter
+}
+
+// close releases the iterators opened by the cursors of the transaction, and returns the
+// first error of a read of the transaction.
+func (t *pebbleTx) close() error {
+	err := t.readErr
+	for _, iter := range t.iters {
+		if closeErr := iter.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	t.iters = nil
+	return err
+}
+
+type pebbleBucket struct {
+	tx     *pebbleTx
+	prefix []byte
+}
+
+// Get returns a copy of the value of the key, or nil if the key does not exist. As in
+// BoltDB, an existing empty value is returned as a non-nil empty slice.
+func (b *pebbleBucket) Get(key []byte) []byte {
+	value, closer, err := b.tx.reader.Get(b.key(key))
+	if err != nil {
+		if err != pebble.ErrNotFound {
+			b.tx.setReadErr(errors.Wrap(err, "could not read key"))
+		}
+		return nil
+	}
+	// The value is only valid until the closer is closed. Copying it into a new slice also
+	// keeps an empty value distinct from a missing key.
+	v := make([]byte, len(value))
+	copy(v, value)
+	_ = closer.Close()
+	return v
+}
+
+func (b *pebbleBucket) Put(key []byte, value []byte) error {
+	if b.tx.batch == nil {
+		return errTxNotWritable
+	}
+	if len(key) == 0 {
+		return errors.New("key required")
+	}
+	return b.tx.batch.Set(b.key(key), value, nil)
+}
+
+func (b *pebbleBucket) Delete(key []byte) error {
+	if b.tx.batch == nil {
+		return errTxNotWritable
+	}
+	return b.tx.batch.Delete(b.key(key), nil)
+}
+
+func (b *pebbleBucket) Cursor() kvCursor {
+	return &pebbleCursor{
+		bucket: b,
+		iter: b.tx.newIter(&pebble.IterOptions{
+			LowerBound: b.prefix,
+			UpperBound: prefixEnd(b.prefix),
+		}),
+	}
+}
+
+func (b *pebbleBucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *pebbleBucket) key(key []byte) []byte {
+	k := make([]byte, 0, len(b.prefix)+len(key))
+	return append(append(k, b.prefix...), key...)
+}
+
+// pebbleCursor iterates over the keys of a bucket. An iterator observes the database as
+// it was when the cursor was created, so keys deleted through the cursor do not affect it.
+type pebbleCursor struct {
+	bucket *pebbleBucket
+	iter   *pebble.Iterator
+}
+
+func (c *pebbleCursor) First() ([]byte, []byte) {
+	return c.item(c.iter.First())
+}
+
+func (c *pebbleCursor) Last() ([]byte, []byte) {
+	return c.item(c.iter.Last())
+}
+
+func (c *pebbleCursor) Next() ([]byte, []byte) {
+	return c.item(c.iter.Next())
+}
+
+func (c *pebbleCursor) Prev() ([]byte, []byte) {
+	return c.item(c.iter.Prev())
+}
+
+func (c *pebbleCursor) Seek(seek []byte) ([]byte, []byte) {
+	return c.item(c.iter.SeekGE(c.bucket.key(seek)))
+}
+
+func (c *pebbleCursor) Delete() error {
+	if c.bucket.tx.batch == nil {
+		return errTxNotWritable
+	}
+	if !c.iter.Valid() {
+		return errors.New("cursor is not positioned at a key")
+	}
+	key := make([]byte, len(c.iter.Key()))
+	copy(key, c.iter.Key())
+	return c.bucket.tx.batch.Delete(key, nil)
+}
+
+// item returns copies of the key, without the bucket prefix, and of the value the cursor
+// is positioned at, as they are only valid until the iterator is moved.
+func (c *pebbleCursor) item(valid bool) ([]byte, []byte) {
+	if !valid {
+		return nil, nil
+	}
+	k := c.iter.Key()[len(c.bucket.prefix):]
+	key := make([]byte, len(k))
+	copy(key, k)
+	value := make([]byte, len(c.iter.Value()))
+	copy(value, c.iter.Value())
+	return key, value
+}
+
+// dirSize returns the total size of the files in the directory.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func pebbleBucketKey(name []byte) []byte {
+	return append([]byte{pebbleBucketTag}, name...)
+}
+
+// prefixEnd returns the smallest key greater than every key with the given prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.TrimRight(prefix, "\xff")
+	if len(end) == 0 {
+		return nil
+	}
+	end = append([]byte{}, end...)
+	end[len(end)-1]++
+	return end
+}
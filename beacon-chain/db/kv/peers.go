@@ -0,0 +1,54 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"go.opencensus.io/trace"
+)
+
+// SavePeers replaces the persisted peers with the given peer records.
+func (k *Store) SavePeers(ctx context.Context, peers []*db.PeerRecord) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SavePeers")
+	defer span.End()
+
+	return k.db.Update(func(tx kvTx) error {
+		if err := tx.DeleteBucket(peersBucket); err != nil {
+			return err
+		}
+		bkt, err := tx.CreateBucket(peersBucket)
+		if err != nil {
+			return err
+		}
+		for _, p := range peers {
+			enc, err := proto.Marshal(p)
+			if err != nil {
+				return err
+			}
+			if err := bkt.Put([]byte(p.PeerId), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Peers retrieves the persisted peer records.
+func (k *Store) Peers(ctx context.Context) ([]*db.PeerRecord, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.Peers")
+	defer span.End()
+
+	var peers []*db.PeerRecord
+	err := k.db.View(func(tx kvTx) error {
+		return tx.Bucket(peersBucket).ForEach(func(_, enc []byte) error {
+			p := &db.PeerRecord{}
+			if err := proto.Unmarshal(enc, p); err != nil {
+				return err
+			}
+			peers = append(peers, p)
+			return nil
+		})
+	})
+	return peers, err
+}
@@ -0,0 +1,47 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prysmaticlabs/prysm/proto/beacon/db"
+)
+
+func TestStore_SavePeers(t *testing.T) {
+	store := setupDB(t)
+	ctx := context.Background()
+
+	peers, err := store.Peers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 0 {
+		t.Fatalf("Wanted no peers in a new database, received %v", peers)
+	}
+
+	first := &db.PeerRecord{PeerId: "a", Addresses: []string{"/ip4/127.0.0.1/tcp/13000"}, LastSeen: 1}
+	second := &db.PeerRecord{PeerId: "b", BadResponses: 1, LastSeen: 2}
+	if err := store.SavePeers(ctx, []*db.PeerRecord{first, second}); err != nil {
+		t.Fatal(err)
+	}
+	peers, err = store.Peers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 2 || !proto.Equal(peers[0], first) || !proto.Equal(peers[1], second) {
+		t.Errorf("Wanted %v, received %v", []*db.PeerRecord{first, second}, peers)
+	}
+
+	// Saving peers replaces the previously persisted ones.
+	if err := store.SavePeers(ctx, []*db.PeerRecord{second}); err != nil {
+		t.Fatal(err)
+	}
+	peers, err = store.Peers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 1 || !proto.Equal(peers[0], second) {
+		t.Errorf("Wanted %v, received %v", []*db.PeerRecord{second}, peers)
+	}
+}
@@ -14,6 +14,7 @@ var (
 	proposerSlashingsBucket              = []byte("proposer-slashings")
 	attesterSlashingsBucket              = []byte("attester-slashings")
 	voluntaryExitsBucket                 = []byte("voluntary-exits")
+	pendingVoluntaryExitsBucket          = []byte("pending-voluntary-exits")
 	chainMetadataBucket                  = []byte("chain-metadata")
 	checkpointBucket                     = []byte("check-point")
 	archivedValidatorSetChangesBucket    = []byte("archived-active-changes")
@@ -23,6 +24,10 @@ var (
 	powchainBucket                       = []byte("powchain")
 	archivedIndexRootBucket              = []byte("archived-index-root")
 	slotsHasObjectBucket                 = []byte("slots-has-objects")
+	schemaVersionBucket                  = []byte("schema-version")
+	lightClientFinalityUpdatesBucket     = []byte("light-client-finality-updates")
+	stateDiffsBucket                     = []byte("state-diffs")
+	peersBucket                          = []byte("peers")
 
 	// Key indices buckets.
 	blockParentRootIndicesBucket        = []byte("block-parent-root-indices")
@@ -33,6 +38,7 @@ var (
 	attestationTargetRootIndicesBucket  = []byte("attestation-target-root-indices")
 	attestationTargetEpochIndicesBucket = []byte("attestation-target-epoch-indices")
 	finalizedBlockRootsIndexBucket      = []byte("finalized-block-roots-index")
+	finalizedSlotRootsIndexBucket       = []byte("finalized-slot-roots-index")
 
 	// Specific item keys.
 	headBlockRootKey          = []byte("head-root")
@@ -41,9 +47,13 @@ var (
 	justifiedCheckpointKey    = []byte("justified-checkpoint")
 	finalizedCheckpointKey    = []byte("finalized-checkpoint")
 	powchainDataKey           = []byte("powchain-data")
+	forkChoiceCheckpointKey   = []byte("fork-choice-checkpoint")
 	lastArchivedIndexKey      = []byte("last-archived")
 	savedBlockSlotsKey        = []byte("saved-block-slots")
 	savedStateSlotsKey        = []byte("saved-state-slots")
+	schemaVersionKey          = []byte("schema-version")
+	verifiedWsCheckpointKey   = []byte("verified-weak-subjectivity-checkpoint")
+	slotsPerArchivedPointKey  = []byte("slots-per-archived-point")
 
 	// New state management service compatibility bucket.
 	newStateServiceCompatibleBucket = []byte("new-state-compatible")
@@ -5,7 +5,6 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -14,7 +13,7 @@ func (k *Store) ProposerSlashing(ctx context.Context, slashingRoot [32]byte) (*e
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.ProposerSlashing")
 	defer span.End()
 	var slashing *ethpb.ProposerSlashing
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(proposerSlashingsBucket)
 		enc := bkt.Get(slashingRoot[:])
 		if enc == nil {
@@ -31,7 +30,7 @@ func (k *Store) HasProposerSlashing(ctx context.Context, slashingRoot [32]byte)
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasProposerSlashing")
 	defer span.End()
 	exists := false
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(proposerSlashingsBucket)
 		exists = bkt.Get(slashingRoot[:]) != nil
 		return nil
@@ -41,6 +40,24 @@ func (k *Store) HasProposerSlashing(ctx context.Context, slashingRoot [32]byte)
 	return exists
 }
 
+// ProposerSlashings retrieves all the proposer slashings stored in the db.
+func (k *Store) ProposerSlashings(ctx context.Context) ([]*ethpb.ProposerSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ProposerSlashings")
+	defer span.End()
+	slashings := make([]*ethpb.ProposerSlashing, 0)
+	err := k.db.View(func(tx kvTx) error {
+		return tx.Bucket(proposerSlashingsBucket).ForEach(func(k, enc []byte) error {
+			slashing := &ethpb.ProposerSlashing{}
+			if err := decode(enc, slashing); err != nil {
+				return err
+			}
+			slashings = append(slashings, slashing)
+			return nil
+		})
+	})
+	return slashings, err
+}
+
 // SaveProposerSlashing to the db by its hash tree root.
 func (k *Store) SaveProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveProposerSlashing")
@@ -53,7 +70,7 @@ func (k *Store) SaveProposerSlashing(ctx context.Context, slashing *ethpb.Propos
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(proposerSlashingsBucket)
 		return bucket.Put(slashingRoot[:], enc)
 	})
@@ -63,7 +80,7 @@ func (k *Store) SaveProposerSlashing(ctx context.Context, slashing *ethpb.Propos
 func (k *Store) DeleteProposerSlashing(ctx context.Context, slashingRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteProposerSlashing")
 	defer span.End()
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(proposerSlashingsBucket)
 		return bucket.Delete(slashingRoot[:])
 	})
@@ -74,7 +91,7 @@ func (k *Store) AttesterSlashing(ctx context.Context, slashingRoot [32]byte) (*e
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.AttesterSlashing")
 	defer span.End()
 	var slashing *ethpb.AttesterSlashing
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(attesterSlashingsBucket)
 		enc := bkt.Get(slashingRoot[:])
 		if enc == nil {
@@ -91,7 +108,7 @@ func (k *Store) HasAttesterSlashing(ctx context.Context, slashingRoot [32]byte)
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasAttesterSlashing")
 	defer span.End()
 	exists := false
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bkt := tx.Bucket(attesterSlashingsBucket)
 		exists = bkt.Get(slashingRoot[:]) != nil
 		return nil
@@ -101,6 +118,24 @@ func (k *Store) HasAttesterSlashing(ctx context.Context, slashingRoot [32]byte)
 	return exists
 }
 
+// AttesterSlashings retrieves all the attester slashings stored in the db.
+func (k *Store) AttesterSlashings(ctx context.Context) ([]*ethpb.AttesterSlashing, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.AttesterSlashings")
+	defer span.End()
+	slashings := make([]*ethpb.AttesterSlashing, 0)
+	err := k.db.View(func(tx kvTx) error {
+		return tx.Bucket(attesterSlashingsBucket).ForEach(func(k, enc []byte) error {
+			slashing := &ethpb.AttesterSlashing{}
+			if err := decode(enc, slashing); err != nil {
+				return err
+			}
+			slashings = append(slashings, slashing)
+			return nil
+		})
+	})
+	return slashings, err
+}
+
 // SaveAttesterSlashing to the db by its hash tree root.
 func (k *Store) SaveAttesterSlashing(ctx context.Context, slashing *ethpb.AttesterSlashing) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveAttesterSlashing")
@@ -113,7 +148,7 @@ func (k *Store) SaveAttesterSlashing(ctx context.Context, slashing *ethpb.Attest
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(attesterSlashingsBucket)
 		return bucket.Put(slashingRoot[:], enc)
 	})
@@ -123,7 +158,7 @@ func (k *Store) SaveAttesterSlashing(ctx context.Context, slashing *ethpb.Attest
 func (k *Store) DeleteAttesterSlashing(ctx context.Context, slashingRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteAttesterSlashing")
 	defer span.End()
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(attesterSlashingsBucket)
 		return bucket.Delete(slashingRoot[:])
 	})
@@ -57,6 +57,13 @@ func TestStore_ProposerSlashing_CRUD(t *testing.T) {
 	if !proto.Equal(prop, retrieved) {
 		t.Errorf("Wanted %v, received %v", prop, retrieved)
 	}
+	all, err := db.ProposerSlashings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || !proto.Equal(prop, all[0]) {
+		t.Errorf("Wanted [%v], received %v", prop, all)
+	}
 	if err := db.DeleteProposerSlashing(ctx, slashingRoot); err != nil {
 		t.Fatal(err)
 	}
@@ -125,6 +132,13 @@ func TestStore_AttesterSlashing_CRUD(t *testing.T) {
 	if !proto.Equal(att, retrieved) {
 		t.Errorf("Wanted %v, received %v", att, retrieved)
 	}
+	all, err := db.AttesterSlashings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || !proto.Equal(att, all[0]) {
+		t.Errorf("Wanted [%v], received %v", att, all)
+	}
 	if err := db.DeleteAttesterSlashing(ctx, slashingRoot); err != nil {
 		t.Fatal(err)
 	}
@@ -11,7 +11,6 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -21,7 +20,7 @@ func (k *Store) State(ctx context.Context, blockRoot [32]byte) (*state.BeaconSta
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.State")
 	defer span.End()
 	var s *pb.BeaconState
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bucket := tx.Bucket(stateBucket)
 		enc := bucket.Get(blockRoot[:])
 		if enc == nil {
@@ -46,7 +45,7 @@ func (k *Store) HeadState(ctx context.Context) (*state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HeadState")
 	defer span.End()
 	var s *pb.BeaconState
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		// Retrieve head block's signing root from blocks bucket,
 		// to look up what the head state is.
 		bucket := tx.Bucket(blocksBucket)
@@ -80,7 +79,7 @@ func (k *Store) GenesisState(ctx context.Context) (*state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.GenesisState")
 	defer span.End()
 	var s *pb.BeaconState
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		// Retrieve genesis block's signing root from blocks bucket,
 		// to look up what the genesis state is.
 		bucket := tx.Bucket(blocksBucket)
@@ -117,7 +116,7 @@ func (k *Store) SaveState(ctx context.Context, state *state.BeaconState, blockRo
 		return err
 	}
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(stateBucket)
 		if err := bucket.Put(blockRoot[:], enc); err != nil {
 			return err
@@ -142,7 +141,7 @@ func (k *Store) SaveStates(ctx context.Context, states []*state.BeaconState, blo
 		}
 	}
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(stateBucket)
 		for i, rt := range blockRoots {
 			if err := k.setStateSlotBitField(ctx, tx, states[i].Slot()); err != nil {
@@ -162,7 +161,7 @@ func (k *Store) HasState(ctx context.Context, blockRoot [32]byte) bool {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasState")
 	defer span.End()
 	var exists bool
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bucket := tx.Bucket(stateBucket)
 		exists = bucket.Get(blockRoot[:]) != nil
 		return nil
@@ -177,7 +176,7 @@ func (k *Store) DeleteState(ctx context.Context, blockRoot [32]byte) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteState")
 	defer span.End()
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		genesisBlockRoot := bkt.Get(genesisBlockRootKey)
 
@@ -226,7 +225,7 @@ func (k *Store) DeleteStates(ctx context.Context, blockRoots [][32]byte) error {
 		rootMap[blockRoot] = true
 	}
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bkt := tx.Bucket(blocksBucket)
 		genesisBlockRoot := bkt.Get(genesisBlockRootKey)
 
@@ -279,7 +278,7 @@ func createState(enc []byte) (*pb.BeaconState, error) {
 }
 
 // slotByBlockRoot retrieves the corresponding slot of the input block root.
-func slotByBlockRoot(ctx context.Context, tx *bolt.Tx, blockRoot []byte) (uint64, error) {
+func slotByBlockRoot(ctx context.Context, tx kvTx, blockRoot []byte) (uint64, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.slotByBlockRoot")
 	defer span.End()
 
@@ -332,7 +331,7 @@ func (k *Store) HighestSlotStates(ctx context.Context) ([]*state.BeaconState, er
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HighestSlotState")
 	defer span.End()
 	var states []*state.BeaconState
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		slotBkt := tx.Bucket(slotsHasObjectBucket)
 		savedSlots := slotBkt.Get(savedStateSlotsKey)
 		highestIndex, err := bytesutil.HighestBitIndex(savedSlots)
@@ -362,7 +361,7 @@ func (k *Store) HighestSlotStatesBelow(ctx context.Context, slot uint64) ([]*sta
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HighestSlotStatesBelow")
 	defer span.End()
 	var states []*state.BeaconState
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		slotBkt := tx.Bucket(slotsHasObjectBucket)
 		savedSlots := slotBkt.Get(savedStateSlotsKey)
 		if len(savedSlots) == 0 {
@@ -390,7 +389,7 @@ func (k *Store) HighestSlotStatesBelow(ctx context.Context, slot uint64) ([]*sta
 
 // statesAtSlotBitfieldIndex retrieves the states in DB given the input index. The index represents
 // the position of the slot bitfield the saved state maps to.
-func (k *Store) statesAtSlotBitfieldIndex(ctx context.Context, tx *bolt.Tx, index int) ([]*state.BeaconState, error) {
+func (k *Store) statesAtSlotBitfieldIndex(ctx context.Context, tx kvTx, index int) ([]*state.BeaconState, error) {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.statesAtSlotBitfieldIndex")
 	defer span.End()
 
@@ -439,7 +438,7 @@ func (k *Store) statesAtSlotBitfieldIndex(ctx context.Context, tx *bolt.Tx, inde
 
 // setStateSlotBitField sets the state slot bit in DB.
 // This helps to track which slot has a saved state in db.
-func (k *Store) setStateSlotBitField(ctx context.Context, tx *bolt.Tx, slot uint64) error {
+func (k *Store) setStateSlotBitField(ctx context.Context, tx kvTx, slot uint64) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.setStateSlotBitField")
 	defer span.End()
 
@@ -459,7 +458,7 @@ func (k *Store) setStateSlotBitField(ctx context.Context, tx *bolt.Tx, slot uint
 
 // clearStateSlotBitField clears the state slot bit in DB.
 // This helps to track which slot has a saved state in db.
-func (k *Store) clearStateSlotBitField(ctx context.Context, tx *bolt.Tx, slot uint64) error {
+func (k *Store) clearStateSlotBitField(ctx context.Context, tx kvTx, slot uint64) error {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.clearStateSlotBitField")
 	defer span.End()
 
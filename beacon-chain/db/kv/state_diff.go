@@ -0,0 +1,55 @@
+package kv
+
+import (
+	"context"
+
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"go.opencensus.io/trace"
+)
+
+// SaveStateDiff saves the diff of an archived state by the root of its block.
+func (k *Store) SaveStateDiff(ctx context.Context, blockRoot [32]byte, diff *pb.StateDiff) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+
+	enc, err := encode(diff)
+	if err != nil {
+		return err
+	}
+	return k.db.Update(func(tx kvTx) error {
+		bkt := tx.Bucket(stateDiffsBucket)
+		return bkt.Put(blockRoot[:], enc)
+	})
+}
+
+// StateDiff retrieves the diff of an archived state by the root of its block,
+// or nil if no diff was saved for the block root.
+func (k *Store) StateDiff(ctx context.Context, blockRoot [32]byte) (*pb.StateDiff, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.StateDiff")
+	defer span.End()
+
+	var diff *pb.StateDiff
+	err := k.db.View(func(tx kvTx) error {
+		enc := tx.Bucket(stateDiffsBucket).Get(blockRoot[:])
+		if enc == nil {
+			return nil
+		}
+		diff = &pb.StateDiff{}
+		return decode(enc, diff)
+	})
+	return diff, err
+}
+
+// HasStateDiff checks if a state diff was saved for the block root.
+func (k *Store) HasStateDiff(ctx context.Context, blockRoot [32]byte) bool {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasStateDiff")
+	defer span.End()
+	var exists bool
+	if err := k.db.View(func(tx kvTx) error {
+		exists = tx.Bucket(stateDiffsBucket).Get(blockRoot[:]) != nil
+		return nil
+	}); err != nil { // This view never returns an error, but we'll handle anyway for sanity.
+		panic(err)
+	}
+	return exists
+}
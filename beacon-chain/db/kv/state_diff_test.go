@@ -0,0 +1,48 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestStore_StateDiff_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	blockRoot := [32]byte{'a'}
+	if db.HasStateDiff(ctx, blockRoot) {
+		t.Error("Expected no state diff before saving")
+	}
+	diff, err := db.StateDiff(ctx, blockRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil {
+		t.Errorf("Expected nil state diff, received %v", diff)
+	}
+
+	want := &pb.StateDiff{
+		BaseRoot:       []byte{'b'},
+		State:          &pb.BeaconState{Slot: 5},
+		BlockRoots:     []*pb.IndexedRoot{{Index: 4, Root: []byte{'c'}}},
+		Balances:       []*pb.IndexedUint64{{Index: 1, Value: 32}},
+		ValidatorCount: 2,
+	}
+	if err := db.SaveStateDiff(ctx, blockRoot, want); err != nil {
+		t.Fatal(err)
+	}
+	if !db.HasStateDiff(ctx, blockRoot) {
+		t.Error("Expected state diff to be saved")
+	}
+	diff, err = db.StateDiff(ctx, blockRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(diff, want) {
+		t.Errorf("Wanted %v, received %v", want, diff)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
-	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
@@ -17,7 +16,7 @@ func (k *Store) SaveStateSummary(ctx context.Context, summary *pb.StateSummary)
 	if err != nil {
 		return err
 	}
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(stateSummaryBucket)
 		return bucket.Put(summary.Root, enc)
 	})
@@ -28,7 +27,7 @@ func (k *Store) SaveStateSummaries(ctx context.Context, summaries []*pb.StateSum
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateSummaries")
 	defer span.End()
 
-	return k.db.Update(func(tx *bolt.Tx) error {
+	return k.db.Update(func(tx kvTx) error {
 		bucket := tx.Bucket(stateSummaryBucket)
 		for _, summary := range summaries {
 			enc, err := encode(summary)
@@ -49,7 +48,7 @@ func (k *Store) StateSummary(ctx context.Context, blockRoot [32]byte) (*pb.State
 	defer span.End()
 
 	var summary *pb.StateSummary
-	err := k.db.View(func(tx *bolt.Tx) error {
+	err := k.db.View(func(tx kvTx) error {
 		bucket := tx.Bucket(stateSummaryBucket)
 		enc := bucket.Get(blockRoot[:])
 		if enc == nil {
@@ -67,7 +66,7 @@ func (k *Store) HasStateSummary(ctx context.Context, blockRoot [32]byte) bool {
 	ctx, span := trace.StartSpan(ctx, "BeaconDB.HasStateSummary")
 	defer span.End()
 	var exists bool
-	if err := k.db.View(func(tx *bolt.Tx) error {
+	if err := k.db.View(func(tx kvTx) error {
 		bucket := tx.Bucket(stateSummaryBucket)
 		exists = bucket.Get(blockRoot[:]) != nil
 		return nil
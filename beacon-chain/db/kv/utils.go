@@ -2,8 +2,6 @@ package kv
 
 import (
 	"bytes"
-
-	bolt "go.etcd.io/bbolt"
 )
 
 // lookupValuesForIndices takes in a list of indices and looks up
@@ -13,7 +11,7 @@ import (
 // attestations and we have an index `[]byte("5")` under the shard indices bucket,
 // we might find roots `0x23` and `0x45` stored under that index. We can then
 // do a batch read for attestations corresponding to those roots.
-func lookupValuesForIndices(indicesByBucket map[string][]byte, tx *bolt.Tx) [][][]byte {
+func lookupValuesForIndices(indicesByBucket map[string][]byte, tx kvTx) [][][]byte {
 	values := make([][][]byte, 0)
 	for k, v := range indicesByBucket {
 		bkt := tx.Bucket([]byte(k))
@@ -30,7 +28,7 @@ func lookupValuesForIndices(indicesByBucket map[string][]byte, tx *bolt.Tx) [][]
 // updateValueForIndices updates the value for each index by appending it to the previous
 // values stored at said index. Typically, indices are roots of data that can then
 // be used for reads or batch reads from the DB.
-func updateValueForIndices(indicesByBucket map[string][]byte, root []byte, tx *bolt.Tx) error {
+func updateValueForIndices(indicesByBucket map[string][]byte, root []byte, tx kvTx) error {
 	for k, idx := range indicesByBucket {
 		bkt := tx.Bucket([]byte(k))
 		valuesAtIndex := bkt.Get(idx)
@@ -54,7 +52,7 @@ func updateValueForIndices(indicesByBucket map[string][]byte, root []byte, tx *b
 }
 
 // deleteValueForIndices clears a root stored at each index.
-func deleteValueForIndices(indicesByBucket map[string][]byte, root []byte, tx *bolt.Tx) error {
+func deleteValueForIndices(indicesByBucket map[string][]byte, root []byte, tx kvTx) error {
 	for k, idx := range indicesByBucket {
 		bkt := tx.Bucket([]byte(k))
 		valuesAtIndex := bkt.Get(idx)
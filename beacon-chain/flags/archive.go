@@ -29,4 +29,40 @@ var (
 		Name:  "archive-attestations",
 		Usage: "Whether or not beacon chain should archive historical blocks",
 	}
+	// ArchiveStateDiffsFlag defines whether or not the beacon chain should archive
+	// the states in between archived points as diffs from the archived point state.
+	ArchiveStateDiffsFlag = &cli.BoolFlag{
+		Name:  "archive-state-diffs",
+		Usage: "Whether or not beacon chain should archive every finalized state as a diff from the nearest archived point state, allowing fast queries of states at any slot",
+	}
+	// ArchiveBalancesRetentionFlag defines the number of most recent epochs of archived balances kept.
+	ArchiveBalancesRetentionFlag = &cli.Uint64Flag{
+		Name:  "archive-balances-retention",
+		Usage: "Number of most recent epochs of archived validator balances to keep, older epochs are pruned. 0 keeps every epoch",
+	}
+	// ArchiveBalancesKeepEveryFlag defines the epoch interval of archived balances kept forever.
+	ArchiveBalancesKeepEveryFlag = &cli.Uint64Flag{
+		Name:  "archive-balances-keep-every",
+		Usage: "Keep the archived validator balances of every epoch multiple of this value forever, regardless of the retention",
+	}
+	// ArchiveCommitteesRetentionFlag defines the number of most recent epochs of archived committee info kept.
+	ArchiveCommitteesRetentionFlag = &cli.Uint64Flag{
+		Name:  "archive-committees-retention",
+		Usage: "Number of most recent epochs of archived committee info to keep, older epochs are pruned. 0 keeps every epoch",
+	}
+	// ArchiveCommitteesKeepEveryFlag defines the epoch interval of archived committee info kept forever.
+	ArchiveCommitteesKeepEveryFlag = &cli.Uint64Flag{
+		Name:  "archive-committees-keep-every",
+		Usage: "Keep the archived committee info of every epoch multiple of this value forever, regardless of the retention",
+	}
+	// ArchiveParticipationRetentionFlag defines the number of most recent epochs of archived participation kept.
+	ArchiveParticipationRetentionFlag = &cli.Uint64Flag{
+		Name:  "archive-participation-retention",
+		Usage: "Number of most recent epochs of archived validator participation to keep, older epochs are pruned. 0 keeps every epoch",
+	}
+	// ArchiveParticipationKeepEveryFlag defines the epoch interval of archived participation kept forever.
+	ArchiveParticipationKeepEveryFlag = &cli.Uint64Flag{
+		Name:  "archive-participation-keep-every",
+		Usage: "Keep the archived validator participation of every epoch multiple of this value forever, regardless of the retention",
+	}
 )
@@ -3,14 +3,17 @@
 package flags
 
 import (
+	"time"
+
 	"gopkg.in/urfave/cli.v2"
 )
 
 var (
 	// HTTPWeb3ProviderFlag provides an HTTP access endpoint to an ETH 1.0 RPC.
 	HTTPWeb3ProviderFlag = &cli.StringFlag{
-		Name:  "http-web3provider",
-		Usage: "A mainchain web3 provider string http endpoint",
+		Name: "http-web3provider",
+		Usage: "A mainchain web3 provider string http endpoint. Multiple endpoints may be provided as a comma separated list, " +
+			"in which case the beacon node falls back to the next endpoint whenever the active one fails or lags behind.",
 		Value: "https://goerli.prylabs.net",
 	}
 	// Web3ProviderFlag defines a flag for a mainchain RPC endpoint.
@@ -25,6 +28,21 @@ var (
 		Usage: "Deposit contract address. Beacon chain node will listen logs coming from the deposit contract to determine when validator is eligible to participate.",
 		Value: "0x5cA1e00004366Ac85f492887AAab12d0e6418876",
 	}
+	// Eth1ChainIDFlag defines the chain id the eth1 endpoints are expected to be on.
+	Eth1ChainIDFlag = &cli.Uint64Flag{
+		Name:  "eth1-chain-id",
+		Usage: "The chain id of the eth1 chain. Http endpoints reporting a different chain id are skipped. If unset, every endpoint must be on the chain of the first endpoint connected to.",
+	}
+	// DepositSnapshotFlag defines a deposit snapshot file to initialize the deposit tree from.
+	DepositSnapshotFlag = &cli.StringFlag{
+		Name:  "deposit-snapshot",
+		Usage: "Path to a finalized deposit tree snapshot exported by another node. Deposit logs are only processed from the snapshot's eth1 block onwards",
+	}
+	// ExportDepositSnapshotFlag defines the file the finalized deposit snapshot is written to.
+	ExportDepositSnapshotFlag = &cli.StringFlag{
+		Name:  "export-deposit-snapshot",
+		Usage: "Path to write the finalized deposit tree snapshot to every time it is updated",
+	}
 	// RPCHost defines the host on which the RPC server should listen.
 	RPCHost = &cli.StringFlag{
 		Name:  "rpc-host",
@@ -44,6 +62,38 @@ var (
 		Usage: "Max number of items returned per page in RPC responses for paginated endpoints.",
 		Value: 500,
 	}
+	// RPCMaxRecvMsgSize defines the largest message the RPC server accepts.
+	RPCMaxRecvMsgSize = &cli.IntFlag{
+		Name:  "rpc-max-recv-msg-size",
+		Usage: "Max size in bytes of a message received by the RPC server",
+		Value: 50 << 20,
+	}
+	// RPCMaxSendMsgSize defines the largest message the RPC server sends.
+	RPCMaxSendMsgSize = &cli.IntFlag{
+		Name:  "rpc-max-send-msg-size",
+		Usage: "Max size in bytes of a message sent by the RPC server. 0 uses the gRPC default",
+	}
+	// RPCMaxConcurrentStreams defines the number of concurrent streams allowed per RPC connection.
+	RPCMaxConcurrentStreams = &cli.UintFlag{
+		Name:  "rpc-max-concurrent-streams",
+		Usage: "Max number of concurrent streams, including unary calls, per RPC client connection. 0 is unlimited",
+	}
+	// RPCMaxConnections defines the number of simultaneous client connections to the RPC server.
+	RPCMaxConnections = &cli.IntFlag{
+		Name:  "rpc-max-connections",
+		Usage: "Max number of simultaneous client connections accepted by the RPC server. 0 is unlimited",
+	}
+	// RPCKeepaliveMinTime defines how often clients may send keepalive pings to the RPC server.
+	RPCKeepaliveMinTime = &cli.DurationFlag{
+		Name: "rpc-keepalive-min-time",
+		Usage: "Minimum time between keepalive pings of an RPC client, clients pinging more often are " +
+			"disconnected. 0 uses the gRPC default of 5 minutes",
+	}
+	// RPCKeepalivePermitWithoutStream allows clients to send keepalive pings without active streams.
+	RPCKeepalivePermitWithoutStream = &cli.BoolFlag{
+		Name:  "rpc-keepalive-permit-without-stream",
+		Usage: "Allow RPC clients to send keepalive pings while they have no active calls",
+	}
 	// MonitoringPortFlag defines the http port used to serve prometheus metrics.
 	MonitoringPortFlag = &cli.Int64Flag{
 		Name:  "monitoring-port",
@@ -60,6 +110,12 @@ var (
 		Name:  "tls-key",
 		Usage: "Key for secure gRPC. Pass this and the tls-cert flag in order to use gRPC securely.",
 	}
+	// GRPCGatewayHost defines the host interface the gRPC gateway listens on.
+	GRPCGatewayHost = &cli.StringFlag{
+		Name:  "grpc-gateway-host",
+		Usage: "Host interface to serve the gRPC gateway on, such as 127.0.0.1 to only serve local requests.",
+		Value: "0.0.0.0",
+	}
 	// GRPCGatewayPort enables a gRPC gateway to be exposed for Prysm.
 	GRPCGatewayPort = &cli.IntFlag{
 		Name:  "grpc-gateway-port",
@@ -84,12 +140,6 @@ var (
 		Usage: "The eth1 block in which the deposit contract was deployed.",
 		Value: 2523557,
 	}
-	// SetGCPercent is the percentage of current live allocations at which the garbage collector is to run.
-	SetGCPercent = &cli.IntFlag{
-		Name:  "gc-percent",
-		Usage: "The percentage of freshly allocated data to live data on which the gc will be run again.",
-		Value: 100,
-	}
 	// UnsafeSync starts the beacon node from the previously saved head state and syncs from there.
 	UnsafeSync = &cli.BoolFlag{
 		Name:  "unsafe-sync",
@@ -106,27 +156,233 @@ var (
 		Usage: "A slasher provider string endpoint. Can either be an grpc server endpoint.",
 		Value: "127.0.0.1:5000",
 	}
+	// SlasherFlag enables slashing detection within the beacon node itself.
+	SlasherFlag = &cli.BoolFlag{
+		Name: "slasher",
+		Usage: "Runs surround and double vote detection within the beacon node against all received blocks and attestations, " +
+			"inserting any detected slashings into the local slashing pool for inclusion in blocks.",
+	}
+	// EnableLightClientServer enables storing and serving light client finality and optimistic updates.
+	EnableLightClientServer = &cli.BoolFlag{
+		Name:  "enable-light-client-server",
+		Usage: "Stores light client finality updates as the chain finalizes and serves them along with optimistic updates over the light client RPC",
+	}
 	// SlotsPerArchivedPoint specifies the number of slots between the archived points, to save beacon state in the cold
 	// section of DB.
 	SlotsPerArchivedPoint = &cli.IntFlag{
 		Name:  "slots-per-archive-point",
-		Usage: "The slot durations of when an archived state gets saved in the DB.",
-		Value: 128,
+		Usage: "The slot durations of when an archived state gets saved in the DB. Must be a multiple of the slots per epoch, states in between archived points are regenerated by replaying blocks",
+		Value: 256,
 	}
 	// DisableDiscv5 disables running discv5.
 	DisableDiscv5 = &cli.BoolFlag{
 		Name:  "disable-discv5",
 		Usage: "Does not run the discoveryV5 dht.",
 	}
+	// SubscribeToAllSubnets makes the node subscribe to every attestation subnet.
+	SubscribeToAllSubnets = &cli.BoolFlag{
+		Name: "subscribe-all-subnets",
+		Usage: "Subscribes to every attestation subnet regardless of validator duties and advertises them in the node's ENR. " +
+			"Useful for aggregator reliability, slashers and research nodes at the cost of extra bandwidth",
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
 		Usage: "The amount of blocks the local peer is bounded to request and respond to in a batch.",
 		Value: 64,
 	}
+	// BlocksByRangeMaxCount specifies the maximum number of blocks served for a single blocks by range request.
+	BlocksByRangeMaxCount = &cli.IntFlag{
+		Name:  "blocks-by-range-max-count",
+		Usage: "The maximum number of blocks the local peer responds with to a single blocks by range request. Larger requests are truncated.",
+		Value: 1024,
+	}
+	// BlocksByRangeTimeout specifies the deadline, in seconds, for serving a single blocks by range request.
+	BlocksByRangeTimeout = &cli.IntFlag{
+		Name:  "blocks-by-range-timeout",
+		Usage: "The number of seconds the local peer spends streaming the response to a single blocks by range request before giving up.",
+		Value: 30,
+	}
+	// BlockValidationQueueSize specifies the number of gossiped blocks queued for validation per topic.
+	BlockValidationQueueSize = &cli.IntFlag{
+		Name:  "block-validation-queue-size",
+		Usage: "The number of gossiped blocks waiting for validation. Blocks are never dropped, so a full queue delays receiving more blocks.",
+		Value: 64,
+	}
+	// AttestationValidationQueueSize specifies the number of gossiped attestations queued for validation per topic.
+	AttestationValidationQueueSize = &cli.IntFlag{
+		Name:  "attestation-validation-queue-size",
+		Usage: "The number of gossiped attestations or aggregates waiting for validation on each topic. The oldest attestation is dropped when the queue is full.",
+		Value: 4096,
+	}
+	// SignatureVerificationWorkers specifies the number of workers verifying signatures of gossip and RPC messages.
+	SignatureVerificationWorkers = &cli.IntFlag{
+		Name: "signature-verification-workers",
+		Usage: "The number of signatures of gossiped and RPC submitted messages verified at once. Lowering it caps the CPU used " +
+			"for cryptography so that block processing is not starved on small machines. Defaults to the number of CPUs",
+	}
+	// DBBackupOutputDirFlag defines the directory database backups are written to.
+	DBBackupOutputDirFlag = &cli.StringFlag{
+		Name:  "db-backup-output-dir",
+		Usage: "Output directory for database backups triggered via the backup webhook or debug RPC endpoint. Defaults to the backups directory within the data directory",
+	}
+	// CompactDBFlag compacts the beacon chain database before the node starts.
+	CompactDBFlag = &cli.BoolFlag{
+		Name: "compact-db",
+		Usage: "Compact the beacon chain database on startup to reclaim the space of deleted data. This may take a while on large databases. " +
+			"A running node can be compacted with the CompactDatabase debug RPC instead",
+	}
+	// StateCacheSize specifies the number of recently used hot states kept in memory.
+	StateCacheSize = &cli.IntFlag{
+		Name:  "state-cache-size",
+		Usage: "The number of hot states kept in memory to avoid regenerating them by replaying blocks.",
+		Value: 16,
+	}
+	// CommitteeCacheSize specifies the number of shuffled committees kept in memory.
+	CommitteeCacheSize = &cli.IntFlag{
+		Name:  "committee-cache-size",
+		Usage: "The number of shuffled committees, one per seed, kept in memory to avoid recomputing the shuffling.",
+		Value: 10,
+	}
+	// CheckpointStateCacheSize specifies the number of checkpoint states kept in memory.
+	CheckpointStateCacheSize = &cli.IntFlag{
+		Name:  "checkpoint-state-cache-size",
+		Usage: "The number of checkpoint states kept in memory to avoid regenerating them when verifying attestations.",
+		Value: 10,
+	}
+	// SkipSlotCacheSize specifies the number of states processed through skip slots kept in memory.
+	SkipSlotCacheSize = &cli.IntFlag{
+		Name:  "skip-slot-cache-size",
+		Usage: "The number of states processed through empty slots kept in memory to avoid processing the slots again.",
+		Value: 8,
+	}
+	// StateReplayBudget defines the maximum number of slots replayed to serve a historical state over RPC.
+	StateReplayBudget = &cli.Uint64Flag{
+		Name: "state-replay-budget",
+		Usage: "The maximum number of slots replayed to regenerate a historical state requested over RPC. " +
+			"Requests for states which are more expensive to regenerate are rejected. 0 disables the limit",
+		Value: 4096,
+	}
+	// GenesisStateFlag defines a file or URL to load the SSZ encoded genesis state from.
+	GenesisStateFlag = &cli.StringFlag{
+		Name:  "genesis-state",
+		Usage: "Load a genesis state from a local file path or http(s) URL to an SSZ encoded BeaconState, instead of waiting for genesis to be triggered by the deposit contract. Useful for testnets and interop",
+	}
+	// WeakSubjectivityCheckpt defines the weak subjectivity checkpoint the node verifies the chain against.
+	WeakSubjectivityCheckpt = &cli.StringFlag{
+		Name: "weak-subjectivity-checkpoint",
+		Usage: "Input in `block_root:epoch` format. The node halts if the block root is not the canonical checkpoint " +
+			"at that epoch once it is finalized, which protects nodes syncing from genesis against long range attacks",
+	}
+	// ExportStateSlot defines the slot of the state written by the db export-state command.
+	ExportStateSlot = &cli.Uint64Flag{
+		Name:     "slot",
+		Usage:    "The slot of the state to regenerate and export",
+		Required: true,
+	}
+	// ExportStateOutput defines the file the db export-state command writes the SSZ encoded state to.
+	ExportStateOutput = &cli.StringFlag{
+		Name:  "output",
+		Usage: "The file path to write the SSZ encoded state to",
+		Value: "state.ssz",
+	}
+	// InspectBucket defines the bucket whose keys the db inspect command dumps.
+	InspectBucket = &cli.StringFlag{
+		Name:  "bucket",
+		Usage: "Dump the keys of this bucket instead of reporting the size of every bucket",
+	}
+	// InspectPrefix defines the hex encoded prefix of the keys dumped by the db inspect command.
+	InspectPrefix = &cli.StringFlag{
+		Name:  "prefix",
+		Usage: "Only dump the keys of the bucket starting with this hex encoded prefix",
+	}
+	// InspectLimit defines the maximum number of keys dumped by the db inspect command.
+	InspectLimit = &cli.IntFlag{
+		Name:  "limit",
+		Usage: "The maximum number of keys to dump, 0 for no limit",
+		Value: 100,
+	}
+	// SnapshotOutput defines the file the db export-snapshot command writes the node snapshot to.
+	SnapshotOutput = &cli.StringFlag{
+		Name:  "output",
+		Usage: "The file path to write the compressed node snapshot to",
+		Value: "node-snapshot.bin",
+	}
+	// SnapshotInput defines the node snapshot file the db import-snapshot command reads.
+	SnapshotInput = &cli.StringFlag{
+		Name:     "input",
+		Usage:    "The file path of the node snapshot to import",
+		Required: true,
+	}
+	// SnapshotBlockEpochs defines how many epochs of blocks preceding the finalized block are exported.
+	SnapshotBlockEpochs = &cli.Uint64Flag{
+		Name:  "block-epochs",
+		Usage: "The number of epochs of blocks preceding the finalized block to include in the node snapshot",
+		Value: 1,
+	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "enable-debug-rpc-endpoints",
 		Usage: "Enables the debug rpc service, containing utility endpoints such as /eth/v1alpha1/beacon/state. Requires --new-state-mgmt",
 	}
+	// BlockProposalBudget defines how long into the slot a block proposal may wait for its operations.
+	BlockProposalBudget = &cli.DurationFlag{
+		Name: "block-proposal-budget",
+		Usage: "Time from the start of the slot after which a block proposal stops waiting for ETH1 data and " +
+			"attestation packing, and is produced with the operations ready so far. 0 disables the budget",
+		Value: 2 * time.Second,
+	}
+	// MinAttestationInclusionReward defines the minimum estimated proposer reward of attestations packed into blocks.
+	MinAttestationInclusionReward = &cli.Uint64Flag{
+		Name: "min-attestation-inclusion-reward",
+		Usage: "Minimum estimated proposer reward, in Gwei, for including an attestation in a proposed block. " +
+			"Attestations below it are left out of blocks proposed through this beacon node",
+	}
+	// ExcludeAttestationCommittees defines the committee indices whose attestations are left out of proposed blocks.
+	ExcludeAttestationCommittees = &cli.IntSliceFlag{
+		Name:  "exclude-attestation-committees",
+		Usage: "Comma separated list of committee indices whose attestations are never included in proposed blocks",
+	}
+	// PreferOwnValidatorAttestations defines whether attestations of the node's own validators are packed first.
+	PreferOwnValidatorAttestations = &cli.BoolFlag{
+		Name: "prefer-own-validator-attestations",
+		Usage: "Pack the attestations of validators which request their duties from this beacon node first " +
+			"when proposing blocks",
+	}
+	// BroadcastProtectionValidators defines the validators whose slashable blocks and attestations are never broadcast.
+	BroadcastProtectionValidators = &cli.StringSliceFlag{
+		Name: "broadcast-protection-validators",
+		Usage: "Comma separated list of validator indices or hex encoded public keys whose blocks and attestations " +
+			"submitted to this beacon node are refused if they conflict with a block or attestation of the validator " +
+			"already seen by the node",
+	}
+	// RPCSlowRequestThreshold defines the duration after which a gRPC request is logged as slow.
+	RPCSlowRequestThreshold = &cli.DurationFlag{
+		Name: "rpc-slow-request-threshold",
+		Usage: "Log gRPC requests taking longer than this duration, along with the calling client and a summary " +
+			"of the request. 0 disables slow request logging",
+	}
+	// DisableGRPCReflection disables the gRPC server reflection service.
+	DisableGRPCReflection = &cli.BoolFlag{
+		Name:  "disable-grpc-reflection",
+		Usage: "Does not register the gRPC server reflection service used by tools such as grpcurl to discover the available services",
+	}
+	// DeepReorgThreshold defines the number of orphaned blocks from which a reorg is logged as a warning.
+	DeepReorgThreshold = &cli.Uint64Flag{
+		Name:  "deep-reorg-threshold",
+		Usage: "Log a warning for chain reorgs orphaning at least this many blocks. 0 disables the warning",
+		Value: 3,
+	}
+	// HeadLagWatchdogSlots defines the number of slots the head may fall behind the current slot.
+	HeadLagWatchdogSlots = &cli.Uint64Flag{
+		Name: "head-lag-watchdog-slots",
+		Usage: "Report the node as stalled, through logs and metrics, when its head is more than this many slots " +
+			"behind the current slot while not syncing. 0 disables the watchdog",
+	}
+	// HeadLagDumpDir defines the directory the head lag watchdog writes diagnostic dumps to.
+	HeadLagDumpDir = &cli.StringFlag{
+		Name: "head-lag-dump-dir",
+		Usage: "Directory to write the fork choice store, peers and pending queues to when the head lag watchdog " +
+			"reports the node as stalled",
+	}
 )
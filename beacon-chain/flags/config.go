@@ -13,12 +13,26 @@ type GlobalFlags struct {
 	EnableArchivedValidatorSetChanges bool
 	EnableArchivedBlocks              bool
 	EnableArchivedAttestations        bool
+	EnableArchivedStateDiffs          bool
+	EnableSlasher                     bool
+	EnableLightClientServer           bool
 	UnsafeSync                        bool
 	DisableDiscv5                     bool
+	SubscribeToAllSubnets             bool
 	MinimumSyncPeers                  int
 	MaxPageSize                       int
 	DeploymentBlock                   int
 	BlockBatchLimit                   int
+	BlocksByRangeMaxCount             int
+	BlocksByRangeTimeout              int
+	BlockValidationQueueSize          int
+	AttestationValidationQueueSize    int
+	SignatureVerificationWorkers      int
+	StateCacheSize                    int
+	CommitteeCacheSize                int
+	CheckpointStateCacheSize          int
+	SkipSlotCacheSize                 int
+	StateReplayBudget                 uint64
 }
 
 var globalConfig *GlobalFlags
@@ -52,13 +66,36 @@ func ConfigureGlobalFlags(ctx *cli.Context) {
 	if ctx.Bool(ArchiveAttestationsFlag.Name) {
 		cfg.EnableArchivedAttestations = true
 	}
+	if ctx.Bool(ArchiveStateDiffsFlag.Name) {
+		cfg.EnableArchivedStateDiffs = true
+	}
+	if ctx.Bool(SlasherFlag.Name) {
+		cfg.EnableSlasher = true
+	}
+	if ctx.Bool(EnableLightClientServer.Name) {
+		cfg.EnableLightClientServer = true
+	}
 	if ctx.Bool(UnsafeSync.Name) {
 		cfg.UnsafeSync = true
 	}
 	if ctx.Bool(DisableDiscv5.Name) {
 		cfg.DisableDiscv5 = true
 	}
+	if ctx.Bool(SubscribeToAllSubnets.Name) {
+		log.Warn("Subscribing to all attestation subnets")
+		cfg.SubscribeToAllSubnets = true
+	}
 	cfg.BlockBatchLimit = ctx.Int(BlockBatchLimit.Name)
+	cfg.BlocksByRangeMaxCount = ctx.Int(BlocksByRangeMaxCount.Name)
+	cfg.BlocksByRangeTimeout = ctx.Int(BlocksByRangeTimeout.Name)
+	cfg.BlockValidationQueueSize = ctx.Int(BlockValidationQueueSize.Name)
+	cfg.AttestationValidationQueueSize = ctx.Int(AttestationValidationQueueSize.Name)
+	cfg.SignatureVerificationWorkers = ctx.Int(SignatureVerificationWorkers.Name)
+	cfg.StateCacheSize = ctx.Int(StateCacheSize.Name)
+	cfg.CommitteeCacheSize = ctx.Int(CommitteeCacheSize.Name)
+	cfg.CheckpointStateCacheSize = ctx.Int(CheckpointStateCacheSize.Name)
+	cfg.SkipSlotCacheSize = ctx.Int(SkipSlotCacheSize.Name)
+	cfg.StateReplayBudget = ctx.Uint64(StateReplayBudget.Name)
 	cfg.MaxPageSize = ctx.Int(RPCMaxPageSize.Name)
 	cfg.DeploymentBlock = ctx.Int(ContractDeploymentBlock.Name)
 	configureMinimumPeers(ctx, cfg)
@@ -19,7 +19,7 @@ var (
 	// InteropGenesisTimeFlag specifies genesis time for state generation.
 	InteropGenesisTimeFlag = &cli.Uint64Flag{
 		Name: "interop-genesis-time",
-		Usage: "Specify the genesis time for interop genesis state generation. Must be used with " +
+		Usage: "Specify the genesis time for interop genesis state generation, defaults to the current time. Must be used with " +
 			"--interop-num-validators",
 	}
 	// InteropNumValidatorsFlag specifies number of genesis validators for state generation.
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/proto/beacon/db"
 )
 
 // ForkChoicer represents the full fork choice interface composed of all of the sub-interfaces.
@@ -13,6 +14,7 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Pruner               // to clean old data for fork choice.
 	Getter               // to retrieve fork choice information.
+	Checkpointer         // to persist fork choice across restarts.
 }
 
 // HeadRetriever retrieves head root of the current chain.
@@ -40,4 +42,10 @@ type Getter interface {
 	Nodes() []*protoarray.Node
 	Node([32]byte) *protoarray.Node
 	HasNode([32]byte) bool
+	Snapshot() *protoarray.Snapshot
+}
+
+// Checkpointer snapshots the fork choice store so it can be persisted and restored on startup.
+type Checkpointer interface {
+	Checkpoint() *db.ForkChoiceCheckpoint
 }
@@ -0,0 +1,81 @@
+package protoarray
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	pbdb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Checkpoint returns a snapshot of the fork choice store which can be persisted and restored
+// with NewFromCheckpoint. Node weights are not included, they are recomputed from the votes
+// on the next head computation.
+func (f *ForkChoice) Checkpoint() *pbdb.ForkChoiceCheckpoint {
+	f.store.nodeIndicesLock.RLock()
+	defer f.store.nodeIndicesLock.RUnlock()
+
+	finalizedRoot := f.store.finalizedRoot
+	cp := &pbdb.ForkChoiceCheckpoint{
+		JustifiedEpoch: f.store.justifiedEpoch,
+		FinalizedEpoch: f.store.finalizedEpoch,
+		FinalizedRoot:  finalizedRoot[:],
+		Nodes:          make([]*pbdb.ForkChoiceNode, len(f.store.nodes)),
+		Votes:          make([]*pbdb.ForkChoiceVote, len(f.votes)),
+	}
+	for i, n := range f.store.nodes {
+		root := n.root
+		parentRoot := params.BeaconConfig().ZeroHash
+		if n.Parent != nonExistentNode && n.Parent < uint64(len(f.store.nodes)) {
+			parentRoot = f.store.nodes[n.Parent].root
+		}
+		cp.Nodes[i] = &pbdb.ForkChoiceNode{
+			Slot:           n.Slot,
+			Root:           root[:],
+			ParentRoot:     parentRoot[:],
+			JustifiedEpoch: n.justifiedEpoch,
+			FinalizedEpoch: n.finalizedEpoch,
+		}
+	}
+	for i, v := range f.votes {
+		root := v.nextRoot
+		cp.Votes[i] = &pbdb.ForkChoiceVote{
+			Root:  root[:],
+			Epoch: v.nextEpoch,
+		}
+	}
+
+	return cp
+}
+
+// NewFromCheckpoint initializes a fork choice store from a snapshot returned by Checkpoint.
+// The restored votes are treated as not yet applied, so the next call to Head recomputes
+// the weights of all nodes from the justified balances.
+func NewFromCheckpoint(ctx context.Context, cp *pbdb.ForkChoiceCheckpoint) (*ForkChoice, error) {
+	if cp == nil {
+		return nil, errors.New("nil fork choice checkpoint")
+	}
+	f := New(cp.JustifiedEpoch, cp.FinalizedEpoch, bytesutil.ToBytes32(cp.FinalizedRoot))
+	for _, n := range cp.Nodes {
+		if n == nil {
+			return nil, errors.New("nil node in fork choice checkpoint")
+		}
+		if err := f.store.insert(ctx, n.Slot, bytesutil.ToBytes32(n.Root), bytesutil.ToBytes32(n.ParentRoot), n.JustifiedEpoch, n.FinalizedEpoch); err != nil {
+			return nil, errors.Wrap(err, "could not insert node")
+		}
+	}
+	f.votes = make([]Vote, len(cp.Votes))
+	for i, v := range cp.Votes {
+		if v == nil {
+			return nil, errors.New("nil vote in fork choice checkpoint")
+		}
+		f.votes[i] = Vote{
+			currentRoot: params.BeaconConfig().ZeroHash,
+			nextRoot:    bytesutil.ToBytes32(v.Root),
+			nextEpoch:   v.Epoch,
+		}
+	}
+
+	return f, nil
+}
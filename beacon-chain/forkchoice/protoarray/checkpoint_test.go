@@ -0,0 +1,75 @@
+package protoarray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestCheckpoint_RestoresHeadAndWeights(t *testing.T) {
+	ctx := context.Background()
+	balances := []uint64{1, 2, 3}
+	f := setup(1, 1)
+
+	// Build the tree below and vote for both branches:
+	//            0
+	//           / \
+	//          1   2
+	//          |
+	//          3
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 2, indexToHash(3), indexToHash(1), 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(3), 2)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(2), 2)
+	wantedHead, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wantedHead != indexToHash(3) {
+		t.Fatal("Incorrect head before checkpoint")
+	}
+
+	restored, err := NewFromCheckpoint(ctx, f.Checkpoint())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Nodes()) != len(f.Nodes()) {
+		t.Fatalf("Wanted %d nodes, received %d", len(f.Nodes()), len(restored.Nodes()))
+	}
+	if restored.store.finalizedEpoch != f.store.finalizedEpoch || restored.store.justifiedEpoch != f.store.justifiedEpoch {
+		t.Error("Restored checkpoint epochs do not match")
+	}
+	head, err := restored.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if head != wantedHead {
+		t.Errorf("Wanted head %#x, received %#x", wantedHead, head)
+	}
+	for _, n := range f.Nodes() {
+		restoredNode := restored.Node(n.Root())
+		if restoredNode == nil {
+			t.Fatalf("Node %#x was not restored", n.Root())
+		}
+		if restoredNode.Weight != n.Weight {
+			t.Errorf("Wanted weight %d for node %#x, received %d", n.Weight, n.Root(), restoredNode.Weight)
+		}
+		if restoredNode.Parent != n.Parent {
+			t.Errorf("Wanted parent %d for node %#x, received %d", n.Parent, n.Root(), restoredNode.Parent)
+		}
+	}
+}
+
+func TestNewFromCheckpoint_Nil(t *testing.T) {
+	if _, err := NewFromCheckpoint(context.Background(), nil); err == nil {
+		t.Error("Expected error restoring a nil checkpoint")
+	}
+}
@@ -101,6 +101,9 @@ func (f *ForkChoice) Prune(ctx context.Context, finalizedRoot [32]byte) error {
 
 // Nodes returns the copied list of block nodes in the fork choice store.
 func (f *ForkChoice) Nodes() []*Node {
+	f.store.nodeIndicesLock.RLock()
+	defer f.store.nodeIndicesLock.RUnlock()
+
 	cpy := make([]*Node, len(f.store.nodes))
 	copy(cpy, f.store.nodes)
 	return cpy
@@ -128,3 +131,41 @@ func (f *ForkChoice) HasNode(root [32]byte) bool {
 	_, ok := f.store.nodeIndices[root]
 	return ok
 }
+
+// Snapshot returns a copy of the checkpoints and block nodes of the fork choice store. The
+// exclusive lock is taken as head computation updates node weights under the read lock.
+func (f *ForkChoice) Snapshot() *Snapshot {
+	f.store.nodeIndicesLock.Lock()
+	defer f.store.nodeIndicesLock.Unlock()
+
+	nodes := make([]*Node, len(f.store.nodes))
+	for i, n := range f.store.nodes {
+		nodes[i] = copyNode(n)
+	}
+	return &Snapshot{
+		JustifiedEpoch: f.store.justifiedEpoch,
+		FinalizedEpoch: f.store.finalizedEpoch,
+		FinalizedRoot:  f.store.finalizedRoot,
+		Nodes:          nodes,
+	}
+}
+
+// Root of the fork choice node.
+func (n *Node) Root() [32]byte {
+	return n.root
+}
+
+// JustifiedEpoch of the fork choice node.
+func (n *Node) JustifiedEpoch() uint64 {
+	return n.justifiedEpoch
+}
+
+// FinalizedEpoch of the fork choice node.
+func (n *Node) FinalizedEpoch() uint64 {
+	return n.finalizedEpoch
+}
+
+// BestChild of the fork choice node.
+func (n *Node) BestChild() uint64 {
+	return n.bestChild
+}
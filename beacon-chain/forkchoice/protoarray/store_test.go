@@ -0,0 +1,67 @@
+package protoarray
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestForkChoice_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, []uint64{}, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := f.Snapshot()
+	if snapshot.JustifiedEpoch != 1 || snapshot.FinalizedEpoch != 1 {
+		t.Errorf("Unexpected checkpoints %d, %d", snapshot.JustifiedEpoch, snapshot.FinalizedEpoch)
+	}
+	if len(snapshot.Nodes) != 2 || snapshot.Nodes[1].Root() != indexToHash(1) {
+		t.Fatalf("Unexpected nodes %v", snapshot.Nodes)
+	}
+
+	// The snapshot is a copy which is not affected by later changes to the store.
+	snapshot.Nodes[1].Weight = 100
+	if f.store.nodes[1].Weight == 100 {
+		t.Error("Expected snapshot nodes to be copies")
+	}
+	if err := f.ProcessBlock(ctx, 2, indexToHash(2), indexToHash(1), 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Nodes) != 2 {
+		t.Errorf("Expected snapshot to keep 2 nodes, received %d", len(snapshot.Nodes))
+	}
+}
+
+func TestForkChoice_SnapshotDuringHead(t *testing.T) {
+	ctx := context.Background()
+	f := setup(1, 1)
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(1), 2)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			if _, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, []uint64{uint64(i)}, 1); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if len(f.Snapshot().Nodes) != 2 {
+			t.Error("Expected 2 nodes in snapshot")
+		}
+	}
+	wg.Wait()
+}
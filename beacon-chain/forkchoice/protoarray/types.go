@@ -20,6 +20,14 @@ type Store struct {
 	nodeIndicesLock sync.RWMutex
 }
 
+// Snapshot is a copy of the checkpoint information and block nodes of the fork choice store.
+type Snapshot struct {
+	JustifiedEpoch uint64   // latest justified epoch in store.
+	FinalizedEpoch uint64   // latest finalized epoch in store.
+	FinalizedRoot  [32]byte // latest finalized root in store.
+	Nodes          []*Node  // copied list of block nodes.
+}
+
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
 // This is used as an array based stateful DAG for efficient fork choice look up.
 type Node struct {
@@ -13,6 +13,7 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1_gateway"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1_gateway"
 	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/httputil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 )
@@ -25,11 +26,10 @@ type Gateway struct {
 	conn                    *grpc.ClientConn
 	ctx                     context.Context
 	cancel                  context.CancelFunc
-	gatewayAddr             string
+	serverCfg               *httputil.ServerConfig
 	remoteAddr              string
-	server                  *http.Server
+	server                  *httputil.Server
 	mux                     *http.ServeMux
-	allowedOrigins          []string
 	startFailure            error
 	enableDebugRPCEndpoints bool
 }
@@ -40,7 +40,7 @@ func (g *Gateway) Start() {
 	ctx, cancel := context.WithCancel(g.ctx)
 	g.cancel = cancel
 
-	log.WithField("address", g.gatewayAddr).Info("Starting gRPC gateway.")
+	log.WithField("address", g.serverCfg.Addr()).Info("Starting gRPC gateway.")
 
 	conn, err := dial(ctx, "tcp", g.remoteAddr)
 	if err != nil {
@@ -61,6 +61,12 @@ func (g *Gateway) Start() {
 		ethpb.RegisterNodeHandler,
 		ethpb.RegisterBeaconChainHandler,
 		ethpb.RegisterBeaconNodeValidatorHandler,
+		pbrpc.RegisterHealthHandler,
+		pbrpc.RegisterValidatorsHandler,
+		pbrpc.RegisterNodeHandler,
+		pbrpc.RegisterAttestationsHandler,
+		pbrpc.RegisterDutiesHandler,
+		pbrpc.RegisterLightClientHandler,
 	}
 	if g.enableDebugRPCEndpoints {
 		handlers = append(handlers, pbrpc.RegisterDebugHandler)
@@ -75,19 +81,8 @@ func (g *Gateway) Start() {
 
 	g.mux.Handle("/", gwmux)
 
-	g.server = &http.Server{
-		Addr:    g.gatewayAddr,
-		Handler: newCorsHandler(g.mux, g.allowedOrigins),
-	}
-	go func() {
-		if err := g.server.ListenAndServe(); err != http.ErrServerClosed {
-			log.WithError(err).Error("Failed to listen and serve")
-			g.startFailure = err
-			return
-		}
-	}()
-
-	return
+	g.server = httputil.NewServer("gRPC gateway", g.serverCfg, g.mux)
+	g.server.Start()
 }
 
 // Status of grpc gateway. Returns an error if this service is unhealthy.
@@ -95,6 +90,9 @@ func (g *Gateway) Status() error {
 	if g.startFailure != nil {
 		return g.startFailure
 	}
+	if err := g.server.Status(); err != nil {
+		return err
+	}
 
 	if s := g.conn.GetState(); s != connectivity.Ready {
 		return fmt.Errorf("grpc server is %s", s)
@@ -105,8 +103,10 @@ func (g *Gateway) Status() error {
 
 // Stop the gateway with a graceful shutdown.
 func (g *Gateway) Stop() error {
-	if err := g.server.Shutdown(g.ctx); err != nil {
-		log.WithError(err).Error("Failed to shut down server")
+	if g.server != nil {
+		if err := g.server.Stop(); err != nil {
+			log.WithError(err).Error("Failed to shut down server")
+		}
 	}
 
 	if g.cancel != nil {
@@ -116,14 +116,13 @@ func (g *Gateway) Stop() error {
 	return nil
 }
 
-// New returns a new gateway server which translates HTTP into gRPC.
-// Accepts a context and optional http.ServeMux.
+// New returns a new gateway server which translates HTTP into gRPC, serving HTTP according
+// to the server configuration. Accepts a context and optional http.ServeMux.
 func New(
 	ctx context.Context,
-	remoteAddress,
-	gatewayAddress string,
+	remoteAddress string,
+	serverCfg *httputil.ServerConfig,
 	mux *http.ServeMux,
-	allowedOrigins []string,
 	enableDebugRPCEndpoints bool,
 ) *Gateway {
 	if mux == nil {
@@ -132,10 +131,9 @@ func New(
 
 	return &Gateway{
 		remoteAddr:              remoteAddress,
-		gatewayAddr:             gatewayAddress,
+		serverCfg:               serverCfg,
 		ctx:                     ctx,
 		mux:                     mux,
-		allowedOrigins:          allowedOrigins,
 		enableDebugRPCEndpoints: enableDebugRPCEndpoints,
 	}
 }
@@ -7,10 +7,10 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
-	"strings"
 
 	joonix "github.com/joonix/log"
 	"github.com/prysmaticlabs/prysm/beacon-chain/gateway"
+	"github.com/prysmaticlabs/prysm/shared/httputil"
 	"github.com/sirupsen/logrus"
 	_ "go.uber.org/automaxprocs"
 )
@@ -21,6 +21,8 @@ var (
 	debug                   = flag.Bool("debug", false, "Enable debug logging")
 	allowedOrigins          = flag.String("corsdomain", "", "A comma separated list of CORS domains to allow")
 	enableDebugRPCEndpoints = flag.Bool("enable-debug-rpc-endpoints", false, "Enable debug rpc endpoints such as /eth/v1alpha1/beacon/state")
+	tlsCert                 = flag.String("tls-cert", "", "Certificate for serving HTTPS, along with --tls-key")
+	tlsKey                  = flag.String("tls-key", "", "Key for serving HTTPS, along with --tls-cert")
 )
 
 func init() {
@@ -39,9 +41,14 @@ func main() {
 	gw := gateway.New(
 		context.Background(),
 		*beaconRPC,
-		fmt.Sprintf("0.0.0.0:%d", *port),
+		&httputil.ServerConfig{
+			Host:           "0.0.0.0",
+			Port:           *port,
+			AllowedOrigins: httputil.SplitList(*allowedOrigins),
+			CertFile:       *tlsCert,
+			KeyFile:        *tlsKey,
+		},
 		mux,
-		strings.Split(*allowedOrigins, ","),
 		*enableDebugRPCEndpoints,
 	)
 	mux.HandleFunc("/swagger/", gateway.SwaggerServer())
@@ -8,11 +8,9 @@ import (
 	"io/ioutil"
 	"math/big"
 
-	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
-	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
@@ -32,7 +30,7 @@ type Service struct {
 	cancel             context.CancelFunc
 	genesisTime        uint64
 	numValidators      uint64
-	beaconDB           db.HeadAccessDatabase
+	beaconDB           db.Database
 	powchain           powchain.Service
 	depositCache       *depositcache.DepositCache
 	genesisPath        string
@@ -43,7 +41,7 @@ type Service struct {
 type Config struct {
 	GenesisTime   uint64
 	NumValidators uint64
-	BeaconDB      db.HeadAccessDatabase
+	BeaconDB      db.Database
 	DepositCache  *depositcache.DepositCache
 	GenesisPath   string
 }
@@ -65,6 +63,19 @@ func NewColdStartService(ctx context.Context, cfg *Config) *Service {
 		genesisPath:   cfg.GenesisPath,
 	}
 
+	// A restarted node must keep the genesis state it was started with, as regenerating it
+	// without a fixed genesis time would produce a different chain.
+	existing, err := s.beaconDB.GenesisState(ctx)
+	if err != nil {
+		log.Fatalf("Could not get genesis state: %v", err)
+	}
+	if existing != nil {
+		log.Info("Using genesis state from database")
+		s.genesisTime = existing.GenesisTime()
+		s.setChainStartDeposits(existing)
+		return s
+	}
+
 	if s.genesisPath != "" {
 		data, err := ioutil.ReadFile(s.genesisPath)
 		if err != nil {
@@ -155,54 +166,22 @@ func (s *Service) DepositsNumberAndRootAtHeight(ctx context.Context, blockHeight
 }
 
 func (s *Service) saveGenesisState(ctx context.Context, genesisState *stateTrie.BeaconState) error {
-	s.chainStartDeposits = make([]*ethpb.Deposit, genesisState.NumValidators())
-	stateRoot, err := genesisState.HashTreeRoot(ctx)
-	if err != nil {
-		return err
-	}
-	genesisBlk := blocks.NewGenesisBlock(stateRoot[:])
-	genesisBlkRoot, err := ssz.HashTreeRoot(genesisBlk.Block)
-	if err != nil {
-		return errors.Wrap(err, "could not get genesis block root")
-	}
-
-	if err := s.beaconDB.SaveBlock(ctx, genesisBlk); err != nil {
-		return errors.Wrap(err, "could not save genesis block")
-	}
-	if err := s.beaconDB.SaveStateSummary(ctx, &pb.StateSummary{
-		Slot: 0,
-		Root: genesisBlkRoot[:],
-	}); err != nil {
+	if err := s.beaconDB.SaveGenesisData(ctx, genesisState); err != nil {
 		return err
 	}
-	if err := s.beaconDB.SaveState(ctx, genesisState, genesisBlkRoot); err != nil {
-		return errors.Wrap(err, "could not save genesis state")
-	}
-	if err := s.beaconDB.SaveGenesisBlockRoot(ctx, genesisBlkRoot); err != nil {
-		return errors.Wrap(err, "could save genesis block root")
-	}
-	if err := s.beaconDB.SaveHeadBlockRoot(ctx, genesisBlkRoot); err != nil {
-		return errors.Wrap(err, "could not save head block root")
-	}
-	genesisCheckpoint := &ethpb.Checkpoint{Root: genesisBlkRoot[:]}
-	if err := s.beaconDB.SaveJustifiedCheckpoint(ctx, genesisCheckpoint); err != nil {
-		return errors.Wrap(err, "could save justified checkpoint")
-	}
-	if err := s.beaconDB.SaveFinalizedCheckpoint(ctx, genesisCheckpoint); err != nil {
-		return errors.Wrap(err, "could save finalized checkpoint")
-	}
+	s.setChainStartDeposits(genesisState)
+	return nil
+}
 
-	pubKeys := make([][48]byte, 0, genesisState.NumValidators())
-	indices := make([]uint64, 0, genesisState.NumValidators())
+// setChainStartDeposits mocks out the chain start deposits of the genesis validators.
+func (s *Service) setChainStartDeposits(genesisState *stateTrie.BeaconState) {
+	s.chainStartDeposits = make([]*ethpb.Deposit, genesisState.NumValidators())
 	for i := uint64(0); i < uint64(genesisState.NumValidators()); i++ {
 		pk := genesisState.PubkeyAtIndex(i)
-		pubKeys = append(pubKeys, pk)
-		indices = append(indices, i)
 		s.chainStartDeposits[i] = &ethpb.Deposit{
 			Data: &ethpb.Deposit_Data{
 				PublicKey: pk[:],
 			},
 		}
 	}
-	return nil
 }
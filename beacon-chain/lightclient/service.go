@@ -0,0 +1,153 @@
+// Package lightclient defines a service which stores the data needed to serve
+// light clients as the beacon chain finalizes.
+package lightclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "lightclient")
+
+// Service saving a light client finality update every time the head state
+// finalizes a new checkpoint.
+type Service struct {
+	ctx                context.Context
+	cancel             context.CancelFunc
+	beaconDB           db.NoHeadAccessDatabase
+	headFetcher        blockchain.HeadFetcher
+	stateNotifier      statefeed.Notifier
+	lastFinalizedEpoch uint64
+}
+
+// Config options for the light client service.
+type Config struct {
+	BeaconDB      db.NoHeadAccessDatabase
+	HeadFetcher   blockchain.HeadFetcher
+	StateNotifier statefeed.Notifier
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		beaconDB:      cfg.BeaconDB,
+		headFetcher:   cfg.HeadFetcher,
+		stateNotifier: cfg.StateNotifier,
+	}
+}
+
+// Start the light client service event loop.
+func (s *Service) Start() {
+	latest, err := s.beaconDB.LatestLightClientFinalityUpdate(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve latest light client finality update")
+	}
+	if latest != nil {
+		s.lastFinalizedEpoch = latest.FinalizedEpoch
+	}
+	go s.run(s.ctx)
+}
+
+// Stop the light client service event loop.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports the healthy status of the light client service. Returning nil means service
+// is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
+
+// saveFinalityUpdate builds a finality update proving the finalized checkpoint of the head
+// state against the head block header, and saves it to the database.
+func (s *Service) saveFinalityUpdate(ctx context.Context) (*pb.LightClientFinalityUpdate, error) {
+	headState, err := s.headFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	if headState == nil {
+		return nil, errors.New("nil head state")
+	}
+	finalized := headState.FinalizedCheckpoint()
+	if finalized == nil || finalized.Epoch <= s.lastFinalizedEpoch {
+		return nil, nil
+	}
+	headBlock, err := s.headFetcher.HeadBlock(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head block")
+	}
+	attestedHeader, err := BlockHeader(headBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build attested header")
+	}
+	finalizedBlock, err := s.beaconDB.Block(ctx, bytesutil.ToBytes32(finalized.Root))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized block")
+	}
+	finalizedHeader, err := BlockHeader(finalizedBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build finalized header")
+	}
+	branch, err := FinalityBranch(headState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build finality branch")
+	}
+	update := &pb.LightClientFinalityUpdate{
+		AttestedHeader:  attestedHeader,
+		FinalizedHeader: finalizedHeader,
+		FinalityBranch:  branch,
+		FinalizedEpoch:  finalized.Epoch,
+	}
+	if err := s.beaconDB.SaveLightClientFinalityUpdate(ctx, update); err != nil {
+		return nil, errors.Wrap(err, "could not save light client finality update")
+	}
+	s.lastFinalizedEpoch = finalized.Epoch
+	return update, nil
+}
+
+func (s *Service) run(ctx context.Context) {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type == statefeed.BlockProcessed {
+				data, ok := event.Data.(*statefeed.BlockProcessedData)
+				if !ok {
+					log.Error("Event feed data is not type *statefeed.BlockProcessedData")
+					continue
+				}
+				log.WithField("headRoot", fmt.Sprintf("%#x", data.BlockRoot)).Debug("Received block processed event")
+				update, err := s.saveFinalityUpdate(ctx)
+				if err != nil {
+					log.WithError(err).Error("Could not save light client finality update")
+					continue
+				}
+				if update != nil {
+					log.WithField("epoch", update.FinalizedEpoch).Debug("Saved light client finality update")
+				}
+			}
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Subscription to state feed notifier failed")
+			return
+		}
+	}
+}
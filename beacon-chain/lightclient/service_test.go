@@ -0,0 +1,90 @@
+package lightclient
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+func TestFinalityBranch_VerifiesAgainstStateRoot(t *testing.T) {
+	ctx := context.Background()
+	st := testutil.NewBeaconState()
+	if err := st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 3, Root: []byte{'a'}}); err != nil {
+		t.Fatal(err)
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	branch, err := FinalityBranch(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(branch) != 6 {
+		t.Fatalf("Wanted branch of depth 6, received %d", len(branch))
+	}
+	leafIndex := FinalizedRootGeneralizedIndex - (1 << len(branch))
+	if !trieutil.VerifyMerkleBranch(stateRoot[:], st.FinalizedCheckpoint().Root, leafIndex, branch) {
+		t.Error("Finality branch does not verify against the state root")
+	}
+}
+
+func TestService_SaveFinalityUpdate(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+
+	finalizedBlock := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 32, Body: &ethpb.BeaconBlockBody{}}}
+	if err := beaconDB.SaveBlock(ctx, finalizedBlock); err != nil {
+		t.Fatal(err)
+	}
+	finalizedRoot, err := ssz.HashTreeRoot(finalizedBlock.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := testutil.NewBeaconState()
+	if err := st.SetSlot(100); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+	headBlock := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 100, Body: &ethpb.BeaconBlockBody{}}}
+
+	svc := NewService(ctx, &Config{
+		BeaconDB:    beaconDB,
+		HeadFetcher: &mock.ChainService{State: st, Block: headBlock},
+	})
+	update, err := svc.saveFinalityUpdate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update == nil {
+		t.Fatal("Expected a finality update to be saved")
+	}
+	if update.FinalizedHeader.Slot != 32 || update.AttestedHeader.Slot != 100 {
+		t.Errorf("Unexpected header slots, finalized %d attested %d", update.FinalizedHeader.Slot, update.AttestedHeader.Slot)
+	}
+	saved, err := beaconDB.LatestLightClientFinalityUpdate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved == nil || saved.FinalizedEpoch != 1 {
+		t.Fatalf("Unexpected saved update %v", saved)
+	}
+
+	// The same finalized checkpoint should not be saved twice.
+	update, err = svc.saveFinalityUpdate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update != nil {
+		t.Error("Expected no update for an already saved finalized epoch")
+	}
+}
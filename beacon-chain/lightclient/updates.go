@@ -0,0 +1,67 @@
+package lightclient
+
+import (
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+const (
+	// stateFieldCount is the number of fields in the beacon state container.
+	stateFieldCount = 21
+	// finalizedCheckpointFieldIndex is the position of the finalized checkpoint in the beacon state container.
+	finalizedCheckpointFieldIndex = 20
+)
+
+// FinalizedRootGeneralizedIndex is the generalized index of the finalized checkpoint root
+// in the beacon state tree, which a light client uses to verify a finality branch.
+const FinalizedRootGeneralizedIndex = ((1<<5)+finalizedCheckpointFieldIndex)*2 + 1
+
+// BlockHeader returns the header of a signed beacon block.
+func BlockHeader(blk *ethpb.SignedBeaconBlock) (*ethpb.BeaconBlockHeader, error) {
+	if blk == nil || blk.Block == nil {
+		return nil, errors.New("nil block")
+	}
+	bodyRoot, err := stateutil.BlockBodyRoot(blk.Block.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block body root")
+	}
+	return &ethpb.BeaconBlockHeader{
+		Slot:          blk.Block.Slot,
+		ProposerIndex: blk.Block.ProposerIndex,
+		ParentRoot:    blk.Block.ParentRoot,
+		StateRoot:     blk.Block.StateRoot,
+		BodyRoot:      bodyRoot[:],
+	}, nil
+}
+
+// FinalityBranch returns the merkle branch of the finalized checkpoint root against the
+// hash tree root of the given state, ordered from the leaf up.
+func FinalityBranch(st *state.BeaconState) ([][]byte, error) {
+	if st == nil {
+		return nil, errors.New("nil state")
+	}
+	fieldRoots, err := stateutil.ComputeFieldRoots(st.InnerStateUnsafe())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute state field roots")
+	}
+	if len(fieldRoots) != stateFieldCount {
+		return nil, errors.Errorf("expected %d state field roots, received %d", stateFieldCount, len(fieldRoots))
+	}
+	// The finalized root is the second leaf of the checkpoint container, its sibling is the epoch.
+	epochRoot := stateutil.Uint64Root(st.FinalizedCheckpoint().Epoch)
+	hasher := stateutil.NewHasherFunc(hashutil.CustomSHA256Hasher())
+	leaf := func(i uint64) []byte {
+		return fieldRoots[i]
+	}
+	stateBranch := stateutil.ConstructProof(hasher, stateFieldCount, stateFieldCount, leaf, finalizedCheckpointFieldIndex)
+
+	branch := make([][]byte, 0, len(stateBranch)+1)
+	branch = append(branch, epochRoot[:])
+	for i := range stateBranch {
+		branch = append(branch, stateBranch[i][:])
+	}
+	return branch, nil
+}
@@ -4,7 +4,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"runtime"
 	runtimeDebug "runtime/debug"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
@@ -20,7 +19,6 @@ import (
 	"github.com/sirupsen/logrus"
 	gologging "github.com/whyrusleeping/go-logging"
 	prefixed "github.com/x-cray/logrus-prefixed-formatter"
-	_ "go.uber.org/automaxprocs"
 	"gopkg.in/urfave/cli.v2"
 	"gopkg.in/urfave/cli.v2/altsrc"
 )
@@ -29,18 +27,43 @@ var appFlags = []cli.Flag{
 	flags.DepositContractFlag,
 	flags.Web3ProviderFlag,
 	flags.HTTPWeb3ProviderFlag,
+	flags.Eth1ChainIDFlag,
+	flags.DepositSnapshotFlag,
+	flags.ExportDepositSnapshotFlag,
 	flags.RPCHost,
 	flags.RPCPort,
 	flags.CertFlag,
 	flags.KeyFlag,
+	flags.GRPCGatewayHost,
 	flags.GRPCGatewayPort,
+	flags.GPRCGatewayCorsDomain,
 	flags.MinSyncPeers,
 	flags.RPCMaxPageSize,
+	flags.RPCMaxRecvMsgSize,
+	flags.RPCMaxSendMsgSize,
+	flags.RPCMaxConcurrentStreams,
+	flags.RPCMaxConnections,
+	flags.RPCKeepaliveMinTime,
+	flags.RPCKeepalivePermitWithoutStream,
 	flags.ContractDeploymentBlock,
-	flags.SetGCPercent,
 	flags.UnsafeSync,
 	flags.DisableDiscv5,
+	flags.SubscribeToAllSubnets,
 	flags.BlockBatchLimit,
+	flags.BlocksByRangeMaxCount,
+	flags.BlocksByRangeTimeout,
+	flags.BlockValidationQueueSize,
+	flags.AttestationValidationQueueSize,
+	flags.SignatureVerificationWorkers,
+	flags.StateCacheSize,
+	flags.CommitteeCacheSize,
+	flags.CheckpointStateCacheSize,
+	flags.SkipSlotCacheSize,
+	flags.StateReplayBudget,
+	flags.GenesisStateFlag,
+	flags.WeakSubjectivityCheckpt,
+	flags.CompactDBFlag,
+	flags.DBBackupOutputDirFlag,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropGenesisStateFlag,
 	flags.InteropNumValidatorsFlag,
@@ -49,14 +72,35 @@ var appFlags = []cli.Flag{
 	flags.ArchiveValidatorSetChangesFlag,
 	flags.ArchiveBlocksFlag,
 	flags.ArchiveAttestationsFlag,
+	flags.ArchiveStateDiffsFlag,
+	flags.ArchiveBalancesRetentionFlag,
+	flags.ArchiveBalancesKeepEveryFlag,
+	flags.ArchiveCommitteesRetentionFlag,
+	flags.ArchiveCommitteesKeepEveryFlag,
+	flags.ArchiveParticipationRetentionFlag,
+	flags.ArchiveParticipationKeepEveryFlag,
 	flags.SlotsPerArchivedPoint,
+	flags.SlasherFlag,
+	flags.EnableLightClientServer,
 	flags.EnableDebugRPCEndpoints,
+	flags.DisableGRPCReflection,
+	flags.BlockProposalBudget,
+	flags.MinAttestationInclusionReward,
+	flags.ExcludeAttestationCommittees,
+	flags.PreferOwnValidatorAttestations,
+	flags.BroadcastProtectionValidators,
+	flags.RPCSlowRequestThreshold,
+	flags.DeepReorgThreshold,
+	flags.HeadLagWatchdogSlots,
+	flags.HeadLagDumpDir,
 	cmd.BootstrapNode,
 	cmd.NoDiscovery,
 	cmd.StaticPeers,
+	cmd.TrustedPeers,
 	cmd.RelayNode,
 	cmd.P2PUDPPort,
 	cmd.P2PTCPPort,
+	cmd.P2PQUICPort,
 	cmd.P2PIP,
 	cmd.P2PHost,
 	cmd.P2PHostDNS,
@@ -64,15 +108,29 @@ var appFlags = []cli.Flag{
 	cmd.P2PPrivKey,
 	cmd.P2PMetadata,
 	cmd.P2PWhitelist,
+	cmd.P2PAllowList,
+	cmd.P2PDenyList,
 	cmd.P2PEncoding,
 	cmd.P2PPubsub,
+	cmd.P2PScoreSnapshotInterval,
+	cmd.P2PScoreSnapshotFile,
+	cmd.P2PSeenMessagesTTL,
+	cmd.P2PSeenMessagesCacheSize,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
+	cmd.LogLevelOverrideFlag,
 	cmd.EnableTracingFlag,
 	cmd.TracingProcessNameFlag,
 	cmd.TracingEndpointFlag,
+	cmd.TracingExporterFlag,
+	cmd.TracingTagsFlag,
 	cmd.TraceSampleFractionFlag,
 	flags.MonitoringPortFlag,
+	cmd.MonitoringHostFlag,
+	cmd.MonitoringCorsDomainFlag,
+	cmd.HTTPTLSCertFlag,
+	cmd.HTTPTLSKeyFlag,
+	cmd.HTTPDisabledEndpointsFlag,
 	cmd.DisableMonitoringFlag,
 	cmd.ClearDB,
 	cmd.ForceClearDB,
@@ -84,13 +142,18 @@ var appFlags = []cli.Flag{
 	debug.MemProfileRateFlag,
 	debug.CPUProfileFlag,
 	debug.TraceFlag,
+	debug.GCPercentFlag,
+	debug.GCBallastFlag,
 	cmd.LogFileName,
 	cmd.EnableUPnPFlag,
 	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.NetworkFlag,
 }
 
 func init() {
-	appFlags = cmd.WrapFlags(append(appFlags, featureconfig.BeaconChainFlags...))
+	appFlags = append(appFlags, featureconfig.BeaconChainFlags...)
+	appFlags = cmd.WrapFlags(append(appFlags, cmd.AliasFlags(appFlags)...))
 }
 
 func main() {
@@ -102,6 +165,66 @@ func main() {
 	app.Version = version.GetVersion()
 
 	app.Flags = appFlags
+	app.Commands = []*cli.Command{
+		{
+			Name:     "db",
+			Category: "db",
+			Usage:    "defines commands for maintaining the beacon chain database",
+			Subcommands: []*cli.Command{
+				{
+					Name:        "compact",
+					Description: "rewrites the beacon chain database to reclaim the space of deleted data and reports the size of each bucket before and after. The beacon node must be stopped",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+					},
+					Action: node.CompactDB,
+				},
+				{
+					Name:        "export-state",
+					Description: "regenerates the beacon state at the given slot from the beacon chain database and writes it SSZ encoded to the output file. The beacon node must be stopped",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.SlotsPerArchivedPoint,
+						flags.ExportStateSlot,
+						flags.ExportStateOutput,
+					},
+					Action: node.ExportState,
+				},
+				{
+					Name:        "inspect",
+					Description: "reports the schema version of the beacon chain database and the number of keys and bytes used by each bucket, or dumps the keys of a bucket with the --bucket flag",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.InspectBucket,
+						flags.InspectPrefix,
+						flags.InspectLimit,
+					},
+					Action: node.InspectDB,
+				},
+				{
+					Name:        "export-snapshot",
+					Description: "writes a compressed snapshot of the finalized state, the finalized block and the blocks preceding it, and the finalized deposit tree snapshot to the output file. The beacon node must be stopped",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.SlotsPerArchivedPoint,
+						flags.SnapshotOutput,
+						flags.SnapshotBlockEpochs,
+					},
+					Action: node.ExportSnapshot,
+				},
+				{
+					Name:        "import-snapshot",
+					Description: "initializes an empty beacon chain database from a node snapshot written by export-snapshot, so the node starts syncing from the snapshot's finalized checkpoint",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.SlotsPerArchivedPoint,
+						flags.SnapshotInput,
+					},
+					Action: node.ImportSnapshot,
+				},
+			},
+		},
+	}
 
 	app.Before = func(ctx *cli.Context) error {
 		// Load any flags from file, if specified.
@@ -110,6 +233,9 @@ func main() {
 				return err
 			}
 		}
+		if err := cmd.ApplyRenamedFlags(ctx); err != nil {
+			return err
+		}
 
 		format := ctx.String(cmd.LogFormat.Name)
 		switch format {
@@ -135,6 +261,11 @@ func main() {
 		default:
 			return fmt.Errorf("unknown log format %s", format)
 		}
+		if format != "text" {
+			// Structured log output carries the service, current slot and epoch as fields.
+			logrus.AddHook(logutil.NewContextHook())
+		}
+		logrus.SetFormatter(logutil.WrapFormatter(logrus.StandardLogger().Formatter))
 
 		logFileName := ctx.String(cmd.LogFileName.Name)
 		if logFileName != "" {
@@ -143,10 +274,6 @@ func main() {
 			}
 		}
 
-		if ctx.IsSet(flags.SetGCPercent.Name) {
-			runtimeDebug.SetGCPercent(ctx.Int(flags.SetGCPercent.Name))
-		}
-		runtime.GOMAXPROCS(runtime.NumCPU())
 		return debug.Setup(ctx)
 	}
 
@@ -169,7 +296,12 @@ func startNode(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	logrus.SetLevel(level)
+	overrides, err := logutil.ParseLevelOverrides(ctx.StringSlice(cmd.LogLevelOverrideFlag.Name))
+	if err != nil {
+		return err
+	}
+	logutil.SetLevels(level, overrides)
+	logutil.ReloadLevelsOnSIGHUP(ctx.String(cmd.ConfigFileFlag.Name))
 	if level == logrus.TraceLevel {
 		// libp2p specific logging.
 		golog.SetAllLoggers(gologging.DEBUG)
@@ -0,0 +1,143 @@
+// Package maintenance puts the beacon node into maintenance mode ahead of a restart. The node
+// stops serving validator duties, drains gossip publishing and block processing, and saves the
+// data it only keeps in memory, so that it can be stopped without losing or corrupting work.
+package maintenance
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "maintenance")
+
+// BlockProcessor pauses the processing of incoming blocks and saves its caches to the DB.
+type BlockProcessor interface {
+	PauseBlockProcessing()
+	ResumeBlockProcessing()
+	FlushCaches(ctx context.Context) error
+}
+
+// BroadcastPauser pauses the publishing of gossip messages.
+type BroadcastPauser interface {
+	PauseBroadcasts()
+	ResumeBroadcasts()
+}
+
+// Status of the maintenance mode. The steps of entering maintenance mode complete in order, and
+// the node is safe to stop once all of them completed.
+type Status struct {
+	Enabled           bool
+	BroadcastsDrained bool
+	BlocksDrained     bool
+	CachesFlushed     bool
+	Err               error
+}
+
+// SafeToStop returns true if the node finished entering maintenance mode.
+func (s Status) SafeToStop() bool {
+	return s.Enabled && s.CachesFlushed
+}
+
+// Mode tracks whether the beacon node is in maintenance mode.
+type Mode struct {
+	ctx            context.Context
+	blocks         BlockProcessor
+	broadcasts     BroadcastPauser
+	transitionLock sync.Mutex
+	lock           sync.RWMutex
+	status         Status
+	drained        chan struct{}
+}
+
+// New creates the maintenance mode of the node, initially disabled. The context bounds the
+// flushing of caches when entering maintenance mode.
+func New(ctx context.Context, blocks BlockProcessor, broadcasts BroadcastPauser) *Mode {
+	return &Mode{
+		ctx:        ctx,
+		blocks:     blocks,
+		broadcasts: broadcasts,
+	}
+}
+
+// Enter puts the node into maintenance mode. Validator duties are rejected immediately, while
+// broadcasts and block processing are drained and caches flushed in the background; Status
+// reports when the node is safe to stop.
+func (m *Mode) Enter() {
+	m.transitionLock.Lock()
+	defer m.transitionLock.Unlock()
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.status.Enabled {
+		return
+	}
+	m.status = Status{Enabled: true}
+	m.drained = make(chan struct{})
+	log.Info("Entering maintenance mode")
+	go m.drain(m.drained)
+}
+
+// Exit takes the node out of maintenance mode, resuming broadcasts and block processing once
+// draining finished.
+func (m *Mode) Exit() {
+	m.transitionLock.Lock()
+	defer m.transitionLock.Unlock()
+	m.lock.Lock()
+	if !m.status.Enabled {
+		m.lock.Unlock()
+		return
+	}
+	drained := m.drained
+	m.status = Status{}
+	m.lock.Unlock()
+
+	<-drained
+	m.broadcasts.ResumeBroadcasts()
+	m.blocks.ResumeBlockProcessing()
+	log.Info("Exited maintenance mode")
+}
+
+// Enabled returns true if the node is in maintenance mode. It is safe to call on a nil mode.
+func (m *Mode) Enabled() bool {
+	if m == nil {
+		return false
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.status.Enabled
+}
+
+// Status returns the progress of entering maintenance mode.
+func (m *Mode) Status() Status {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.status
+}
+
+func (m *Mode) drain(drained chan struct{}) {
+	defer close(drained)
+
+	m.broadcasts.PauseBroadcasts()
+	m.update(func(s *Status) { s.BroadcastsDrained = true })
+
+	m.blocks.PauseBlockProcessing()
+	m.update(func(s *Status) { s.BlocksDrained = true })
+
+	if err := m.blocks.FlushCaches(m.ctx); err != nil {
+		log.WithError(err).Error("Could not flush caches to the DB")
+		m.update(func(s *Status) { s.Err = err })
+		return
+	}
+	m.update(func(s *Status) { s.CachesFlushed = true })
+	log.Info("Node is safe to stop")
+}
+
+// update applies the change to the status, unless maintenance mode was exited meanwhile.
+func (m *Mode) update(change func(s *Status)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.status.Enabled {
+		change(&m.status)
+	}
+}
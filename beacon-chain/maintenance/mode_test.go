@@ -0,0 +1,94 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockBlockProcessor struct {
+	paused   bool
+	flushed  bool
+	flushErr error
+}
+
+func (m *mockBlockProcessor) PauseBlockProcessing() {
+	m.paused = true
+}
+
+func (m *mockBlockProcessor) ResumeBlockProcessing() {
+	m.paused = false
+}
+
+func (m *mockBlockProcessor) FlushCaches(_ context.Context) error {
+	m.flushed = m.flushErr == nil
+	return m.flushErr
+}
+
+type mockBroadcastPauser struct {
+	paused bool
+}
+
+func (m *mockBroadcastPauser) PauseBroadcasts() {
+	m.paused = true
+}
+
+func (m *mockBroadcastPauser) ResumeBroadcasts() {
+	m.paused = false
+}
+
+func waitForDrain(t *testing.T, m *Mode) Status {
+	select {
+	case <-m.drained:
+	case <-time.After(time.Second):
+		t.Fatal("Maintenance mode was not entered within 1s")
+	}
+	return m.Status()
+}
+
+func TestMode_EnterAndExit(t *testing.T) {
+	blocks := &mockBlockProcessor{}
+	broadcasts := &mockBroadcastPauser{}
+	m := New(context.Background(), blocks, broadcasts)
+	if m.Enabled() {
+		t.Fatal("Expected maintenance mode to be disabled initially")
+	}
+
+	m.Enter()
+	if !m.Enabled() {
+		t.Fatal("Expected maintenance mode to be enabled")
+	}
+	status := waitForDrain(t, m)
+	if !status.BroadcastsDrained || !status.BlocksDrained || !status.CachesFlushed || !status.SafeToStop() {
+		t.Errorf("Expected every maintenance step to complete, received %+v", status)
+	}
+	if !blocks.paused || !blocks.flushed || !broadcasts.paused {
+		t.Error("Expected block processing and broadcasts to be paused and caches flushed")
+	}
+
+	m.Exit()
+	if m.Enabled() || m.Status().SafeToStop() {
+		t.Error("Expected maintenance mode to be disabled")
+	}
+	if blocks.paused || broadcasts.paused {
+		t.Error("Expected block processing and broadcasts to be resumed")
+	}
+}
+
+func TestMode_FlushError(t *testing.T) {
+	blocks := &mockBlockProcessor{flushErr: errors.New("bad")}
+	m := New(context.Background(), blocks, &mockBroadcastPauser{})
+	m.Enter()
+	status := waitForDrain(t, m)
+	if status.Err == nil || status.SafeToStop() {
+		t.Errorf("Expected the node not to be safe to stop, received %+v", status)
+	}
+}
+
+func TestMode_NilNotEnabled(t *testing.T) {
+	var m *Mode
+	if m.Enabled() {
+		t.Error("Expected a nil maintenance mode to be disabled")
+	}
+}
@@ -6,8 +6,11 @@ package node
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
 	"path"
@@ -18,26 +21,37 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/archiver"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/broadcastprotection"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	"github.com/prysmaticlabs/prysm/beacon-chain/gateway"
 	interopcoldstart "github.com/prysmaticlabs/prysm/beacon-chain/interop-cold-start"
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
+	"github.com/prysmaticlabs/prysm/beacon-chain/maintenance"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc"
+	"github.com/prysmaticlabs/prysm/beacon-chain/scheduler"
+	"github.com/prysmaticlabs/prysm/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	initialsync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync"
 	initialsyncold "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync-old"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/progress"
+	"github.com/prysmaticlabs/prysm/beacon-chain/watchdog"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/debug"
@@ -47,6 +61,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/prometheus"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/prysmaticlabs/prysm/shared/tracing"
+	"github.com/prysmaticlabs/prysm/shared/verifypool"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/urfave/cli.v2"
@@ -55,6 +70,7 @@ import (
 var log = logrus.WithField("prefix", "node")
 
 const beaconChainDBName = "beaconchaindata"
+const slasherDBName = "slasherdata"
 const testSkipPowFlag = "test-skip-pow"
 
 // BeaconNode defines a struct that handles the services running a random beacon chain
@@ -86,7 +102,9 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 	if err := tracing.Setup(
 		"beacon-chain", // service name
 		cliCtx.String(cmd.TracingProcessNameFlag.Name),
+		cliCtx.String(cmd.TracingExporterFlag.Name),
 		cliCtx.String(cmd.TracingEndpointFlag.Name),
+		cliCtx.StringSlice(cmd.TracingTagsFlag.Name),
 		cliCtx.Float64(cmd.TraceSampleFractionFlag.Name),
 		cliCtx.Bool(cmd.EnableTracingFlag.Name),
 	); err != nil {
@@ -95,6 +113,8 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 
 	featureconfig.ConfigureBeaconChain(cliCtx)
 	flags.ConfigureGlobalFlags(cliCtx)
+	configureCaches()
+	verifypool.Init(flags.Get().SignatureVerificationWorkers)
 	registry := shared.NewServiceRegistry()
 
 	ctx, cancel := context.WithCancel(cliCtx)
@@ -108,8 +128,6 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		blockFeed:         new(event.Feed),
 		opFeed:            new(event.Feed),
 		attestationPool:   attestations.NewPool(),
-		exitPool:          voluntaryexits.NewPool(),
-		slashingsPool:     slashings.NewPool(),
 		stateSummaryCache: cache.NewStateSummaryCache(),
 	}
 
@@ -117,7 +135,17 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
-	beacon.startStateGen()
+	if err := beacon.startStateGen(); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.startOperationPools(); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.registerScheduler(); err != nil {
+		return nil, err
+	}
 
 	if err := beacon.registerP2P(cliCtx); err != nil {
 		return nil, err
@@ -149,6 +177,10 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerBroadcastProtectionService(); err != nil {
+		return nil, err
+	}
+
 	if err := beacon.registerRPCService(); err != nil {
 		return nil, err
 	}
@@ -161,6 +193,18 @@ func NewBeaconNode(cliCtx *cli.Context) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	if err := beacon.registerLightClientService(); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.registerSlasherService(); err != nil {
+		return nil, err
+	}
+
+	if err := beacon.registerWatchdogService(); err != nil {
+		return nil, err
+	}
+
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		if err := beacon.registerPrometheusService(); err != nil {
 			return nil, err
@@ -244,6 +288,14 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 	clearDB := cliCtx.Bool(cmd.ClearDB.Name)
 	forceClearDB := cliCtx.Bool(cmd.ForceClearDB.Name)
 
+	if cliCtx.Bool(flags.CompactDBFlag.Name) {
+		if _, err := os.Stat(dbPath); err == nil {
+			if err := db.Compact(dbPath); err != nil {
+				return errors.Wrap(err, "could not compact database")
+			}
+		}
+	}
+
 	d, err := db.NewDB(dbPath, b.stateSummaryCache)
 	if err != nil {
 		return err
@@ -272,6 +324,19 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 			return err
 		}
 	}
+	if err := configureSlotsPerArchivedPoint(b.ctx, cliCtx, d); err != nil {
+		return err
+	}
+
+	genesisStatePath := cliCtx.String(flags.GenesisStateFlag.Name)
+	if network := selectedNetwork(cliCtx); network != nil && !cliCtx.IsSet(flags.GenesisStateFlag.Name) {
+		genesisStatePath = network.GenesisState
+	}
+	if genesisStatePath != "" {
+		if err := loadGenesisState(b.ctx, d, genesisStatePath); err != nil {
+			return errors.Wrap(err, "could not load genesis state")
+		}
+	}
 
 	log.WithField("database-path", dbPath).Info("Checking DB")
 	b.db = d
@@ -279,13 +344,211 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context) error {
 	return nil
 }
 
-func (b *BeaconNode) startStateGen() {
+// loadGenesisState initializes the database with the SSZ encoded genesis state at the given
+// file path or http(s) URL.
+func loadGenesisState(ctx context.Context, d db.Database, genesisStatePath string) error {
+	var r io.ReadCloser
+	if strings.HasPrefix(genesisStatePath, "http://") || strings.HasPrefix(genesisStatePath, "https://") {
+		resp, err := http.Get(genesisStatePath)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			_ = resp.Body.Close()
+			return fmt.Errorf("could not fetch genesis state, received status %s", resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(genesisStatePath)
+		if err != nil {
+			return err
+		}
+		r = f
+	}
+	defer func() {
+		if err := r.Close(); err != nil {
+			log.WithError(err).Error("Could not close genesis state")
+		}
+	}()
+	log.WithField("genesisState", genesisStatePath).Info("Loading genesis state")
+	return d.LoadGenesis(ctx, r)
+}
+
+// configureCaches sets the max sizes of the caches from the global flags.
+func configureCaches() {
+	cache.Configure(&cache.Config{
+		CommitteeCacheSize:       flags.Get().CommitteeCacheSize,
+		CheckpointStateCacheSize: flags.Get().CheckpointStateCacheSize,
+	})
+	state.SkipSlotCache.Resize(flags.Get().SkipSlotCacheSize)
+}
+
+// startOperationPools creates the slashings and voluntary exits pools and loads the pending
+// operations persisted by a previous run of the node.
+func (b *BeaconNode) startOperationPools() error {
+	b.slashingsPool = slashings.NewPoolWithDatabase(b.db)
+	b.exitPool = voluntaryexits.NewPoolWithDatabase(b.db)
+	headState, err := b.db.HeadState(b.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if headState == nil {
+		return nil
+	}
+	if err := b.slashingsPool.LoadPendingSlashings(b.ctx, headState); err != nil {
+		return err
+	}
+	return b.exitPool.LoadPendingExits(b.ctx, headState)
+}
+
+// CompactDB compacts the beacon chain database in the data directory specified by the cli
+// context. The beacon node must not be running.
+func CompactDB(cliCtx *cli.Context) error {
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), beaconChainDBName)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return fmt.Errorf("no beacon chain database found at %s", dbPath)
+	}
+	return db.Compact(dbPath)
+}
+
+// InspectDB reports the buckets of the beacon chain database in the data directory
+// specified by the cli context, or dumps the keys of a bucket if one is requested.
+func InspectDB(cliCtx *cli.Context) error {
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), beaconChainDBName)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return fmt.Errorf("no beacon chain database found at %s", dbPath)
+	}
+	bucket := cliCtx.String(flags.InspectBucket.Name)
+	if bucket == "" {
+		return db.Inspect(dbPath, os.Stdout)
+	}
+	prefix, err := hex.DecodeString(strings.TrimPrefix(cliCtx.String(flags.InspectPrefix.Name), "0x"))
+	if err != nil {
+		return errors.Wrapf(err, "could not decode %s", flags.InspectPrefix.Name)
+	}
+	return db.DumpKeys(dbPath, bucket, prefix, cliCtx.Int(flags.InspectLimit.Name), os.Stdout)
+}
+
+// ExportState regenerates the state at the requested slot from the beacon chain
+// database and writes it SSZ encoded to the requested output file. The state is
+// regenerated by replaying blocks on top of the closest saved state.
+func ExportState(cliCtx *cli.Context) error {
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), beaconChainDBName)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return fmt.Errorf("no beacon chain database found at %s", dbPath)
+	}
+	stateSummaryCache := cache.NewStateSummaryCache()
+	d, err := db.NewDB(dbPath, stateSummaryCache)
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	ctx := context.Background()
+	if err := configureSlotsPerArchivedPoint(ctx, cliCtx, d); err != nil {
+		return err
+	}
+	sg := stategen.New(d, stateSummaryCache)
+	if _, err := sg.Resume(ctx); err != nil {
+		return errors.Wrap(err, "could not resume state management from database")
+	}
+	slot := cliCtx.Uint64(flags.ExportStateSlot.Name)
+	st, err := sg.StateBySlot(ctx, slot)
+	if err != nil {
+		return errors.Wrapf(err, "could not regenerate state at slot %d", slot)
+	}
+	if st == nil {
+		return fmt.Errorf("no state could be regenerated at slot %d", slot)
+	}
+	enc, err := ssz.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state")
+	}
+	output := cliCtx.String(flags.ExportStateOutput.Name)
+	if err := ioutil.WriteFile(output, enc, 0600); err != nil {
+		return errors.Wrapf(err, "could not write state to %s", output)
+	}
+	log.WithFields(logrus.Fields{
+		"slot":   st.Slot(),
+		"output": output,
+		"size":   len(enc),
+	}).Info("Exported state")
+	return nil
+}
+
+// configureSlotsPerArchivedPoint sets the archived point frequency of the beacon config to
+// the one the database is written with. Archived points are indexed by slot over the
+// frequency, so it is saved in the database on first start, from the flag if set, and
+// the node refuses to start with a flag value not matching the saved one.
+func configureSlotsPerArchivedPoint(ctx context.Context, cliCtx *cli.Context, d db.Database) error {
+	slotsPerArchivedPoint := params.BeaconConfig().SlotsPerArchivedPoint
+	flagSet := cliCtx.IsSet(flags.SlotsPerArchivedPoint.Name)
+	if flagSet {
+		slotsPerArchivedPoint = uint64(cliCtx.Int(flags.SlotsPerArchivedPoint.Name))
+		if !stategen.VerifySlotsPerArchivePoint(slotsPerArchivedPoint) {
+			return fmt.Errorf("%s must be a non-zero multiple of %d slots per epoch, received %d",
+				flags.SlotsPerArchivedPoint.Name, params.BeaconConfig().SlotsPerEpoch, slotsPerArchivedPoint)
+		}
+	}
+	saved, err := d.SlotsPerArchivedPoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not read slots per archived point from database")
+	}
+	if saved == 0 {
+		lastArchivedIndex, err := d.LastArchivedIndex(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not read last archived index from database")
+		}
+		// Databases which archived states before the frequency was saved were written
+		// with the default one.
+		if lastArchivedIndex > 0 || d.HasArchivedPoint(ctx, 0) {
+			saved = params.BeaconConfig().SlotsPerArchivedPoint
+		}
+	}
+	if saved != 0 && saved != slotsPerArchivedPoint {
+		if flagSet {
+			return fmt.Errorf("%s is %d, but the database is written with %d slots per archived point, "+
+				"remove the flag or resync the database to change it",
+				flags.SlotsPerArchivedPoint.Name, slotsPerArchivedPoint, saved)
+		}
+		slotsPerArchivedPoint = saved
+	}
+	if err := d.SaveSlotsPerArchivedPoint(ctx, slotsPerArchivedPoint); err != nil {
+		return errors.Wrap(err, "could not save slots per archived point")
+	}
+	c := params.BeaconConfig()
+	c.SlotsPerArchivedPoint = slotsPerArchivedPoint
+	params.OverrideBeaconConfig(c)
+	return nil
+}
+
+func (b *BeaconNode) startStateGen() error {
 	b.stateGen = stategen.New(b.db, b.stateSummaryCache)
+	return nil
+}
+
+func (b *BeaconNode) registerScheduler() error {
+	svc := scheduler.NewService(b.ctx, &scheduler.Config{
+		StateNotifier: b,
+	})
+	return b.services.RegisterService(svc)
 }
 
 func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
+	var schedulerService *scheduler.Service
+	if err := b.services.FetchService(&schedulerService); err != nil {
+		return err
+	}
+
 	// Bootnode ENR may be a filepath to an ENR file.
 	bootnodeAddrs := strings.Split(cliCtx.String(cmd.BootstrapNode.Name), ",")
+	if network := selectedNetwork(cliCtx); network != nil && !cliCtx.IsSet(cmd.BootstrapNode.Name) && len(network.BootstrapNodes) > 0 {
+		bootnodeAddrs = append([]string{}, network.BootstrapNodes...)
+	}
 	for i, addr := range bootnodeAddrs {
 		if filepath.Ext(addr) == ".enr" {
 			b, err := ioutil.ReadFile(addr)
@@ -302,25 +565,35 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 	}
 
 	svc, err := p2p.NewService(&p2p.Config{
-		NoDiscovery:       cliCtx.Bool(cmd.NoDiscovery.Name),
-		StaticPeers:       sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
-		BootstrapNodeAddr: bootnodeAddrs,
-		RelayNodeAddr:     cliCtx.String(cmd.RelayNode.Name),
-		DataDir:           datadir,
-		LocalIP:           cliCtx.String(cmd.P2PIP.Name),
-		HostAddress:       cliCtx.String(cmd.P2PHost.Name),
-		HostDNS:           cliCtx.String(cmd.P2PHostDNS.Name),
-		PrivateKey:        cliCtx.String(cmd.P2PPrivKey.Name),
-		MetaDataDir:       cliCtx.String(cmd.P2PMetadata.Name),
-		TCPPort:           cliCtx.Uint(cmd.P2PTCPPort.Name),
-		UDPPort:           cliCtx.Uint(cmd.P2PUDPPort.Name),
-		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
-		WhitelistCIDR:     cliCtx.String(cmd.P2PWhitelist.Name),
-		EnableUPnP:        cliCtx.Bool(cmd.EnableUPnPFlag.Name),
-		DisableDiscv5:     cliCtx.Bool(flags.DisableDiscv5.Name),
-		Encoding:          cliCtx.String(cmd.P2PEncoding.Name),
-		StateNotifier:     b,
-		PubSub:            cliCtx.String(cmd.P2PPubsub.Name),
+		NoDiscovery:           cliCtx.Bool(cmd.NoDiscovery.Name),
+		StaticPeers:           sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.StaticPeers.Name)),
+		TrustedPeers:          sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.TrustedPeers.Name)),
+		BootstrapNodeAddr:     bootnodeAddrs,
+		RelayNodeAddr:         cliCtx.String(cmd.RelayNode.Name),
+		DataDir:               datadir,
+		LocalIP:               cliCtx.String(cmd.P2PIP.Name),
+		HostAddress:           cliCtx.String(cmd.P2PHost.Name),
+		HostDNS:               cliCtx.String(cmd.P2PHostDNS.Name),
+		PrivateKey:            cliCtx.String(cmd.P2PPrivKey.Name),
+		MetaDataDir:           cliCtx.String(cmd.P2PMetadata.Name),
+		TCPPort:               cliCtx.Uint(cmd.P2PTCPPort.Name),
+		UDPPort:               cliCtx.Uint(cmd.P2PUDPPort.Name),
+		QUICPort:              cliCtx.Uint(cmd.P2PQUICPort.Name),
+		MaxPeers:              cliCtx.Uint(cmd.P2PMaxPeers.Name),
+		WhitelistCIDR:         cliCtx.String(cmd.P2PWhitelist.Name),
+		AllowList:             sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PAllowList.Name)),
+		DenyList:              sliceutil.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
+		EnableUPnP:            cliCtx.Bool(cmd.EnableUPnPFlag.Name),
+		DisableDiscv5:         cliCtx.Bool(flags.DisableDiscv5.Name),
+		SubscribeAllSubnets:   cliCtx.Bool(flags.SubscribeToAllSubnets.Name),
+		Encoding:              cliCtx.String(cmd.P2PEncoding.Name),
+		StateNotifier:         b,
+		PubSub:                cliCtx.String(cmd.P2PPubsub.Name),
+		ScoreSnapshotInterval: cliCtx.Duration(cmd.P2PScoreSnapshotInterval.Name),
+		ScoreSnapshotFile:     cliCtx.String(cmd.P2PScoreSnapshotFile.Name),
+		SeenMessagesTTL:       cliCtx.Duration(cmd.P2PSeenMessagesTTL.Name),
+		PeerStore:             b.db,
+		Scheduler:             schedulerService,
 	})
 	if err != nil {
 		return err
@@ -337,8 +610,13 @@ func (b *BeaconNode) fetchP2P() p2p.P2P {
 }
 
 func (b *BeaconNode) registerAttestationPool() error {
+	var schedulerService *scheduler.Service
+	if err := b.services.FetchService(&schedulerService); err != nil {
+		return err
+	}
 	s, err := attestations.NewService(b.ctx, &attestations.Config{
-		Pool: b.attestationPool,
+		Pool:      b.attestationPool,
+		Scheduler: schedulerService,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not register atts pool service")
@@ -357,6 +635,15 @@ func (b *BeaconNode) registerBlockchainService() error {
 		return err
 	}
 
+	var wsCheckpt *ethpb.Checkpoint
+	if wsCheckpointString := b.cliCtx.String(flags.WeakSubjectivityCheckpt.Name); wsCheckpointString != "" {
+		var err error
+		wsCheckpt, err = helpers.ParseWeakSubjectivityInputString(wsCheckpointString)
+		if err != nil {
+			return errors.Wrap(err, "could not parse weak subjectivity checkpoint")
+		}
+	}
+
 	maxRoutines := b.cliCtx.Int64(cmd.MaxGoroutines.Name)
 	blockchainService, err := blockchain.NewService(b.ctx, &blockchain.Config{
 		BeaconDB:          b.db,
@@ -371,6 +658,8 @@ func (b *BeaconNode) registerBlockchainService() error {
 		ForkChoiceStore:   b.forkChoiceStore,
 		OpsService:        opsService,
 		StateGen:          b.stateGen,
+		WsCheckpt:         wsCheckpt,
+		DeepReorgDepth:    b.cliCtx.Uint64(flags.DeepReorgThreshold.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not register blockchain service")
@@ -383,6 +672,9 @@ func (b *BeaconNode) registerPOWChainService() error {
 		return b.services.RegisterService(&powchain.Service{})
 	}
 	depAddress := b.cliCtx.String(flags.DepositContractFlag.Name)
+	if !b.cliCtx.IsSet(flags.DepositContractFlag.Name) && params.BeaconConfig().DepositContractAddress != "" {
+		depAddress = params.BeaconConfig().DepositContractAddress
+	}
 	if depAddress == "" {
 		log.Fatal(fmt.Sprintf("%s is required", flags.DepositContractFlag.Name))
 	}
@@ -392,12 +684,15 @@ func (b *BeaconNode) registerPOWChainService() error {
 	}
 
 	cfg := &powchain.Web3ServiceConfig{
-		ETH1Endpoint:    b.cliCtx.String(flags.Web3ProviderFlag.Name),
-		HTTPEndPoint:    b.cliCtx.String(flags.HTTPWeb3ProviderFlag.Name),
-		DepositContract: common.HexToAddress(depAddress),
-		BeaconDB:        b.db,
-		DepositCache:    b.depositCache,
-		StateNotifier:   b,
+		ETH1Endpoint:              b.cliCtx.String(flags.Web3ProviderFlag.Name),
+		HTTPEndpoints:             strings.Split(b.cliCtx.String(flags.HTTPWeb3ProviderFlag.Name), ","),
+		ChainID:                   b.cliCtx.Uint64(flags.Eth1ChainIDFlag.Name),
+		DepositContract:           common.HexToAddress(depAddress),
+		BeaconDB:                  b.db,
+		DepositCache:              b.depositCache,
+		StateNotifier:             b,
+		DepositSnapshotPath:       b.cliCtx.String(flags.DepositSnapshotFlag.Name),
+		DepositSnapshotExportPath: b.cliCtx.String(flags.ExportDepositSnapshotFlag.Name),
 	}
 	web3Service, err := powchain.NewService(b.ctx, cfg)
 	if err != nil {
@@ -445,18 +740,19 @@ func (b *BeaconNode) registerSyncService() error {
 	}
 
 	rs := prysmsync.NewRegularSync(&prysmsync.Config{
-		DB:                  b.db,
-		P2P:                 b.fetchP2P(),
-		Chain:               chainService,
-		InitialSync:         initSync,
-		StateNotifier:       b,
-		BlockNotifier:       b,
-		AttestationNotifier: b,
-		AttPool:             b.attestationPool,
-		ExitPool:            b.exitPool,
-		SlashingPool:        b.slashingsPool,
-		StateSummaryCache:   b.stateSummaryCache,
-		StateGen:            b.stateGen,
+		DB:                    b.db,
+		P2P:                   b.fetchP2P(),
+		Chain:                 chainService,
+		InitialSync:           initSync,
+		StateNotifier:         b,
+		BlockNotifier:         b,
+		AttestationNotifier:   b,
+		AttPool:               b.attestationPool,
+		ExitPool:              b.exitPool,
+		SlashingPool:          b.slashingsPool,
+		StateSummaryCache:     b.stateSummaryCache,
+		StateGen:              b.stateGen,
+		SeenMessagesCacheSize: b.cliCtx.Int(cmd.P2PSeenMessagesCacheSize.Name),
 	})
 
 	return b.services.RegisterService(rs)
@@ -500,19 +796,29 @@ func (b *BeaconNode) registerRPCService() error {
 		return err
 	}
 
+	var broadcastProtection *broadcastprotection.Service
+	if len(b.cliCtx.StringSlice(flags.BroadcastProtectionValidators.Name)) > 0 {
+		if err := b.services.FetchService(&broadcastProtection); err != nil {
+			return err
+		}
+	}
+
 	var syncService prysmsync.Checker
+	var syncProgress progress.Fetcher
 	if cfg := featureconfig.Get(); cfg.DisableInitSyncQueue {
 		var initSyncTmp *initialsyncold.Service
 		if err := b.services.FetchService(&initSyncTmp); err != nil {
 			return err
 		}
 		syncService = initSyncTmp
+		syncProgress = initSyncTmp
 	} else {
 		var initSyncTmp *initialsync.Service
 		if err := b.services.FetchService(&initSyncTmp); err != nil {
 			return err
 		}
 		syncService = initSyncTmp
+		syncProgress = initSyncTmp
 	}
 
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
@@ -539,7 +845,26 @@ func (b *BeaconNode) registerRPCService() error {
 	slasherProvider := b.cliCtx.String(flags.SlasherProviderFlag.Name)
 	mockEth1DataVotes := b.cliCtx.Bool(flags.InteropMockEth1DataVotesFlag.Name)
 	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
+	serverLimits := rpc.ServerLimits{
+		MaxRecvMsgSize:               b.cliCtx.Int(flags.RPCMaxRecvMsgSize.Name),
+		MaxSendMsgSize:               b.cliCtx.Int(flags.RPCMaxSendMsgSize.Name),
+		MaxConcurrentStreams:         uint32(b.cliCtx.Uint(flags.RPCMaxConcurrentStreams.Name)),
+		MaxConnections:               b.cliCtx.Int(flags.RPCMaxConnections.Name),
+		KeepaliveMinTime:             b.cliCtx.Duration(flags.RPCKeepaliveMinTime.Name),
+		KeepalivePermitWithoutStream: b.cliCtx.Bool(flags.RPCKeepalivePermitWithoutStream.Name),
+	}
+	excludedCommittees := make([]uint64, 0)
+	for _, committeeIndex := range b.cliCtx.IntSlice(flags.ExcludeAttestationCommittees.Name) {
+		if committeeIndex < 0 {
+			return fmt.Errorf("invalid committee index %d in --%s", committeeIndex, flags.ExcludeAttestationCommittees.Name)
+		}
+		excludedCommittees = append(excludedCommittees, uint64(committeeIndex))
+	}
 	p2pService := b.fetchP2P()
+	var broadcastPauser *p2p.Service
+	if err := b.services.FetchService(&broadcastPauser); err != nil {
+		return err
+	}
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		Host:                    host,
 		Port:                    port,
@@ -548,10 +873,12 @@ func (b *BeaconNode) registerRPCService() error {
 		BeaconDB:                b.db,
 		Broadcaster:             p2pService,
 		PeersFetcher:            p2pService,
+		IdentityProvider:        p2pService,
 		HeadFetcher:             chainService,
 		ForkFetcher:             chainService,
 		FinalizationFetcher:     chainService,
 		ParticipationFetcher:    chainService,
+		ForkChoiceFetcher:       chainService,
 		BlockReceiver:           chainService,
 		AttestationReceiver:     chainService,
 		GenesisTimeFetcher:      chainService,
@@ -562,7 +889,15 @@ func (b *BeaconNode) registerRPCService() error {
 		POWChainService:         web3Service,
 		ChainStartFetcher:       chainStartFetcher,
 		MockEth1Votes:           mockEth1DataVotes,
+		BlockProposalBudget:     b.cliCtx.Duration(flags.BlockProposalBudget.Name),
+		MinAttInclusionReward:   b.cliCtx.Uint64(flags.MinAttestationInclusionReward.Name),
+		ExcludedAttCommittees:   excludedCommittees,
+		PreferOwnValidatorAtts:  b.cliCtx.Bool(flags.PreferOwnValidatorAttestations.Name),
+		SlowRequestThreshold:    b.cliCtx.Duration(flags.RPCSlowRequestThreshold.Name),
+		ServerLimits:            serverLimits,
 		SyncService:             syncService,
+		SyncProgress:            syncProgress,
+		BroadcastProtection:     broadcastProtection,
 		DepositFetcher:          depositFetcher,
 		PendingDepositFetcher:   b.depositCache,
 		BlockNotifier:           b,
@@ -572,11 +907,27 @@ func (b *BeaconNode) registerRPCService() error {
 		SlasherProvider:         slasherProvider,
 		StateGen:                b.stateGen,
 		EnableDebugRPCEndpoints: enableDebugRPCEndpoints,
+		EnableLightClientServer: flags.Get().EnableLightClientServer,
+		DisableReflection:       b.cliCtx.Bool(flags.DisableGRPCReflection.Name),
+		DatabaseBackuper:        b.db,
+		DatabaseCompacter:       b.db,
+		BackupOutputDir:         b.backupOutputDir(),
+		Maintenance:             maintenance.New(b.ctx, chainService, broadcastPauser),
+		DepositProcessing:       web3Service,
 	})
 
 	return b.services.RegisterService(rpcService)
 }
 
+// backupOutputDir returns the directory database backups are written to, which defaults
+// to the backups directory of the database.
+func (b *BeaconNode) backupOutputDir() string {
+	if dir := b.cliCtx.String(flags.DBBackupOutputDirFlag.Name); dir != "" {
+		return dir
+	}
+	return path.Join(b.db.DatabasePath(), "backups")
+}
+
 func (b *BeaconNode) registerPrometheusService() error {
 	var additionalHandlers []prometheus.Handler
 	var p *p2p.Service
@@ -591,13 +942,18 @@ func (b *BeaconNode) registerPrometheusService() error {
 	}
 
 	if featureconfig.Get().EnableBackupWebhook {
-		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/db/backup", Handler: db.BackupHandler(b.db)})
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/db/backup", Handler: db.BackupHandler(b.db, b.cliCtx.String(flags.DBBackupOutputDirFlag.Name))})
 	}
 
 	additionalHandlers = append(additionalHandlers, prometheus.Handler{Path: "/tree", Handler: c.TreeHandler})
 
 	service := prometheus.NewPrometheusService(
-		fmt.Sprintf(":%d", b.cliCtx.Int64(flags.MonitoringPortFlag.Name)),
+		cmd.HTTPServerConfig(
+			b.cliCtx,
+			b.cliCtx.String(cmd.MonitoringHostFlag.Name),
+			int(b.cliCtx.Int64(flags.MonitoringPortFlag.Name)),
+			b.cliCtx.String(cmd.MonitoringCorsDomainFlag.Name),
+		),
 		b.services,
 		additionalHandlers...,
 	)
@@ -610,16 +966,19 @@ func (b *BeaconNode) registerGRPCGateway() error {
 	gatewayPort := b.cliCtx.Int(flags.GRPCGatewayPort.Name)
 	if gatewayPort > 0 {
 		selfAddress := fmt.Sprintf("127.0.0.1:%d", b.cliCtx.Int(flags.RPCPort.Name))
-		gatewayAddress := fmt.Sprintf("0.0.0.0:%d", gatewayPort)
-		allowedOrigins := strings.Split(b.cliCtx.String(flags.GPRCGatewayCorsDomain.Name), ",")
+		serverCfg := cmd.HTTPServerConfig(
+			b.cliCtx,
+			b.cliCtx.String(flags.GRPCGatewayHost.Name),
+			gatewayPort,
+			b.cliCtx.String(flags.GPRCGatewayCorsDomain.Name),
+		)
 		enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
 		return b.services.RegisterService(
 			gateway.New(
 				b.ctx,
 				selfAddress,
-				gatewayAddress,
+				serverCfg,
 				nil, /*optional mux*/
-				allowedOrigins,
 				enableDebugRPCEndpoints,
 			),
 		)
@@ -632,6 +991,9 @@ func (b *BeaconNode) registerInteropServices() error {
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
 	genesisStatePath := b.cliCtx.String(flags.InteropGenesisStateFlag.Name)
 
+	if b.cliCtx.IsSet(flags.InteropGenesisTimeFlag.Name) && genesisValidators == 0 && genesisStatePath == "" {
+		return errors.New("--interop-genesis-time requires --interop-num-validators to be set")
+	}
 	if genesisValidators > 0 || genesisStatePath != "" {
 		svc := interopcoldstart.NewColdStartService(b.ctx, &interopcoldstart.Config{
 			GenesisTime:   genesisTime,
@@ -654,11 +1016,139 @@ func (b *BeaconNode) registerArchiverService() error {
 	if err := b.services.FetchService(&chainService); err != nil {
 		return err
 	}
+	var schedulerService *scheduler.Service
+	if err := b.services.FetchService(&schedulerService); err != nil {
+		return err
+	}
 	svc := archiver.NewArchiverService(b.ctx, &archiver.Config{
 		BeaconDB:             b.db,
 		HeadFetcher:          chainService,
 		ParticipationFetcher: chainService,
 		StateNotifier:        b,
+		Scheduler:            schedulerService,
+		BalancesRetention: archiver.Retention{
+			Epochs:    b.cliCtx.Uint64(flags.ArchiveBalancesRetentionFlag.Name),
+			KeepEvery: b.cliCtx.Uint64(flags.ArchiveBalancesKeepEveryFlag.Name),
+		},
+		CommitteesRetention: archiver.Retention{
+			Epochs:    b.cliCtx.Uint64(flags.ArchiveCommitteesRetentionFlag.Name),
+			KeepEvery: b.cliCtx.Uint64(flags.ArchiveCommitteesKeepEveryFlag.Name),
+		},
+		ParticipationRetention: archiver.Retention{
+			Epochs:    b.cliCtx.Uint64(flags.ArchiveParticipationRetentionFlag.Name),
+			KeepEvery: b.cliCtx.Uint64(flags.ArchiveParticipationKeepEveryFlag.Name),
+		},
 	})
 	return b.services.RegisterService(svc)
 }
+
+func (b *BeaconNode) registerLightClientService() error {
+	if !flags.Get().EnableLightClientServer {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc := lightclient.NewService(b.ctx, &lightclient.Config{
+		BeaconDB:      b.db,
+		HeadFetcher:   chainService,
+		StateNotifier: b,
+	})
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerBroadcastProtectionService() error {
+	validators := b.cliCtx.StringSlice(flags.BroadcastProtectionValidators.Name)
+	if len(validators) == 0 {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	svc, err := broadcastprotection.NewService(b.ctx, &broadcastprotection.Config{
+		Validators:        validators,
+		HeadFetcher:       chainService,
+		OperationNotifier: b,
+		BlockNotifier:     b,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not register broadcast protection service")
+	}
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerSlasherService() error {
+	if !flags.Get().EnableSlasher {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	baseDir := b.cliCtx.String(cmd.DataDirFlag.Name)
+	svc, err := slasher.NewSlasherService(b.ctx, &slasher.Config{
+		DatabasePath:      path.Join(baseDir, slasherDBName),
+		HeadFetcher:       chainService,
+		SlashingPool:      b.slashingsPool,
+		OperationNotifier: b,
+		BlockNotifier:     b,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not register slasher service")
+	}
+	return b.services.RegisterService(svc)
+}
+
+func (b *BeaconNode) registerWatchdogService() error {
+	maxHeadLag := b.cliCtx.Uint64(flags.HeadLagWatchdogSlots.Name)
+	if maxHeadLag == 0 {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	var syncService *prysmsync.Service
+	if err := b.services.FetchService(&syncService); err != nil {
+		return err
+	}
+	var initSync prysmsync.Checker
+	if cfg := featureconfig.Get(); cfg.DisableInitSyncQueue {
+		var initSyncTmp *initialsyncold.Service
+		if err := b.services.FetchService(&initSyncTmp); err != nil {
+			return err
+		}
+		initSync = initSyncTmp
+	} else {
+		var initSyncTmp *initialsync.Service
+		if err := b.services.FetchService(&initSyncTmp); err != nil {
+			return err
+		}
+		initSync = initSyncTmp
+	}
+	svc := watchdog.NewService(b.ctx, &watchdog.Config{
+		HeadFetcher:          chainService,
+		TimeFetcher:          chainService,
+		ForkChoiceFetcher:    chainService,
+		PeersProvider:        b.fetchP2P(),
+		SyncChecker:          initSync,
+		PendingQueuesFetcher: syncService,
+		MaxHeadLag:           maxHeadLag,
+		DumpDir:              b.cliCtx.String(flags.HeadLagDumpDir.Name),
+	})
+	return b.services.RegisterService(svc)
+}
+
+// selectedNetwork returns the network selected with the network flag, if any.
+func selectedNetwork(cliCtx *cli.Context) *params.Network {
+	if !cliCtx.IsSet(cmd.NetworkFlag.Name) {
+		return nil
+	}
+	network, err := params.NetworkByName(cliCtx.String(cmd.NetworkFlag.Name))
+	if err != nil {
+		log.WithError(err).Fatal("Could not select network")
+	}
+	return network
+}
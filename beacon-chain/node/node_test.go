@@ -1,12 +1,16 @@
 package node
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	"testing"
 
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 	"gopkg.in/urfave/cli.v2"
@@ -48,3 +52,75 @@ func TestNodeClose_OK(t *testing.T) {
 		t.Log(err)
 	}
 }
+
+func TestConfigureSlotsPerArchivedPoint(t *testing.T) {
+	resetCfg := params.OverrideBeaconConfigWithReset(params.BeaconConfig().Copy())
+	defer resetCfg()
+	d := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, d)
+	ctx := context.Background()
+
+	newCliCtx := func(slotsPerArchivedPoint string) *cli.Context {
+		set := flag.NewFlagSet("test", 0)
+		set.Int(flags.SlotsPerArchivedPoint.Name, 256, "")
+		if slotsPerArchivedPoint != "" {
+			if err := set.Set(flags.SlotsPerArchivedPoint.Name, slotsPerArchivedPoint); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	// The flag sets the frequency of a new database.
+	if err := configureSlotsPerArchivedPoint(ctx, newCliCtx("64"), d); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := d.SlotsPerArchivedPoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved != 64 || params.BeaconConfig().SlotsPerArchivedPoint != 64 {
+		t.Errorf("Expected 64 slots per archived point, saved %d configured %d", saved, params.BeaconConfig().SlotsPerArchivedPoint)
+	}
+
+	// Without the flag, the saved frequency is used.
+	params.OverrideBeaconConfig(params.MainnetConfig().Copy())
+	if err := configureSlotsPerArchivedPoint(ctx, newCliCtx(""), d); err != nil {
+		t.Fatal(err)
+	}
+	if params.BeaconConfig().SlotsPerArchivedPoint != 64 {
+		t.Errorf("Expected the saved 64 slots per archived point, configured %d", params.BeaconConfig().SlotsPerArchivedPoint)
+	}
+
+	// A flag not matching the saved frequency is refused.
+	if err := configureSlotsPerArchivedPoint(ctx, newCliCtx("128"), d); err == nil {
+		t.Error("Expected a flag not matching the database to fail")
+	}
+	if err := configureSlotsPerArchivedPoint(ctx, newCliCtx("64"), d); err != nil {
+		t.Errorf("Expected a flag matching the database to succeed: %v", err)
+	}
+}
+
+func TestConfigureSlotsPerArchivedPoint_ExistingArchivedPoints(t *testing.T) {
+	resetCfg := params.OverrideBeaconConfigWithReset(params.BeaconConfig().Copy())
+	defer resetCfg()
+	d := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, d)
+	ctx := context.Background()
+	// Archived points of a database written before the frequency was saved.
+	if err := d.SaveArchivedPointRoot(ctx, [32]byte{'A'}, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SaveLastArchivedIndex(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	set := flag.NewFlagSet("test", 0)
+	set.Int(flags.SlotsPerArchivedPoint.Name, 256, "")
+	if err := set.Set(flags.SlotsPerArchivedPoint.Name, "64"); err != nil {
+		t.Fatal(err)
+	}
+	if err := configureSlotsPerArchivedPoint(ctx, cli.NewContext(&cli.App{}, set, nil), d); err == nil {
+		t.Error("Expected a flag not matching the default frequency of an existing database to fail")
+	}
+}
@@ -0,0 +1,255 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/urfave/cli.v2"
+)
+
+// ExportSnapshot writes a snappy compressed snapshot of the finalized chain data of the
+// beacon chain database to the requested output file, which ImportSnapshot initializes
+// a fresh database from.
+func ExportSnapshot(cliCtx *cli.Context) error {
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), beaconChainDBName)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return fmt.Errorf("no beacon chain database found at %s", dbPath)
+	}
+	stateSummaryCache := cache.NewStateSummaryCache()
+	d, err := db.NewDB(dbPath, stateSummaryCache)
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	ctx := context.Background()
+	if err := configureSlotsPerArchivedPoint(ctx, cliCtx, d); err != nil {
+		return err
+	}
+	sg := stategen.New(d, stateSummaryCache)
+	if _, err := sg.Resume(ctx); err != nil {
+		return errors.Wrap(err, "could not resume state management from database")
+	}
+	snapshot, err := exportSnapshot(ctx, d, sg, cliCtx.Uint64(flags.SnapshotBlockEpochs.Name))
+	if err != nil {
+		return err
+	}
+	enc, err := proto.Marshal(snapshot)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal snapshot")
+	}
+	compressed := snappy.Encode(nil, enc)
+	output := cliCtx.String(flags.SnapshotOutput.Name)
+	if err := ioutil.WriteFile(output, compressed, 0600); err != nil {
+		return errors.Wrapf(err, "could not write snapshot to %s", output)
+	}
+	log.WithFields(logrus.Fields{
+		"finalizedEpoch":  snapshot.FinalizedCheckpoint.Epoch,
+		"blocks":          len(snapshot.Blocks),
+		"depositSnapshot": snapshot.DepositSnapshot != nil,
+		"output":          output,
+		"size":            len(compressed),
+	}).Info("Exported node snapshot")
+	return nil
+}
+
+// ImportSnapshot initializes an empty beacon chain database from a node snapshot written
+// by ExportSnapshot.
+func ImportSnapshot(cliCtx *cli.Context) error {
+	input := cliCtx.String(flags.SnapshotInput.Name)
+	compressed, err := ioutil.ReadFile(input)
+	if err != nil {
+		return errors.Wrapf(err, "could not read snapshot from %s", input)
+	}
+	enc, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return errors.Wrap(err, "could not decompress snapshot")
+	}
+	snapshot := &protodb.NodeSnapshot{}
+	if err := proto.Unmarshal(enc, snapshot); err != nil {
+		return errors.Wrap(err, "could not unmarshal snapshot")
+	}
+
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), beaconChainDBName)
+	d, err := db.NewDB(dbPath, cache.NewStateSummaryCache())
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	ctx := context.Background()
+	if err := configureSlotsPerArchivedPoint(ctx, cliCtx, d); err != nil {
+		return err
+	}
+	if err := importSnapshot(ctx, d, snapshot); err != nil {
+		return err
+	}
+	log.WithFields(logrus.Fields{
+		"finalizedEpoch": snapshot.FinalizedCheckpoint.Epoch,
+		"finalizedRoot":  fmt.Sprintf("%#x", bytesutil.Trunc(snapshot.FinalizedCheckpoint.Root)),
+		"blocks":         len(snapshot.Blocks),
+	}).Info("Imported node snapshot, verify the finalized root against a trusted source or start the node with --weak-subjectivity-checkpoint")
+	return nil
+}
+
+// exportSnapshot collects the finalized checkpoint, its state, the finalized block with its
+// canonical ancestors from the requested number of preceding epochs and the deposit snapshot.
+func exportSnapshot(ctx context.Context, d db.ReadOnlyDatabase, sg *stategen.State, blockEpochs uint64) (*protodb.NodeSnapshot, error) {
+	cp, err := d.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if cp == nil {
+		return nil, errors.New("no finalized checkpoint in database")
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+	st, err := sg.StateByRoot(ctx, root)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized state")
+	}
+	if st == nil {
+		return nil, errors.New("finalized state is not available")
+	}
+
+	finalizedBlock, err := d.Block(ctx, root)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get finalized block")
+	}
+	if finalizedBlock == nil || finalizedBlock.Block == nil {
+		return nil, errors.New("finalized block is not available")
+	}
+	startSlot := uint64(0)
+	if span := blockEpochs * params.BeaconConfig().SlotsPerEpoch; finalizedBlock.Block.Slot > span {
+		startSlot = finalizedBlock.Block.Slot - span
+	}
+	// Walk back the canonical chain from the finalized block, then order the blocks by increasing slot.
+	blocks := []*ethpb.SignedBeaconBlock{finalizedBlock}
+	for blk := finalizedBlock; blk.Block.Slot > startSlot; {
+		blk, err = d.Block(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get block")
+		}
+		if blk == nil || blk.Block == nil || blk.Block.Slot < startSlot {
+			break
+		}
+		blocks = append(blocks, blk)
+	}
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+
+	snapshot := &protodb.NodeSnapshot{
+		FinalizedCheckpoint: cp,
+		FinalizedState:      st.CloneInnerState(),
+		Blocks:              blocks,
+	}
+	powchainData, err := d.PowchainData(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get powchain data")
+	}
+	if powchainData != nil && powchainData.DepositSnapshot != nil {
+		snapshot.DepositSnapshot = powchainData.DepositSnapshot
+	} else {
+		log.Warn("No finalized deposit snapshot in database, the importing node will have to process deposit logs from genesis")
+	}
+	return snapshot, nil
+}
+
+// importSnapshot verifies the snapshot is consistent with its finalized checkpoint and saves
+// it to an empty database, so the node resumes from the finalized checkpoint on start.
+func importSnapshot(ctx context.Context, d db.Database, snapshot *protodb.NodeSnapshot) error {
+	headState, err := d.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if headState != nil {
+		return errors.New("a snapshot can only be imported into an empty database")
+	}
+	cp := snapshot.FinalizedCheckpoint
+	if cp == nil || snapshot.FinalizedState == nil || len(snapshot.Blocks) == 0 {
+		return errors.New("snapshot is missing the finalized checkpoint, state or block")
+	}
+	root := bytesutil.ToBytes32(cp.Root)
+
+	finalizedBlock := snapshot.Blocks[len(snapshot.Blocks)-1]
+	if finalizedBlock == nil || finalizedBlock.Block == nil {
+		return errors.New("nil finalized block in snapshot")
+	}
+	blockRoot, err := ssz.HashTreeRoot(finalizedBlock.Block)
+	if err != nil {
+		return errors.Wrap(err, "could not compute finalized block root")
+	}
+	if blockRoot != root {
+		return fmt.Errorf("finalized block root %#x does not match the finalized checkpoint root %#x", blockRoot, root)
+	}
+	st, err := stateTrie.InitializeFromProto(snapshot.FinalizedState)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize finalized state")
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not compute finalized state root")
+	}
+	if bytesutil.ToBytes32(finalizedBlock.Block.StateRoot) != stateRoot {
+		return fmt.Errorf("finalized state root %#x does not match the finalized block state root %#x", stateRoot, finalizedBlock.Block.StateRoot)
+	}
+
+	if err := d.SaveBlocks(ctx, snapshot.Blocks); err != nil {
+		return errors.Wrap(err, "could not save blocks")
+	}
+	if err := d.SaveState(ctx, st, root); err != nil {
+		return errors.Wrap(err, "could not save finalized state")
+	}
+	if err := d.SaveStateSummary(ctx, &pb.StateSummary{Slot: st.Slot(), Root: root[:]}); err != nil {
+		return errors.Wrap(err, "could not save finalized state summary")
+	}
+	archivedIndex := st.Slot() / params.BeaconConfig().SlotsPerArchivedPoint
+	if err := d.SaveArchivedPointRoot(ctx, root, archivedIndex); err != nil {
+		return errors.Wrap(err, "could not save archived point")
+	}
+	if err := d.SaveLastArchivedIndex(ctx, archivedIndex); err != nil {
+		return errors.Wrap(err, "could not save last archived index")
+	}
+	if err := d.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := d.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	if err := d.SaveHeadBlockRoot(ctx, root); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	if snapshot.DepositSnapshot != nil {
+		if err := d.SavePowchainData(ctx, &protodb.ETH1ChainData{DepositSnapshot: snapshot.DepositSnapshot}); err != nil {
+			return errors.Wrap(err, "could not save deposit snapshot")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func testSnapshot(t *testing.T) *protodb.NodeSnapshot {
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisState(t, 32)
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{StateRoot: stateRoot[:], Body: &ethpb.BeaconBlockBody{}}}
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &protodb.NodeSnapshot{
+		FinalizedCheckpoint: &ethpb.Checkpoint{Root: root[:]},
+		FinalizedState:      st.CloneInnerState(),
+		Blocks:              []*ethpb.SignedBeaconBlock{blk},
+	}
+}
+
+func TestImportSnapshot_OK(t *testing.T) {
+	ctx := context.Background()
+	d := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, d)
+
+	snapshot := testSnapshot(t)
+	if err := importSnapshot(ctx, d, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	headBlock, err := d.HeadBlock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if headBlock == nil || headBlock.Block.Slot != 0 {
+		t.Errorf("Wanted the finalized block as head, received %v", headBlock)
+	}
+	cp, err := d.FinalizedCheckpoint(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ssz.DeepEqual(cp, snapshot.FinalizedCheckpoint) {
+		t.Errorf("Wanted finalized checkpoint %v, received %v", snapshot.FinalizedCheckpoint, cp)
+	}
+
+	if err := importSnapshot(ctx, d, snapshot); err == nil {
+		t.Error("Expected error importing a snapshot into a non empty database")
+	}
+}
+
+func TestImportSnapshot_MismatchedRoot(t *testing.T) {
+	d := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, d)
+
+	snapshot := testSnapshot(t)
+	snapshot.FinalizedCheckpoint.Root = []byte{'a'}
+	if err := importSnapshot(context.Background(), d, snapshot); err == nil {
+		t.Error("Expected error importing a snapshot with a mismatched finalized root")
+	}
+}
@@ -65,7 +65,9 @@ func (p *AttCaches) AggregateUnaggregatedAttestations() error {
 	return nil
 }
 
-// SaveAggregatedAttestation saves an aggregated attestation in cache.
+// SaveAggregatedAttestation saves an aggregated attestation in cache. Attestations whose
+// aggregation bits are a subset of a cached aggregate with the same data are dropped, as they
+// add no information.
 func (p *AttCaches) SaveAggregatedAttestation(att *ethpb.Attestation) error {
 	if att == nil || att.Data == nil {
 		return nil
@@ -87,6 +89,10 @@ func (p *AttCaches) SaveAggregatedAttestation(att *ethpb.Attestation) error {
 		p.aggregatedAtt[r] = atts
 		return nil
 	}
+	if containedIn(att, atts) {
+		droppedSubsetAtts.WithLabelValues("aggregated").Inc()
+		return nil
+	}
 
 	atts, err = helpers.AggregateAttestations(append(atts, copiedAtt))
 	if err != nil {
@@ -136,6 +142,30 @@ func (p *AttCaches) AggregatedAttestationsBySlotIndex(slot uint64, committeeInde
 	return atts
 }
 
+// BestAggregatedAttestationBySlotIndex returns the aggregated attestation in cache with the most
+// aggregation bits set for the given committee index and slot, or nil if there is none.
+func (p *AttCaches) BestAggregatedAttestationBySlotIndex(slot uint64, committeeIndex uint64) *ethpb.Attestation {
+	var best *ethpb.Attestation
+
+	p.aggregatedAttLock.RLock()
+	defer p.aggregatedAttLock.RUnlock()
+	for _, atts := range p.aggregatedAtt {
+		if slot != atts[0].Data.Slot || committeeIndex != atts[0].Data.CommitteeIndex {
+			continue
+		}
+		for _, a := range atts {
+			if best == nil || a.AggregationBits.Count() > best.AggregationBits.Count() {
+				best = a
+			}
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	return stateTrie.CopyAttestation(best)
+}
+
 // DeleteAggregatedAttestation deletes the aggregated attestations in cache.
 func (p *AttCaches) DeleteAggregatedAttestation(att *ethpb.Attestation) error {
 	if att == nil || att.Data == nil {
@@ -171,6 +201,17 @@ func (p *AttCaches) DeleteAggregatedAttestation(att *ethpb.Attestation) error {
 	return nil
 }
 
+// containedIn returns true if the aggregation bits of the attestation are a subset of the
+// aggregation bits of one of the attestations, which must share its data.
+func containedIn(att *ethpb.Attestation, atts []*ethpb.Attestation) bool {
+	for _, a := range atts {
+		if a.AggregationBits.Len() == att.AggregationBits.Len() && a.AggregationBits.Contains(att.AggregationBits) {
+			return true
+		}
+	}
+	return false
+}
+
 // HasAggregatedAttestation checks if the input attestations has already existed in cache.
 func (p *AttCaches) HasAggregatedAttestation(att *ethpb.Attestation) (bool, error) {
 	if att == nil || att.Data == nil {
@@ -94,6 +94,31 @@ func TestKV_Aggregated_CanDelete(t *testing.T) {
 	}
 }
 
+func TestKV_Aggregated_BestBySlotIndex(t *testing.T) {
+	cache := NewAttCaches()
+
+	att1 := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: []byte{'A'}}, AggregationBits: bitfield.Bitlist{0b10011}}
+	att2 := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1, BeaconBlockRoot: []byte{'B'}}, AggregationBits: bitfield.Bitlist{0b11101}}
+	att3 := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1, CommitteeIndex: 1}, AggregationBits: bitfield.Bitlist{0b11111}}
+	atts := []*ethpb.Attestation{att1, att2, att3}
+
+	for _, att := range atts {
+		if err := cache.SaveAggregatedAttestation(att); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if best := cache.BestAggregatedAttestationBySlotIndex(1, 0); !reflect.DeepEqual(best, att2) {
+		t.Errorf("Wanted best aggregate %v, received %v", att2, best)
+	}
+	if best := cache.BestAggregatedAttestationBySlotIndex(1, 1); !reflect.DeepEqual(best, att3) {
+		t.Errorf("Wanted best aggregate %v, received %v", att3, best)
+	}
+	if best := cache.BestAggregatedAttestationBySlotIndex(2, 0); best != nil {
+		t.Errorf("Wanted no aggregate, received %v", best)
+	}
+}
+
 func TestKV_HasAggregatedAttestation(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -287,3 +312,21 @@ func TestKV_Aggregated_AggregatesAttestations(t *testing.T) {
 		t.Error("Did not receive correct aggregated atts")
 	}
 }
+
+func TestKV_Aggregated_DropsSubsets(t *testing.T) {
+	cache := NewAttCaches()
+
+	att1 := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b11101}}
+	att2 := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b10101}}
+	att3 := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b11101}}
+	for _, att := range []*ethpb.Attestation{att1, att2, att3} {
+		if err := cache.SaveAggregatedAttestation(att); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	returned := cache.AggregatedAttestations()
+	if len(returned) != 1 || !reflect.DeepEqual(att1, returned[0]) {
+		t.Errorf("Expected only %v in the pool, received %v", att1, returned)
+	}
+}
@@ -0,0 +1,11 @@
+package kv
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var droppedSubsetAtts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "attestations_dropped_as_subset_total",
+	Help: "The number of attestations not saved in the pool because their aggregation bits are a subset of a known aggregate with the same data.",
+}, []string{"pool"})
@@ -7,7 +7,8 @@ import (
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 )
 
-// SaveUnaggregatedAttestation saves an unaggregated attestation in cache.
+// SaveUnaggregatedAttestation saves an unaggregated attestation in cache. Attestations already
+// included in a cached aggregate with the same data are dropped.
 func (p *AttCaches) SaveUnaggregatedAttestation(att *ethpb.Attestation) error {
 	if att == nil {
 		return nil
@@ -15,6 +16,19 @@ func (p *AttCaches) SaveUnaggregatedAttestation(att *ethpb.Attestation) error {
 	if helpers.IsAggregated(att) {
 		return errors.New("attestation is aggregated")
 	}
+	if att.Data != nil {
+		dataRoot, err := hashFn(att.Data)
+		if err != nil {
+			return errors.Wrap(err, "could not tree hash attestation data")
+		}
+		p.aggregatedAttLock.RLock()
+		covered := containedIn(att, p.aggregatedAtt[dataRoot])
+		p.aggregatedAttLock.RUnlock()
+		if covered {
+			droppedSubsetAtts.WithLabelValues("unaggregated").Inc()
+			return nil
+		}
+	}
 
 	r, err := hashFn(att)
 	if err != nil {
@@ -51,3 +51,22 @@ func TestKV_Unaggregated_CanDelete(t *testing.T) {
 		t.Error("Did not receive correct aggregated atts")
 	}
 }
+
+func TestKV_Unaggregated_DropsAggregatedSubset(t *testing.T) {
+	cache := NewAttCaches()
+
+	aggregated := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b1011}}
+	if err := cache.SaveAggregatedAttestation(aggregated); err != nil {
+		t.Fatal(err)
+	}
+	covered := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b1001}}
+	uncovered := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b1100}}
+	otherData := &ethpb.Attestation{Data: &ethpb.AttestationData{Slot: 2}, AggregationBits: bitfield.Bitlist{0b1001}}
+	if err := cache.SaveUnaggregatedAttestations([]*ethpb.Attestation{covered, uncovered, otherData}); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := cache.UnaggregatedAttestationCount(); count != 2 {
+		t.Errorf("Expected 2 unaggregated attestations in the pool, received %d", count)
+	}
+}
@@ -30,6 +30,18 @@ var (
 		Name: "expired_block_atts_total",
 		Help: "The number of expired and deleted block attestations in the pool.",
 	})
+	evictedAggregatedAtts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "evicted_aggregated_atts_total",
+		Help: "The number of aggregated attestations evicted from the full pool.",
+	})
+	evictedUnaggregatedAtts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "evicted_unaggregated_atts_total",
+		Help: "The number of unaggregated attestations evicted from the full pool.",
+	})
+	seenForkchoiceAtts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "seen_forkchoice_atts_total",
+		Help: "The number of attestations skipped for fork choice because their aggregation bits were already processed.",
+	})
 )
 
 func (s *Service) updateMetrics() {
@@ -59,6 +59,7 @@ func (s *Service) batchForkChoiceAtts(ctx context.Context) error {
 			return err
 		}
 		if seen {
+			seenForkchoiceAtts.Inc()
 			continue
 		}
 
@@ -1,8 +1,10 @@
 package attestations
 
 import (
+	"sort"
 	"time"
 
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 )
@@ -10,6 +12,13 @@ import (
 // Prune expired attestations from the pool every slot interval.
 var pruneExpiredAttsPeriod = time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
 
+// Maximum number of aggregated and unaggregated attestations kept in the pool. The oldest
+// attestations are evicted first when a limit is exceeded.
+var (
+	maxAggregatedAtts   = 1 << 16
+	maxUnaggregatedAtts = 1 << 16
+)
+
 // This prunes attestations pool by running pruneExpiredAtts
 // at every pruneExpiredAttsPeriod.
 func (s *Service) pruneAttsPool() {
@@ -17,6 +26,10 @@ func (s *Service) pruneAttsPool() {
 	for {
 		select {
 		case <-ticker.C:
+			if err := s.scheduler.Wait(s.ctx, "prune-attestations"); err != nil {
+				log.Debug("Context closed, exiting routine")
+				return
+			}
 			s.pruneExpiredAtts()
 		case <-s.ctx.Done():
 			log.Debug("Context closed, exiting routine")
@@ -53,9 +66,46 @@ func (s *Service) pruneExpiredAtts() {
 			if err := s.pool.DeleteBlockAttestation(att); err != nil {
 				log.WithError(err).Error("Could not delete expired block attestation")
 			}
+			expiredBlockAtts.Inc()
 		}
-		expiredBlockAtts.Inc()
 	}
+
+	s.pruneExcessAtts()
+}
+
+// This evicts the oldest aggregated and unaggregated attestations from the pool while it holds
+// more than the maximum number of attestations, which happens when attestations stop being
+// included in blocks, such as during long periods of non-finality.
+func (s *Service) pruneExcessAtts() {
+	if excess := len(s.pool.AggregatedAttestations()) - maxAggregatedAtts; excess > 0 {
+		for _, att := range oldestAtts(s.pool.AggregatedAttestations(), excess) {
+			if err := s.pool.DeleteAggregatedAttestation(att); err != nil {
+				log.WithError(err).Error("Could not evict aggregated attestation")
+				continue
+			}
+			evictedAggregatedAtts.Inc()
+		}
+	}
+	if excess := len(s.pool.UnaggregatedAttestations()) - maxUnaggregatedAtts; excess > 0 {
+		for _, att := range oldestAtts(s.pool.UnaggregatedAttestations(), excess) {
+			if err := s.pool.DeleteUnaggregatedAttestation(att); err != nil {
+				log.WithError(err).Error("Could not evict unaggregated attestation")
+				continue
+			}
+			evictedUnaggregatedAtts.Inc()
+		}
+	}
+}
+
+// oldestAtts returns the n attestations with the lowest slots.
+func oldestAtts(atts []*ethpb.Attestation, n int) []*ethpb.Attestation {
+	sort.Slice(atts, func(i, j int) bool {
+		return atts[i].Data.Slot < atts[j].Data.Slot
+	})
+	if n > len(atts) {
+		n = len(atts)
+	}
+	return atts[:n]
 }
 
 // Return true if the input slot has been expired.
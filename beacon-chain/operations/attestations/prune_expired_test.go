@@ -60,3 +60,34 @@ func TestExpired_AttsCanExpire(t *testing.T) {
 		t.Error("Should not expired")
 	}
 }
+
+func TestPruneExpiredAtts_EvictsOldestWhenFull(t *testing.T) {
+	defer func(max int) { maxUnaggregatedAtts = max }(maxUnaggregatedAtts)
+	maxUnaggregatedAtts = 2
+
+	s, err := NewService(context.Background(), &Config{Pool: NewPool()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.genesisTime = uint64(roughtime.Now().Unix())
+
+	atts := []*ethpb.Attestation{
+		{Data: &ethpb.AttestationData{Slot: 2}, AggregationBits: bitfield.Bitlist{0b101}},
+		{Data: &ethpb.AttestationData{Slot: 0}, AggregationBits: bitfield.Bitlist{0b101}},
+		{Data: &ethpb.AttestationData{Slot: 1}, AggregationBits: bitfield.Bitlist{0b101}},
+	}
+	if err := s.pool.SaveUnaggregatedAttestations(atts); err != nil {
+		t.Fatal(err)
+	}
+
+	s.pruneExpiredAtts()
+	remaining := s.pool.UnaggregatedAttestations()
+	if len(remaining) != 2 {
+		t.Fatalf("Wanted 2 attestations in the pool, have %d", len(remaining))
+	}
+	for _, att := range remaining {
+		if att.Data.Slot == 0 {
+			t.Error("Expected the oldest attestation to be evicted")
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	lru "github.com/hashicorp/golang-lru"
+	"github.com/prysmaticlabs/prysm/beacon-chain/scheduler"
 )
 
 var forkChoiceProcessedRootsSize = 1 << 16
@@ -19,11 +20,13 @@ type Service struct {
 	err                      error
 	forkChoiceProcessedRoots *lru.Cache
 	genesisTime              uint64
+	scheduler                *scheduler.Service
 }
 
 // Config options for the service.
 type Config struct {
-	Pool Pool
+	Pool      Pool
+	Scheduler *scheduler.Service
 }
 
 // NewService instantiates a new attestation pool service instance that will
@@ -40,6 +43,7 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		cancel:                   cancel,
 		pool:                     cfg.Pool,
 		forkChoiceProcessedRoots: cache,
+		scheduler:                cfg.Scheduler,
 	}, nil
 }
 
@@ -54,4 +54,18 @@ var (
 			Help: "Times a proposer slashing for an already slashed validator is received",
 		},
 	)
+	attesterSlashingsEvicted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "attester_slashings_evicted_total",
+			Help: "Number of attester slashings removed from or refused by the pool before inclusion, by reason",
+		},
+		[]string{"reason"},
+	)
+	proposerSlashingsEvicted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "proposer_slashings_evicted_total",
+			Help: "Number of proposer slashings removed from or refused by the pool before inclusion, by reason",
+		},
+		[]string{"reason"},
+	)
 )
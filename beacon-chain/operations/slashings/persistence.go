@@ -0,0 +1,86 @@
+package slashings
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/sirupsen/logrus"
+)
+
+// NewPoolWithDatabase returns a pool which persists its pending slashings in the beacon
+// database, so slashings which have not been included in a block yet survive restarts.
+func NewPoolWithDatabase(beaconDB db.NoHeadAccessDatabase) *Pool {
+	p := NewPool()
+	p.beaconDB = beaconDB
+	return p
+}
+
+// LoadPendingSlashings inserts the slashings persisted by a previous run back into the
+// pool. Slashings which are no longer valid against the given state are removed from the
+// database.
+func (p *Pool) LoadPendingSlashings(ctx context.Context, state *beaconstate.BeaconState) error {
+	if p.beaconDB == nil {
+		return nil
+	}
+	proposerSlashings, err := p.beaconDB.ProposerSlashings(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get proposer slashings")
+	}
+	loaded := 0
+	for _, slashing := range proposerSlashings {
+		if err := p.InsertProposerSlashing(ctx, state, slashing); err != nil {
+			p.deleteProposerSlashing(ctx, slashing)
+			continue
+		}
+		loaded++
+	}
+	attesterSlashings, err := p.beaconDB.AttesterSlashings(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get attester slashings")
+	}
+	for _, slashing := range attesterSlashings {
+		if err := p.InsertAttesterSlashing(ctx, state, slashing); err != nil {
+			p.deleteAttesterSlashing(ctx, slashing)
+			continue
+		}
+		loaded++
+	}
+	if loaded > 0 {
+		log.WithField("count", loaded).Info("Loaded pending slashings from database")
+	}
+	return nil
+}
+
+func (p *Pool) deleteProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) {
+	if p.beaconDB == nil {
+		return
+	}
+	root, err := ssz.HashTreeRoot(slashing)
+	if err == nil {
+		err = p.beaconDB.DeleteProposerSlashing(ctx, root)
+	}
+	if err != nil {
+		log.WithError(err).WithField("proposerIndex", slashing.Header_1.Header.ProposerIndex).Warn(
+			"Could not delete proposer slashing from database")
+	}
+}
+
+func (p *Pool) deleteAttesterSlashing(ctx context.Context, slashing *ethpb.AttesterSlashing) {
+	if p.beaconDB == nil {
+		return
+	}
+	root, err := ssz.HashTreeRoot(slashing)
+	if err == nil {
+		err = p.beaconDB.DeleteAttesterSlashing(ctx, root)
+	}
+	if err != nil {
+		log.WithError(err).WithFields(logrus.Fields{
+			"attestation1Indices": slashing.Attestation_1.AttestingIndices,
+			"attestation2Indices": slashing.Attestation_2.AttestingIndices,
+		}).Warn("Could not delete attester slashing from database")
+	}
+}
@@ -0,0 +1,127 @@
+package slashings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestPool_LoadPendingSlashings(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 64)
+
+	proposerSlashing, err := testutil.GenerateProposerSlashingForValidator(beaconState, privKeys[1], 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attesterSlashing, err := testutil.GenerateAttesterSlashingForValidator(beaconState, privKeys[2], 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPoolWithDatabase(db)
+	if err := p.InsertProposerSlashing(ctx, beaconState, proposerSlashing); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.InsertAttesterSlashing(ctx, beaconState, attesterSlashing); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new pool, as created when the node restarts, loads the slashings back.
+	p = NewPoolWithDatabase(db)
+	if err := p.LoadPendingSlashings(ctx, beaconState); err != nil {
+		t.Fatal(err)
+	}
+	pendingProposer := p.PendingProposerSlashings(ctx)
+	if len(pendingProposer) != 1 || !proto.Equal(pendingProposer[0], proposerSlashing) {
+		t.Errorf("Wanted pending proposer slashings [%v], received %v", proposerSlashing, pendingProposer)
+	}
+	pendingAttester := p.PendingAttesterSlashings(ctx)
+	if len(pendingAttester) != 1 || !proto.Equal(pendingAttester[0], attesterSlashing) {
+		t.Errorf("Wanted pending attester slashings [%v], received %v", attesterSlashing, pendingAttester)
+	}
+
+	// Included slashings are removed from the database.
+	p.MarkIncludedProposerSlashing(proposerSlashing)
+	p.MarkIncludedAttesterSlashing(attesterSlashing)
+	persistedProposer, err := db.ProposerSlashings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	persistedAttester, err := db.AttesterSlashings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(persistedProposer) != 0 || len(persistedAttester) != 0 {
+		t.Errorf(
+			"Expected included slashings to be deleted, found %d proposer and %d attester slashings",
+			len(persistedProposer),
+			len(persistedAttester),
+		)
+	}
+}
+
+func TestPool_LoadPendingSlashings_DropsInvalid(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 64)
+
+	proposerSlashing, err := testutil.GenerateProposerSlashingForValidator(beaconState, privKeys[1], 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveProposerSlashing(ctx, proposerSlashing); err != nil {
+		t.Fatal(err)
+	}
+	val, err := beaconState.ValidatorAtIndex(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val.Slashed = true
+	if err := beaconState.UpdateValidatorAtIndex(1, val); err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPoolWithDatabase(db)
+	if err := p.LoadPendingSlashings(ctx, beaconState); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.PendingProposerSlashings(ctx)) != 0 {
+		t.Error("Expected slashing of an already slashed validator to be dropped")
+	}
+	persisted, err := db.ProposerSlashings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(persisted) != 0 {
+		t.Errorf("Expected invalid slashing to be deleted from the database, found %d", len(persisted))
+	}
+}
+
+func TestPool_PendingAttesterSlashings_Priority(t *testing.T) {
+	single := &ethpb.AttesterSlashing{
+		Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{1}},
+		Attestation_2: &ethpb.IndexedAttestation{AttestingIndices: []uint64{1}},
+	}
+	double := &ethpb.AttesterSlashing{
+		Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+		Attestation_2: &ethpb.IndexedAttestation{AttestingIndices: []uint64{2, 3}},
+	}
+	p := &Pool{
+		pendingAttesterSlashing: []*PendingAttesterSlashing{
+			{attesterSlashing: single, validatorToSlash: 1},
+			{attesterSlashing: double, validatorToSlash: 2},
+			{attesterSlashing: double, validatorToSlash: 3},
+		},
+	}
+	pending := p.PendingAttesterSlashings(context.Background())
+	if len(pending) == 0 || pending[0] != double {
+		t.Errorf("Expected the slashing slashing the most validators first, received %v", pending)
+	}
+}
@@ -25,7 +25,8 @@ func NewPool() *Pool {
 }
 
 // PendingAttesterSlashings returns attester slashings that are able to be included into a block.
-// This method will not return more than the block enforced MaxAttesterSlashings.
+// Slashings slashing the most validators are returned first. This method will not return more
+// than the block enforced MaxAttesterSlashings.
 func (p *Pool) PendingAttesterSlashings(ctx context.Context) []*ethpb.AttesterSlashing {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
@@ -37,12 +38,9 @@ func (p *Pool) PendingAttesterSlashings(ctx context.Context) []*ethpb.AttesterSl
 
 	included := make(map[uint64]bool)
 	pending := make([]*ethpb.AttesterSlashing, 0, params.BeaconConfig().MaxAttesterSlashings)
-	for i, slashing := range p.pendingAttesterSlashing {
-		if i >= int(params.BeaconConfig().MaxAttesterSlashings) {
-			break
-		}
+	numSlashed := make(map[*ethpb.AttesterSlashing]int)
+	for _, slashing := range p.pendingAttesterSlashing {
 		if included[slashing.validatorToSlash] {
-			p.pendingAttesterSlashing = append(p.pendingAttesterSlashing[:i], p.pendingAttesterSlashing[i+1:]...)
 			continue
 		}
 		attSlashing := slashing.attesterSlashing
@@ -50,9 +48,16 @@ func (p *Pool) PendingAttesterSlashings(ctx context.Context) []*ethpb.AttesterSl
 		for _, idx := range slashedVal {
 			included[idx] = true
 		}
+		numSlashed[attSlashing] = len(slashedVal)
 
 		pending = append(pending, attSlashing)
 	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return numSlashed[pending[i]] > numSlashed[pending[j]]
+	})
+	if len(pending) > int(params.BeaconConfig().MaxAttesterSlashings) {
+		pending = pending[:params.BeaconConfig().MaxAttesterSlashings]
+	}
 
 	return pending
 }
@@ -95,6 +100,7 @@ func (p *Pool) InsertAttesterSlashing(
 		return errors.Wrap(err, "could not verify attester slashing")
 	}
 
+	inserted := false
 	slashedVal := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
 	for _, val := range slashedVal {
 		// Has this validator index been included recently?
@@ -119,6 +125,11 @@ func (p *Pool) InsertAttesterSlashing(
 			continue
 		}
 
+		if len(p.pendingAttesterSlashing) >= maxPendingAttesterSlashings {
+			attesterSlashingsEvicted.WithLabelValues("pool_full").Inc()
+			return errors.New("attester slashing pool is full")
+		}
+
 		pendingSlashing := &PendingAttesterSlashing{
 			attesterSlashing: slashing,
 			validatorToSlash: val,
@@ -129,6 +140,12 @@ func (p *Pool) InsertAttesterSlashing(
 		sort.Slice(p.pendingAttesterSlashing, func(i, j int) bool {
 			return p.pendingAttesterSlashing[i].validatorToSlash < p.pendingAttesterSlashing[j].validatorToSlash
 		})
+		inserted = true
+	}
+	if inserted && p.beaconDB != nil {
+		if err := p.beaconDB.SaveAttesterSlashing(ctx, slashing); err != nil {
+			return errors.Wrap(err, "could not save attester slashing")
+		}
 	}
 	return nil
 }
@@ -173,11 +190,21 @@ func (p *Pool) InsertProposerSlashing(
 		return errors.New("slashing object already exists in pending proposer slashings")
 	}
 
+	if len(p.pendingProposerSlashing) >= maxPendingProposerSlashings {
+		proposerSlashingsEvicted.WithLabelValues("pool_full").Inc()
+		return errors.New("proposer slashing pool is full")
+	}
+
 	// Insert into pending list and sort again.
 	p.pendingProposerSlashing = append(p.pendingProposerSlashing, slashing)
 	sort.Slice(p.pendingProposerSlashing, func(i, j int) bool {
 		return p.pendingProposerSlashing[i].Header_1.Header.ProposerIndex < p.pendingProposerSlashing[j].Header_1.Header.ProposerIndex
 	})
+	if p.beaconDB != nil {
+		if err := p.beaconDB.SaveProposerSlashing(ctx, slashing); err != nil {
+			return errors.Wrap(err, "could not save proposer slashing")
+		}
+	}
 	return nil
 }
 
@@ -193,7 +220,11 @@ func (p *Pool) MarkIncludedAttesterSlashing(as *ethpb.AttesterSlashing) {
 			return p.pendingAttesterSlashing[i].validatorToSlash >= val
 		})
 		if i != len(p.pendingAttesterSlashing) && p.pendingAttesterSlashing[i].validatorToSlash == val {
+			removed := p.pendingAttesterSlashing[i].attesterSlashing
 			p.pendingAttesterSlashing = append(p.pendingAttesterSlashing[:i], p.pendingAttesterSlashing[i+1:]...)
+			if !p.hasPendingAttesterSlashing(removed) {
+				p.deleteAttesterSlashing(context.Background(), removed)
+			}
 		}
 		p.included[val] = true
 		numAttesterSlashingsIncluded.Inc()
@@ -210,12 +241,93 @@ func (p *Pool) MarkIncludedProposerSlashing(ps *ethpb.ProposerSlashing) {
 		return p.pendingProposerSlashing[i].Header_1.Header.ProposerIndex >= ps.Header_1.Header.ProposerIndex
 	})
 	if i != len(p.pendingProposerSlashing) && p.pendingProposerSlashing[i].Header_1.Header.ProposerIndex == ps.Header_1.Header.ProposerIndex {
+		p.deleteProposerSlashing(context.Background(), p.pendingProposerSlashing[i])
 		p.pendingProposerSlashing = append(p.pendingProposerSlashing[:i], p.pendingProposerSlashing[i+1:]...)
 	}
 	p.included[ps.Header_1.Header.ProposerIndex] = true
 	numProposerSlashingsIncluded.Inc()
 }
 
+// MarkNotIncludedAttesterSlashing is used when a block including the attester slashing was orphaned
+// by a reorg, allowing the slashing to be inserted into the pool again.
+func (p *Pool) MarkNotIncludedAttesterSlashing(as *ethpb.AttesterSlashing) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	slashedVal := sliceutil.IntersectionUint64(as.Attestation_1.AttestingIndices, as.Attestation_2.AttestingIndices)
+	for _, val := range slashedVal {
+		delete(p.included, val)
+	}
+}
+
+// MarkNotIncludedProposerSlashing is used when a block including the proposer slashing was orphaned
+// by a reorg, allowing the slashing to be inserted into the pool again.
+func (p *Pool) MarkNotIncludedProposerSlashing(ps *ethpb.ProposerSlashing) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.included, ps.Header_1.Header.ProposerIndex)
+}
+
+// PruneExpired removes the pending slashings of validators which can no longer be slashed in the
+// given state, because they were slashed already or became withdrawable. Included slashings of
+// slashed validators are forgotten too, as the state now rejects their slashings.
+func (p *Pool) PruneExpired(ctx context.Context, state *beaconstate.BeaconState) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	epoch := helpers.CurrentEpoch(state)
+	slashable := func(validatorIndex uint64) bool {
+		v, err := state.ValidatorAtIndexReadOnly(validatorIndex)
+		return err != nil || (!v.Slashed() && epoch < v.WithdrawableEpoch())
+	}
+
+	proposerSlashings := p.pendingProposerSlashing[:0]
+	for _, slashing := range p.pendingProposerSlashing {
+		if !slashable(slashing.Header_1.Header.ProposerIndex) {
+			p.deleteProposerSlashing(ctx, slashing)
+			proposerSlashingsEvicted.WithLabelValues("expired").Inc()
+			continue
+		}
+		proposerSlashings = append(proposerSlashings, slashing)
+	}
+	p.pendingProposerSlashing = proposerSlashings
+
+	attesterSlashings := p.pendingAttesterSlashing[:0]
+	var removed []*ethpb.AttesterSlashing
+	for _, slashing := range p.pendingAttesterSlashing {
+		if !slashable(slashing.validatorToSlash) {
+			removed = append(removed, slashing.attesterSlashing)
+			attesterSlashingsEvicted.WithLabelValues("expired").Inc()
+			continue
+		}
+		attesterSlashings = append(attesterSlashings, slashing)
+	}
+	p.pendingAttesterSlashing = attesterSlashings
+	for _, slashing := range removed {
+		if !p.hasPendingAttesterSlashing(slashing) {
+			p.deleteAttesterSlashing(ctx, slashing)
+		}
+	}
+
+	for validatorIndex := range p.included {
+		if !slashable(validatorIndex) {
+			delete(p.included, validatorIndex)
+		}
+	}
+	numPendingProposerSlashings.Set(float64(len(p.pendingProposerSlashing)))
+	numPendingAttesterSlashings.Set(float64(len(p.pendingAttesterSlashing)))
+}
+
+// hasPendingAttesterSlashing returns true if any pending validator slashing is still
+// covered by the given attester slashing.
+func (p *Pool) hasPendingAttesterSlashing(slashing *ethpb.AttesterSlashing) bool {
+	for _, pending := range p.pendingAttesterSlashing {
+		if pending.attesterSlashing == slashing {
+			return true
+		}
+	}
+	return false
+}
+
 // this function checks a few items about a validator before proceeding with inserting
 // a proposer/attester slashing into the pool. First, it checks if the validator
 // has been recently included in the pool, then it checks if the validator has exited,
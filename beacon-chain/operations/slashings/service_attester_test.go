@@ -512,3 +512,49 @@ func TestPool_PendingAttesterSlashings_NoDuplicates(t *testing.T) {
 		t.Errorf("Unexpected return from PendingAttesterSlashings, wanted %v, received %v", want, got)
 	}
 }
+
+func TestPool_PruneExpired(t *testing.T) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	if err := beaconState.SetSlot(helpers.StartSlot(10)); err != nil {
+		t.Fatal(err)
+	}
+	slashedVal, err := beaconState.ValidatorAtIndex(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slashedVal.Slashed = true
+	if err := beaconState.UpdateValidatorAtIndex(1, slashedVal); err != nil {
+		t.Fatal(err)
+	}
+	withdrawableVal, err := beaconState.ValidatorAtIndex(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withdrawableVal.WithdrawableEpoch = 5
+	if err := beaconState.UpdateValidatorAtIndex(3, withdrawableVal); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &Pool{
+		pendingProposerSlashing: []*ethpb.ProposerSlashing{
+			proposerSlashingForValIdx(0),
+			proposerSlashingForValIdx(1),
+		},
+		pendingAttesterSlashing: []*PendingAttesterSlashing{
+			pendingSlashingForValIdx(2),
+			pendingSlashingForValIdx(3),
+		},
+		included: map[uint64]bool{1: true, 4: true},
+	}
+	p.PruneExpired(context.Background(), beaconState)
+
+	if !reflect.DeepEqual(p.pendingProposerSlashing, []*ethpb.ProposerSlashing{proposerSlashingForValIdx(0)}) {
+		t.Errorf("Unexpected pending proposer slashings %v", p.pendingProposerSlashing)
+	}
+	if !reflect.DeepEqual(p.pendingAttesterSlashing, []*PendingAttesterSlashing{pendingSlashingForValIdx(2)}) {
+		t.Errorf("Unexpected pending attester slashings %v", p.pendingAttesterSlashing)
+	}
+	if !reflect.DeepEqual(p.included, map[uint64]bool{4: true}) {
+		t.Errorf("Expected included slashings of slashed validators to be forgotten, have %v", p.included)
+	}
+}
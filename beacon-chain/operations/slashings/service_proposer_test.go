@@ -438,3 +438,27 @@ func TestPool_PendingProposerSlashings(t *testing.T) {
 		})
 	}
 }
+
+func TestPool_InsertProposerSlashing_PoolFull(t *testing.T) {
+	defer func(max int) { maxPendingProposerSlashings = max }(maxPendingProposerSlashings)
+	maxPendingProposerSlashings = 1
+
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	p := NewPool()
+	for i := uint64(0); i < 2; i++ {
+		sl, err := testutil.GenerateProposerSlashingForValidator(beaconState, privKeys[i], i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = p.InsertProposerSlashing(context.Background(), beaconState, sl)
+		if i == 0 && err != nil {
+			t.Fatal(err)
+		}
+		if i == 1 && (err == nil || !strings.Contains(err.Error(), "pool is full")) {
+			t.Errorf("Expected pool full error, received %v", err)
+		}
+	}
+	if len(p.pendingProposerSlashing) != 1 {
+		t.Errorf("Wanted 1 pending proposer slashing, have %d", len(p.pendingProposerSlashing))
+	}
+}
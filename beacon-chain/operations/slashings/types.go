@@ -4,6 +4,14 @@ import (
 	"sync"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+)
+
+// Maximum number of pending slashings kept in the pool, counting one attester slashing entry per
+// slashed validator. Slashings received once the pool is full are refused.
+var (
+	maxPendingAttesterSlashings = 1 << 12
+	maxPendingProposerSlashings = 1 << 10
 )
 
 // Pool implements a struct to maintain pending and recently included attester and
@@ -13,6 +21,7 @@ type Pool struct {
 	pendingProposerSlashing []*ethpb.ProposerSlashing
 	pendingAttesterSlashing []*PendingAttesterSlashing
 	included                map[uint64]bool
+	beaconDB                db.NoHeadAccessDatabase
 }
 
 // PendingAttesterSlashing represents an attester slashing in the operation pool.
@@ -0,0 +1,22 @@
+package voluntaryexits
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	numPendingExits = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "num_pending_voluntary_exits",
+			Help: "Number of pending voluntary exits in the pool",
+		},
+	)
+	exitsEvicted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "voluntary_exits_evicted_total",
+			Help: "Number of voluntary exits removed from the pool before inclusion, by reason",
+		},
+		[]string{"reason"},
+	)
+)
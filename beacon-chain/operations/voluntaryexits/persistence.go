@@ -0,0 +1,65 @@
+package voluntaryexits
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// NewPoolWithDatabase returns a pool which persists its pending exits in the beacon database,
+// so exits which have not been included in a block yet survive restarts.
+func NewPoolWithDatabase(beaconDB db.NoHeadAccessDatabase) *Pool {
+	p := NewPool()
+	p.beaconDB = beaconDB
+	return p
+}
+
+// LoadPendingExits inserts the exits persisted by a previous run back into the pool. Exits of
+// validators which have exited since are removed from the database.
+func (p *Pool) LoadPendingExits(ctx context.Context, state *beaconstate.BeaconState) error {
+	if p.beaconDB == nil {
+		return nil
+	}
+	exits, err := p.beaconDB.PendingVoluntaryExits(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get pending voluntary exits")
+	}
+	for _, exit := range exits {
+		p.InsertVoluntaryExit(ctx, state, exit)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, exit := range exits {
+		if p.search(exit.Exit.ValidatorIndex) == len(p.pending) {
+			p.deletePendingExit(ctx, exit.Exit.ValidatorIndex)
+		}
+	}
+	if len(p.pending) > 0 {
+		log.WithField("count", len(p.pending)).Info("Loaded pending voluntary exits from database")
+	}
+	return nil
+}
+
+func (p *Pool) savePendingExit(ctx context.Context, exit *ethpb.SignedVoluntaryExit) {
+	if p.beaconDB == nil {
+		return
+	}
+	if err := p.beaconDB.SavePendingVoluntaryExit(ctx, exit); err != nil {
+		log.WithError(err).WithField("validatorIndex", exit.Exit.ValidatorIndex).Warn(
+			"Could not save voluntary exit to database")
+	}
+}
+
+func (p *Pool) deletePendingExit(ctx context.Context, validatorIndex uint64) {
+	if p.beaconDB == nil {
+		return
+	}
+	if err := p.beaconDB.DeletePendingVoluntaryExit(ctx, validatorIndex); err != nil {
+		log.WithError(err).WithField("validatorIndex", validatorIndex).Warn(
+			"Could not delete voluntary exit from database")
+	}
+}
@@ -0,0 +1,69 @@
+package voluntaryexits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestPool_LoadPendingExits(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	ctx := context.Background()
+	s, err := beaconstate.InitializeFromProtoUnsafe(&p2ppb.BeaconState{Validators: []*ethpb.Validator{
+		{ExitEpoch: params.BeaconConfig().FarFutureEpoch},
+		{ExitEpoch: params.BeaconConfig().FarFutureEpoch},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exits := []*ethpb.SignedVoluntaryExit{
+		{Exit: &ethpb.VoluntaryExit{Epoch: 12, ValidatorIndex: 0}},
+		{Exit: &ethpb.VoluntaryExit{Epoch: 12, ValidatorIndex: 1}},
+	}
+	p := NewPoolWithDatabase(db)
+	for _, exit := range exits {
+		p.InsertVoluntaryExit(ctx, s, exit)
+	}
+
+	// Validator 1 exits before the node restarts.
+	val, err := s.ValidatorAtIndex(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	val.ExitEpoch = 20
+	if err := s.UpdateValidatorAtIndex(1, val); err != nil {
+		t.Fatal(err)
+	}
+
+	p = NewPoolWithDatabase(db)
+	if err := p.LoadPendingExits(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.pending) != 1 || !proto.Equal(p.pending[0], exits[0]) {
+		t.Errorf("Wanted pending exits [%v], received %v", exits[0], p.pending)
+	}
+	persisted, err := db.PendingVoluntaryExits(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(persisted) != 1 || !proto.Equal(persisted[0], exits[0]) {
+		t.Errorf("Wanted persisted exits [%v], received %v", exits[0], persisted)
+	}
+
+	// Included exits are removed from the database.
+	p.MarkIncluded(exits[0])
+	persisted, err = db.PendingVoluntaryExits(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(persisted) != 0 {
+		t.Errorf("Expected included exit to be deleted from the database, received %v", persisted)
+	}
+}
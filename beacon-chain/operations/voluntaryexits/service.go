@@ -7,16 +7,22 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
+// Maximum number of pending exits kept in the pool. Once full, exits which become valid the
+// latest are evicted first.
+var maxPendingExits = 1 << 13
+
 // Pool implements a struct to maintain pending and recently included voluntary exits. This pool
 // is used by proposers to insert into new blocks.
 type Pool struct {
 	lock     sync.RWMutex
 	pending  []*ethpb.SignedVoluntaryExit
 	included map[uint64]bool
+	beaconDB db.NoHeadAccessDatabase
 }
 
 // NewPool accepts a head fetcher (for reading the validator set) and returns an initialized
@@ -65,22 +71,34 @@ func (p *Pool) InsertVoluntaryExit(ctx context.Context, state *beaconstate.Beaco
 	}
 
 	// Does this validator exist in the list already? Use binary search to find the answer.
-	if found := sort.Search(len(p.pending), func(i int) bool {
-		e := p.pending[i].Exit
-		return e.ValidatorIndex == exit.Exit.ValidatorIndex
-	}); found != len(p.pending) {
+	if found := p.search(exit.Exit.ValidatorIndex); found != len(p.pending) {
 		// If an exit exists with this validator index, prefer one with an earlier exit epoch.
 		if p.pending[found].Exit.Epoch > exit.Exit.Epoch {
 			p.pending[found] = exit
+			p.savePendingExit(ctx, exit)
 		}
 		return
 	}
 
+	// Make room for the exit if the pool is full, unless it would be the latest to become valid.
+	if len(p.pending) >= maxPendingExits {
+		latest := p.latestPendingExit()
+		if exit.Exit.Epoch >= p.pending[latest].Exit.Epoch {
+			exitsEvicted.WithLabelValues("pool_full").Inc()
+			return
+		}
+		p.deletePendingExit(ctx, p.pending[latest].Exit.ValidatorIndex)
+		p.pending = append(p.pending[:latest], p.pending[latest+1:]...)
+		exitsEvicted.WithLabelValues("pool_full").Inc()
+	}
+
 	// Insert into pending list and sort again.
 	p.pending = append(p.pending, exit)
 	sort.Slice(p.pending, func(i, j int) bool {
 		return p.pending[i].Exit.ValidatorIndex < p.pending[j].Exit.ValidatorIndex
 	})
+	p.savePendingExit(ctx, exit)
+	numPendingExits.Set(float64(len(p.pending)))
 }
 
 // MarkIncluded is used when an exit has been included in a beacon block. Every block seen by this
@@ -88,11 +106,69 @@ func (p *Pool) InsertVoluntaryExit(ctx context.Context, state *beaconstate.Beaco
 func (p *Pool) MarkIncluded(exit *ethpb.SignedVoluntaryExit) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
-	i := sort.Search(len(p.pending), func(i int) bool {
-		return p.pending[i].Exit.ValidatorIndex == exit.Exit.ValidatorIndex
-	})
-	if i != len(p.pending) {
+	if i := p.search(exit.Exit.ValidatorIndex); i != len(p.pending) {
 		p.pending = append(p.pending[:i], p.pending[i+1:]...)
+		p.deletePendingExit(context.Background(), exit.Exit.ValidatorIndex)
 	}
 	p.included[exit.Exit.ValidatorIndex] = true
 }
+
+// PruneExpired removes the pending exits of validators which have exited in the given state, as
+// their exits can no longer be included in a block. Included exits of these validators are
+// forgotten too, as the state now rejects their exits.
+func (p *Pool) PruneExpired(ctx context.Context, state *beaconstate.BeaconState) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	exited := func(validatorIndex uint64) bool {
+		v, err := state.ValidatorAtIndexReadOnly(validatorIndex)
+		return err == nil && v.ExitEpoch() != params.BeaconConfig().FarFutureEpoch
+	}
+	pending := p.pending[:0]
+	for _, e := range p.pending {
+		if exited(e.Exit.ValidatorIndex) {
+			p.deletePendingExit(ctx, e.Exit.ValidatorIndex)
+			exitsEvicted.WithLabelValues("expired").Inc()
+			continue
+		}
+		pending = append(pending, e)
+	}
+	p.pending = pending
+	for validatorIndex := range p.included {
+		if exited(validatorIndex) {
+			delete(p.included, validatorIndex)
+		}
+	}
+	numPendingExits.Set(float64(len(p.pending)))
+}
+
+// MarkNotIncluded is used when a block including the exit was orphaned by a reorg, allowing the
+// exit to be inserted into the pool again.
+func (p *Pool) MarkNotIncluded(exit *ethpb.SignedVoluntaryExit) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.included, exit.Exit.ValidatorIndex)
+}
+
+// latestPendingExit returns the position of the pending exit with the latest exit epoch.
+func (p *Pool) latestPendingExit() int {
+	latest := 0
+	for i, e := range p.pending {
+		if e.Exit.Epoch > p.pending[latest].Exit.Epoch {
+			latest = i
+		}
+	}
+	return latest
+}
+
+// search returns the position of the pending exit of the given validator, or the length of
+// the pending list if the validator has no pending exit.
+func (p *Pool) search(validatorIndex uint64) int {
+	i := sort.Search(len(p.pending), func(i int) bool {
+		return p.pending[i].Exit.ValidatorIndex >= validatorIndex
+	})
+	if i != len(p.pending) && p.pending[i].Exit.ValidatorIndex == validatorIndex {
+		return i
+	}
+	return len(p.pending)
+}
@@ -308,6 +308,41 @@ func TestPool_MarkIncluded(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Removes first of pending list",
+			fields: fields{
+				pending: []*ethpb.SignedVoluntaryExit{
+					{
+						Exit: &ethpb.VoluntaryExit{ValidatorIndex: 1},
+					},
+					{
+						Exit: &ethpb.VoluntaryExit{ValidatorIndex: 2},
+					},
+					{
+						Exit: &ethpb.VoluntaryExit{ValidatorIndex: 3},
+					},
+				},
+				included: make(map[uint64]bool),
+			},
+			args: args{
+				exit: &ethpb.SignedVoluntaryExit{
+					Exit: &ethpb.VoluntaryExit{ValidatorIndex: 1},
+				},
+			},
+			want: fields{
+				pending: []*ethpb.SignedVoluntaryExit{
+					{
+						Exit: &ethpb.VoluntaryExit{ValidatorIndex: 2},
+					},
+					{
+						Exit: &ethpb.VoluntaryExit{ValidatorIndex: 3},
+					},
+				},
+				included: map[uint64]bool{
+					1: true,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -460,3 +495,59 @@ func TestPool_PendingExits(t *testing.T) {
 		})
 	}
 }
+
+func TestPool_InsertVoluntaryExit_EvictsLatestWhenFull(t *testing.T) {
+	defer func(max int) { maxPendingExits = max }(maxPendingExits)
+	maxPendingExits = 2
+
+	ctx := context.Background()
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	s, err := beaconstate.InitializeFromProtoUnsafe(&p2ppb.BeaconState{Validators: []*ethpb.Validator{
+		{ExitEpoch: farFuture}, {ExitEpoch: farFuture}, {ExitEpoch: farFuture}, {ExitEpoch: farFuture},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewPool()
+	p.InsertVoluntaryExit(ctx, s, &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 5, ValidatorIndex: 0}})
+	p.InsertVoluntaryExit(ctx, s, &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 10, ValidatorIndex: 1}})
+
+	// An exit valid later than every pending exit is dropped.
+	p.InsertVoluntaryExit(ctx, s, &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 20, ValidatorIndex: 2}})
+	// An exit valid earlier evicts the exit valid the latest.
+	p.InsertVoluntaryExit(ctx, s, &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 3}})
+
+	want := []*ethpb.SignedVoluntaryExit{
+		{Exit: &ethpb.VoluntaryExit{Epoch: 5, ValidatorIndex: 0}},
+		{Exit: &ethpb.VoluntaryExit{Epoch: 1, ValidatorIndex: 3}},
+	}
+	if !reflect.DeepEqual(p.pending, want) {
+		t.Errorf("Pending exits = %v, want %v", p.pending, want)
+	}
+}
+
+func TestPool_PruneExpired(t *testing.T) {
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	s, err := beaconstate.InitializeFromProtoUnsafe(&p2ppb.BeaconState{Validators: []*ethpb.Validator{
+		{ExitEpoch: farFuture}, {ExitEpoch: 10}, {ExitEpoch: 12},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &Pool{
+		pending: []*ethpb.SignedVoluntaryExit{
+			{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 0}},
+			{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 1}},
+		},
+		included: map[uint64]bool{2: true},
+	}
+	p.PruneExpired(context.Background(), s)
+
+	want := []*ethpb.SignedVoluntaryExit{{Exit: &ethpb.VoluntaryExit{ValidatorIndex: 0}}}
+	if !reflect.DeepEqual(p.pending, want) {
+		t.Errorf("Pending exits = %v, want %v", p.pending, want)
+	}
+	if len(p.included) != 0 {
+		t.Errorf("Expected included exits of exited validators to be forgotten, have %v", p.included)
+	}
+}
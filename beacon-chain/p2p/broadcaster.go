@@ -18,10 +18,19 @@ import (
 // GossipTypeMapping.
 var ErrMessageNotMapped = errors.New("message type is not mapped to a PubSub topic")
 
+// ErrBroadcastsPaused occurs on a Broadcast attempt while broadcasts are paused for maintenance.
+var ErrBroadcastsPaused = errors.New("broadcasts are paused for maintenance")
+
 // Broadcast a message to the p2p network.
 func (s *Service) Broadcast(ctx context.Context, msg proto.Message) error {
 	ctx, span := trace.StartSpan(ctx, "p2p.Broadcast")
 	defer span.End()
+	s.broadcastLock.RLock()
+	defer s.broadcastLock.RUnlock()
+	if s.broadcastsPaused {
+		traceutil.AnnotateError(span, ErrBroadcastsPaused)
+		return ErrBroadcastsPaused
+	}
 	forkDigest, err := s.forkDigest()
 	if err != nil {
 		return err
@@ -56,19 +65,40 @@ func (s *Service) Broadcast(ctx context.Context, msg proto.Message) error {
 		span.AddMessageSendEvent(int64(id), messageLen /*uncompressed*/, messageLen /*compressed*/)
 	}
 
-	if err := s.pubsub.Publish(topic+s.Encoding().ProtocolSuffix(), buf.Bytes()); err != nil {
+	if err := s.PublishToTopic(ctx, topic+s.Encoding().ProtocolSuffix(), buf.Bytes()); err != nil {
 		err := errors.Wrap(err, "could not publish message")
 		traceutil.AnnotateError(span, err)
 		return err
 	}
+	messagePublishedCounter.WithLabelValues(topic + s.Encoding().ProtocolSuffix()).Inc()
 	return nil
 }
 
+// PauseBroadcasts waits for the messages being published to finish, and rejects any further
+// broadcasts until ResumeBroadcasts is called.
+func (s *Service) PauseBroadcasts() {
+	s.broadcastLock.Lock()
+	defer s.broadcastLock.Unlock()
+	s.broadcastsPaused = true
+}
+
+// ResumeBroadcasts accepts broadcasts again after PauseBroadcasts.
+func (s *Service) ResumeBroadcasts() {
+	s.broadcastLock.Lock()
+	defer s.broadcastLock.Unlock()
+	s.broadcastsPaused = false
+}
+
 const attestationSubnetTopicFormat = "/eth2/%x/committee_index%d_beacon_attestation"
 
 func attestationToTopic(att *eth.Attestation, forkDigest [4]byte) string {
 	if att == nil || att.Data == nil {
 		return ""
 	}
-	return fmt.Sprintf(attestationSubnetTopicFormat, forkDigest, att.Data.CommitteeIndex)
+	return fmt.Sprintf(attestationSubnetTopicFormat, forkDigest, AttestationSubnet(att.Data.CommitteeIndex))
+}
+
+// AttestationSubnet returns the subnet unaggregated attestations of the committee are published on.
+func AttestationSubnet(committeeIndex uint64) uint64 {
+	return committeeIndex % attestationSubnetCount
 }
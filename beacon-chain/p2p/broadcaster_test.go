@@ -96,6 +96,21 @@ func TestService_Broadcast_ReturnsErr_TopicNotMapped(t *testing.T) {
 	}
 }
 
+func TestService_Broadcast_ReturnsErr_Paused(t *testing.T) {
+	p := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: []byte{'A'},
+	}
+	p.PauseBroadcasts()
+	if err := p.Broadcast(context.Background(), &testpb.AddressBook{}); err != ErrBroadcastsPaused {
+		t.Fatalf("Expected error %v, got %v", ErrBroadcastsPaused, err)
+	}
+	p.ResumeBroadcasts()
+	if err := p.Broadcast(context.Background(), &testpb.AddressBook{}); err != ErrMessageNotMapped {
+		t.Fatalf("Expected error %v, got %v", ErrMessageNotMapped, err)
+	}
+}
+
 func TestService_Attestation_Subnet(t *testing.T) {
 	if gtm := GossipTypeMapping[reflect.TypeOf(&eth.Attestation{})]; gtm != attestationSubnetTopicFormat {
 		t.Errorf("Constant is out of date. Wanted %s, got %s", attestationSubnetTopicFormat, gtm)
@@ -1,7 +1,10 @@
 package p2p
 
 import (
+	"time"
+
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/scheduler"
 )
 
 // Config for the p2p service. These parameters are set from application level flags
@@ -10,10 +13,13 @@ type Config struct {
 	NoDiscovery           bool
 	EnableUPnP            bool
 	DisableDiscv5         bool
+	SubscribeAllSubnets   bool
 	StaticPeers           []string
+	TrustedPeers          []string
 	BootstrapNodeAddr     []string
 	KademliaBootStrapAddr []string
 	Discv5BootStrapAddr   []string
+	DNSBootstrapAddr      []string
 	RelayNodeAddr         string
 	LocalIP               string
 	HostAddress           string
@@ -23,9 +29,17 @@ type Config struct {
 	MetaDataDir           string
 	TCPPort               uint
 	UDPPort               uint
+	QUICPort              uint
 	MaxPeers              uint
 	WhitelistCIDR         string
+	AllowList             []string
+	DenyList              []string
 	Encoding              string
 	StateNotifier         statefeed.Notifier
 	PubSub                string
+	ScoreSnapshotInterval time.Duration
+	ScoreSnapshotFile     string
+	SeenMessagesTTL       time.Duration
+	PeerStore             PeerStore
+	Scheduler             *scheduler.Service
 }
@@ -0,0 +1,190 @@
+package p2p
+
+import (
+	"net"
+
+	"github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/control"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+)
+
+var _ = connmgr.ConnectionGater(&connectionGater{})
+
+// connectionGater restricts inbound and outbound connections using the configured
+// peer allow and deny lists. Entries of either list may be a peer ID or a CIDR range.
+// Deny list entries always take precedence. When an allow list is configured, only
+// peers matching one of its entries are connected to. The gater is installed in the
+// libp2p host, which consults it before dialing, accepting and upgrading connections.
+// A nil gater permits all connections.
+type connectionGater struct {
+	allowedIDs  map[peer.ID]bool
+	allowedNets []*net.IPNet
+	deniedIDs   map[peer.ID]bool
+	deniedNets  []*net.IPNet
+}
+
+func newConnectionGater(allowList []string, denyList []string) (*connectionGater, error) {
+	g := &connectionGater{
+		allowedIDs: make(map[peer.ID]bool),
+		deniedIDs:  make(map[peer.ID]bool),
+	}
+	for _, entry := range allowList {
+		id, ipNet, err := parseGaterEntry(entry)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid allow list entry")
+		}
+		if ipNet != nil {
+			g.allowedNets = append(g.allowedNets, ipNet)
+			continue
+		}
+		g.allowedIDs[id] = true
+	}
+	for _, entry := range denyList {
+		id, ipNet, err := parseGaterEntry(entry)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid deny list entry")
+		}
+		if ipNet != nil {
+			g.deniedNets = append(g.deniedNets, ipNet)
+			continue
+		}
+		g.deniedIDs[id] = true
+	}
+	return g, nil
+}
+
+// parseGaterEntry parses a list entry as either a CIDR range or a peer ID.
+func parseGaterEntry(entry string) (peer.ID, *net.IPNet, error) {
+	if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+		return "", ipNet, nil
+	}
+	id, err := peer.IDB58Decode(entry)
+	if err != nil {
+		return "", nil, errors.Errorf("%s is neither a CIDR range nor a peer ID", entry)
+	}
+	return id, nil, nil
+}
+
+// InterceptPeerDial tests whether we are permitted to dial the specified peer.
+func (g *connectionGater) InterceptPeerDial(pid peer.ID) bool {
+	if g == nil {
+		return true
+	}
+	if g.deniedIDs[pid] {
+		return false
+	}
+	// With an allow list of only CIDR ranges the decision is deferred to the address check.
+	return len(g.allowedIDs) == 0 || g.allowedIDs[pid] || len(g.allowedNets) > 0
+}
+
+// InterceptAddrDial tests whether we are permitted to dial the specified peer on the
+// given address.
+func (g *connectionGater) InterceptAddrDial(pid peer.ID, addr ma.Multiaddr) bool {
+	if g == nil {
+		return true
+	}
+	if !g.InterceptPeerDial(pid) {
+		return false
+	}
+	ip := multiAddrIP(addr)
+	if ip != nil {
+		for _, ipNet := range g.deniedNets {
+			if ipNet.Contains(ip) {
+				return false
+			}
+		}
+	}
+	if len(g.allowedIDs) == 0 && len(g.allowedNets) == 0 {
+		return true
+	}
+	if g.allowedIDs[pid] {
+		return true
+	}
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range g.allowedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// InterceptAccept tests whether an inbound connection from the remote address is
+// permitted. The identity of the peer is not known yet, so only CIDR ranges are
+// checked, and peer ID entries are enforced once the connection is secured.
+func (g *connectionGater) InterceptAccept(n network.ConnMultiaddrs) bool {
+	if g == nil {
+		return true
+	}
+	ip := multiAddrIP(n.RemoteMultiaddr())
+	if ip == nil {
+		return true
+	}
+	for _, ipNet := range g.deniedNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+	// An allowed peer ID may connect from any address.
+	if len(g.allowedIDs) > 0 || len(g.allowedNets) == 0 {
+		return true
+	}
+	for _, ipNet := range g.allowedNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// InterceptSecured tests whether a connection with the given peer, whose identity
+// has been authenticated, on the remote address is permitted. This applies to both
+// inbound and outbound connections.
+func (g *connectionGater) InterceptSecured(_ network.Direction, pid peer.ID, n network.ConnMultiaddrs) bool {
+	return g.InterceptAddrDial(pid, n.RemoteMultiaddr())
+}
+
+// InterceptUpgraded permits every fully upgraded connection, as its peer and address
+// have already been checked when it was secured.
+func (g *connectionGater) InterceptUpgraded(_ network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// filterAddrInfo strips all the addresses we are not permitted to dial from the
+// peer's address info. It returns false if the peer may not be dialed at all.
+func (g *connectionGater) filterAddrInfo(info peer.AddrInfo) (peer.AddrInfo, bool) {
+	if g == nil {
+		return info, true
+	}
+	if !g.InterceptPeerDial(info.ID) {
+		return info, false
+	}
+	addrs := make([]ma.Multiaddr, 0, len(info.Addrs))
+	for _, addr := range info.Addrs {
+		if g.InterceptAddrDial(info.ID, addr) {
+			addrs = append(addrs, addr)
+		}
+	}
+	info.Addrs = addrs
+	return info, len(addrs) > 0
+}
+
+// multiAddrIP extracts the ip address of a multiaddress, returning nil for
+// addresses without an ip component such as dns addresses.
+func multiAddrIP(addr ma.Multiaddr) net.IP {
+	if addr == nil {
+		return nil
+	}
+	if ip4, err := addr.ValueForProtocol(ma.P_IP4); err == nil {
+		return net.ParseIP(ip4)
+	}
+	if ip6, err := addr.ValueForProtocol(ma.P_IP6); err == nil {
+		return net.ParseIP(ip6)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestConnectionGater_InvalidEntry(t *testing.T) {
+	if _, err := newConnectionGater([]string{"not-a-peer"}, nil); err == nil {
+		t.Error("Expected error for invalid allow list entry")
+	}
+	if _, err := newConnectionGater(nil, []string{"10.0.0.0/33"}); err == nil {
+		t.Error("Expected error for invalid deny list entry")
+	}
+}
+
+func TestConnectionGater_InterceptAddrDial(t *testing.T) {
+	allowedID, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deniedID, err := peer.IDB58Decode("QmUn6ycS8Fu6L462uZvuEfDoSgYX6kqP4aSZWMa7z1tWAX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherID, err := peer.IDB58Decode("16Uiu2HAm7Qwe19vz9WzD2Mxn7fXd1vgHHp4iccuyq7TxwRXoAGfc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateAddr, err := ma.NewMultiaddr("/ip4/192.168.0.10/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicAddr, err := ma.NewMultiaddr("/ip4/35.1.2.3/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	deniedAddr, err := ma.NewMultiaddr("/ip4/192.168.1.10/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		allowList []string
+		denyList  []string
+		pid       peer.ID
+		addr      ma.Multiaddr
+		want      bool
+	}{
+		{name: "no lists", pid: otherID, addr: publicAddr, want: true},
+		{name: "denied id", denyList: []string{deniedID.String()}, pid: deniedID, addr: publicAddr, want: false},
+		{name: "denied cidr", denyList: []string{"192.168.1.0/24"}, pid: otherID, addr: deniedAddr, want: false},
+		{name: "outside denied cidr", denyList: []string{"192.168.1.0/24"}, pid: otherID, addr: privateAddr, want: true},
+		{name: "allowed cidr", allowList: []string{"192.168.0.0/16"}, pid: otherID, addr: privateAddr, want: true},
+		{name: "outside allowed cidr", allowList: []string{"192.168.0.0/16"}, pid: otherID, addr: publicAddr, want: false},
+		{name: "allowed id", allowList: []string{allowedID.String()}, pid: allowedID, addr: publicAddr, want: true},
+		{name: "not allowed id", allowList: []string{allowedID.String()}, pid: otherID, addr: publicAddr, want: false},
+		{
+			name:      "deny takes precedence",
+			allowList: []string{"192.168.0.0/16"},
+			denyList:  []string{"192.168.1.0/24"},
+			pid:       otherID,
+			addr:      deniedAddr,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := newConnectionGater(tt.allowList, tt.denyList)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.InterceptAddrDial(tt.pid, tt.addr); got != tt.want {
+				t.Errorf("InterceptAddrDial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectionGater_FilterAddrInfo(t *testing.T) {
+	g, err := newConnectionGater([]string{"192.168.0.0/16"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateAddr, err := ma.NewMultiaddr("/ip4/192.168.0.10/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicAddr, err := ma.NewMultiaddr("/ip4/35.1.2.3/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, ok := g.filterAddrInfo(peer.AddrInfo{ID: "foo", Addrs: []ma.Multiaddr{privateAddr, publicAddr}})
+	if !ok {
+		t.Fatal("Expected peer to be dialable")
+	}
+	if len(info.Addrs) != 1 || !info.Addrs[0].Equal(privateAddr) {
+		t.Errorf("Expected only allowed address to remain, got %v", info.Addrs)
+	}
+	if _, ok := g.filterAddrInfo(peer.AddrInfo{ID: "foo", Addrs: []ma.Multiaddr{publicAddr}}); ok {
+		t.Error("Expected peer without allowed addresses to not be dialable")
+	}
+
+	var nilGater *connectionGater
+	if _, ok := nilGater.filterAddrInfo(peer.AddrInfo{ID: "foo", Addrs: []ma.Multiaddr{publicAddr}}); !ok {
+		t.Error("Expected nil gater to permit all peers")
+	}
+}
+
+type mockConnMultiaddrs struct {
+	remote ma.Multiaddr
+}
+
+func (m *mockConnMultiaddrs) LocalMultiaddr() ma.Multiaddr {
+	return nil
+}
+
+func (m *mockConnMultiaddrs) RemoteMultiaddr() ma.Multiaddr {
+	return m.remote
+}
+
+func TestConnectionGater_InterceptAccept(t *testing.T) {
+	allowedID, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateAddr, err := ma.NewMultiaddr("/ip4/192.168.0.10/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicAddr, err := ma.NewMultiaddr("/ip4/35.1.2.3/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		allowList []string
+		denyList  []string
+		addr      ma.Multiaddr
+		want      bool
+	}{
+		{name: "no lists", addr: publicAddr, want: true},
+		{name: "denied cidr", denyList: []string{"35.0.0.0/8"}, addr: publicAddr, want: false},
+		{name: "allowed cidr", allowList: []string{"192.168.0.0/16"}, addr: privateAddr, want: true},
+		{name: "outside allowed cidr", allowList: []string{"192.168.0.0/16"}, addr: publicAddr, want: false},
+		// The peer ID is only known once the connection is secured.
+		{name: "allowed id", allowList: []string{allowedID.String()}, addr: publicAddr, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := newConnectionGater(tt.allowList, tt.denyList)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := g.InterceptAccept(&mockConnMultiaddrs{remote: tt.addr}); got != tt.want {
+				t.Errorf("InterceptAccept() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectionGater_InterceptSecured(t *testing.T) {
+	allowedID, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherID, err := peer.IDB58Decode("16Uiu2HAm7Qwe19vz9WzD2Mxn7fXd1vgHHp4iccuyq7TxwRXoAGfc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicAddr, err := ma.NewMultiaddr("/ip4/35.1.2.3/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := newConnectionGater([]string{allowedID.String()}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn := &mockConnMultiaddrs{remote: publicAddr}
+	for _, dir := range []network.Direction{network.DirInbound, network.DirOutbound} {
+		if !g.InterceptSecured(dir, allowedID, conn) {
+			t.Errorf("Expected allowed peer to be permitted in direction %v", dir)
+		}
+		if g.InterceptSecured(dir, otherID, conn) {
+			t.Errorf("Expected other peer to be rejected in direction %v", dir)
+		}
+	}
+	if allow, _ := g.InterceptUpgraded(nil); !allow {
+		t.Error("Expected upgraded connections to be permitted")
+	}
+}
@@ -30,6 +30,12 @@ type Listener interface {
 	LocalNode() *enode.LocalNode
 }
 
+// quicEntry is the ENR entry holding the UDP port a node accepts QUIC connections on.
+type quicEntry uint16
+
+// ENRKey of the QUIC port entry.
+func (quicEntry) ENRKey() string { return "quic" }
+
 func (s *Service) createListener(
 	ipAddr net.IP,
 	privKey *ecdsa.PrivateKey,
@@ -66,6 +72,9 @@ func (s *Service) createListener(
 			localNode.SetFallbackIP(hostIP)
 		}
 	}
+	if s.cfg.EnableUPnP {
+		s.setupPortMapping(localNode)
+	}
 	dv5Cfg := discover.Config{
 		PrivateKey: privKey,
 	}
@@ -102,6 +111,9 @@ func (s *Service) createLocalNode(
 	localNode.Set(ipEntry)
 	localNode.Set(udpEntry)
 	localNode.Set(tcpEntry)
+	if s.cfg != nil && s.cfg.QUICPort != 0 {
+		localNode.Set(quicEntry(s.cfg.QUICPort))
+	}
 	localNode.SetFallbackIP(ipAddr)
 	localNode.SetFallbackUDP(udpPort)
 
@@ -201,6 +213,28 @@ func convertToSingleMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
 	return multiAddr, nil
 }
 
+// convertToQUICMultiAddr returns the QUIC multiaddress of the node, if its record has a QUIC port.
+func convertToQUICMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
+	ip4 := node.IP().To4()
+	if ip4 == nil {
+		return nil, errors.Errorf("node doesn't have an ip4 address, it's stated IP is %s", node.IP().String())
+	}
+	var port quicEntry
+	if err := node.Record().Load(&port); err != nil {
+		return nil, err
+	}
+	assertedKey := convertToInterfacePubkey(node.Pubkey())
+	id, err := peer.IDFromPublicKey(assertedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get peer id")
+	}
+	multiAddr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/udp/%d/quic/p2p/%s", ip4.String(), port, id))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get multiaddr")
+	}
+	return multiAddr, nil
+}
+
 func peersFromStringAddrs(addrs []string) ([]ma.Multiaddr, error) {
 	var allAddrs []ma.Multiaddr
 	enodeString, multiAddrString := parseGenericAddrs(addrs)
@@ -8,11 +8,13 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/libp2p/go-libp2p-core/host"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
@@ -146,6 +148,35 @@ func TestMultiAddrsConversion_InvalidIPAddr(t *testing.T) {
 	}
 }
 
+func TestQUICMultiAddrConversion(t *testing.T) {
+	_, pkey := createAddrAndPrivKey(t)
+	s := &Service{
+		cfg:                   &Config{QUICPort: 14000},
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: []byte{'A'},
+	}
+	node, err := s.createLocalNode(pkey, net.ParseIP("127.0.0.1"), 12000, 13000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiAddr, err := convertToQUICMultiAddr(node.Node())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(multiAddr.String(), "/ip4/127.0.0.1/udp/14000/quic/p2p/") {
+		t.Errorf("Unexpected QUIC multiaddr %s", multiAddr.String())
+	}
+
+	s.cfg.QUICPort = 0
+	node, err = s.createLocalNode(pkey, net.ParseIP("127.0.0.1"), 12000, 13000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := convertToQUICMultiAddr(node.Node()); !enr.IsNotFound(err) {
+		t.Errorf("Wanted a not found error for a node without a QUIC port, received %v", err)
+	}
+}
+
 func TestMultiAddrConversion_OK(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ipAddr, pkey := createAddrAndPrivKey(t)
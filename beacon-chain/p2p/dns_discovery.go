@@ -0,0 +1,111 @@
+package p2p
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+)
+
+const (
+	// enrTreeScheme is the url scheme used by EIP-1459 for signed ENR trees.
+	enrTreeScheme = "enrtree://"
+	// dnsScheme is accepted as an alias of the enrtree scheme for bootstrap entries.
+	dnsScheme = "dns://"
+)
+
+// Resync the DNS trees every 5 minutes, as the tree contents are expected to change slowly.
+var dnsSyncPeriod = 5 * time.Minute
+
+// Maximum time to wait for the DNS trees when seeding the discovery table at startup.
+var dnsSeedTimeout = 30 * time.Second
+
+// parseDNSBootstrapAddrs splits out the EIP-1459 DNS tree urls from the provided bootstrap
+// addresses. Entries using the dns:// scheme are normalized to enrtree:// urls. All other
+// entries are returned unchanged.
+func parseDNSBootstrapAddrs(addrs []string) (dnsAddrs []string, otherAddrs []string) {
+	for _, addr := range addrs {
+		switch {
+		case strings.HasPrefix(addr, enrTreeScheme):
+			dnsAddrs = append(dnsAddrs, addr)
+		case strings.HasPrefix(addr, dnsScheme):
+			dnsAddrs = append(dnsAddrs, enrTreeScheme+strings.TrimPrefix(addr, dnsScheme))
+		default:
+			otherAddrs = append(otherAddrs, addr)
+		}
+	}
+	return dnsAddrs, otherAddrs
+}
+
+// newDNSClient creates a dns discovery client, verifying that all provided tree urls
+// are well formed before any lookups are made.
+func newDNSClient(urls []string) (*dnsdisc.Client, error) {
+	for _, url := range urls {
+		if _, err := dnsdisc.ParseURL(url); err != nil {
+			return nil, errors.Wrapf(err, "invalid dns discovery url %s", url)
+		}
+	}
+	return dnsdisc.NewClient(dnsdisc.Config{})
+}
+
+// resolveDNSNodes syncs every configured tree and returns all the nodes whose records
+// were successfully verified against the tree's signing key.
+func resolveDNSNodes(client *dnsdisc.Client, urls []string) []*enode.Node {
+	var nodes []*enode.Node
+	for _, url := range urls {
+		tree, err := client.SyncTree(url)
+		if err != nil {
+			log.WithError(err).WithField("url", url).Error("Could not sync dns discovery tree")
+			continue
+		}
+		nodes = append(nodes, tree.Nodes()...)
+	}
+	return nodes
+}
+
+// resolveDNSNodesWithTimeout resolves the nodes of the trees in the background, returning no
+// nodes if the trees cannot be synced within the timeout.
+func resolveDNSNodesWithTimeout(client *dnsdisc.Client, urls []string, timeout time.Duration) []*enode.Node {
+	resolved := make(chan []*enode.Node, 1)
+	go func() {
+		resolved <- resolveDNSNodes(client, urls)
+	}()
+	select {
+	case nodes := <-resolved:
+		return nodes
+	case <-time.After(timeout):
+		log.WithField("timeout", timeout).Warn("Timed out syncing dns discovery trees")
+		return nil
+	}
+}
+
+// seedDiscoveryFromDNS pings the nodes of the configured ENR trees, so discv5 adds them to
+// its table and can bootstrap when static bootnodes are down.
+func (s *Service) seedDiscoveryFromDNS() {
+	nodes := resolveDNSNodesWithTimeout(s.dnsClient, s.cfg.DNSBootstrapAddr, dnsSeedTimeout)
+	for _, node := range nodes {
+		if s.ctx.Err() != nil {
+			return
+		}
+		if err := s.dv5Listener.Ping(node); err != nil {
+			log.WithError(err).WithField("node", node.ID()).Trace("Could not ping dns discovery node")
+		}
+	}
+}
+
+// listenForDNSNodes periodically syncs the configured ENR trees over DNS and dials
+// the discovered nodes. This acts as an additional peer source to the bootnodes.
+func (s *Service) listenForDNSNodes() {
+	runutil.RunEvery(s.ctx, dnsSyncPeriod, func() {
+		nodes := resolveDNSNodes(s.dnsClient, s.cfg.DNSBootstrapAddr)
+		log.WithField("count", len(nodes)).Debug("Resolved nodes from dns discovery")
+		multiAddresses := s.processPeers(nodes)
+		if len(multiAddresses) > lookupLimit {
+			multiAddresses = multiAddresses[:lookupLimit]
+		}
+		s.connectWithAllPeers(multiAddresses)
+	})
+}
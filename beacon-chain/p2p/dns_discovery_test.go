@@ -0,0 +1,62 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
+)
+
+// blockingResolver does not answer any lookup until it is closed.
+type blockingResolver chan struct{}
+
+func (r blockingResolver) LookupTXT(_ context.Context, _ string) ([]string, error) {
+	<-r
+	return nil, errors.New("resolver closed")
+}
+
+func TestParseDNSBootstrapAddrs(t *testing.T) {
+	enrAddr := "enr:-Ku4QAGwOT9StqmwI5LHaIymIO4ooFKfNkEjWa0f1P8OsElgBh2Ijb-GrD_-b9W4kcPFcwmHQEy5RncqXNqdpVo1heoBh2F0dG5ldHOIAAAAAAAAAACEZXRoMpAAAAAAAAAAAP__________gmlkgnY0gmlwhBLf22SJc2VjcDI1NmsxoQJxCnE6v_x2ekgY_uoE1rtwzvGy40mq9eD66XfHPBWgIIN1ZHCCD6A"
+	treeAddr := "enrtree://AKA3AM6LPBYEUDMVNU3BSVQJ5AD45Y7YPOHJLEF6W26QOE4VTUDPE@nodes.example.org"
+	aliasAddr := "dns://AKA3AM6LPBYEUDMVNU3BSVQJ5AD45Y7YPOHJLEF6W26QOE4VTUDPE@all.example.org"
+
+	dnsAddrs, otherAddrs := parseDNSBootstrapAddrs([]string{enrAddr, treeAddr, aliasAddr})
+	wantDNS := []string{
+		treeAddr,
+		"enrtree://AKA3AM6LPBYEUDMVNU3BSVQJ5AD45Y7YPOHJLEF6W26QOE4VTUDPE@all.example.org",
+	}
+	if !reflect.DeepEqual(dnsAddrs, wantDNS) {
+		t.Errorf("Wanted dns addresses %v, got %v", wantDNS, dnsAddrs)
+	}
+	if !reflect.DeepEqual(otherAddrs, []string{enrAddr}) {
+		t.Errorf("Wanted other addresses %v, got %v", []string{enrAddr}, otherAddrs)
+	}
+}
+
+func TestNewDNSClient_InvalidURL(t *testing.T) {
+	if _, err := newDNSClient([]string{"enrtree://not-a-valid-tree"}); err == nil {
+		t.Error("Expected error when creating client with an invalid tree url")
+	}
+}
+
+func TestResolveDNSNodesWithTimeout(t *testing.T) {
+	resolver := make(blockingResolver)
+	defer close(resolver)
+	client, err := dnsdisc.NewClient(dnsdisc.Config{Resolver: resolver})
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeAddr := "enrtree://AKA3AM6LPBYEUDMVNU3BSVQJ5AD45Y7YPOHJLEF6W26QOE4VTUDPE@nodes.example.org"
+
+	start := time.Now()
+	nodes := resolveDNSNodesWithTimeout(client, []string{treeAddr}, 100*time.Millisecond)
+	if len(nodes) != 0 {
+		t.Errorf("Expected no nodes, received %d", len(nodes))
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected resolution to time out, took %v", elapsed)
+	}
+}
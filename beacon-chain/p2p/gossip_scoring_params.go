@@ -0,0 +1,180 @@
+package p2p
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+const (
+	// Scores decaying below this value are reset to zero.
+	decayToZero = 0.01
+	// Upper bound of the total topic score of a peer.
+	topicScoreCap = 32.72
+
+	// Weights of the different beacon topics in a peer's topic score.
+	beaconBlockWeight      = 0.5
+	aggregateWeight        = 0.5
+	attestationTotalWeight = 1
+	operationWeight        = 0.05
+)
+
+// peerScoringParams returns the gossipsub v1.1 peer scoring parameters and score thresholds
+// used by the beacon node. Topic specific parameters are applied as topics are joined, see
+// topicScoreParams.
+func peerScoringParams() (*pubsub.PeerScoreParams, *pubsub.PeerScoreThresholds) {
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             -4000,
+		PublishThreshold:            -8000,
+		GraylistThreshold:           -16000,
+		AcceptPXThreshold:           100,
+		OpportunisticGraftThreshold: 5,
+	}
+	scoreParams := &pubsub.PeerScoreParams{
+		Topics:        make(map[string]*pubsub.TopicScoreParams),
+		TopicScoreCap: topicScoreCap,
+		AppSpecificScore: func(p peer.ID) float64 {
+			return 0
+		},
+		AppSpecificWeight:           1,
+		IPColocationFactorWeight:    -topicScoreCap,
+		IPColocationFactorThreshold: 10,
+		BehaviourPenaltyWeight:      -15.92,
+		BehaviourPenaltyDecay:       scoreDecay(10 * oneEpochDuration()),
+		DecayInterval:               oneSlotDuration(),
+		DecayToZero:                 decayToZero,
+		RetainScore:                 100 * oneEpochDuration(),
+	}
+	return scoreParams, thresholds
+}
+
+// topicScoreParams returns the score parameters of the given beacon gossip topic, or nil
+// if the topic is not scored.
+func topicScoreParams(topic string) *pubsub.TopicScoreParams {
+	switch {
+	case strings.Contains(topic, "beacon_block"):
+		return defaultBlockTopicParams()
+	case strings.Contains(topic, "beacon_aggregate_and_proof"):
+		return defaultAggregateTopicParams()
+	case strings.Contains(topic, "beacon_attestation"):
+		return defaultAttestationSubnetTopicParams()
+	case strings.Contains(topic, "voluntary_exit"),
+		strings.Contains(topic, "proposer_slashing"),
+		strings.Contains(topic, "attester_slashing"):
+		return defaultOperationTopicParams()
+	default:
+		return nil
+	}
+}
+
+// One block is expected every slot, so peers delivering blocks first are rewarded.
+func defaultBlockTopicParams() *pubsub.TopicScoreParams {
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                     beaconBlockWeight,
+		TimeInMeshWeight:                0.0324,
+		TimeInMeshQuantum:               oneSlotDuration(),
+		TimeInMeshCap:                   300,
+		FirstMessageDeliveriesWeight:    1,
+		FirstMessageDeliveriesDecay:     scoreDecay(20 * oneEpochDuration()),
+		FirstMessageDeliveriesCap:       23,
+		MeshMessageDeliveriesWeight:     0,
+		MeshMessageDeliveriesDecay:      scoreDecay(5 * oneEpochDuration()),
+		MeshMessageDeliveriesCap:        float64(params.BeaconConfig().SlotsPerEpoch * 5),
+		MeshMessageDeliveriesThreshold:  float64(params.BeaconConfig().SlotsPerEpoch),
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: 4 * oneEpochDuration(),
+		MeshFailurePenaltyWeight:        0,
+		MeshFailurePenaltyDecay:         scoreDecay(5 * oneEpochDuration()),
+		InvalidMessageDeliveriesWeight:  -2 * topicScoreCap / beaconBlockWeight,
+		InvalidMessageDeliveriesDecay:   scoreDecay(50 * oneEpochDuration()),
+	}
+}
+
+// Aggregates arrive in bulk once per slot from the aggregators of every committee.
+func defaultAggregateTopicParams() *pubsub.TopicScoreParams {
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                     aggregateWeight,
+		TimeInMeshWeight:                0.0324,
+		TimeInMeshQuantum:               oneSlotDuration(),
+		TimeInMeshCap:                   300,
+		FirstMessageDeliveriesWeight:    0.128,
+		FirstMessageDeliveriesDecay:     scoreDecay(1 * oneEpochDuration()),
+		FirstMessageDeliveriesCap:       179,
+		MeshMessageDeliveriesWeight:     0,
+		MeshMessageDeliveriesDecay:      scoreDecay(1 * oneEpochDuration()),
+		MeshMessageDeliveriesCap:        68,
+		MeshMessageDeliveriesThreshold:  17,
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: 1 * oneEpochDuration(),
+		MeshFailurePenaltyWeight:        0,
+		MeshFailurePenaltyDecay:         scoreDecay(1 * oneEpochDuration()),
+		InvalidMessageDeliveriesWeight:  -2 * topicScoreCap / aggregateWeight,
+		InvalidMessageDeliveriesDecay:   scoreDecay(50 * oneEpochDuration()),
+	}
+}
+
+// The weight of attestations is split evenly over all attestation subnets.
+func defaultAttestationSubnetTopicParams() *pubsub.TopicScoreParams {
+	topicWeight := attestationTotalWeight / float64(params.BeaconNetworkConfig().AttestationSubnetCount)
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                     topicWeight,
+		TimeInMeshWeight:                0.0324,
+		TimeInMeshQuantum:               oneSlotDuration(),
+		TimeInMeshCap:                   300,
+		FirstMessageDeliveriesWeight:    0.955,
+		FirstMessageDeliveriesDecay:     scoreDecay(1 * oneEpochDuration()),
+		FirstMessageDeliveriesCap:       24,
+		MeshMessageDeliveriesWeight:     0,
+		MeshMessageDeliveriesDecay:      scoreDecay(1 * oneEpochDuration()),
+		MeshMessageDeliveriesCap:        24,
+		MeshMessageDeliveriesThreshold:  6,
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: 1 * oneEpochDuration(),
+		MeshFailurePenaltyWeight:        0,
+		MeshFailurePenaltyDecay:         scoreDecay(1 * oneEpochDuration()),
+		InvalidMessageDeliveriesWeight:  -2 * topicScoreCap / topicWeight,
+		InvalidMessageDeliveriesDecay:   scoreDecay(50 * oneEpochDuration()),
+	}
+}
+
+// Exits and slashings are rare, so their scores decay slowly.
+func defaultOperationTopicParams() *pubsub.TopicScoreParams {
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                     operationWeight,
+		TimeInMeshWeight:                0.0324,
+		TimeInMeshQuantum:               oneSlotDuration(),
+		TimeInMeshCap:                   300,
+		FirstMessageDeliveriesWeight:    36,
+		FirstMessageDeliveriesDecay:     scoreDecay(100 * oneEpochDuration()),
+		FirstMessageDeliveriesCap:       1,
+		MeshMessageDeliveriesWeight:     0,
+		MeshMessageDeliveriesDecay:      scoreDecay(100 * oneEpochDuration()),
+		MeshMessageDeliveriesCap:        1,
+		MeshMessageDeliveriesThreshold:  1,
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: 4 * oneEpochDuration(),
+		MeshFailurePenaltyWeight:        0,
+		MeshFailurePenaltyDecay:         scoreDecay(100 * oneEpochDuration()),
+		InvalidMessageDeliveriesWeight:  -2 * topicScoreCap / operationWeight,
+		InvalidMessageDeliveriesDecay:   scoreDecay(100 * oneEpochDuration()),
+	}
+}
+
+func oneSlotDuration() time.Duration {
+	return time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+}
+
+func oneEpochDuration() time.Duration {
+	return time.Duration(params.BeaconConfig().SlotsPerEpoch) * oneSlotDuration()
+}
+
+// scoreDecay returns the decay factor which, applied once every decay interval, takes a
+// score down to decayToZero over the given duration.
+func scoreDecay(totalDuration time.Duration) float64 {
+	numOfTimes := totalDuration / oneSlotDuration()
+	return math.Pow(decayToZero, 1/float64(numOfTimes))
+}
@@ -0,0 +1,42 @@
+package p2p
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestTopicScoreParams_AllGossipTopicsScored(t *testing.T) {
+	for topic := range GossipTopicMappings {
+		formatted := fmt.Sprintf(topic, []byte{0xab, 0xcd, 0xef, 0x01})
+		if topic == attestationSubnetTopicFormat {
+			formatted = fmt.Sprintf(topic, []byte{0xab, 0xcd, 0xef, 0x01}, 1)
+		}
+		params := topicScoreParams(formatted)
+		if params == nil {
+			t.Errorf("No score parameters for topic %s", formatted)
+			continue
+		}
+		if params.TopicWeight <= 0 {
+			t.Errorf("Expected positive weight for topic %s, got %f", formatted, params.TopicWeight)
+		}
+		if params.InvalidMessageDeliveriesWeight >= 0 {
+			t.Errorf("Expected invalid messages on topic %s to be penalized", formatted)
+		}
+	}
+	if params := topicScoreParams("/eth2/abcdef01/unknown_topic"); params != nil {
+		t.Error("Expected unknown topic to not be scored")
+	}
+}
+
+func TestScoreDecay(t *testing.T) {
+	duration := 10 * oneEpochDuration()
+	decay := scoreDecay(duration)
+	if decay <= 0 || decay >= 1 {
+		t.Fatalf("Expected decay factor between 0 and 1, got %f", decay)
+	}
+	intervals := float64(duration / oneSlotDuration())
+	if remaining := math.Pow(decay, intervals); math.Abs(remaining-decayToZero) > 1e-9 {
+		t.Errorf("Expected score to decay to %f over the duration, got %f", decayToZero, remaining)
+	}
+}
@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+var _ = pubsub.EventTracer(&meshTracer{})
+
+// meshTracer is a pubsub event tracer which keeps track of the number of
+// peers in our gossip mesh for each topic.
+type meshTracer struct {
+	lock sync.Mutex
+	// mesh holds the peers of our mesh by topic. Peers are tracked rather than counted, as
+	// disconnected peers are removed from the mesh without a prune event.
+	mesh map[string]map[string]bool
+}
+
+// Trace updates the mesh peers of a topic whenever a peer is grafted onto or
+// pruned from our mesh, a peer is removed or a topic is joined or left.
+func (m *meshTracer) Trace(evt *pubsubpb.TraceEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.mesh == nil {
+		m.mesh = make(map[string]map[string]bool)
+	}
+
+	switch evt.GetType() {
+	case pubsubpb.TraceEvent_GRAFT:
+		topic := evt.GetGraft().GetTopic()
+		if m.mesh[topic] == nil {
+			m.mesh[topic] = make(map[string]bool)
+		}
+		m.mesh[topic][string(evt.GetGraft().GetPeerID())] = true
+		m.updateCount(topic)
+	case pubsubpb.TraceEvent_PRUNE:
+		topic := evt.GetPrune().GetTopic()
+		delete(m.mesh[topic], string(evt.GetPrune().GetPeerID()))
+		m.updateCount(topic)
+	case pubsubpb.TraceEvent_REMOVE_PEER:
+		pid := string(evt.GetRemovePeer().GetPeerID())
+		for topic, peers := range m.mesh {
+			if peers[pid] {
+				delete(peers, pid)
+				m.updateCount(topic)
+			}
+		}
+	case pubsubpb.TraceEvent_JOIN:
+		// Joining a topic starts a new mesh, which is grafted peer by peer.
+		topic := evt.GetJoin().GetTopic()
+		delete(m.mesh, topic)
+		m.updateCount(topic)
+	case pubsubpb.TraceEvent_LEAVE:
+		// Leaving a topic drops the whole mesh for it.
+		topic := evt.GetLeave().GetTopic()
+		delete(m.mesh, topic)
+		m.updateCount(topic)
+	}
+}
+
+func (m *meshTracer) updateCount(topic string) {
+	meshPeerCount.WithLabelValues(topic).Set(float64(len(m.mesh[topic])))
+}
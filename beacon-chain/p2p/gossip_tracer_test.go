@@ -0,0 +1,57 @@
+package p2p
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMeshTracer_TracksMeshPeers(t *testing.T) {
+	topic := "/eth2/00000000/beacon_block/ssz_snappy"
+	otherTopic := "/eth2/00000000/voluntary_exit/ssz_snappy"
+	tracer := &meshTracer{}
+	graft := func(topic string, pid string) {
+		tracer.Trace(&pubsubpb.TraceEvent{
+			Type:  pubsubpb.TraceEvent_GRAFT.Enum(),
+			Graft: &pubsubpb.TraceEvent_Graft{Topic: proto.String(topic), PeerID: []byte(pid)},
+		})
+	}
+	assertCount := func(topic string, want float64) {
+		if got := testutil.ToFloat64(meshPeerCount.WithLabelValues(topic)); got != want {
+			t.Errorf("Wanted %v mesh peers for %s, got %v", want, topic, got)
+		}
+	}
+
+	tracer.Trace(&pubsubpb.TraceEvent{
+		Type: pubsubpb.TraceEvent_JOIN.Enum(),
+		Join: &pubsubpb.TraceEvent_Join{Topic: proto.String(topic)},
+	})
+	graft(topic, "a")
+	graft(topic, "b")
+	// A repeated graft does not count the peer twice.
+	graft(topic, "b")
+	graft(otherTopic, "b")
+	tracer.Trace(&pubsubpb.TraceEvent{
+		Type:  pubsubpb.TraceEvent_PRUNE.Enum(),
+		Prune: &pubsubpb.TraceEvent_Prune{Topic: proto.String(topic), PeerID: []byte("a")},
+	})
+	assertCount(topic, 1)
+	assertCount(otherTopic, 1)
+
+	// Disconnected peers leave every mesh without a prune.
+	tracer.Trace(&pubsubpb.TraceEvent{
+		Type:       pubsubpb.TraceEvent_REMOVE_PEER.Enum(),
+		RemovePeer: &pubsubpb.TraceEvent_RemovePeer{PeerID: []byte("b")},
+	})
+	assertCount(topic, 0)
+	assertCount(otherTopic, 0)
+
+	graft(topic, "c")
+	tracer.Trace(&pubsubpb.TraceEvent{
+		Type:  pubsubpb.TraceEvent_LEAVE.Enum(),
+		Leave: &pubsubpb.TraceEvent_Leave{Topic: proto.String(topic)},
+	})
+	assertCount(topic, 0)
+}
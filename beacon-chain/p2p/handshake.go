@@ -27,7 +27,7 @@ func (s *Service) AddConnectionHandler(reqFunc func(ctx context.Context, id peer
 				return
 			}
 			s.peers.Add(nil /* ENR */, conn.RemotePeer(), conn.RemoteMultiaddr(), conn.Stat().Direction)
-			if len(s.peers.Active()) >= int(s.cfg.MaxPeers) {
+			if len(s.peers.Active()) >= int(s.cfg.MaxPeers) && !s.isPrivilegedPeer(conn.RemotePeer()) {
 				go func() {
 					log.WithField("reason", "at peer limit").Trace("Ignoring connection request")
 					if err := goodbyeFunc(context.Background(), conn.RemotePeer()); err != nil {
@@ -75,6 +75,12 @@ func (s *Service) AddConnectionHandler(reqFunc func(ctx context.Context, id peer
 					return
 				}
 				s.host.ConnManager().Protect(conn.RemotePeer(), "protocol")
+				// The identify protocol has completed by the time the handshake succeeds.
+				if agent, err := s.host.Peerstore().Get(conn.RemotePeer(), "AgentVersion"); err == nil {
+					if agentString, ok := agent.(string); ok {
+						s.peers.SetAgent(conn.RemotePeer(), agentString)
+					}
+				}
 				s.peers.SetConnectionState(conn.RemotePeer(), peers.PeerConnected)
 				log.Info("Peer connected")
 			}()
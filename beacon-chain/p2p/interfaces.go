@@ -3,10 +3,12 @@ package p2p
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/gogo/protobuf/proto"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -49,6 +51,9 @@ type EncodingProvider interface {
 // PubSubProvider provides the p2p pubsub protocol.
 type PubSubProvider interface {
 	PubSub() *pubsub.PubSub
+	JoinTopic(topic string) (*pubsub.Topic, error)
+	SubscribeToTopic(topic string, opts ...pubsub.SubOpt) (*pubsub.Subscription, error)
+	PublishToTopic(ctx context.Context, topic string, data []byte, opts ...pubsub.PubOpt) error
 }
 
 // PeerManager abstracts some peer management methods from libp2p.
@@ -70,6 +75,14 @@ type PeersProvider interface {
 	Peers() *peers.Status
 }
 
+// IdentityProvider returns the identity the local peer advertises to the network.
+type IdentityProvider interface {
+	PeerID() peer.ID
+	ENR() *enr.Record
+	HostAddrs() []ma.Multiaddr
+	MetadataProvider
+}
+
 // MetadataProvider returns the metadata related information for the local peer.
 type MetadataProvider interface {
 	Metadata() *pb.MetaData
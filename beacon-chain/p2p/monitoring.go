@@ -11,6 +11,21 @@ var (
 		Help: "The number of peers in a given state.",
 	},
 		[]string{"state"})
+	messagePublishedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_message_published_total",
+		Help: "Count of messages published by this node.",
+	},
+		[]string{"topic"})
+	meshPeerCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_mesh_peer_count",
+		Help: "The number of peers in our gossip mesh for a given topic.",
+	},
+		[]string{"topic"})
+	messageIDCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_message_id_computed_total",
+		Help: "Count of gossip message-ids computed, by whether the message data was valid snappy.",
+	},
+		[]string{"snappy"})
 )
 
 func (s *Service) updateMetrics() {
@@ -0,0 +1,58 @@
+package p2p
+
+import (
+	"net"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/nat"
+)
+
+// Description applied to port mappings created on the local router.
+const natMappingName = "prysm beacon node"
+
+// setupPortMapping maps the discovery udp port on the local router using UPnP or
+// NAT-PMP, whichever is available. The libp2p tcp port is mapped separately by the
+// libp2p host through its NATPortMap option. Once the router reports
+// our external IP address, it is set as the static IP of the local node, so that the
+// advertised ENR is reachable by peers outside of the local network.
+func (s *Service) setupPortMapping(localNode *enode.LocalNode) {
+	natm := nat.Any()
+	go nat.Map(natm, s.ctx.Done(), "udp", int(s.cfg.UDPPort), int(s.cfg.UDPPort), natMappingName)
+	go func() {
+		// Resolving the external IP may block for a while, as the
+		// router is discovered in the background.
+		ip, err := natm.ExternalIP()
+		if err != nil {
+			log.WithError(err).Warn("Could not retrieve external IP through port mapping")
+			return
+		}
+		setExternalIP(localNode, ip)
+	}()
+}
+
+// setExternalIP sets the provided ip as the advertised ip of the local node, unless it is
+// a private or unspecified address which would be of no use to remote peers.
+func setExternalIP(localNode *enode.LocalNode, ip net.IP) bool {
+	if ip == nil || ip.IsUnspecified() || ip.IsLoopback() || isPrivateIP(ip) {
+		log.WithField("ip", ip).Debug("Ignoring non-routable external IP from port mapping")
+		return false
+	}
+	localNode.SetStaticIP(ip)
+	log.WithField("ip", ip).Info("Advertising external IP address obtained through port mapping")
+	return true
+}
+
+var privateCIDRs = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7", "fe80::/10"}
+
+func isPrivateIP(ip net.IP) bool {
+	for _, cidr := range privateCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
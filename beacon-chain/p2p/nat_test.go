@@ -0,0 +1,37 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+func TestSetExternalIP(t *testing.T) {
+	_, pkey := createAddrAndPrivKey(t)
+	db, err := enode.OpenDB("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	localNode := enode.NewLocalNode(db, pkey)
+
+	tests := []struct {
+		ip   net.IP
+		want bool
+	}{
+		{ip: nil, want: false},
+		{ip: net.ParseIP("0.0.0.0"), want: false},
+		{ip: net.ParseIP("127.0.0.1"), want: false},
+		{ip: net.ParseIP("192.168.1.20"), want: false},
+		{ip: net.ParseIP("10.2.3.4"), want: false},
+		{ip: net.ParseIP("35.1.2.3"), want: true},
+	}
+	for _, tt := range tests {
+		if got := setExternalIP(localNode, tt.ip); got != tt.want {
+			t.Errorf("setExternalIP(%v) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+	if !localNode.Node().IP().Equal(net.ParseIP("35.1.2.3")) {
+		t.Errorf("Expected local node to advertise external ip, got %v", localNode.Node().IP())
+	}
+}
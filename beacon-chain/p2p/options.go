@@ -8,7 +8,7 @@ import (
 
 	"github.com/libp2p/go-libp2p"
 	noise "github.com/libp2p/go-libp2p-noise"
-	filter "github.com/libp2p/go-maddr-filter"
+	libp2pquic "github.com/libp2p/go-libp2p-quic-transport"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/connmgr"
@@ -16,7 +16,7 @@ import (
 )
 
 // buildOptions for the libp2p host.
-func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Option {
+func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey, gater *connectionGater) []libp2p.Option {
 	listen, err := multiAddressBuilder(ip.String(), cfg.TCPPort)
 	if err != nil {
 		log.Fatalf("Failed to p2p listen: %v", err)
@@ -25,7 +25,7 @@ func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 		privKeyOption(priKey),
 		libp2p.EnableRelay(),
 		libp2p.ListenAddrs(listen),
-		whitelistSubnet(cfg.WhitelistCIDR),
+		libp2p.ConnectionGater(gater),
 		// Add one for the boot node and another for the relay, otherwise when we are close to maxPeers we will be above the high
 		// water mark and continually trigger pruning.
 		libp2p.ConnectionManager(connmgr.NewConnManager(int(cfg.MaxPeers+2), int(cfg.MaxPeers+2), 1*time.Second)),
@@ -34,6 +34,18 @@ func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 		// Enable NOISE for the beacon node
 		options = append(options, libp2p.Security(noise.ID, noise.New))
 	}
+	if cfg.QUICPort != 0 {
+		quicListen, err := quicMultiAddressBuilder(ip.String(), cfg.QUICPort)
+		if err != nil {
+			log.Fatalf("Failed to p2p listen: %v", err)
+		}
+		// Setting a transport replaces the default transports, so they are added back for TCP.
+		options = append(options,
+			libp2p.ListenAddrs(quicListen),
+			libp2p.DefaultTransports,
+			libp2p.Transport(libp2pquic.NewTransport),
+		)
+	}
 	if cfg.EnableUPnP {
 		options = append(options, libp2p.NATPortMap()) //Allow to use UPnP
 	}
@@ -48,6 +60,14 @@ func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 			} else {
 				addrs = append(addrs, external)
 			}
+			if cfg.QUICPort != 0 {
+				external, err := quicMultiAddressBuilder(cfg.HostAddress, cfg.QUICPort)
+				if err != nil {
+					log.WithError(err).Error("Unable to create external QUIC multiaddress")
+				} else {
+					addrs = append(addrs, external)
+				}
+			}
 			return addrs
 		}))
 	}
@@ -59,6 +79,14 @@ func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 			} else {
 				addrs = append(addrs, external)
 			}
+			if cfg.QUICPort != 0 {
+				external, err := ma.NewMultiaddr(fmt.Sprintf("/dns4/%s/udp/%d/quic", cfg.HostDNS, cfg.QUICPort))
+				if err != nil {
+					log.WithError(err).Error("Unable to create external QUIC multiaddress")
+				} else {
+					addrs = append(addrs, external)
+				}
+			}
 			return addrs
 		}))
 	}
@@ -72,6 +100,13 @@ func buildOptions(cfg *Config, ip net.IP, priKey *ecdsa.PrivateKey) []libp2p.Opt
 			log.Fatalf("Failed to p2p listen: %v", err)
 		}
 		options = append(options, libp2p.ListenAddrs(listen))
+		if cfg.QUICPort != 0 {
+			quicListen, err := quicMultiAddressBuilder(cfg.LocalIP, cfg.QUICPort)
+			if err != nil {
+				log.Fatalf("Failed to p2p listen: %v", err)
+			}
+			options = append(options, libp2p.ListenAddrs(quicListen))
+		}
 	}
 	return options
 }
@@ -87,6 +122,17 @@ func multiAddressBuilder(ipAddr string, port uint) (ma.Multiaddr, error) {
 	return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/tcp/%d", ipAddr, port))
 }
 
+func quicMultiAddressBuilder(ipAddr string, port uint) (ma.Multiaddr, error) {
+	parsedIP := net.ParseIP(ipAddr)
+	if parsedIP.To4() == nil && parsedIP.To16() == nil {
+		return nil, errors.Errorf("invalid ip address provided: %s", ipAddr)
+	}
+	if parsedIP.To4() != nil {
+		return ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/udp/%d/quic", ipAddr, port))
+	}
+	return ma.NewMultiaddr(fmt.Sprintf("/ip6/%s/udp/%d/quic", ipAddr, port))
+}
+
 // Adds a private key to the libp2p option if the option was provided.
 // If the private key file is missing or cannot be read, or if the
 // private key contents cannot be marshaled, an exception is thrown.
@@ -96,28 +142,3 @@ func privKeyOption(privkey *ecdsa.PrivateKey) libp2p.Option {
 		return cfg.Apply(libp2p.Identity(convertToInterfacePrivkey(privkey)))
 	}
 }
-
-// whitelistSubnet adds a whitelist multiaddress filter for a given CIDR subnet.
-// Example: 192.168.0.0/16 may be used to accept only connections on your local
-// network.
-func whitelistSubnet(cidr string) libp2p.Option {
-	if cidr == "" {
-		return func(_ *libp2p.Config) error {
-			return nil
-		}
-	}
-
-	return func(cfg *libp2p.Config) error {
-		_, ipnet, err := net.ParseCIDR(cidr)
-		if err != nil {
-			return err
-		}
-
-		if cfg.Filters == nil {
-			cfg.Filters = filter.NewFilters()
-		}
-		cfg.Filters.AddFilter(*ipnet, filter.ActionAccept)
-
-		return nil
-	}
-}
@@ -72,7 +72,11 @@ type peerStatus struct {
 	enr                   *enr.Record
 	metaData              *pb.MetaData
 	chainStateLastUpdated time.Time
+	connectedAt           time.Time
+	agent                 string
 	badResponses          int
+	trusted               bool
+	static                bool
 }
 
 // NewStatus creates a new status entity.
@@ -246,6 +250,16 @@ func (p *Status) SetConnectionState(pid peer.ID, state PeerConnectionState) {
 	defer p.lock.Unlock()
 
 	status := p.fetch(pid)
+	if state == PeerConnecting && status.peerState != PeerConnecting {
+		// A new connection starts with no known chain state or metadata, as the peer
+		// may have restarted with a new sequence number since we last saw it.
+		status.chainState = nil
+		status.metaData = nil
+		status.chainStateLastUpdated = roughtime.Now()
+	}
+	if state == PeerConnected && status.peerState != PeerConnected {
+		status.connectedAt = roughtime.Now()
+	}
 	status.peerState = state
 }
 
@@ -273,12 +287,48 @@ func (p *Status) ChainStateLastUpdated(pid peer.ID) (time.Time, error) {
 	return roughtime.Now(), ErrPeerUnknown
 }
 
+// ConnectedAt gets the time the given remote peer last completed the handshake and became connected.
+// This will error if the peer does not exist.
+func (p *Status) ConnectedAt(pid peer.ID) (time.Time, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		return status.connectedAt, nil
+	}
+	return time.Time{}, ErrPeerUnknown
+}
+
+// SetAgent sets the agent string the given remote peer identified itself with.
+func (p *Status) SetAgent(pid peer.ID, agent string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	status := p.fetch(pid)
+	status.agent = agent
+}
+
+// Agent gets the agent string the given remote peer identified itself with.
+// This will error if the peer does not exist.
+func (p *Status) Agent(pid peer.ID) (string, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		return status.agent, nil
+	}
+	return "", ErrPeerUnknown
+}
+
 // IncrementBadResponses increments the number of bad responses we have received from the given remote peer.
 func (p *Status) IncrementBadResponses(pid peer.ID) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
 	status := p.fetch(pid)
+	if status.trusted || status.static {
+		return
+	}
 	status.badResponses++
 }
 
@@ -296,12 +346,56 @@ func (p *Status) BadResponses(pid peer.ID) (int, error) {
 
 // IsBad states if the peer is to be considered bad.
 // If the peer is unknown this will return `false`, which makes using this function easier than returning an error.
+// Trusted and static peers are never considered bad.
 func (p *Status) IsBad(pid peer.ID) bool {
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
 	if status, ok := p.status[pid]; ok {
-		return status.badResponses >= p.maxBadResponses
+		return !status.trusted && !status.static && status.badResponses >= p.maxBadResponses
+	}
+	return false
+}
+
+// SetTrusted marks the given remote peer as trusted, exempting it from scoring and pruning.
+func (p *Status) SetTrusted(pid peer.ID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	status := p.fetch(pid)
+	status.trusted = true
+}
+
+// IsTrusted states if the peer has been marked as trusted.
+// If the peer is unknown this will return `false`.
+func (p *Status) IsTrusted(pid peer.ID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		return status.trusted
+	}
+	return false
+}
+
+// SetStatic marks the given remote peer as static, exempting it from scoring and pruning like
+// trusted peers, as the node keeps redialing it.
+func (p *Status) SetStatic(pid peer.ID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	status := p.fetch(pid)
+	status.static = true
+}
+
+// IsStatic states if the peer has been marked as static.
+// If the peer is unknown this will return `false`.
+func (p *Status) IsStatic(pid peer.ID) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if status, ok := p.status[pid]; ok {
+		return status.static
 	}
 	return false
 }
@@ -286,6 +286,54 @@ func TestPeerImplicitAdd(t *testing.T) {
 	}
 }
 
+func TestPeerConnectedAtAndAgent(t *testing.T) {
+	maxBadResponses := 2
+	p := peers.NewStatus(maxBadResponses)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.SetConnectionState(id, peers.PeerConnecting)
+	connectedAt, err := p.ConnectedAt(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !connectedAt.IsZero() {
+		t.Errorf("Expected no connection time for a connecting peer, received %v", connectedAt)
+	}
+
+	p.SetConnectionState(id, peers.PeerConnected)
+	connectedAt, err = p.ConnectedAt(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if connectedAt.IsZero() {
+		t.Error("Expected connection time to be set for a connected peer")
+	}
+
+	// Setting the same state again must not reset the connection time.
+	p.SetConnectionState(id, peers.PeerConnected)
+	resConnectedAt, err := p.ConnectedAt(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !resConnectedAt.Equal(connectedAt) {
+		t.Errorf("Unexpected connection time: expected %v, received %v", connectedAt, resConnectedAt)
+	}
+
+	agent := "Prysm/v0.2.0"
+	p.SetAgent(id, agent)
+	resAgent, err := p.Agent(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resAgent != agent {
+		t.Errorf("Unexpected agent: expected %s, received %s", agent, resAgent)
+	}
+}
+
 func TestPeerChainState(t *testing.T) {
 	maxBadResponses := 2
 	p := peers.NewStatus(maxBadResponses)
@@ -326,6 +374,41 @@ func TestPeerChainState(t *testing.T) {
 	}
 }
 
+func TestPeerConnecting_ResetsChainStateAndMetadata(t *testing.T) {
+	p := peers.NewStatus(2)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	if err != nil {
+		t.Fatalf("Failed to create address: %v", err)
+	}
+	p.Add(new(enr.Record), id, address, network.DirInbound)
+	p.SetConnectionState(id, peers.PeerConnected)
+	p.SetChainState(id, &pb.Status{FinalizedEpoch: 123})
+	p.SetMetadata(id, &pb.MetaData{SeqNumber: 8})
+
+	p.SetConnectionState(id, peers.PeerDisconnected)
+	p.SetConnectionState(id, peers.PeerConnecting)
+
+	chainState, err := p.ChainState(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if chainState != nil {
+		t.Errorf("Wanted no chain state after reconnecting, received %v", chainState)
+	}
+	md, err := p.Metadata(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if md != nil {
+		t.Errorf("Wanted no metadata after reconnecting, received %v", md)
+	}
+}
+
 func TestPeerBadResponses(t *testing.T) {
 	maxBadResponses := 2
 	p := peers.NewStatus(maxBadResponses)
@@ -697,3 +780,56 @@ func addPeer(t *testing.T, p *peers.Status, state peers.PeerConnectionState) pee
 	})
 	return id
 }
+
+func TestTrustedPeer_NeverBad(t *testing.T) {
+	maxBadResponses := 1
+	p := peers.NewStatus(maxBadResponses)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.IsTrusted(id) {
+		t.Error("Unknown peer marked as trusted")
+	}
+	p.SetTrusted(id)
+	if !p.IsTrusted(id) {
+		t.Error("Peer not marked as trusted when it should be")
+	}
+
+	p.IncrementBadResponses(id)
+	p.IncrementBadResponses(id)
+	resBadResponses, err := p.BadResponses(id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resBadResponses != 0 {
+		t.Errorf("Unexpected bad responses: expected 0, received %v", resBadResponses)
+	}
+	if p.IsBad(id) {
+		t.Error("Trusted peer marked as bad")
+	}
+}
+
+func TestStaticPeer_NeverBad(t *testing.T) {
+	maxBadResponses := 1
+	p := peers.NewStatus(maxBadResponses)
+
+	id, err := peer.IDB58Decode("16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.IsStatic(id) {
+		t.Error("Unknown peer marked as static")
+	}
+	p.SetStatic(id)
+	if !p.IsStatic(id) {
+		t.Error("Peer not marked as static when it should be")
+	}
+
+	p.IncrementBadResponses(id)
+	p.IncrementBadResponses(id)
+	if p.IsBad(id) {
+		t.Error("Static peer marked as bad")
+	}
+}
@@ -0,0 +1,155 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// peerStoreInterval is how often the known-good peers are persisted.
+	peerStoreInterval = 5 * time.Minute
+	// maxPersistedPeers is the maximum number of peers kept in the peer store.
+	maxPersistedPeers = 100
+	// persistedPeerExpiry is how long a peer which is no longer connected is kept in the peer store.
+	persistedPeerExpiry = 24 * time.Hour
+)
+
+// PeerStore persists the known-good peers of the node, so they can be dialed again after a
+// restart instead of relying on discovery alone.
+type PeerStore interface {
+	Peers(ctx context.Context) ([]*dbpb.PeerRecord, error)
+	SavePeers(ctx context.Context, peers []*dbpb.PeerRecord) error
+}
+
+// persistPeers saves the currently connected peers which are not bad, along with the
+// previously persisted peers which have not expired.
+func (s *Service) persistPeers() {
+	if s.cfg.PeerStore == nil {
+		return
+	}
+	ctx := context.Background()
+	previous, err := s.cfg.PeerStore.Peers(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve persisted peers")
+		return
+	}
+	records := mergePeerRecords(previous, connectedPeerRecords(s.Peers(), time.Now()), s.Peers().MaxBadResponses(), time.Now())
+	if err := s.cfg.PeerStore.SavePeers(ctx, records); err != nil {
+		log.WithError(err).Error("Could not persist peers")
+		return
+	}
+	log.WithField("peers", len(records)).Debug("Persisted known-good peers")
+}
+
+// dialPersistedPeers dials the peers persisted during a previous run of the node.
+func (s *Service) dialPersistedPeers() {
+	if s.cfg.PeerStore == nil {
+		return
+	}
+	records, err := s.cfg.PeerStore.Peers(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve persisted peers")
+		return
+	}
+	for _, record := range records {
+		if record.BadResponses >= uint64(s.Peers().MaxBadResponses()) {
+			continue
+		}
+		info, err := peerRecordToAddrInfo(record)
+		if err != nil {
+			log.WithError(err).WithField("peer", record.PeerId).Debug("Could not parse persisted peer")
+			continue
+		}
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(s.ctx, info); err != nil {
+				log.WithError(err).WithFields(logrus.Fields{
+					"peer": info.ID.String(),
+				}).Trace("Could not dial persisted peer")
+			}
+		}(info)
+	}
+	log.WithField("peers", len(records)).Info("Dialing persisted peers")
+}
+
+// connectedPeerRecords returns the records of the connected peers which are not bad.
+func connectedPeerRecords(p *peers.Status, now time.Time) []*dbpb.PeerRecord {
+	var records []*dbpb.PeerRecord
+	for _, pid := range p.Connected() {
+		if p.IsBad(pid) {
+			continue
+		}
+		address, err := p.Address(pid)
+		if err != nil || address == nil {
+			continue
+		}
+		badResponses, err := p.BadResponses(pid)
+		if err != nil {
+			continue
+		}
+		records = append(records, &dbpb.PeerRecord{
+			PeerId:       pid.String(),
+			Addresses:    []string{address.String()},
+			BadResponses: uint64(badResponses),
+			LastSeen:     uint64(now.Unix()),
+		})
+	}
+	return records
+}
+
+// mergePeerRecords merges the records of the currently connected peers into the previously
+// persisted records. Bad and expired peers are dropped, and only the most recently seen peers
+// are kept.
+func mergePeerRecords(previous []*dbpb.PeerRecord, current []*dbpb.PeerRecord, maxBadResponses int, now time.Time) []*dbpb.PeerRecord {
+	expiry := uint64(now.Add(-persistedPeerExpiry).Unix())
+	merged := make(map[string]*dbpb.PeerRecord, len(previous)+len(current))
+	for _, record := range previous {
+		if record.LastSeen < expiry {
+			continue
+		}
+		merged[record.PeerId] = record
+	}
+	for _, record := range current {
+		merged[record.PeerId] = record
+	}
+	records := make([]*dbpb.PeerRecord, 0, len(merged))
+	for _, record := range merged {
+		if record.BadResponses >= uint64(maxBadResponses) {
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].LastSeen != records[j].LastSeen {
+			return records[i].LastSeen > records[j].LastSeen
+		}
+		return records[i].PeerId < records[j].PeerId
+	})
+	if len(records) > maxPersistedPeers {
+		records = records[:maxPersistedPeers]
+	}
+	return records
+}
+
+// peerRecordToAddrInfo converts a persisted peer record into the address info used to dial it.
+func peerRecordToAddrInfo(record *dbpb.PeerRecord) (peer.AddrInfo, error) {
+	id, err := peer.IDB58Decode(record.PeerId)
+	if err != nil {
+		return peer.AddrInfo{}, err
+	}
+	info := peer.AddrInfo{ID: id}
+	for _, addr := range record.Addresses {
+		multiAddr, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			return peer.AddrInfo{}, err
+		}
+		info.Addrs = append(info.Addrs, multiAddr)
+	}
+	return info, nil
+}
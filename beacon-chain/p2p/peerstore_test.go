@@ -0,0 +1,76 @@
+package p2p
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	dbpb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+)
+
+func TestMergePeerRecords(t *testing.T) {
+	now := time.Now()
+	seen := func(ago time.Duration) uint64 {
+		return uint64(now.Add(-ago).Unix())
+	}
+	previous := []*dbpb.PeerRecord{
+		{PeerId: "a", Addresses: []string{"/ip4/1.1.1.1/tcp/13000"}, LastSeen: seen(time.Hour)},
+		{PeerId: "b", Addresses: []string{"/ip4/2.2.2.2/tcp/13000"}, LastSeen: seen(2 * persistedPeerExpiry)},
+		{PeerId: "c", Addresses: []string{"/ip4/3.3.3.3/tcp/13000"}, LastSeen: seen(time.Minute), BadResponses: 5},
+	}
+	current := []*dbpb.PeerRecord{
+		{PeerId: "a", Addresses: []string{"/ip4/1.1.1.2/tcp/13000"}, LastSeen: seen(0)},
+		{PeerId: "d", Addresses: []string{"/ip4/4.4.4.4/tcp/13000"}, LastSeen: seen(0)},
+	}
+
+	records := mergePeerRecords(previous, current, 5, now)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 persisted peers, received %d", len(records))
+	}
+	if records[0].PeerId != "a" || records[1].PeerId != "d" {
+		t.Errorf("Unexpected persisted peers %s and %s", records[0].PeerId, records[1].PeerId)
+	}
+	if records[0].Addresses[0] != "/ip4/1.1.1.2/tcp/13000" {
+		t.Errorf("Expected the last seen address to be persisted, received %s", records[0].Addresses[0])
+	}
+}
+
+func TestMergePeerRecords_KeepsMostRecent(t *testing.T) {
+	now := time.Now()
+	var current []*dbpb.PeerRecord
+	for i := 0; i < maxPersistedPeers+10; i++ {
+		current = append(current, &dbpb.PeerRecord{
+			PeerId:   fmt.Sprintf("peer%d", i),
+			LastSeen: uint64(now.Unix()) - uint64(i),
+		})
+	}
+	records := mergePeerRecords(nil, current, 5, now)
+	if len(records) != maxPersistedPeers {
+		t.Fatalf("Expected %d persisted peers, received %d", maxPersistedPeers, len(records))
+	}
+	if records[len(records)-1].PeerId != fmt.Sprintf("peer%d", maxPersistedPeers-1) {
+		t.Errorf("Expected the least recently seen peers to be dropped, last kept %s", records[len(records)-1].PeerId)
+	}
+}
+
+func TestPeerRecordToAddrInfo(t *testing.T) {
+	record := &dbpb.PeerRecord{
+		PeerId:    "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR",
+		Addresses: []string{"/ip4/127.0.0.1/tcp/13000"},
+	}
+	info, err := peerRecordToAddrInfo(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ID.String() != record.PeerId {
+		t.Errorf("Expected peer %s, received %s", record.PeerId, info.ID.String())
+	}
+	if len(info.Addrs) != 1 || info.Addrs[0].String() != record.Addresses[0] {
+		t.Errorf("Unexpected addresses %v", info.Addrs)
+	}
+
+	record.Addresses = []string{"not a multiaddr"}
+	if _, err := peerRecordToAddrInfo(record); err == nil {
+		t.Error("Expected error for invalid address")
+	}
+}
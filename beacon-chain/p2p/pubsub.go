@@ -0,0 +1,88 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// JoinTopic joins the given pubsub topic, applying the gossip scoring parameters of the
+// topic, and returns its handle. Joining a topic which was already joined returns the
+// existing handle.
+func (s *Service) JoinTopic(topic string) (*pubsub.Topic, error) {
+	s.joinedTopicsLock.Lock()
+	defer s.joinedTopicsLock.Unlock()
+
+	if s.joinedTopics == nil {
+		s.joinedTopics = make(map[string]*pubsub.Topic)
+	}
+	if topicHandle, ok := s.joinedTopics[topic]; ok {
+		return topicHandle, nil
+	}
+	topicHandle, err := s.pubsub.Join(topic)
+	if err != nil {
+		return nil, err
+	}
+	if s.cfg != nil && s.cfg.PubSub == pubsubGossip {
+		if scoreParams := topicScoreParams(topic); scoreParams != nil {
+			if err := topicHandle.SetScoreParams(scoreParams); err != nil {
+				return nil, err
+			}
+		}
+	}
+	s.joinedTopics[topic] = topicHandle
+	return topicHandle, nil
+}
+
+// SubscribeToTopic joins the given pubsub topic if needed and subscribes to it.
+func (s *Service) SubscribeToTopic(topic string, opts ...pubsub.SubOpt) (*pubsub.Subscription, error) {
+	topicHandle, err := s.JoinTopic(topic)
+	if err != nil {
+		return nil, err
+	}
+	return topicHandle.Subscribe(opts...)
+}
+
+// PublishToTopic joins the given pubsub topic if needed and publishes the data to it.
+func (s *Service) PublishToTopic(ctx context.Context, topic string, data []byte, opts ...pubsub.PubOpt) error {
+	topicHandle, err := s.JoinTopic(topic)
+	if err != nil {
+		return err
+	}
+	return topicHandle.Publish(ctx, data, opts...)
+}
+
+// peerScoreInspector returns a function writing snapshots of the gossip scores of
+// all peers to the given file as JSON, or logging them if no file is given.
+func peerScoreInspector(outputFile string) pubsub.PeerScoreInspectFn {
+	return func(scores map[peer.ID]float64) {
+		if outputFile == "" {
+			for pid, score := range scores {
+				log.WithField("peer", pid.Pretty()).WithField("score", score).Debug("Gossip peer score")
+			}
+			return
+		}
+		snapshot := make(map[string]float64, len(scores))
+		for pid, score := range scores {
+			snapshot[pid.Pretty()] = score
+		}
+		enc, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			log.WithError(err).Error("Could not encode peer score snapshot")
+			return
+		}
+		// Write to a temporary file first so readers never see a partial snapshot.
+		tmpFile := outputFile + ".tmp"
+		if err := ioutil.WriteFile(tmpFile, enc, 0600); err != nil {
+			log.WithError(err).Error("Could not write peer score snapshot")
+			return
+		}
+		if err := os.Rename(tmpFile, outputFile); err != nil {
+			log.WithError(err).Error("Could not write peer score snapshot")
+		}
+	}
+}
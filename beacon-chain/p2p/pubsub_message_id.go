@@ -1,17 +1,40 @@
 package p2p
 
 import (
-	"encoding/base64"
-
+	"github.com/golang/snappy"
 	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// Message domains mixed into the message-id, distinguishing messages whose data is valid
+// snappy from those whose data is not.
+var (
+	messageDomainInvalidSnappy = [4]byte{0, 0, 0, 0}
+	messageDomainValidSnappy   = [4]byte{1, 0, 0, 0}
 )
 
-// Content addressable ID function.
+// MsgID computes the gossipsub message-id of a message as defined by the spec. If the message
+// data has a valid snappy decompression, the message-id is the first 20 bytes of
+// SHA256(MESSAGE_DOMAIN_VALID_SNAPPY + snappy_decompress(message.data)), otherwise it is the
+// first 20 bytes of SHA256(MESSAGE_DOMAIN_INVALID_SNAPPY + message.data).
 //
-// Loosely defined as Base64(sha2(data)) until a formal specification is determined.
-// Pending: https://github.com/ethereum/eth2.0-specs/issues/1528
-func msgIDFunction(pmsg *pubsub_pb.Message) string {
-	h := hashutil.FastSum256(pmsg.Data)
-	return base64.URLEncoding.EncodeToString(h[:])
+// Hashing the decompressed data gives the same message the same id regardless of how it was
+// compressed, so re-encoded duplicates are recognized by the seen messages cache.
+func MsgID(pmsg *pubsub_pb.Message) string {
+	if pmsg == nil {
+		return ""
+	}
+	// Data claiming to decompress beyond the maximum gossip size is treated as invalid snappy,
+	// so a message can't make the node allocate more than the maximum gossip size.
+	if n, err := snappy.DecodedLen(pmsg.Data); err == nil && uint64(n) <= params.BeaconNetworkConfig().GossipMaxSize {
+		if decoded, err := snappy.Decode(nil, pmsg.Data); err == nil {
+			messageIDCounter.WithLabelValues("valid_snappy").Inc()
+			h := hashutil.Hash(append(messageDomainValidSnappy[:], decoded...))
+			return string(h[:20])
+		}
+	}
+	messageIDCounter.WithLabelValues("invalid_snappy").Inc()
+	h := hashutil.Hash(append(messageDomainInvalidSnappy[:], pmsg.Data...))
+	return string(h[:20])
 }
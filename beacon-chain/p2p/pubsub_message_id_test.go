@@ -0,0 +1,41 @@
+package p2p
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/snappy"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+func TestMsgID_ValidSnappy(t *testing.T) {
+	data := bytes.Repeat([]byte("ab"), 20)
+	want := hashutil.Hash(append([]byte{1, 0, 0, 0}, data...))
+
+	id := MsgID(&pubsub_pb.Message{Data: snappy.Encode(nil, data)})
+	if id != string(want[:20]) {
+		t.Errorf("Wanted message-id %#x, received %#x", want[:20], id)
+	}
+	// Encoding the data as a single literal, without compression, gives the same message-id.
+	uncompressed := append([]byte{byte(len(data)), byte((len(data) - 1) << 2)}, data...)
+	if bytes.Equal(uncompressed, snappy.Encode(nil, data)) {
+		t.Fatal("Wanted a different snappy encoding of the data")
+	}
+	if MsgID(&pubsub_pb.Message{Data: uncompressed}) != id {
+		t.Error("Wanted the same message-id for a different snappy encoding of the same data")
+	}
+}
+
+func TestMsgID_InvalidSnappy(t *testing.T) {
+	data := []byte{0xff, 0xff, 0xff, 0xff, 0xff}
+	want := hashutil.Hash(append([]byte{0, 0, 0, 0}, data...))
+
+	id := MsgID(&pubsub_pb.Message{Data: data})
+	if id != string(want[:20]) {
+		t.Errorf("Wanted message-id %#x, received %#x", want[:20], id)
+	}
+	if len(id) != 20 {
+		t.Errorf("Wanted a 20 byte message-id, received %d bytes", len(id))
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/ristretto"
+	"github.com/ethereum/go-ethereum/p2p/dnsdisc"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/gogo/protobuf/proto"
@@ -82,19 +84,29 @@ type Service struct {
 	exclusionList         *ristretto.Cache
 	metaData              *pb.MetaData
 	pubsub                *pubsub.PubSub
+	joinedTopics          map[string]*pubsub.Topic
+	joinedTopicsLock      sync.Mutex
 	dv5Listener           Listener
+	dnsClient             *dnsdisc.Client
+	staticPeers           *staticPeerManager
+	gater                 *connectionGater
 	startupErr            error
 	stateNotifier         statefeed.Notifier
 	ctx                   context.Context
 	host                  host.Host
 	genesisTime           time.Time
 	genesisValidatorsRoot []byte
+	broadcastLock         sync.RWMutex
+	broadcastsPaused      bool
 }
 
 // NewService initializes a new p2p service compatible with shared.Service interface. No
 // connections are made until the Start function is called during the service registry startup.
 func NewService(cfg *Config) (*Service, error) {
 	var err error
+	if cfg.QUICPort != 0 && cfg.QUICPort == cfg.UDPPort && !cfg.NoDiscovery && !cfg.DisableDiscv5 {
+		return nil, fmt.Errorf("QUIC port %d must differ from the discovery UDP port", cfg.QUICPort)
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	cache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1000,
@@ -114,11 +126,33 @@ func NewService(cfg *Config) (*Service, error) {
 		isPreGenesis:  true,
 	}
 
-	dv5Nodes, kadDHTNodes := parseBootStrapAddrs(s.cfg.BootstrapNodeAddr)
+	dnsNodes, bootstrapAddrs := parseDNSBootstrapAddrs(s.cfg.BootstrapNodeAddr)
+	dv5Nodes, kadDHTNodes := parseBootStrapAddrs(bootstrapAddrs)
 
+	cfg.DNSBootstrapAddr = dnsNodes
 	cfg.Discv5BootStrapAddr = dv5Nodes
 	cfg.KademliaBootStrapAddr = kadDHTNodes
 
+	if len(cfg.DNSBootstrapAddr) != 0 && !cfg.NoDiscovery {
+		s.dnsClient, err = newDNSClient(cfg.DNSBootstrapAddr)
+		if err != nil {
+			log.WithError(err).Error("Failed to create dns discovery client")
+			return nil, err
+		}
+	}
+
+	// The configured allow list is copied, so appending to it cannot modify the config.
+	allowList := make([]string, 0, len(cfg.AllowList)+1)
+	allowList = append(allowList, cfg.AllowList...)
+	if cfg.WhitelistCIDR != "" {
+		allowList = append(allowList, cfg.WhitelistCIDR)
+	}
+	s.gater, err = newConnectionGater(allowList, cfg.DenyList)
+	if err != nil {
+		log.WithError(err).Error("Failed to create connection gater")
+		return nil, err
+	}
+
 	ipAddr := ipAddr()
 	s.privKey, err = privKey(s.cfg)
 	if err != nil {
@@ -131,7 +165,7 @@ func NewService(cfg *Config) (*Service, error) {
 		return nil, err
 	}
 
-	opts := buildOptions(s.cfg, ipAddr, s.privKey)
+	opts := buildOptions(s.cfg, ipAddr, s.privKey, s.gater)
 	h, err := libp2p.New(s.ctx, opts...)
 	if err != nil {
 		log.WithError(err).Error("Failed to create p2p host")
@@ -156,7 +190,6 @@ func NewService(cfg *Config) (*Service, error) {
 	}
 	s.host = h
 
-	// TODO(3147): Add gossip sub options
 	// Gossipsub registration is done before we add in any new peers
 	// due to libp2p's gossipsub implementation not taking into
 	// account previously added peers when creating the gossipsub
@@ -164,7 +197,14 @@ func NewService(cfg *Config) (*Service, error) {
 	psOpts := []pubsub.Option{
 		pubsub.WithMessageSigning(false),
 		pubsub.WithStrictSignatureVerification(false),
-		pubsub.WithMessageIdFn(msgIDFunction),
+		pubsub.WithMessageIdFn(MsgID),
+		pubsub.WithEventTracer(&meshTracer{}),
+	}
+
+	if cfg.SeenMessagesTTL > 0 {
+		// The seen messages cache duration is global in the pubsub library and is read when
+		// the pubsub object is created.
+		pubsub.TimeCacheDuration = cfg.SeenMessagesTTL
 	}
 
 	var gs *pubsub.PubSub
@@ -174,6 +214,11 @@ func NewService(cfg *Config) (*Service, error) {
 	if cfg.PubSub == pubsubFlood {
 		gs, err = pubsub.NewFloodSub(s.ctx, s.host, psOpts...)
 	} else if cfg.PubSub == pubsubGossip {
+		// Gossipsub v1.1 scores peers on their behavior in each topic, see gossip_scoring_params.go.
+		psOpts = append(psOpts, pubsub.WithPeerScore(peerScoringParams()))
+		if cfg.ScoreSnapshotInterval > 0 {
+			psOpts = append(psOpts, pubsub.WithPeerScoreInspect(peerScoreInspector(cfg.ScoreSnapshotFile), cfg.ScoreSnapshotInterval))
+		}
 		gs, err = pubsub.NewGossipSub(s.ctx, s.host, psOpts...)
 	} else if cfg.PubSub == pubsubRandom {
 		gs, err = pubsub.NewRandomSub(s.ctx, s.host, psOpts...)
@@ -235,6 +280,14 @@ func (s *Service) Start() {
 		}
 		s.dv5Listener = listener
 		go s.listenForNewNodes()
+		// Nodes verified through dns discovery also seed the discovery table.
+		if s.dnsClient != nil {
+			go s.seedDiscoveryFromDNS()
+		}
+	}
+
+	if s.dnsClient != nil {
+		go s.listenForDNSNodes()
 	}
 
 	if len(s.cfg.KademliaBootStrapAddr) != 0 && !s.cfg.NoDiscovery {
@@ -265,13 +318,8 @@ func (s *Service) Start() {
 
 	s.started = true
 
-	if len(s.cfg.StaticPeers) > 0 {
-		addrs, err := peersFromStringAddrs(s.cfg.StaticPeers)
-		if err != nil {
-			log.Errorf("Could not connect to static peer: %v", err)
-		}
-		s.connectWithAllPeers(addrs)
-	}
+	s.setupStaticPeers()
+	s.dialPersistedPeers()
 
 	// Periodic functions.
 	runutil.RunEvery(s.ctx, 5*time.Second, func() {
@@ -279,10 +327,20 @@ func (s *Service) Start() {
 	})
 	runutil.RunEvery(s.ctx, time.Hour, s.Peers().Decay)
 	runutil.RunEvery(s.ctx, 10*time.Second, s.updateMetrics)
+	runutil.RunEvery(s.ctx, peerStoreInterval, func() {
+		if err := s.cfg.Scheduler.Wait(s.ctx, "persist-peers"); err != nil {
+			return
+		}
+		s.persistPeers()
+	})
 	runutil.RunEvery(s.ctx, refreshRate, func() {
 		currentEpoch := helpers.SlotToEpoch(helpers.SlotsSince(s.genesisTime))
 		s.RefreshENR(currentEpoch)
 	})
+	if s.cfg.SubscribeAllSubnets {
+		// Advertise every subnet right away instead of waiting for the first refresh.
+		s.RefreshENR(helpers.SlotToEpoch(helpers.SlotsSince(s.genesisTime)))
+	}
 
 	multiAddrs := s.host.Network().ListenAddresses()
 	logIPAddr(s.host.ID(), multiAddrs...)
@@ -303,6 +361,9 @@ func (s *Service) Start() {
 // Stop the p2p service and terminate all peer connections.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if s.started {
+		s.persistPeers()
+	}
 	s.started = false
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
@@ -369,6 +430,19 @@ func (s *Service) Peers() *peers.Status {
 	return s.peers
 }
 
+// ENR returns the local node's current ENR, or nil when discovery is disabled.
+func (s *Service) ENR() *enr.Record {
+	if s.dv5Listener == nil {
+		return nil
+	}
+	return s.dv5Listener.Self().Record()
+}
+
+// HostAddrs returns the multiaddrs the libp2p host listens on.
+func (s *Service) HostAddrs() []ma.Multiaddr {
+	return s.host.Addrs()
+}
+
 // Metadata returns a copy of the peer's metadata.
 func (s *Service) Metadata() *pb.MetaData {
 	return proto.Clone(s.metaData).(*pb.MetaData)
@@ -382,6 +456,7 @@ func (s *Service) MetadataSeq() uint64 {
 // RefreshENR uses an epoch to refresh the enr entry for our node
 // with the tracked committee id's for the epoch, allowing our node
 // to be dynamically discoverable by others given our tracked committee id's.
+// Every subnet is advertised if the node subscribes to all subnets.
 func (s *Service) RefreshENR(epoch uint64) {
 	// return early if discv5 isnt running
 	if s.dv5Listener == nil {
@@ -389,14 +464,20 @@ func (s *Service) RefreshENR(epoch uint64) {
 	}
 	bitV := bitfield.NewBitvector64()
 
-	var committees []uint64
-	epochStartSlot := helpers.StartSlot(epoch)
-	for i := epochStartSlot; i < epochStartSlot+2*params.BeaconConfig().SlotsPerEpoch; i++ {
-		committees = append(committees, sliceutil.UnionUint64(cache.CommitteeIDs.GetAttesterCommitteeIDs(i),
-			cache.CommitteeIDs.GetAggregatorCommitteeIDs(i))...)
-	}
-	for _, idx := range committees {
-		bitV.SetBitAt(idx, true)
+	if s.cfg.SubscribeAllSubnets {
+		for i := uint64(0); i < attestationSubnetCount; i++ {
+			bitV.SetBitAt(i, true)
+		}
+	} else {
+		var committees []uint64
+		epochStartSlot := helpers.StartSlot(epoch)
+		for i := epochStartSlot; i < epochStartSlot+2*params.BeaconConfig().SlotsPerEpoch; i++ {
+			committees = append(committees, sliceutil.UnionUint64(cache.CommitteeIDs.GetAttesterCommitteeIDs(i),
+				cache.CommitteeIDs.GetAggregatorCommitteeIDs(i))...)
+		}
+		for _, idx := range committees {
+			bitV.SetBitAt(idx, true)
+		}
 	}
 	currentBitV, err := retrieveBitvector(s.dv5Listener.Self().Record())
 	if err != nil {
@@ -458,7 +539,7 @@ func (s *Service) FindPeersWithSubnet(index uint64) (bool, error) {
 					continue
 				}
 				s.peers.Add(node.Record(), info.ID, multiAddr, network.DirUnknown)
-				if err := s.connectWithPeer(*info); err != nil {
+				if err := s.connectWithPeer(s.ctx, *info); err != nil {
 					log.WithError(err).Tracef("Could not connect with peer %s", info.String())
 					continue
 				}
@@ -533,15 +614,17 @@ func (s *Service) connectWithAllPeers(multiAddrs []ma.Multiaddr) {
 	for _, info := range addrInfos {
 		// make each dial non-blocking
 		go func(info peer.AddrInfo) {
-			if err := s.connectWithPeer(info); err != nil {
+			if err := s.connectWithPeer(s.ctx, info); err != nil {
 				log.WithError(err).Tracef("Could not connect with peer %s", info.String())
 			}
 		}(info)
 	}
 }
 
-func (s *Service) connectWithPeer(info peer.AddrInfo) error {
-	if len(s.Peers().Active()) >= int(s.cfg.MaxPeers) {
+// connectWithPeer dials the given peer unless it is over the peer limit, a bad peer or
+// not permitted by the peer filters.
+func (s *Service) connectWithPeer(ctx context.Context, info peer.AddrInfo) error {
+	if len(s.Peers().Active()) >= int(s.cfg.MaxPeers) && !s.isPrivilegedPeer(info.ID) {
 		log.WithFields(logrus.Fields{"peer": info.ID.String(),
 			"reason": "at peer limit"}).Trace("Not dialing peer")
 		return nil
@@ -552,7 +635,13 @@ func (s *Service) connectWithPeer(info peer.AddrInfo) error {
 	if s.Peers().IsBad(info.ID) {
 		return nil
 	}
-	if err := s.host.Connect(s.ctx, info); err != nil {
+	info, ok := s.gater.filterAddrInfo(info)
+	if !ok {
+		log.WithFields(logrus.Fields{"peer": info.ID.String(),
+			"reason": "not permitted by peer filters"}).Trace("Not dialing peer")
+		return nil
+	}
+	if err := s.host.Connect(ctx, info); err != nil {
 		s.Peers().IncrementBadResponses(info.ID)
 		return err
 	}
@@ -606,6 +695,15 @@ func (s *Service) processPeers(nodes []*enode.Node) []ma.Multiaddr {
 		// Add peer to peer handler.
 		s.peers.Add(nodeENR, peerData.ID, multiAddr, network.DirUnknown)
 		multiAddrs = append(multiAddrs, multiAddr)
+		// Peers advertising a QUIC port are dialed over both transports.
+		if s.cfg.QUICPort != 0 {
+			quicAddr, err := convertToQUICMultiAddr(node)
+			if err == nil {
+				multiAddrs = append(multiAddrs, quicAddr)
+			} else if !enr.IsNotFound(err) {
+				log.WithError(err).Debug("Could not retrieve QUIC multiaddr")
+			}
+		}
 	}
 	return multiAddrs
 }
@@ -0,0 +1,171 @@
+package p2p
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Initial delay before redialing a static peer after a failed attempt.
+	minStaticPeerBackoff = 5 * time.Second
+	// Upper bound on the delay between redial attempts of a static peer.
+	maxStaticPeerBackoff = 5 * time.Minute
+	// Timeout of a single dial attempt to a static peer.
+	staticPeerDialTimeout = 30 * time.Second
+)
+
+// staticPeer tracks the redial schedule of a single static peer.
+type staticPeer struct {
+	info     peer.AddrInfo
+	backoff  time.Duration
+	nextDial time.Time
+}
+
+// staticPeerManager keeps connections open to a fixed set of peers, redialing
+// them with an exponential backoff whenever they are dropped.
+type staticPeerManager struct {
+	host host.Host
+	// dial connects to a static peer, applying the same checks as any other dial.
+	dial  func(ctx context.Context, info peer.AddrInfo) error
+	lock  sync.Mutex
+	peers map[peer.ID]*staticPeer
+}
+
+func newStaticPeerManager(
+	h host.Host,
+	infos []peer.AddrInfo,
+	dial func(ctx context.Context, info peer.AddrInfo) error,
+) *staticPeerManager {
+	m := &staticPeerManager{
+		host:  h,
+		dial:  dial,
+		peers: make(map[peer.ID]*staticPeer, len(infos)),
+	}
+	for _, info := range infos {
+		m.peers[info.ID] = &staticPeer{
+			info:    info,
+			backoff: minStaticPeerBackoff,
+		}
+	}
+	return m
+}
+
+// isStatic returns true if the provided peer is managed as a static peer.
+func (m *staticPeerManager) isStatic(pid peer.ID) bool {
+	if m == nil {
+		return false
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	_, ok := m.peers[pid]
+	return ok
+}
+
+// dueForDial returns the static peers which are not connected and whose
+// backoff period has elapsed.
+func (m *staticPeerManager) dueForDial(now time.Time) []peer.AddrInfo {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var infos []peer.AddrInfo
+	for pid, p := range m.peers {
+		if m.host.Network().Connectedness(pid) == network.Connected {
+			p.backoff = minStaticPeerBackoff
+			continue
+		}
+		if now.Before(p.nextDial) {
+			continue
+		}
+		infos = append(infos, p.info)
+	}
+	return infos
+}
+
+// recordDial updates the redial schedule of a static peer given the outcome
+// of the latest dial attempt.
+func (m *staticPeerManager) recordDial(pid peer.ID, now time.Time, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	p, ok := m.peers[pid]
+	if !ok {
+		return
+	}
+	if err == nil {
+		p.backoff = minStaticPeerBackoff
+		p.nextDial = time.Time{}
+		return
+	}
+	p.nextDial = now.Add(p.backoff)
+	p.backoff *= 2
+	if p.backoff > maxStaticPeerBackoff {
+		p.backoff = maxStaticPeerBackoff
+	}
+}
+
+// reconnect dials every static peer which is due for a redial.
+func (m *staticPeerManager) reconnect(ctx context.Context) {
+	for _, info := range m.dueForDial(roughtime.Now()) {
+		dialCtx, cancel := context.WithTimeout(ctx, staticPeerDialTimeout)
+		err := m.dial(dialCtx, info)
+		cancel()
+		m.recordDial(info.ID, roughtime.Now(), err)
+		if err != nil {
+			log.WithFields(logrus.Fields{
+				"peer":  info.ID,
+				"addrs": info.Addrs,
+			}).WithError(err).Debug("Could not connect to static peer")
+		}
+	}
+}
+
+// setupStaticPeers registers the configured static and trusted peers. Static peers
+// are protected from pruning and redialed whenever they are dropped, while trusted
+// peers are exempt from peer scoring and allowed past the peer limit.
+func (s *Service) setupStaticPeers() {
+	if len(s.cfg.StaticPeers) > 0 {
+		addrs, err := peersFromStringAddrs(s.cfg.StaticPeers)
+		if err != nil {
+			log.Errorf("Could not connect to static peer: %v", err)
+		}
+		infos, err := peer.AddrInfosFromP2pAddrs(addrs...)
+		if err != nil {
+			log.Errorf("Could not convert to peer address info's from multiaddresses: %v", err)
+		}
+		for _, info := range infos {
+			s.peers.SetStatic(info.ID)
+			s.host.ConnManager().Protect(info.ID, "static")
+		}
+		s.staticPeers = newStaticPeerManager(s.host, infos, s.connectWithPeer)
+		runutil.RunEvery(s.ctx, minStaticPeerBackoff, func() {
+			s.staticPeers.reconnect(s.ctx)
+		})
+	}
+	if len(s.cfg.TrustedPeers) > 0 {
+		addrs, err := peersFromStringAddrs(s.cfg.TrustedPeers)
+		if err != nil {
+			log.Errorf("Could not connect to trusted peer: %v", err)
+		}
+		infos, err := peer.AddrInfosFromP2pAddrs(addrs...)
+		if err != nil {
+			log.Errorf("Could not convert to peer address info's from multiaddresses: %v", err)
+		}
+		for _, info := range infos {
+			s.peers.SetTrusted(info.ID)
+			s.host.ConnManager().Protect(info.ID, "trusted")
+		}
+		s.connectWithAllPeers(addrs)
+	}
+}
+
+// isPrivilegedPeer returns true for static and trusted peers, which are allowed
+// to connect even when the node is at its peer limit.
+func (s *Service) isPrivilegedPeer(pid peer.ID) bool {
+	return s.staticPeers.isStatic(pid) || s.peers.IsTrusted(pid)
+}
@@ -0,0 +1,111 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	bh "github.com/libp2p/go-libp2p-blankhost"
+	"github.com/libp2p/go-libp2p-core/peer"
+	swarmt "github.com/libp2p/go-libp2p-swarm/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+)
+
+func TestStaticPeerManager_Backoff(t *testing.T) {
+	ctx := context.Background()
+	h := bh.NewBlankHost(swarmt.GenSwarm(t, ctx))
+	remote := bh.NewBlankHost(swarmt.GenSwarm(t, ctx))
+	info := peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}
+
+	m := newStaticPeerManager(h, []peer.AddrInfo{info}, h.Connect)
+	if !m.isStatic(remote.ID()) {
+		t.Fatal("Expected peer to be managed as static")
+	}
+	now := time.Now()
+	if len(m.dueForDial(now)) != 1 {
+		t.Fatal("Expected disconnected static peer to be due for dial")
+	}
+
+	m.recordDial(remote.ID(), now, errors.New("dial failed"))
+	if len(m.dueForDial(now)) != 0 {
+		t.Error("Expected static peer to be backing off after failed dial")
+	}
+	if len(m.dueForDial(now.Add(minStaticPeerBackoff))) != 1 {
+		t.Error("Expected static peer to be due for dial after backoff")
+	}
+
+	// Repeated failures double the backoff up to the maximum.
+	for i := 0; i < 20; i++ {
+		m.recordDial(remote.ID(), now, errors.New("dial failed"))
+	}
+	if len(m.dueForDial(now.Add(maxStaticPeerBackoff-time.Second))) != 0 {
+		t.Error("Expected static peer to be backing off")
+	}
+	if len(m.dueForDial(now.Add(maxStaticPeerBackoff))) != 1 {
+		t.Error("Expected backoff to be capped")
+	}
+
+	m.recordDial(remote.ID(), now, nil)
+	if len(m.dueForDial(now)) != 1 {
+		t.Error("Expected backoff to be reset after a successful dial")
+	}
+}
+
+func TestStaticPeerManager_Reconnect(t *testing.T) {
+	ctx := context.Background()
+	h := bh.NewBlankHost(swarmt.GenSwarm(t, ctx))
+	remote := bh.NewBlankHost(swarmt.GenSwarm(t, ctx))
+	info := peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}
+
+	m := newStaticPeerManager(h, []peer.AddrInfo{info}, h.Connect)
+	m.reconnect(ctx)
+	if len(h.Network().ConnsToPeer(remote.ID())) == 0 {
+		t.Fatal("Expected connection to static peer")
+	}
+
+	if err := h.Network().ClosePeer(remote.ID()); err != nil {
+		t.Fatal(err)
+	}
+	m.reconnect(ctx)
+	if len(h.Network().ConnsToPeer(remote.ID())) == 0 {
+		t.Error("Expected static peer to be reconnected")
+	}
+}
+
+func TestStaticPeerManager_ReconnectAppliesPeerFilters(t *testing.T) {
+	ctx := context.Background()
+	h := bh.NewBlankHost(swarmt.GenSwarm(t, ctx))
+	remote := bh.NewBlankHost(swarmt.GenSwarm(t, ctx))
+	info := peer.AddrInfo{ID: remote.ID(), Addrs: remote.Addrs()}
+
+	gater, err := newConnectionGater(nil, []string{remote.ID().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{
+		ctx:   ctx,
+		cfg:   &Config{MaxPeers: 30},
+		host:  h,
+		peers: peers.NewStatus(3),
+		gater: gater,
+	}
+	s.staticPeers = newStaticPeerManager(h, []peer.AddrInfo{info}, s.connectWithPeer)
+	s.staticPeers.reconnect(ctx)
+	if len(h.Network().ConnsToPeer(remote.ID())) != 0 {
+		t.Error("Expected denied static peer not to be dialed")
+	}
+
+	s.gater = nil
+	s.staticPeers.reconnect(ctx)
+	if len(h.Network().ConnsToPeer(remote.ID())) == 0 {
+		t.Error("Expected static peer to be dialed")
+	}
+}
+
+func TestStaticPeerManager_NilIsNotStatic(t *testing.T) {
+	var m *staticPeerManager
+	if m.isStatic("foo") {
+		t.Error("Expected nil manager to have no static peers")
+	}
+}
@@ -144,3 +144,34 @@ func TestStartDiscV5_DiscoverPeersWithSubnets(t *testing.T) {
 	}
 	exitRoutine <- true
 }
+
+func TestRefreshENR_SubscribeAllSubnets(t *testing.T) {
+	ipAddr, pkey := createAddrAndPrivKey(t)
+	s := &Service{
+		cfg:                   &Config{UDPPort: 5000, SubscribeAllSubnets: true},
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: make([]byte, 32),
+		metaData:              &pb.MetaData{Attnets: bitfield.NewBitvector64()},
+	}
+	listener := s.createListener(ipAddr, pkey)
+	defer listener.Close()
+	s.dv5Listener = listener
+
+	s.RefreshENR(0)
+
+	bitV, err := retrieveBitvector(listener.Self().Record())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint64(0); i < attestationSubnetCount; i++ {
+		if !bitV.BitAt(i) {
+			t.Errorf("Expected subnet %d to be advertised in the ENR", i)
+		}
+		if !s.metaData.Attnets.BitAt(i) {
+			t.Errorf("Expected subnet %d to be set in the metadata", i)
+		}
+	}
+	if s.metaData.SeqNumber != 1 {
+		t.Errorf("Wanted metadata sequence number 1, received %d", s.metaData.SeqNumber)
+	}
+}
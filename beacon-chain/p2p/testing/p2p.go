@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/protocol"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	swarmt "github.com/libp2p/go-libp2p-swarm/testing"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	peers "github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -45,6 +47,8 @@ type TestP2P struct {
 	Digest          [4]byte
 	peers           *peers.Status
 	LocalMetadata   *pb.MetaData
+	joinedTopics    map[string]*pubsub.Topic
+	joinedTopicsMu  sync.Mutex
 }
 
 // NewTestP2P initializes a new p2p test service.
@@ -159,6 +163,44 @@ func (p *TestP2P) PubSub() *pubsub.PubSub {
 	return p.pubsub
 }
 
+// JoinTopic joins the topic on the underlying floodsub, returning the existing handle
+// if the topic was already joined.
+func (p *TestP2P) JoinTopic(topic string) (*pubsub.Topic, error) {
+	p.joinedTopicsMu.Lock()
+	defer p.joinedTopicsMu.Unlock()
+
+	if p.joinedTopics == nil {
+		p.joinedTopics = make(map[string]*pubsub.Topic)
+	}
+	if topicHandle, ok := p.joinedTopics[topic]; ok {
+		return topicHandle, nil
+	}
+	topicHandle, err := p.pubsub.Join(topic)
+	if err != nil {
+		return nil, err
+	}
+	p.joinedTopics[topic] = topicHandle
+	return topicHandle, nil
+}
+
+// SubscribeToTopic joins the topic if needed and subscribes to it.
+func (p *TestP2P) SubscribeToTopic(topic string, opts ...pubsub.SubOpt) (*pubsub.Subscription, error) {
+	topicHandle, err := p.JoinTopic(topic)
+	if err != nil {
+		return nil, err
+	}
+	return topicHandle.Subscribe(opts...)
+}
+
+// PublishToTopic joins the topic if needed and publishes the data to it.
+func (p *TestP2P) PublishToTopic(ctx context.Context, topic string, data []byte, opts ...pubsub.PubOpt) error {
+	topicHandle, err := p.JoinTopic(topic)
+	if err != nil {
+		return err
+	}
+	return topicHandle.Publish(ctx, data, opts...)
+}
+
 // Disconnect from a peer.
 func (p *TestP2P) Disconnect(pid peer.ID) error {
 	return p.Host.Network().ClosePeer(pid)
@@ -274,6 +316,16 @@ func (p *TestP2P) MetadataSeq() uint64 {
 	return p.LocalMetadata.SeqNumber
 }
 
+// ENR mocks the p2p func. Test peers do not run discovery, so there is no ENR.
+func (p *TestP2P) ENR() *enr.Record {
+	return nil
+}
+
+// HostAddrs mocks the p2p func.
+func (p *TestP2P) HostAddrs() []ma.Multiaddr {
+	return p.Host.Addrs()
+}
+
 // AddPingMethod mocks the p2p func.
 func (p *TestP2P) AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error) {
 	// no-op
@@ -3,6 +3,7 @@ package powchain
 import (
 	"errors"
 	"math/big"
+	"sort"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -148,11 +149,15 @@ func (b *blockCache) BlockInfoByHeight(height *big.Int) (bool, *blockInfo, error
 // the desired behavior is that the blocks with the highest block number should
 // be present in the cache.
 func (b *blockCache) AddBlock(blk *gethTypes.Block) error {
+	return b.AddBlockInfo(blockToBlockInfo(blk))
+}
+
+// AddBlockInfo adds a blockInfo object to the cache, trimming the least recently
+// added block info the same way as AddBlock.
+func (b *blockCache) AddBlockInfo(bInfo *blockInfo) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	bInfo := blockToBlockInfo(blk)
-
 	if err := b.hashCache.AddIfNotPresent(bInfo); err != nil {
 		return err
 	}
@@ -168,6 +173,34 @@ func (b *blockCache) AddBlock(blk *gethTypes.Block) error {
 	return nil
 }
 
+// HeightExists returns true if a block info with the block number is in the
+// cache. Unlike BlockInfoByHeight, it does not count as a cache hit or miss.
+func (b *blockCache) HeightExists(height *big.Int) (bool, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	_, exists, err := b.heightCache.GetByKey(height.String())
+	return exists, err
+}
+
+// BlockInfos returns all the block infos in the cache, sorted by block number.
+func (b *blockCache) BlockInfos() []*blockInfo {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	objs := b.heightCache.List()
+	infos := make([]*blockInfo, 0, len(objs))
+	for _, obj := range objs {
+		if bInfo, ok := obj.(*blockInfo); ok {
+			infos = append(infos, bInfo)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Number.Cmp(infos[j].Number) < 0
+	})
+	return infos
+}
+
 // trim the FIFO queue to the maxSize.
 func trim(queue *cache.FIFO, maxSize int) {
 	for s := len(queue.ListKeys()); s > maxSize; s-- {
@@ -91,12 +91,16 @@ func (s *Service) BlockTimeByHeight(ctx context.Context, height *big.Int) (uint6
 	if err != nil {
 		return 0, errors.Wrap(err, fmt.Sprintf("could not query block with height %d", height.Uint64()))
 	}
+	if err := s.blockCache.AddBlock(block); err != nil {
+		return 0, err
+	}
 	return block.Time(), nil
 }
 
 // BlockNumberByTimestamp returns the most recent block number up to a given timestamp.
-// This is a naive implementation that will use O(ETH1_FOLLOW_DISTANCE) calls to cache
-// or ETH1. This is called for multiple times but only changes every
+// The headers preceding the head block are backfilled into the block cache in bulk
+// first, so walking back from the head only calls ETH1 for blocks older than the
+// cache. This is called for multiple times but only changes every
 // SlotsPerEth1VotingPeriod (1024 slots) so the whole method should be cached.
 func (s *Service) BlockNumberByTimestamp(ctx context.Context, time uint64) (*big.Int, error) {
 	ctx, span := trace.StartSpan(ctx, "beacon-chain.web3service.BlockNumberByTimestamp")
@@ -108,6 +112,9 @@ func (s *Service) BlockNumberByTimestamp(ctx context.Context, time uint64) (*big
 		if err != nil {
 			return nil, err
 		}
+		if err := s.backfillHeaders(ctx, head.NumberU64()); err != nil {
+			log.WithError(err).Warn("Could not backfill eth1 block headers")
+		}
 
 		for bn := head.Number(); ; bn = big.NewInt(0).Sub(bn, big.NewInt(1)) {
 			if ctx.Err() != nil {
@@ -124,6 +131,9 @@ func (s *Service) BlockNumberByTimestamp(ctx context.Context, time uint64) (*big
 				if err != nil {
 					return nil, err
 				}
+				if err := s.blockCache.AddBlock(blk); err != nil {
+					return nil, err
+				}
 				info = blockToBlockInfo(blk)
 			}
 
@@ -0,0 +1,154 @@
+package powchain
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"github.com/sirupsen/logrus"
+)
+
+// DepositSnapshot returns the latest snapshot of the finalized deposit tree, or nil if the
+// deposit tree has not been finalized yet.
+func (s *Service) DepositSnapshot() *protodb.DepositSnapshot {
+	return s.depositSnapshot
+}
+
+// loadDepositSnapshot reads a deposit snapshot exported by another node and initializes the
+// deposit trie from it, so deposit logs only need to be processed from the snapshot's
+// execution block onwards. Importing a snapshot requires the beacon chain to have started.
+func (s *Service) loadDepositSnapshot(ctx context.Context, path string) error {
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "could not read deposit snapshot")
+	}
+	snapshot := &protodb.DepositSnapshot{}
+	if err := proto.Unmarshal(enc, snapshot); err != nil {
+		return errors.Wrap(err, "could not unmarshal deposit snapshot")
+	}
+	return s.restoreDepositSnapshot(ctx, snapshot)
+}
+
+// restoreDepositSnapshot initializes the deposit trie from a deposit snapshot.
+func (s *Service) restoreDepositSnapshot(ctx context.Context, snapshot *protodb.DepositSnapshot) error {
+	headState, err := s.beaconDB.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if headState == nil {
+		return errors.New("importing a deposit snapshot requires an initialized beacon chain database")
+	}
+	// The chain start of the eth1 chain cannot be found from the deposits of a snapshot,
+	// so the snapshot must record it.
+	if snapshot.GenesisTime == 0 || snapshot.GenesisBlock == 0 {
+		return errors.New("deposit snapshot does not carry the genesis time and eth1 block")
+	}
+	if snapshot.GenesisTime != headState.GenesisTime() {
+		return errors.Errorf(
+			"deposit snapshot genesis time %d does not match the genesis time %d of the database",
+			snapshot.GenesisTime,
+			headState.GenesisTime(),
+		)
+	}
+	genesisState, err := s.beaconDB.GenesisState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis state")
+	}
+	depositTrie, err := trieutil.TrieFromSnapshot(snapshot, int(params.BeaconConfig().DepositContractTreeDepth))
+	if err != nil {
+		return errors.Wrap(err, "could not restore deposit trie from snapshot")
+	}
+	s.depositTrie = depositTrie
+	s.depositSnapshot = snapshot
+	s.lastReceivedMerkleIndex = int64(snapshot.DepositCount) - 1
+	s.latestEth1Data.LastRequestedBlock = snapshot.ExecutionBlockHeight
+	s.chainStartData.Chainstarted = true
+	s.chainStartData.GenesisTime = snapshot.GenesisTime
+	s.chainStartData.GenesisBlock = snapshot.GenesisBlock
+	if genesisState != nil {
+		s.chainStartData.Eth1Data = genesisState.Eth1Data()
+	}
+	log.WithFields(logrus.Fields{
+		"depositCount": snapshot.DepositCount,
+		"eth1Block":    snapshot.ExecutionBlockHeight,
+	}).Info("Imported deposit snapshot")
+	return nil
+}
+
+// updateDepositSnapshot takes a new snapshot of the deposit trie once more deposits have
+// been finalized by the beacon chain, persists it and writes it to the export path if one
+// is configured.
+func (s *Service) updateDepositSnapshot(ctx context.Context) error {
+	checkpoint, err := s.beaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if checkpoint == nil {
+		return nil
+	}
+	finalizedState, err := s.beaconDB.State(ctx, bytesutil.ToBytes32(checkpoint.Root))
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized state")
+	}
+	if finalizedState == nil {
+		return nil
+	}
+	count := finalizedState.Eth1DepositIndex()
+	if count == 0 || count > uint64(s.lastReceivedMerkleIndex+1) {
+		return nil
+	}
+	if s.depositSnapshot != nil && count <= s.depositSnapshot.DepositCount {
+		return nil
+	}
+
+	var blockHeight uint64
+	found := false
+	for _, ctr := range s.depositCache.AllDepositContainers(ctx) {
+		if ctr.Index == int64(count-1) {
+			blockHeight = ctr.Eth1BlockHeight
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	blockHash, err := s.BlockHashByHeight(ctx, new(big.Int).SetUint64(blockHeight))
+	if err != nil {
+		return errors.Wrap(err, "could not get block hash of finalized deposit")
+	}
+
+	snapshot, err := s.depositTrie.Snapshot(count)
+	if err != nil {
+		return errors.Wrap(err, "could not take deposit snapshot")
+	}
+	snapshot.ExecutionBlockHash = blockHash.Bytes()
+	snapshot.ExecutionBlockHeight = blockHeight
+	snapshot.GenesisTime = s.chainStartData.GenesisTime
+	snapshot.GenesisBlock = s.chainStartData.GenesisBlock
+	s.depositSnapshot = snapshot
+
+	if err := s.beaconDB.SavePowchainData(ctx, s.powchainData(ctx)); err != nil {
+		return errors.Wrap(err, "could not save deposit snapshot")
+	}
+	if s.snapshotExportPath != "" {
+		enc, err := proto.Marshal(snapshot)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal deposit snapshot")
+		}
+		if err := ioutil.WriteFile(s.snapshotExportPath, enc, 0600); err != nil {
+			return errors.Wrap(err, "could not export deposit snapshot")
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"depositCount": count,
+		"eth1Block":    blockHeight,
+	}).Debug("Updated finalized deposit snapshot")
+	return nil
+}
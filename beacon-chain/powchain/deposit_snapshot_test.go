@@ -0,0 +1,163 @@
+package powchain
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+// writeTestDepositSnapshot writes a snapshot of numDeposits deposits. The snapshot carries the
+// genesis of the chain unless genesisTime is 0.
+func writeTestDepositSnapshot(t *testing.T, numDeposits int, genesisTime uint64) (string, *trieutil.SparseMerkleTrie) {
+	items := make([][]byte, numDeposits)
+	for i := range items {
+		h := hashutil.Hash([]byte{byte(i)})
+		items[i] = h[:]
+	}
+	depositTrie, err := trieutil.GenerateTrieFromItems(items, int(params.BeaconConfig().DepositContractTreeDepth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := depositTrie.Snapshot(uint64(numDeposits))
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot.ExecutionBlockHeight = 100
+	if genesisTime != 0 {
+		snapshot.GenesisTime = genesisTime
+		snapshot.GenesisBlock = 50
+	}
+	enc, err := proto.Marshal(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir(testutil.TempDir(), "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "deposit_snapshot.pb")
+	if err := ioutil.WriteFile(path, enc, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path, depositTrie
+}
+
+func TestNewService_ImportDepositSnapshot(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+
+	st, _ := testutil.DeterministicGenesisState(t, 8)
+	headRoot := [32]byte{'a'}
+	if err := beaconDB.SaveState(ctx, st, headRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveHeadBlockRoot(ctx, headRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	path, depositTrie := writeTestDepositSnapshot(t, 11, st.GenesisTime())
+	defer func() {
+		if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	web3Service, err := NewService(ctx, &Web3ServiceConfig{
+		ETH1Endpoint:        endpoint,
+		BeaconDB:            beaconDB,
+		DepositSnapshotPath: path,
+	})
+	if err != nil {
+		t.Fatalf("Unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+	if web3Service.DepositTrie().HashTreeRoot() != depositTrie.HashTreeRoot() {
+		t.Error("Deposit trie root does not match snapshot")
+	}
+	if web3Service.lastReceivedMerkleIndex != 10 {
+		t.Errorf("Expected last received merkle index 10, received %d", web3Service.lastReceivedMerkleIndex)
+	}
+	if web3Service.latestEth1Data.LastRequestedBlock != 100 {
+		t.Errorf("Expected last requested block 100, received %d", web3Service.latestEth1Data.LastRequestedBlock)
+	}
+	if web3Service.DepositSnapshot() == nil {
+		t.Error("Expected deposit snapshot to be set")
+	}
+	if !web3Service.chainStartData.Chainstarted {
+		t.Error("Expected chain to be started")
+	}
+	if web3Service.chainStartData.GenesisTime != st.GenesisTime() || web3Service.chainStartData.GenesisBlock != 50 {
+		t.Errorf(
+			"Unexpected genesis time %d and block %d",
+			web3Service.chainStartData.GenesisTime,
+			web3Service.chainStartData.GenesisBlock,
+		)
+	}
+}
+
+func TestNewService_ImportDepositSnapshot_RequiresGenesis(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+
+	st, _ := testutil.DeterministicGenesisState(t, 8)
+	headRoot := [32]byte{'a'}
+	if err := beaconDB.SaveState(ctx, st, headRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := beaconDB.SaveHeadBlockRoot(ctx, headRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		genesisTime uint64
+	}{
+		{name: "no genesis", genesisTime: 0},
+		{name: "other genesis", genesisTime: st.GenesisTime() + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, _ := writeTestDepositSnapshot(t, 3, tt.genesisTime)
+			defer func() {
+				if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+					t.Fatal(err)
+				}
+			}()
+			if _, err := NewService(ctx, &Web3ServiceConfig{
+				ETH1Endpoint:        endpoint,
+				BeaconDB:            beaconDB,
+				DepositSnapshotPath: path,
+			}); err == nil {
+				t.Error("Expected error importing a deposit snapshot without the genesis of the chain")
+			}
+		})
+	}
+}
+
+func TestNewService_ImportDepositSnapshot_RequiresChainStart(t *testing.T) {
+	beaconDB := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, beaconDB)
+
+	path, _ := writeTestDepositSnapshot(t, 3, 1)
+	defer func() {
+		if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if _, err := NewService(context.Background(), &Web3ServiceConfig{
+		ETH1Endpoint:        endpoint,
+		BeaconDB:            beaconDB,
+		DepositSnapshotPath: path,
+	}); err == nil {
+		t.Error("Expected error importing a deposit snapshot into an empty database")
+	}
+}
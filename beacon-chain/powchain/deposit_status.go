@@ -0,0 +1,65 @@
+package powchain
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	lastProcessedBlockGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "powchain_last_processed_block",
+		Help: "The last proof-of-work block whose deposit contract logs were processed",
+	})
+	processedDepositsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "powchain_processed_deposits",
+		Help: "The number of deposits processed from the deposit contract logs",
+	})
+	pendingDepositsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "powchain_pending_deposits",
+		Help: "The number of processed deposits which are not yet included in the beacon state",
+	})
+)
+
+// DepositProcessingStatus describes the progress of processing the deposit contract logs.
+type DepositProcessingStatus struct {
+	// ConnectedToETH1 is true when the node is connected to an eth1 node.
+	ConnectedToETH1 bool
+	// ChainStarted is true once the deposits triggered the start of the beacon chain.
+	ChainStarted bool
+	// LatestBlock is the latest eth1 block observed.
+	LatestBlock uint64
+	// LastProcessedBlock is the last eth1 block whose deposit logs were processed.
+	LastProcessedBlock uint64
+	// ProcessedDeposits is the number of deposits in the deposit trie.
+	ProcessedDeposits uint64
+	// PendingDeposits is the number of deposits not yet included in the beacon state.
+	PendingDeposits uint64
+	// DepositRoot is the root of the deposit trie.
+	DepositRoot [32]byte
+}
+
+// DepositProcessingStatus returns the progress of processing the deposit contract logs.
+func (s *Service) DepositProcessingStatus(ctx context.Context) *DepositProcessingStatus {
+	st := &DepositProcessingStatus{
+		ConnectedToETH1:    s.connectedETH1,
+		ChainStarted:       s.chainStartData.Chainstarted,
+		LatestBlock:        s.latestEth1Data.BlockHeight,
+		LastProcessedBlock: s.latestEth1Data.LastRequestedBlock,
+		ProcessedDeposits:  uint64(s.lastReceivedMerkleIndex + 1),
+		DepositRoot:        s.depositTrie.Root(),
+	}
+	if s.depositCache != nil {
+		st.PendingDeposits = uint64(len(s.depositCache.PendingContainers(ctx, nil)))
+	}
+	return st
+}
+
+// updateDepositProcessingMetrics exports the progress of processing the deposit contract logs.
+func (s *Service) updateDepositProcessingMetrics(ctx context.Context) {
+	st := s.DepositProcessingStatus(ctx)
+	lastProcessedBlockGauge.Set(float64(st.LastProcessedBlock))
+	processedDepositsGauge.Set(float64(st.ProcessedDeposits))
+	pendingDepositsGauge.Set(float64(st.PendingDeposits))
+}
@@ -0,0 +1,45 @@
+package powchain
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+)
+
+func TestDepositProcessingStatus(t *testing.T) {
+	beaconDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	web3Service, err := NewService(ctx, &Web3ServiceConfig{
+		ETH1Endpoint: endpoint,
+		BeaconDB:     beaconDB,
+		DepositCache: depositcache.NewDepositCache(),
+	})
+	if err != nil {
+		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+	web3Service.latestEth1Data.BlockHeight = 100
+	web3Service.latestEth1Data.LastRequestedBlock = 90
+	web3Service.lastReceivedMerkleIndex = 1
+	web3Service.depositCache.InsertPendingDeposit(ctx, &ethpb.Deposit{}, 80, 0, [32]byte{})
+	web3Service.depositCache.InsertPendingDeposit(ctx, &ethpb.Deposit{}, 85, 1, [32]byte{})
+
+	st := web3Service.DepositProcessingStatus(ctx)
+	if st.LatestBlock != 100 || st.LastProcessedBlock != 90 {
+		t.Errorf("Wanted blocks 100/90, received %d/%d", st.LatestBlock, st.LastProcessedBlock)
+	}
+	if st.ProcessedDeposits != 2 {
+		t.Errorf("Wanted 2 processed deposits, received %d", st.ProcessedDeposits)
+	}
+	if st.PendingDeposits != 2 {
+		t.Errorf("Wanted 2 pending deposits, received %d", st.PendingDeposits)
+	}
+	root := web3Service.depositTrie.Root()
+	if !bytes.Equal(st.DepositRoot[:], root[:]) {
+		t.Errorf("Wanted deposit root %#x, received %#x", root, st.DepositRoot)
+	}
+}
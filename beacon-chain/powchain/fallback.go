@@ -0,0 +1,233 @@
+package powchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	activeHTTPEndpointGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "powchain_active_http_endpoint",
+		Help: "Set to 1 for the eth1 http endpoint currently in use and to 0 for all the other configured endpoints, " +
+			"labeled by the position of the endpoint in the configured list",
+	}, []string{"endpoint_index"})
+	httpEndpointFailoverCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "powchain_http_endpoint_failovers_total",
+		Help: "The number of times the beacon node fell back to the next eth1 http endpoint",
+	})
+)
+
+var (
+	// How often the active http endpoint is checked for being in sync with the eth1 chain.
+	endpointHealthCheckPeriod = 1 * time.Minute
+	// Maximum age of the latest block reported by an http endpoint before the endpoint is
+	// considered to be lagging behind the eth1 chain.
+	maxEth1SyncLag = 5 * time.Minute
+)
+
+// errWrongEth1Chain is returned for endpoints which are reachable, but not on the
+// configured eth1 chain.
+var errWrongEth1Chain = errors.New("endpoint is not on the configured eth1 chain")
+
+// chainIDFetcher retrieves the chain id of an eth1 endpoint.
+type chainIDFetcher interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// verifyChainID checks that the endpoint is on the expected eth1 chain and returns its
+// chain id. An expected chain id of zero accepts any chain.
+func verifyChainID(ctx context.Context, fetcher chainIDFetcher, expected uint64) (uint64, error) {
+	chainID, err := fetcher.ChainID(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not retrieve chain id")
+	}
+	if expected != 0 && chainID.Uint64() != expected {
+		return 0, errors.Wrapf(errWrongEth1Chain, "endpoint is on chain id %d, expected chain id %d", chainID.Uint64(), expected)
+	}
+	return chainID.Uint64(), nil
+}
+
+// verifyDepositContract checks that the deposit contract is deployed at the configured
+// address of the endpoint's chain. A zero address disables the check.
+func verifyDepositContract(ctx context.Context, caller bind.ContractCaller, address common.Address) error {
+	if address == (common.Address{}) {
+		return nil
+	}
+	code, err := caller.CodeAt(ctx, address, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve deposit contract code")
+	}
+	if len(code) == 0 {
+		return errors.Wrapf(errWrongEth1Chain, "no deposit contract deployed at %#x", address)
+	}
+	return nil
+}
+
+// redactEndpoint strips the credentials, path and query of an endpoint url, which may
+// carry an API key, so the endpoint can be logged.
+func redactEndpoint(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "[redacted]"
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// dialHTTPEndpoint dials the configured http endpoints in order, starting from the active
+// one, and returns a client for the first endpoint which is reachable, on the expected chain
+// and has the deposit contract deployed.
+func (s *Service) dialHTTPEndpoint(ctx context.Context) (*ethclient.Client, *gethRPC.Client, error) {
+	s.httpEndpointLock.Lock()
+	defer s.httpEndpointLock.Unlock()
+	return s.dialHTTPEndpointFrom(ctx, s.currHTTPEndpoint)
+}
+
+// dialHTTPEndpointFrom dials the configured http endpoints in order, starting from the
+// endpoint at the given index. The caller must hold httpEndpointLock.
+func (s *Service) dialHTTPEndpointFrom(ctx context.Context, start int) (*ethclient.Client, *gethRPC.Client, error) {
+	if len(s.httpEndpoints) == 0 {
+		return nil, nil, errors.New("no eth1 http endpoint provided")
+	}
+	var lastErr error
+	mismatched := 0
+	for i := 0; i < len(s.httpEndpoints); i++ {
+		idx := (start + i) % len(s.httpEndpoints)
+		fields := logrus.Fields{
+			"endpoint":      redactEndpoint(s.httpEndpoints[idx]),
+			"endpointIndex": idx,
+		}
+		rpcClient, err := gethRPC.Dial(s.httpEndpoints[idx])
+		if err != nil {
+			lastErr = err
+			log.WithError(err).WithFields(fields).Warn("Could not dial eth1 http endpoint")
+			continue
+		}
+		client := ethclient.NewClient(rpcClient)
+		if err := s.verifyHTTPEndpoint(ctx, client); err != nil {
+			client.Close()
+			if errors.Cause(err) == errWrongEth1Chain {
+				mismatched++
+			}
+			lastErr = err
+			log.WithError(err).WithFields(fields).Warn("Skipping eth1 http endpoint")
+			continue
+		}
+		s.setActiveHTTPEndpoint(idx)
+		return client, rpcClient, nil
+	}
+	if mismatched == len(s.httpEndpoints) {
+		// Only report a wrong chain once none of the endpoints can be used, so callers can
+		// tell a misconfiguration apart from endpoints which are temporarily unreachable.
+		return nil, nil, errors.Wrap(lastErr, "all eth1 http endpoints are on the wrong chain")
+	}
+	return nil, nil, errors.Errorf("could not connect to any eth1 http endpoint: %v", lastErr)
+}
+
+// verifyHTTPEndpoint checks that the endpoint is on the configured eth1 chain before it is
+// used. Without a configured chain id, the endpoint must be on the chain of the first
+// endpoint connected to, so a fallback never switches the node over to another chain.
+// The caller must hold httpEndpointLock.
+func (s *Service) verifyHTTPEndpoint(ctx context.Context, client *ethclient.Client) error {
+	expected := s.eth1ChainID
+	if expected == 0 {
+		expected = s.httpChainID
+	}
+	chainID, err := verifyChainID(ctx, client, expected)
+	if err != nil {
+		return err
+	}
+	if err := verifyDepositContract(ctx, client, s.depositContractAddress); err != nil {
+		return err
+	}
+	s.httpChainID = chainID
+	return nil
+}
+
+// setActiveHTTPEndpoint marks the endpoint at the given index as the one in use. The
+// caller must hold httpEndpointLock.
+func (s *Service) setActiveHTTPEndpoint(idx int) {
+	s.currHTTPEndpoint = idx
+	for i := range s.httpEndpoints {
+		if i == idx {
+			activeHTTPEndpointGauge.WithLabelValues(strconv.Itoa(i)).Set(1)
+			continue
+		}
+		activeHTTPEndpointGauge.WithLabelValues(strconv.Itoa(i)).Set(0)
+	}
+}
+
+// checkHTTPEndpointHealth falls back to the next http endpoint whenever the active one
+// is unresponsive or its latest block lags too far behind the wall clock.
+func (s *Service) checkHTTPEndpointHealth(ctx context.Context) {
+	if s.blockFetcher == nil {
+		return
+	}
+	header, err := s.blockFetcher.HeaderByNumber(ctx, nil)
+	if err != nil {
+		s.fallbackToNextHTTPEndpoint(errors.Wrap(err, "could not retrieve latest header"))
+		return
+	}
+	blockTime := time.Unix(int64(header.Time), 0)
+	if lag := roughtime.Now().Sub(blockTime); lag > maxEth1SyncLag {
+		s.fallbackToNextHTTPEndpoint(fmt.Errorf("latest block is %v old", lag.Round(time.Second)))
+	}
+}
+
+// fallbackToNextHTTPEndpoint switches the service over to the next healthy http endpoint
+// after the active one failed, and closes the client of the failed endpoint. This is a
+// no-op if only a single endpoint is configured.
+func (s *Service) fallbackToNextHTTPEndpoint(reason error) {
+	if len(s.httpEndpoints) < 2 {
+		return
+	}
+	s.httpEndpointLock.Lock()
+	defer s.httpEndpointLock.Unlock()
+	failed := s.currHTTPEndpoint
+	httpClient, rpcClient, err := s.dialHTTPEndpointFrom(s.ctx, (failed+1)%len(s.httpEndpoints))
+	if err != nil {
+		log.WithError(err).Error("Could not fall back to another eth1 http endpoint")
+		return
+	}
+	depositContractCaller, err := contracts.NewDepositContractCaller(s.depositContractAddress, httpClient)
+	if err != nil {
+		httpClient.Close()
+		log.WithError(err).Error("Could not create deposit contract caller")
+		return
+	}
+
+	s.processingLock.Lock()
+	previous := s.httpRPCClient
+	s.client = httpClient
+	s.httpLogger = httpClient
+	s.blockFetcher = httpClient
+	s.depositContractCaller = depositContractCaller
+	s.rpcClient = rpcClient
+	s.httpRPCClient = rpcClient
+	s.processingLock.Unlock()
+	if previous != nil {
+		previous.Close()
+	}
+
+	httpEndpointFailoverCount.Inc()
+	log.WithError(reason).WithFields(logrus.Fields{
+		"failedEndpoint":      redactEndpoint(s.httpEndpoints[failed]),
+		"failedEndpointIndex": failed,
+		"endpoint":            redactEndpoint(s.httpEndpoints[s.currHTTPEndpoint]),
+		"endpointIndex":       s.currHTTPEndpoint,
+	}).Warn("Falling back to next eth1 http endpoint")
+}
@@ -0,0 +1,116 @@
+package powchain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+var backfilledHeaders = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "powchain_backfilled_headers_total",
+	Help: "The number of eth1 block headers requested in bulk to fill the block cache.",
+})
+
+// backfillHeaders requests the headers missing from the block cache for the blocks
+// preceding the given head block number, which covers the range of blocks used for eth1
+// data voting. Contiguous missing headers are requested in batches of up to
+// eth1HeaderReqLimit. When any header was requested, the cached headers are persisted so
+// that a restarted node does not have to request them again before it can vote.
+func (s *Service) backfillHeaders(ctx context.Context, head uint64) error {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.web3service.backfillHeaders")
+	defer span.End()
+
+	// Headers can only be requested in bulk once connected to an eth1 node.
+	if s.rpcClient == nil {
+		return nil
+	}
+	start := uint64(0)
+	if head >= uint64(maxCacheSize) {
+		start = head - uint64(maxCacheSize) + 1
+	}
+	requested := uint64(0)
+	for bn := start; bn <= head; {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		exists, err := s.blockCache.HeightExists(new(big.Int).SetUint64(bn))
+		if err != nil {
+			return err
+		}
+		if exists {
+			bn++
+			continue
+		}
+		end := bn
+		for end < head && end-bn+1 < eth1HeaderReqLimit {
+			exists, err := s.blockCache.HeightExists(new(big.Int).SetUint64(end + 1))
+			if err != nil {
+				return err
+			}
+			if exists {
+				break
+			}
+			end++
+		}
+		if _, err := s.batchRequestHeaders(bn, end); err != nil {
+			return errors.Wrapf(err, "could not request headers of blocks %d to %d", bn, end)
+		}
+		requested += end - bn + 1
+		bn = end + 1
+	}
+	span.AddAttributes(trace.Int64Attribute("requested", int64(requested)))
+	if requested == 0 {
+		return nil
+	}
+	backfilledHeaders.Add(float64(requested))
+	log.WithFields(logrus.Fields{
+		"requested": requested,
+		"head":      head,
+	}).Debug("Backfilled eth1 block headers")
+	return s.beaconDB.SavePowchainData(ctx, s.powchainData(ctx))
+}
+
+// followedBlockHeaders returns the cached headers of the blocks which are at least
+// Eth1FollowDistance behind the latest eth1 block. Headers of more recent blocks are not
+// persisted, as those blocks may still be reorganized.
+func (s *Service) followedBlockHeaders() []*protodb.ETH1BlockHeader {
+	followDistance := params.BeaconConfig().Eth1FollowDistance
+	if s.latestEth1Data == nil || s.latestEth1Data.BlockHeight < followDistance {
+		return nil
+	}
+	lastFollowed := new(big.Int).SetUint64(s.latestEth1Data.BlockHeight - followDistance)
+	var headers []*protodb.ETH1BlockHeader
+	for _, bInfo := range s.blockCache.BlockInfos() {
+		if bInfo.Number.Cmp(lastFollowed) > 0 {
+			break
+		}
+		headers = append(headers, &protodb.ETH1BlockHeader{
+			BlockNumber: bInfo.Number.Uint64(),
+			BlockHash:   bInfo.Hash.Bytes(),
+			BlockTime:   bInfo.Time,
+		})
+	}
+	return headers
+}
+
+// loadBlockHeaders adds the persisted headers to the block cache.
+func (s *Service) loadBlockHeaders(headers []*protodb.ETH1BlockHeader) error {
+	for _, h := range headers {
+		if err := s.blockCache.AddBlockInfo(&blockInfo{
+			Number: new(big.Int).SetUint64(h.BlockNumber),
+			Hash:   common.BytesToHash(h.BlockHash),
+			Time:   h.BlockTime,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package powchain
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestBackfillHeaders_PersistsFollowedHeaders(t *testing.T) {
+	testAcc, err := contracts.Setup()
+	if err != nil {
+		t.Fatalf("Unable to set up simulated backend %v", err)
+	}
+	beaconDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+	web3Service, err := NewService(ctx, &Web3ServiceConfig{
+		ETH1Endpoint:    endpoint,
+		DepositContract: testAcc.ContractAddr,
+		BeaconDB:        beaconDB,
+		DepositCache:    depositcache.NewDepositCache(),
+	})
+	if err != nil {
+		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+	web3Service = setDefaultMocks(web3Service)
+	web3Service.rpcClient = &mockPOW.RPCClient{Backend: testAcc.Backend}
+
+	head := uint64(maxCacheSize) + 100
+	web3Service.latestEth1Data.BlockHeight = head
+	if err := web3Service.backfillHeaders(ctx, head); err != nil {
+		t.Fatal(err)
+	}
+	for _, bn := range []uint64{head - uint64(maxCacheSize) + 1, head} {
+		exists, err := web3Service.blockCache.HeightExists(new(big.Int).SetUint64(bn))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Errorf("Expected header of block %d to be backfilled", bn)
+		}
+	}
+
+	data, err := beaconDB.PowchainData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastFollowed := head - params.BeaconConfig().Eth1FollowDistance
+	headers := data.BlockHeaders
+	if len(headers) == 0 || headers[len(headers)-1].BlockNumber != lastFollowed {
+		t.Fatalf("Expected persisted headers to end at block %d", lastFollowed)
+	}
+
+	restarted, err := NewService(ctx, &Web3ServiceConfig{
+		ETH1Endpoint:    endpoint,
+		DepositContract: testAcc.ContractAddr,
+		BeaconDB:        beaconDB,
+	})
+	if err != nil {
+		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+	_, want, err := web3Service.blockCache.BlockInfoByHeight(new(big.Int).SetUint64(lastFollowed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exists, info, err := restarted.blockCache.BlockInfoByHeight(new(big.Int).SetUint64(lastFollowed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Fatal("Expected persisted header to be loaded into the block cache")
+	}
+	if info.Hash != want.Hash || info.Time != want.Time {
+		t.Errorf("Expected loaded block info %v, received %v", want, info)
+	}
+}
+
+func TestBackfillHeaders_NothingMissing(t *testing.T) {
+	beaconDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	web3Service, err := NewService(context.Background(), &Web3ServiceConfig{
+		ETH1Endpoint: endpoint,
+		BeaconDB:     beaconDB,
+	})
+	if err != nil {
+		t.Fatalf("unable to setup web3 ETH1.0 chain service: %v", err)
+	}
+	// A nil backend leaves the requested headers empty, so any request would add
+	// block infos without a number to the cache.
+	web3Service.rpcClient = &mockPOW.RPCClient{}
+	for i := int64(0); i <= 10; i++ {
+		if err := web3Service.blockCache.AddBlockInfo(&blockInfo{Number: big.NewInt(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := web3Service.backfillHeaders(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(web3Service.blockCache.BlockInfos()); n != 11 {
+		t.Errorf("Expected 11 cached block infos, received %d", n)
+	}
+}
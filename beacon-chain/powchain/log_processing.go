@@ -20,7 +20,6 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
-	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
@@ -84,14 +83,7 @@ func (s *Service) ProcessLog(ctx context.Context, depositLog gethTypes.Log) erro
 			return errors.Wrap(err, "Could not process deposit log")
 		}
 		if s.lastReceivedMerkleIndex%eth1DataSavingInterval == 0 {
-			eth1Data := &protodb.ETH1ChainData{
-				CurrentEth1Data:   s.latestEth1Data,
-				ChainstartData:    s.chainStartData,
-				BeaconState:       s.preGenesisState.InnerStateUnsafe(), // I promise not to mutate it!
-				Trie:              s.depositTrie.ToProto(),
-				DepositContainers: s.depositCache.AllDepositContainers(ctx),
-			}
-			return s.beaconDB.SavePowchainData(ctx, eth1Data)
+			return s.beaconDB.SavePowchainData(ctx, s.powchainData(ctx))
 		}
 		return nil
 	}
@@ -338,6 +330,7 @@ func (s *Service) processPastLogs(ctx context.Context) error {
 	if currentState != nil && currentState.Eth1DepositIndex() > 0 {
 		s.depositCache.PrunePendingDeposits(ctx, int(currentState.Eth1DepositIndex()))
 	}
+	s.updateDepositProcessingMetrics(ctx)
 
 	return nil
 }
@@ -356,6 +349,7 @@ func (s *Service) requestBatchedLogs(ctx context.Context) error {
 		}
 		s.latestEth1Data.LastRequestedBlock = i
 	}
+	s.updateDepositProcessingMetrics(ctx)
 
 	return nil
 }
@@ -86,6 +86,11 @@ type POWBlockFetcher interface {
 	BlockExists(ctx context.Context, hash common.Hash) (bool, *big.Int, error)
 }
 
+// DepositProcessingFetcher retrieves the progress of processing the deposit contract logs.
+type DepositProcessingFetcher interface {
+	DepositProcessingStatus(ctx context.Context) *DepositProcessingStatus
+}
+
 // Chain defines a standard interface for the powchain service in Prysm.
 type Chain interface {
 	ChainStartFetcher
@@ -132,14 +137,19 @@ type Service struct {
 	client                  Client
 	headerChan              chan *gethTypes.Header
 	eth1Endpoint            string
-	httpEndpoint            string
+	httpEndpoints           []string
+	httpEndpointLock        sync.Mutex // protects currHTTPEndpoint and httpChainID.
+	currHTTPEndpoint        int
+	httpChainID             uint64 // chain id of the http endpoints when eth1ChainID is not configured.
+	eth1ChainID             uint64
 	stateNotifier           statefeed.Notifier
 	reader                  Reader
 	logger                  bind.ContractFilterer
 	httpLogger              bind.ContractFilterer
 	blockFetcher            RPCBlockFetcher
 	rpcClient               RPCClient
-	blockCache              *blockCache // cache to store block hash/block height.
+	httpRPCClient           *gethRPC.Client // closed when falling back to another http endpoint.
+	blockCache              *blockCache     // cache to store block hash/block height.
 	latestEth1Data          *protodb.LatestETH1Data
 	depositContractCaller   *contracts.DepositContractCaller
 	depositRoot             []byte
@@ -150,16 +160,23 @@ type Service struct {
 	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
 	runError                error
 	preGenesisState         *stateTrie.BeaconState
+	depositSnapshot         *protodb.DepositSnapshot
+	snapshotExportPath      string
 }
 
 // Web3ServiceConfig defines a config struct for web3 service to use through its life cycle.
 type Web3ServiceConfig struct {
 	ETH1Endpoint    string
-	HTTPEndPoint    string
+	HTTPEndpoints   []string
+	ChainID         uint64
 	DepositContract common.Address
 	BeaconDB        db.HeadAccessDatabase
 	DepositCache    *depositcache.DepositCache
 	StateNotifier   statefeed.Notifier
+	// DepositSnapshotPath is a deposit snapshot file to initialize the deposit trie from.
+	DepositSnapshotPath string
+	// DepositSnapshotExportPath is the file the finalized deposit snapshot is written to.
+	DepositSnapshotExportPath string
 }
 
 // NewService sets up a new instance with an ethclient when
@@ -183,11 +200,12 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 	}
 
 	s := &Service{
-		ctx:          ctx,
-		cancel:       cancel,
-		headerChan:   make(chan *gethTypes.Header),
-		eth1Endpoint: config.ETH1Endpoint,
-		httpEndpoint: config.HTTPEndPoint,
+		ctx:           ctx,
+		cancel:        cancel,
+		headerChan:    make(chan *gethTypes.Header),
+		eth1Endpoint:  config.ETH1Endpoint,
+		httpEndpoints: config.HTTPEndpoints,
+		eth1ChainID:   config.ChainID,
 		latestEth1Data: &protodb.LatestETH1Data{
 			BlockHeight:        0,
 			BlockTime:          0,
@@ -206,13 +224,19 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 		depositCache:            config.DepositCache,
 		lastReceivedMerkleIndex: -1,
 		preGenesisState:         genState,
+		snapshotExportPath:      config.DepositSnapshotExportPath,
 	}
 
 	eth1Data, err := config.BeaconDB.PowchainData(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to retrieve eth1 data")
 	}
-	if eth1Data != nil {
+	if eth1Data != nil && eth1Data.Trie == nil && eth1Data.DepositSnapshot != nil {
+		// The database was initialized from a node snapshot, which only carries the deposit snapshot.
+		if err := s.restoreDepositSnapshot(ctx, eth1Data.DepositSnapshot); err != nil {
+			return nil, errors.Wrap(err, "could not restore deposit snapshot")
+		}
+	} else if eth1Data != nil {
 		s.depositTrie = trieutil.CreateTrieFromProto(eth1Data.Trie)
 		s.chainStartData = eth1Data.ChainstartData
 		if !reflect.ValueOf(eth1Data.BeaconState).IsZero() {
@@ -226,6 +250,26 @@ func NewService(ctx context.Context, config *Web3ServiceConfig) (*Service, error
 		if err := s.initDepositCaches(ctx, eth1Data.DepositContainers); err != nil {
 			return nil, errors.Wrap(err, "could not initialize caches")
 		}
+		s.depositSnapshot = eth1Data.DepositSnapshot
+		if err := s.loadBlockHeaders(eth1Data.BlockHeaders); err != nil {
+			return nil, errors.Wrap(err, "could not load eth1 block headers")
+		}
+	} else if config.DepositSnapshotPath != "" {
+		if err := s.loadDepositSnapshot(ctx, config.DepositSnapshotPath); err != nil {
+			return nil, errors.Wrap(err, "could not import deposit snapshot")
+		}
+	} else {
+		// The database may have been initialized from a genesis state provided at startup,
+		// in which case the chain has already started and genesis is not triggered by deposits.
+		genesisState, err := config.BeaconDB.GenesisState(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get genesis state")
+		}
+		if genesisState != nil {
+			s.chainStartData.Chainstarted = true
+			s.chainStartData.GenesisTime = genesisState.GenesisTime()
+			s.chainStartData.Eth1Data = genesisState.Eth1Data()
+		}
 	}
 	return s, nil
 }
@@ -350,11 +394,10 @@ func (s *Service) connectToPowChain() error {
 }
 
 func (s *Service) dialETH1Nodes() (*ethclient.Client, *ethclient.Client, *gethRPC.Client, error) {
-	httpRPCClient, err := gethRPC.Dial(s.httpEndpoint)
+	httpClient, httpRPCClient, err := s.dialHTTPEndpoint(s.ctx)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	httpClient := ethclient.NewClient(httpRPCClient)
 
 	rpcClient, err := gethRPC.Dial(s.eth1Endpoint)
 	if err != nil {
@@ -376,6 +419,7 @@ func (s *Service) initializeConnection(powClient *ethclient.Client,
 	s.blockFetcher = httpClient
 	s.depositContractCaller = contractCaller
 	s.rpcClient = rpcClient
+	s.httpRPCClient = rpcClient
 }
 
 func (s *Service) waitForConnection() {
@@ -387,6 +431,10 @@ func (s *Service) waitForConnection() {
 		}).Info("Connected to eth1 proof-of-work chain")
 		return
 	}
+	if errors.Cause(err) == errWrongEth1Chain {
+		// Following the wrong chain would silently process another chain's deposits.
+		log.WithError(err).Fatal("Eth1 endpoints do not match the configured eth1 chain")
+	}
 	log.WithError(err).Error("Could not connect to powchain endpoint")
 	ticker := time.NewTicker(backOffPeriod)
 	for {
@@ -449,6 +497,19 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*protodb.Deposit
 	return nil
 }
 
+// powchainData gathers the eth1 data of the service which is persisted in the database.
+func (s *Service) powchainData(ctx context.Context) *protodb.ETH1ChainData {
+	return &protodb.ETH1ChainData{
+		CurrentEth1Data:   s.latestEth1Data,
+		ChainstartData:    s.chainStartData,
+		BeaconState:       s.preGenesisState.InnerStateUnsafe(), // I promise not to mutate it!
+		Trie:              s.depositTrie.ToProto(),
+		DepositContainers: s.depositCache.AllDepositContainers(ctx),
+		DepositSnapshot:   s.depositSnapshot,
+		BlockHeaders:      s.followedBlockHeaders(),
+	}
+}
+
 // processSubscribedHeaders adds a newly observed eth1 block to the block cache and
 // updates the latest blockHeight, blockHash, and blockTime properties of the service.
 func (s *Service) processSubscribedHeaders(header *gethTypes.Header) {
@@ -483,7 +544,7 @@ func (s *Service) batchRequestHeaders(startBlock uint64, endBlock uint64) ([]*ge
 		err := error(nil)
 		elems = append(elems, gethRPC.BatchElem{
 			Method: "eth_getBlockByNumber",
-			Args:   []interface{}{hexutil.EncodeBig(big.NewInt(int64(i))), true},
+			Args:   []interface{}{hexutil.EncodeBig(big.NewInt(int64(i))), false},
 			Result: header,
 			Error:  err,
 		})
@@ -547,6 +608,7 @@ func (s *Service) handleDelayTicker() {
 	if err := s.requestBatchedLogs(context.Background()); err != nil {
 		s.runError = err
 		log.Error(err)
+		s.fallbackToNextHTTPEndpoint(err)
 		return
 	}
 	// Reset the Status.
@@ -587,9 +649,17 @@ func (s *Service) run(done <-chan struct{}) {
 		return
 	}
 
+	if err := s.backfillHeaders(s.ctx, s.latestEth1Data.BlockHeight); err != nil {
+		log.WithError(err).Warn("Could not backfill eth1 block headers")
+	}
+
 	ticker := time.NewTicker(1 * time.Second)
+	healthTicker := time.NewTicker(endpointHealthCheckPeriod)
+	snapshotTicker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot*params.BeaconConfig().SlotsPerEpoch) * time.Second)
 	defer headSub.Unsubscribe()
 	defer ticker.Stop()
+	defer healthTicker.Stop()
+	defer snapshotTicker.Stop()
 
 	for {
 		select {
@@ -616,6 +686,12 @@ func (s *Service) run(done <-chan struct{}) {
 			}
 		case <-ticker.C:
 			s.handleDelayTicker()
+		case <-healthTicker.C:
+			s.checkHTTPEndpointHealth(s.ctx)
+		case <-snapshotTicker.C:
+			if err := s.updateDepositSnapshot(s.ctx); err != nil {
+				log.WithError(err).Error("Could not update deposit snapshot")
+			}
 		}
 	}
 }
@@ -29,6 +29,7 @@ var _ = ChainStartFetcher(&Service{})
 var _ = ChainInfoFetcher(&Service{})
 var _ = POWBlockFetcher(&Service{})
 var _ = Chain(&Service{})
+var _ = DepositProcessingFetcher(&Service{})
 
 type badReader struct{}
 
@@ -191,6 +192,30 @@ func TestNewWeb3Service_OK(t *testing.T) {
 	}
 }
 
+func TestNewWeb3Service_GenesisStateInDB(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, beaconDB)
+	st, _ := testutil.DeterministicGenesisState(t, 8)
+	if err := beaconDB.SaveGenesisData(ctx, st); err != nil {
+		t.Fatal(err)
+	}
+	web3Service, err := NewService(ctx, &Web3ServiceConfig{
+		ETH1Endpoint:    "ws://127.0.0.1",
+		DepositContract: common.Address{},
+		BeaconDB:        beaconDB,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !web3Service.chainStartData.Chainstarted {
+		t.Error("Expected chain to be started from the genesis state in the database")
+	}
+	if web3Service.chainStartData.GenesisTime != st.GenesisTime() {
+		t.Errorf("Wanted genesis time %d, received %d", st.GenesisTime(), web3Service.chainStartData.GenesisTime)
+	}
+}
+
 func TestStart_OK(t *testing.T) {
 	hook := logTest.NewGlobal()
 	beaconDB := dbutil.SetupDB(t)
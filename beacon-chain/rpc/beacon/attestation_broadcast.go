@@ -0,0 +1,88 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BroadcastAttestations publishes signed attestations and aggregates to the network without
+// adding them to the beacon node's pools. Every attestation is checked to belong to an existing
+// committee at its slot and published on that committee's subnet.
+func (bs *Server) BroadcastAttestations(
+	ctx context.Context, req *pbrpc.BroadcastAttestationsRequest,
+) (*pbrpc.BroadcastAttestationsResponse, error) {
+	if len(req.Attestations) == 0 && len(req.Aggregates) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "No attestations or aggregates to broadcast")
+	}
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve head state: %v", err)
+	}
+
+	// Validate the whole request first, so it is either broadcast in full or not at all.
+	for i, att := range req.Attestations {
+		if err := validateAttestationForBroadcast(headState, att); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid attestation %d: %v", i, err)
+		}
+	}
+	for i, agg := range req.Aggregates {
+		if agg == nil || agg.Message == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid aggregate %d: empty aggregate", i)
+		}
+		if _, err := bls.SignatureFromBytes(agg.Signature); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid aggregate %d: incorrect signature", i)
+		}
+		if err := validateAttestationForBroadcast(headState, agg.Message.Aggregate); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid aggregate %d: %v", i, err)
+		}
+	}
+
+	subnets := make([]uint64, len(req.Attestations))
+	for i, att := range req.Attestations {
+		if err := bs.Broadcaster.Broadcast(ctx, att); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not broadcast attestation %d: %v", i, err)
+		}
+		subnets[i] = p2p.AttestationSubnet(att.Data.CommitteeIndex)
+	}
+	for i, agg := range req.Aggregates {
+		if err := bs.Broadcaster.Broadcast(ctx, agg); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not broadcast aggregate %d: %v", i, err)
+		}
+	}
+	return &pbrpc.BroadcastAttestationsResponse{
+		AttestationSubnets: subnets,
+	}, nil
+}
+
+// validateAttestationForBroadcast checks that the attestation is signed and that its committee
+// exists at its slot, so it is published on a subnet peers are listening on.
+func validateAttestationForBroadcast(headState *stateTrie.BeaconState, att *ethpb.Attestation) error {
+	if att == nil || att.Data == nil || att.Data.Target == nil || att.Data.Source == nil {
+		return errors.New("empty attestation data")
+	}
+	if _, err := bls.SignatureFromBytes(att.Signature); err != nil {
+		return errors.New("incorrect signature")
+	}
+	activeCount, err := helpers.ActiveValidatorCount(headState, helpers.SlotToEpoch(att.Data.Slot))
+	if err != nil {
+		return errors.Wrap(err, "could not count active validators")
+	}
+	if committees := helpers.SlotCommitteeCount(activeCount); att.Data.CommitteeIndex >= committees {
+		return errors.Errorf(
+			"committee index %d is out of range of the %d committees at slot %d",
+			att.Data.CommitteeIndex,
+			committees,
+			att.Data.Slot,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package beacon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	mockp2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_BroadcastAttestations(t *testing.T) {
+	ctx := context.Background()
+	st, privs := testutil.DeterministicGenesisState(t, 64)
+	mb := &mockp2p.MockBroadcaster{}
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{
+			State: st,
+		},
+		Broadcaster: mb,
+	}
+
+	att := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  0,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		AggregationBits: []byte{0x03},
+		Signature:       privs[0].Sign([]byte("attestation")).Marshal(),
+	}
+	res, err := bs.BroadcastAttestations(ctx, &pbrpc.BroadcastAttestationsRequest{
+		Attestations: []*ethpb.Attestation{att},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AttestationSubnets) != 1 || res.AttestationSubnets[0] != 0 {
+		t.Errorf("Wanted subnets [0], received %v", res.AttestationSubnets)
+	}
+	if !mb.BroadcastCalled {
+		t.Error("Expected attestation to be broadcast")
+	}
+}
+
+func TestServer_BroadcastAttestations_CommitteeOutOfRange(t *testing.T) {
+	ctx := context.Background()
+	st, privs := testutil.DeterministicGenesisState(t, 64)
+	mb := &mockp2p.MockBroadcaster{}
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{
+			State: st,
+		},
+		Broadcaster: mb,
+	}
+
+	// With 64 validators there is a single committee per slot.
+	att := &ethpb.Attestation{
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  1,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		AggregationBits: []byte{0x03},
+		Signature:       privs[0].Sign([]byte("attestation")).Marshal(),
+	}
+	_, err := bs.BroadcastAttestations(ctx, &pbrpc.BroadcastAttestationsRequest{
+		Attestations: []*ethpb.Attestation{att},
+	})
+	if err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Fatalf("Expected out of range error, received %v", err)
+	}
+	if mb.BroadcastCalled {
+		t.Error("Expected invalid attestation not to be broadcast")
+	}
+}
@@ -0,0 +1,146 @@
+package beacon
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// committeeEpochsToCache is the number of epochs of committees kept by an indexed attestation
+// event stream. Attestations are only valid for about an epoch, so this covers the current and
+// previous epochs along with attestations from blocks synced with a small delay.
+const committeeEpochsToCache = 4
+
+// StreamIndexedAttestationEvents sends every attestation processed by the beacon node to the
+// client in indexed form as soon as it is received. Unlike StreamIndexedAttestations, which
+// aggregates the unaggregated attestations of each slot, it also covers aggregated attestations
+// and the attestations included in verified blocks.
+func (bs *Server) StreamIndexedAttestationEvents(
+	_ *pbrpc.IndexedAttestationEventsRequest, stream pbrpc.Attestations_StreamIndexedAttestationEventsServer,
+) error {
+	attestationsChannel := make(chan *feed.Event, 1)
+	attSub := bs.AttestationNotifier.OperationFeed().Subscribe(attestationsChannel)
+	defer attSub.Unsubscribe()
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := bs.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+
+	committees := make(map[uint64]map[uint64]*ethpb.BeaconCommittees_CommitteesList)
+	send := func(att *ethpb.Attestation, source pbrpc.IndexedAttestationEvent_Source, blockRoot []byte) error {
+		if att == nil || att.Data == nil || att.Data.Target == nil {
+			// One nil attestation shouldn't stop the stream.
+			return nil
+		}
+		idxAtt, err := bs.indexAttestation(stream.Context(), committees, att)
+		if err != nil {
+			logrus.WithError(err).WithField("slot", att.Data.Slot).Debug("Could not convert attestation to indexed form")
+			return nil
+		}
+		if err := stream.Send(&pbrpc.IndexedAttestationEvent{
+			Source:             source,
+			IndexedAttestation: idxAtt,
+			BlockRoot:          blockRoot,
+		}); err != nil {
+			return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case event := <-attestationsChannel:
+			switch event.Type {
+			case operation.UnaggregatedAttReceived:
+				data, ok := event.Data.(*operation.UnAggregatedAttReceivedData)
+				if !ok {
+					// Got bad data over the stream.
+					continue
+				}
+				if err := send(data.Attestation, pbrpc.IndexedAttestationEvent_UNAGGREGATED, nil); err != nil {
+					return err
+				}
+			case operation.AggregatedAttReceived:
+				data, ok := event.Data.(*operation.AggregatedAttReceivedData)
+				if !ok || data.Attestation == nil {
+					continue
+				}
+				if err := send(data.Attestation.Aggregate, pbrpc.IndexedAttestationEvent_AGGREGATED, nil); err != nil {
+					return err
+				}
+			}
+		case event := <-stateChannel:
+			if event.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := event.Data.(*statefeed.BlockProcessedData)
+			if !ok || !data.Verified {
+				// Only stream attestations of blocks which have been fully verified.
+				continue
+			}
+			blk, err := bs.BeaconDB.Block(bs.Ctx, data.BlockRoot)
+			if err != nil {
+				return status.Errorf(codes.Internal, "Could not retrieve processed block: %v", err)
+			}
+			if blk == nil || blk.Block == nil || blk.Block.Body == nil {
+				// One missing block shouldn't stop the stream.
+				continue
+			}
+			for _, att := range blk.Block.Body.Attestations {
+				if err := send(att, pbrpc.IndexedAttestationEvent_BLOCK, data.BlockRoot[:]); err != nil {
+					return err
+				}
+			}
+		case <-attSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-stateSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-bs.Ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// indexAttestation converts the attestation to indexed form using the committees of its target
+// epoch, retrieving and caching them in the given map if needed.
+func (bs *Server) indexAttestation(
+	ctx context.Context,
+	committees map[uint64]map[uint64]*ethpb.BeaconCommittees_CommitteesList,
+	att *ethpb.Attestation,
+) (*ethpb.IndexedAttestation, error) {
+	epoch := att.Data.Target.Epoch
+	committeesBySlot, ok := committees[epoch]
+	if !ok {
+		var err error
+		committeesBySlot, _, err = bs.retrieveCommitteesForEpoch(ctx, epoch)
+		if err != nil {
+			return nil, err
+		}
+		committees[epoch] = committeesBySlot
+		if len(committees) > committeeEpochsToCache {
+			oldest := epoch
+			for e := range committees {
+				if e < oldest {
+					oldest = e
+				}
+			}
+			delete(committees, oldest)
+		}
+	}
+	committeesForSlot, ok := committeesBySlot[att.Data.Slot]
+	if !ok || committeesForSlot == nil || att.Data.CommitteeIndex >= uint64(len(committeesForSlot.Committees)) {
+		return nil, status.Errorf(codes.InvalidArgument, "No committee %d at slot %d in epoch %d",
+			att.Data.CommitteeIndex, att.Data.Slot, epoch)
+	}
+	committee := committeesForSlot.Committees[att.Data.CommitteeIndex]
+	return attestationutil.ConvertToIndexed(ctx, att, committee.ValidatorIndices), nil
+}
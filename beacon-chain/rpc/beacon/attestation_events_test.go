@@ -0,0 +1,138 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"google.golang.org/grpc"
+)
+
+type indexedAttestationEventsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pbrpc.IndexedAttestationEvent
+}
+
+func (s *indexedAttestationEventsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *indexedAttestationEventsStream) Send(event *pbrpc.IndexedAttestationEvent) error {
+	s.sent <- event
+	return nil
+}
+
+func TestServer_StreamIndexedAttestationEvents(t *testing.T) {
+	resetCfg := params.OverrideBeaconConfigWithReset(params.MainnetConfig())
+	defer resetCfg()
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	headState, _ := testutil.DeterministicGenesisState(t, 64)
+	genesis := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	if err := db.SaveBlock(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	gRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveGenesisBlockRoot(ctx, gRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, headState, gRoot); err != nil {
+		t.Fatal(err)
+	}
+	committee, err := helpers.BeaconCommitteeFromState(headState, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(0, true)
+	att := &ethpb.Attestation{
+		AggregationBits: bits,
+		Data: &ethpb.AttestationData{
+			Slot:            1,
+			BeaconBlockRoot: gRoot[:],
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: gRoot[:]},
+		},
+		Signature: make([]byte, 96),
+	}
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{
+		Slot:       2,
+		ParentRoot: gRoot[:],
+		Body:       &ethpb.BeaconBlockBody{Attestations: []*ethpb.Attestation{att}},
+	}}
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	blkRoot, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainService := &mock.ChainService{}
+	server := &Server{
+		Ctx:                 ctx,
+		BeaconDB:            db,
+		AttestationNotifier: chainService.OperationNotifier(),
+		StateNotifier:       chainService.StateNotifier(),
+		StateGen:            stategen.New(db, cache.NewStateSummaryCache()),
+	}
+	stream := &indexedAttestationEventsStream{ctx: ctx, sent: make(chan *pbrpc.IndexedAttestationEvent, 2)}
+	go func(tt *testing.T) {
+		if err := server.StreamIndexedAttestationEvents(&pbrpc.IndexedAttestationEventsRequest{}, stream); err != nil &&
+			ctx.Err() == nil {
+			tt.Errorf("Could not call RPC method: %v", err)
+		}
+	}(t)
+
+	// Send in a loop to ensure it is delivered (busy wait for the service to subscribe to the feeds).
+	for sent := 0; sent == 0; {
+		sent = server.AttestationNotifier.OperationFeed().Send(&feed.Event{
+			Type: operation.UnaggregatedAttReceived,
+			Data: &operation.UnAggregatedAttReceivedData{Attestation: att},
+		})
+	}
+	event := <-stream.sent
+	if event.Source != pbrpc.IndexedAttestationEvent_UNAGGREGATED {
+		t.Errorf("Wanted source %v, received %v", pbrpc.IndexedAttestationEvent_UNAGGREGATED, event.Source)
+	}
+	if len(event.IndexedAttestation.AttestingIndices) != 1 || event.IndexedAttestation.AttestingIndices[0] != committee[0] {
+		t.Errorf("Wanted attesting indices [%d], received %v", committee[0], event.IndexedAttestation.AttestingIndices)
+	}
+
+	for sent := 0; sent == 0; {
+		sent = server.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.BlockProcessed,
+			Data: &statefeed.BlockProcessedData{BlockRoot: blkRoot, Verified: true},
+		})
+	}
+	event = <-stream.sent
+	if event.Source != pbrpc.IndexedAttestationEvent_BLOCK {
+		t.Errorf("Wanted source %v, received %v", pbrpc.IndexedAttestationEvent_BLOCK, event.Source)
+	}
+	if string(event.BlockRoot) != string(blkRoot[:]) {
+		t.Errorf("Wanted block root %#x, received %#x", blkRoot, event.BlockRoot)
+	}
+	if len(event.IndexedAttestation.AttestingIndices) != 1 {
+		t.Errorf("Wanted 1 attesting index, received %v", event.IndexedAttestation.AttestingIndices)
+	}
+}
@@ -0,0 +1,85 @@
+package beacon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BackupDatabase writes a consistent backup of the beacon node database to the node's
+// configured backup directory, or to the requested directory within it, while the node
+// keeps running.
+func (bs *Server) BackupDatabase(
+	ctx context.Context,
+	req *pbrpc.BackupDatabaseRequest,
+) (*pbrpc.BackupDatabaseResponse, error) {
+	if bs.DatabaseBackuper == nil {
+		return nil, status.Error(codes.Unavailable, "database backups are not supported by this node")
+	}
+	outputDir, err := resolveBackupDir(bs.BackupOutputDir, req.OutputDirectory)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid output directory: %v", err)
+	}
+	backupPath, err := bs.DatabaseBackuper.Backup(ctx, outputDir)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not backup database: %v", err)
+	}
+	return &pbrpc.BackupDatabaseResponse{BackupPath: backupPath}, nil
+}
+
+// resolveBackupDir returns the requested backup directory, resolved relative to the
+// configured backup directory. Requests may only write within the configured directory,
+// so the RPC cannot be used to write files anywhere the node has access to.
+func resolveBackupDir(backupDir string, requested string) (string, error) {
+	if requested == "" {
+		return backupDir, nil
+	}
+	if backupDir == "" {
+		return "", errors.New("no backup directory is configured")
+	}
+	if filepath.IsAbs(requested) {
+		return "", errors.New("must be relative to the backup directory")
+	}
+	dir := filepath.Join(backupDir, requested)
+	if !withinDir(backupDir, dir) {
+		return "", errors.New("must be within the backup directory")
+	}
+	// Symbolic links within the backup directory must not lead out of it either, so the
+	// deepest existing part of the directory is checked once its links are resolved.
+	resolvedBackupDir, err := filepath.EvalSymlinks(backupDir)
+	if os.IsNotExist(err) {
+		return dir, nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "could not resolve backup directory")
+	}
+	for existing := dir; withinDir(backupDir, existing); existing = filepath.Dir(existing) {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "could not resolve output directory")
+		}
+		if !withinDir(resolvedBackupDir, resolved) {
+			return "", errors.New("must be within the backup directory")
+		}
+		break
+	}
+	return dir, nil
+}
+
+// withinDir returns whether the path is the directory itself or within it.
+func withinDir(dir string, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,122 @@
+package beacon
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_BackupDatabase(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	head := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 10}}
+	if err := db.SaveBlock(ctx, head); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ssz.HashTreeRoot(head.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), root); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveHeadBlockRoot(ctx, root); err != nil {
+		t.Fatal(err)
+	}
+
+	outputDir := path.Join(testutil.TempDir(), "rpc_backup")
+	defer func() {
+		if err := os.RemoveAll(outputDir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	bs := &Server{
+		DatabaseBackuper: db,
+		BackupOutputDir:  outputDir,
+	}
+	res, err := bs.BackupDatabase(ctx, &pbrpc.BackupDatabaseRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path.Dir(res.BackupPath) != outputDir {
+		t.Errorf("Expected backup in %s, received %s", outputDir, res.BackupPath)
+	}
+	if _, err := os.Stat(res.BackupPath); err != nil {
+		t.Errorf("Expected backup file to exist: %v", err)
+	}
+}
+
+func TestResolveBackupDir(t *testing.T) {
+	backupDir := path.Join(testutil.TempDir(), "resolve_backup")
+	outside := path.Join(testutil.TempDir(), "resolve_backup_outside")
+	for _, dir := range []string{backupDir, outside} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer func() {
+		for _, dir := range []string{backupDir, outside} {
+			if err := os.RemoveAll(dir); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}()
+	if err := os.Symlink(outside, path.Join(backupDir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		requested string
+		want      string
+		wantErr   bool
+	}{
+		{requested: "", want: backupDir},
+		{requested: "daily", want: path.Join(backupDir, "daily")},
+		{requested: "daily/../weekly", want: path.Join(backupDir, "weekly")},
+		{requested: "../escape", wantErr: true},
+		{requested: "daily/../../escape", wantErr: true},
+		{requested: "/etc", wantErr: true},
+		{requested: "link", wantErr: true},
+		{requested: "link/nested", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := resolveBackupDir(backupDir, tt.requested)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected %q to be rejected, received %s", tt.requested, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tt.requested, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Expected %q to resolve to %s, received %s", tt.requested, tt.want, got)
+		}
+	}
+	if _, err := resolveBackupDir("", "daily"); err == nil {
+		t.Error("Expected an output directory to be rejected without a backup directory")
+	}
+}
+
+func TestServer_BackupDatabase_RejectsOutsideDirectory(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	bs := &Server{
+		DatabaseBackuper: db,
+		BackupOutputDir:  path.Join(testutil.TempDir(), "rpc_backup_rejected"),
+	}
+	if _, err := bs.BackupDatabase(context.Background(), &pbrpc.BackupDatabaseRequest{OutputDirectory: "../elsewhere"}); err == nil {
+		t.Error("Expected a directory outside of the backup directory to be rejected")
+	}
+}
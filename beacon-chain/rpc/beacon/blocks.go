@@ -1,6 +1,7 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"strconv"
 
@@ -8,7 +9,6 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
-	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
@@ -171,32 +171,61 @@ func (bs *Server) ListBlocks(
 // This includes the head block slot and root as well as information about
 // the most recent finalized and justified slots.
 func (bs *Server) GetChainHead(ctx context.Context, _ *ptypes.Empty) (*ethpb.ChainHead, error) {
-	return bs.chainHeadRetrieval(ctx)
+	if bs.ChainHeadCache == nil {
+		return bs.chainHeadRetrieval(ctx)
+	}
+	headRoot, err := bs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head root: %v", err)
+	}
+	if res := bs.ChainHeadCache.Get(headRoot); res != nil {
+		return res, nil
+	}
+	res, err := bs.chainHeadRetrieval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bs.ChainHeadCache.Put(res)
+	return res, nil
 }
 
-// StreamBlocks to clients every single time a block is received by the beacon node.
+// StreamBlocks to clients every single time a block is processed by the beacon node,
+// its signatures have been verified and it became the head of the chain.
 func (bs *Server) StreamBlocks(_ *ptypes.Empty, stream ethpb.BeaconChain_StreamBlocksServer) error {
-	blocksChannel := make(chan *feed.Event, 1)
-	blockSub := bs.BlockNotifier.BlockFeed().Subscribe(blocksChannel)
-	defer blockSub.Unsubscribe()
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := bs.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
 	for {
 		select {
-		case event := <-blocksChannel:
-			if event.Type == blockfeed.ReceivedBlock {
-				data, ok := event.Data.(*blockfeed.ReceivedBlockData)
-				if !ok {
-					// Got bad data over the stream.
+		case event := <-stateChannel:
+			if event.Type == statefeed.BlockProcessed {
+				data, ok := event.Data.(*statefeed.BlockProcessedData)
+				if !ok || !data.Verified {
+					// Only stream blocks which have been fully verified.
+					continue
+				}
+				// Only stream blocks which became the head of the chain, so that subscribers
+				// do not act upon blocks of forks which are not canonical.
+				headRoot, err := bs.HeadFetcher.HeadRoot(bs.Ctx)
+				if err != nil {
+					return status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
+				}
+				if !bytes.Equal(headRoot, data.BlockRoot[:]) {
 					continue
 				}
-				if data.SignedBlock == nil {
-					// One nil block shouldn't stop the stream.
+				blk, err := bs.BeaconDB.Block(bs.Ctx, data.BlockRoot)
+				if err != nil {
+					return status.Errorf(codes.Internal, "Could not retrieve processed block: %v", err)
+				}
+				if blk == nil {
+					// One missing block shouldn't stop the stream.
 					continue
 				}
-				if err := stream.Send(data.SignedBlock); err != nil {
+				if err := stream.Send(blk); err != nil {
 					return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
 				}
 			}
-		case <-blockSub.Err():
+		case <-stateSub.Err():
 			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
 		case <-bs.Ctx.Done():
 			return status.Error(codes.Canceled, "Context canceled")
@@ -211,6 +240,7 @@ func (bs *Server) StreamChainHead(_ *ptypes.Empty, stream ethpb.BeaconChain_Stre
 	stateChannel := make(chan *feed.Event, 1)
 	stateSub := bs.StateNotifier.StateFeed().Subscribe(stateChannel)
 	defer stateSub.Unsubscribe()
+	var lastHeadRoot []byte
 	for {
 		select {
 		case event := <-stateChannel:
@@ -219,6 +249,11 @@ func (bs *Server) StreamChainHead(_ *ptypes.Empty, stream ethpb.BeaconChain_Stre
 				if err != nil {
 					return status.Errorf(codes.Internal, "Could not retrieve chain head: %v", err)
 				}
+				// Processed blocks which did not change the head are not sent to subscribers.
+				if bytes.Equal(res.HeadBlockRoot, lastHeadRoot) {
+					continue
+				}
+				lastHeadRoot = res.HeadBlockRoot
 				if err := stream.Send(res); err != nil {
 					return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
 				}
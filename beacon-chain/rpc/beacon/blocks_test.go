@@ -15,7 +15,6 @@ import (
 	"github.com/prysmaticlabs/go-ssz"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
-	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
@@ -633,7 +632,7 @@ func TestServer_StreamBlocks_ContextCanceled(t *testing.T) {
 	ctx, cancel := context.WithCancel(ctx)
 	server := &Server{
 		Ctx:           ctx,
-		BlockNotifier: chainService.BlockNotifier(),
+		StateNotifier: chainService.StateNotifier(),
 		BeaconDB:      db,
 	}
 
@@ -661,17 +660,42 @@ func TestServer_StreamBlocks_OnHeadUpdated(t *testing.T) {
 			Slot: 1,
 		},
 	}
-
-	chainService := &mock.ChainService{}
 	ctx := context.Background()
+	if err := db.SaveBlock(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ssz.HashTreeRoot(b.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A block of a fork which did not become the head.
+	fork := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:       1,
+			ParentRoot: []byte{'a'},
+		},
+	}
+	if err := db.SaveBlock(ctx, fork); err != nil {
+		t.Fatal(err)
+	}
+	forkRoot, err := ssz.HashTreeRoot(fork.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chainService := &mock.ChainService{Root: root[:]}
 	server := &Server{
 		Ctx:           ctx,
-		BlockNotifier: chainService.BlockNotifier(),
+		BeaconDB:      db,
+		HeadFetcher:   chainService,
+		StateNotifier: chainService.StateNotifier(),
 	}
 	exitRoutine := make(chan bool)
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockStream := mockRPC.NewMockBeaconChain_StreamBlocksServer(ctrl)
+	// Only the head block is expected to be sent.
 	mockStream.EXPECT().Send(b).Do(func(arg0 interface{}) {
 		exitRoutine <- true
 	})
@@ -685,10 +709,14 @@ func TestServer_StreamBlocks_OnHeadUpdated(t *testing.T) {
 
 	// Send in a loop to ensure it is delivered (busy wait for the service to subscribe to the state feed).
 	for sent := 0; sent == 0; {
-		sent = server.BlockNotifier.BlockFeed().Send(&feed.Event{
-			Type: blockfeed.ReceivedBlock,
-			Data: &blockfeed.ReceivedBlockData{SignedBlock: b},
+		sent = server.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.BlockProcessed,
+			Data: &statefeed.BlockProcessedData{Slot: 1, BlockRoot: forkRoot, Verified: true},
 		})
 	}
+	server.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.BlockProcessed,
+		Data: &statefeed.BlockProcessedData{Slot: 1, BlockRoot: root, Verified: true},
+	})
 	<-exitRoutine
 }
@@ -5,6 +5,7 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -80,6 +81,27 @@ func (bs *Server) retrieveCommitteesForEpoch(
 	epoch uint64,
 ) (map[uint64]*ethpb.BeaconCommittees_CommitteesList, []uint64, error) {
 	startSlot := helpers.StartSlot(epoch)
+	if flags.Get().EnableArchive {
+		archivedCommitteeInfo, err := bs.BeaconDB.ArchivedCommitteeInfo(ctx, epoch)
+		if err != nil {
+			return nil, nil, status.Errorf(codes.Internal, "Could not request archival data for epoch %d: %v", epoch, err)
+		}
+		// Committee info archived before active indices were persisted can not be used
+		// to reconstruct committees, so we fall back to regenerating the state.
+		if archivedCommitteeInfo != nil && len(archivedCommitteeInfo.ActiveIndices) > 0 {
+			attesterSeed := bytesutil.ToBytes32(archivedCommitteeInfo.AttesterSeed)
+			committeesListsBySlot, err := computeCommittees(startSlot, archivedCommitteeInfo.ActiveIndices, attesterSeed)
+			if err != nil {
+				return nil, nil, status.Errorf(
+					codes.InvalidArgument,
+					"Could not compute committees for epoch %d: %v",
+					epoch,
+					err,
+				)
+			}
+			return committeesListsBySlot, archivedCommitteeInfo.ActiveIndices, nil
+		}
+	}
 	requestedState, err := bs.StateGen.StateBySlot(ctx, startSlot)
 	if err != nil {
 		return nil, nil, status.Error(codes.Internal, "Could not get state")
@@ -115,15 +137,6 @@ func (bs *Server) retrieveCommitteesForEpochUsingOldArchival(
 	startSlot := helpers.StartSlot(epoch)
 	currentEpoch := helpers.SlotToEpoch(bs.GenesisTimeFetcher.CurrentSlot())
 	if helpers.SlotToEpoch(startSlot)+1 < currentEpoch {
-		activeIndices, err = bs.HeadFetcher.HeadValidatorsIndices(helpers.SlotToEpoch(startSlot))
-		if err != nil {
-			return nil, nil, status.Errorf(
-				codes.Internal,
-				"Could not retrieve active indices for epoch %d: %v",
-				helpers.SlotToEpoch(startSlot),
-				err,
-			)
-		}
 		archivedCommitteeInfo, err := bs.BeaconDB.ArchivedCommitteeInfo(ctx, helpers.SlotToEpoch(startSlot))
 		if err != nil {
 			return nil, nil, status.Errorf(
@@ -141,6 +154,18 @@ func (bs *Server) retrieveCommitteesForEpochUsingOldArchival(
 			)
 		}
 		attesterSeed = bytesutil.ToBytes32(archivedCommitteeInfo.AttesterSeed)
+		activeIndices = archivedCommitteeInfo.ActiveIndices
+		if len(activeIndices) == 0 {
+			activeIndices, err = bs.HeadFetcher.HeadValidatorsIndices(helpers.SlotToEpoch(startSlot))
+			if err != nil {
+				return nil, nil, status.Errorf(
+					codes.Internal,
+					"Could not retrieve active indices for epoch %d: %v",
+					helpers.SlotToEpoch(startSlot),
+					err,
+				)
+			}
+		}
 	} else if helpers.SlotToEpoch(startSlot)+1 == currentEpoch || helpers.SlotToEpoch(startSlot) == currentEpoch {
 		// Otherwise, we use current beacon state to calculate the committees.
 		requestedEpoch := helpers.SlotToEpoch(startSlot)
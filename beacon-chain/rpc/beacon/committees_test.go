@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
@@ -260,6 +261,66 @@ func TestServer_ListBeaconCommittees_FromArchive(t *testing.T) {
 	}
 }
 
+func TestServer_ListBeaconCommittees_FromArchiveWithNewStateMgmt(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{NewStateMgmt: true})
+	defer resetCfg()
+	prevFlags := flags.Get()
+	archiveFlags := *prevFlags
+	archiveFlags.EnableArchive = true
+	flags.Init(&archiveFlags)
+	defer flags.Init(prevFlags)
+
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	helpers.ClearCache()
+	ctx := context.Background()
+
+	// The archived committee info is enough to compute committees, no state is
+	// saved in the database to be regenerated.
+	activeIndices := make([]uint64, 64)
+	for i := 0; i < len(activeIndices); i++ {
+		activeIndices[i] = uint64(i)
+	}
+	seed := [32]byte{'A'}
+	if err := db.SaveArchivedCommitteeInfo(ctx, 1, &pbp2p.ArchivedCommitteeInfo{
+		AttesterSeed:  seed[:],
+		ActiveIndices: activeIndices,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &mock.ChainService{
+		Genesis: roughtime.Now().Add(time.Duration(-1*int64(2*params.BeaconConfig().SlotsPerEpoch*params.BeaconConfig().SecondsPerSlot)) * time.Second),
+	}
+	bs := &Server{
+		BeaconDB:           db,
+		HeadFetcher:        m,
+		GenesisTimeFetcher: m,
+	}
+
+	wanted, err := computeCommittees(helpers.StartSlot(1), activeIndices, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := bs.ListBeaconCommittees(ctx, &ethpb.ListCommitteesRequest{
+		QueryFilter: &ethpb.ListCommitteesRequest_Epoch{
+			Epoch: 1,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedRes := &ethpb.BeaconCommittees{
+		Epoch:                1,
+		Committees:           wanted,
+		ActiveValidatorCount: uint64(len(activeIndices)),
+	}
+	if !reflect.DeepEqual(wantedRes, res) {
+		t.Errorf("Wanted %v", wantedRes)
+		t.Errorf("Received %v", res)
+	}
+}
+
 func setupActiveValidators(t *testing.T, db db.Database, count int) *stateTrie.BeaconState {
 	balances := make([]uint64, count)
 	validators := make([]*ethpb.Validator, 0, count)
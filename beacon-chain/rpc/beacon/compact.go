@@ -0,0 +1,25 @@
+package beacon
+
+import (
+	"context"
+
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CompactDatabase compacts the beacon node database while the node keeps running and
+// reports the size of the database files before and after compaction.
+func (bs *Server) CompactDatabase(
+	ctx context.Context,
+	_ *pbrpc.CompactDatabaseRequest,
+) (*pbrpc.CompactDatabaseResponse, error) {
+	if bs.DatabaseCompacter == nil {
+		return nil, status.Error(codes.Unavailable, "database compaction is not supported by this node")
+	}
+	before, after, err := bs.DatabaseCompacter.Compact(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not compact database: %v", err)
+	}
+	return &pbrpc.CompactDatabaseResponse{SizeBefore: before, SizeAfter: after}, nil
+}
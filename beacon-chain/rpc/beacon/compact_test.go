@@ -0,0 +1,48 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_CompactDatabase(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 10}}
+	if err := db.SaveBlock(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{DatabaseCompacter: db}
+	res, err := bs.CompactDatabase(ctx, &pbrpc.CompactDatabaseRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.SizeBefore == 0 || res.SizeAfter == 0 {
+		t.Errorf("Expected database sizes to be reported, received %v", res)
+	}
+	if !db.HasBlock(ctx, root) {
+		t.Error("Expected block to be retained after compaction")
+	}
+}
+
+func TestServer_CompactDatabase_Unavailable(t *testing.T) {
+	bs := &Server{}
+	_, err := bs.CompactDatabase(context.Background(), &pbrpc.CompactDatabaseRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected unavailable error, received %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package beacon
+
+import (
+	"context"
+
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetProtoArrayForkChoice returns the proto array fork choice store, including every
+// tracked block node with its parent, weight and checkpoints, along with the current head.
+func (bs *Server) GetProtoArrayForkChoice(
+	ctx context.Context,
+	_ *pbrpc.ProtoArrayForkChoiceRequest,
+) (*pbrpc.ProtoArrayForkChoiceResponse, error) {
+	if bs.ForkChoiceFetcher == nil || bs.ForkChoiceFetcher.ForkChoiceStore() == nil {
+		return nil, status.Error(codes.Unavailable, "Fork choice store is not available")
+	}
+	headRoot, err := bs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head root: %v", err)
+	}
+
+	snapshot := bs.ForkChoiceFetcher.ForkChoiceStore().Snapshot()
+	nodes := snapshot.Nodes
+	protoNodes := make([]*pbrpc.ProtoArrayNode, len(nodes))
+	for i, n := range nodes {
+		root := n.Root()
+		var parentRoot []byte
+		if n.Parent < uint64(len(nodes)) {
+			r := nodes[n.Parent].Root()
+			parentRoot = r[:]
+		}
+		protoNodes[i] = &pbrpc.ProtoArrayNode{
+			Slot:           n.Slot,
+			Root:           root[:],
+			Parent:         n.Parent,
+			ParentRoot:     parentRoot,
+			JustifiedEpoch: n.JustifiedEpoch(),
+			FinalizedEpoch: n.FinalizedEpoch(),
+			Weight:         n.Weight,
+			BestChild:      n.BestChild(),
+			BestDescendant: n.BestDescendent,
+		}
+	}
+
+	return &pbrpc.ProtoArrayForkChoiceResponse{
+		JustifiedEpoch:  snapshot.JustifiedEpoch,
+		FinalizedEpoch:  snapshot.FinalizedEpoch,
+		FinalizedRoot:   snapshot.FinalizedRoot[:],
+		HeadRoot:        headRoot,
+		ProtoArrayNodes: protoNodes,
+	}, nil
+}
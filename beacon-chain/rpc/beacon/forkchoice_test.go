@@ -0,0 +1,62 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+)
+
+func TestServer_GetProtoArrayForkChoice(t *testing.T) {
+	ctx := context.Background()
+	genesisRoot := [32]byte{'a'}
+	childRoot := [32]byte{'b'}
+	store := protoarray.New(0, 0, genesisRoot)
+	if err := store.ProcessBlock(ctx, 0, genesisRoot, [32]byte{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ProcessBlock(ctx, 1, childRoot, genesisRoot, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{
+		HeadFetcher:       &mock.ChainService{Root: childRoot[:]},
+		ForkChoiceFetcher: &mock.ChainService{ForkChoice: store},
+	}
+	res, err := bs.GetProtoArrayForkChoice(ctx, &pbrpc.ProtoArrayForkChoiceRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.HeadRoot, childRoot[:]) {
+		t.Errorf("Wanted head root %#x, received %#x", childRoot, res.HeadRoot)
+	}
+	if !bytes.Equal(res.FinalizedRoot, genesisRoot[:]) {
+		t.Errorf("Wanted finalized root %#x, received %#x", genesisRoot, res.FinalizedRoot)
+	}
+	if len(res.ProtoArrayNodes) != 2 {
+		t.Fatalf("Wanted 2 nodes, received %d", len(res.ProtoArrayNodes))
+	}
+	child := res.ProtoArrayNodes[1]
+	if child.Slot != 1 || !bytes.Equal(child.Root, childRoot[:]) {
+		t.Errorf("Unexpected child node %v", child)
+	}
+	if child.Parent != 0 || !bytes.Equal(child.ParentRoot, genesisRoot[:]) {
+		t.Errorf("Wanted child node parent to be genesis, received %v", child)
+	}
+	if res.ProtoArrayNodes[0].BestDescendant != 1 {
+		t.Errorf("Wanted genesis best descendant 1, received %d", res.ProtoArrayNodes[0].BestDescendant)
+	}
+}
+
+func TestServer_GetProtoArrayForkChoice_NoStore(t *testing.T) {
+	bs := &Server{
+		HeadFetcher:       &mock.ChainService{},
+		ForkChoiceFetcher: &mock.ChainService{},
+	}
+	if _, err := bs.GetProtoArrayForkChoice(context.Background(), &pbrpc.ProtoArrayForkChoiceRequest{}); err == nil {
+		t.Error("Expected error without a fork choice store")
+	}
+}
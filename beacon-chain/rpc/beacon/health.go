@@ -0,0 +1,52 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetEpochParticipation returns the validator participation of the previous epoch of the
+// head state, broken down by source, target and head votes, along with the justified and
+// finalized checkpoints of the head state.
+func (bs *Server) GetEpochParticipation(
+	ctx context.Context,
+	_ *pbrpc.EpochParticipationRequest,
+) (*pbrpc.EpochParticipation, error) {
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	if headState == nil {
+		return nil, status.Error(codes.Unavailable, "Beacon chain has not started yet")
+	}
+
+	v, b, err := precompute.New(ctx, headState)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not set up pre compute instance: %v", err)
+	}
+	_, b, err = precompute.ProcessAttestations(ctx, headState, v, b)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not pre compute attestations: %v", err)
+	}
+
+	resp := &pbrpc.EpochParticipation{
+		Epoch:                 helpers.PrevEpoch(headState),
+		EligibleBalance:       b.ActivePrevEpoch,
+		TotalAttestingBalance: b.PrevEpochAttested,
+		JustifiedCheckpoint:   headState.CurrentJustifiedCheckpoint(),
+		FinalizedCheckpoint:   headState.FinalizedCheckpoint(),
+	}
+	if b.ActivePrevEpoch > 0 {
+		eligible := float32(b.ActivePrevEpoch)
+		resp.ParticipationRate = float32(b.PrevEpochTargetAttested) / eligible
+		resp.SourceVotePercentage = 100 * float32(b.PrevEpochAttested) / eligible
+		resp.TargetVotePercentage = 100 * float32(b.PrevEpochTargetAttested) / eligible
+		resp.HeadVotePercentage = 100 * float32(b.PrevEpochHeadAttested) / eligible
+	}
+	return resp, nil
+}
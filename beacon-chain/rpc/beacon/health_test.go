@@ -0,0 +1,59 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_GetEpochParticipation(t *testing.T) {
+	ctx := context.Background()
+	headState, _ := testutil.DeterministicGenesisState(t, 64)
+	if err := headState.SetSlot(2 * params.BeaconConfig().SlotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	justified := &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)}
+	if err := headState.SetCurrentJustifiedCheckpoint(justified); err != nil {
+		t.Fatal(err)
+	}
+	if err := headState.SetPreviousEpochAttestations([]*pb.PendingAttestation{}); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{State: headState},
+	}
+	resp, err := bs.GetEpochParticipation(ctx, &pbrpc.EpochParticipationRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Epoch != 1 {
+		t.Errorf("Wanted epoch 1, received %d", resp.Epoch)
+	}
+	wantEligible := 64 * params.BeaconConfig().MaxEffectiveBalance
+	if resp.EligibleBalance != wantEligible {
+		t.Errorf("Wanted eligible balance %d, received %d", wantEligible, resp.EligibleBalance)
+	}
+	if resp.TotalAttestingBalance != 0 || resp.ParticipationRate != 0 || resp.TargetVotePercentage != 0 {
+		t.Errorf("Expected no participation without attestations, received %v", resp)
+	}
+	if !proto.Equal(resp.JustifiedCheckpoint, justified) {
+		t.Errorf("Wanted justified checkpoint %v, received %v", justified, resp.JustifiedCheckpoint)
+	}
+}
+
+func TestServer_GetEpochParticipation_NoHeadState(t *testing.T) {
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{},
+	}
+	if _, err := bs.GetEpochParticipation(context.Background(), &pbrpc.EpochParticipationRequest{}); err == nil {
+		t.Error("Expected error when the beacon chain has not started")
+	}
+}
@@ -0,0 +1,120 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetIndividualVotes reports the attestation inclusion and vote correctness of the requested
+// validators in a past epoch, along with whether they were slashed or exited by its end.
+//
+// The archived participation only keeps the totals of an epoch, so the votes are computed from
+// the pending attestations of the state at the end of the following epoch, which holds every
+// attestation of the requested epoch included in time. Both the state at the end of the epoch
+// and the state at the end of the following epoch are regenerated, within the replay budget.
+func (bs *Server) GetIndividualVotes(
+	ctx context.Context,
+	req *pbrpc.IndividualVotesRequest,
+) (*pbrpc.IndividualVotesResponse, error) {
+	currentEpoch := helpers.SlotToEpoch(bs.GenesisTimeFetcher.CurrentSlot())
+	if req.Epoch+1 >= currentEpoch {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"votes of epoch %d are only final once epoch %d has ended, current epoch is %d",
+			req.Epoch,
+			req.Epoch+1,
+			currentEpoch,
+		)
+	}
+	epochEndState, err := bs.historicalState(ctx, helpers.StartSlot(req.Epoch+1)-1)
+	if err != nil {
+		return nil, err
+	}
+	votesState, err := bs.historicalState(ctx, helpers.StartSlot(req.Epoch+2)-1)
+	if err != nil {
+		return nil, err
+	}
+	votes, _, err := precompute.New(ctx, votesState)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not set up votes: %v", err)
+	}
+	// Attestations are processed here rather than with precompute.ProcessAttestations, which
+	// overwrites the balances reported in the metrics of the head.
+	for _, a := range votesState.PreviousEpochAttestations() {
+		record := &precompute.Validator{}
+		record.IsPrevEpochAttester, record.IsPrevEpochTargetAttester, record.IsPrevEpochHeadAttester, err = precompute.AttestedPrevEpoch(votesState, a)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not check votes of attestation: %v", err)
+		}
+		committee, err := helpers.BeaconCommitteeFromState(votesState, a.Data.Slot, a.Data.CommitteeIndex)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get attestation committee: %v", err)
+		}
+		votes = precompute.UpdateValidator(votes, record, attestationutil.AttestingIndices(a.AggregationBits, committee), a, a.Data.Slot)
+	}
+
+	res := &pbrpc.IndividualVotesResponse{
+		Epoch:             req.Epoch,
+		Votes:             make([]*pbrpc.IndividualVotesResponse_IndividualVote, 0, len(req.PublicKeys)+len(req.Indices)),
+		MissingPublicKeys: make([][]byte, 0),
+		MissingIndices:    make([]uint64, 0),
+	}
+	// Validators which joined after the end of the epoch are unknown to it.
+	numValidators := uint64(epochEndState.NumValidators())
+	requested := make([]uint64, 0, len(req.PublicKeys)+len(req.Indices))
+	for _, key := range req.PublicKeys {
+		idx, ok := epochEndState.ValidatorIndexByPubkey(bytesutil.ToBytes48(key))
+		if !ok || idx >= numValidators {
+			res.MissingPublicKeys = append(res.MissingPublicKeys, key)
+			continue
+		}
+		requested = append(requested, idx)
+	}
+	for _, idx := range req.Indices {
+		if idx >= numValidators {
+			res.MissingIndices = append(res.MissingIndices, idx)
+			continue
+		}
+		requested = append(requested, idx)
+	}
+
+	seen := make(map[uint64]bool, len(requested))
+	for _, idx := range requested {
+		if seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		val, err := epochEndState.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator: %v", err)
+		}
+		pubKey := val.PublicKey()
+		vote := votes[idx]
+		v := &pbrpc.IndividualVotesResponse_IndividualVote{
+			PublicKey:            pubKey[:],
+			Index:                idx,
+			IsActiveInEpoch:      vote.IsActivePrevEpoch,
+			CorrectlyVotedSource: vote.IsPrevEpochAttester,
+			CorrectlyVotedTarget: vote.IsPrevEpochTargetAttester,
+			CorrectlyVotedHead:   vote.IsPrevEpochHeadAttester,
+			IsSlashed:            val.Slashed(),
+			IsExited:             val.ExitEpoch() <= req.Epoch,
+			EffectiveBalance:     val.EffectiveBalance(),
+		}
+		// The inclusion is left at the far future epoch when no attestation was included.
+		if vote.InclusionSlot != params.BeaconConfig().FarFutureEpoch {
+			v.InclusionSlot = vote.InclusionSlot
+			v.InclusionDistance = vote.InclusionDistance
+		}
+		res.Votes = append(res.Votes, v)
+	}
+	return res, nil
+}
@@ -0,0 +1,145 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_GetIndividualVotes_EpochNotFinal(t *testing.T) {
+	genesis := time.Now().Add(-time.Duration(params.BeaconConfig().SlotsPerEpoch+1) * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	bs := &Server{
+		GenesisTimeFetcher: &mock.ChainService{Genesis: genesis},
+	}
+	wanted := "only final once epoch 1 has ended"
+	if _, err := bs.GetIndividualVotes(
+		context.Background(),
+		&pbrpc.IndividualVotesRequest{Epoch: 0},
+	); err == nil || !strings.Contains(err.Error(), wanted) {
+		t.Errorf("Expected error %v, received %v", wanted, err)
+	}
+}
+
+func TestServer_GetIndividualVotes(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{NewStateMgmt: true})
+	defer resetCfg()
+
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	helpers.ClearCache()
+
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	slashed, err := st.ValidatorAtIndex(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slashed.Slashed = true
+	if err := st.UpdateValidatorAtIndex(5, slashed); err != nil {
+		t.Fatal(err)
+	}
+	exited, err := st.ValidatorAtIndex(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exited.ExitEpoch = 0
+	if err := st.UpdateValidatorAtIndex(6, exited); err != nil {
+		t.Fatal(err)
+	}
+
+	committee, err := helpers.BeaconCommitteeFromState(st, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggregationBits := bitfield.NewBitlist(uint64(len(committee)))
+	aggregationBits.SetBitAt(0, true)
+	attester := committee[0]
+	if err := st.AppendCurrentEpochAttestations(&pbp2p.PendingAttestation{
+		AggregationBits: aggregationBits,
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		},
+		InclusionDelay: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	if err := db.SaveBlock(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	gRoot, err := ssz.HashTreeRoot(b.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, st, gRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveGenesisBlockRoot(ctx, gRoot); err != nil {
+		t.Fatal(err)
+	}
+	genesis := time.Now().Add(-100 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	bs := &Server{
+		BeaconDB:           db,
+		StateGen:           stategen.New(db, cache.NewStateSummaryCache()),
+		GenesisTimeFetcher: &mock.ChainService{Genesis: genesis},
+	}
+
+	unknownKey := bytes.Repeat([]byte{'u'}, 48)
+	attesterKey := st.PubkeyAtIndex(attester)
+	res, err := bs.GetIndividualVotes(ctx, &pbrpc.IndividualVotesRequest{
+		Epoch:      0,
+		PublicKeys: [][]byte{attesterKey[:], unknownKey},
+		Indices:    []uint64{5, 6, attester, 1000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.MissingPublicKeys) != 1 || !bytes.Equal(res.MissingPublicKeys[0], unknownKey) {
+		t.Errorf("Expected unknown public key to be missing, received %#x", res.MissingPublicKeys)
+	}
+	if len(res.MissingIndices) != 1 || res.MissingIndices[0] != 1000 {
+		t.Errorf("Expected unknown index to be missing, received %v", res.MissingIndices)
+	}
+	if len(res.Votes) != 3 {
+		t.Fatalf("Expected a vote per distinct validator, received %d", len(res.Votes))
+	}
+
+	vote := res.Votes[0]
+	if vote.Index != attester || !bytes.Equal(vote.PublicKey, attesterKey[:]) {
+		t.Errorf("Unexpected validator %d %#x", vote.Index, vote.PublicKey)
+	}
+	if !vote.IsActiveInEpoch || !vote.CorrectlyVotedSource {
+		t.Errorf("Expected validator %d to have attested, received %v", attester, vote)
+	}
+	if vote.InclusionSlot != 1 || vote.InclusionDistance != 1 {
+		t.Errorf("Expected inclusion at slot 1 with distance 1, received %d and %d", vote.InclusionSlot, vote.InclusionDistance)
+	}
+
+	vote = res.Votes[1]
+	if vote.Index != 5 || !vote.IsSlashed || vote.IsExited || vote.CorrectlyVotedSource || vote.InclusionSlot != 0 {
+		t.Errorf("Expected slashed validator without vote, received %v", vote)
+	}
+	vote = res.Votes[2]
+	if vote.Index != 6 || !vote.IsExited || vote.IsActiveInEpoch || vote.IsSlashed {
+		t.Errorf("Expected exited validator, received %v", vote)
+	}
+}
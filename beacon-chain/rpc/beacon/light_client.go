@@ -0,0 +1,63 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/lightclient"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetFinalityUpdate returns the light client finality update of the latest finalized
+// checkpoint stored by the light client service.
+func (bs *Server) GetFinalityUpdate(ctx context.Context, _ *pbrpc.FinalityUpdateRequest) (*pb.LightClientFinalityUpdate, error) {
+	update, err := bs.BeaconDB.LatestLightClientFinalityUpdate(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve finality update: %v", err)
+	}
+	if update == nil {
+		return nil, status.Error(codes.NotFound, "No finality update has been stored yet")
+	}
+	return update, nil
+}
+
+// GetOptimisticUpdate returns a light client optimistic update with the header of the
+// current head block.
+func (bs *Server) GetOptimisticUpdate(ctx context.Context, _ *pbrpc.OptimisticUpdateRequest) (*pb.LightClientOptimisticUpdate, error) {
+	headBlock, err := bs.HeadFetcher.HeadBlock(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head block: %v", err)
+	}
+	if headBlock == nil {
+		return nil, status.Error(codes.NotFound, "Head block is not available")
+	}
+	header, err := lightclient.BlockHeader(headBlock)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not build head block header: %v", err)
+	}
+	return &pb.LightClientOptimisticUpdate{AttestedHeader: header}, nil
+}
+
+// ListFinalityUpdates returns the stored light client finality updates with finalized
+// epochs between the requested start and end epochs, inclusive.
+func (bs *Server) ListFinalityUpdates(ctx context.Context, req *pbrpc.ListFinalityUpdatesRequest) (*pbrpc.FinalityUpdates, error) {
+	if req.EndEpoch < req.StartEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "End epoch %d can not be before start epoch %d", req.EndEpoch, req.StartEpoch)
+	}
+	if req.EndEpoch-req.StartEpoch >= uint64(flags.Get().MaxPageSize) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Requested epoch range %d can not be greater than max size %d",
+			req.EndEpoch-req.StartEpoch+1,
+			flags.Get().MaxPageSize,
+		)
+	}
+	updates, err := bs.BeaconDB.LightClientFinalityUpdates(ctx, req.StartEpoch, req.EndEpoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve finality updates: %v", err)
+	}
+	return &pbrpc.FinalityUpdates{Updates: updates}, nil
+}
@@ -0,0 +1,76 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_GetFinalityUpdate(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	ctx := context.Background()
+	bs := &Server{BeaconDB: db}
+
+	_, err := bs.GetFinalityUpdate(ctx, &pbrpc.FinalityUpdateRequest{})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Wanted not found error without stored updates, received %v", err)
+	}
+
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		update := &pb.LightClientFinalityUpdate{
+			AttestedHeader:  &ethpb.BeaconBlockHeader{Slot: epoch*8 + 16},
+			FinalizedHeader: &ethpb.BeaconBlockHeader{Slot: epoch * 8},
+			FinalizedEpoch:  epoch,
+		}
+		if err := db.SaveLightClientFinalityUpdate(ctx, update); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err := bs.GetFinalityUpdate(ctx, &pbrpc.FinalityUpdateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.FinalizedEpoch != 3 {
+		t.Errorf("Wanted latest finalized epoch 3, received %d", res.FinalizedEpoch)
+	}
+
+	list, err := bs.ListFinalityUpdates(ctx, &pbrpc.ListFinalityUpdatesRequest{StartEpoch: 2, EndEpoch: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Updates) != 2 || list.Updates[0].FinalizedEpoch != 2 {
+		t.Errorf("Unexpected finality updates %v", list.Updates)
+	}
+}
+
+func TestServer_ListFinalityUpdates_InvalidRange(t *testing.T) {
+	bs := &Server{}
+	_, err := bs.ListFinalityUpdates(context.Background(), &pbrpc.ListFinalityUpdatesRequest{StartEpoch: 5, EndEpoch: 1})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Wanted invalid argument error, received %v", err)
+	}
+	_, err = bs.ListFinalityUpdates(context.Background(), &pbrpc.ListFinalityUpdatesRequest{StartEpoch: 0, EndEpoch: 1000})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Wanted invalid argument error, received %v", err)
+	}
+}
+
+func TestServer_GetOptimisticUpdate(t *testing.T) {
+	headBlock := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 10, Body: &ethpb.BeaconBlockBody{}}}
+	bs := &Server{HeadFetcher: &mock.ChainService{Block: headBlock}}
+	res, err := bs.GetOptimisticUpdate(context.Background(), &pbrpc.OptimisticUpdateRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.AttestedHeader.Slot != 10 {
+		t.Errorf("Wanted attested header slot 10, received %d", res.AttestedHeader.Slot)
+	}
+}
@@ -0,0 +1,50 @@
+package beacon
+
+import (
+	"context"
+
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/logutil"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetLoggingLevel sets the log level of the beacon node along with per-package
+// overrides, taking effect immediately.
+func (bs *Server) SetLoggingLevel(
+	ctx context.Context,
+	req *pbrpc.LoggingLevelRequest,
+) (*pbrpc.LoggingLevelResponse, error) {
+	level, _ := logutil.Levels()
+	if req.Level != "" {
+		var err error
+		level, err = logrus.ParseLevel(req.Level)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Could not parse log level: %v", err)
+		}
+	}
+	overrides := make(map[string]logrus.Level, len(req.PackageLevels))
+	for prefix, l := range req.PackageLevels {
+		packageLevel, err := logrus.ParseLevel(l)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Could not parse log level of package %s: %v", prefix, err)
+		}
+		overrides[prefix] = packageLevel
+	}
+	logutil.SetLevels(level, overrides)
+	logrus.WithFields(logrus.Fields{
+		"level":     level,
+		"overrides": req.PackageLevels,
+	}).Info("Updated log levels")
+
+	level, overrides = logutil.Levels()
+	res := &pbrpc.LoggingLevelResponse{
+		Level:         level.String(),
+		PackageLevels: make(map[string]string, len(overrides)),
+	}
+	for prefix, l := range overrides {
+		res.PackageLevels[prefix] = l.String()
+	}
+	return res, nil
+}
@@ -0,0 +1,45 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/logutil"
+	"github.com/sirupsen/logrus"
+)
+
+func TestServer_SetLoggingLevel(t *testing.T) {
+	level, overrides := logutil.Levels()
+	defer logutil.SetLevels(level, overrides)
+	logutil.SetLevels(logrus.InfoLevel, nil)
+
+	bs := &Server{}
+	res, err := bs.SetLoggingLevel(context.Background(), &pbrpc.LoggingLevelRequest{
+		PackageLevels: map[string]string{"sync": "trace"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Level != "info" {
+		t.Errorf("Expected level to be kept at info, received %s", res.Level)
+	}
+	if res.PackageLevels["sync"] != "trace" {
+		t.Errorf("Wanted sync level trace, received %s", res.PackageLevels["sync"])
+	}
+	if logrus.GetLevel() != logrus.TraceLevel {
+		t.Errorf("Expected logrus level to allow trace entries, received %v", logrus.GetLevel())
+	}
+
+	res, err = bs.SetLoggingLevel(context.Background(), &pbrpc.LoggingLevelRequest{Level: "debug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Level != "debug" || len(res.PackageLevels) != 0 {
+		t.Errorf("Wanted level debug without overrides, received %s %v", res.Level, res.PackageLevels)
+	}
+
+	if _, err := bs.SetLoggingLevel(context.Background(), &pbrpc.LoggingLevelRequest{Level: "loud"}); err == nil {
+		t.Error("Expected error for unknown log level")
+	}
+}
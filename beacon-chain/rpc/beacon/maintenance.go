@@ -0,0 +1,52 @@
+package beacon
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/maintenance"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SetMaintenanceMode puts the beacon node into or out of maintenance mode. Entering maintenance
+// mode completes in the background, and GetMaintenanceStatus reports when the node is safe to stop.
+func (bs *Server) SetMaintenanceMode(
+	_ context.Context,
+	req *pbrpc.MaintenanceModeRequest,
+) (*pbrpc.MaintenanceStatus, error) {
+	if bs.Maintenance == nil {
+		return nil, status.Error(codes.Unavailable, "maintenance mode is not supported by this node")
+	}
+	if req.Enabled {
+		bs.Maintenance.Enter()
+	} else {
+		bs.Maintenance.Exit()
+	}
+	return maintenanceStatus(bs.Maintenance.Status()), nil
+}
+
+// GetMaintenanceStatus returns the progress of entering maintenance mode.
+func (bs *Server) GetMaintenanceStatus(
+	_ context.Context,
+	_ *pbrpc.MaintenanceStatusRequest,
+) (*pbrpc.MaintenanceStatus, error) {
+	if bs.Maintenance == nil {
+		return nil, status.Error(codes.Unavailable, "maintenance mode is not supported by this node")
+	}
+	return maintenanceStatus(bs.Maintenance.Status()), nil
+}
+
+func maintenanceStatus(s maintenance.Status) *pbrpc.MaintenanceStatus {
+	res := &pbrpc.MaintenanceStatus{
+		Enabled:           s.Enabled,
+		BroadcastsDrained: s.BroadcastsDrained,
+		BlocksDrained:     s.BlocksDrained,
+		CachesFlushed:     s.CachesFlushed,
+		SafeToStop:        s.SafeToStop(),
+	}
+	if s.Err != nil {
+		res.Error = s.Err.Error()
+	}
+	return res
+}
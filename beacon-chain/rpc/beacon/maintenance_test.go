@@ -0,0 +1,61 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/maintenance"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+)
+
+type mockBlockProcessor struct{}
+
+func (m *mockBlockProcessor) PauseBlockProcessing() {}
+
+func (m *mockBlockProcessor) ResumeBlockProcessing() {}
+
+func (m *mockBlockProcessor) FlushCaches(_ context.Context) error {
+	return nil
+}
+
+type mockBroadcastPauser struct{}
+
+func (m *mockBroadcastPauser) PauseBroadcasts() {}
+
+func (m *mockBroadcastPauser) ResumeBroadcasts() {}
+
+func TestServer_SetMaintenanceMode(t *testing.T) {
+	ctx := context.Background()
+	bs := &Server{
+		Maintenance: maintenance.New(ctx, &mockBlockProcessor{}, &mockBroadcastPauser{}),
+	}
+	res, err := bs.SetMaintenanceMode(ctx, &pbrpc.MaintenanceModeRequest{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Enabled {
+		t.Error("Expected maintenance mode to be enabled")
+	}
+
+	res, err = bs.SetMaintenanceMode(ctx, &pbrpc.MaintenanceModeRequest{Enabled: false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Enabled || res.SafeToStop {
+		t.Error("Expected maintenance mode to be disabled")
+	}
+	res, err = bs.GetMaintenanceStatus(ctx, &pbrpc.MaintenanceStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Enabled {
+		t.Error("Expected maintenance mode to be disabled")
+	}
+}
+
+func TestServer_GetMaintenanceStatus_Unavailable(t *testing.T) {
+	bs := &Server{}
+	if _, err := bs.GetMaintenanceStatus(context.Background(), &pbrpc.MaintenanceStatusRequest{}); err == nil {
+		t.Error("Expected error when maintenance mode is not supported")
+	}
+}
@@ -9,11 +9,13 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/maintenance"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
@@ -32,6 +34,7 @@ type Server struct {
 	HeadFetcher                 blockchain.HeadFetcher
 	FinalizationFetcher         blockchain.FinalizationFetcher
 	ParticipationFetcher        blockchain.ParticipationFetcher
+	ForkChoiceFetcher           blockchain.ForkChoiceFetcher
 	DepositFetcher              depositcache.DepositFetcher
 	BlockFetcher                powchain.POWBlockFetcher
 	GenesisTimeFetcher          blockchain.TimeFetcher
@@ -46,4 +49,9 @@ type Server struct {
 	ReceivedAttestationsBuffer  chan *ethpb.Attestation
 	CollectedAttestationsBuffer chan []*ethpb.Attestation
 	StateGen                    *stategen.State
+	DatabaseBackuper            db.Backuper
+	DatabaseCompacter           db.Compacter
+	BackupOutputDir             string
+	ChainHeadCache              *cache.ChainHeadCache
+	Maintenance                 *maintenance.Mode
 }
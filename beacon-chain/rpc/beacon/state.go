@@ -3,6 +3,8 @@ package beacon
 import (
 	"context"
 
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -37,3 +39,67 @@ func (bs *Server) GetBeaconState(
 		return nil, status.Error(codes.InvalidArgument, "need to specify either a block root or slot to request state")
 	}
 }
+
+// GetStateBySlot retrieves the beacon state at a slot, regenerating it
+// by replaying blocks when it is not stored.
+func (bs *Server) GetStateBySlot(
+	ctx context.Context,
+	req *pbrpc.StateBySlotRequest,
+) (*pbp2p.BeaconState, error) {
+	st, err := bs.historicalState(ctx, req.Slot)
+	if err != nil {
+		return nil, err
+	}
+	return st.CloneInnerState(), nil
+}
+
+// GetStateRoot retrieves the root of the beacon state at a slot,
+// regenerating the state by replaying blocks when it is not stored.
+func (bs *Server) GetStateRoot(
+	ctx context.Context,
+	req *pbrpc.StateRootRequest,
+) (*pbrpc.StateRootResponse, error) {
+	st, err := bs.historicalState(ctx, req.Slot)
+	if err != nil {
+		return nil, err
+	}
+	root, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not compute state root: %v", err)
+	}
+	return &pbrpc.StateRootResponse{
+		Slot:      req.Slot,
+		StateRoot: root[:],
+	}, nil
+}
+
+// This retrieves the state at the slot, rejecting the request with a resource exhausted error
+// when regenerating the state would replay more slots than the configured budget.
+func (bs *Server) historicalState(ctx context.Context, slot uint64) (*state.BeaconState, error) {
+	if !featureconfig.Get().NewStateMgmt {
+		return nil, status.Error(codes.FailedPrecondition, "requires --enable-new-state-mgmt to function")
+	}
+	if currentSlot := bs.GenesisTimeFetcher.CurrentSlot(); slot > currentSlot {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot retrieve state at slot %d after the current slot %d", slot, currentSlot)
+	}
+	if budget := flags.Get().StateReplayBudget; budget > 0 {
+		slots, err := bs.StateGen.SlotsToReplay(ctx, slot)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not estimate state regeneration cost: %v", err)
+		}
+		if slots > budget {
+			return nil, status.Errorf(
+				codes.ResourceExhausted,
+				"regenerating the state at slot %d requires replaying %d slots, more than the budget of %d slots",
+				slot,
+				slots,
+				budget,
+			)
+		}
+	}
+	st, err := bs.StateGen.StateBySlot(ctx, slot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not compute state by slot: %v", err)
+	}
+	return st, nil
+}
@@ -1,18 +1,26 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestServer_GetBeaconState(t *testing.T) {
@@ -78,3 +86,66 @@ func TestServer_GetBeaconState(t *testing.T) {
 		t.Errorf("Wanted %v, received %v", wanted, res)
 	}
 }
+
+func TestServer_GetStateBySlot(t *testing.T) {
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{NewStateMgmt: true})
+	defer resetCfg()
+	prevFlags := flags.Get()
+	defer flags.Init(prevFlags)
+
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+
+	ctx := context.Background()
+	st, _ := testutil.DeterministicGenesisState(t, 32)
+	b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	if err := db.SaveBlock(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	gRoot, err := ssz.HashTreeRoot(b.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, st, gRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveGenesisBlockRoot(ctx, gRoot); err != nil {
+		t.Fatal(err)
+	}
+	genesis := time.Now().Add(-100 * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	bs := &Server{
+		BeaconDB:           db,
+		StateGen:           stategen.New(db, cache.NewStateSummaryCache()),
+		GenesisTimeFetcher: &mock.ChainService{Genesis: genesis},
+	}
+
+	slot := params.BeaconConfig().SlotsPerEpoch + 2
+	flags.Init(&flags.GlobalFlags{StateReplayBudget: 1})
+	if _, err := bs.GetStateBySlot(ctx, &pbrpc.StateBySlotRequest{Slot: slot}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected resource exhausted error when exceeding the replay budget, received %v", err)
+	}
+	if _, err := bs.GetStateBySlot(ctx, &pbrpc.StateBySlotRequest{Slot: 1000}); err == nil || !strings.Contains(err.Error(), "after the current slot") {
+		t.Errorf("Expected error for future slot, received %v", err)
+	}
+
+	flags.Init(&flags.GlobalFlags{StateReplayBudget: 2})
+	res, err := bs.GetStateBySlot(ctx, &pbrpc.StateBySlotRequest{Slot: slot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Slot != slot {
+		t.Errorf("Wanted state at slot %d, received %d", slot, res.Slot)
+	}
+
+	rootRes, err := bs.GetStateRoot(ctx, &pbrpc.StateRootRequest{Slot: slot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted, err := ssz.HashTreeRoot(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rootRes.Slot != slot || !bytes.Equal(rootRes.StateRoot, wanted[:]) {
+		t.Errorf("Wanted state root %#x at slot %d, received %#x at slot %d", wanted, slot, rootRes.StateRoot, rootRes.Slot)
+	}
+}
@@ -0,0 +1,112 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetValidatorPerformanceReport reports the attestation inclusion and vote correctness, the balance
+// change in the epoch transition and the missed block proposals of the requested validators in the
+// previous epoch. Unknown validators, and validators inactive in the previous epoch, are returned
+// as missing.
+func (bs *Server) GetValidatorPerformanceReport(
+	ctx context.Context,
+	req *pbrpc.ValidatorPerformanceReportRequest,
+) (*pbrpc.ValidatorPerformanceReport, error) {
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	if headState == nil {
+		return nil, status.Error(codes.Unavailable, "Beacon chain has not started yet")
+	}
+	currentEpoch := helpers.CurrentEpoch(headState)
+	if currentEpoch == 0 {
+		return nil, status.Error(codes.Unavailable, "No performance to report before the end of the first epoch")
+	}
+	prevEpoch := currentEpoch - 1
+
+	proposerSlots, missedSlots, err := proposalsInEpoch(headState, prevEpoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not determine block proposals of epoch %d: %v", prevEpoch, err)
+	}
+
+	validatorSummary := state.ValidatorSummary
+	res := &pbrpc.ValidatorPerformanceReport{
+		Epoch:             prevEpoch,
+		Performances:      make([]*pbrpc.ValidatorPerformanceReport_Performance, 0, len(req.PublicKeys)),
+		MissingValidators: make([][]byte, 0),
+	}
+	for _, key := range req.PublicKeys {
+		idx, ok := headState.ValidatorIndexByPubkey(bytesutil.ToBytes48(key))
+		if !ok || idx >= uint64(len(validatorSummary)) {
+			// Unknown or not listed in the validator summary yet; treat it as missing.
+			res.MissingValidators = append(res.MissingValidators, key)
+			continue
+		}
+		val, err := headState.ValidatorAtIndexReadOnly(idx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get validator: %v", err)
+		}
+		if !helpers.IsActiveValidatorUsingTrie(val, prevEpoch) {
+			res.MissingValidators = append(res.MissingValidators, key)
+			continue
+		}
+		summary := validatorSummary[idx]
+		res.Performances = append(res.Performances, &pbrpc.ValidatorPerformanceReport_Performance{
+			PublicKey:                    key,
+			Index:                        idx,
+			InclusionSlot:                summary.InclusionSlot,
+			InclusionDistance:            summary.InclusionDistance,
+			CorrectlyVotedSource:         summary.IsPrevEpochAttester,
+			CorrectlyVotedTarget:         summary.IsPrevEpochTargetAttester,
+			CorrectlyVotedHead:           summary.IsPrevEpochHeadAttester,
+			BalanceBeforeEpochTransition: summary.BeforeEpochTransitionBalance,
+			BalanceAfterEpochTransition:  summary.AfterEpochTransitionBalance,
+			BalanceDelta:                 int64(summary.AfterEpochTransitionBalance) - int64(summary.BeforeEpochTransitionBalance),
+			EffectiveBalance:             summary.CurrentEpochEffectiveBalance,
+			AssignedProposals:            uint64(len(proposerSlots[idx])),
+			MissedProposalSlots:          missedSlots[idx],
+		})
+	}
+	return res, nil
+}
+
+// proposalsInEpoch returns the slots each proposer was assigned in the given past epoch, and the
+// slots among them without a canonical block. A slot has no block when its block root in the
+// state equals the root of the slot before.
+func proposalsInEpoch(st *stateTrie.BeaconState, epoch uint64) (map[uint64][]uint64, map[uint64][]uint64, error) {
+	_, proposerSlots, err := helpers.CommitteeAssignments(st.Copy(), epoch)
+	if err != nil {
+		return nil, nil, err
+	}
+	missedSlots := make(map[uint64][]uint64)
+	for idx, slots := range proposerSlots {
+		for _, slot := range slots {
+			// The genesis block has no proposer.
+			if slot == 0 {
+				continue
+			}
+			root, err := helpers.BlockRootAtSlot(st, slot)
+			if err != nil {
+				return nil, nil, err
+			}
+			prevRoot, err := helpers.BlockRootAtSlot(st, slot-1)
+			if err != nil {
+				return nil, nil, err
+			}
+			if bytes.Equal(root, prevRoot) {
+				missedSlots[idx] = append(missedSlots[idx], slot)
+			}
+		}
+	}
+	return proposerSlots, missedSlots, nil
+}
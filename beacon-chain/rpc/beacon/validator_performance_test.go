@@ -0,0 +1,119 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_GetValidatorPerformanceReport_NoPreviousEpoch(t *testing.T) {
+	headState, _ := testutil.DeterministicGenesisState(t, 8)
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{State: headState},
+	}
+	wanted := "No performance to report"
+	if _, err := bs.GetValidatorPerformanceReport(
+		context.Background(),
+		&pbrpc.ValidatorPerformanceReportRequest{},
+	); err == nil || !strings.Contains(err.Error(), wanted) {
+		t.Errorf("Expected error %v, received %v", wanted, err)
+	}
+}
+
+func TestServer_GetValidatorPerformanceReport(t *testing.T) {
+	numValidators := uint64(64)
+	headState, _ := testutil.DeterministicGenesisState(t, numValidators)
+	if err := headState.SetSlot(helpers.StartSlot(2)); err != nil {
+		t.Fatal(err)
+	}
+	// Every slot has its own block, except the missed slot which repeats the root before it.
+	missedSlot := helpers.StartSlot(1) + 5
+	roots := make([][]byte, params.BeaconConfig().SlotsPerHistoricalRoot)
+	for i := range roots {
+		roots[i] = bytesutil.Bytes32(uint64(i) + 1)
+	}
+	roots[missedSlot] = roots[missedSlot-1]
+	if err := headState.SetBlockRoots(roots); err != nil {
+		t.Fatal(err)
+	}
+	_, proposerSlots, err := helpers.CommitteeAssignments(headState.Copy(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var proposer uint64
+	for idx, slots := range proposerSlots {
+		for _, slot := range slots {
+			if slot == missedSlot {
+				proposer = idx
+			}
+		}
+	}
+
+	defaultBal := params.BeaconConfig().MaxEffectiveBalance
+	summary := make([]*precompute.Validator, numValidators)
+	for i := range summary {
+		summary[i] = &precompute.Validator{}
+	}
+	summary[proposer] = &precompute.Validator{
+		CurrentEpochEffectiveBalance: defaultBal,
+		BeforeEpochTransitionBalance: defaultBal,
+		AfterEpochTransitionBalance:  defaultBal - 1000,
+		InclusionSlot:                helpers.StartSlot(1) + 3,
+		InclusionDistance:            2,
+		IsPrevEpochAttester:          true,
+		IsPrevEpochTargetAttester:    true,
+	}
+	state.ValidatorSummary = summary
+	defer func() { state.ValidatorSummary = nil }()
+
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{State: headState},
+	}
+	proposerKey := headState.PubkeyAtIndex(proposer)
+	unknownKey := bytesutil.PadTo([]byte{0xff}, 48)
+	res, err := bs.GetValidatorPerformanceReport(context.Background(), &pbrpc.ValidatorPerformanceReportRequest{
+		PublicKeys: [][]byte{proposerKey[:], unknownKey},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Epoch != 1 {
+		t.Errorf("Wanted epoch 1, received %d", res.Epoch)
+	}
+	if len(res.MissingValidators) != 1 || !bytes.Equal(res.MissingValidators[0], unknownKey) {
+		t.Errorf("Wanted the unknown key to be missing, received %#x", res.MissingValidators)
+	}
+	if len(res.Performances) != 1 {
+		t.Fatalf("Wanted 1 performance, received %d", len(res.Performances))
+	}
+	perf := res.Performances[0]
+	if perf.Index != proposer {
+		t.Errorf("Wanted index %d, received %d", proposer, perf.Index)
+	}
+	if perf.BalanceDelta != -1000 {
+		t.Errorf("Wanted balance delta -1000, received %d", perf.BalanceDelta)
+	}
+	if !perf.CorrectlyVotedSource || !perf.CorrectlyVotedTarget || perf.CorrectlyVotedHead {
+		t.Errorf("Unexpected votes: source %t, target %t, head %t",
+			perf.CorrectlyVotedSource, perf.CorrectlyVotedTarget, perf.CorrectlyVotedHead)
+	}
+	if perf.InclusionDistance != 2 {
+		t.Errorf("Wanted inclusion distance 2, received %d", perf.InclusionDistance)
+	}
+	if perf.AssignedProposals != uint64(len(proposerSlots[proposer])) {
+		t.Errorf("Wanted %d assigned proposals, received %d", len(proposerSlots[proposer]), perf.AssignedProposals)
+	}
+	if len(perf.MissedProposalSlots) != 1 || perf.MissedProposalSlots[0] != missedSlot {
+		t.Errorf("Wanted missed proposal at slot %d, received %v", missedSlot, perf.MissedProposalSlots)
+	}
+}
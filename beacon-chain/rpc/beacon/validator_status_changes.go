@@ -0,0 +1,223 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamValidatorStatusChanges sends the current status of the requested validators, and then
+// notifies the client whenever one of them transitions to another status. The statuses are
+// recomputed from the head state on every head change and compared with the statuses
+// previously sent over the stream.
+func (bs *Server) StreamValidatorStatusChanges(
+	req *pbrpc.ValidatorStatusChangesRequest,
+	stream pbrpc.Validators_StreamValidatorStatusChangesServer,
+) error {
+	if len(req.PublicKeys) == 0 {
+		return status.Error(codes.InvalidArgument, "No public keys requested")
+	}
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := bs.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+
+	tracker := newStatusChangesTracker(req.PublicKeys, bs.DepositFetcher)
+	var lastHeadRoot []byte
+	checkStatuses := func() error {
+		ctx := stream.Context()
+		headRoot, err := bs.HeadFetcher.HeadRoot(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not get head root: %v", err)
+		}
+		if bytes.Equal(headRoot, lastHeadRoot) {
+			return nil
+		}
+		lastHeadRoot = headRoot
+		headState, err := bs.HeadFetcher.HeadState(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not get head state: %v", err)
+		}
+		if headState == nil {
+			return status.Error(codes.Unavailable, "Beacon chain has not started yet")
+		}
+		epoch := helpers.SlotToEpoch(bs.GenesisTimeFetcher.CurrentSlot())
+		changes, err := tracker.update(ctx, headState, epoch)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not compute validator statuses: %v", err)
+		}
+		for _, change := range changes {
+			if err := stream.Send(change); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		}
+		return nil
+	}
+
+	if err := checkStatuses(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type != statefeed.BlockProcessed {
+				continue
+			}
+			if err := checkStatuses(); err != nil {
+				return err
+			}
+		case <-stateSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-bs.Ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// statusChangesTracker keeps the statuses of a set of validators, as last sent over a status
+// changes stream.
+type statusChangesTracker struct {
+	pubKeys        [][]byte
+	depositFetcher depositcache.DepositFetcher
+	statuses       map[[48]byte]ethpb.ValidatorStatus
+}
+
+func newStatusChangesTracker(pubKeys [][]byte, depositFetcher depositcache.DepositFetcher) *statusChangesTracker {
+	return &statusChangesTracker{
+		pubKeys:        pubKeys,
+		depositFetcher: depositFetcher,
+	}
+}
+
+// update recomputes the statuses of the validators at the given epoch from the state, returning
+// a change for every validator whose status differs from the last computation. Every status is
+// returned on the first computation.
+func (t *statusChangesTracker) update(
+	ctx context.Context,
+	s *stateTrie.BeaconState,
+	epoch uint64,
+) ([]*pbrpc.ValidatorStatusChange, error) {
+	initial := t.statuses == nil
+	statuses := make(map[[48]byte]ethpb.ValidatorStatus, len(t.pubKeys))
+	var queue map[uint64]uint64
+	var changes []*pbrpc.ValidatorStatusChange
+	for _, pubKey := range t.pubKeys {
+		key := bytesutil.ToBytes48(pubKey)
+		change := &pbrpc.ValidatorStatusChange{
+			PublicKey:      pubKey,
+			PreviousStatus: t.statuses[key],
+			Status:         ethpb.ValidatorStatus_UNKNOWN_STATUS,
+			Epoch:          epoch,
+		}
+		idx, ok := s.ValidatorIndexByPubkey(key)
+		if !ok {
+			if t.depositFetcher != nil {
+				if deposit, _ := t.depositFetcher.DepositByPubkey(ctx, pubKey); deposit != nil {
+					change.Status = ethpb.ValidatorStatus_DEPOSITED
+				}
+			}
+		} else {
+			validator, err := s.ValidatorAtIndexReadOnly(idx)
+			if err != nil {
+				return nil, err
+			}
+			change.Index = idx
+			change.Status = validatorStatusAtEpoch(validator, epoch)
+			change.ActivationEpoch = validator.ActivationEpoch()
+			change.ExitEpoch = validator.ExitEpoch()
+			if change.ActivationEpoch == params.BeaconConfig().FarFutureEpoch {
+				if queue == nil {
+					queue = activationQueuePositions(s)
+				}
+				activationEpoch, err := estimatedActivationEpoch(s, validator, queue[idx], epoch)
+				if err != nil {
+					return nil, err
+				}
+				change.ActivationEpoch = activationEpoch
+			}
+			change.EstimatedActivationTime = epochStartTime(s, change.ActivationEpoch)
+			change.EstimatedExitTime = epochStartTime(s, change.ExitEpoch)
+		}
+		statuses[key] = change.Status
+		if initial || change.Status != change.PreviousStatus {
+			changes = append(changes, change)
+		}
+	}
+	t.statuses = statuses
+	return changes, nil
+}
+
+// activationQueuePositions returns the position in the activation queue of every validator
+// waiting for activation, ordered as the epoch processing activates them.
+func activationQueuePositions(s *stateTrie.BeaconState) map[uint64]uint64 {
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+	var queued []uint64
+	eligibility := make(map[uint64]uint64)
+	for idx, validator := range s.ValidatorsReadOnly() {
+		if validator.ActivationEpoch() != farFutureEpoch || validator.ActivationEligibilityEpoch() == farFutureEpoch {
+			continue
+		}
+		queued = append(queued, uint64(idx))
+		eligibility[uint64(idx)] = validator.ActivationEligibilityEpoch()
+	}
+	sort.Slice(queued, func(i, j int) bool {
+		if eligibility[queued[i]] != eligibility[queued[j]] {
+			return eligibility[queued[i]] < eligibility[queued[j]]
+		}
+		return queued[i] < queued[j]
+	})
+	positions := make(map[uint64]uint64, len(queued))
+	for position, idx := range queued {
+		positions[idx] = uint64(position)
+	}
+	return positions
+}
+
+// estimatedActivationEpoch estimates the activation epoch of a validator which is not yet
+// scheduled for activation, assuming the churn limit stays the same until it is activated.
+func estimatedActivationEpoch(
+	s *stateTrie.BeaconState,
+	validator *stateTrie.ReadOnlyValidator,
+	queuePosition uint64,
+	epoch uint64,
+) (uint64, error) {
+	activeCount, err := helpers.ActiveValidatorCount(s, helpers.CurrentEpoch(s))
+	if err != nil {
+		return 0, err
+	}
+	churnLimit, err := helpers.ValidatorChurnLimit(activeCount)
+	if err != nil {
+		return 0, err
+	}
+	// Validators which are not yet eligible become eligible at the next epoch processing.
+	eligibilityEpoch := validator.ActivationEligibilityEpoch()
+	if eligibilityEpoch == params.BeaconConfig().FarFutureEpoch {
+		eligibilityEpoch = epoch + 1
+	}
+	if eligibilityEpoch < epoch {
+		eligibilityEpoch = epoch
+	}
+	return helpers.ActivationExitEpoch(eligibilityEpoch) + queuePosition/churnLimit, nil
+}
+
+// epochStartTime returns the unix time in seconds of the start of the epoch, or 0 for the far
+// future epoch.
+func epochStartTime(s *stateTrie.BeaconState, epoch uint64) uint64 {
+	if epoch == params.BeaconConfig().FarFutureEpoch {
+		return 0
+	}
+	return s.GenesisTime() + helpers.StartSlot(epoch)*params.BeaconConfig().SecondsPerSlot
+}
@@ -0,0 +1,86 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestStatusChangesTracker_Update(t *testing.T) {
+	ctx := context.Background()
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	key0 := beaconState.PubkeyAtIndex(0)
+	key1 := beaconState.PubkeyAtIndex(1)
+	unknownKey := make([]byte, 48)
+	unknownKey[0] = 'a'
+	tracker := newStatusChangesTracker([][]byte{key0[:], key1[:], unknownKey}, depositcache.NewDepositCache())
+
+	changes, err := tracker.update(ctx, beaconState, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("Wanted the statuses of all 3 validators when opening the stream, received %d", len(changes))
+	}
+	wanted := []ethpb.ValidatorStatus{
+		ethpb.ValidatorStatus_ACTIVE,
+		ethpb.ValidatorStatus_ACTIVE,
+		ethpb.ValidatorStatus_UNKNOWN_STATUS,
+	}
+	for i, change := range changes {
+		if change.Status != wanted[i] {
+			t.Errorf("Wanted status %v for validator %d, received %v", wanted[i], i, change.Status)
+		}
+	}
+	changes, err = tracker.update(ctx, beaconState, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Wanted no changes for unchanged statuses, received %v", changes)
+	}
+
+	// Validator 0 is slashed and validator 1 is sent back to the activation queue.
+	slashed, err := beaconState.ValidatorAtIndex(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slashed.Slashed = true
+	slashed.ExitEpoch = 5
+	if err := beaconState.UpdateValidatorAtIndex(0, slashed); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := beaconState.ValidatorAtIndex(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending.ActivationEpoch = params.BeaconConfig().FarFutureEpoch
+	if err := beaconState.UpdateValidatorAtIndex(1, pending); err != nil {
+		t.Fatal(err)
+	}
+	changes, err = tracker.update(ctx, beaconState, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("Wanted 2 changes, received %v", changes)
+	}
+	if changes[0].PreviousStatus != ethpb.ValidatorStatus_ACTIVE || changes[0].Status != ethpb.ValidatorStatus_SLASHING {
+		t.Errorf("Wanted validator 0 to change from ACTIVE to SLASHING, received %v to %v", changes[0].PreviousStatus, changes[0].Status)
+	}
+	wantedExitTime := beaconState.GenesisTime() + helpers.StartSlot(5)*params.BeaconConfig().SecondsPerSlot
+	if changes[0].EstimatedExitTime != wantedExitTime {
+		t.Errorf("Wanted estimated exit time %d, received %d", wantedExitTime, changes[0].EstimatedExitTime)
+	}
+	if changes[1].Status != ethpb.ValidatorStatus_PENDING {
+		t.Errorf("Wanted validator 1 to be pending, received %v", changes[1].Status)
+	}
+	if wantedEpoch := helpers.ActivationExitEpoch(1); changes[1].ActivationEpoch != wantedEpoch {
+		t.Errorf("Wanted estimated activation epoch %d, received %d", wantedEpoch, changes[1].ActivationEpoch)
+	}
+}
@@ -0,0 +1,190 @@
+package beacon
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/pagination"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListValidatorStatuses retrieves the statuses and balances of a set of validators, filtered by
+// public keys and indices, or of every validator in the registry if no filter is given. An optional
+// Epoch parameter is provided to request historical statuses from archived, persistent data.
+func (bs *Server) ListValidatorStatuses(
+	ctx context.Context,
+	req *pbrpc.ListValidatorStatusesRequest,
+) (*pbrpc.ValidatorStatuses, error) {
+	if int(req.PageSize) > flags.Get().MaxPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "Requested page size %d can not be greater than max size %d",
+			req.PageSize, flags.Get().MaxPageSize)
+	}
+	if bs.GenesisTimeFetcher == nil {
+		return nil, status.Errorf(codes.Internal, "Nil genesis time fetcher")
+	}
+	currentEpoch := helpers.SlotToEpoch(bs.GenesisTimeFetcher.CurrentSlot())
+	requestedEpoch := currentEpoch
+	switch q := req.QueryFilter.(type) {
+	case *pbrpc.ListValidatorStatusesRequest_Epoch:
+		requestedEpoch = q.Epoch
+	case *pbrpc.ListValidatorStatusesRequest_Genesis:
+		requestedEpoch = 0
+	}
+	if requestedEpoch > currentEpoch {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Cannot retrieve information about an epoch in the future, current epoch %d, requesting %d",
+			currentEpoch,
+			requestedEpoch,
+		)
+	}
+
+	requestedState, err := bs.validatorStatusesState(ctx, requestedEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	var indices []uint64
+	filtered := map[uint64]bool{} // Track filtered validators to prevent duplication in the response.
+	for _, pubKey := range req.PublicKeys {
+		// Skip empty public key.
+		if len(pubKey) == 0 {
+			continue
+		}
+		pubkeyBytes := bytesutil.ToBytes48(pubKey)
+		index, ok := requestedState.ValidatorIndexByPubkey(pubkeyBytes)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "Could not find validator index for public key %#x", pubkeyBytes)
+		}
+		if !filtered[index] {
+			filtered[index] = true
+			indices = append(indices, index)
+		}
+	}
+	for _, index := range req.Indices {
+		if int(index) >= requestedState.NumValidators() {
+			return nil, status.Errorf(codes.OutOfRange, "Validator index %d >= validator count %d",
+				index, requestedState.NumValidators())
+		}
+		if !filtered[index] {
+			filtered[index] = true
+			indices = append(indices, index)
+		}
+	}
+	// Depending on the indices and public keys given, results might not be sorted.
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	filterRequested := len(req.PublicKeys) > 0 || len(req.Indices) > 0
+	totalSize := len(indices)
+	if !filterRequested {
+		totalSize = requestedState.NumValidators()
+	}
+	// If there are no validators, we simply return a response specifying this.
+	// Otherwise, attempting to paginate 0 validators below would result in an error.
+	if totalSize == 0 {
+		return &pbrpc.ValidatorStatuses{
+			Epoch:         requestedEpoch,
+			Statuses:      make([]*pbrpc.ValidatorStatuses_Status, 0),
+			TotalSize:     int32(0),
+			NextPageToken: strconv.Itoa(0),
+		}, nil
+	}
+
+	start, end, nextPageToken, err := pagination.StartAndEndPage(req.PageToken, int(req.PageSize), totalSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not paginate results: %v", err)
+	}
+
+	res := make([]*pbrpc.ValidatorStatuses_Status, 0, end-start)
+	for i := start; i < end; i++ {
+		index := uint64(i)
+		if filterRequested {
+			index = indices[i]
+		}
+		validator, err := requestedState.ValidatorAtIndexReadOnly(index)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve validator %d: %v", index, err)
+		}
+		balance, err := requestedState.BalanceAtIndex(index)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve balance of validator %d: %v", index, err)
+		}
+		pubKey := validator.PublicKey()
+		res = append(res, &pbrpc.ValidatorStatuses_Status{
+			PublicKey:        pubKey[:],
+			Index:            index,
+			Status:           validatorStatusAtEpoch(validator, requestedEpoch),
+			Balance:          balance,
+			EffectiveBalance: validator.EffectiveBalance(),
+			ActivationEpoch:  validator.ActivationEpoch(),
+			ExitEpoch:        validator.ExitEpoch(),
+		})
+	}
+
+	return &pbrpc.ValidatorStatuses{
+		Epoch:         requestedEpoch,
+		Statuses:      res,
+		TotalSize:     int32(totalSize),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// validatorStatusesState returns the state at the start of the requested epoch. Without the new
+// state management, only the head state is available so historical epochs can not be served.
+func (bs *Server) validatorStatusesState(ctx context.Context, requestedEpoch uint64) (*stateTrie.BeaconState, error) {
+	if featureconfig.Get().NewStateMgmt {
+		requestedState, err := bs.StateGen.StateBySlot(ctx, helpers.StartSlot(requestedEpoch))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not get state: %v", err)
+		}
+		return requestedState, nil
+	}
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not get head state: %v", err)
+	}
+	if headState == nil {
+		return nil, status.Error(codes.Unavailable, "Beacon chain has not started yet")
+	}
+	if requestedEpoch < helpers.CurrentEpoch(headState) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Historical validator statuses require new state management, current epoch %d, requesting %d",
+			helpers.CurrentEpoch(headState),
+			requestedEpoch,
+		)
+	}
+	return headState, nil
+}
+
+// validatorStatusAtEpoch determines the status of a validator at the given epoch.
+func validatorStatusAtEpoch(validator *stateTrie.ReadOnlyValidator, epoch uint64) ethpb.ValidatorStatus {
+	if epoch < validator.ActivationEligibilityEpoch() {
+		return ethpb.ValidatorStatus_DEPOSITED
+	}
+	if epoch < validator.ActivationEpoch() {
+		return ethpb.ValidatorStatus_PENDING
+	}
+	if validator.ExitEpoch() == params.BeaconConfig().FarFutureEpoch {
+		return ethpb.ValidatorStatus_ACTIVE
+	}
+	if epoch < validator.ExitEpoch() {
+		if validator.Slashed() {
+			return ethpb.ValidatorStatus_SLASHING
+		}
+		return ethpb.ValidatorStatus_EXITING
+	}
+	return ethpb.ValidatorStatus_EXITED
+}
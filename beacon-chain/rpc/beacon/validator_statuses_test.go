@@ -0,0 +1,125 @@
+package beacon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	dbTest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestServer_ListValidatorStatuses_CannotRequestFutureEpoch(t *testing.T) {
+	bs := &Server{
+		GenesisTimeFetcher: &mock.ChainService{},
+	}
+	wanted := "Cannot retrieve information about an epoch in the future"
+	if _, err := bs.ListValidatorStatuses(
+		context.Background(),
+		&pbrpc.ListValidatorStatusesRequest{
+			QueryFilter: &pbrpc.ListValidatorStatusesRequest_Epoch{
+				Epoch: 1,
+			},
+		},
+	); err == nil || !strings.Contains(err.Error(), wanted) {
+		t.Errorf("Expected error %v, received %v", wanted, err)
+	}
+}
+
+func TestServer_ListValidatorStatuses(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{NewStateMgmt: true})
+	defer resetCfg()
+
+	ctx := context.Background()
+	headState, _ := testutil.DeterministicGenesisState(t, 8)
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	if err := headState.UpdateValidatorAtIndex(2, &ethpb.Validator{
+		PublicKey:                  headState.PubkeyAtIndex(2)[:],
+		EffectiveBalance:           params.BeaconConfig().MaxEffectiveBalance,
+		ActivationEligibilityEpoch: 0,
+		ActivationEpoch:            1,
+		ExitEpoch:                  farFuture,
+		WithdrawableEpoch:          farFuture,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	if err := db.SaveBlock(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	gRoot, err := ssz.HashTreeRoot(b.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveGenesisBlockRoot(ctx, gRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, headState, gRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	bs := &Server{
+		GenesisTimeFetcher: &mock.ChainService{},
+		StateGen:           stategen.New(db, cache.NewStateSummaryCache()),
+	}
+
+	// Every validator is returned in pages when no filter is given.
+	res, err := bs.ListValidatorStatuses(ctx, &pbrpc.ListValidatorStatusesRequest{
+		QueryFilter: &pbrpc.ListValidatorStatusesRequest_Genesis{Genesis: true},
+		PageSize:    5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TotalSize != 8 || len(res.Statuses) != 5 || res.NextPageToken != "1" {
+		t.Fatalf("Unexpected first page, total %d, page %d, next token %s", res.TotalSize, len(res.Statuses), res.NextPageToken)
+	}
+	res, err = bs.ListValidatorStatuses(ctx, &pbrpc.ListValidatorStatusesRequest{
+		PageSize:  5,
+		PageToken: res.NextPageToken,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Statuses) != 3 || res.Statuses[0].Index != 5 {
+		t.Fatalf("Unexpected second page %v", res.Statuses)
+	}
+
+	// Public keys and indices are deduplicated and returned in index order.
+	pubKey := headState.PubkeyAtIndex(2)
+	res, err = bs.ListValidatorStatuses(ctx, &pbrpc.ListValidatorStatusesRequest{
+		PublicKeys: [][]byte{pubKey[:]},
+		Indices:    []uint64{4, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.TotalSize != 2 || len(res.Statuses) != 2 {
+		t.Fatalf("Wanted 2 statuses, received %d", len(res.Statuses))
+	}
+	if res.Statuses[0].Index != 2 || res.Statuses[0].Status != ethpb.ValidatorStatus_PENDING {
+		t.Errorf("Wanted validator 2 to be pending, received %v", res.Statuses[0])
+	}
+	if res.Statuses[1].Index != 4 || res.Statuses[1].Status != ethpb.ValidatorStatus_ACTIVE {
+		t.Errorf("Wanted validator 4 to be active, received %v", res.Statuses[1])
+	}
+	if res.Statuses[1].Balance != params.BeaconConfig().MaxEffectiveBalance {
+		t.Errorf("Wanted balance %d, received %d", params.BeaconConfig().MaxEffectiveBalance, res.Statuses[1].Balance)
+	}
+
+	if _, err := bs.ListValidatorStatuses(ctx, &pbrpc.ListValidatorStatusesRequest{
+		Indices: []uint64{8},
+	}); err == nil {
+		t.Error("Expected error for out of range index")
+	}
+}
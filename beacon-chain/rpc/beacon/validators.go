@@ -464,29 +464,26 @@ func (bs *Server) GetValidator(
 			"Need to specify either validator index or public key in request",
 		)
 	}
+	if !requestingIndex {
+		var ok bool
+		index, ok = bs.HeadFetcher.HeadValidatorIndex(bytesutil.ToBytes48(pubKey))
+		if !ok {
+			return nil, status.Error(codes.NotFound, "No validator matched filter criteria")
+		}
+	}
 	headState, err := bs.HeadFetcher.HeadState(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Could not get head state")
 	}
-	if requestingIndex {
-		if index >= uint64(headState.NumValidators()) {
-			return nil, status.Errorf(
-				codes.OutOfRange,
-				"Requesting index %d, but there are only %d validators",
-				index,
-				headState.NumValidators(),
-			)
-		}
-		return headState.ValidatorAtIndex(index)
-	}
-	pk48 := bytesutil.ToBytes48(pubKey)
-	for i := 0; i < headState.NumValidators(); i++ {
-		keyFromState := headState.PubkeyAtIndex(uint64(i))
-		if keyFromState == pk48 {
-			return headState.ValidatorAtIndex(uint64(i))
-		}
+	if index >= uint64(headState.NumValidators()) {
+		return nil, status.Errorf(
+			codes.OutOfRange,
+			"Requesting index %d, but there are only %d validators",
+			index,
+			headState.NumValidators(),
+		)
 	}
-	return nil, status.Error(codes.NotFound, "No validator matched filter criteria")
+	return headState.ValidatorAtIndex(index)
 }
 
 // GetValidatorActiveSetChanges retrieves the active set changes for a given epoch.
@@ -721,6 +718,28 @@ func (bs *Server) GetValidatorParticipation(
 		)
 	}
 
+	headState, err := bs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Could not get head state")
+	}
+
+	// Serve participation persisted by the archiver to avoid regenerating the state. Records
+	// without eligible ether were archived before participation was computed, and the
+	// participation of their epoch is recomputed instead.
+	if flags.Get().EnableArchive {
+		participation, err := bs.BeaconDB.ArchivedValidatorParticipation(ctx, requestedEpoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not fetch archived participation: %v", err)
+		}
+		if participation != nil && participation.EligibleEther > 0 {
+			return &ethpb.ValidatorParticipationResponse{
+				Epoch:         requestedEpoch,
+				Finalized:     requestedEpoch <= headState.FinalizedCheckpointEpoch(),
+				Participation: participation,
+			}, nil
+		}
+	}
+
 	requestedState, err := bs.StateGen.StateBySlot(ctx, helpers.StartSlot(requestedEpoch+1))
 	if err != nil {
 		return nil, status.Error(codes.Internal, "Could not get state")
@@ -735,11 +754,6 @@ func (bs *Server) GetValidatorParticipation(
 		return nil, status.Error(codes.Internal, "Could not pre compute attestations")
 	}
 
-	headState, err := bs.HeadFetcher.HeadState(ctx)
-	if err != nil {
-		return nil, status.Error(codes.Internal, "Could not get head state")
-	}
-
 	return &ethpb.ValidatorParticipationResponse{
 		Epoch:     requestedEpoch,
 		Finalized: requestedEpoch <= headState.FinalizedCheckpointEpoch(),
@@ -1800,6 +1800,75 @@ func TestServer_GetValidatorParticipation_PrevEpoch(t *testing.T) {
 	}
 }
 
+func TestServer_GetValidatorParticipation_RecomputesEmptyArchivedRecord(t *testing.T) {
+	db := dbTest.SetupDB(t)
+	defer dbTest.TeardownDB(t, db)
+	resetCfg := featureconfig.InitWithReset(&featureconfig.Flags{NewStateMgmt: true})
+	defer resetCfg()
+	prevFlags := flags.Get()
+	archiveFlags := *prevFlags
+	archiveFlags.EnableArchive = true
+	flags.Init(&archiveFlags)
+	defer flags.Init(prevFlags)
+
+	ctx := context.Background()
+	validatorCount := uint64(100)
+
+	validators := make([]*ethpb.Validator, validatorCount)
+	balances := make([]uint64, validatorCount)
+	for i := 0; i < len(validators); i++ {
+		validators[i] = &ethpb.Validator{
+			ExitEpoch:        params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance: params.BeaconConfig().MaxEffectiveBalance,
+		}
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+
+	headState := testutil.NewBeaconState()
+	if err := headState.SetSlot(params.BeaconConfig().SlotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	if err := headState.SetValidators(validators); err != nil {
+		t.Fatal(err)
+	}
+	if err := headState.SetBalances(balances); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: params.BeaconConfig().SlotsPerEpoch}}
+	if err := db.SaveBlock(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	bRoot, err := ssz.HashTreeRoot(b.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, headState, bRoot); err != nil {
+		t.Fatal(err)
+	}
+	// An empty record archived before participation was available.
+	if err := db.SaveArchivedValidatorParticipation(ctx, 0, &ethpb.ValidatorParticipation{}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &mock.ChainService{State: headState}
+	bs := &Server{
+		BeaconDB:             db,
+		HeadFetcher:          m,
+		ParticipationFetcher: m,
+		GenesisTimeFetcher:   &mock.ChainService{},
+		StateGen:             stategen.New(db, cache.NewStateSummaryCache()),
+	}
+
+	res, err := bs.GetValidatorParticipation(ctx, &ethpb.GetValidatorParticipationRequest{QueryFilter: &ethpb.GetValidatorParticipationRequest_Epoch{Epoch: 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Participation.EligibleEther != validatorCount*params.BeaconConfig().MaxEffectiveBalance {
+		t.Errorf("Expected participation to be recomputed, received %v", res.Participation)
+	}
+}
+
 func TestServer_GetValidatorParticipation_DoesntExist(t *testing.T) {
 	db := dbTest.SetupDB(t)
 	defer dbTest.TeardownDB(t, db)
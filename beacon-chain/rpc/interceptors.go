@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/maintenance"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	rpcRequestsByClient = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rpc_requests_by_client_total",
+			Help: "Number of gRPC requests handled, by method, response code and client address",
+		},
+		[]string{"method", "code", "client"},
+	)
+	rpcRequestLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "rpc_request_latency_seconds",
+			Help:    "Latency of unary gRPC requests, by method",
+			Buckets: []float64{0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 4, 8},
+		},
+		[]string{"method"},
+	)
+	rpcSlowRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "rpc_slow_requests_total",
+			Help: "Number of unary gRPC requests exceeding the slow request threshold, by method",
+		},
+		[]string{"method"},
+	)
+)
+
+// unaryClientMetricsInterceptor records per method and per client request metrics, and logs
+// requests taking longer than the slow request threshold. A threshold of 0 disables logging.
+func unaryClientMetricsInterceptor(slowThreshold time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		elapsed := time.Since(start)
+
+		client := clientAddress(ctx)
+		code := status.Code(err)
+		rpcRequestsByClient.WithLabelValues(info.FullMethod, code.String(), client).Inc()
+		rpcRequestLatency.WithLabelValues(info.FullMethod).Observe(elapsed.Seconds())
+		if slowThreshold > 0 && elapsed >= slowThreshold {
+			rpcSlowRequests.WithLabelValues(info.FullMethod).Inc()
+			log.WithFields(logrus.Fields{
+				"method":   info.FullMethod,
+				"client":   client,
+				"code":     code.String(),
+				"duration": elapsed,
+				"request":  summarizePayload(req),
+			}).Warn("Slow RPC request")
+		}
+		return resp, err
+	}
+}
+
+// streamClientMetricsInterceptor records per method and per client stream metrics. Streams are
+// long lived, so neither their latency nor slowness is recorded.
+func streamClientMetricsInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	rpcRequestsByClient.WithLabelValues(info.FullMethod, status.Code(err).String(), clientAddress(ss.Context())).Inc()
+	return err
+}
+
+// servicesRejectedInMaintenance are the gRPC services serving validator duties, which are
+// rejected while the node is in maintenance mode.
+var servicesRejectedInMaintenance = map[string]bool{
+	"ethereum.eth.v1alpha1.BeaconNodeValidator": true,
+	"ethereum.beacon.rpc.v1.Duties":             true,
+}
+
+// maintenanceUnaryInterceptor rejects requests for validator duties while the node is in
+// maintenance mode, so that validator clients fail over to another beacon node.
+func maintenanceUnaryInterceptor(m *maintenance.Mode) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if m.Enabled() && rejectedInMaintenance(info.FullMethod) {
+			return nil, status.Error(codes.Unavailable, "beacon node is in maintenance mode")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// maintenanceStreamInterceptor rejects streams of validator duties while the node is in
+// maintenance mode.
+func maintenanceStreamInterceptor(m *maintenance.Mode) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if m.Enabled() && rejectedInMaintenance(info.FullMethod) {
+			return status.Error(codes.Unavailable, "beacon node is in maintenance mode")
+		}
+		return handler(srv, ss)
+	}
+}
+
+// rejectedInMaintenance returns true if the full gRPC method name, such as
+// /ethereum.beacon.rpc.v1.Duties/GetDuties, belongs to a service rejected in maintenance mode.
+func rejectedInMaintenance(fullMethod string) bool {
+	parts := strings.SplitN(strings.TrimPrefix(fullMethod, "/"), "/", 2)
+	return servicesRejectedInMaintenance[parts[0]]
+}
+
+// clientAddress returns the IP address of the client calling the server, without the port so
+// that reconnecting clients are counted together.
+func clientAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// summarizePayload describes a request by its message type and encoded size only, so that no
+// field values such as signatures or keys end up in the logs.
+func summarizePayload(req interface{}) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("%T", req)
+	}
+	name := proto.MessageName(msg)
+	if name == "" {
+		name = fmt.Sprintf("%T", req)
+	}
+	return fmt.Sprintf("%s (%d bytes)", name, proto.Size(msg))
+}
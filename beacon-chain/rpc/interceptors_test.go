@@ -0,0 +1,112 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/maintenance"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryClientMetricsInterceptor_LogsSlowRequests(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4000},
+	})
+	req := &ethpb.DomainRequest{Epoch: 1, Domain: []byte("secret-domain")}
+	info := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.BeaconNodeValidator/DomainData"}
+
+	interceptor := unaryClientMetricsInterceptor(10 * time.Millisecond)
+	fast := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	if _, err := interceptor(ctx, req, info, fast); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertLogsDoNotContain(t, hook, "Slow RPC request")
+
+	slow := func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return nil, nil
+	}
+	if _, err := interceptor(ctx, req, info, slow); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertLogsContain(t, hook, "Slow RPC request")
+	entry := hook.LastEntry()
+	if entry.Data["client"] != "10.0.0.1" {
+		t.Errorf("Wanted client 10.0.0.1, got %v", entry.Data["client"])
+	}
+	testutil.AssertLogsDoNotContain(t, hook, "secret-domain")
+}
+
+func TestUnaryClientMetricsInterceptor_ZeroThresholdDisablesLogging(t *testing.T) {
+	hook := logTest.NewGlobal()
+	interceptor := unaryClientMetricsInterceptor(0)
+	info := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.Node/GetVersion"}
+	slow := func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(time.Millisecond)
+		return nil, nil
+	}
+	if _, err := interceptor(context.Background(), &ethpb.DomainRequest{}, info, slow); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertLogsDoNotContain(t, hook, "Slow RPC request")
+}
+
+type mockMaintained struct{}
+
+func (m *mockMaintained) PauseBlockProcessing() {}
+
+func (m *mockMaintained) ResumeBlockProcessing() {}
+
+func (m *mockMaintained) FlushCaches(_ context.Context) error {
+	return nil
+}
+
+func (m *mockMaintained) PauseBroadcasts() {}
+
+func (m *mockMaintained) ResumeBroadcasts() {}
+
+func TestMaintenanceUnaryInterceptor_RejectsDuties(t *testing.T) {
+	ctx := context.Background()
+	mode := maintenance.New(ctx, &mockMaintained{}, &mockMaintained{})
+	defer mode.Exit()
+	interceptor := maintenanceUnaryInterceptor(mode)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	duties := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.BeaconNodeValidator/GetDuties"}
+	node := &grpc.UnaryServerInfo{FullMethod: "/ethereum.eth.v1alpha1.Node/GetVersion"}
+
+	if _, err := interceptor(ctx, nil, duties, handler); err != nil {
+		t.Fatalf("Expected duties to be served outside of maintenance mode: %v", err)
+	}
+	mode.Enter()
+	if _, err := interceptor(ctx, nil, duties, handler); status.Code(err) != codes.Unavailable {
+		t.Errorf("Wanted code %v, received %v", codes.Unavailable, status.Code(err))
+	}
+	if _, err := interceptor(ctx, nil, node, handler); err != nil {
+		t.Errorf("Expected node requests to be served in maintenance mode: %v", err)
+	}
+}
+
+func TestClientAddress(t *testing.T) {
+	if got := clientAddress(context.Background()); got != "unknown" {
+		t.Errorf("Wanted unknown client without peer, got %s", got)
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("192.168.1.2"), Port: 5000},
+	})
+	if got := clientAddress(ctx); got != "192.168.1.2" {
+		t.Errorf("Wanted 192.168.1.2, got %s", got)
+	}
+}
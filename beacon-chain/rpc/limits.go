@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"net"
+	"time"
+
+	"golang.org/x/net/netutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// ServerLimits configures the resource limits of the gRPC server. Zero values keep the gRPC
+// defaults, or no limit where gRPC has none.
+type ServerLimits struct {
+	// MaxRecvMsgSize is the largest message in bytes the server accepts.
+	MaxRecvMsgSize int
+	// MaxSendMsgSize is the largest message in bytes the server sends.
+	MaxSendMsgSize int
+	// MaxConcurrentStreams is the number of concurrent calls allowed per client connection.
+	MaxConcurrentStreams uint32
+	// MaxConnections is the number of client connections the server accepts at once.
+	MaxConnections int
+	// KeepaliveMinTime is the minimum time clients must wait between keepalive pings.
+	KeepaliveMinTime time.Duration
+	// KeepalivePermitWithoutStream allows clients to ping while they have no active calls.
+	KeepalivePermitWithoutStream bool
+}
+
+// serverOptions returns the gRPC server options applying the limits.
+func (l ServerLimits) serverOptions() []grpc.ServerOption {
+	opts := make([]grpc.ServerOption, 0)
+	if l.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(l.MaxRecvMsgSize))
+	}
+	if l.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(l.MaxSendMsgSize))
+	}
+	if l.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(l.MaxConcurrentStreams))
+	}
+	if l.KeepaliveMinTime > 0 || l.KeepalivePermitWithoutStream {
+		policy := keepalive.EnforcementPolicy{PermitWithoutStream: l.KeepalivePermitWithoutStream}
+		// The gRPC default of 5 minutes also applies when only pings without streams are permitted.
+		policy.MinTime = 5 * time.Minute
+		if l.KeepaliveMinTime > 0 {
+			policy.MinTime = l.KeepaliveMinTime
+		}
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+	return opts
+}
+
+// limitListener restricts the number of connections accepted at once by the listener. Further
+// clients wait until a connection is closed.
+func (l ServerLimits) limitListener(lis net.Listener) net.Listener {
+	if lis == nil || l.MaxConnections <= 0 {
+		return lis
+	}
+	return netutil.LimitListener(lis, l.MaxConnections)
+}
@@ -0,0 +1,44 @@
+package rpc
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestServerLimits_ServerOptions(t *testing.T) {
+	if opts := (ServerLimits{}).serverOptions(); len(opts) != 0 {
+		t.Errorf("Expected no server options without limits, received %d", len(opts))
+	}
+	limits := ServerLimits{
+		MaxRecvMsgSize:       1 << 20,
+		MaxSendMsgSize:       1 << 20,
+		MaxConcurrentStreams: 100,
+		KeepaliveMinTime:     time.Minute,
+	}
+	if opts := limits.serverOptions(); len(opts) != 4 {
+		t.Errorf("Expected 4 server options, received %d", len(opts))
+	}
+}
+
+func TestServerLimits_LimitListener(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := lis.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	if got := (ServerLimits{}).limitListener(lis); got != lis {
+		t.Error("Expected listener to be unchanged without a connection limit")
+	}
+	if got := (ServerLimits{MaxConnections: 1}).limitListener(lis); got == lis {
+		t.Error("Expected listener to be limited")
+	}
+	if got := (ServerLimits{MaxConnections: 1}).limitListener(nil); got != nil {
+		t.Error("Expected no listener when listening failed")
+	}
+}
@@ -8,13 +8,20 @@ import (
 	"fmt"
 	"sort"
 
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/libp2p/go-libp2p-core/network"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/progress"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -31,6 +38,10 @@ type Server struct {
 	PeersFetcher       p2p.PeersProvider
 	GenesisTimeFetcher blockchain.TimeFetcher
 	GenesisFetcher     blockchain.GenesisFetcher
+	ForkFetcher        blockchain.ForkFetcher
+	IdentityProvider   p2p.IdentityProvider
+	DepositProcessing  powchain.DepositProcessingFetcher
+	SyncProgress       progress.Fetcher
 }
 
 // GetSyncStatus checks the current network sync status of the node.
@@ -97,16 +108,9 @@ func (ns *Server) ListPeers(ctx context.Context, _ *ptypes.Empty) (*ethpb.Peers,
 		}
 
 		address := fmt.Sprintf("%s/p2p/%s", multiaddr.String(), pid.Pretty())
-		pbDirection := ethpb.PeerDirection_UNKNOWN
-		switch direction {
-		case network.DirInbound:
-			pbDirection = ethpb.PeerDirection_INBOUND
-		case network.DirOutbound:
-			pbDirection = ethpb.PeerDirection_OUTBOUND
-		}
 		res = append(res, &ethpb.Peer{
 			Address:   address,
-			Direction: pbDirection,
+			Direction: peerDirectionToProto(direction),
 		})
 	}
 
@@ -114,3 +118,190 @@ func (ns *Server) ListPeers(ctx context.Context, _ *ptypes.Empty) (*ethpb.Peers,
 		Peers: res,
 	}, nil
 }
+
+// GetIdentity returns the peer ID, ENR, listening addresses and metadata the node
+// advertises to the network.
+func (ns *Server) GetIdentity(ctx context.Context, _ *pbrpc.IdentityRequest) (*pbrpc.Identity, error) {
+	peerID := ns.IdentityProvider.PeerID()
+	addrs := ns.IdentityProvider.HostAddrs()
+	p2pAddresses := make([]string, len(addrs))
+	for i, addr := range addrs {
+		p2pAddresses[i] = addr.String() + "/p2p/" + peerID.Pretty()
+	}
+	var enrString string
+	if record := ns.IdentityProvider.ENR(); record != nil {
+		n, err := enode.New(enode.ValidSchemes, record)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not decode local ENR: %v", err)
+		}
+		enrString = n.String()
+	}
+	identity := &pbrpc.Identity{
+		PeerId:       peerID.Pretty(),
+		Enr:          enrString,
+		P2PAddresses: p2pAddresses,
+	}
+	if metadata := ns.IdentityProvider.Metadata(); metadata != nil {
+		identity.MetadataSeqNumber = metadata.SeqNumber
+		identity.Attnets = metadata.Attnets
+	}
+	return identity, nil
+}
+
+// ListDetailedPeers lists the peers known to this node with their connection details
+// and the chain status they last reported, filtered by connection state and direction.
+func (ns *Server) ListDetailedPeers(ctx context.Context, req *pbrpc.ListDetailedPeersRequest) (*pbrpc.DetailedPeers, error) {
+	states := make(map[pbrpc.PeerConnectionState]bool)
+	for _, state := range req.States {
+		states[state] = true
+	}
+	if len(states) == 0 {
+		states[pbrpc.PeerConnectionState_CONNECTED] = true
+	}
+
+	peerStatus := ns.PeersFetcher.Peers()
+	res := make([]*pbrpc.DetailedPeer, 0)
+	for _, pid := range peerStatus.All() {
+		connState, err := peerStatus.ConnectionState(pid)
+		if err != nil {
+			continue
+		}
+		pbConnState := peerConnectionStateToProto(connState)
+		if !states[pbConnState] {
+			continue
+		}
+		direction, err := peerStatus.Direction(pid)
+		if err != nil {
+			continue
+		}
+		pbDirection := peerDirectionToProto(direction)
+		if req.Direction != ethpb.PeerDirection_UNKNOWN && req.Direction != pbDirection {
+			continue
+		}
+
+		detailedPeer := &pbrpc.DetailedPeer{
+			PeerId:          pid.Pretty(),
+			Direction:       pbDirection,
+			ConnectionState: pbConnState,
+		}
+		if multiaddr, err := peerStatus.Address(pid); err == nil && multiaddr != nil {
+			detailedPeer.Address = fmt.Sprintf("%s/p2p/%s", multiaddr.String(), pid.Pretty())
+		}
+		if agent, err := peerStatus.Agent(pid); err == nil {
+			detailedPeer.Agent = agent
+		}
+		if badResponses, err := peerStatus.BadResponses(pid); err == nil {
+			detailedPeer.BadResponses = uint64(badResponses)
+		}
+		if chainState, err := peerStatus.ChainState(pid); err == nil && chainState != nil {
+			detailedPeer.HeadSlot = chainState.HeadSlot
+			detailedPeer.HeadRoot = chainState.HeadRoot
+			detailedPeer.FinalizedEpoch = chainState.FinalizedEpoch
+		}
+		if connState == peers.PeerConnected {
+			if connectedAt, err := peerStatus.ConnectedAt(pid); err == nil && !connectedAt.IsZero() {
+				detailedPeer.ConnectedSeconds = uint64(roughtime.Since(connectedAt).Seconds())
+			}
+		}
+		res = append(res, detailedPeer)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].PeerId < res[j].PeerId
+	})
+
+	return &pbrpc.DetailedPeers{
+		Peers: res,
+	}, nil
+}
+
+// GetBeaconConfig returns the chain configuration of the node, which validator clients
+// compare against their own to detect mismatched timing or fork parameters.
+func (ns *Server) GetBeaconConfig(ctx context.Context, _ *pbrpc.BeaconConfigRequest) (*pbrpc.BeaconConfig, error) {
+	cfg := params.BeaconConfig()
+	res := &pbrpc.BeaconConfig{
+		ConfigName:         cfg.ConfigName,
+		SecondsPerSlot:     cfg.SecondsPerSlot,
+		SlotsPerEpoch:      cfg.SlotsPerEpoch,
+		GenesisForkVersion: cfg.GenesisForkVersion,
+		Syncing:            ns.SyncChecker.Syncing(),
+	}
+	if genesisTime := ns.GenesisTimeFetcher.GenesisTime(); !genesisTime.IsZero() {
+		res.GenesisTime = uint64(genesisTime.Unix())
+	}
+	genValRoot := ns.GenesisFetcher.GenesisValidatorRoot()
+	res.GenesisValidatorsRoot = genValRoot[:]
+	if fork := ns.ForkFetcher.CurrentFork(); fork != nil {
+		res.CurrentForkVersion = fork.CurrentVersion
+		res.PreviousForkVersion = fork.PreviousVersion
+		res.ForkEpoch = fork.Epoch
+	}
+	return res, nil
+}
+
+// GetDepositProcessingStatus returns the progress of processing the deposit contract logs.
+func (ns *Server) GetDepositProcessingStatus(
+	ctx context.Context,
+	_ *pbrpc.DepositProcessingStatusRequest,
+) (*pbrpc.DepositProcessingStatus, error) {
+	if ns.DepositProcessing == nil {
+		return nil, status.Error(codes.Unavailable, "deposit processing is not tracked by this node")
+	}
+	st := ns.DepositProcessing.DepositProcessingStatus(ctx)
+	return &pbrpc.DepositProcessingStatus{
+		ConnectedToEth1:    st.ConnectedToETH1,
+		ChainStarted:       st.ChainStarted,
+		LatestBlock:        st.LatestBlock,
+		LastProcessedBlock: st.LastProcessedBlock,
+		ProcessedDeposits:  st.ProcessedDeposits,
+		PendingDeposits:    st.PendingDeposits,
+		DepositRoot:        st.DepositRoot[:],
+	}, nil
+}
+
+// GetDetailedSyncStatus returns the progress of syncing the chain, along with the estimated
+// time until the node is synced.
+func (ns *Server) GetDetailedSyncStatus(
+	ctx context.Context,
+	_ *pbrpc.DetailedSyncStatusRequest,
+) (*pbrpc.DetailedSyncStatus, error) {
+	if ns.SyncProgress == nil {
+		return nil, status.Error(codes.Unavailable, "sync progress is not tracked by this node")
+	}
+	st := ns.SyncProgress.SyncProgress()
+	res := &pbrpc.DetailedSyncStatus{
+		Syncing:                   st.Syncing,
+		CurrentSlot:               st.CurrentSlot,
+		HeadSlot:                  st.HeadSlot,
+		BlocksPerSecond:           st.BlocksPerSecond,
+		SyncPeers:                 uint64(st.Peers),
+		EstimatedSecondsRemaining: uint64(st.EstimatedTimeRemaining.Seconds()),
+	}
+	if st.EstimatedTimeRemaining > 0 {
+		res.EstimatedCompletionTime = uint64(roughtime.Now().Add(st.EstimatedTimeRemaining).Unix())
+	}
+	return res, nil
+}
+
+func peerDirectionToProto(direction network.Direction) ethpb.PeerDirection {
+	switch direction {
+	case network.DirInbound:
+		return ethpb.PeerDirection_INBOUND
+	case network.DirOutbound:
+		return ethpb.PeerDirection_OUTBOUND
+	default:
+		return ethpb.PeerDirection_UNKNOWN
+	}
+}
+
+func peerConnectionStateToProto(state peers.PeerConnectionState) pbrpc.PeerConnectionState {
+	switch state {
+	case peers.PeerConnecting:
+		return pbrpc.PeerConnectionState_CONNECTING
+	case peers.PeerConnected:
+		return pbrpc.PeerConnectionState_CONNECTED
+	case peers.PeerDisconnecting:
+		return pbrpc.PeerConnectionState_DISCONNECTING
+	default:
+		return pbrpc.PeerConnectionState_DISCONNECTED
+	}
+}
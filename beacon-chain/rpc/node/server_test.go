@@ -11,13 +11,21 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/progress"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 func TestNodeServer_GetSyncStatus(t *testing.T) {
@@ -79,6 +87,138 @@ func TestNodeServer_GetGenesis(t *testing.T) {
 	}
 }
 
+func TestNodeServer_GetBeaconConfig(t *testing.T) {
+	genValRoot := bytesutil.ToBytes32([]byte("I am root"))
+	fork := &pb.Fork{
+		PreviousVersion: []byte{0, 0, 0, 0},
+		CurrentVersion:  []byte{1, 0, 0, 0},
+		Epoch:           10,
+	}
+	chain := &mock.ChainService{
+		Genesis:        time.Unix(100, 0),
+		ValidatorsRoot: genValRoot,
+		Fork:           fork,
+	}
+	ns := &Server{
+		SyncChecker:        &mockSync.Sync{IsSyncing: true},
+		GenesisTimeFetcher: chain,
+		GenesisFetcher:     chain,
+		ForkFetcher:        chain,
+	}
+	res, err := ns.GetBeaconConfig(context.Background(), &pbrpc.BeaconConfigRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := params.BeaconConfig()
+	if res.ConfigName != cfg.ConfigName {
+		t.Errorf("Wanted ConfigName = %s, received %s", cfg.ConfigName, res.ConfigName)
+	}
+	if res.GenesisTime != 100 {
+		t.Errorf("Wanted GenesisTime = %d, received %d", 100, res.GenesisTime)
+	}
+	if res.SecondsPerSlot != cfg.SecondsPerSlot || res.SlotsPerEpoch != cfg.SlotsPerEpoch {
+		t.Errorf("Wanted timing %d/%d, received %d/%d", cfg.SecondsPerSlot, cfg.SlotsPerEpoch, res.SecondsPerSlot, res.SlotsPerEpoch)
+	}
+	if !bytes.Equal(res.GenesisForkVersion, cfg.GenesisForkVersion) {
+		t.Errorf("Wanted GenesisForkVersion = %#x, received %#x", cfg.GenesisForkVersion, res.GenesisForkVersion)
+	}
+	if !bytes.Equal(res.CurrentForkVersion, fork.CurrentVersion) || !bytes.Equal(res.PreviousForkVersion, fork.PreviousVersion) {
+		t.Errorf("Wanted fork versions %#x/%#x, received %#x/%#x", fork.CurrentVersion, fork.PreviousVersion, res.CurrentForkVersion, res.PreviousForkVersion)
+	}
+	if res.ForkEpoch != fork.Epoch {
+		t.Errorf("Wanted ForkEpoch = %d, received %d", fork.Epoch, res.ForkEpoch)
+	}
+	if !bytes.Equal(res.GenesisValidatorsRoot, genValRoot[:]) {
+		t.Errorf("Wanted GenesisValidatorsRoot = %#x, received %#x", genValRoot, res.GenesisValidatorsRoot)
+	}
+	if !res.Syncing {
+		t.Error("Expected the node to report syncing")
+	}
+}
+
+func TestNodeServer_GetDetailedSyncStatus(t *testing.T) {
+	ns := &Server{
+		SyncProgress: &mockSync.Sync{Progress: &progress.Status{
+			Syncing:                true,
+			CurrentSlot:            1000,
+			HeadSlot:               400,
+			BlocksPerSecond:        20,
+			Peers:                  3,
+			EstimatedTimeRemaining: 30 * time.Second,
+		}},
+	}
+	res, err := ns.GetDetailedSyncStatus(context.Background(), &pbrpc.DetailedSyncStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Syncing || res.CurrentSlot != 1000 || res.HeadSlot != 400 || res.BlocksPerSecond != 20 || res.SyncPeers != 3 {
+		t.Errorf("Unexpected sync status %v", res)
+	}
+	if res.EstimatedSecondsRemaining != 30 {
+		t.Errorf("Wanted 30 seconds remaining, received %d", res.EstimatedSecondsRemaining)
+	}
+	if completion := time.Unix(int64(res.EstimatedCompletionTime), 0); completion.Before(time.Now().Add(25 * time.Second)) {
+		t.Errorf("Expected completion in about 30 seconds, received %v", completion)
+	}
+
+	ns.SyncProgress = &mockSync.Sync{}
+	res, err = ns.GetDetailedSyncStatus(context.Background(), &pbrpc.DetailedSyncStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Syncing || res.EstimatedCompletionTime != 0 {
+		t.Errorf("Expected synced node without an estimate, received %v", res)
+	}
+
+	ns.SyncProgress = nil
+	if _, err := ns.GetDetailedSyncStatus(context.Background(), &pbrpc.DetailedSyncStatusRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected unavailable error without a sync progress fetcher, received %v", err)
+	}
+}
+
+type depositProcessingFetcher struct {
+	status *powchain.DepositProcessingStatus
+}
+
+func (d *depositProcessingFetcher) DepositProcessingStatus(_ context.Context) *powchain.DepositProcessingStatus {
+	return d.status
+}
+
+func TestNodeServer_GetDepositProcessingStatus(t *testing.T) {
+	want := &powchain.DepositProcessingStatus{
+		ConnectedToETH1:    true,
+		LatestBlock:        1000,
+		LastProcessedBlock: 990,
+		ProcessedDeposits:  64,
+		PendingDeposits:    2,
+		DepositRoot:        bytesutil.ToBytes32([]byte("deposit root")),
+	}
+	ns := &Server{
+		DepositProcessing: &depositProcessingFetcher{status: want},
+	}
+	res, err := ns.GetDepositProcessingStatus(context.Background(), &pbrpc.DepositProcessingStatusRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.ConnectedToEth1 || res.ChainStarted {
+		t.Errorf("Wanted connected and not started, received connected=%v started=%v", res.ConnectedToEth1, res.ChainStarted)
+	}
+	if res.LatestBlock != want.LatestBlock || res.LastProcessedBlock != want.LastProcessedBlock {
+		t.Errorf("Wanted blocks %d/%d, received %d/%d", want.LatestBlock, want.LastProcessedBlock, res.LatestBlock, res.LastProcessedBlock)
+	}
+	if res.ProcessedDeposits != want.ProcessedDeposits || res.PendingDeposits != want.PendingDeposits {
+		t.Errorf("Wanted deposits %d/%d, received %d/%d", want.ProcessedDeposits, want.PendingDeposits, res.ProcessedDeposits, res.PendingDeposits)
+	}
+	if !bytes.Equal(res.DepositRoot, want.DepositRoot[:]) {
+		t.Errorf("Wanted DepositRoot = %#x, received %#x", want.DepositRoot, res.DepositRoot)
+	}
+
+	ns.DepositProcessing = nil
+	if _, err := ns.GetDepositProcessingStatus(context.Background(), &pbrpc.DepositProcessingStatusRequest{}); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected unavailable error without a deposit processing fetcher, received %v", err)
+	}
+}
+
 func TestNodeServer_GetVersion(t *testing.T) {
 	v := version.GetVersion()
 	ns := &Server{}
@@ -133,3 +273,103 @@ func TestNodeServer_ListPeers(t *testing.T) {
 		t.Errorf("Expected 2st peer to be an outbound (%d) connection, received %d", ethpb.PeerDirection_OUTBOUND, res.Peers[0].Direction)
 	}
 }
+
+func TestNodeServer_GetIdentity(t *testing.T) {
+	peer := mockP2p.NewTestP2P(t)
+	peer.LocalMetadata = &pb.MetaData{
+		SeqNumber: 5,
+		Attnets:   []byte{1, 0, 0, 0, 0, 0, 0, 0},
+	}
+	ns := &Server{
+		IdentityProvider: peer,
+	}
+	res, err := ns.GetIdentity(context.Background(), &pbrpc.IdentityRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PeerId != peer.PeerID().Pretty() {
+		t.Errorf("Wanted peer ID %s, received %s", peer.PeerID().Pretty(), res.PeerId)
+	}
+	if res.Enr != "" {
+		t.Errorf("Expected no ENR for a peer without discovery, received %s", res.Enr)
+	}
+	if len(res.P2PAddresses) != len(peer.Host.Addrs()) {
+		t.Errorf("Wanted %d addresses, received %d", len(peer.Host.Addrs()), len(res.P2PAddresses))
+	}
+	if res.MetadataSeqNumber != 5 {
+		t.Errorf("Wanted metadata sequence number %d, received %d", 5, res.MetadataSeqNumber)
+	}
+	if !bytes.Equal(res.Attnets, peer.LocalMetadata.Attnets) {
+		t.Errorf("Wanted attnets %#x, received %#x", peer.LocalMetadata.Attnets, res.Attnets)
+	}
+}
+
+func TestNodeServer_ListDetailedPeers(t *testing.T) {
+	peersProvider := &mockP2p.MockPeersProvider{}
+	ns := &Server{
+		PeersFetcher: peersProvider,
+	}
+	pids := peersProvider.Peers().Connected()
+	if len(pids) != 2 {
+		t.Fatalf("Expected 2 connected peers, received %d", len(pids))
+	}
+	peersProvider.Peers().SetAgent(pids[0], "Prysm/v0.2.0")
+	peersProvider.Peers().IncrementBadResponses(pids[0])
+	peersProvider.Peers().SetConnectionState(pids[1], peers.PeerDisconnected)
+
+	res, err := ns.ListDetailedPeers(context.Background(), &pbrpc.ListDetailedPeersRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Peers) != 1 {
+		t.Fatalf("Expected 1 connected peer, received %d: %v", len(res.Peers), res.Peers)
+	}
+	p := res.Peers[0]
+	if p.PeerId != pids[0].Pretty() {
+		t.Errorf("Wanted peer ID %s, received %s", pids[0].Pretty(), p.PeerId)
+	}
+	if p.ConnectionState != pbrpc.PeerConnectionState_CONNECTED {
+		t.Errorf("Wanted connection state %v, received %v", pbrpc.PeerConnectionState_CONNECTED, p.ConnectionState)
+	}
+	if p.Agent != "Prysm/v0.2.0" {
+		t.Errorf("Wanted agent %s, received %s", "Prysm/v0.2.0", p.Agent)
+	}
+	if p.BadResponses != 1 {
+		t.Errorf("Wanted %d bad responses, received %d", 1, p.BadResponses)
+	}
+	chainState, err := peersProvider.Peers().ChainState(pids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.FinalizedEpoch != chainState.FinalizedEpoch {
+		t.Errorf("Wanted finalized epoch %d, received %d", chainState.FinalizedEpoch, p.FinalizedEpoch)
+	}
+
+	res, err = ns.ListDetailedPeers(context.Background(), &pbrpc.ListDetailedPeersRequest{
+		States: []pbrpc.PeerConnectionState{
+			pbrpc.PeerConnectionState_CONNECTED,
+			pbrpc.PeerConnectionState_DISCONNECTED,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Peers) != 2 {
+		t.Fatalf("Expected 2 peers, received %d: %v", len(res.Peers), res.Peers)
+	}
+
+	direction, err := peersProvider.Peers().Direction(pids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = ns.ListDetailedPeers(context.Background(), &pbrpc.ListDetailedPeersRequest{
+		States:    []pbrpc.PeerConnectionState{pbrpc.PeerConnectionState_DISCONNECTED},
+		Direction: peerDirectionToProto(direction),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Peers) != 1 || res.Peers[0].PeerId != pids[1].Pretty() {
+		t.Errorf("Expected only peer %s, received %v", pids[1].Pretty(), res.Peers)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"net"
 	"os"
+	"time"
 
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
@@ -15,12 +16,14 @@ import (
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/broadcastprotection"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
 	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/maintenance"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/voluntaryexits"
@@ -31,6 +34,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validator"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/progress"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
@@ -41,11 +45,23 @@ import (
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 var log logrus.FieldLogger
 
+// servicesRequiringSync are the gRPC services which can only serve accurate data
+// once the node has completed initial sync.
+var servicesRequiringSync = map[string]bool{
+	"ethereum.eth.v1alpha1.BeaconChain":         true,
+	"ethereum.eth.v1alpha1.BeaconNodeValidator": true,
+	"ethereum.beacon.rpc.v1.Debug":              true,
+	"ethereum.beacon.rpc.v1.Duties":             true,
+	"ethereum.beacon.rpc.v1.Validators":         true,
+}
+
 func init() {
 	log = logrus.WithField("prefix", "rpc")
 	rand.Seed(int64(os.Getpid()))
@@ -60,6 +76,7 @@ type Service struct {
 	forkFetcher             blockchain.ForkFetcher
 	finalizationFetcher     blockchain.FinalizationFetcher
 	participationFetcher    blockchain.ParticipationFetcher
+	forkChoiceFetcher       blockchain.ForkChoiceFetcher
 	genesisTimeFetcher      blockchain.TimeFetcher
 	genesisFetcher          blockchain.GenesisFetcher
 	attestationReceiver     blockchain.AttestationReceiver
@@ -67,7 +84,13 @@ type Service struct {
 	powChainService         powchain.Chain
 	chainStartFetcher       powchain.ChainStartFetcher
 	mockEth1Votes           bool
+	blockProposalBudget     time.Duration
+	attInclusionPolicy      *validator.AttestationInclusionPolicy
+	slowRequestThreshold    time.Duration
+	serverLimits            ServerLimits
 	enableDebugRPCEndpoints bool
+	enableLightClientServer bool
+	disableReflection       bool
 	attestationsPool        attestations.Pool
 	exitPool                *voluntaryexits.Pool
 	slashingsPool           *slashings.Pool
@@ -78,11 +101,13 @@ type Service struct {
 	withCert                string
 	withKey                 string
 	grpcServer              *grpc.Server
+	healthServer            *health.Server
 	canonicalStateChan      chan *pbp2p.BeaconState
 	incomingAttestation     chan *ethpb.Attestation
 	credentialError         error
 	p2p                     p2p.Broadcaster
 	peersFetcher            p2p.PeersProvider
+	identityProvider        p2p.IdentityProvider
 	depositFetcher          depositcache.DepositFetcher
 	pendingDepositFetcher   depositcache.PendingDepositsFetcher
 	stateNotifier           statefeed.Notifier
@@ -94,6 +119,13 @@ type Service struct {
 	slasherCredentialError  error
 	slasherClient           slashpb.SlasherClient
 	stateGen                *stategen.State
+	databaseBackuper        db.Backuper
+	databaseCompacter       db.Compacter
+	backupOutputDir         string
+	maintenance             *maintenance.Mode
+	depositProcessing       powchain.DepositProcessingFetcher
+	syncProgress            progress.Fetcher
+	broadcastProtection     *broadcastprotection.Service
 }
 
 // Config options for the beacon node RPC server.
@@ -107,6 +139,7 @@ type Config struct {
 	ForkFetcher             blockchain.ForkFetcher
 	FinalizationFetcher     blockchain.FinalizationFetcher
 	ParticipationFetcher    blockchain.ParticipationFetcher
+	ForkChoiceFetcher       blockchain.ForkChoiceFetcher
 	AttestationReceiver     blockchain.AttestationReceiver
 	BlockReceiver           blockchain.BlockReceiver
 	POWChainService         powchain.Chain
@@ -114,13 +147,22 @@ type Config struct {
 	GenesisTimeFetcher      blockchain.TimeFetcher
 	GenesisFetcher          blockchain.GenesisFetcher
 	EnableDebugRPCEndpoints bool
+	EnableLightClientServer bool
+	DisableReflection       bool
 	MockEth1Votes           bool
+	BlockProposalBudget     time.Duration
+	MinAttInclusionReward   uint64
+	ExcludedAttCommittees   []uint64
+	PreferOwnValidatorAtts  bool
+	SlowRequestThreshold    time.Duration
+	ServerLimits            ServerLimits
 	AttestationsPool        attestations.Pool
 	ExitPool                *voluntaryexits.Pool
 	SlashingsPool           *slashings.Pool
 	SyncService             sync.Checker
 	Broadcaster             p2p.Broadcaster
 	PeersFetcher            p2p.PeersProvider
+	IdentityProvider        p2p.IdentityProvider
 	DepositFetcher          depositcache.DepositFetcher
 	PendingDepositFetcher   depositcache.PendingDepositsFetcher
 	SlasherProvider         string
@@ -129,6 +171,13 @@ type Config struct {
 	BlockNotifier           blockfeed.Notifier
 	OperationNotifier       opfeed.Notifier
 	StateGen                *stategen.State
+	DatabaseBackuper        db.Backuper
+	DatabaseCompacter       db.Compacter
+	BackupOutputDir         string
+	Maintenance             *maintenance.Mode
+	DepositProcessing       powchain.DepositProcessingFetcher
+	SyncProgress            progress.Fetcher
+	BroadcastProtection     *broadcastprotection.Service
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -143,15 +192,21 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		forkFetcher:             cfg.ForkFetcher,
 		finalizationFetcher:     cfg.FinalizationFetcher,
 		participationFetcher:    cfg.ParticipationFetcher,
+		forkChoiceFetcher:       cfg.ForkChoiceFetcher,
 		genesisTimeFetcher:      cfg.GenesisTimeFetcher,
 		genesisFetcher:          cfg.GenesisFetcher,
 		attestationReceiver:     cfg.AttestationReceiver,
 		blockReceiver:           cfg.BlockReceiver,
 		p2p:                     cfg.Broadcaster,
 		peersFetcher:            cfg.PeersFetcher,
+		identityProvider:        cfg.IdentityProvider,
 		powChainService:         cfg.POWChainService,
 		chainStartFetcher:       cfg.ChainStartFetcher,
 		mockEth1Votes:           cfg.MockEth1Votes,
+		blockProposalBudget:     cfg.BlockProposalBudget,
+		attInclusionPolicy:      attestationInclusionPolicy(cfg),
+		slowRequestThreshold:    cfg.SlowRequestThreshold,
+		serverLimits:            cfg.ServerLimits,
 		attestationsPool:        cfg.AttestationsPool,
 		exitPool:                cfg.ExitPool,
 		slashingsPool:           cfg.SlashingsPool,
@@ -171,6 +226,32 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		slasherCert:             cfg.SlasherCert,
 		stateGen:                cfg.StateGen,
 		enableDebugRPCEndpoints: cfg.EnableDebugRPCEndpoints,
+		enableLightClientServer: cfg.EnableLightClientServer,
+		disableReflection:       cfg.DisableReflection,
+		databaseBackuper:        cfg.DatabaseBackuper,
+		databaseCompacter:       cfg.DatabaseCompacter,
+		backupOutputDir:         cfg.BackupOutputDir,
+		maintenance:             cfg.Maintenance,
+		depositProcessing:       cfg.DepositProcessing,
+		syncProgress:            cfg.SyncProgress,
+		broadcastProtection:     cfg.BroadcastProtection,
+	}
+}
+
+// attestationInclusionPolicy returns the attestation inclusion policy of proposed blocks
+// configured by cfg, or nil if every attestation may be included.
+func attestationInclusionPolicy(cfg *Config) *validator.AttestationInclusionPolicy {
+	if cfg.MinAttInclusionReward == 0 && len(cfg.ExcludedAttCommittees) == 0 && !cfg.PreferOwnValidatorAtts {
+		return nil
+	}
+	excluded := make(map[uint64]bool, len(cfg.ExcludedAttCommittees))
+	for _, committeeIndex := range cfg.ExcludedAttCommittees {
+		excluded[committeeIndex] = true
+	}
+	return &validator.AttestationInclusionPolicy{
+		MinProposerReward:   cfg.MinAttInclusionReward,
+		ExcludedCommittees:  excluded,
+		PreferOwnValidators: cfg.PreferOwnValidatorAtts,
 	}
 }
 
@@ -181,7 +262,7 @@ func (s *Service) Start() {
 	if err != nil {
 		log.Errorf("Could not listen to port in Start() %s: %v", address, err)
 	}
-	s.listener = lis
+	s.listener = s.serverLimits.limitListener(lis)
 	log.WithField("address", address).Info("RPC-API listening on port")
 
 	opts := []grpc.ServerOption{
@@ -192,6 +273,8 @@ func (s *Service) Start() {
 			),
 			grpc_prometheus.StreamServerInterceptor,
 			grpc_opentracing.StreamServerInterceptor(),
+			streamClientMetricsInterceptor,
+			maintenanceStreamInterceptor(s.maintenance),
 		)),
 		grpc.UnaryInterceptor(middleware.ChainUnaryServer(
 			recovery.UnaryServerInterceptor(
@@ -199,8 +282,11 @@ func (s *Service) Start() {
 			),
 			grpc_prometheus.UnaryServerInterceptor,
 			grpc_opentracing.UnaryServerInterceptor(),
+			unaryClientMetricsInterceptor(s.slowRequestThreshold),
+			maintenanceUnaryInterceptor(s.maintenance),
 		)),
 	}
+	opts = append(opts, s.serverLimits.serverOptions()...)
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	// TODO(#791): Utilize a certificate for secure connections
 	// between beacon nodes and validator clients.
@@ -220,6 +306,7 @@ func (s *Service) Start() {
 		Ctx:                    s.ctx,
 		BeaconDB:               s.beaconDB,
 		AttestationCache:       cache.NewAttestationCache(),
+		DomainDataCache:        cache.NewDomainDataCache(),
 		AttPool:                s.attestationsPool,
 		ExitPool:               s.exitPool,
 		HeadFetcher:            s.headFetcher,
@@ -238,10 +325,13 @@ func (s *Service) Start() {
 		P2P:                    s.p2p,
 		BlockReceiver:          s.blockReceiver,
 		MockEth1Votes:          s.mockEth1Votes,
+		BlockProposalBudget:    s.blockProposalBudget,
+		AttInclusionPolicy:     s.attInclusionPolicy,
 		Eth1BlockFetcher:       s.powChainService,
 		PendingDepositsFetcher: s.pendingDepositFetcher,
 		SlashingsPool:          s.slashingsPool,
 		StateGen:               s.stateGen,
+		BroadcastProtection:    s.broadcastProtection,
 	}
 	nodeServer := &node.Server{
 		BeaconDB:           s.beaconDB,
@@ -250,6 +340,10 @@ func (s *Service) Start() {
 		GenesisTimeFetcher: s.genesisTimeFetcher,
 		PeersFetcher:       s.peersFetcher,
 		GenesisFetcher:     s.genesisFetcher,
+		ForkFetcher:        s.forkFetcher,
+		IdentityProvider:   s.identityProvider,
+		DepositProcessing:  s.depositProcessing,
+		SyncProgress:       s.syncProgress,
 	}
 	beaconChainServer := &beacon.Server{
 		Ctx:                         s.ctx,
@@ -259,6 +353,7 @@ func (s *Service) Start() {
 		HeadFetcher:                 s.headFetcher,
 		FinalizationFetcher:         s.finalizationFetcher,
 		ParticipationFetcher:        s.participationFetcher,
+		ForkChoiceFetcher:           s.forkChoiceFetcher,
 		ChainStartFetcher:           s.chainStartFetcher,
 		DepositFetcher:              s.depositFetcher,
 		BlockFetcher:                s.powChainService,
@@ -269,19 +364,42 @@ func (s *Service) Start() {
 		AttestationNotifier:         s.operationNotifier,
 		Broadcaster:                 s.p2p,
 		StateGen:                    s.stateGen,
+		DatabaseBackuper:            s.databaseBackuper,
+		DatabaseCompacter:           s.databaseCompacter,
+		ChainHeadCache:              cache.NewChainHeadCache(),
+		BackupOutputDir:             s.backupOutputDir,
+		Maintenance:                 s.maintenance,
 		ReceivedAttestationsBuffer:  make(chan *ethpb.Attestation, 100),
 		CollectedAttestationsBuffer: make(chan []*ethpb.Attestation, 100),
 	}
 	ethpb.RegisterNodeServer(s.grpcServer, nodeServer)
+	pbrpc.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpb.RegisterBeaconChainServer(s.grpcServer, beaconChainServer)
+	pbrpc.RegisterHealthServer(s.grpcServer, beaconChainServer)
+	pbrpc.RegisterValidatorsServer(s.grpcServer, beaconChainServer)
+	pbrpc.RegisterAttestationsServer(s.grpcServer, beaconChainServer)
 	if s.enableDebugRPCEndpoints {
 		log.Info("Enabled debug RPC endpoints")
 		pbrpc.RegisterDebugServer(s.grpcServer, beaconChainServer)
 	}
+	if s.enableLightClientServer {
+		log.Info("Enabled light client RPC endpoints")
+		pbrpc.RegisterLightClientServer(s.grpcServer, beaconChainServer)
+	}
 	ethpb.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
+	pbrpc.RegisterDutiesServer(s.grpcServer, validatorServer)
+
+	// Register the standard gRPC health service, reporting whether each service
+	// is ready to serve requests.
+	s.healthServer = health.NewServer()
+	healthpb.RegisterHealthServer(s.grpcServer, s.healthServer)
+	s.updateHealthStatus()
+	go s.healthStatusRoutine()
 
 	// Register reflection service on gRPC server.
-	reflection.Register(s.grpcServer)
+	if !s.disableReflection {
+		reflection.Register(s.grpcServer)
+	}
 
 	go func() {
 		if s.listener != nil {
@@ -295,6 +413,40 @@ func (s *Service) Start() {
 	}
 }
 
+// updateHealthStatus reports every registered service as serving, except for those
+// which require a synced node while initial sync is still in progress. The overall
+// server status, queried with an empty service name, follows the sync status.
+func (s *Service) updateHealthStatus() {
+	syncing := s.syncService != nil && s.syncService.Syncing()
+	for svc := range s.grpcServer.GetServiceInfo() {
+		if syncing && servicesRequiringSync[svc] || s.maintenance.Enabled() && servicesRejectedInMaintenance[svc] {
+			s.healthServer.SetServingStatus(svc, healthpb.HealthCheckResponse_NOT_SERVING)
+			continue
+		}
+		s.healthServer.SetServingStatus(svc, healthpb.HealthCheckResponse_SERVING)
+	}
+	if syncing {
+		s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+	s.healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+}
+
+// healthStatusRoutine refreshes the health status of the gRPC services once per
+// slot until the service is stopped.
+func (s *Service) healthStatusRoutine() {
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.updateHealthStatus()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
 func (s *Service) startSlasherClient() {
 	var dialOpt grpc.DialOption
 	if s.slasherCert != "" {
@@ -333,6 +485,9 @@ func (s *Service) startSlasherClient() {
 // Stop the service.
 func (s *Service) Stop() error {
 	s.cancel()
+	if s.healthServer != nil {
+		s.healthServer.Shutdown()
+	}
 	if s.listener != nil {
 		s.grpcServer.GracefulStop()
 		log.Debug("Initiated graceful stop of gRPC server")
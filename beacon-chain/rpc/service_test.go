@@ -8,12 +8,18 @@ import (
 	"testing"
 	"time"
 
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	mockPOW "github.com/prysmaticlabs/prysm/beacon-chain/powchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beacon"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/node"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/sirupsen/logrus"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func init() {
@@ -80,3 +86,35 @@ func TestRPC_InsecureEndpoint(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestHealthStatus_FollowsSyncStatus(t *testing.T) {
+	syncChecker := &mockSync.Sync{IsSyncing: true}
+	s := &Service{
+		syncService:  syncChecker,
+		grpcServer:   grpc.NewServer(),
+		healthServer: health.NewServer(),
+	}
+	ethpb.RegisterNodeServer(s.grpcServer, &node.Server{})
+	ethpb.RegisterBeaconChainServer(s.grpcServer, &beacon.Server{})
+
+	checkStatus := func(svc string, want healthpb.HealthCheckResponse_ServingStatus) {
+		res, err := s.healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: svc})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Status != want {
+			t.Errorf("Wanted status %v for service %q, received %v", want, svc, res.Status)
+		}
+	}
+
+	s.updateHealthStatus()
+	checkStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	checkStatus("ethereum.eth.v1alpha1.Node", healthpb.HealthCheckResponse_SERVING)
+	checkStatus("ethereum.eth.v1alpha1.BeaconChain", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	syncChecker.IsSyncing = false
+	s.updateHealthStatus()
+	checkStatus("", healthpb.HealthCheckResponse_SERVING)
+	checkStatus("ethereum.eth.v1alpha1.Node", healthpb.HealthCheckResponse_SERVING)
+	checkStatus("ethereum.eth.v1alpha1.BeaconChain", healthpb.HealthCheckResponse_SERVING)
+}
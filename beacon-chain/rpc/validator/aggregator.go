@@ -24,12 +24,7 @@ func (as *Server) SubmitAggregateSelectionProof(ctx context.Context, req *ethpb.
 		return nil, status.Errorf(codes.Unavailable, "Syncing to latest head, not ready to respond")
 	}
 
-	st, err := as.HeadFetcher.HeadState(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not determine head state: %v", err)
-	}
-
-	validatorIndex, exists := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(req.PublicKey))
+	validatorIndex, exists := as.HeadFetcher.HeadValidatorIndex(bytesutil.ToBytes48(req.PublicKey))
 	if !exists {
 		return nil, status.Error(codes.Internal, "Could not locate validator index in DB")
 	}
@@ -60,18 +55,11 @@ func (as *Server) SubmitAggregateSelectionProof(ctx context.Context, req *ethpb.
 	if err := as.AttPool.AggregateUnaggregatedAttestations(); err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not aggregate unaggregated attestations")
 	}
-	aggregatedAtts := as.AttPool.AggregatedAttestationsBySlotIndex(req.Slot, req.CommitteeIndex)
-
-	// Filter out the best aggregated attestation (ie. the one with the most aggregated bits).
-	if len(aggregatedAtts) == 0 {
+	// Serve the best aggregated attestation (ie. the one with the most aggregated bits).
+	best := as.AttPool.BestAggregatedAttestationBySlotIndex(req.Slot, req.CommitteeIndex)
+	if best == nil {
 		return nil, status.Error(codes.Internal, "No aggregated attestation in beacon node")
 	}
-	best := aggregatedAtts[0]
-	for _, aggregatedAtt := range aggregatedAtts[1:] {
-		if aggregatedAtt.AggregationBits.Count() > best.AggregationBits.Count() {
-			best = aggregatedAtt
-		}
-	}
 
 	a := &ethpb.AggregateAttestationAndProof{
 		Aggregate:       best,
@@ -93,6 +81,14 @@ func (as *Server) SubmitSignedAggregateSelectionProof(ctx context.Context, req *
 		return nil, status.Errorf(codes.Internal, "Could not broadcast signed aggregated attestation: %v", err)
 	}
 
+	// Our own broadcast is not received back over gossip, so track the aggregate in the pool
+	// for other aggregators and proposers of this node.
+	if aggregate := req.SignedAggregateAndProof.Message.Aggregate; helpers.IsAggregated(aggregate) {
+		if err := as.AttPool.SaveAggregatedAttestation(aggregate); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not save signed aggregated attestation: %v", err)
+		}
+	}
+
 	log.WithFields(logrus.Fields{
 		"slot":            req.SignedAggregateAndProof.Message.Aggregate.Data.Slot,
 		"committeeIndex":  req.SignedAggregateAndProof.Message.Aggregate.Data.CommitteeIndex,
@@ -218,6 +218,43 @@ func TestSubmitAggregateAndProof_AggregateNotOk(t *testing.T) {
 	}
 }
 
+func TestSubmitSignedAggregateSelectionProof_SavesAggregate(t *testing.T) {
+	ctx := context.Background()
+	aggregatorServer := &Server{
+		AttPool: attestations.NewPool(),
+		P2P:     &mockp2p.MockBroadcaster{},
+	}
+
+	att := &ethpb.Attestation{
+		Data:            &ethpb.AttestationData{Slot: 1, CommitteeIndex: 2},
+		AggregationBits: bitfield.Bitlist{0b1101},
+	}
+	req := &ethpb.SignedAggregateSubmitRequest{
+		SignedAggregateAndProof: &ethpb.SignedAggregateAttestationAndProof{
+			Message: &ethpb.AggregateAttestationAndProof{Aggregate: att},
+		},
+	}
+	if _, err := aggregatorServer.SubmitSignedAggregateSelectionProof(ctx, req); err != nil {
+		t.Fatal(err)
+	}
+	if !aggregatorServer.P2P.(*mockp2p.MockBroadcaster).BroadcastCalled {
+		t.Error("Expected the aggregate to be broadcast")
+	}
+	best := aggregatorServer.AttPool.BestAggregatedAttestationBySlotIndex(1, 2)
+	if !reflect.DeepEqual(best, att) {
+		t.Errorf("Wanted best aggregate %v, received %v", att, best)
+	}
+}
+
+func TestSubmitSignedAggregateSelectionProof_NilRequest(t *testing.T) {
+	aggregatorServer := &Server{}
+	req := &ethpb.SignedAggregateSubmitRequest{}
+	wanted := "Signed aggregate request can't be nil"
+	if _, err := aggregatorServer.SubmitSignedAggregateSelectionProof(context.Background(), req); err == nil || !strings.Contains(err.Error(), wanted) {
+		t.Errorf("Expected error %q, received %v", wanted, err)
+	}
+}
+
 func generateAtt(state *beaconstate.BeaconState, index uint64, privKeys []*bls.SecretKey) (*ethpb.Attestation, error) {
 	aggBits := bitfield.NewBitlist(4)
 	aggBits.SetBitAt(index, true)
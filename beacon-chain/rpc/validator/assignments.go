@@ -58,6 +58,7 @@ func (vs *Server) GetDuties(ctx context.Context, req *ethpb.DutiesRequest) (*eth
 
 		idx, ok := s.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubKey))
 		if ok {
+			vs.ownValidators.add(idx)
 			assignment.ValidatorIndex = idx
 			assignment.Status = vs.assignmentStatus(idx, s)
 			assignment.ProposerSlots = proposerIndexToSlots[idx]
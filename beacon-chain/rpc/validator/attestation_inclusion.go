@@ -0,0 +1,111 @@
+package validator
+
+import (
+	"sort"
+	"sync"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// AttestationInclusionPolicy configures which of the attestations in the pool are packed into
+// the blocks proposed through this beacon node, and in which order.
+type AttestationInclusionPolicy struct {
+	// MinProposerReward is the minimum estimated proposer reward, in Gwei, for including an attestation.
+	MinProposerReward uint64
+	// ExcludedCommittees are the committee indices whose attestations are never included.
+	ExcludedCommittees map[uint64]bool
+	// PreferOwnValidators packs the attestations of the validators which requested their duties
+	// from this beacon node first.
+	PreferOwnValidators bool
+}
+
+// ownValidatorSet holds the indices of the validators which requested their duties from this
+// beacon node.
+type ownValidatorSet struct {
+	indices sync.Map
+}
+
+func (s *ownValidatorSet) add(idx uint64) {
+	s.indices.Store(idx, true)
+}
+
+func (s *ownValidatorSet) contains(idx uint64) bool {
+	_, ok := s.indices.Load(idx)
+	return ok
+}
+
+// applyAttestationInclusionPolicy drops the attestations excluded by the inclusion policy, and
+// orders the remaining ones for packing. Attestations whose committee can not be computed are
+// kept, so they are pruned from the pool by the block inclusion filter.
+func (vs *Server) applyAttestationInclusionPolicy(st *stateTrie.BeaconState, atts []*ethpb.Attestation) ([]*ethpb.Attestation, error) {
+	policy := vs.AttInclusionPolicy
+	if policy == nil || len(atts) == 0 {
+		return atts, nil
+	}
+	needsCommittees := policy.MinProposerReward > 0 || policy.PreferOwnValidators
+	var sqrtTotalBalance uint64
+	if needsCommittees {
+		totalBalance, err := helpers.TotalActiveBalance(st)
+		if err != nil {
+			return nil, err
+		}
+		sqrtTotalBalance = mathutil.IntegerSquareRoot(totalBalance)
+	}
+
+	included := make([]*ethpb.Attestation, 0, len(atts))
+	own := make(map[*ethpb.Attestation]bool)
+	for _, att := range atts {
+		if att == nil || att.Data == nil {
+			continue
+		}
+		if policy.ExcludedCommittees[att.Data.CommitteeIndex] {
+			continue
+		}
+		if !needsCommittees {
+			included = append(included, att)
+			continue
+		}
+		committee, err := helpers.BeaconCommitteeFromState(st, att.Data.Slot, att.Data.CommitteeIndex)
+		if err != nil {
+			included = append(included, att)
+			continue
+		}
+		var reward uint64
+		for _, idx := range attestationutil.AttestingIndices(att.AggregationBits, committee) {
+			if vs.ownValidators.contains(idx) {
+				own[att] = true
+			}
+			val, err := st.ValidatorAtIndexReadOnly(idx)
+			if err != nil {
+				return nil, err
+			}
+			reward += proposerRewardForAttester(val.EffectiveBalance(), sqrtTotalBalance)
+		}
+		if reward < policy.MinProposerReward {
+			continue
+		}
+		included = append(included, att)
+	}
+	if policy.PreferOwnValidators {
+		sort.SliceStable(included, func(i, j int) bool {
+			return own[included[i]] && !own[included[j]]
+		})
+	}
+	return included, nil
+}
+
+// proposerRewardForAttester is the reward of the proposer including the attestation of a
+// validator with the given effective balance.
+func proposerRewardForAttester(effectiveBalance uint64, sqrtTotalBalance uint64) uint64 {
+	if sqrtTotalBalance == 0 {
+		return 0
+	}
+	baseReward := effectiveBalance * params.BeaconConfig().BaseRewardFactor /
+		sqrtTotalBalance / params.BeaconConfig().BaseRewardsPerEpoch
+	return baseReward / params.BeaconConfig().ProposerRewardQuotient
+}
@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestApplyAttestationInclusionPolicy(t *testing.T) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	committee, err := helpers.BeaconCommitteeFromState(beaconState, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(committee) < 2 {
+		t.Fatalf("Wanted a committee of at least 2 validators, received %d", len(committee))
+	}
+	newAtt := func(slot uint64, committeeIndex uint64, bit uint64) *ethpb.Attestation {
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		bits.SetBitAt(bit, true)
+		return &ethpb.Attestation{
+			Data:            &ethpb.AttestationData{Slot: slot, CommitteeIndex: committeeIndex},
+			AggregationBits: bits,
+		}
+	}
+	att0 := newAtt(1, 0, 0)
+	att1 := newAtt(1, 0, 1)
+	atts := []*ethpb.Attestation{att0, att1}
+
+	vs := &Server{}
+	res, err := vs.applyAttestationInclusionPolicy(beaconState, atts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 {
+		t.Errorf("Wanted every attestation without a policy, received %d", len(res))
+	}
+
+	vs.AttInclusionPolicy = &AttestationInclusionPolicy{ExcludedCommittees: map[uint64]bool{0: true}}
+	res, err = vs.applyAttestationInclusionPolicy(beaconState, atts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 0 {
+		t.Errorf("Wanted attestations of excluded committees to be left out, received %d", len(res))
+	}
+
+	vs.AttInclusionPolicy = &AttestationInclusionPolicy{MinProposerReward: 1 << 40}
+	res, err = vs.applyAttestationInclusionPolicy(beaconState, atts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 0 {
+		t.Errorf("Wanted attestations below the minimum reward to be left out, received %d", len(res))
+	}
+
+	vs.AttInclusionPolicy = &AttestationInclusionPolicy{PreferOwnValidators: true}
+	vs.ownValidators.add(committee[1])
+	res, err = vs.applyAttestationInclusionPolicy(beaconState, atts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 || res[0] != att1 || res[1] != att0 {
+		t.Errorf("Wanted the attestation of an own validator to be packed first, received %v", res)
+	}
+}
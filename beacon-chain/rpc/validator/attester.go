@@ -4,8 +4,10 @@ import (
 	"context"
 
 	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/broadcastprotection"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
@@ -145,6 +147,14 @@ func (vs *Server) ProposeAttestation(ctx context.Context, att *ethpb.Attestation
 	if _, err := bls.SignatureFromBytes(att.Signature); err != nil {
 		return nil, status.Error(codes.InvalidArgument, "Incorrect attestation signature")
 	}
+	if vs.BroadcastProtection != nil {
+		if err := vs.BroadcastProtection.CheckAttestation(ctx, att); err != nil {
+			if errors.Cause(err) == broadcastprotection.ErrSlashable {
+				return nil, status.Errorf(codes.FailedPrecondition, "Refusing to broadcast attestation: %v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "Could not check attestation for conflicts: %v", err)
+		}
+	}
 
 	root, err := ssz.HashTreeRoot(att.Data)
 	if err != nil {
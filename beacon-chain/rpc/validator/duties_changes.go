@@ -0,0 +1,179 @@
+package validator
+
+import (
+	"bytes"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dutyAssignment is the committee assignment and proposer duties of a validator in an epoch.
+type dutyAssignment struct {
+	assigned       bool
+	attesterSlot   uint64
+	committeeIndex uint64
+	proposerSlots  []uint64
+}
+
+func (a *dutyAssignment) equal(b *dutyAssignment) bool {
+	if a.assigned != b.assigned || a.attesterSlot != b.attesterSlot || a.committeeIndex != b.committeeIndex {
+		return false
+	}
+	if len(a.proposerSlots) != len(b.proposerSlots) {
+		return false
+	}
+	for i := range a.proposerSlots {
+		if a.proposerSlots[i] != b.proposerSlots[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// StreamDutiesChanges notifies the client whenever the committee assignments or proposer duties
+// of the requested validators change in the current or next epoch. The duties are recomputed from
+// the head state on every head change and compared with the duties previously computed for the
+// stream, so the first computation of an epoch's duties is never sent as a change.
+func (vs *Server) StreamDutiesChanges(req *pbrpc.DutiesChangesRequest, stream pbrpc.Duties_StreamDutiesChangesServer) error {
+	if len(req.PublicKeys) == 0 {
+		return status.Error(codes.InvalidArgument, "No public keys requested")
+	}
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := vs.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+
+	tracker := newDutiesChangesTracker(req.PublicKeys)
+	var lastHeadRoot []byte
+	checkDuties := func() error {
+		ctx := stream.Context()
+		headRoot, err := vs.HeadFetcher.HeadRoot(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not get head root: %v", err)
+		}
+		if bytes.Equal(headRoot, lastHeadRoot) {
+			return nil
+		}
+		lastHeadRoot = headRoot
+		s, err := vs.HeadFetcher.HeadState(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not get head state: %v", err)
+		}
+		epoch := helpers.SlotToEpoch(vs.GenesisTimeFetcher.CurrentSlot())
+		// Advance state with empty transitions up to the current epoch start slot.
+		if epochStartSlot := helpers.StartSlot(epoch); s.Slot() < epochStartSlot {
+			s, err = state.ProcessSlots(ctx, s, epochStartSlot)
+			if err != nil {
+				return status.Errorf(codes.Internal, "Could not process slots up to %d: %v", epochStartSlot, err)
+			}
+		}
+		events, err := tracker.update(s, epoch, headRoot)
+		if err != nil {
+			return status.Errorf(codes.Internal, "Could not compute committee assignments: %v", err)
+		}
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		}
+		return nil
+	}
+
+	if err := checkDuties(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case event := <-stateChannel:
+			if event.Type != statefeed.BlockProcessed {
+				continue
+			}
+			if err := checkDuties(); err != nil {
+				return err
+			}
+		case <-stateSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-vs.Ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// dutiesChangesTracker keeps the duty assignments of a set of validators in the current and
+// next epochs, as last computed for a duties changes stream.
+type dutiesChangesTracker struct {
+	pubKeys [][]byte
+	duties  map[uint64]map[[48]byte]*dutyAssignment
+}
+
+func newDutiesChangesTracker(pubKeys [][]byte) *dutiesChangesTracker {
+	return &dutiesChangesTracker{
+		pubKeys: pubKeys,
+		duties:  make(map[uint64]map[[48]byte]*dutyAssignment),
+	}
+}
+
+// update recomputes the duty assignments in the given and next epoch from the state, returning
+// an event for each epoch in which the duties of some validators differ from the last computation.
+func (t *dutiesChangesTracker) update(s *stateTrie.BeaconState, epoch uint64, headRoot []byte) ([]*pbrpc.DutiesChangedEvent, error) {
+	var events []*pbrpc.DutiesChangedEvent
+	duties := make(map[uint64]map[[48]byte]*dutyAssignment, 2)
+	for _, e := range []uint64{epoch, epoch + 1} {
+		assignments, err := dutyAssignments(s, e, t.pubKeys)
+		if err != nil {
+			return nil, err
+		}
+		duties[e] = assignments
+		previous, ok := t.duties[e]
+		if !ok {
+			continue
+		}
+		var changed [][]byte
+		for _, pubKey := range t.pubKeys {
+			key := bytesutil.ToBytes48(pubKey)
+			if !assignments[key].equal(previous[key]) {
+				changed = append(changed, pubKey)
+			}
+		}
+		if len(changed) > 0 {
+			events = append(events, &pbrpc.DutiesChangedEvent{
+				Epoch:         e,
+				PublicKeys:    changed,
+				HeadBlockRoot: headRoot,
+			})
+		}
+	}
+	t.duties = duties
+	return events, nil
+}
+
+// dutyAssignments computes the duty assignments of the given validators in the epoch.
+func dutyAssignments(s *stateTrie.BeaconState, epoch uint64, pubKeys [][]byte) (map[[48]byte]*dutyAssignment, error) {
+	committeeAssignments, proposerIndexToSlots, err := helpers.CommitteeAssignments(s, epoch)
+	if err != nil {
+		return nil, err
+	}
+	assignments := make(map[[48]byte]*dutyAssignment, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		key := bytesutil.ToBytes48(pubKey)
+		assignment := &dutyAssignment{}
+		if idx, ok := s.ValidatorIndexByPubkey(key); ok {
+			assignment.proposerSlots = proposerIndexToSlots[idx]
+			if ca, ok := committeeAssignments[idx]; ok {
+				assignment.assigned = true
+				assignment.attesterSlot = ca.AttesterSlot
+				assignment.committeeIndex = ca.CommitteeIndex
+			}
+		}
+		assignments[key] = assignment
+	}
+	return assignments, nil
+}
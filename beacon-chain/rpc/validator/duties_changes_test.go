@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestDutiesChangesTracker_Update(t *testing.T) {
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+	key0 := beaconState.PubkeyAtIndex(0)
+	key1 := beaconState.PubkeyAtIndex(1)
+	pubKeys := [][]byte{key0[:], key1[:], pubKey(99999)}
+	tracker := newDutiesChangesTracker(pubKeys)
+
+	events, err := tracker.update(beaconState, 0, []byte{'a'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Wanted no events for the first computation of the duties, received %v", events)
+	}
+	events, err = tracker.update(beaconState, 0, []byte{'b'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Wanted no events for unchanged duties, received %v", events)
+	}
+
+	// Changing the randao mixes changes the seeds, and with them the assignments of the validators.
+	reorgState := beaconState.Copy()
+	mixes := reorgState.RandaoMixes()
+	for i := range mixes {
+		mixes[i] = bytes.Repeat([]byte{'c'}, 32)
+	}
+	if err := reorgState.SetRandaoMixes(mixes); err != nil {
+		t.Fatal(err)
+	}
+	events, err = tracker.update(reorgState, 0, []byte{'c'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 {
+		t.Fatal("Wanted events for changed duties")
+	}
+	for _, event := range events {
+		if event.Epoch > 1 {
+			t.Errorf("Wanted events for epochs 0 and 1, received epoch %d", event.Epoch)
+		}
+		if !bytes.Equal(event.HeadBlockRoot, []byte{'c'}) {
+			t.Errorf("Wanted head block root %#x, received %#x", []byte{'c'}, event.HeadBlockRoot)
+		}
+		for _, key := range event.PublicKeys {
+			if bytes.Equal(key, pubKeys[2]) {
+				t.Error("Wanted no changes for an unknown validator")
+			}
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/broadcastprotection"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
@@ -22,6 +23,8 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/prysmaticlabs/prysm/shared/trieutil"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
@@ -49,7 +52,13 @@ func (vs *Server) GetBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not retrieve head root: %v", err)
 	}
-	eth1Data, err := vs.eth1Data(ctx, req.Slot)
+
+	// ETH1 data and attestations which are not ready once the proposal budget of the slot
+	// runs out are left out of the block, rather than missing the proposal entirely.
+	budgetCtx, cancel := vs.proposalBudgetContext(ctx, req.Slot)
+	defer cancel()
+
+	eth1Data, err := vs.eth1DataWithinBudget(ctx, budgetCtx, req.Slot)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not get ETH1 data: %v", err)
 	}
@@ -61,9 +70,13 @@ func (vs *Server) GetBlock(ctx context.Context, req *ethpb.BlockRequest) (*ethpb
 	}
 
 	// Pack aggregated attestations which have not been included in the beacon chain.
-	atts, err := vs.packAttestations(ctx, req.Slot)
+	atts, err := vs.packAttestations(budgetCtx, req.Slot)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not get attestations to pack into block: %v", err)
+		if budgetCtx.Err() == nil {
+			return nil, status.Errorf(codes.Internal, "Could not get attestations to pack into block: %v", err)
+		}
+		log.WithField("slot", req.Slot).Warn("Attestation packing exceeded the block proposal budget, proposing without attestations")
+		atts = []*ethpb.Attestation{}
 	}
 
 	// Use zero hash as stub for state root to compute later.
@@ -120,6 +133,14 @@ func (vs *Server) ProposeBlock(ctx context.Context, blk *ethpb.SignedBeaconBlock
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not tree hash block: %v", err)
 	}
+	if vs.BroadcastProtection != nil {
+		if err := vs.BroadcastProtection.CheckBlock(ctx, blk.Block); err != nil {
+			if errors.Cause(err) == broadcastprotection.ErrSlashable {
+				return nil, status.Errorf(codes.FailedPrecondition, "Refusing to broadcast block: %v", err)
+			}
+			return nil, status.Errorf(codes.Internal, "Could not check block for conflicts: %v", err)
+		}
+	}
 	log.WithField("blockRoot", fmt.Sprintf("%#x", bytesutil.Trunc(root[:]))).Debugf(
 		"Block proposal received via RPC")
 	vs.BlockNotifier.BlockFeed().Send(&feed.Event{
@@ -176,6 +197,50 @@ func (vs *Server) eth1Data(ctx context.Context, slot uint64) (*ethpb.Eth1Data, e
 	return eth1Data, nil
 }
 
+// proposalBudgetContext returns a context which is done once the block proposal budget of the
+// slot is exhausted. The budget is measured from the start of the slot, or from now if the
+// proposal is requested after the slot started.
+func (vs *Server) proposalBudgetContext(ctx context.Context, slot uint64) (context.Context, context.CancelFunc) {
+	if vs.BlockProposalBudget <= 0 || vs.GenesisTimeFetcher == nil {
+		return context.WithCancel(ctx)
+	}
+	start := slotutil.SlotStartTime(uint64(vs.GenesisTimeFetcher.GenesisTime().Unix()), slot)
+	if now := roughtime.Now(); now.After(start) {
+		start = now
+	}
+	return context.WithDeadline(ctx, start.Add(vs.BlockProposalBudget))
+}
+
+// eth1DataWithinBudget retrieves the ETH1 data vote of the block proposal. If the retrieval does
+// not complete before the budget context is done, the proposer votes for the ETH1 data already in
+// the head state, which leaves the ETH1 data of the chain unchanged.
+func (vs *Server) eth1DataWithinBudget(ctx context.Context, budgetCtx context.Context, slot uint64) (*ethpb.Eth1Data, error) {
+	type eth1DataResult struct {
+		eth1Data *ethpb.Eth1Data
+		err      error
+	}
+	result := make(chan eth1DataResult, 1)
+	go func() {
+		eth1Data, err := vs.eth1Data(budgetCtx, slot)
+		result <- eth1DataResult{eth1Data: eth1Data, err: err}
+	}()
+
+	select {
+	case res := <-result:
+		if budgetCtx.Err() == nil {
+			return res.eth1Data, res.err
+		}
+	case <-budgetCtx.Done():
+	}
+
+	log.WithField("slot", slot).Warn("ETH1 data retrieval exceeded the block proposal budget, voting for the current ETH1 data")
+	headState, err := vs.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get head state")
+	}
+	return headState.Eth1Data(), nil
+}
+
 func (vs *Server) mockETH1DataVote(ctx context.Context, slot uint64) (*ethpb.Eth1Data, error) {
 	if !eth1DataNotification {
 		log.Warn("Beacon Node is no longer connected to an ETH1 chain, so ETH1 data votes are now mocked.")
@@ -393,6 +458,10 @@ func (vs *Server) filterAttestationsForBlockInclusion(ctx context.Context, state
 		if i == int(params.BeaconConfig().MaxAttestations) {
 			break
 		}
+		// Out of time, propose with the attestations checked so far.
+		if ctx.Err() != nil {
+			break
+		}
 
 		if _, err := blocks.ProcessAttestation(ctx, state, att); err != nil {
 			inValidAtts = append(inValidAtts, att)
@@ -452,7 +521,10 @@ func (vs *Server) packAttestations(ctx context.Context, slot uint64) ([]*ethpb.A
 		}
 	}
 
-	atts := vs.AttPool.AggregatedAttestations()
+	atts, err := vs.applyAttestationInclusionPolicy(st, vs.AttPool.AggregatedAttestations())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not apply attestation inclusion policy")
+	}
 	atts, err = vs.filterAttestationsForBlockInclusion(ctx, st, atts)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not filter attestations")
@@ -460,7 +532,10 @@ func (vs *Server) packAttestations(ctx context.Context, slot uint64) ([]*ethpb.A
 
 	// If there is any room left in the block, consider unaggregated attestations as well.
 	if len(atts) < int(params.BeaconConfig().MaxAttestations) {
-		uAtts := vs.AttPool.UnaggregatedAttestations()
+		uAtts, err := vs.applyAttestationInclusionPolicy(st, vs.AttPool.UnaggregatedAttestations())
+		if err != nil {
+			return nil, errors.Wrap(err, "could not apply attestation inclusion policy")
+		}
 		uAtts, err = vs.filterAttestationsForBlockInclusion(ctx, st, uAtts)
 		if len(uAtts)+len(atts) > int(params.BeaconConfig().MaxAttestations) {
 			uAtts = uAtts[:int(params.BeaconConfig().MaxAttestations)-len(atts)]
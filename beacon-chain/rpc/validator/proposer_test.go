@@ -6,12 +6,14 @@ import (
 	"math/big"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/go-ssz"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/broadcastprotection"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	b "github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
@@ -33,6 +35,8 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/shared/trieutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -322,6 +326,50 @@ func TestProposeBlock_OK(t *testing.T) {
 	}
 }
 
+func TestProposeBlock_RefusesDoubleProposalOfProtectedValidator(t *testing.T) {
+	db := dbutil.SetupDB(t)
+	defer dbutil.TeardownDB(t, db)
+	ctx := context.Background()
+
+	c := &mock.ChainService{}
+	protection, err := broadcastprotection.NewService(ctx, &broadcastprotection.Config{
+		Validators:  []string{"3"},
+		HeadFetcher: c,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proposerServer := &Server{
+		BeaconDB:            db,
+		BlockReceiver:       c,
+		HeadFetcher:         c,
+		BlockNotifier:       c.BlockNotifier(),
+		BroadcastProtection: protection,
+	}
+	blk := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:          5,
+			ProposerIndex: 3,
+			ParentRoot:    bytesutil.PadTo([]byte("parent-hash"), 32),
+			Body:          &ethpb.BeaconBlockBody{},
+		},
+	}
+	if _, err := proposerServer.ProposeBlock(ctx, blk); err != nil {
+		t.Fatalf("Could not propose block correctly: %v", err)
+	}
+	conflicting := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:          5,
+			ProposerIndex: 3,
+			ParentRoot:    bytesutil.PadTo([]byte("other-parent-hash"), 32),
+			Body:          &ethpb.BeaconBlockBody{},
+		},
+	}
+	if _, err := proposerServer.ProposeBlock(ctx, conflicting); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Expected conflicting block to be refused, received %v", err)
+	}
+}
+
 func TestComputeStateRoot_OK(t *testing.T) {
 	db := dbutil.SetupDB(t)
 	defer dbutil.TeardownDB(t, db)
@@ -1612,3 +1660,58 @@ func TestDeleteAttsInPool_Aggregated(t *testing.T) {
 		t.Error("Did not delete unaggregated attestation")
 	}
 }
+
+func TestEth1DataWithinBudget_FallsBackToHeadEth1Data(t *testing.T) {
+	headState := testutil.NewBeaconState()
+	headEth1Data := &ethpb.Eth1Data{
+		DepositRoot:  bytesutil.PadTo([]byte("root"), 32),
+		BlockHash:    bytesutil.PadTo([]byte("hash"), 32),
+		DepositCount: 8,
+	}
+	if err := headState.SetEth1Data(headEth1Data); err != nil {
+		t.Fatal(err)
+	}
+	p := &mockPOW.POWChain{}
+	ps := &Server{
+		HeadFetcher:       &mock.ChainService{State: headState},
+		ChainStartFetcher: p,
+		Eth1InfoFetcher:   p,
+		Eth1BlockFetcher:  p,
+	}
+
+	ctx := context.Background()
+	budgetCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	eth1Data, err := ps.eth1DataWithinBudget(ctx, budgetCtx, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(eth1Data, headEth1Data) {
+		t.Errorf("Wanted %v, received %v", headEth1Data, eth1Data)
+	}
+}
+
+func TestProposalBudgetContext(t *testing.T) {
+	// Genesis is in the future so the budget is measured from the start of the slot.
+	genesis := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	ps := &Server{
+		GenesisTimeFetcher: &mock.ChainService{Genesis: genesis},
+	}
+	ctx, cancel := ps.proposalBudgetContext(context.Background(), 1)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected no deadline without a proposal budget")
+	}
+
+	ps.BlockProposalBudget = time.Second
+	ctx, cancel = ps.proposalBudgetContext(context.Background(), 1)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("Expected a deadline with a proposal budget")
+	}
+	want := genesis.Add(time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second + time.Second)
+	if !deadline.Equal(want) {
+		t.Errorf("Wanted deadline %v, received %v", want, deadline)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/broadcastprotection"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
@@ -48,6 +49,7 @@ type Server struct {
 	Ctx                    context.Context
 	BeaconDB               db.NoHeadAccessDatabase
 	AttestationCache       *cache.AttestationCache
+	DomainDataCache        *cache.DomainDataCache
 	HeadFetcher            blockchain.HeadFetcher
 	ForkFetcher            blockchain.ForkFetcher
 	FinalizationFetcher    blockchain.FinalizationFetcher
@@ -67,10 +69,14 @@ type Server struct {
 	ExitPool               *voluntaryexits.Pool
 	BlockReceiver          blockchain.BlockReceiver
 	MockEth1Votes          bool
+	BlockProposalBudget    time.Duration
 	Eth1BlockFetcher       powchain.POWBlockFetcher
 	PendingDepositsFetcher depositcache.PendingDepositsFetcher
 	OperationNotifier      opfeed.Notifier
 	StateGen               *stategen.State
+	AttInclusionPolicy     *AttestationInclusionPolicy
+	BroadcastProtection    *broadcastprotection.Service
+	ownValidators          ownValidatorSet
 }
 
 // WaitForActivation checks if a validator public key exists in the active validator registry of the current
@@ -118,11 +124,7 @@ func (vs *Server) WaitForActivation(req *ethpb.ValidatorActivationRequest, strea
 
 // ValidatorIndex is called by a validator to get its index location in the beacon state.
 func (vs *Server) ValidatorIndex(ctx context.Context, req *ethpb.ValidatorIndexRequest) (*ethpb.ValidatorIndexResponse, error) {
-	st, err := vs.HeadFetcher.HeadState(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not determine head state: %v", err)
-	}
-	index, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(req.PublicKey))
+	index, ok := vs.HeadFetcher.HeadValidatorIndex(bytesutil.ToBytes48(req.PublicKey))
 	if !ok {
 		return nil, status.Errorf(codes.Internal, "Could not find validator index for public key %#x not found", req.PublicKey)
 	}
@@ -132,15 +134,24 @@ func (vs *Server) ValidatorIndex(ctx context.Context, req *ethpb.ValidatorIndexR
 
 // DomainData fetches the current domain version information from the beacon state.
 func (vs *Server) DomainData(ctx context.Context, request *ethpb.DomainRequest) (*ethpb.DomainResponse, error) {
+	if vs.DomainDataCache != nil {
+		if res := vs.DomainDataCache.Get(request); res != nil {
+			return res, nil
+		}
+	}
 	fork := vs.ForkFetcher.CurrentFork()
 	headGenesisValidatorRoot := vs.HeadFetcher.HeadGenesisValidatorRoot()
 	dv, err := helpers.Domain(fork, request.Epoch, bytesutil.ToBytes4(request.Domain), headGenesisValidatorRoot[:])
 	if err != nil {
 		return nil, err
 	}
-	return &ethpb.DomainResponse{
+	res := &ethpb.DomainResponse{
 		SignatureDomain: dv,
-	}, nil
+	}
+	if vs.DomainDataCache != nil {
+		vs.DomainDataCache.Put(request, res)
+	}
+	return res, nil
 }
 
 // CanonicalHead of the current beacon chain. This method is requested on-demand
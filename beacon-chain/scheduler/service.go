@@ -0,0 +1,144 @@
+// Package scheduler defines a service which coordinates background work of the beacon node,
+// such as archival and pruning, with the duties of the validators relying on it.
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "scheduler")
+
+var backgroundWorkDelayed = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "background_work_delayed_total",
+	Help: "The number of times background work was delayed until the end of the slot's duty window.",
+}, []string{"task"})
+
+// Service delays background work while the duty window of a slot is in progress. Attestations
+// are produced at the end of the first third of a slot and blocks are proposed at its start, so
+// background IO during that window competes with the validator duties served by the node.
+type Service struct {
+	ctx           context.Context
+	cancel        context.CancelFunc
+	stateNotifier statefeed.Notifier
+	genesisLock   sync.RWMutex
+	genesisTime   time.Time
+}
+
+// Config options for the scheduler service.
+type Config struct {
+	StateNotifier statefeed.Notifier
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:           ctx,
+		cancel:        cancel,
+		stateNotifier: cfg.StateNotifier,
+	}
+}
+
+// Start the scheduler service. Background work is only delayed once the node is synced, so it
+// does not slow down initial sync, and the service must be started before the initial sync
+// service to receive its synced event.
+func (s *Service) Start() {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := s.stateNotifier.StateFeed().Subscribe(stateChannel)
+	go func() {
+		defer stateSub.Unsubscribe()
+		for {
+			select {
+			case event := <-stateChannel:
+				if event.Type != statefeed.Synced {
+					continue
+				}
+				data, ok := event.Data.(*statefeed.SyncedData)
+				if !ok {
+					log.Error("Event feed data is not type *statefeed.SyncedData")
+					continue
+				}
+				s.genesisLock.Lock()
+				s.genesisTime = data.StartTime
+				s.genesisLock.Unlock()
+				return
+			case <-s.ctx.Done():
+				log.Debug("Context closed, exiting goroutine")
+				return
+			case err := <-stateSub.Err():
+				log.WithError(err).Error("Subscription to state notifier failed")
+				return
+			}
+		}
+	}()
+}
+
+// Stop the scheduler service.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status of the scheduler service.
+func (s *Service) Status() error {
+	return nil
+}
+
+// Wait blocks until the duty window of the current slot is over, so the named background task
+// can run without delaying validator duties. It returns immediately if the scheduler is nil or
+// the node is not synced yet, and returns an error if the context is canceled while waiting.
+func (s *Service) Wait(ctx context.Context, task string) error {
+	if s == nil {
+		return nil
+	}
+	s.genesisLock.RLock()
+	genesis := s.genesisTime
+	s.genesisLock.RUnlock()
+	if genesis.IsZero() {
+		return nil
+	}
+	remaining := dutyWindowRemaining(genesis, roughtime.Now())
+	if remaining == 0 {
+		return nil
+	}
+	backgroundWorkDelayed.WithLabelValues(task).Inc()
+	log.WithFields(logrus.Fields{
+		"task":  task,
+		"delay": remaining,
+	}).Trace("Delaying background work until the end of the duty window")
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// dutyWindowRemaining returns the time left in the duty window, the first third of the slot, at
+// the given time. It returns 0 outside of the duty window and before genesis.
+func dutyWindowRemaining(genesis time.Time, now time.Time) time.Duration {
+	if now.Before(genesis) {
+		return 0
+	}
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	window := slotDuration / 3
+	intoSlot := now.Sub(genesis) % slotDuration
+	if intoSlot >= window {
+		return 0
+	}
+	return window - intoSlot
+}
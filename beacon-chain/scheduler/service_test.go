@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestDutyWindowRemaining(t *testing.T) {
+	genesis := time.Unix(1000, 0)
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	window := slotDuration / 3
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Duration
+	}{
+		{
+			name: "before genesis",
+			now:  genesis.Add(-time.Second),
+			want: 0,
+		},
+		{
+			name: "start of slot",
+			now:  genesis.Add(5 * slotDuration),
+			want: window,
+		},
+		{
+			name: "inside duty window",
+			now:  genesis.Add(5*slotDuration + time.Second),
+			want: window - time.Second,
+		},
+		{
+			name: "end of duty window",
+			now:  genesis.Add(5*slotDuration + window),
+			want: 0,
+		},
+		{
+			name: "end of slot",
+			now:  genesis.Add(6*slotDuration - time.Second),
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dutyWindowRemaining(genesis, tt.now); got != tt.want {
+				t.Errorf("dutyWindowRemaining() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWait_NotStarted(t *testing.T) {
+	var s *Service
+	if err := s.Wait(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	s = NewService(context.Background(), &Config{})
+	if err := s.Wait(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWait_ContextCanceled(t *testing.T) {
+	s := NewService(context.Background(), &Config{})
+	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	// Set the genesis time so the current slot has just started.
+	s.genesisTime = time.Now().Add(-10 * slotDuration).Add(-time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Wait(ctx, "test"); err != context.Canceled {
+		t.Errorf("Expected context canceled error, received %v", err)
+	}
+}
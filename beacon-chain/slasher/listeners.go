@@ -0,0 +1,174 @@
+package slasher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// run subscribes to the operation and block feeds of the beacon node. Both feeds are
+// consumed from a single loop, so the span updates of the detector are never raced.
+func (s *Service) run(ctx context.Context) {
+	opChannel := make(chan *feed.Event, 1)
+	opSub := s.opNotifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+	blockChannel := make(chan *feed.Event, 1)
+	blockSub := s.blockNotifier.BlockFeed().Subscribe(blockChannel)
+	defer blockSub.Unsubscribe()
+	for {
+		select {
+		case event := <-opChannel:
+			switch event.Type {
+			case opfeed.UnaggregatedAttReceived:
+				data, ok := event.Data.(*opfeed.UnAggregatedAttReceivedData)
+				if !ok || data.Attestation == nil {
+					continue
+				}
+				s.detectAttestation(ctx, data.Attestation)
+			case opfeed.AggregatedAttReceived:
+				data, ok := event.Data.(*opfeed.AggregatedAttReceivedData)
+				if !ok || data.Attestation == nil || data.Attestation.Aggregate == nil {
+					continue
+				}
+				s.detectAttestation(ctx, data.Attestation.Aggregate)
+			}
+		case event := <-blockChannel:
+			if event.Type != blockfeed.ReceivedBlock {
+				continue
+			}
+			data, ok := event.Data.(*blockfeed.ReceivedBlockData)
+			if !ok || data.SignedBlock == nil || data.SignedBlock.Block == nil {
+				continue
+			}
+			s.detectBlock(ctx, data.SignedBlock)
+		case <-s.ctx.Done():
+			log.Debug("Context closed, exiting goroutine")
+			return
+		case err := <-opSub.Err():
+			log.WithError(err).Error("Subscription to operation feed notifier failed")
+			return
+		case err := <-blockSub.Err():
+			log.WithError(err).Error("Subscription to block feed notifier failed")
+			return
+		}
+	}
+}
+
+// detectAttestation converts the attestation into its indexed form and runs double and
+// surround vote detection on it, submitting any slashings found to the slashing pool.
+func (s *Service) detectAttestation(ctx context.Context, att *ethpb.Attestation) {
+	ctx, span := trace.StartSpan(ctx, "slasher.detectAttestation")
+	defer span.End()
+	if att.Data == nil || att.Data.Source == nil || att.Data.Target == nil {
+		return
+	}
+	headState, err := s.headFetcher.HeadState(ctx)
+	if err != nil || headState == nil {
+		log.WithError(err).Error("Head state is not available")
+		return
+	}
+	committee, err := helpers.BeaconCommitteeFromState(headState, att.Data.Slot, att.Data.CommitteeIndex)
+	if err != nil {
+		log.WithError(err).Debug("Could not retrieve committee for attestation")
+		return
+	}
+	indexedAtt := attestationutil.ConvertToIndexed(ctx, att, committee)
+	// The same attestation is commonly seen several times, via gossip and within blocks.
+	seen, err := s.slasherDB.HasIndexedAttestation(ctx, indexedAtt)
+	if err != nil {
+		log.WithError(err).Error("Could not check for indexed attestation in slasher database")
+		return
+	}
+	if seen {
+		return
+	}
+	if err := s.slasherDB.SaveIndexedAttestation(ctx, indexedAtt); err != nil {
+		log.WithError(err).Error("Could not save indexed attestation")
+		return
+	}
+	attSlashings, err := s.detector.DetectAttesterSlashings(ctx, indexedAtt)
+	if err != nil {
+		log.WithError(err).Error("Could not detect attester slashings")
+		return
+	}
+	if len(attSlashings) == 0 {
+		if err := s.detector.UpdateSpans(ctx, indexedAtt); err != nil {
+			log.WithError(err).Error("Could not update spans")
+		}
+		return
+	}
+	for _, slashing := range attSlashings {
+		attesterSlashingsDetected.Inc()
+		log.WithFields(logrus.Fields{
+			"sourceEpoch": slashing.Attestation_1.Data.Source.Epoch,
+			"targetEpoch": slashing.Attestation_1.Data.Target.Epoch,
+		}).Info("Detected attester slashing, inserting into slashing pool")
+		if err := s.slashingPool.InsertAttesterSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Debug("Could not insert attester slashing into pool")
+		}
+	}
+}
+
+// detectBlock runs double proposal detection on the block and attestation detection on
+// every attestation it contains.
+func (s *Service) detectBlock(ctx context.Context, blk *ethpb.SignedBeaconBlock) {
+	ctx, span := trace.StartSpan(ctx, "slasher.detectBlock")
+	defer span.End()
+	header, err := signedBeaconBlockHeaderFromBlock(blk)
+	if err != nil {
+		log.WithError(err).Error("Could not compute block header")
+		return
+	}
+	slashing, err := s.proposalsDetector.DetectDoublePropose(ctx, header)
+	if err != nil {
+		log.WithError(err).Error("Could not detect proposer slashings")
+	}
+	if err := s.slasherDB.SaveBlockHeader(ctx, header); err != nil {
+		log.WithError(err).Error("Could not save block header")
+	}
+	if slashing != nil {
+		proposerSlashingsDetected.Inc()
+		log.WithFields(logrus.Fields{
+			"slot":          header.Header.Slot,
+			"proposerIndex": header.Header.ProposerIndex,
+		}).Info("Detected proposer slashing, inserting into slashing pool")
+		headState, err := s.headFetcher.HeadState(ctx)
+		if err != nil || headState == nil {
+			log.WithError(err).Error("Head state is not available")
+		} else if err := s.slashingPool.InsertProposerSlashing(ctx, headState, slashing); err != nil {
+			log.WithError(err).Debug("Could not insert proposer slashing into pool")
+		}
+	}
+	if blk.Block.Body == nil {
+		return
+	}
+	for _, att := range blk.Block.Body.Attestations {
+		s.detectAttestation(ctx, att)
+	}
+}
+
+func signedBeaconBlockHeaderFromBlock(block *ethpb.SignedBeaconBlock) (*ethpb.SignedBeaconBlockHeader, error) {
+	bodyRoot, err := ssz.HashTreeRoot(block.Block.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute block body root")
+	}
+	return &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          block.Block.Slot,
+			ProposerIndex: block.Block.ProposerIndex,
+			ParentRoot:    block.Block.ParentRoot,
+			StateRoot:     block.Block.StateRoot,
+			BodyRoot:      bodyRoot[:],
+		},
+		Signature: block.Signature,
+	}, nil
+}
@@ -0,0 +1,101 @@
+// Package slasher defines a service which runs slashing detection within the beacon node.
+// Every attestation received via gossip or contained in a received block is checked for
+// double and surround votes, and every received block is checked for double proposals.
+// Detected slashings are inserted directly into the node's slashing pool for inclusion
+// in future blocks, removing the need for a separately deployed slasher.
+package slasher
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	slasherDB "github.com/prysmaticlabs/prysm/slasher/db"
+	"github.com/prysmaticlabs/prysm/slasher/db/kv"
+	"github.com/prysmaticlabs/prysm/slasher/detection"
+	"github.com/prysmaticlabs/prysm/slasher/detection/proposals"
+	proposerIface "github.com/prysmaticlabs/prysm/slasher/detection/proposals/iface"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "slasher")
+
+var (
+	attesterSlashingsDetected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_slasher_attester_slashings_detected_total",
+		Help: "The number of attester slashings detected by the integrated slasher",
+	})
+	proposerSlashingsDetected = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_slasher_proposer_slashings_detected_total",
+		Help: "The number of proposer slashings detected by the integrated slasher",
+	})
+)
+
+// Service running slashing detection on the attestations and blocks received by the
+// beacon node.
+type Service struct {
+	ctx               context.Context
+	cancel            context.CancelFunc
+	slasherDB         slasherDB.Database
+	detector          *detection.Service
+	proposalsDetector proposerIface.ProposalsDetector
+	headFetcher       blockchain.HeadFetcher
+	slashingPool      *slashings.Pool
+	opNotifier        opfeed.Notifier
+	blockNotifier     blockfeed.Notifier
+}
+
+// Config options for the integrated slasher service.
+type Config struct {
+	DatabasePath      string
+	HeadFetcher       blockchain.HeadFetcher
+	SlashingPool      *slashings.Pool
+	OperationNotifier opfeed.Notifier
+	BlockNotifier     blockfeed.Notifier
+}
+
+// NewSlasherService opens the slasher database at the configured path and initializes
+// the service from configuration options.
+func NewSlasherService(ctx context.Context, cfg *Config) (*Service, error) {
+	db, err := slasherDB.NewDB(cfg.DatabasePath, &kv.Config{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open slasher database")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:       ctx,
+		cancel:    cancel,
+		slasherDB: db,
+		detector: detection.NewDetectionService(ctx, &detection.Config{
+			SlasherDB: db,
+		}),
+		proposalsDetector: proposals.NewProposeDetector(db),
+		headFetcher:       cfg.HeadFetcher,
+		slashingPool:      cfg.SlashingPool,
+		opNotifier:        cfg.OperationNotifier,
+		blockNotifier:     cfg.BlockNotifier,
+	}, nil
+}
+
+// Start the slasher service event loop.
+func (s *Service) Start() {
+	log.Info("Starting integrated slasher")
+	go s.run(s.ctx)
+}
+
+// Stop the slasher service event loop and close its database.
+func (s *Service) Stop() error {
+	s.cancel()
+	return s.slasherDB.Close()
+}
+
+// Status reports the healthy status of the slasher. Returning nil means service
+// is correctly running without error.
+func (s *Service) Status() error {
+	return nil
+}
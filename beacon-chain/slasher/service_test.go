@@ -0,0 +1,134 @@
+package slasher
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/slashings"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	status "github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/prysmaticlabs/prysm/slasher/detection"
+	"github.com/prysmaticlabs/prysm/slasher/detection/proposals"
+)
+
+func setupService(t *testing.T) (*Service, func()) {
+	db := testDB.SetupSlasherDB(t, false)
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	ctx := context.Background()
+	s := &Service{
+		ctx:               ctx,
+		slasherDB:         db,
+		detector:          detection.NewDetectionService(ctx, &detection.Config{SlasherDB: db}),
+		proposalsDetector: proposals.NewProposeDetector(db),
+		headFetcher:       &mock.ChainService{State: st},
+		slashingPool:      slashings.NewPool(),
+	}
+	return s, func() {
+		testDB.TeardownSlasherDB(t, db)
+	}
+}
+
+func TestDetectAttestation_DoubleVote(t *testing.T) {
+	s, teardown := setupService(t)
+	defer teardown()
+	ctx := context.Background()
+
+	headState, err := s.headFetcher.HeadState(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	committee, err := helpers.BeaconCommitteeFromState(headState, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bits := bitfield.NewBitlist(uint64(len(committee)))
+	bits.SetBitAt(0, true)
+	att1 := &ethpb.Attestation{
+		AggregationBits: bits,
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: bytesutil.PadTo([]byte{'A'}, 32),
+			Source:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+		},
+		Signature: bytesutil.PadTo([]byte{1, 2}, 96),
+	}
+	att2 := &ethpb.Attestation{
+		AggregationBits: bits,
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: bytesutil.PadTo([]byte{'B'}, 32),
+			Source:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 0, Root: make([]byte, 32)},
+		},
+		Signature: bytesutil.PadTo([]byte{1, 3}, 96),
+	}
+
+	s.detectAttestation(ctx, att1)
+	found, err := s.slasherDB.AttesterSlashings(ctx, status.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 0 {
+		t.Fatalf("Expected no attester slashings, received %d", len(found))
+	}
+
+	s.detectAttestation(ctx, att2)
+	found, err = s.slasherDB.AttesterSlashings(ctx, status.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("Expected 1 attester slashing, received %d", len(found))
+	}
+}
+
+func TestDetectBlock_DoublePropose(t *testing.T) {
+	s, teardown := setupService(t)
+	defer teardown()
+	ctx := context.Background()
+
+	blk1 := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:          1,
+			ProposerIndex: 3,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     bytesutil.PadTo([]byte{'A'}, 32),
+			Body:          &ethpb.BeaconBlockBody{},
+		},
+		Signature: bytesutil.PadTo([]byte{1, 2}, 96),
+	}
+	blk2 := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:          1,
+			ProposerIndex: 3,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     bytesutil.PadTo([]byte{'B'}, 32),
+			Body:          &ethpb.BeaconBlockBody{},
+		},
+		Signature: bytesutil.PadTo([]byte{1, 3}, 96),
+	}
+
+	s.detectBlock(ctx, blk1)
+	s.detectBlock(ctx, blk1)
+	found, err := s.slasherDB.ProposalSlashingsByStatus(ctx, status.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 0 {
+		t.Fatalf("Expected no proposer slashings, received %d", len(found))
+	}
+
+	s.detectBlock(ctx, blk2)
+	found, err = s.slasherDB.ProposalSlashingsByStatus(ctx, status.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 {
+		t.Fatalf("Expected 1 proposer slashing, received %d", len(found))
+	}
+}
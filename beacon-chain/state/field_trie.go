@@ -1,6 +1,7 @@
 package state
 
 import (
+	"encoding/binary"
 	"reflect"
 	"sync"
 
@@ -20,6 +21,9 @@ type FieldTrie struct {
 	*reference
 	fieldLayers [][]*[32]byte
 	field       fieldIndex
+	// length is the number of elements in a packed list, which is
+	// mixed into its root instead of the number of chunks.
+	length uint64
 }
 
 // NewFieldTrie is the constructor for the field trie data structure. It creates the corresponding
@@ -56,6 +60,18 @@ func NewFieldTrie(field fieldIndex, elements interface{}, length uint64) (*Field
 			reference:   &reference{1},
 			Mutex:       new(sync.Mutex),
 		}, nil
+	case packedList:
+		numOfElems, err := packedListLength(field, elements)
+		if err != nil {
+			return nil, err
+		}
+		return &FieldTrie{
+			fieldLayers: stateutil.ReturnTrieLayerVariable(fieldRoots, length),
+			field:       field,
+			reference:   &reference{1},
+			Mutex:       new(sync.Mutex),
+			length:      numOfElems,
+		}, nil
 	default:
 		return nil, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
@@ -76,6 +92,10 @@ func (f *FieldTrie) RecomputeTrie(indices []uint64, elements interface{}) ([32]b
 	if !ok {
 		return [32]byte{}, errors.Errorf("unrecognized field in trie")
 	}
+	if datType == packedList {
+		// Several elements share a chunk, so only each changed chunk is recomputed.
+		indices = chunkIndices(f.field, indices)
+	}
 	fieldRoots, err := fieldConverters(f.field, indices, elements, false)
 	if err != nil {
 		return [32]byte{}, err
@@ -93,6 +113,16 @@ func (f *FieldTrie) RecomputeTrie(indices []uint64, elements interface{}) ([32]b
 			return [32]byte{}, err
 		}
 		return stateutil.AddInMixin(fieldRoot, uint64(len(f.fieldLayers[0])))
+	case packedList:
+		f.length, err = packedListLength(f.field, elements)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		fieldRoot, f.fieldLayers, err = stateutil.RecomputeFromLayerVariable(fieldRoots, indices, f.fieldLayers)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		return stateutil.AddInMixin(fieldRoot, f.length)
 	default:
 		return [32]byte{}, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
@@ -134,6 +164,7 @@ func (f *FieldTrie) CopyTrie() *FieldTrie {
 		field:       f.field,
 		reference:   &reference{1},
 		Mutex:       new(sync.Mutex),
+		length:      f.length,
 	}
 }
 
@@ -149,6 +180,9 @@ func (f *FieldTrie) TrieRoot() ([32]byte, error) {
 	case compositeArray:
 		trieRoot := *f.fieldLayers[len(f.fieldLayers)-1][0]
 		return stateutil.AddInMixin(trieRoot, uint64(len(f.fieldLayers[0])))
+	case packedList:
+		trieRoot := *f.fieldLayers[len(f.fieldLayers)-1][0]
+		return stateutil.AddInMixin(trieRoot, f.length)
 	default:
 		return [32]byte{}, errors.Errorf("unrecognized data type in field map: %v", reflect.TypeOf(datType).Name())
 	}
@@ -185,11 +219,78 @@ func fieldConverters(field fieldIndex, indices []uint64, elements interface{}, c
 				reflect.TypeOf([]*pb.PendingAttestation{}).Name(), reflect.TypeOf(elements).Name())
 		}
 		return handlePendingAttestation(val, indices, convertAll)
+	case balances:
+		val, ok := elements.([]uint64)
+		if !ok {
+			return nil, errors.Errorf("Wanted type of %v but got %v",
+				reflect.TypeOf([]uint64{}).Name(), reflect.TypeOf(elements).Name())
+		}
+		return handleBalanceSlice(val, indices, convertAll)
 	default:
 		return [][32]byte{}, errors.Errorf("got unsupported type of %v", reflect.TypeOf(elements).Name())
 	}
 }
 
+// packedListLength returns the number of elements in the provided packed list.
+func packedListLength(field fieldIndex, elements interface{}) (uint64, error) {
+	switch field {
+	case balances:
+		val, ok := elements.([]uint64)
+		if !ok {
+			return 0, errors.Errorf("Wanted type of %v but got %v",
+				reflect.TypeOf([]uint64{}).Name(), reflect.TypeOf(elements).Name())
+		}
+		return uint64(len(val)), nil
+	default:
+		return 0, errors.Errorf("field %d is not a packed list", field)
+	}
+}
+
+// chunkIndices converts the changed element indices of a packed list into the
+// sorted, de-duplicated indices of the chunks which hold them.
+func chunkIndices(field fieldIndex, indices []uint64) []uint64 {
+	perChunk := uint64(1)
+	if field == balances {
+		perChunk = balancesPerChunk
+	}
+	chunks := make([]uint64, 0, len(indices))
+	for _, idx := range indices {
+		chunk := idx / perChunk
+		// Element indices are sorted, so duplicate chunks are always adjacent.
+		if len(chunks) > 0 && chunks[len(chunks)-1] == chunk {
+			continue
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// balancesPerChunk is the number of 8 byte balances packed into a 32 byte chunk.
+const balancesPerChunk = 4
+
+func handleBalanceSlice(val []uint64, indices []uint64, convertAll bool) ([][32]byte, error) {
+	roots := [][32]byte{}
+	rootCreator := func(chunk uint64) {
+		var newRoot [32]byte
+		start := chunk * balancesPerChunk
+		for i := uint64(0); i < balancesPerChunk && start+i < uint64(len(val)); i++ {
+			binary.LittleEndian.PutUint64(newRoot[i*8:], val[start+i])
+		}
+		roots = append(roots, newRoot)
+	}
+	if convertAll {
+		numOfChunks := (uint64(len(val)) + balancesPerChunk - 1) / balancesPerChunk
+		for i := uint64(0); i < numOfChunks; i++ {
+			rootCreator(i)
+		}
+		return roots, nil
+	}
+	for _, idx := range indices {
+		rootCreator(idx)
+	}
+	return roots, nil
+}
+
 func handleByteArrays(val [][]byte, indices []uint64, convertAll bool) ([][32]byte, error) {
 	roots := [][32]byte{}
 	rootCreater := func(input []byte) {
@@ -104,3 +104,51 @@ func TestFieldTrie_CopyTrieImmutable(t *testing.T) {
 		t.Errorf("Wanted roots to be different, but they are the same: %#x", root)
 	}
 }
+
+func TestFieldTrie_RecomputeBalances(t *testing.T) {
+	newState, _ := testutil.DeterministicGenesisState(t, 30)
+	balLimit := (params.BeaconConfig().ValidatorRegistryLimit*8 + 31) / 32
+	// 12 represents the enum value of balances.
+	trie, err := state.NewFieldTrie(12, newState.Balances(), balLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedRoot, err := stateutil.ValidatorBalancesRoot(newState.Balances())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := trie.TrieRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != expectedRoot {
+		t.Errorf("Wanted root of %#x but got %#x", expectedRoot, root)
+	}
+
+	// Indices 4 and 5 share a chunk, and the appended balance starts a new one.
+	changedIdx := []uint64{4, 5, 29, 30}
+	if err := newState.UpdateBalancesAtIndex(4, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := newState.UpdateBalancesAtIndex(5, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := newState.UpdateBalancesAtIndex(29, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := newState.AppendBalance(4); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedRoot, err = stateutil.ValidatorBalancesRoot(newState.Balances())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err = trie.RecomputeTrie(changedIdx, newState.Balances())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root != expectedRoot {
+		t.Errorf("Wanted root of %#x but got %#x", expectedRoot, root)
+	}
+}
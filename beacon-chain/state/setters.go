@@ -393,6 +393,7 @@ func (b *BeaconState) SetBalances(val []uint64) error {
 
 	b.state.Balances = val
 	b.markFieldAsDirty(balances)
+	b.rebuildTrie[balances] = true
 	return nil
 }
 
@@ -421,6 +422,7 @@ func (b *BeaconState) UpdateBalancesAtIndex(idx uint64, val uint64) error {
 	bals[idx] = val
 	b.state.Balances = bals
 	b.markFieldAsDirty(balances)
+	b.AddDirtyIndices(balances, []uint64{idx})
 	return nil
 }
 
@@ -694,6 +696,7 @@ func (b *BeaconState) AppendBalance(bal uint64) error {
 
 	b.state.Balances = append(bals, bal)
 	b.markFieldAsDirty(balances)
+	b.AddDirtyIndices(balances, []uint64{uint64(len(b.state.Balances) - 1)})
 	return nil
 }
 
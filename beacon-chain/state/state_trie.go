@@ -142,14 +142,7 @@ func (b *BeaconState) Copy() *BeaconState {
 	}
 
 	if b.merkleLayers != nil {
-		dst.merkleLayers = make([][][]byte, len(b.merkleLayers))
-		for i, layer := range b.merkleLayers {
-			dst.merkleLayers[i] = make([][]byte, len(layer))
-			for j, content := range layer {
-				dst.merkleLayers[i][j] = make([]byte, len(content))
-				copy(dst.merkleLayers[i][j], content)
-			}
-		}
+		dst.merkleLayers = copyMerkleLayers(b.merkleLayers)
 	}
 
 	// Finalizer runs when dst is being destroyed in garbage collection.
@@ -172,11 +165,45 @@ func (b *BeaconState) Copy() *BeaconState {
 				memorypool.PutValidatorsTrie(b.stateFieldLeaves[validators].fieldLayers)
 			}
 		}
+		if b.merkleLayers != nil {
+			memorypool.PutMerkleLayers(b.merkleLayers)
+		}
 	})
 
 	return dst
 }
 
+// copyMerkleLayers deep copies the provided merkle layers. The nodes of each
+// layer are copied into a single contiguous buffer, so a copy costs a couple
+// of allocations per layer rather than one per node.
+func copyMerkleLayers(layers [][][]byte) [][][]byte {
+	dst := memorypool.GetMerkleLayers(len(layers))
+	for i, layer := range layers {
+		size := 0
+		for _, content := range layer {
+			size += len(content)
+		}
+		buf := make([]byte, size)
+		var dstLayer [][]byte
+		if cap(dst[i]) >= len(layer) {
+			dstLayer = dst[i][:len(layer)]
+		} else {
+			dstLayer = make([][]byte, len(layer))
+		}
+		offset := 0
+		for j, content := range layer {
+			end := offset + len(content)
+			// Cap each node at its length so appending to it never
+			// writes into the neighbouring node.
+			dstLayer[j] = buf[offset:end:end]
+			copy(dstLayer[j], content)
+			offset = end
+		}
+		dst[i] = dstLayer
+	}
+	return dst
+}
+
 // HashTreeRoot of the beacon state retrieves the Merkle root of the trie
 // representation of the beacon state based on the eth2 Simple Serialize specification.
 func (b *BeaconState) HashTreeRoot(ctx context.Context) ([32]byte, error) {
@@ -315,6 +342,21 @@ func (b *BeaconState) rootSelector(field fieldIndex) ([32]byte, error) {
 		}
 		return stateutil.ValidatorRegistryRoot(b.state.Validators)
 	case balances:
+		if featureconfig.Get().EnableFieldTrie {
+			if b.rebuildTrie[field] {
+				maxBalCap := params.BeaconConfig().ValidatorRegistryLimit
+				elemSize := uint64(8)
+				balLimit := (maxBalCap*elemSize + 31) / 32
+				err := b.resetFieldTrie(field, b.state.Balances, balLimit)
+				if err != nil {
+					return [32]byte{}, err
+				}
+				b.dirtyIndices[field] = []uint64{}
+				delete(b.rebuildTrie, field)
+				return b.stateFieldLeaves[field].TrieRoot()
+			}
+			return b.recomputeFieldTrie(balances, b.state.Balances)
+		}
 		return stateutil.ValidatorBalancesRoot(b.state.Balances)
 	case randaoMixes:
 		if featureconfig.Get().EnableFieldTrie {
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 
 	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/sirupsen/logrus"
@@ -41,6 +42,10 @@ func (s *State) loadColdStateByRoot(ctx context.Context, blockRoot [32]byte) (*s
 	ctx, span := trace.StartSpan(ctx, "stateGen.loadColdStateByRoot")
 	defer span.End()
 
+	if s.beaconDB.HasStateDiff(ctx, blockRoot) {
+		return s.loadStateFromDiff(ctx, blockRoot)
+	}
+
 	summary, err := s.stateSummary(ctx, blockRoot)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get state summary")
@@ -54,5 +59,93 @@ func (s *State) loadColdStateBySlot(ctx context.Context, slot uint64) (*state.Be
 	ctx, span := trace.StartSpan(ctx, "stateGen.loadColdStateBySlot")
 	defer span.End()
 
+	// Use the diff of the last block's state when one was saved, only the empty slots
+	// after the block have to be processed.
+	lastBlockRoot, _, err := s.lastSavedBlock(ctx, slot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get last saved block")
+	}
+	if s.beaconDB.HasStateDiff(ctx, lastBlockRoot) {
+		st, err := s.loadStateFromDiff(ctx, lastBlockRoot)
+		if err != nil {
+			return nil, err
+		}
+		return s.ReplayBlocks(ctx, st, []*ethpb.SignedBeaconBlock{}, slot)
+	}
+
 	return s.ComputeStateUpToSlot(ctx, slot)
 }
+
+// This reconstructs a cold state by applying its saved diff to the state on its archived point.
+func (s *State) loadStateFromDiff(ctx context.Context, blockRoot [32]byte) (*state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.loadStateFromDiff")
+	defer span.End()
+
+	diff, err := s.beaconDB.StateDiff(ctx, blockRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get state diff")
+	}
+	if diff == nil {
+		return nil, errUnknownStateDiff
+	}
+	base, err := s.beaconDB.State(ctx, bytesutil.ToBytes32(diff.BaseRoot))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get archived point state")
+	}
+	if base == nil {
+		return nil, errUnknownArchivedState
+	}
+	return applyStateDiff(base, diff)
+}
+
+// This saves the diffs of the finalized states in between archived points, which are about
+// to be migrated to the cold section, from the state on the preceding archived point. The
+// input block roots are expected in increasing slot order.
+func (s *State) saveColdStateDiffs(ctx context.Context, blockRoots [][32]byte, lastArchivedIndex uint64) error {
+	ctx, span := trace.StartSpan(ctx, "stateGen.saveColdStateDiffs")
+	defer span.End()
+
+	baseRoot := s.beaconDB.ArchivedPointRoot(ctx, lastArchivedIndex)
+	base, err := s.beaconDB.State(ctx, baseRoot)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range blockRoots {
+		stateSummary, err := s.beaconDB.StateSummary(ctx, r)
+		if err != nil {
+			return err
+		}
+		if stateSummary == nil || stateSummary.Slot == 0 {
+			continue
+		}
+
+		// The state on the next archived point is kept in full, and is the base of the diffs after it.
+		nextArchivedPointSlot := (lastArchivedIndex + 1) * s.slotsPerArchivedPoint
+		if stateSummary.Slot >= nextArchivedPointSlot && s.beaconDB.HasState(ctx, r) {
+			base, err = s.beaconDB.State(ctx, r)
+			if err != nil {
+				return err
+			}
+			baseRoot = r
+			lastArchivedIndex = stateSummary.Slot / s.slotsPerArchivedPoint
+			continue
+		}
+		if base == nil || s.beaconDB.HasStateDiff(ctx, r) {
+			continue
+		}
+
+		st, err := s.loadHotStateByRoot(ctx, r)
+		if err != nil {
+			return errors.Wrap(err, "could not load hot state")
+		}
+		if err := s.beaconDB.SaveStateDiff(ctx, r, computeStateDiff(baseRoot, base, st)); err != nil {
+			return errors.Wrap(err, "could not save state diff")
+		}
+		log.WithFields(logrus.Fields{
+			"slot":      stateSummary.Slot,
+			"blockRoot": hex.EncodeToString(bytesutil.Trunc(r[:]))}).Debug("Saved state diff during state migration")
+	}
+
+	return nil
+}
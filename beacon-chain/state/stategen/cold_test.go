@@ -132,3 +132,32 @@ func TestLoadColdStateBySlot_CanGet(t *testing.T) {
 		t.Error("Did not correctly save state")
 	}
 }
+
+func TestLoadColdStateByRoot_FromStateDiff(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service := New(db, cache.NewStateSummaryCache())
+	baseState, _ := testutil.DeterministicGenesisState(t, 32)
+	baseRoot := [32]byte{'a'}
+	if err := service.beaconDB.SaveState(ctx, baseState, baseRoot); err != nil {
+		t.Fatal(err)
+	}
+	targetState := baseState.Copy()
+	if err := targetState.SetSlot(10); err != nil {
+		t.Fatal(err)
+	}
+	targetRoot := [32]byte{'b'}
+	if err := service.beaconDB.SaveStateDiff(ctx, targetRoot, computeStateDiff(baseRoot, baseState, targetState)); err != nil {
+		t.Fatal(err)
+	}
+
+	loadedState, err := service.loadColdStateByRoot(ctx, targetRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loadedState.Slot() != 10 {
+		t.Errorf("Wanted slot 10, received %d", loadedState.Slot())
+	}
+}
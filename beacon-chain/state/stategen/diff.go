@@ -0,0 +1,153 @@
+package stategen
+
+import (
+	"bytes"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// This computes the diff of the target state from the base state saved on an archived point.
+// The per-slot changing vectors and the validator registry are stored by index, every other
+// field is small and stored in full.
+func computeStateDiff(baseRoot [32]byte, base *state.BeaconState, target *state.BeaconState) *pb.StateDiff {
+	diff := &pb.StateDiff{
+		BaseRoot: baseRoot[:],
+		State: &pb.BeaconState{
+			GenesisTime:                 target.GenesisTime(),
+			GenesisValidatorsRoot:       target.GenesisValidatorRoot(),
+			Slot:                        target.Slot(),
+			Fork:                        target.Fork(),
+			LatestBlockHeader:           target.LatestBlockHeader(),
+			HistoricalRoots:             target.HistoricalRoots(),
+			Eth1Data:                    target.Eth1Data(),
+			Eth1DataVotes:               target.Eth1DataVotes(),
+			Eth1DepositIndex:            target.Eth1DepositIndex(),
+			PreviousEpochAttestations:   target.PreviousEpochAttestations(),
+			CurrentEpochAttestations:    target.CurrentEpochAttestations(),
+			JustificationBits:           target.JustificationBits(),
+			PreviousJustifiedCheckpoint: target.PreviousJustifiedCheckpoint(),
+			CurrentJustifiedCheckpoint:  target.CurrentJustifiedCheckpoint(),
+			FinalizedCheckpoint:         target.FinalizedCheckpoint(),
+		},
+		BlockRoots:     diffRoots(base.BlockRoots(), target.BlockRoots()),
+		StateRoots:     diffRoots(base.StateRoots(), target.StateRoots()),
+		RandaoMixes:    diffRoots(base.RandaoMixes(), target.RandaoMixes()),
+		Slashings:      diffUint64s(base.Slashings(), target.Slashings()),
+		Balances:       diffUint64s(base.Balances(), target.Balances()),
+		ValidatorCount: uint64(target.NumValidators()),
+	}
+
+	baseVals := base.Validators()
+	for i, v := range target.Validators() {
+		if i < len(baseVals) && proto.Equal(baseVals[i], v) {
+			continue
+		}
+		diff.Validators = append(diff.Validators, &pb.IndexedValidator{Index: uint64(i), Validator: v})
+	}
+
+	return diff
+}
+
+// This reconstructs a state by applying the diff to the base state saved on its archived point.
+func applyStateDiff(base *state.BeaconState, diff *pb.StateDiff) (*state.BeaconState, error) {
+	if diff == nil || diff.State == nil {
+		return nil, errors.New("nil state diff")
+	}
+	st := base.CloneInnerState()
+	if diff.ValidatorCount < uint64(len(st.Validators)) {
+		return nil, errors.Errorf("state diff has %d validators, fewer than the %d in the base state", diff.ValidatorCount, len(st.Validators))
+	}
+
+	st.GenesisTime = diff.State.GenesisTime
+	st.GenesisValidatorsRoot = diff.State.GenesisValidatorsRoot
+	st.Slot = diff.State.Slot
+	st.Fork = diff.State.Fork
+	st.LatestBlockHeader = diff.State.LatestBlockHeader
+	st.HistoricalRoots = diff.State.HistoricalRoots
+	st.Eth1Data = diff.State.Eth1Data
+	st.Eth1DataVotes = diff.State.Eth1DataVotes
+	st.Eth1DepositIndex = diff.State.Eth1DepositIndex
+	st.PreviousEpochAttestations = diff.State.PreviousEpochAttestations
+	st.CurrentEpochAttestations = diff.State.CurrentEpochAttestations
+	st.JustificationBits = diff.State.JustificationBits
+	st.PreviousJustifiedCheckpoint = diff.State.PreviousJustifiedCheckpoint
+	st.CurrentJustifiedCheckpoint = diff.State.CurrentJustifiedCheckpoint
+	st.FinalizedCheckpoint = diff.State.FinalizedCheckpoint
+
+	if err := applyRoots(st.BlockRoots, diff.BlockRoots); err != nil {
+		return nil, errors.Wrap(err, "could not apply block roots")
+	}
+	if err := applyRoots(st.StateRoots, diff.StateRoots); err != nil {
+		return nil, errors.Wrap(err, "could not apply state roots")
+	}
+	if err := applyRoots(st.RandaoMixes, diff.RandaoMixes); err != nil {
+		return nil, errors.Wrap(err, "could not apply randao mixes")
+	}
+	if err := applyUint64s(st.Slashings, diff.Slashings); err != nil {
+		return nil, errors.Wrap(err, "could not apply slashings")
+	}
+
+	validators := make([]*ethpb.Validator, diff.ValidatorCount)
+	copy(validators, st.Validators)
+	for _, v := range diff.Validators {
+		if v.Index >= diff.ValidatorCount {
+			return nil, errors.Errorf("validator index %d out of range", v.Index)
+		}
+		validators[v.Index] = v.Validator
+	}
+	st.Validators = validators
+	balances := make([]uint64, diff.ValidatorCount)
+	copy(balances, st.Balances)
+	if err := applyUint64s(balances, diff.Balances); err != nil {
+		return nil, errors.Wrap(err, "could not apply balances")
+	}
+	st.Balances = balances
+
+	return state.InitializeFromProtoUnsafe(st)
+}
+
+func diffRoots(base [][]byte, target [][]byte) []*pb.IndexedRoot {
+	var diff []*pb.IndexedRoot
+	for i, r := range target {
+		if i < len(base) && bytes.Equal(base[i], r) {
+			continue
+		}
+		diff = append(diff, &pb.IndexedRoot{Index: uint64(i), Root: r})
+	}
+	return diff
+}
+
+func diffUint64s(base []uint64, target []uint64) []*pb.IndexedUint64 {
+	var diff []*pb.IndexedUint64
+	for i, v := range target {
+		if i < len(base) && base[i] == v {
+			continue
+		}
+		diff = append(diff, &pb.IndexedUint64{Index: uint64(i), Value: v})
+	}
+	return diff
+}
+
+func applyRoots(roots [][]byte, diff []*pb.IndexedRoot) error {
+	for _, r := range diff {
+		if r.Index >= uint64(len(roots)) {
+			return errors.Errorf("index %d out of range", r.Index)
+		}
+		roots[r.Index] = r.Root
+	}
+	return nil
+}
+
+func applyUint64s(values []uint64, diff []*pb.IndexedUint64) error {
+	for _, v := range diff {
+		if v.Index >= uint64(len(values)) {
+			return errors.Errorf("index %d out of range", v.Index)
+		}
+		values[v.Index] = v.Value
+	}
+	return nil
+}
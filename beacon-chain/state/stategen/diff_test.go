@@ -0,0 +1,75 @@
+package stategen
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestStateDiff_ComputeAndApply(t *testing.T) {
+	ctx := context.Background()
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	target := base.Copy()
+	if err := target.SetSlot(10); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.UpdateBlockRootAtIndex(3, [32]byte{'a'}); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.UpdateRandaoMixesAtIndex(1, []byte{'b'}); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.UpdateBalancesAtIndex(5, 1); err != nil {
+		t.Fatal(err)
+	}
+	newVal := &ethpb.Validator{
+		PublicKey:             []byte{'c'},
+		WithdrawalCredentials: []byte{'d'},
+		EffectiveBalance:      params.BeaconConfig().MaxEffectiveBalance,
+	}
+	if err := target.AppendValidator(newVal); err != nil {
+		t.Fatal(err)
+	}
+	if err := target.AppendBalance(params.BeaconConfig().MaxEffectiveBalance); err != nil {
+		t.Fatal(err)
+	}
+
+	diff := computeStateDiff([32]byte{'e'}, base, target)
+	if len(diff.BlockRoots) != 1 || len(diff.RandaoMixes) != 1 || len(diff.StateRoots) != 0 {
+		t.Errorf("Unexpected root diffs %v %v %v", diff.BlockRoots, diff.RandaoMixes, diff.StateRoots)
+	}
+	if len(diff.Validators) != 1 || diff.Validators[0].Index != 32 {
+		t.Errorf("Wanted only the appended validator in the diff, received %v", diff.Validators)
+	}
+	if len(diff.Balances) != 2 {
+		t.Errorf("Wanted 2 balances in the diff, received %d", len(diff.Balances))
+	}
+
+	applied, err := applyStateDiff(base, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted, err := target.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	received, err := applied.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wanted != received {
+		t.Errorf("Wanted state root %#x, received %#x", wanted, received)
+	}
+}
+
+func TestStateDiff_ApplyFewerValidators(t *testing.T) {
+	base, _ := testutil.DeterministicGenesisState(t, 32)
+	diff := computeStateDiff([32]byte{}, base, base)
+	diff.ValidatorCount = 1
+	if _, err := applyStateDiff(base, diff); err == nil {
+		t.Error("Expected error applying a diff with fewer validators than the base state")
+	}
+}
@@ -7,5 +7,6 @@ var errUnknownArchivedState = errors.New("unknown archived state")
 var errUnknownBoundaryState = errors.New("unknown boundary state")
 var errUnknownBoundaryRoot = errors.New("unknown boundary root")
 var errUnknownState = errors.New("unknown state")
+var errUnknownStateDiff = errors.New("unknown state diff")
 var errUnknownBlock = errors.New("unknown block")
 var errSlotNonArchivedPoint = errors.New("slot is not an archived point index")
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -50,6 +51,44 @@ func (s *State) StateBySlot(ctx context.Context, slot uint64) (*state.BeaconStat
 	return s.loadHotStateBySlot(ctx, slot)
 }
 
+// SlotsToReplay estimates the number of slots StateBySlot processes to regenerate the state of
+// the input slot, counting from the nearest state it starts from. Callers use it to reject
+// requests for states which are too expensive to regenerate.
+func (s *State) SlotsToReplay(ctx context.Context, slot uint64) (uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "stateGen.SlotsToReplay")
+	defer span.End()
+
+	if slot == 0 {
+		return 0, nil
+	}
+
+	if slot >= s.splitInfo.slot {
+		// Hot states are saved on epoch boundaries, down to the split point.
+		startSlot := helpers.StartSlot(helpers.SlotToEpoch(slot))
+		if startSlot < s.splitInfo.slot {
+			startSlot = s.splitInfo.slot
+		}
+		return slot - startSlot, nil
+	}
+
+	// Only the empty slots after the last block are processed when its state diff was saved.
+	lastBlockRoot, lastBlockSlot, err := s.lastSavedBlock(ctx, slot)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get last saved block")
+	}
+	if s.beaconDB.HasStateDiff(ctx, lastBlockRoot) {
+		return slot - lastBlockSlot, nil
+	}
+
+	// Otherwise blocks are replayed from the last archived point with a saved state.
+	for i := slot / s.slotsPerArchivedPoint; i > 0; i-- {
+		if s.beaconDB.HasState(ctx, s.beaconDB.ArchivedPointRoot(ctx, i)) {
+			return slot - i*s.slotsPerArchivedPoint, nil
+		}
+	}
+	return slot, nil
+}
+
 // StateSummaryExists returns true if the corresponding state summary of the input block root either
 // exists in the DB or in the cache.
 func (s *State) StateSummaryExists(ctx context.Context, blockRoot [32]byte) bool {
@@ -220,6 +220,103 @@ func TestStateBySlot_HotStateDB(t *testing.T) {
 	}
 }
 
+func TestSlotsToReplay_HotState(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service := New(db, cache.NewStateSummaryCache())
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	slots, err := service.SlotsToReplay(ctx, 2*slotsPerEpoch+3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots != 3 {
+		t.Errorf("Wanted 3 slots to replay from the epoch boundary, received %d", slots)
+	}
+
+	service.splitInfo.slot = 2*slotsPerEpoch + 1
+	slots, err = service.SlotsToReplay(ctx, 2*slotsPerEpoch+3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots != 2 {
+		t.Errorf("Wanted 2 slots to replay from the split point, received %d", slots)
+	}
+}
+
+func TestSlotsToReplay_ColdState(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service := New(db, cache.NewStateSummaryCache())
+	service.slotsPerArchivedPoint = 8
+	service.splitInfo.slot = 100
+
+	genesis := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	if err := db.SaveBlock(ctx, genesis); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesis.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveGenesisBlockRoot(ctx, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+	slots, err := service.SlotsToReplay(ctx, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots != 13 {
+		t.Errorf("Wanted 13 slots to replay from genesis, received %d", slots)
+	}
+
+	archivedState, _ := testutil.DeterministicGenesisState(t, 32)
+	if err := archivedState.SetSlot(8); err != nil {
+		t.Fatal(err)
+	}
+	archivedRoot := [32]byte{'a'}
+	if err := db.SaveState(ctx, archivedState, archivedRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveArchivedPointRoot(ctx, archivedRoot, 1); err != nil {
+		t.Fatal(err)
+	}
+	slots, err = service.SlotsToReplay(ctx, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots != 22 {
+		t.Errorf("Wanted 22 slots to replay from the archived point, received %d", slots)
+	}
+
+	b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 20}}
+	if err := db.SaveBlock(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	bRoot, err := ssz.HashTreeRoot(b.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetState := archivedState.Copy()
+	if err := targetState.SetSlot(20); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveStateDiff(ctx, bRoot, computeStateDiff(archivedRoot, archivedState, targetState)); err != nil {
+		t.Fatal(err)
+	}
+	slots, err = service.SlotsToReplay(ctx, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slots != 10 {
+		t.Errorf("Wanted 10 slots to replay from the state diff, received %d", slots)
+	}
+}
+
 func TestStateSummary_CanGetFromCacheOrDB(t *testing.T) {
 	ctx := context.Background()
 	db := testDB.SetupDB(t)
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/hex"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -25,10 +27,9 @@ func (s *State) MigrateToCold(ctx context.Context, finalizedSlot uint64, finaliz
 	}
 
 	// Migrate all state summary objects from cache to DB.
-	if err := s.beaconDB.SaveStateSummaries(ctx, s.stateSummaryCache.GetAll()); err != nil {
+	if err := s.SaveStateSummariesToDB(ctx); err != nil {
 		return err
 	}
-	s.stateSummaryCache.Clear()
 
 	lastArchivedIndex, err := s.beaconDB.LastArchivedIndex(ctx)
 	if err != nil {
@@ -42,6 +43,13 @@ func (s *State) MigrateToCold(ctx context.Context, finalizedSlot uint64, finaliz
 		return err
 	}
 
+	// Save the diffs of the states in between archived points before their full states are deleted.
+	if flags.Get().EnableArchivedStateDiffs {
+		if err := s.saveColdStateDiffs(ctx, blockRoots, lastArchivedIndex); err != nil {
+			return errors.Wrap(err, "could not save state diffs")
+		}
+	}
+
 	for _, r := range blockRoots {
 		stateSummary, err := s.beaconDB.StateSummary(ctx, r)
 		if err != nil {
@@ -64,7 +72,7 @@ func (s *State) MigrateToCold(ctx context.Context, finalizedSlot uint64, finaliz
 			if err := s.beaconDB.SaveLastArchivedIndex(ctx, archivedPointIndex); err != nil {
 				return err
 			}
-			lastArchivedIndex++
+			lastArchivedIndex = archivedPointIndex
 			log.WithFields(logrus.Fields{
 				"slot":         stateSummary.Slot,
 				"archiveIndex": archivedPointIndex,
@@ -98,3 +106,12 @@ func (s *State) MigrateToCold(ctx context.Context, finalizedSlot uint64, finaliz
 
 	return nil
 }
+
+// SaveStateSummariesToDB moves the state summary objects from the cache to the DB.
+func (s *State) SaveStateSummariesToDB(ctx context.Context) error {
+	if err := s.beaconDB.SaveStateSummaries(ctx, s.stateSummaryCache.GetAll()); err != nil {
+		return err
+	}
+	s.stateSummaryCache.Clear()
+	return nil
+}
@@ -107,3 +107,57 @@ func TestMigrateToCold_MigrationCompletes(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Deleted state during migration")
 	testutil.AssertLogsContain(t, hook, "Set hot and cold state split point")
 }
+
+func TestMigrateToCold_SkippedArchivedPoints(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service := New(db, cache.NewStateSummaryCache())
+	service.slotsPerArchivedPoint = 2
+
+	// Blocks at slots 6 and 7 skip the archived points at index 1 and 2, only the
+	// block at slot 6 should be saved as an archived point.
+	roots := make([][32]byte, 0)
+	for _, slot := range []uint64{6, 7} {
+		beaconState, _ := testutil.DeterministicGenesisState(t, 32)
+		if err := beaconState.SetSlot(slot); err != nil {
+			t.Fatal(err)
+		}
+		b := &ethpb.SignedBeaconBlock{
+			Block: &ethpb.BeaconBlock{Slot: slot},
+		}
+		if err := service.beaconDB.SaveBlock(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+		bRoot, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := service.beaconDB.SaveStateSummary(ctx, &pb.StateSummary{Root: bRoot[:], Slot: slot}); err != nil {
+			t.Fatal(err)
+		}
+		if err := service.beaconDB.SaveState(ctx, beaconState, bRoot); err != nil {
+			t.Fatal(err)
+		}
+		roots = append(roots, bRoot)
+	}
+
+	if err := service.MigrateToCold(ctx, 8, [32]byte{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if service.beaconDB.ArchivedPointRoot(ctx, 3) != roots[0] {
+		t.Error("Archived point was overwritten by a later state")
+	}
+	lastIndex, err := service.beaconDB.LastArchivedIndex(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastIndex != 3 {
+		t.Errorf("Wanted last archived index 3, got %d", lastIndex)
+	}
+	if service.beaconDB.HasState(ctx, roots[1]) {
+		t.Error("Expected state in between archived points to be deleted")
+	}
+}
@@ -9,11 +9,15 @@ import (
 
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"go.opencensus.io/trace"
 )
 
+// defaultHotStateCacheSize is the number of hot states cached when no cache size is configured.
+const defaultHotStateCacheSize = 16
+
 // State represents a management object that handles the internal
 // logic of maintaining both hot and cold states in DB.
 type State struct {
@@ -35,10 +39,14 @@ type splitSlotAndRoot struct {
 
 // New returns a new state management object.
 func New(db db.NoHeadAccessDatabase, stateSummaryCache *cache.StateSummaryCache) *State {
+	cacheSize := flags.Get().StateCacheSize
+	if cacheSize <= 0 {
+		cacheSize = defaultHotStateCacheSize
+	}
 	return &State{
 		beaconDB:                db,
 		epochBoundarySlotToRoot: make(map[uint64][32]byte),
-		hotStateCache:           cache.NewHotStateCache(),
+		hotStateCache:           cache.NewHotStateCache(cacheSize),
 		splitInfo:               &splitSlotAndRoot{slot: 0, root: params.BeaconConfig().ZeroHash},
 		slotsPerArchivedPoint:   params.BeaconConfig().SlotsPerArchivedPoint,
 		stateSummaryCache:       stateSummaryCache,
@@ -66,12 +74,12 @@ func (s *State) Resume(ctx context.Context) (*state.BeaconState, error) {
 	return lastArchivedState, nil
 }
 
-// This verifies the archive point frequency is valid. It checks the interval
+// VerifySlotsPerArchivePoint verifies the archive point frequency is valid. It checks the interval
 // is a divisor of the number of slots per epoch. This ensures we have at least one
 // archive point within range of our state root history when iterating
 // backwards. It also ensures the archive points align with hot state summaries
 // which makes it quicker to migrate hot to cold.
-func verifySlotsPerArchivePoint(slotsPerArchivePoint uint64) bool {
+func VerifySlotsPerArchivePoint(slotsPerArchivePoint uint64) bool {
 	return slotsPerArchivePoint > 0 &&
 		slotsPerArchivePoint%params.BeaconConfig().SlotsPerEpoch == 0
 }
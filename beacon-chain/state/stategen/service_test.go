@@ -7,10 +7,29 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 )
 
+func TestNew_ConfiguredStateCacheSize(t *testing.T) {
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	flags.Init(&flags.GlobalFlags{StateCacheSize: 1})
+	defer flags.Init(&flags.GlobalFlags{})
+
+	service := New(db, cache.NewStateSummaryCache())
+	beaconState, _ := testutil.DeterministicGenesisState(t, 32)
+	service.hotStateCache.Put([32]byte{'A'}, beaconState)
+	service.hotStateCache.Put([32]byte{'B'}, beaconState)
+	if service.hotStateCache.Has([32]byte{'A'}) {
+		t.Error("Expected least recently used state to be evicted")
+	}
+	if !service.hotStateCache.Has([32]byte{'B'}) {
+		t.Error("Expected most recently used state to be cached")
+	}
+}
+
 func TestResume(t *testing.T) {
 	ctx := context.Background()
 	db := testDB.SetupDB(t)
@@ -62,8 +81,8 @@ func TestVerifySlotsPerArchivePoint(t *testing.T) {
 		{params.BeaconConfig().SlotsPerHistoricalRoot + 1, false},
 	}
 	for _, tt := range tests {
-		if got := verifySlotsPerArchivePoint(tt.input); got != tt.result {
-			t.Errorf("verifySlotsPerArchivePoint(%d) = %v, want %v", tt.input, got, tt.result)
+		if got := VerifySlotsPerArchivePoint(tt.input); got != tt.result {
+			t.Errorf("VerifySlotsPerArchivePoint(%d) = %v, want %v", tt.input, got, tt.result)
 		}
 	}
 }
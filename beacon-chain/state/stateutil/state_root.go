@@ -291,15 +291,17 @@ func HistoricalRootsRoot(historicalRoots [][]byte) ([32]byte, error) {
 // a list of uint64 slashing values according to the eth2
 // Simple Serialize specification.
 func SlashingsRoot(slashings []uint64) ([32]byte, error) {
-	slashingMarshaling := make([][]byte, params.BeaconConfig().EpochsPerSlashingsVector)
-	for i := 0; i < len(slashings) && i < len(slashingMarshaling); i++ {
-		slashBuf := make([]byte, 8)
-		binary.LittleEndian.PutUint64(slashBuf, slashings[i])
-		slashingMarshaling[i] = slashBuf
-	}
-	slashingChunks, err := pack(slashingMarshaling)
-	if err != nil {
-		return [32]byte{}, errors.Wrap(err, "could not pack slashings into chunks")
+	vectorLength := params.BeaconConfig().EpochsPerSlashingsVector
+	limit := (vectorLength*8 + bytesPerChunk - 1) / bytesPerChunk
+	merkleizer := NewChunkedMerkleizer(NewHasherFunc(hashutil.CustomSHA256Hasher()), limit)
+	for i := uint64(0); i < vectorLength; i++ {
+		var slashing uint64
+		if i < uint64(len(slashings)) {
+			slashing = slashings[i]
+		}
+		if err := merkleizer.WriteUint64(slashing); err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not pack slashings into chunks")
+		}
 	}
-	return bitwiseMerkleize(hashutil.CustomSHA256Hasher(), slashingChunks, uint64(len(slashingChunks)), uint64(len(slashingChunks)))
+	return merkleizer.Root()
 }
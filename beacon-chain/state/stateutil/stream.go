@@ -0,0 +1,126 @@
+package stateutil
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/trieutil"
+)
+
+// ChunkedMerkleizer computes the merkle root of a list of chunks which are
+// streamed into it, keeping only a single pending node per tree level in memory.
+// This allows large objects, such as the balances of a big validator registry,
+// to be hashed without first materializing their full serialized form.
+//
+// Serialized bytes are written with Write, or WriteUint64 for packed basic
+// types, and are split into 32-byte chunks as they arrive. The last chunk is
+// right-padded with zero bytes when the root is computed.
+type ChunkedMerkleizer struct {
+	hasher     Hasher
+	limit      uint64
+	limitDepth uint8
+	count      uint64
+	tmp        [][32]byte
+	chunk      [bytesPerChunk]byte
+	chunkLen   int
+	err        error
+}
+
+// NewChunkedMerkleizer creates a merkleizer for a list of at most limit chunks.
+func NewChunkedMerkleizer(hasher Hasher, limit uint64) *ChunkedMerkleizer {
+	limitDepth := uint8(0)
+	if limit > 1 {
+		limitDepth = GetDepth(limit)
+	}
+	return &ChunkedMerkleizer{
+		hasher:     hasher,
+		limit:      limit,
+		limitDepth: limitDepth,
+		tmp:        make([][32]byte, limitDepth+1),
+	}
+}
+
+// Write appends serialized bytes to the streamed list. It fails once the
+// written data exceeds the chunk limit of the merkleizer.
+func (m *ChunkedMerkleizer) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		if m.err != nil {
+			return written, m.err
+		}
+		n := copy(m.chunk[m.chunkLen:], p[written:])
+		m.chunkLen += n
+		written += n
+		if m.chunkLen == bytesPerChunk {
+			m.appendChunk()
+		}
+	}
+	return written, m.err
+}
+
+// WriteUint64 appends the little-endian serialization of a uint64, packing
+// four values into each chunk.
+func (m *ChunkedMerkleizer) WriteUint64(v uint64) error {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	_, err := m.Write(buf[:])
+	return err
+}
+
+// Root returns the merkle root of all chunks written so far, virtually padded
+// with zero chunks up to the limit.
+func (m *ChunkedMerkleizer) Root() ([32]byte, error) {
+	if m.chunkLen > 0 {
+		for i := m.chunkLen; i < bytesPerChunk; i++ {
+			m.chunk[i] = 0
+		}
+		m.appendChunk()
+	}
+	if m.err != nil {
+		return [32]byte{}, m.err
+	}
+	if m.limit == 0 {
+		return [32]byte{}, nil
+	}
+	// Fold the pending left nodes of every level together with the partial
+	// subtree on their right, using zero-hashes where no right side exists.
+	var h [32]byte
+	hasTail := false
+	for j := uint8(0); j < m.limitDepth; j++ {
+		pending := m.count&(uint64(1)<<j) != 0
+		switch {
+		case pending && hasTail:
+			h = m.hasher.Combi(m.tmp[j], h)
+		case pending:
+			h = m.hasher.Combi(m.tmp[j], trieutil.ZeroHashes[j])
+			hasTail = true
+		case hasTail:
+			h = m.hasher.Combi(h, trieutil.ZeroHashes[j])
+		}
+	}
+	if hasTail {
+		return h, nil
+	}
+	if m.count == 0 {
+		return trieutil.ZeroHashes[m.limitDepth], nil
+	}
+	// The tree is completely filled.
+	return m.tmp[m.limitDepth], nil
+}
+
+// appendChunk merges the current chunk into the tree, combining it with the
+// pending left siblings for as many levels as it completes.
+func (m *ChunkedMerkleizer) appendChunk() {
+	m.chunkLen = 0
+	if m.count >= m.limit {
+		m.err = errors.New("merkleizing list that is too large, over limit")
+		return
+	}
+	h := m.chunk
+	j := uint8(0)
+	for ; m.count&(uint64(1)<<j) != 0; j++ {
+		h = m.hasher.Combi(m.tmp[j], h)
+	}
+	m.tmp[j] = h
+	m.count++
+}
@@ -0,0 +1,73 @@
+package stateutil
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+func TestChunkedMerkleizer_MatchesMerkleize(t *testing.T) {
+	hashFn := hashutil.CustomSHA256Hasher()
+	limits := []uint64{1, 2, 3, 8, 13, 1024}
+	for _, limit := range limits {
+		for count := uint64(0); count <= limit && count <= 40; count++ {
+			chunks := make([][]byte, count)
+			merkleizer := NewChunkedMerkleizer(NewHasherFunc(hashFn), limit)
+			for i := range chunks {
+				chunks[i] = make([]byte, bytesPerChunk)
+				binary.LittleEndian.PutUint64(chunks[i], uint64(i)+1)
+				if _, err := merkleizer.Write(chunks[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := bitwiseMerkleize(hashFn, chunks, count, limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := merkleizer.Root()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Errorf("Wrong root for count %d and limit %d, wanted %#x, received %#x", count, limit, want, got)
+			}
+		}
+	}
+}
+
+func TestChunkedMerkleizer_OverLimit(t *testing.T) {
+	merkleizer := NewChunkedMerkleizer(NewHasherFunc(hashutil.CustomSHA256Hasher()), 1)
+	for i := uint64(0); i < 4; i++ {
+		if err := merkleizer.WriteUint64(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := merkleizer.WriteUint64(4); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := merkleizer.Root(); err == nil {
+		t.Error("Expected error when writing more chunks than the limit")
+	}
+}
+
+func TestValidatorBalancesRoot_MatchesSSZ(t *testing.T) {
+	for _, numBalances := range []int{0, 1, 5, 100, 1023} {
+		balances := make([]uint64, numBalances)
+		for i := range balances {
+			balances[i] = uint64(i) * 1e9
+		}
+		want, err := ssz.HashTreeRootWithCapacity(balances, 1099511627776)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ValidatorBalancesRoot(balances)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Wrong root for %d balances, wanted %#x, received %#x", numBalances, want, got)
+		}
+	}
+}
@@ -26,17 +26,7 @@ func ValidatorRegistryRoot(vals []*ethpb.Validator) ([32]byte, error) {
 // a list of validator uint64 balances according to the eth2
 // Simple Serialize specification.
 func ValidatorBalancesRoot(balances []uint64) ([32]byte, error) {
-	hasher := hashutil.CustomSHA256Hasher()
-	balancesMarshaling := make([][]byte, 0)
-	for i := 0; i < len(balances); i++ {
-		balanceBuf := make([]byte, 8)
-		binary.LittleEndian.PutUint64(balanceBuf, balances[i])
-		balancesMarshaling = append(balancesMarshaling, balanceBuf)
-	}
-	balancesChunks, err := pack(balancesMarshaling)
-	if err != nil {
-		return [32]byte{}, errors.Wrap(err, "could not pack balances into chunks")
-	}
+	hasher := NewHasherFunc(hashutil.CustomSHA256Hasher())
 	maxBalCap := params.BeaconConfig().ValidatorRegistryLimit
 	elemSize := uint64(8)
 	balLimit := (maxBalCap*elemSize + 31) / 32
@@ -47,17 +37,19 @@ func ValidatorBalancesRoot(balances []uint64) ([32]byte, error) {
 			balLimit = uint64(len(balances))
 		}
 	}
-	balancesRootsRoot, err := bitwiseMerkleize(hasher, balancesChunks, uint64(len(balancesChunks)), balLimit)
+	// Balances are streamed into the merkleizer so the packed chunks of a
+	// large registry are never held in memory at once.
+	merkleizer := NewChunkedMerkleizer(hasher, balLimit)
+	for _, balance := range balances {
+		if err := merkleizer.WriteUint64(balance); err != nil {
+			return [32]byte{}, errors.Wrap(err, "could not pack balances into chunks")
+		}
+	}
+	balancesRootsRoot, err := merkleizer.Root()
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not compute balances merkleization")
 	}
-	balancesRootsBuf := new(bytes.Buffer)
-	if err := binary.Write(balancesRootsBuf, binary.LittleEndian, uint64(len(balances))); err != nil {
-		return [32]byte{}, errors.Wrap(err, "could not marshal balances length")
-	}
-	balancesRootsBufRoot := make([]byte, 32)
-	copy(balancesRootsBufRoot, balancesRootsBuf.Bytes())
-	return mixInLength(balancesRootsRoot, balancesRootsBufRoot), nil
+	return hasher.MixIn(balancesRootsRoot, uint64(len(balances))), nil
 }
 
 // ValidatorRoot describes a method from which the hash tree root
@@ -24,6 +24,9 @@ func init() {
 	fieldMap[validators] = compositeArray
 	fieldMap[previousEpochAttestations] = compositeArray
 	fieldMap[currentEpochAttestations] = compositeArray
+
+	// Initialize the packed lists.
+	fieldMap[balances] = packedList
 }
 
 type fieldIndex int
@@ -64,6 +67,7 @@ const (
 const (
 	basicArray dataType = iota
 	compositeArray
+	packedList
 )
 
 // fieldMap keeps track of each field
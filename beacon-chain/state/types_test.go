@@ -165,6 +165,53 @@ func BenchmarkStateClone_Manual(b *testing.B) {
 	}
 }
 
+func BenchmarkBeaconState_Copy(b *testing.B) {
+	b.StopTimer()
+	params.UseMinimalConfig()
+	genesis := setupGenesisState(b, 64)
+	st, err := stateTrie.InitializeFromProto(genesis)
+	if err != nil {
+		b.Fatal(err)
+	}
+	// Compute the root so the merkle layers are populated and copied.
+	if _, err := st.HashTreeRoot(context.Background()); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		_ = st.Copy()
+	}
+}
+
+func TestBeaconState_CopyKeepsMerkleLayersIndependent(t *testing.T) {
+	params.UseMinimalConfig()
+	ctx := context.Background()
+	genesis := setupGenesisState(t, 64)
+	a, err := stateTrie.InitializeFromProto(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantedRoot, err := a.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := a.Copy()
+	if err := a.SetSlot(100); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.HashTreeRoot(ctx); err != nil {
+		t.Fatal(err)
+	}
+	copiedRoot, err := b.HashTreeRoot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copiedRoot != wantedRoot {
+		t.Errorf("Wanted copied state root %#x, received %#x", wantedRoot, copiedRoot)
+	}
+}
+
 func cloneValidatorsWithProto(vals []*ethpb.Validator) []*ethpb.Validator {
 	var ok bool
 	res := make([]*ethpb.Validator, len(vals))
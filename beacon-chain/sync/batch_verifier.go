@@ -0,0 +1,139 @@
+package sync
+
+import (
+	"context"
+	"time"
+
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/verifypool"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// How long unaggregated attestations are queued before their signatures are verified.
+	attBatchWindow = 50 * time.Millisecond
+	// Queued attestations of a subnet are verified right away once this many are pending.
+	attBatchLimit = 128
+)
+
+// batchAttestationVerification is whether unaggregated attestations are queued to verify their
+// signatures in batches. Batches only save work when the BLS backend verifies them in a single
+// multi-pairing, otherwise queuing merely delays every attestation.
+var batchAttestationVerification = bls.BatchVerificationSupported
+
+// attVerificationRequest is an unaggregated attestation waiting for its signature to be
+// verified as part of a batch.
+type attVerificationRequest struct {
+	att    *eth.Attestation
+	result chan bool
+}
+
+// verifyAttestationSignature queues the unaggregated attestation on its subnet and blocks
+// until the signatures of the queued attestations have been verified in batches, or verifies
+// it right away if the BLS backend does not support batch verification. It returns whether
+// the signature of the attestation is valid.
+func (s *Service) verifyAttestationSignature(ctx context.Context, att *eth.Attestation) bool {
+	if !batchAttestationVerification {
+		valid := false
+		err := verifypool.Run(ctx, func() error {
+			valid = s.chain.IsValidAttestation(ctx, att)
+			return nil
+		})
+		return err == nil && valid
+	}
+	subnet := att.Data.CommitteeIndex % params.BeaconNetworkConfig().AttestationSubnetCount
+	req := &attVerificationRequest{att: att, result: make(chan bool, 1)}
+
+	s.pendingAttBatchLock.Lock()
+	if s.pendingAttBatches == nil {
+		s.pendingAttBatches = make(map[uint64][]*attVerificationRequest)
+	}
+	batch := append(s.pendingAttBatches[subnet], req)
+	s.pendingAttBatches[subnet] = batch
+	if len(batch) == 1 {
+		time.AfterFunc(attBatchWindow, func() {
+			s.flushAttBatch(subnet)
+		})
+	}
+	s.pendingAttBatchLock.Unlock()
+	if len(batch) >= attBatchLimit {
+		s.flushAttBatch(subnet)
+	}
+
+	select {
+	case valid := <-req.result:
+		return valid
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// flushAttBatch verifies all attestations queued for the subnet.
+func (s *Service) flushAttBatch(subnet uint64) {
+	s.pendingAttBatchLock.Lock()
+	batch := s.pendingAttBatches[subnet]
+	delete(s.pendingAttBatches, subnet)
+	s.pendingAttBatchLock.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+	s.verifyAttBatch(s.ctx, batch)
+}
+
+// verifyAttBatch groups the requests by attestation data and verifies the signatures of each
+// group as a single aggregate. If a group fails to verify, its attestations are verified
+// individually so that valid attestations are not rejected along with an invalid one.
+func (s *Service) verifyAttBatch(ctx context.Context, batch []*attVerificationRequest) {
+	ctx, span := trace.StartSpan(ctx, "sync.verifyAttBatch")
+	defer span.End()
+	span.AddAttributes(trace.Int64Attribute("batchSize", int64(len(batch))))
+
+	groups := make(map[[32]byte][]*attVerificationRequest)
+	// Keep the order in which data was first seen so groups are verified deterministically.
+	roots := make([][32]byte, 0, len(batch))
+	for _, req := range batch {
+		root, err := ssz.HashTreeRoot(req.att.Data)
+		if err != nil {
+			log.WithError(err).Error("Could not hash attestation data")
+			req.result <- false
+			continue
+		}
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], req)
+	}
+
+	for _, root := range roots {
+		group := groups[root]
+		atts := make([]*eth.Attestation, len(group))
+		for i, req := range group {
+			atts[i] = req.att
+		}
+		// A verification which did not complete is not read, as it may still be running.
+		valid := false
+		err := verifypool.Run(ctx, func() error {
+			valid = s.chain.IsValidAttestationBatch(ctx, atts)
+			return nil
+		})
+		if err == nil && valid {
+			for _, req := range group {
+				req.result <- true
+			}
+			continue
+		}
+		attestationBatchFailedCounter.Inc()
+		for _, req := range group {
+			req := req
+			valid := false
+			err := verifypool.Run(ctx, func() error {
+				valid = s.chain.IsValidAttestation(ctx, req.att)
+				return nil
+			})
+			req.result <- err == nil && valid
+		}
+	}
+}
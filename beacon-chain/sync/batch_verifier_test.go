@@ -0,0 +1,82 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mockChain "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+)
+
+func TestVerifyAttestationSignature_BatchesPerSubnet(t *testing.T) {
+	defer func(batch bool) {
+		batchAttestationVerification = batch
+	}(batchAttestationVerification)
+	batchAttestationVerification = true
+	for _, valid := range []bool{true, false} {
+		s := &Service{
+			ctx:   context.Background(),
+			chain: &mockChain.ChainService{ValidAttestation: valid},
+		}
+
+		var wg sync.WaitGroup
+		results := make([]bool, 2*attBatchLimit)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				att := &eth.Attestation{
+					Data: &eth.AttestationData{
+						Slot:           uint64(i % 3),
+						CommitteeIndex: uint64(i % 2),
+					},
+				}
+				results[i] = s.verifyAttestationSignature(context.Background(), att)
+			}(i)
+		}
+		wg.Wait()
+
+		for i, result := range results {
+			if result != valid {
+				t.Errorf("Attestation %d: wanted valid = %v, got %v", i, valid, result)
+			}
+		}
+		if len(s.pendingAttBatches) != 0 {
+			t.Errorf("Expected no pending attestation batches, got %d", len(s.pendingAttBatches))
+		}
+	}
+}
+
+func TestVerifyAttestationSignature_WithoutBatchVerification(t *testing.T) {
+	defer func(batch bool) {
+		batchAttestationVerification = batch
+	}(batchAttestationVerification)
+	batchAttestationVerification = false
+	for _, valid := range []bool{true, false} {
+		s := &Service{
+			ctx:   context.Background(),
+			chain: &mockChain.ChainService{ValidAttestation: valid},
+		}
+		att := &eth.Attestation{Data: &eth.AttestationData{}}
+		if result := s.verifyAttestationSignature(context.Background(), att); result != valid {
+			t.Errorf("Wanted valid = %v, got %v", valid, result)
+		}
+		if s.pendingAttBatches != nil {
+			t.Error("Expected the attestation to be verified without being queued")
+		}
+	}
+}
+
+func TestVerifyAttestationSignature_ContextCanceled(t *testing.T) {
+	s := &Service{
+		ctx:   context.Background(),
+		chain: &mockChain.ChainService{ValidAttestation: true},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	att := &eth.Attestation{Data: &eth.AttestationData{}}
+	if s.verifyAttestationSignature(ctx, att) {
+		t.Error("Expected attestation to be rejected once the context is canceled")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"io"
 
+	libp2pcore "github.com/libp2p/go-libp2p-core"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 )
 
@@ -14,20 +16,37 @@ const stepError = "invalid range or step"
 
 var errWrongForkDigestVersion = errors.New("wrong fork digest version")
 var errInvalidEpoch = errors.New("invalid epoch")
+var errInconsistentStatus = errors.New("status is inconsistent with the previous status of the peer")
+var errInvalidSequenceNum = errors.New("metadata sequence number is lower than the previous sequence number of the peer")
 
 var responseCodeSuccess = byte(0x00)
 var responseCodeInvalidRequest = byte(0x01)
 var responseCodeServerError = byte(0x02)
 
 func (r *Service) generateErrorResponse(code byte, reason string) ([]byte, error) {
+	return createErrorResponse(code, reason, r.p2p)
+}
+
+func createErrorResponse(code byte, reason string, p2pProvider p2p.P2P) ([]byte, error) {
 	buf := bytes.NewBuffer([]byte{code})
-	if _, err := r.p2p.Encoding().EncodeWithLength(buf, []byte(reason)); err != nil {
+	if _, err := p2pProvider.Encoding().EncodeWithLength(buf, []byte(reason)); err != nil {
 		return nil, err
 	}
 
 	return buf.Bytes(), nil
 }
 
+func writeErrorResponseToStream(responseCode byte, reason string, stream libp2pcore.Stream, p2pProvider p2p.P2P) {
+	resp, err := createErrorResponse(responseCode, reason, p2pProvider)
+	if err != nil {
+		log.WithError(err).Error("Failed to generate a response error")
+	} else {
+		if _, err := stream.Write(resp); err != nil {
+			log.WithError(err).Errorf("Failed to write to stream")
+		}
+	}
+}
+
 // ReadStatusCode response from a RPC stream.
 func ReadStatusCode(stream io.Reader, encoding encoder.NetworkEncoding) (uint8, string, error) {
 	b := make([]byte, 1)
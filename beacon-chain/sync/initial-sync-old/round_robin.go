@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
-	"github.com/paulbellamy/ratecounter"
 	"github.com/pkg/errors"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
@@ -50,7 +49,6 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 	state.SkipSlotCache.Disable()
 	defer state.SkipSlotCache.Enable()
 
-	counter := ratecounter.NewRateCounter(counterSeconds * time.Second)
 	randGenerator := rand.New(rand.NewSource(roughtime.Now().Unix()))
 	var lastEmptyRequests int
 	highestFinalizedSlot := helpers.StartSlot(s.highestFinalizedEpoch() + 1)
@@ -212,7 +210,7 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 		})
 
 		for _, blk := range blocks {
-			s.logSyncStatus(genesis, blk.Block, peers, counter)
+			s.logSyncStatus(genesis, blk.Block, peers)
 			parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
 			if !s.db.HasBlock(ctx, parentRoot) && !s.chain.HasInitSyncBlock(parentRoot) {
 				log.WithField("parentRoot", parentRoot).Debug("Beacon node doesn't have a block in DB or cache")
@@ -279,7 +277,7 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 		}
 
 		for _, blk := range resp {
-			s.logSyncStatus(genesis, blk.Block, []peer.ID{best}, counter)
+			s.logSyncStatus(genesis, blk.Block, []peer.ID{best})
 			if err := s.chain.ReceiveBlockNoPubsubForkchoice(ctx, blk); err != nil {
 				log.WithError(err).Error("Failed to process block, exiting init sync")
 				return nil
@@ -345,10 +343,10 @@ func (s *Service) highestFinalizedEpoch() uint64 {
 	return highest
 }
 
-// logSyncStatus and increment block processing counter.
-func (s *Service) logSyncStatus(genesis time.Time, blk *eth.BeaconBlock, syncingPeers []peer.ID, counter *ratecounter.RateCounter) {
-	counter.Incr(1)
-	rate := float64(counter.Rate()) / counterSeconds
+// logSyncStatus and record the processed block in the sync progress.
+func (s *Service) logSyncStatus(genesis time.Time, blk *eth.BeaconBlock, syncingPeers []peer.ID) {
+	s.progress.BlockProcessed(blk.Slot, len(syncingPeers))
+	rate := s.progress.BlocksPerSecond()
 	if rate == 0 {
 		rate = 1
 	}
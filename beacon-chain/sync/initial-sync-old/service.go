@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/progress"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
@@ -57,6 +58,7 @@ type Service struct {
 	stateNotifier     statefeed.Notifier
 	blockNotifier     blockfeed.Notifier
 	blocksRateLimiter *leakybucket.Collector
+	progress          *progress.Tracker
 }
 
 // NewInitialSync configures the initial sync service responsible for bringing the node up to the
@@ -72,6 +74,7 @@ func NewInitialSync(cfg *Config) *Service {
 		stateNotifier:     cfg.StateNotifier,
 		blockNotifier:     cfg.BlockNotifier,
 		blocksRateLimiter: leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksPerSecond, false /* deleteEmptyBuckets */),
+		progress:          progress.NewTracker(counterSeconds * time.Second),
 	}
 }
 
@@ -119,6 +122,7 @@ func (s *Service) Start() {
 		).Warn("Genesis time is in the future - waiting to start sync...")
 		time.Sleep(roughtime.Until(genesis))
 	}
+	s.progress.SetGenesis(genesis)
 	s.chainStarted = true
 	currentSlot := helpers.SlotsSince(genesis)
 	if helpers.SlotToEpoch(currentSlot) == 0 {
@@ -160,6 +164,12 @@ func (s *Service) Syncing() bool {
 	return !s.synced
 }
 
+// SyncProgress returns the progress of syncing the chain, including the estimated time
+// until the node is synced.
+func (s *Service) SyncProgress() *progress.Status {
+	return s.progress.Status(s.Syncing(), s.chain.HeadSlot())
+}
+
 // Resync allows a node to start syncing again if it has fallen
 // behind the current network head.
 func (s *Service) Resync() error {
@@ -171,6 +181,7 @@ func (s *Service) Resync() error {
 		return errors.Wrap(err, "could not retrieve head state")
 	}
 	genesis := time.Unix(int64(headState.GenesisTime()), 0)
+	s.progress.SetGenesis(genesis)
 
 	s.waitForMinimumPeers()
 	err = s.roundRobinSync(genesis)
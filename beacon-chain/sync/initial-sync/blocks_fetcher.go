@@ -32,6 +32,10 @@ const (
 	maxPendingRequests = 8
 	// peersPercentagePerRequest caps percentage of peers to be used in a request.
 	peersPercentagePerRequest = 0.75
+	// skippedSlotsSearchCount caps the number of slots covered by a single request when searching
+	// past an empty batch for the next non-empty slot. Peers skip empty finalized slots when serving
+	// a range, so long gaps are crossed in a single round trip instead of one batch at a time.
+	skippedSlotsSearchCount = 1024
 )
 
 var (
@@ -484,10 +488,12 @@ func (f *blocksFetcher) nonSkippedSlotAfter(ctx context.Context, slot uint64) (u
 		return peers[0]
 	}
 
-	for slot <= helpers.StartSlot(epoch+1) {
+	highestFinalizedSlot := helpers.StartSlot(epoch + 1)
+	count := uint64(blockBatchSize)
+	for slot <= highestFinalizedSlot {
 		req := &p2ppb.BeaconBlocksByRangeRequest{
 			StartSlot: slot + 1,
-			Count:     blockBatchSize,
+			Count:     count,
 			Step:      1,
 		}
 
@@ -497,13 +503,14 @@ func (f *blocksFetcher) nonSkippedSlotAfter(ctx context.Context, slot uint64) (u
 		}
 
 		if len(blocks) > 0 {
-			slots := make([]uint64, len(blocks))
-			for i, block := range blocks {
-				slots[i] = block.Block.Slot
-			}
 			return blocks[0].Block.Slot, nil
 		}
-		slot += blockBatchSize
+		slot += count
+		// The next slots are likely part of a long gap, cover the rest of the finalized range
+		// at once so that it does not cost a round trip per batch.
+		if slot < highestFinalizedSlot {
+			count = mathutil.Max(mathutil.Min(highestFinalizedSlot-slot, skippedSlotsSearchCount), blockBatchSize)
+		}
 	}
 
 	return slot, nil
@@ -8,7 +8,6 @@ import (
 	"time"
 
 	"github.com/libp2p/go-libp2p-core/peer"
-	"github.com/paulbellamy/ratecounter"
 	"github.com/pkg/errors"
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
@@ -22,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/sirupsen/logrus"
 )
 
@@ -44,9 +44,7 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 	defer cancel()
 	defer s.chain.ClearCachedStates()
 	state.SkipSlotCache.Enable()
-	defer state.SkipSlotCache.Disable()
 
-	counter := ratecounter.NewRateCounter(counterSeconds * time.Second)
 	highestFinalizedSlot := helpers.StartSlot(s.highestFinalizedEpoch() + 1)
 	queue := newBlocksQueue(ctx, &blocksQueueConfig{
 		p2p:                 s.p2p,
@@ -59,7 +57,7 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 
 	// Step 1 - Sync to end of finalized epoch.
 	for blk := range queue.fetchedBlocks {
-		s.logSyncStatus(genesis, blk.Block, counter)
+		s.logSyncStatus(genesis, blk.Block)
 		if err := s.processBlock(ctx, blk); err != nil {
 			log.WithError(err).Info("Block is invalid")
 			continue
@@ -107,7 +105,7 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 		}
 
 		for _, blk := range resp {
-			s.logSyncStatus(genesis, blk.Block, counter)
+			s.logSyncStatus(genesis, blk.Block)
 			if err := s.chain.ReceiveBlockNoPubsubForkchoice(ctx, blk); err != nil {
 				log.WithError(err).Error("Failed to process block, exiting init sync")
 				return nil
@@ -173,10 +171,12 @@ func (s *Service) highestFinalizedEpoch() uint64 {
 	return highest
 }
 
-// logSyncStatus and increment block processing counter.
-func (s *Service) logSyncStatus(genesis time.Time, blk *eth.BeaconBlock, counter *ratecounter.RateCounter) {
-	counter.Incr(1)
-	rate := float64(counter.Rate()) / counterSeconds
+// logSyncStatus and record the processed block in the sync progress.
+func (s *Service) logSyncStatus(genesis time.Time, blk *eth.BeaconBlock) {
+	// Blocks are requested from the best finalized peers at the head epoch, see the blocks fetcher.
+	_, _, syncPeers := s.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, helpers.SlotToEpoch(s.chain.HeadSlot()))
+	s.progress.BlockProcessed(blk.Slot, len(syncPeers))
+	rate := s.progress.BlocksPerSecond()
 	if rate == 0 {
 		rate = 1
 	}
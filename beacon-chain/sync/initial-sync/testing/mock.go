@@ -2,9 +2,14 @@
 // sync status in unit tests.
 package testing
 
+import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync/progress"
+)
+
 // Sync defines a mock for the sync service.
 type Sync struct {
 	IsSyncing bool
+	Progress  *progress.Status
 }
 
 // Syncing --
@@ -21,3 +26,11 @@ func (s *Sync) Status() error {
 func (s *Sync) Resync() error {
 	return nil
 }
+
+// SyncProgress --
+func (s *Sync) SyncProgress() *progress.Status {
+	if s.Progress != nil {
+		return s.Progress
+	}
+	return &progress.Status{Syncing: s.IsSyncing}
+}
@@ -32,6 +32,74 @@ var (
 		},
 		[]string{"topic"},
 	)
+	messageValidatedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_message_validated_total",
+			Help: "Count of messages that passed validation.",
+		},
+		[]string{"topic"},
+	)
+	messageRejectedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_message_rejected_total",
+			Help: "Count of messages that failed validation by reject reason.",
+		},
+		[]string{"topic", "reason"},
+	)
+	unknownParentRecoveryCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sync_unknown_parent_recovery_total",
+			Help: "Count of attempts to recover the unknown parent of a gossiped block by result.",
+		},
+		[]string{"result"},
+	)
+	pendingBlocksGauge = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "sync_pending_blocks",
+			Help: "The number of blocks waiting in the pending blocks buffer.",
+		},
+	)
+	pendingBlocksEvictedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sync_pending_blocks_evicted_total",
+			Help: "Count of blocks dropped from the pending blocks buffer without being processed by reason.",
+		},
+		[]string{"reason"},
+	)
+	validationQueueLengthGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "p2p_validation_queue_length",
+			Help: "The number of gossip messages waiting for validation by topic.",
+		},
+		[]string{"topic"},
+	)
+	validationQueueSaturatedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_validation_queue_saturated_total",
+			Help: "Count of gossip messages which arrived at a full validation queue by topic.",
+		},
+		[]string{"topic"},
+	)
+	validationQueueDroppedCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_validation_queue_dropped_total",
+			Help: "Count of gossip messages dropped from a full validation queue without being validated by topic.",
+		},
+		[]string{"topic"},
+	)
+	rateLimitedRequestCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "p2p_rpc_rate_limited_total",
+			Help: "Count of rpc requests rejected for exceeding the peer's rate limit.",
+		},
+		[]string{"topic"},
+	)
+	duplicateMessageCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "p2p_message_duplicate_total",
+			Help: "Count of messages rejected for being in the seen message cache.",
+		},
+	)
 	messageFailedProcessingCounter = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "p2p_message_failed_processing_total",
@@ -57,6 +125,12 @@ var (
 			Help: "Count the number of times a missing block not recovered and pruned from attestation vote.",
 		},
 	)
+	attestationBatchFailedCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "p2p_attestation_batch_verification_failed_total",
+			Help: "Count the number of attestation batches which failed verification and were verified individually.",
+		},
+	)
 	numberOfAttsRecovered = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "beacon_attestations_recovered_total",
@@ -0,0 +1,166 @@
+package sync
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+)
+
+// maxPendingBlocks is the maximum number of blocks held in the pending blocks buffer.
+const maxPendingBlocks = 1024
+
+// pendingBlockExpiry is how long a block is held in the pending blocks buffer waiting for
+// its parent before it is dropped.
+var pendingBlockExpiry = time.Duration(2*params.BeaconConfig().SlotsPerEpoch*params.BeaconConfig().SecondsPerSlot) * time.Second
+
+const (
+	evictedFull      = "full"
+	evictedExpired   = "expired"
+	evictedFinalized = "finalized"
+)
+
+// pendingBlock is a block waiting in the pending blocks buffer.
+type pendingBlock struct {
+	block  *ethpb.SignedBeaconBlock
+	root   [32]byte
+	expiry time.Time
+}
+
+// pendingBlocksBuffer holds the blocks which cannot be processed yet because their parent
+// is unknown. Blocks are keyed by root, so several blocks of the same slot are kept. When
+// the buffer is full, the block with the highest slot is evicted, as it is the least likely
+// to become processable soon.
+type pendingBlocksBuffer struct {
+	lock    sync.RWMutex
+	blocks  map[[32]byte]*pendingBlock
+	maxSize int
+	ttl     time.Duration
+}
+
+func newPendingBlocksBuffer(maxSize int, ttl time.Duration) *pendingBlocksBuffer {
+	return &pendingBlocksBuffer{
+		blocks:  make(map[[32]byte]*pendingBlock),
+		maxSize: maxSize,
+		ttl:     ttl,
+	}
+}
+
+// add inserts a block into the buffer. It returns false if the block is already in the
+// buffer or the buffer is full of blocks with a lower slot.
+func (b *pendingBlocksBuffer) add(blk *ethpb.SignedBeaconBlock, root [32]byte) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.blocks[root]; ok {
+		return false
+	}
+	if len(b.blocks) >= b.maxSize {
+		var highest *pendingBlock
+		for _, p := range b.blocks {
+			if highest == nil || p.block.Block.Slot > highest.block.Block.Slot {
+				highest = p
+			}
+		}
+		pendingBlocksEvictedCounter.WithLabelValues(evictedFull).Inc()
+		if highest == nil || highest.block.Block.Slot <= blk.Block.Slot {
+			return false
+		}
+		delete(b.blocks, highest.root)
+	}
+	b.blocks[root] = &pendingBlock{
+		block:  blk,
+		root:   root,
+		expiry: roughtime.Now().Add(b.ttl),
+	}
+	pendingBlocksGauge.Set(float64(len(b.blocks)))
+	return true
+}
+
+// has returns true if the block with the given root is in the buffer.
+func (b *pendingBlocksBuffer) has(root [32]byte) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	_, ok := b.blocks[root]
+	return ok
+}
+
+// hasChildOf returns true if the buffer holds a block whose parent is the given root.
+func (b *pendingBlocksBuffer) hasChildOf(parentRoot [32]byte) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	for _, p := range b.blocks {
+		if bytesutil.ToBytes32(p.block.Block.ParentRoot) == parentRoot {
+			return true
+		}
+	}
+	return false
+}
+
+// remove deletes the block with the given root from the buffer.
+func (b *pendingBlocksBuffer) remove(root [32]byte) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.blocks, root)
+	pendingBlocksGauge.Set(float64(len(b.blocks)))
+}
+
+// sorted returns the blocks in the buffer ordered by slot, then by root.
+func (b *pendingBlocksBuffer) sorted() []*pendingBlock {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	blks := make([]*pendingBlock, 0, len(b.blocks))
+	for _, p := range b.blocks {
+		blks = append(blks, p)
+	}
+	sort.Slice(blks, func(i, j int) bool {
+		if blks[i].block.Block.Slot != blks[j].block.Block.Slot {
+			return blks[i].block.Block.Slot < blks[j].block.Block.Slot
+		}
+		return bytes.Compare(blks[i].root[:], blks[j].root[:]) < 0
+	})
+	return blks
+}
+
+// prune removes the blocks which expired by the given time, along with the blocks at or
+// before the finalized epoch and their descendants.
+func (b *pendingBlocksBuffer) prune(now time.Time, finalizedEpoch uint64) {
+	blks := b.sorted()
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	finalized := make(map[[32]byte]bool)
+	for _, p := range blks {
+		slot := p.block.Block.Slot
+		switch {
+		case finalized[bytesutil.ToBytes32(p.block.Block.ParentRoot)],
+			finalizedEpoch > 0 && helpers.SlotToEpoch(slot) <= finalizedEpoch:
+			finalized[p.root] = true
+			delete(b.blocks, p.root)
+			pendingBlocksEvictedCounter.WithLabelValues(evictedFinalized).Inc()
+		case now.After(p.expiry):
+			delete(b.blocks, p.root)
+			pendingBlocksEvictedCounter.WithLabelValues(evictedExpired).Inc()
+		}
+	}
+	pendingBlocksGauge.Set(float64(len(b.blocks)))
+}
+
+// clear removes every block from the buffer.
+func (b *pendingBlocksBuffer) clear() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.blocks = make(map[[32]byte]*pendingBlock)
+	pendingBlocksGauge.Set(0)
+}
+
+// len returns the number of blocks in the buffer.
+func (b *pendingBlocksBuffer) len() int {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return len(b.blocks)
+}
@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+)
+
+func pendingTestBlock(t *testing.T, slot uint64, parentRoot [32]byte, graffiti byte) (*ethpb.SignedBeaconBlock, [32]byte) {
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{
+		Slot:       slot,
+		ParentRoot: parentRoot[:],
+		Body:       &ethpb.BeaconBlockBody{Graffiti: []byte{graffiti}},
+	}}
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return blk, root
+}
+
+func TestPendingBlocksBuffer_KeepsBlocksOfSameSlot(t *testing.T) {
+	b := newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry)
+	b1, r1 := pendingTestBlock(t, 5, [32]byte{'a'}, 1)
+	b2, r2 := pendingTestBlock(t, 5, [32]byte{'b'}, 2)
+	if !b.add(b1, r1) || !b.add(b2, r2) {
+		t.Fatal("Expected blocks to be added")
+	}
+	if b.add(b1, r1) {
+		t.Error("Expected duplicate block not to be added")
+	}
+	if b.len() != 2 {
+		t.Errorf("Expected 2 pending blocks, received %d", b.len())
+	}
+	if !b.has(r1) || !b.has(r2) {
+		t.Error("Expected both blocks of the slot to be pending")
+	}
+	if !b.hasChildOf([32]byte{'a'}) || b.hasChildOf([32]byte{'c'}) {
+		t.Error("Unexpected result looking up pending children")
+	}
+}
+
+func TestPendingBlocksBuffer_EvictsHighestSlotWhenFull(t *testing.T) {
+	b := newPendingBlocksBuffer(2, pendingBlockExpiry)
+	b1, r1 := pendingTestBlock(t, 1, [32]byte{}, 1)
+	b2, r2 := pendingTestBlock(t, 10, [32]byte{}, 2)
+	b3, r3 := pendingTestBlock(t, 5, [32]byte{}, 3)
+	b4, r4 := pendingTestBlock(t, 20, [32]byte{}, 4)
+	b.add(b1, r1)
+	b.add(b2, r2)
+	if !b.add(b3, r3) {
+		t.Fatal("Expected block to be added by evicting the highest slot block")
+	}
+	if b.has(r2) {
+		t.Error("Expected highest slot block to be evicted")
+	}
+	if b.add(b4, r4) {
+		t.Error("Expected block with a higher slot than every pending block to be rejected")
+	}
+	if b.len() != 2 {
+		t.Errorf("Expected 2 pending blocks, received %d", b.len())
+	}
+}
+
+func TestPendingBlocksBuffer_Prune(t *testing.T) {
+	b := newPendingBlocksBuffer(maxPendingBlocks, time.Minute)
+	finalized, finalizedRoot := pendingTestBlock(t, params.BeaconConfig().SlotsPerEpoch, [32]byte{}, 1)
+	child, childRoot := pendingTestBlock(t, 2*params.BeaconConfig().SlotsPerEpoch, finalizedRoot, 2)
+	other, otherRoot := pendingTestBlock(t, 2*params.BeaconConfig().SlotsPerEpoch, [32]byte{'a'}, 3)
+	b.add(finalized, finalizedRoot)
+	b.add(child, childRoot)
+	b.add(other, otherRoot)
+
+	b.prune(roughtime.Now(), 1)
+	if b.has(finalizedRoot) || b.has(childRoot) {
+		t.Error("Expected finalized block and its descendant to be pruned")
+	}
+	if !b.has(otherRoot) {
+		t.Fatal("Expected unfinalized block to be kept")
+	}
+
+	b.prune(roughtime.Now().Add(2*time.Minute), 1)
+	if b.len() != 0 {
+		t.Errorf("Expected expired block to be pruned, %d blocks pending", b.len())
+	}
+}
+
+func TestReprocessPendingBlocksOnHeadUpdate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	chain := &mock.ChainService{}
+	r := &Service{
+		ctx:           ctx,
+		stateNotifier: chain.StateNotifier(),
+		pendingBlocks: newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
+	}
+	parentRoot := [32]byte{'a'}
+	blk, root := pendingTestBlock(t, 1, parentRoot, 1)
+	r.pendingBlocks.add(blk, root)
+
+	processed := make(chan struct{}, 1)
+	go r.reprocessPendingBlocksOnHeadUpdate(func() {
+		select {
+		case processed <- struct{}{}:
+		default:
+		}
+	})
+
+	for i := 0; i < 100; i++ {
+		r.stateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.BlockProcessed,
+			Data: &statefeed.BlockProcessedData{BlockRoot: parentRoot},
+		})
+		select {
+		case <-processed:
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Error("Expected pending blocks to be processed after their parent was processed")
+}
@@ -3,16 +3,15 @@ package sync
 import (
 	"context"
 	"encoding/hex"
-	"sort"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
-	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
-	"github.com/prysmaticlabs/go-ssz"
-	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/runutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"github.com/sirupsen/logrus"
@@ -22,17 +21,68 @@ import (
 
 var processPendingBlocksPeriod = time.Duration(params.BeaconConfig().SecondsPerSlot/3) * time.Second
 
-// processes pending blocks queue on every processPendingBlocksPeriod
+// processes pending blocks queue on every processPendingBlocksPeriod, and whenever a block
+// which is the parent of a pending block is processed.
 func (r *Service) processPendingBlocksQueue() {
 	ctx := context.Background()
+	if r.pendingBlocks == nil {
+		r.pendingBlocks = newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry)
+	}
 	locker := new(sync.Mutex)
-	runutil.RunEvery(r.ctx, processPendingBlocksPeriod, func() {
+	process := func() {
 		locker.Lock()
 		if err := r.processPendingBlocks(ctx); err != nil {
 			log.WithError(err).Error("Failed to process pending blocks")
 		}
 		locker.Unlock()
-	})
+	}
+	runutil.RunEvery(r.ctx, processPendingBlocksPeriod, process)
+	if r.stateNotifier != nil {
+		go r.reprocessPendingBlocksOnHeadUpdate(process)
+	}
+}
+
+// reprocessPendingBlocksOnHeadUpdate runs the given function whenever a processed block is
+// the parent of a block in the pending blocks queue, rather than waiting for the next
+// processPendingBlocksPeriod.
+func (r *Service) reprocessPendingBlocksOnHeadUpdate(process func()) {
+	trigger := make(chan struct{}, 1)
+	go func() {
+		for {
+			select {
+			case <-r.ctx.Done():
+				return
+			case <-trigger:
+				process()
+			}
+		}
+	}()
+
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := r.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case err := <-stateSub.Err():
+			log.WithError(err).Error("Could not subscribe to state notifier")
+			return
+		case event := <-stateChannel:
+			if event.Type != statefeed.BlockProcessed {
+				continue
+			}
+			data, ok := event.Data.(*statefeed.BlockProcessedData)
+			if !ok || !r.pendingBlocks.hasChildOf(data.BlockRoot) {
+				continue
+			}
+			// A pending run is already scheduled if the trigger is full.
+			select {
+			case trigger <- struct{}{}:
+			default:
+			}
+		}
+	}
 }
 
 // processes the block tree inside the queue
@@ -41,32 +91,34 @@ func (r *Service) processPendingBlocks(ctx context.Context) error {
 	defer span.End()
 
 	pids := r.p2p.Peers().Connected()
-	if err := r.validatePendingSlots(); err != nil {
-		return errors.Wrap(err, "could not validate pending slots")
-	}
-	slots := r.sortedPendingSlots()
+	r.pendingBlocks.prune(roughtime.Now(), r.chain.FinalizedCheckpt().Epoch)
+	blks := r.pendingBlocks.sorted()
 
 	span.AddAttributes(
-		trace.Int64Attribute("numSlots", int64(len(slots))),
+		trace.Int64Attribute("numBlocks", int64(len(blks))),
 		trace.Int64Attribute("numPeers", int64(len(pids))),
 	)
 
-	for _, s := range slots {
+	for _, p := range blks {
 		ctx, span := trace.StartSpan(ctx, "processPendingBlocks.InnerLoop")
+		b := p.block
+		s := b.Block.Slot
 		span.AddAttributes(trace.Int64Attribute("slot", int64(s)))
 
-		r.pendingQueueLock.RLock()
-		b := r.slotToPendingBlocks[uint64(s)]
-		// Skip if block does not exist.
-		if b == nil || b.Block == nil {
-			r.pendingQueueLock.RUnlock()
+		// Skip if the block was removed from the queue in the meantime.
+		if !r.pendingBlocks.has(p.root) {
+			span.End()
+			continue
+		}
+		parentRoot := bytesutil.ToBytes32(b.Block.ParentRoot)
+		inPendingQueue := r.pendingBlocks.has(parentRoot)
+		// The ancestors are already being requested from the peer which sent the block.
+		if r.isRecoveringParent(parentRoot) {
 			span.End()
 			continue
 		}
-		r.pendingQueueLock.RUnlock()
-		inPendingQueue := r.seenPendingBlocks[bytesutil.ToBytes32(b.Block.ParentRoot)]
 
-		inDB := r.db.HasBlock(ctx, bytesutil.ToBytes32(b.Block.ParentRoot))
+		inDB := r.db.HasBlock(ctx, parentRoot)
 		hasPeer := len(pids) != 0
 
 		// Only request for missing parent block if it's not in DB, not in pending cache
@@ -76,7 +128,7 @@ func (r *Service) processPendingBlocks(ctx context.Context) error {
 				"currentSlot": b.Block.Slot,
 				"parentRoot":  hex.EncodeToString(bytesutil.Trunc(b.Block.ParentRoot)),
 			}).Info("Requesting parent block")
-			req := [][32]byte{bytesutil.ToBytes32(b.Block.ParentRoot)}
+			req := [][32]byte{parentRoot}
 
 			// Start with a random peer to query, but choose the first peer in our unsorted list that claims to
 			// have a head slot newer than the block slot we are requesting.
@@ -86,7 +138,7 @@ func (r *Service) processPendingBlocks(ctx context.Context) error {
 				if err != nil {
 					return errors.Wrap(err, "failed to read chain state for peer")
 				}
-				if cs != nil && cs.HeadSlot >= uint64(s) {
+				if cs != nil && cs.HeadSlot >= s {
 					pid = p
 					break
 				}
@@ -115,21 +167,11 @@ func (r *Service) processPendingBlocks(ctx context.Context) error {
 			log.WithError(err).Error("Failed to broadcast block")
 		}
 
-		blkRoot, err := ssz.HashTreeRoot(b.Block)
-		if err != nil {
-			traceutil.AnnotateError(span, err)
-			span.End()
-			return err
-		}
-
-		r.pendingQueueLock.Lock()
-		delete(r.slotToPendingBlocks, uint64(s))
-		delete(r.seenPendingBlocks, blkRoot)
-		r.pendingQueueLock.Unlock()
+		r.pendingBlocks.remove(p.root)
 
 		log.WithFields(logrus.Fields{
 			"slot":      s,
-			"blockRoot": hex.EncodeToString(bytesutil.Trunc(blkRoot[:])),
+			"blockRoot": hex.EncodeToString(bytesutil.Trunc(p.root[:])),
 		}).Debug("Processed pending block and cleared it in cache")
 
 		span.End()
@@ -138,57 +180,6 @@ func (r *Service) processPendingBlocks(ctx context.Context) error {
 	return nil
 }
 
-func (r *Service) sortedPendingSlots() []int {
-	r.pendingQueueLock.RLock()
-	defer r.pendingQueueLock.RUnlock()
-
-	slots := make([]int, 0, len(r.slotToPendingBlocks))
-	for s := range r.slotToPendingBlocks {
-		slots = append(slots, int(s))
-	}
-	sort.Ints(slots)
-	return slots
-}
-
-// validatePendingSlots validates the pending blocks
-// by their slot. If they are before the current finalized
-// checkpoint, these blocks are removed from the queue.
-func (r *Service) validatePendingSlots() error {
-	r.pendingQueueLock.Lock()
-	defer r.pendingQueueLock.Unlock()
-	oldBlockRoots := make(map[[32]byte]bool)
-
-	finalizedEpoch := r.chain.FinalizedCheckpt().Epoch
-	for s, b := range r.slotToPendingBlocks {
-		epoch := helpers.SlotToEpoch(s)
-		// remove all descendant blocks of old blocks
-		if oldBlockRoots[bytesutil.ToBytes32(b.Block.ParentRoot)] {
-			root, err := ssz.HashTreeRoot(b.Block)
-			if err != nil {
-				return err
-			}
-			oldBlockRoots[root] = true
-			delete(r.slotToPendingBlocks, s)
-			delete(r.seenPendingBlocks, root)
-			continue
-		}
-		// don't process old blocks
-		if finalizedEpoch > 0 && epoch <= finalizedEpoch {
-			blkRoot, err := ssz.HashTreeRoot(b.Block)
-			if err != nil {
-				return err
-			}
-			oldBlockRoots[blkRoot] = true
-			delete(r.slotToPendingBlocks, s)
-			delete(r.seenPendingBlocks, blkRoot)
-		}
-	}
-	return nil
-}
-
 func (r *Service) clearPendingSlots() {
-	r.pendingQueueLock.Lock()
-	defer r.pendingQueueLock.Unlock()
-	r.slotToPendingBlocks = make(map[uint64]*ethpb.SignedBeaconBlock)
-	r.seenPendingBlocks = make(map[[32]byte]bool)
+	r.pendingBlocks.clear()
 }
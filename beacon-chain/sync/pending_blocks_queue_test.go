@@ -39,8 +39,7 @@ func TestRegularSyncBeaconBlockSubscriber_ProcessPendingBlocks1(t *testing.T) {
 				Epoch: 0,
 			},
 		},
-		slotToPendingBlocks: make(map[uint64]*ethpb.SignedBeaconBlock),
-		seenPendingBlocks:   make(map[[32]byte]bool),
+		pendingBlocks: newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
 	}
 
 	b0 := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
@@ -62,39 +61,31 @@ func TestRegularSyncBeaconBlockSubscriber_ProcessPendingBlocks1(t *testing.T) {
 		t.Fatal(err)
 	}
 	b2 := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, ParentRoot: b1Root[:]}}
-	b2Root, err := ssz.HashTreeRoot(b1.Block)
+	b2Root, err := ssz.HashTreeRoot(b2.Block)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// Add b2 to the cache
-	r.slotToPendingBlocks[b2.Block.Slot] = b2
-	r.seenPendingBlocks[b2Root] = true
+	r.pendingBlocks.add(b2, b2Root)
 
 	if err := r.processPendingBlocks(context.Background()); err != nil {
 		t.Fatal(err)
 	}
-	if len(r.slotToPendingBlocks) != 1 {
-		t.Errorf("Incorrect size for slot to pending blocks cache: got %d", len(r.slotToPendingBlocks))
-	}
-	if len(r.seenPendingBlocks) != 1 {
-		t.Errorf("Incorrect size for seen pending block: got %d", len(r.seenPendingBlocks))
+	if r.pendingBlocks.len() != 1 {
+		t.Errorf("Incorrect size for pending blocks buffer: got %d", r.pendingBlocks.len())
 	}
 
 	// Add b1 to the cache
-	r.slotToPendingBlocks[b1.Block.Slot] = b1
-	r.seenPendingBlocks[b1Root] = true
+	r.pendingBlocks.add(b1, b1Root)
 	if err := r.db.SaveBlock(context.Background(), b1); err != nil {
 		t.Fatal(err)
 	}
 	if err := r.processPendingBlocks(context.Background()); err != nil {
 		t.Fatal(err)
 	}
-	if len(r.slotToPendingBlocks) != 0 {
-		t.Errorf("Incorrect size for slot to pending blocks cache: got %d", len(r.slotToPendingBlocks))
-	}
-	if len(r.seenPendingBlocks) != 0 {
-		t.Errorf("Incorrect size for seen pending block: got %d", len(r.seenPendingBlocks))
+	if r.pendingBlocks.len() != 0 {
+		t.Errorf("Incorrect size for pending blocks buffer: got %d", r.pendingBlocks.len())
 	}
 }
 
@@ -136,8 +127,8 @@ func TestRegularSyncBeaconBlockSubscriber_ProcessPendingBlocks2(t *testing.T) {
 			FinalizedCheckPoint: &ethpb.Checkpoint{
 				Epoch: 0,
 			},
-		}, slotToPendingBlocks: make(map[uint64]*ethpb.SignedBeaconBlock),
-		seenPendingBlocks: make(map[[32]byte]bool),
+		},
+		pendingBlocks: newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
 	}
 	p1.Peers().Add(new(enr.Record), p2.PeerID(), nil, network.DirOutbound)
 	p1.Peers().SetConnectionState(p2.PeerID(), peers.PeerConnected)
@@ -182,40 +173,30 @@ func TestRegularSyncBeaconBlockSubscriber_ProcessPendingBlocks2(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	r.slotToPendingBlocks[b4.Slot] = &ethpb.SignedBeaconBlock{Block: b4}
-	r.seenPendingBlocks[b4Root] = true
-	r.slotToPendingBlocks[b5.Slot] = &ethpb.SignedBeaconBlock{Block: b5}
-	r.seenPendingBlocks[b5Root] = true
+	r.pendingBlocks.add(&ethpb.SignedBeaconBlock{Block: b4}, b4Root)
+	r.pendingBlocks.add(&ethpb.SignedBeaconBlock{Block: b5}, b5Root)
 
 	if err := r.processPendingBlocks(context.Background()); err != nil {
 		t.Fatal(err)
 	}
-	if len(r.slotToPendingBlocks) != 2 {
-		t.Errorf("Incorrect size for slot to pending blocks cache: got %d", len(r.slotToPendingBlocks))
-	}
-	if len(r.seenPendingBlocks) != 2 {
-		t.Errorf("Incorrect size for seen pending block: got %d", len(r.seenPendingBlocks))
+	if r.pendingBlocks.len() != 2 {
+		t.Errorf("Incorrect size for pending blocks buffer: got %d", r.pendingBlocks.len())
 	}
 
 	// Add b3 to the cache
-	r.slotToPendingBlocks[b3.Slot] = &ethpb.SignedBeaconBlock{Block: b3}
-	r.seenPendingBlocks[b3Root] = true
+	r.pendingBlocks.add(&ethpb.SignedBeaconBlock{Block: b3}, b3Root)
 	if err := r.db.SaveBlock(context.Background(), &ethpb.SignedBeaconBlock{Block: b3}); err != nil {
 		t.Fatal(err)
 	}
 	if err := r.processPendingBlocks(context.Background()); err != nil {
 		t.Fatal(err)
 	}
-	if len(r.slotToPendingBlocks) != 1 {
-		t.Errorf("Incorrect size for slot to pending blocks cache: got %d", len(r.slotToPendingBlocks))
-	}
-	if len(r.seenPendingBlocks) != 1 {
-		t.Errorf("Incorrect size for seen pending block: got %d", len(r.seenPendingBlocks))
+	if r.pendingBlocks.len() != 1 {
+		t.Errorf("Incorrect size for pending blocks buffer: got %d", r.pendingBlocks.len())
 	}
 
 	// Add b2 to the cache
-	r.slotToPendingBlocks[b2.Slot] = &ethpb.SignedBeaconBlock{Block: b2}
-	r.seenPendingBlocks[b2Root] = true
+	r.pendingBlocks.add(&ethpb.SignedBeaconBlock{Block: b2}, b2Root)
 
 	if err := r.db.SaveBlock(context.Background(), &ethpb.SignedBeaconBlock{Block: b2}); err != nil {
 		t.Fatal(err)
@@ -223,12 +204,8 @@ func TestRegularSyncBeaconBlockSubscriber_ProcessPendingBlocks2(t *testing.T) {
 	if err := r.processPendingBlocks(context.Background()); err != nil {
 		t.Fatal(err)
 	}
-	if len(r.slotToPendingBlocks) != 0 {
-		t.Errorf("Incorrect size for slot to pending blocks cache: got %d", len(r.slotToPendingBlocks))
-	}
-	t.Log(r.seenPendingBlocks)
-	if len(r.seenPendingBlocks) != 0 {
-		t.Errorf("Incorrect size for seen pending block: got %d", len(r.seenPendingBlocks))
+	if r.pendingBlocks.len() != 0 {
+		t.Errorf("Incorrect size for pending blocks buffer: got %d", r.pendingBlocks.len())
 	}
 }
 
@@ -249,8 +226,8 @@ func TestRegularSyncBeaconBlockSubscriber_PruneOldPendingBlocks(t *testing.T) {
 			FinalizedCheckPoint: &ethpb.Checkpoint{
 				Epoch: 1,
 			},
-		}, slotToPendingBlocks: make(map[uint64]*ethpb.SignedBeaconBlock),
-		seenPendingBlocks: make(map[[32]byte]bool),
+		},
+		pendingBlocks: newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
 	}
 	p1.Peers().Add(new(enr.Record), p1.PeerID(), nil, network.DirOutbound)
 	p1.Peers().SetConnectionState(p1.PeerID(), peers.PeerConnected)
@@ -295,22 +272,15 @@ func TestRegularSyncBeaconBlockSubscriber_PruneOldPendingBlocks(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	r.slotToPendingBlocks[b2.Slot] = &ethpb.SignedBeaconBlock{Block: b2}
-	r.seenPendingBlocks[b2Root] = true
-	r.slotToPendingBlocks[b3.Slot] = &ethpb.SignedBeaconBlock{Block: b3}
-	r.seenPendingBlocks[b3Root] = true
-	r.slotToPendingBlocks[b4.Slot] = &ethpb.SignedBeaconBlock{Block: b4}
-	r.seenPendingBlocks[b4Root] = true
-	r.slotToPendingBlocks[b5.Slot] = &ethpb.SignedBeaconBlock{Block: b5}
-	r.seenPendingBlocks[b5Root] = true
+	r.pendingBlocks.add(&ethpb.SignedBeaconBlock{Block: b2}, b2Root)
+	r.pendingBlocks.add(&ethpb.SignedBeaconBlock{Block: b3}, b3Root)
+	r.pendingBlocks.add(&ethpb.SignedBeaconBlock{Block: b4}, b4Root)
+	r.pendingBlocks.add(&ethpb.SignedBeaconBlock{Block: b5}, b5Root)
 
 	if err := r.processPendingBlocks(context.Background()); err != nil {
 		t.Fatal(err)
 	}
-	if len(r.slotToPendingBlocks) != 0 {
-		t.Errorf("Incorrect size for slot to pending blocks cache: got %d", len(r.slotToPendingBlocks))
-	}
-	if len(r.seenPendingBlocks) != 0 {
-		t.Errorf("Incorrect size for seen pending block: got %d", len(r.seenPendingBlocks))
+	if r.pendingBlocks.len() != 0 {
+		t.Errorf("Incorrect size for pending blocks buffer: got %d", r.pendingBlocks.len())
 	}
 }
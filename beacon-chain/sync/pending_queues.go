@@ -0,0 +1,54 @@
+package sync
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PendingQueues is a snapshot of the messages regular sync holds until they can be processed.
+type PendingQueues struct {
+	// Blocks waiting for their parent, ordered by slot.
+	Blocks []*PendingBlock `json:"blocks"`
+	// Attestations is the number of aggregates waiting for their block, keyed by block root.
+	Attestations map[string]int `json:"attestations"`
+	// RecoveringParents are the roots of the missing parents being requested from peers.
+	RecoveringParents []string `json:"recovering_parents"`
+}
+
+// PendingBlock is a block waiting in the pending blocks queue.
+type PendingBlock struct {
+	Slot       uint64    `json:"slot"`
+	Root       string    `json:"root"`
+	ParentRoot string    `json:"parent_root"`
+	Expiry     time.Time `json:"expiry"`
+}
+
+// PendingQueues returns a snapshot of the pending blocks and attestations queues.
+func (s *Service) PendingQueues() *PendingQueues {
+	queues := &PendingQueues{
+		Attestations: make(map[string]int),
+	}
+	for _, p := range s.pendingBlocks.sorted() {
+		queues.Blocks = append(queues.Blocks, &PendingBlock{
+			Slot:       p.block.Block.Slot,
+			Root:       fmt.Sprintf("%#x", p.root),
+			ParentRoot: fmt.Sprintf("%#x", p.block.Block.ParentRoot),
+			Expiry:     p.expiry,
+		})
+	}
+
+	s.pendingAttsLock.RLock()
+	for root, atts := range s.blkRootToPendingAtts {
+		queues.Attestations[fmt.Sprintf("%#x", root)] = len(atts)
+	}
+	s.pendingAttsLock.RUnlock()
+
+	s.recoveringParentsLock.Lock()
+	for root := range s.recoveringParents {
+		queues.RecoveringParents = append(queues.RecoveringParents, fmt.Sprintf("%#x", root))
+	}
+	s.recoveringParentsLock.Unlock()
+	sort.Strings(queues.RecoveringParents)
+	return queues
+}
@@ -0,0 +1,35 @@
+package sync
+
+import (
+	"fmt"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+func TestPendingQueues(t *testing.T) {
+	s := &Service{
+		pendingBlocks:        newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
+		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+	}
+	b1, r1 := pendingTestBlock(t, 7, [32]byte{'a'}, 1)
+	b2, r2 := pendingTestBlock(t, 5, [32]byte{'b'}, 2)
+	s.pendingBlocks.add(b1, r1)
+	s.pendingBlocks.add(b2, r2)
+	s.blkRootToPendingAtts[[32]byte{'b'}] = []*ethpb.SignedAggregateAttestationAndProof{{}, {}}
+	s.startParentRecovery([32]byte{'b'})
+
+	queues := s.PendingQueues()
+	if len(queues.Blocks) != 2 || queues.Blocks[0].Slot != 5 || queues.Blocks[1].Slot != 7 {
+		t.Fatalf("Expected pending blocks ordered by slot, received %v", queues.Blocks)
+	}
+	if queues.Blocks[0].Root != fmt.Sprintf("%#x", r2) || queues.Blocks[0].ParentRoot != fmt.Sprintf("%#x", [32]byte{'b'}) {
+		t.Errorf("Unexpected pending block %v", queues.Blocks[0])
+	}
+	if n := queues.Attestations[fmt.Sprintf("%#x", [32]byte{'b'})]; n != 2 {
+		t.Errorf("Expected 2 pending attestations, received %d", n)
+	}
+	if len(queues.RecoveringParents) != 1 || queues.RecoveringParents[0] != fmt.Sprintf("%#x", [32]byte{'b'}) {
+		t.Errorf("Unexpected recovering parents %v", queues.RecoveringParents)
+	}
+}
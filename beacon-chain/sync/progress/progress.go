@@ -0,0 +1,115 @@
+// Package progress measures the progress of syncing the chain from peers, reporting how
+// fast blocks are processed and when the node is expected to reach the current slot.
+package progress
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/paulbellamy/ratecounter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+)
+
+var (
+	blocksPerSecondGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sync_blocks_per_second",
+		Help: "The number of blocks processed per second while syncing.",
+	})
+	peersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sync_peers",
+		Help: "The number of peers blocks are requested from while syncing.",
+	})
+	slotsRemainingGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sync_slots_remaining",
+		Help: "The number of slots between the last synced block and the current slot.",
+	})
+	secondsRemainingGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sync_estimated_seconds_remaining",
+		Help: "The estimated number of seconds until the node is synced to the current slot.",
+	})
+)
+
+// Status is a snapshot of the progress of syncing the chain.
+type Status struct {
+	Syncing         bool
+	CurrentSlot     uint64
+	HeadSlot        uint64
+	BlocksPerSecond float64
+	Peers           int
+	// EstimatedTimeRemaining is zero when the node is synced or no blocks were processed recently.
+	EstimatedTimeRemaining time.Duration
+}
+
+// Fetcher defines a struct which reports the progress of syncing the chain.
+type Fetcher interface {
+	SyncProgress() *Status
+}
+
+// Tracker measures the rate blocks are processed at over a sliding window.
+type Tracker struct {
+	counter     *ratecounter.RateCounter
+	window      time.Duration
+	peers       int64
+	genesis     time.Time
+	genesisLock sync.RWMutex
+}
+
+// NewTracker creates a tracker measuring the rate of processed blocks over the given window.
+func NewTracker(window time.Duration) *Tracker {
+	return &Tracker{
+		counter: ratecounter.NewRateCounter(window),
+		window:  window,
+	}
+}
+
+// SetGenesis sets the genesis time the current slot is computed from.
+func (t *Tracker) SetGenesis(genesis time.Time) {
+	t.genesisLock.Lock()
+	defer t.genesisLock.Unlock()
+	t.genesis = genesis
+}
+
+// BlockProcessed records a block at the given slot synced from the given number of peers,
+// and updates the sync metrics.
+func (t *Tracker) BlockProcessed(slot uint64, peers int) {
+	t.counter.Incr(1)
+	atomic.StoreInt64(&t.peers, int64(peers))
+
+	status := t.Status(true /* syncing */, slot)
+	blocksPerSecondGauge.Set(status.BlocksPerSecond)
+	peersGauge.Set(float64(peers))
+	slotsRemainingGauge.Set(float64(status.CurrentSlot - status.HeadSlot))
+	secondsRemainingGauge.Set(status.EstimatedTimeRemaining.Seconds())
+}
+
+// BlocksPerSecond returns the rate blocks were processed at over the window.
+func (t *Tracker) BlocksPerSecond() float64 {
+	return float64(t.counter.Rate()) / t.window.Seconds()
+}
+
+// Status returns the progress of syncing given the slot of the head block.
+func (t *Tracker) Status(syncing bool, headSlot uint64) *Status {
+	t.genesisLock.RLock()
+	genesis := t.genesis
+	t.genesisLock.RUnlock()
+
+	s := &Status{
+		Syncing:         syncing,
+		HeadSlot:        headSlot,
+		BlocksPerSecond: t.BlocksPerSecond(),
+		Peers:           int(atomic.LoadInt64(&t.peers)),
+	}
+	if !genesis.IsZero() {
+		s.CurrentSlot = helpers.SlotsSince(genesis)
+	}
+	if s.CurrentSlot < s.HeadSlot {
+		s.CurrentSlot = s.HeadSlot
+	}
+	if syncing && s.BlocksPerSecond > 0 {
+		s.EstimatedTimeRemaining = time.Duration(float64(s.CurrentSlot-s.HeadSlot) / s.BlocksPerSecond * float64(time.Second))
+	}
+	return s
+}
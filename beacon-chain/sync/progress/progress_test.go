@@ -0,0 +1,37 @@
+package progress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestTracker_Status(t *testing.T) {
+	tracker := NewTracker(10 * time.Second)
+	if s := tracker.Status(true /* syncing */, 5); s.CurrentSlot != 5 || s.EstimatedTimeRemaining != 0 {
+		t.Errorf("Expected head slot as current slot and no estimate before genesis, received %+v", s)
+	}
+
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	genesisAge := time.Duration(100*secondsPerSlot+secondsPerSlot/2) * time.Second
+	tracker.SetGenesis(time.Now().Add(-genesisAge))
+	for i := 0; i < 20; i++ {
+		tracker.BlockProcessed(50, 3)
+	}
+
+	s := tracker.Status(true /* syncing */, 50)
+	if s.CurrentSlot != 100 || s.HeadSlot != 50 || s.Peers != 3 {
+		t.Errorf("Unexpected sync status %+v", s)
+	}
+	if s.BlocksPerSecond != 2 {
+		t.Errorf("Wanted 2 blocks per second, received %f", s.BlocksPerSecond)
+	}
+	if s.EstimatedTimeRemaining != 25*time.Second {
+		t.Errorf("Wanted 25s remaining to sync 50 slots, received %v", s.EstimatedTimeRemaining)
+	}
+
+	if s := tracker.Status(false /* syncing */, 100); s.EstimatedTimeRemaining != 0 {
+		t.Errorf("Expected no estimate once synced, received %v", s.EstimatedTimeRemaining)
+	}
+}
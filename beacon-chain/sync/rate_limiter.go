@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kevinms/leakybucket-go"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+)
+
+// Default burst allowed for rpc topics which do not request blocks.
+const defaultBurstLimit = 5
+
+// limiter holds a leaky bucket collector per rpc topic, with a bucket for each
+// remote peer in every collector. This allows us to set individual limits for
+// each rpc topic and peer.
+type limiter struct {
+	limiterMap map[string]*leakybucket.Collector
+	p2p        p2p.P2P
+	sync.RWMutex
+}
+
+// newRateLimiter initializes the rate limits for all the supported rpc topics.
+func newRateLimiter(p2pProvider p2p.P2P) *limiter {
+	topicMap := make(map[string]*leakybucket.Collector, len(p2p.RPCTopicMappings))
+	// A peer only ever needs to say goodbye once.
+	topicMap[p2p.RPCGoodByeTopic] = leakybucket.NewCollector(1, 1, false /* deleteEmptyBuckets */)
+	topicMap[p2p.RPCMetaDataTopic] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	topicMap[p2p.RPCPingTopic] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	topicMap[p2p.RPCStatusTopic] = leakybucket.NewCollector(1, defaultBurstLimit, false /* deleteEmptyBuckets */)
+	// Block requests are limited by the number of blocks requested, rather than the number of requests.
+	topicMap[p2p.RPCBlocksByRangeTopic] = leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */)
+	topicMap[p2p.RPCBlocksByRootTopic] = leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */)
+
+	return &limiter{limiterMap: topicMap, p2p: p2pProvider}
+}
+
+// validateAndAdd checks that the remote peer has enough remaining capacity on the given
+// rpc topic for the requested amount, and adds the amount to the peer's bucket. The check
+// and the add happen under the same lock, so concurrent requests of a peer cannot both
+// pass the check on the same capacity. A peer exceeding its capacity is sent a rate
+// limited error response, down-scored and disconnected once it is considered bad. Topics
+// without a configured limit are not rate limited.
+func (l *limiter) validateAndAdd(topic string, stream network.Stream, amt uint64) error {
+	pid := stream.Conn().RemotePeer()
+	if l.tryAdd(topic, pid.String(), amt) {
+		return nil
+	}
+
+	rateLimitedRequestCounter.WithLabelValues(topic).Inc()
+	l.p2p.Peers().IncrementBadResponses(pid)
+	writeErrorResponseToStream(responseCodeInvalidRequest, rateLimitedError, stream, l.p2p)
+	if l.p2p.Peers().IsBad(pid) {
+		log.WithField("peer", pid).Debug("Disconnecting bad peer")
+		if err := l.p2p.Disconnect(pid); err != nil {
+			log.WithError(err).Error("Failed to disconnect peer")
+		}
+	}
+	return errors.New(rateLimitedError)
+}
+
+// tryAdd adds the amount to the peer's bucket on the given rpc topic if the bucket has
+// enough remaining capacity for it, and returns false otherwise.
+func (l *limiter) tryAdd(topic string, key string, amt uint64) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	collector, ok := l.limiterMap[topic]
+	if !ok {
+		return true
+	}
+	if amt > uint64(collector.Remaining(key)) {
+		return false
+	}
+	collector.Add(key, int64(amt))
+	return true
+}
+
+// remaining returns the remote peer's capacity on the given rpc topic.
+func (l *limiter) remaining(topic string, stream network.Stream) int64 {
+	l.RLock()
+	defer l.RUnlock()
+
+	collector, ok := l.limiterMap[topic]
+	if !ok {
+		return 0
+	}
+	return collector.Remaining(stream.Conn().RemotePeer().String())
+}
+
+// free releases all the buckets held by the limiter.
+func (l *limiter) free() {
+	l.Lock()
+	defer l.Unlock()
+
+	for t, collector := range l.limiterMap {
+		collector.Free()
+		delete(l.limiterMap, t)
+	}
+}
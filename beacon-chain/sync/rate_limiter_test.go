@@ -0,0 +1,138 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestNewRateLimiter(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	rlimiter := newRateLimiter(p1)
+	if len(rlimiter.limiterMap) != len(p2p.RPCTopicMappings) {
+		t.Errorf("Wanted a collector for each of the %d rpc topics, got %d", len(p2p.RPCTopicMappings), len(rlimiter.limiterMap))
+	}
+	if rlimiter.limiterMap[p2p.RPCBlocksByRangeTopic] == rlimiter.limiterMap[p2p.RPCBlocksByRootTopic] {
+		t.Error("Expected block requests to be limited per protocol")
+	}
+}
+
+func TestRateLimiter_ExceedCapacity(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Peers().Add(nil, p2.PeerID(), p2.Host.Addrs()[0], network.DirOutbound)
+
+	rlimiter := newRateLimiter(p1)
+	topic := p2p.RPCPingTopic
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	pcl := protocol.ID("/testing")
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		code, errMsg, err := ReadStatusCode(stream, p1.Encoding())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if code != responseCodeInvalidRequest {
+			t.Errorf("Wanted response code %d, got %d", responseCodeInvalidRequest, code)
+		}
+		if errMsg != rateLimitedError {
+			t.Errorf("Wanted error message %s, got %s", rateLimitedError, errMsg)
+		}
+	})
+	stream, err := p1.Host.NewStream(context.Background(), p2.PeerID(), pcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < defaultBurstLimit; i++ {
+		if err := rlimiter.validateAndAdd(topic, stream, 1); err != nil {
+			t.Fatalf("Unexpected error for request %d: %v", i, err)
+		}
+	}
+	if err := rlimiter.validateAndAdd(topic, stream, 1); err == nil || err.Error() != rateLimitedError {
+		t.Errorf("Wanted error %s, got %v", rateLimitedError, err)
+	}
+	badResponses, err := p1.Peers().BadResponses(p2.PeerID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if badResponses != 1 {
+		t.Errorf("Wanted rate limited peer to be down-scored, got %d bad responses", badResponses)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestRateLimiter_UnknownTopicNotLimited(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+
+	rlimiter := newRateLimiter(p1)
+	p2.Host.SetStreamHandler("/testing", func(stream network.Stream) {})
+	stream, err := p1.Host.NewStream(context.Background(), p2.PeerID(), "/testing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rlimiter.validateAndAdd("/testing/foobar/1", stream, 1000000); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestRateLimiter_ConcurrentRequestsShareCapacity(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	p1.Peers().Add(nil, p2.PeerID(), p2.Host.Addrs()[0], network.DirOutbound)
+
+	rlimiter := newRateLimiter(p1)
+	topic := p2p.RPCPingTopic
+
+	pcl := protocol.ID("/testing")
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {})
+	numRequests := 4 * defaultBurstLimit
+	streams := make([]network.Stream, numRequests)
+	for i := range streams {
+		stream, err := p1.Host.NewStream(context.Background(), p2.PeerID(), pcl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		streams[i] = stream
+	}
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	accepted := 0
+	for _, stream := range streams {
+		wg.Add(1)
+		go func(stream network.Stream) {
+			defer wg.Done()
+			if err := rlimiter.validateAndAdd(topic, stream, 1); err == nil {
+				lock.Lock()
+				accepted++
+				lock.Unlock()
+			}
+		}(stream)
+	}
+	wg.Wait()
+
+	if accepted != defaultBurstLimit {
+		t.Errorf("Wanted %d requests to be accepted, got %d", defaultBurstLimit, accepted)
+	}
+}
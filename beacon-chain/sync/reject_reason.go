@@ -0,0 +1,70 @@
+package sync
+
+import (
+	"context"
+	"sync"
+)
+
+// Reasons for a gossip message failing validation. These are used as labels of
+// the rejected message counter, so they must remain a small, fixed set.
+const (
+	reasonSyncing          = "syncing"
+	reasonDecodeFailure    = "decode_failure"
+	reasonWrongType        = "wrong_type"
+	reasonMalformed        = "malformed"
+	reasonDuplicate        = "duplicate"
+	reasonInternalError    = "internal_error"
+	reasonInvalidTiming    = "invalid_timing"
+	reasonFinalized        = "finalized_slot"
+	reasonUnknownParent    = "unknown_parent"
+	reasonUnknownBlock     = "unknown_block"
+	reasonWrongSubnet      = "wrong_subnet"
+	reasonInvalidSignature = "invalid_signature"
+	reasonInvalidProposer  = "invalid_proposer"
+	reasonInvalidCommittee = "invalid_committee"
+	reasonInvalid          = "invalid"
+	reasonQueueSaturated   = "queue_saturated"
+	// reasonUnknown is reported when a validator did not record why it rejected a message.
+	reasonUnknown = "unknown"
+)
+
+type rejectReasonKey struct{}
+
+// rejection holds the reason a validator rejected a message.
+type rejection struct {
+	lock     sync.Mutex
+	recorded string
+}
+
+// withRejection returns a context carrying a rejection, which validators may use to
+// record the reason a message failed validation.
+func withRejection(ctx context.Context) (context.Context, *rejection) {
+	rej := &rejection{}
+	return context.WithValue(ctx, rejectReasonKey{}, rej), rej
+}
+
+// reject records the reason for rejecting a gossip message in the context, if the
+// context carries a rejection, and returns false so validators may return its
+// result directly. Only the first recorded reason is kept.
+func reject(ctx context.Context, reason string) bool {
+	rej, ok := ctx.Value(rejectReasonKey{}).(*rejection)
+	if !ok {
+		return false
+	}
+	rej.lock.Lock()
+	defer rej.lock.Unlock()
+	if rej.recorded == "" {
+		rej.recorded = reason
+	}
+	return false
+}
+
+// reason returns the recorded rejection reason or reasonUnknown if none was recorded.
+func (r *rejection) reason() string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.recorded == "" {
+		return reasonUnknown
+	}
+	return r.recorded
+}
@@ -0,0 +1,93 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestReject_RecordsFirstReason(t *testing.T) {
+	ctx, rej := withRejection(context.Background())
+	if rej.reason() != reasonUnknown {
+		t.Errorf("Wanted reason %s, got %s", reasonUnknown, rej.reason())
+	}
+	if reject(ctx, reasonDuplicate) {
+		t.Error("Expected reject to return false")
+	}
+	reject(ctx, reasonInvalid)
+	if rej.reason() != reasonDuplicate {
+		t.Errorf("Wanted reason %s, got %s", reasonDuplicate, rej.reason())
+	}
+}
+
+func TestReject_NoRejectionInContext(t *testing.T) {
+	if reject(context.Background(), reasonSyncing) {
+		t.Error("Expected reject to return false")
+	}
+}
+
+func TestWrapAndReportValidation_CountsRejectReasons(t *testing.T) {
+	topic := "/eth2/%x/test_reject_topic"
+	_, v := wrapAndReportValidation(topic, func(ctx context.Context, _ peer.ID, _ *pubsub.Message) bool {
+		return reject(ctx, reasonWrongSubnet)
+	})
+	if v(context.Background(), "", &pubsub.Message{}) {
+		t.Fatal("Expected message to fail validation")
+	}
+	if got := promtestutil.ToFloat64(messageRejectedCounter.WithLabelValues(topic, reasonWrongSubnet)); got != 1 {
+		t.Errorf("Wanted 1 rejected message, got %v", got)
+	}
+
+	_, v = wrapAndReportValidation(topic, func(_ context.Context, _ peer.ID, _ *pubsub.Message) bool {
+		return true
+	})
+	if !v(context.Background(), "", &pubsub.Message{}) {
+		t.Fatal("Expected message to pass validation")
+	}
+	if got := promtestutil.ToFloat64(messageValidatedCounter.WithLabelValues(topic)); got != 1 {
+		t.Errorf("Wanted 1 validated message, got %v", got)
+	}
+}
+
+func TestWithSeenMessageCheck_RejectsDuplicates(t *testing.T) {
+	c, err := lru.New(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &Service{seenMessageCache: c}
+	validated := 0
+	v := r.withSeenMessageCheck(func(_ context.Context, _ peer.ID, msg *pubsub.Message) bool {
+		validated++
+		return string(msg.Data) != "invalid"
+	})
+
+	invalid := &pubsub.Message{Message: &pubsub_pb.Message{Data: []byte("invalid")}}
+	for i := 0; i < 2; i++ {
+		if v(context.Background(), "", invalid) {
+			t.Fatal("Expected invalid message to fail validation")
+		}
+	}
+	if validated != 2 {
+		t.Errorf("Wanted invalid messages to be validated every time, validated %d times", validated)
+	}
+
+	msg := &pubsub.Message{Message: &pubsub_pb.Message{Data: []byte("valid")}}
+	if !v(context.Background(), "", msg) {
+		t.Fatal("Expected message to pass validation")
+	}
+	ctx, rej := withRejection(context.Background())
+	if v(ctx, "", msg) {
+		t.Fatal("Expected duplicate message to fail validation")
+	}
+	if rej.reason() != reasonDuplicate {
+		t.Errorf("Wanted reject reason %s, received %s", reasonDuplicate, rej.reason())
+	}
+	if validated != 3 {
+		t.Errorf("Wanted the duplicate message to be rejected before validation, validated %d times", validated)
+	}
+}
@@ -65,8 +65,8 @@ func (r *Service) registerRPCHandlers() {
 }
 
 // registerRPC for a given topic with an expected protobuf message type.
-func (r *Service) registerRPC(topic string, base interface{}, handle rpcHandler) {
-	topic += r.p2p.Encoding().ProtocolSuffix()
+func (r *Service) registerRPC(baseTopic string, base interface{}, handle rpcHandler) {
+	topic := baseTopic + r.p2p.Encoding().ProtocolSuffix()
 	log := log.WithField("topic", topic)
 	r.p2p.SetStreamHandler(topic, func(stream network.Stream) {
 		ctx, cancel := context.WithTimeout(context.Background(), ttfbTimeout)
@@ -90,6 +90,16 @@ func (r *Service) registerRPC(topic string, base interface{}, handle rpcHandler)
 		// Increment message received counter.
 		messageReceivedCounter.WithLabelValues(topic).Inc()
 
+		// Block requests are limited by the number of requested blocks in their handlers,
+		// every other request counts once against the peer's limit for the topic.
+		if baseTopic != p2p.RPCBlocksByRangeTopic && baseTopic != p2p.RPCBlocksByRootTopic {
+			if err := r.rateLimiter.validateAndAdd(baseTopic, stream, 1); err != nil {
+				log.WithError(err).Debug("Peer exceeded rpc rate limit")
+				traceutil.AnnotateError(span, err)
+				return
+			}
+		}
+
 		// since metadata requests do not have any data in the payload, we
 		// do not decode anything.
 		if strings.Contains(topic, p2p.RPCMetaDataTopic) {
@@ -6,13 +6,25 @@ import (
 
 	libp2pcore "github.com/libp2p/go-libp2p-core"
 	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"go.opencensus.io/trace"
 )
 
+const (
+	// defaultBlocksByRangeMaxCount is the maximum number of blocks served for a single
+	// request when no limit is configured, matching MAX_REQUEST_BLOCKS of the p2p spec.
+	defaultBlocksByRangeMaxCount = 1024
+	// defaultBlocksByRangeTimeout is the deadline for serving a single request when no
+	// timeout is configured.
+	defaultBlocksByRangeTimeout = 30 * time.Second
+)
+
 // beaconBlocksByRangeRPCHandler looks up the request blocks from the database from a given start block.
 func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interface{}, stream libp2pcore.Stream) error {
 	ctx, span := trace.StartSpan(ctx, "sync.BeaconBlocksByRangeHandler")
@@ -22,7 +34,7 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 			log.WithError(err).Error("Failed to close stream")
 		}
 	}()
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, blocksByRangeTimeout())
 	defer cancel()
 	setRPCStreamDeadlines(stream)
 	log := log.WithField("handler", "beacon_blocks_by_range")
@@ -35,9 +47,21 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 	if !ok {
 		return errors.New("message is not type *pb.BeaconBlockByRangeRequest")
 	}
+	if m.Count == 0 || m.Step == 0 {
+		r.writeErrorResponseToStream(responseCodeInvalidRequest, stepError, stream)
+		err := errors.New(stepError)
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+
+	// Truncate requests for more blocks than the local peer is willing to serve.
+	reqCount := m.Count
+	if maxCount := blocksByRangeMaxCount(); reqCount > maxCount {
+		reqCount = maxCount
+	}
 
 	// The initial count for the first batch to be returned back.
-	count := m.Count
+	count := reqCount
 	if count > uint64(allowedBlocksPerSecond) {
 		count = uint64(allowedBlocksPerSecond)
 	}
@@ -46,44 +70,68 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 	endSlot := startSlot + (m.Step * (count - 1))
 
 	// The final requested slot from remote peer.
-	endReqSlot := startSlot + (m.Step * (m.Count - 1))
+	endReqSlot := startSlot + (m.Step * (reqCount - 1))
 
-	remainingBucketCapacity := r.blocksRateLimiter.Remaining(stream.Conn().RemotePeer().String())
+	remainingBucketCapacity := r.rateLimiter.remaining(p2p.RPCBlocksByRangeTopic, stream)
 	span.AddAttributes(
 		trace.Int64Attribute("start", int64(startSlot)),
 		trace.Int64Attribute("end", int64(endReqSlot)),
 		trace.Int64Attribute("step", int64(m.Step)),
 		trace.Int64Attribute("count", int64(m.Count)),
+		trace.Int64Attribute("served_count", int64(reqCount)),
 		trace.StringAttribute("peer", stream.Conn().RemotePeer().Pretty()),
 		trace.Int64Attribute("remaining_capacity", remainingBucketCapacity),
 	)
+
+	// The finalized checkpoint is read once, as blocks behind it can not change while the request is served.
+	checkpoint, err := r.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve finalized checkpoint")
+		r.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
+		traceutil.AnnotateError(span, err)
+		return err
+	}
 	for startSlot <= endReqSlot {
-		remainingBucketCapacity = r.blocksRateLimiter.Remaining(stream.Conn().RemotePeer().String())
-
-		if int64(allowedBlocksPerSecond) > remainingBucketCapacity {
-			r.p2p.Peers().IncrementBadResponses(stream.Conn().RemotePeer())
-			if r.p2p.Peers().IsBad(stream.Conn().RemotePeer()) {
-				log.Debug("Disconnecting bad peer")
-				defer func() {
-					if err := r.p2p.Disconnect(stream.Conn().RemotePeer()); err != nil {
-						log.WithError(err).Error("Failed to disconnect peer")
-					}
-				}()
+		// Skip the empty slots at the start of the batch when it is behind the finalized checkpoint,
+		// so long gaps of skipped slots are neither rate limited nor waited on.
+		if finalizedSlot := helpers.StartSlot(checkpoint.Epoch); checkpoint.Epoch > 0 && startSlot <= finalizedSlot {
+			nextSlot, ok, err := r.db.NextFinalizedBlockSlot(ctx, startSlot)
+			if err != nil {
+				log.WithError(err).Error("Failed to retrieve next finalized block slot")
+				r.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
+				traceutil.AnnotateError(span, err)
+				return err
+			}
+			if !ok {
+				// There are no canonical blocks left before the finalized checkpoint.
+				nextSlot = finalizedSlot + 1
+			}
+			if nextSlot > startSlot {
+				startSlot += (nextSlot - startSlot + m.Step - 1) / m.Step * m.Step
+				if startSlot > endReqSlot {
+					break
+				}
+				endSlot = startSlot + (m.Step * (uint64(allowedBlocksPerSecond) - 1))
+				if endSlot > endReqSlot {
+					endSlot = endReqSlot
+				}
 			}
-			r.writeErrorResponseToStream(responseCodeInvalidRequest, rateLimitedError, stream)
-			return errors.New(rateLimitedError)
 		}
-		r.blocksRateLimiter.Add(stream.Conn().RemotePeer().String(), int64(allowedBlocksPerSecond))
+
+		if err := r.rateLimiter.validateAndAdd(p2p.RPCBlocksByRangeTopic, stream, uint64(allowedBlocksPerSecond)); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
 
 		// TODO(3147): Update this with reasonable constraints.
-		if endSlot-startSlot > rangeLimit || m.Step == 0 {
+		if endSlot-startSlot > rangeLimit {
 			r.writeErrorResponseToStream(responseCodeInvalidRequest, stepError, stream)
 			err := errors.New(stepError)
 			traceutil.AnnotateError(span, err)
 			return err
 		}
 
-		if err := r.writeBlockRangeToStream(ctx, startSlot, endSlot, m.Step, stream); err != nil {
+		if err := r.writeBlockRangeToStream(ctx, startSlot, endSlot, m.Step, checkpoint, stream); err != nil {
 			return err
 		}
 
@@ -100,15 +148,45 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		}
 
 		// wait for ticker before resuming streaming blocks to remote peer.
-		<-ticker.C
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			log.WithField("peer", stream.Conn().RemotePeer().Pretty()).Debug("Blocks by range request deadline exceeded")
+			traceutil.AnnotateError(span, ctx.Err())
+			return ctx.Err()
+		}
 	}
 	return nil
 }
 
-func (r *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlot, step uint64, stream libp2pcore.Stream) error {
+// blocksByRangeMaxCount returns the maximum number of blocks served for a single request.
+func blocksByRangeMaxCount() uint64 {
+	maxCount := flags.Get().BlocksByRangeMaxCount
+	if maxCount <= 0 {
+		return defaultBlocksByRangeMaxCount
+	}
+	return uint64(maxCount)
+}
+
+// blocksByRangeTimeout returns the deadline for serving a single request.
+func blocksByRangeTimeout() time.Duration {
+	timeout := flags.Get().BlocksByRangeTimeout
+	if timeout <= 0 {
+		return defaultBlocksByRangeTimeout
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+func (r *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlot, step uint64, checkpoint *ethpb.Checkpoint, stream libp2pcore.Stream) error {
 	ctx, span := trace.StartSpan(ctx, "sync.WriteBlockRangeToStream")
 	defer span.End()
 
+	// Ranges behind the finalized checkpoint are served from the finalized slot index, which
+	// holds exactly the canonical block of each slot.
+	if checkpoint.Epoch > 0 && endSlot <= helpers.StartSlot(checkpoint.Epoch) {
+		return r.writeFinalizedBlockRangeToStream(ctx, startSlot, endSlot, step, stream)
+	}
+
 	filter := filters.NewFilter().SetStartSlot(startSlot).SetEndSlot(endSlot).SetSlotStep(step)
 	blks, err := r.db.Blocks(ctx, filter)
 	if err != nil {
@@ -124,13 +202,6 @@ func (r *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 		traceutil.AnnotateError(span, err)
 		return err
 	}
-	checkpoint, err := r.db.FinalizedCheckpoint(ctx)
-	if err != nil {
-		log.WithError(err).Error("Failed to retrieve finalized checkpoint")
-		r.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
-		traceutil.AnnotateError(span, err)
-		return err
-	}
 	for i, b := range blks {
 		if b == nil || b.Block == nil {
 			continue
@@ -149,13 +220,36 @@ func (r *Service) writeBlockRangeToStream(ctx context.Context, startSlot, endSlo
 	return nil
 }
 
-func (r *Service) writeErrorResponseToStream(responseCode byte, reason string, stream libp2pcore.Stream) {
-	resp, err := r.generateErrorResponse(responseCode, reason)
+func (r *Service) writeFinalizedBlockRangeToStream(ctx context.Context, startSlot, endSlot, step uint64, stream libp2pcore.Stream) error {
+	ctx, span := trace.StartSpan(ctx, "sync.WriteFinalizedBlockRangeToStream")
+	defer span.End()
+
+	roots, err := r.db.FinalizedBlockRootsInRange(ctx, startSlot, endSlot)
 	if err != nil {
-		log.WithError(err).Error("Failed to generate a response error")
-	} else {
-		if _, err := stream.Write(resp); err != nil {
-			log.WithError(err).Errorf("Failed to write to stream")
+		log.WithError(err).Error("Failed to retrieve finalized block roots")
+		r.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	for _, root := range roots {
+		b, err := r.db.Block(ctx, root)
+		if err != nil {
+			log.WithError(err).Error("Failed to retrieve block")
+			r.writeErrorResponseToStream(responseCodeServerError, genericError, stream)
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+		if b == nil || b.Block == nil || (b.Block.Slot-startSlot)%step != 0 {
+			continue
+		}
+		if err := r.chunkWriter(stream, b); err != nil {
+			log.WithError(err).Error("Failed to send a chunked response")
+			return err
 		}
 	}
+	return nil
+}
+
+func (r *Service) writeErrorResponseToStream(responseCode byte, reason string, stream libp2pcore.Stream) {
+	writeErrorResponseToStream(responseCode, reason, stream, r.p2p)
 }
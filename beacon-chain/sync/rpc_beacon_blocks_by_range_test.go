@@ -1,18 +1,21 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"sync"
 	"testing"
 	"time"
 
-	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
 	db "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 )
 
@@ -39,7 +42,7 @@ func TestBeaconBlocksRPCHandler_ReturnsBlocks(t *testing.T) {
 		}
 	}
 
-	r := &Service{p2p: p1, db: d, blocksRateLimiter: leakybucket.NewCollector(10000, 10000, false)}
+	r := &Service{p2p: p1, db: d, rateLimiter: newRateLimiter(p1)}
 	pcl := protocol.ID("/testing")
 
 	var wg sync.WaitGroup
@@ -72,3 +75,248 @@ func TestBeaconBlocksRPCHandler_ReturnsBlocks(t *testing.T) {
 		t.Fatal("Did not receive stream within 1 sec")
 	}
 }
+
+func TestBeaconBlocksRPCHandler_TruncatesToMaxCount(t *testing.T) {
+	resetCfg := flags.Get()
+	flags.Init(&flags.GlobalFlags{BlocksByRangeMaxCount: 4})
+	defer flags.Init(resetCfg)
+
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	if len(p1.Host.Network().Peers()) != 1 {
+		t.Error("Expected peers to be connected")
+	}
+	d := db.SetupDB(t)
+	defer db.TeardownDB(t, d)
+
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: 1,
+		Step:      1,
+		Count:     16,
+	}
+
+	for i := req.StartSlot; i < req.StartSlot+req.Count; i++ {
+		if err := d.SaveBlock(context.Background(), &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: i}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := &Service{p2p: p1, db: d, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID("/testing")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		received := 0
+		for {
+			code, _, err := ReadStatusCode(stream, r.p2p.Encoding())
+			if err != nil {
+				break
+			}
+			if code != 0 {
+				t.Errorf("Unexpected response code %d", code)
+				return
+			}
+			res := &ethpb.SignedBeaconBlock{}
+			if err := r.p2p.Encoding().DecodeWithLength(stream, res); err != nil {
+				t.Error(err)
+				return
+			}
+			received++
+		}
+		if received != 4 {
+			t.Errorf("Expected 4 blocks to be served, received %d", received)
+		}
+	})
+
+	stream1, err := p1.Host.NewStream(context.Background(), p2.Host.ID(), pcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.beaconBlocksByRangeRPCHandler(context.Background(), req, stream1); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestBeaconBlocksRPCHandler_ServesCanonicalFinalizedBlocks(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	if len(p1.Host.Network().Peers()) != 1 {
+		t.Error("Expected peers to be connected")
+	}
+	d := db.SetupDB(t)
+	defer db.TeardownDB(t, d)
+	ctx := context.Background()
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	genesisRoot := [32]byte{'G'}
+	if err := d.SaveGenesisBlockRoot(ctx, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+	// A canonical chain through the first epoch, and a block at slot 2 orphaned by it.
+	parentRoot := genesisRoot
+	for i := uint64(1); i <= slotsPerEpoch; i++ {
+		blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: i, ParentRoot: parentRoot[:]}}
+		if err := d.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parentRoot = root
+	}
+	forkParent := [32]byte{'F'}
+	if err := d.SaveBlock(ctx, &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, ParentRoot: forkParent[:]}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SaveState(ctx, testutil.NewBeaconState(), parentRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: parentRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: 1,
+		Step:      1,
+		Count:     slotsPerEpoch,
+	}
+	r := &Service{p2p: p1, db: d, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID("/testing")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		received := uint64(0)
+		for {
+			code, _, err := ReadStatusCode(stream, r.p2p.Encoding())
+			if err != nil {
+				break
+			}
+			if code != 0 {
+				t.Errorf("Unexpected response code %d", code)
+				return
+			}
+			res := &ethpb.SignedBeaconBlock{}
+			if err := r.p2p.Encoding().DecodeWithLength(stream, res); err != nil {
+				t.Error(err)
+				return
+			}
+			if bytes.Equal(res.Block.ParentRoot, forkParent[:]) {
+				t.Error("Received block which is not part of the finalized chain")
+			}
+			received++
+		}
+		if received != slotsPerEpoch {
+			t.Errorf("Expected %d blocks to be served, received %d", slotsPerEpoch, received)
+		}
+	})
+
+	stream1, err := p1.Host.NewStream(ctx, p2.Host.ID(), pcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.beaconBlocksByRangeRPCHandler(ctx, req, stream1); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestBeaconBlocksRPCHandler_SkipsEmptyFinalizedSlots(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	if len(p1.Host.Network().Peers()) != 1 {
+		t.Error("Expected peers to be connected")
+	}
+	d := db.SetupDB(t)
+	defer db.TeardownDB(t, d)
+	ctx := context.Background()
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	genesisRoot := [32]byte{'G'}
+	if err := d.SaveGenesisBlockRoot(ctx, genesisRoot); err != nil {
+		t.Fatal(err)
+	}
+	// A finalized chain with blocks at the first two slots and the last two slots of ten epochs,
+	// with every slot in between skipped.
+	finalizedSlot := 10 * slotsPerEpoch
+	parentRoot := genesisRoot
+	for _, slot := range []uint64{1, 2, finalizedSlot - 1, finalizedSlot} {
+		blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		if err := d.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parentRoot = root
+	}
+	if err := d.SaveState(ctx, testutil.NewBeaconState(), parentRoot); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 10, Root: parentRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &pb.BeaconBlocksByRangeRequest{
+		StartSlot: 1,
+		Step:      1,
+		Count:     finalizedSlot,
+	}
+	r := &Service{p2p: p1, db: d, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID("/testing")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		received := 0
+		for {
+			code, _, err := ReadStatusCode(stream, r.p2p.Encoding())
+			if err != nil {
+				break
+			}
+			if code != 0 {
+				t.Errorf("Unexpected response code %d", code)
+				return
+			}
+			res := &ethpb.SignedBeaconBlock{}
+			if err := r.p2p.Encoding().DecodeWithLength(stream, res); err != nil {
+				t.Error(err)
+				return
+			}
+			received++
+		}
+		if received != 4 {
+			t.Errorf("Expected 4 blocks to be served, received %d", received)
+		}
+	})
+
+	stream1, err := p1.Host.NewStream(ctx, p2.Host.ID(), pcl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The empty batches are skipped, so the two batches holding blocks are served after a single wait.
+	if err := r.beaconBlocksByRangeRPCHandler(ctx, req, stream1); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if testutil.WaitTimeout(&wg, 2*time.Second) {
+		t.Fatal("Did not receive stream within 2 sec")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	libp2pcore "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 )
@@ -15,13 +16,31 @@ import (
 // sendRecentBeaconBlocksRequest sends a recent beacon blocks request to a peer to get
 // those corresponding blocks from that peer.
 func (r *Service) sendRecentBeaconBlocksRequest(ctx context.Context, blockRoots [][32]byte, id peer.ID) error {
+	blks, err := r.requestBlocksByRoot(ctx, blockRoots, id)
+	if err != nil {
+		return err
+	}
+	for _, blk := range blks {
+		blkRoot, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			return err
+		}
+		r.pendingBlocks.add(blk, blkRoot)
+	}
+	return nil
+}
+
+// requestBlocksByRoot requests the blocks with the given roots from a peer, returning the
+// blocks the peer responded with.
+func (r *Service) requestBlocksByRoot(ctx context.Context, blockRoots [][32]byte, id peer.ID) ([]*ethpb.SignedBeaconBlock, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
 	stream, err := r.p2p.Send(ctx, blockRoots, p2p.RPCBlocksByRootTopic, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	blks := make([]*ethpb.SignedBeaconBlock, 0, len(blockRoots))
 	for i := 0; i < len(blockRoots); i++ {
 		blk, err := ReadChunkedBlock(stream, r.p2p)
 		if err == io.EOF {
@@ -29,20 +48,11 @@ func (r *Service) sendRecentBeaconBlocksRequest(ctx context.Context, blockRoots
 		}
 		if err != nil {
 			log.WithError(err).Error("Unable to retrieve block from stream")
-			return err
+			return nil, err
 		}
-
-		blkRoot, err := ssz.HashTreeRoot(blk.Block)
-		if err != nil {
-			return err
-		}
-		r.pendingQueueLock.Lock()
-		r.slotToPendingBlocks[blk.Block.Slot] = blk
-		r.seenPendingBlocks[blkRoot] = true
-		r.pendingQueueLock.Unlock()
-
+		blks = append(blks, blk)
 	}
-	return nil
+	return blks, nil
 }
 
 // beaconBlocksRootRPCHandler looks up the request blocks from the database from the given block roots.
@@ -73,29 +83,10 @@ func (r *Service) beaconBlocksRootRPCHandler(ctx context.Context, msg interface{
 		return errors.New("no block roots provided")
 	}
 
-	if int64(len(blockRoots)) > r.blocksRateLimiter.Remaining(stream.Conn().RemotePeer().String()) {
-		r.p2p.Peers().IncrementBadResponses(stream.Conn().RemotePeer())
-		if r.p2p.Peers().IsBad(stream.Conn().RemotePeer()) {
-			log.Debug("Disconnecting bad peer")
-			defer func() {
-				if err := r.p2p.Disconnect(stream.Conn().RemotePeer()); err != nil {
-					log.WithError(err).Error("Failed to disconnect peer")
-				}
-			}()
-		}
-		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, rateLimitedError)
-		if err != nil {
-			log.WithError(err).Error("Failed to generate a response error")
-		} else {
-			if _, err := stream.Write(resp); err != nil {
-				log.WithError(err).Errorf("Failed to write to stream")
-			}
-		}
-		return errors.New(rateLimitedError)
+	if err := r.rateLimiter.validateAndAdd(p2p.RPCBlocksByRootTopic, stream, uint64(len(blockRoots))); err != nil {
+		return err
 	}
 
-	r.blocksRateLimiter.Add(stream.Conn().RemotePeer().String(), int64(len(blockRoots)))
-
 	for _, root := range blockRoots {
 		blk, err := r.db.Block(ctx, root)
 		if err != nil {
@@ -7,7 +7,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/kevinms/leakybucket-go"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/libp2p/go-libp2p-core/protocol"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -46,7 +45,7 @@ func TestRecentBeaconBlocksRPCHandler_ReturnsBlocks(t *testing.T) {
 		blkRoots = append(blkRoots, root)
 	}
 
-	r := &Service{p2p: p1, db: d, blocksRateLimiter: leakybucket.NewCollector(10000, 10000, false)}
+	r := &Service{p2p: p1, db: d, rateLimiter: newRateLimiter(p1)}
 	pcl := protocol.ID("/testing")
 
 	var wg sync.WaitGroup
@@ -120,10 +119,9 @@ func TestRecentBeaconBlocks_RPCRequestSent(t *testing.T) {
 			FinalizedCheckPoint: finalizedCheckpt,
 			Root:                blockARoot[:],
 		},
-		slotToPendingBlocks: make(map[uint64]*ethpb.SignedBeaconBlock),
-		seenPendingBlocks:   make(map[[32]byte]bool),
-		ctx:                 context.Background(),
-		blocksRateLimiter:   leakybucket.NewCollector(10000, 10000, false),
+		pendingBlocks: newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
+		ctx:           context.Background(),
+		rateLimiter:   newRateLimiter(p1),
 	}
 
 	// Setup streams
@@ -61,6 +61,16 @@ func (r *Service) sendGenericGoodbyeMessage(ctx context.Context, id peer.ID) err
 	return r.sendGoodByeMessage(ctx, codeGenericError, id)
 }
 
+// disconnectPeer sends a goodbye message with the given code to the peer and disconnects from it.
+func (r *Service) disconnectPeer(ctx context.Context, code uint64, id peer.ID) {
+	if err := r.sendGoodByeMessage(ctx, code, id); err != nil {
+		log.WithField("peer", id).WithError(err).Debug("Failed to send goodbye message to peer")
+	}
+	if err := r.p2p.Disconnect(id); err != nil {
+		log.WithField("peer", id).WithError(err).Error("Failed to disconnect from peer")
+	}
+}
+
 func goodbyeMessage(num uint64) string {
 	reason, ok := goodByes[num]
 	if ok {
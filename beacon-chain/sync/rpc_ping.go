@@ -9,6 +9,7 @@ import (
 	libp2pcore "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 )
 
 // pingHandler reads the incoming ping rpc message from the peer.
@@ -39,7 +40,9 @@ func (r *Service) pingHandler(ctx context.Context, msg interface{}, stream libp2
 				return
 			}
 			// update metadata if there is no error
-			r.p2p.Peers().SetMetadata(stream.Conn().RemotePeer(), md)
+			if err := r.updatePeerMetadata(md, stream.Conn().RemotePeer()); err != nil {
+				log.WithField("peer", stream.Conn().RemotePeer()).WithError(err).Debug("Invalid metadata from peer")
+			}
 		}()
 	}
 	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
@@ -87,7 +90,23 @@ func (r *Service) sendPingRequest(ctx context.Context, id peer.ID) error {
 		// already done in the request method.
 		return err
 	}
-	r.p2p.Peers().SetMetadata(stream.Conn().RemotePeer(), md)
+	return r.updatePeerMetadata(md, stream.Conn().RemotePeer())
+}
+
+// updatePeerMetadata saves the metadata received from the peer, unless its sequence number
+// is lower than the sequence number of the metadata the peer previously sent, as sequence
+// numbers only increase during a connection. Trusted and static peers are not penalized, and
+// their metadata is always saved.
+func (r *Service) updatePeerMetadata(md *pb.MetaData, id peer.ID) error {
+	prev, err := r.p2p.Peers().Metadata(id)
+	if err != nil {
+		return err
+	}
+	if prev != nil && md.SeqNumber < prev.SeqNumber && !isPrivilegedPeer(r.p2p.Peers(), id) {
+		r.p2p.Peers().IncrementBadResponses(id)
+		return errInvalidSequenceNum
+	}
+	r.p2p.Peers().SetMetadata(id, md)
 	return nil
 }
 
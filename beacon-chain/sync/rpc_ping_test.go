@@ -149,3 +149,73 @@ func TestPingRPCHandler_SendsPing(t *testing.T) {
 		t.Error("Peer is disconnected despite receiving a valid ping")
 	}
 }
+
+func TestUpdatePeerMetadata_RejectsLowerSequenceNumber(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	r := &Service{
+		p2p: p1,
+	}
+
+	p1.Peers().Add(new(enr.Record), p2.Host.ID(), p2.Host.Addrs()[0], network.DirUnknown)
+	p1.Peers().SetMetadata(p2.Host.ID(), &pb.MetaData{SeqNumber: 3})
+
+	if err := r.updatePeerMetadata(&pb.MetaData{SeqNumber: 2}, p2.Host.ID()); err != errInvalidSequenceNum {
+		t.Errorf("Wanted error %v, received %v", errInvalidSequenceNum, err)
+	}
+	md, err := p1.Peers().Metadata(p2.Host.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.SeqNumber != 3 {
+		t.Errorf("Wanted sequence number 3 to be kept, received %d", md.SeqNumber)
+	}
+	badResponses, err := p1.Peers().BadResponses(p2.Host.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if badResponses != 1 {
+		t.Errorf("Wanted 1 bad response, received %d", badResponses)
+	}
+
+	if err := r.updatePeerMetadata(&pb.MetaData{SeqNumber: 4}, p2.Host.ID()); err != nil {
+		t.Fatal(err)
+	}
+	md, err = p1.Peers().Metadata(p2.Host.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.SeqNumber != 4 {
+		t.Errorf("Wanted sequence number 4, received %d", md.SeqNumber)
+	}
+}
+
+func TestUpdatePeerMetadata_TrustedPeerNotPenalized(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	r := &Service{
+		p2p: p1,
+	}
+
+	p1.Peers().Add(new(enr.Record), p2.Host.ID(), p2.Host.Addrs()[0], network.DirUnknown)
+	p1.Peers().SetTrusted(p2.Host.ID())
+	p1.Peers().SetMetadata(p2.Host.ID(), &pb.MetaData{SeqNumber: 3})
+
+	if err := r.updatePeerMetadata(&pb.MetaData{SeqNumber: 2}, p2.Host.ID()); err != nil {
+		t.Fatal(err)
+	}
+	md, err := p1.Peers().Metadata(p2.Host.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if md.SeqNumber != 2 {
+		t.Errorf("Wanted sequence number 2, received %d", md.SeqNumber)
+	}
+	badResponses, err := p1.Peers().BadResponses(p2.Host.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if badResponses != 0 {
+		t.Errorf("Wanted 0 bad responses, received %d", badResponses)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
@@ -19,10 +20,14 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-// maintainPeerStatuses by infrequently polling peers for their latest status.
+// maintainPeerStatuses by infrequently polling peers for their latest status and metadata sequence
+// number. Peers whose status is inconsistent with what they previously reported, or which have not
+// provided a status for two epochs, are disconnected so the peer set stays healthy even when the
+// chain is not finalizing.
 func (r *Service) maintainPeerStatuses() {
 	// Run twice per epoch.
 	interval := time.Duration(params.BeaconConfig().SecondsPerSlot*params.BeaconConfig().SlotsPerEpoch/2) * time.Second
+	staleAfter := 4 * interval
 	runutil.RunEvery(r.ctx, interval, func() {
 		for _, pid := range r.p2p.Peers().Connected() {
 			go func(id peer.ID) {
@@ -32,16 +37,47 @@ func (r *Service) maintainPeerStatuses() {
 					// Peer has vanished; nothing to do.
 					return
 				}
-				if roughtime.Now().After(lastUpdated.Add(interval)) {
-					if err := r.reValidatePeer(r.ctx, id); err != nil {
-						log.WithField("peer", id).WithError(err).Error("Failed to revalidate peer")
-					}
+				if !roughtime.Now().After(lastUpdated.Add(interval)) {
+					return
+				}
+				if err := r.reValidatePeer(r.ctx, id); err != nil {
+					r.handleRevalidationFailure(id, err, roughtime.Now().After(lastUpdated.Add(staleAfter)))
 				}
 			}(pid)
 		}
 	})
 }
 
+// handleRevalidationFailure disconnects a peer which failed to revalidate because its status is
+// inconsistent, or whose status has become stale. Trusted and static peers are never disconnected.
+func (r *Service) handleRevalidationFailure(id peer.ID, err error, stale bool) {
+	log := log.WithField("peer", id).WithError(err)
+	switch {
+	case isPrivilegedPeer(r.p2p.Peers(), id):
+		log.Debug("Failed to revalidate trusted or static peer")
+	case isInconsistentPeerError(err):
+		log.Debug("Disconnecting peer with inconsistent status")
+		r.disconnectPeer(r.ctx, codeWrongNetwork, id)
+	case stale:
+		log.Debug("Disconnecting peer with stale status")
+		r.disconnectPeer(r.ctx, codeGenericError, id)
+	default:
+		log.Error("Failed to revalidate peer")
+	}
+}
+
+// isPrivilegedPeer returns true for trusted and static peers, which are exempt from scoring and pruning.
+func isPrivilegedPeer(p *peers.Status, id peer.ID) bool {
+	return p.IsTrusted(id) || p.IsStatic(id)
+}
+
+// isInconsistentPeerError returns true if the error shows the peer is on a different network
+// or is reporting a status or metadata inconsistent with what it reported previously.
+func isInconsistentPeerError(err error) bool {
+	return err == errWrongForkDigestVersion || err == errInvalidEpoch ||
+		err == errInconsistentStatus || err == errInvalidSequenceNum
+}
+
 // resyncIfBehind checks periodically to see if we are in normal sync but have fallen behind our peers by more than an epoch,
 // in which case we attempt a resync using the initial sync method to catch up.
 func (r *Service) resyncIfBehind() {
@@ -108,6 +144,10 @@ func (r *Service) sendRPCStatusRequest(ctx context.Context, id peer.ID) error {
 	if err := r.p2p.Encoding().DecodeWithLength(stream, msg); err != nil {
 		return err
 	}
+	if err := r.validateStatusUpdate(msg, stream.Conn().RemotePeer()); err != nil {
+		r.p2p.Peers().IncrementBadResponses(stream.Conn().RemotePeer())
+		return err
+	}
 	r.p2p.Peers().SetChainState(stream.Conn().RemotePeer(), msg)
 
 	err = r.validateStatusMessage(msg, stream)
@@ -148,8 +188,12 @@ func (r *Service) statusRPCHandler(ctx context.Context, msg interface{}, stream
 		return errors.New("message is not type *pb.Status")
 	}
 
-	if err := r.validateStatusMessage(m, stream); err != nil {
-		log.WithField("peer", stream.Conn().RemotePeer()).Debug("Invalid fork version from peer")
+	err := r.validateStatusMessage(m, stream)
+	if err == nil {
+		err = r.validateStatusUpdate(m, stream.Conn().RemotePeer())
+	}
+	if err != nil {
+		log.WithField("peer", stream.Conn().RemotePeer()).WithError(err).Debug("Invalid status from peer")
 		r.p2p.Peers().IncrementBadResponses(stream.Conn().RemotePeer())
 		originalErr := err
 		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, err.Error())
@@ -217,3 +261,16 @@ func (r *Service) validateStatusMessage(msg *pb.Status, stream network.Stream) e
 	}
 	return nil
 }
+
+// validateStatusUpdate checks the status message is consistent with the status the peer previously
+// reported, as a peer cannot move its finalized checkpoint backwards.
+func (r *Service) validateStatusUpdate(msg *pb.Status, id peer.ID) error {
+	prev, err := r.p2p.Peers().ChainState(id)
+	if err != nil || prev == nil {
+		return nil
+	}
+	if msg.FinalizedEpoch < prev.FinalizedEpoch {
+		return errInconsistentStatus
+	}
+	return nil
+}
@@ -491,3 +491,52 @@ func TestStatusRPCRequest_BadPeerHandshake(t *testing.T) {
 		t.Errorf("Bad response was not bumped to one, instead it is %d", badResponses)
 	}
 }
+
+func TestValidateStatusUpdate_FinalizedEpochDecreased(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	r := &Service{
+		p2p: p1,
+	}
+
+	p1.Peers().Add(new(enr.Record), p2.Host.ID(), p2.Host.Addrs()[0], network.DirUnknown)
+	if err := r.validateStatusUpdate(&pb.Status{FinalizedEpoch: 5}, p2.Host.ID()); err != nil {
+		t.Errorf("Unexpected error for a peer without a previous status: %v", err)
+	}
+	p1.Peers().SetChainState(p2.Host.ID(), &pb.Status{FinalizedEpoch: 5})
+	if err := r.validateStatusUpdate(&pb.Status{FinalizedEpoch: 5}, p2.Host.ID()); err != nil {
+		t.Errorf("Unexpected error for an unchanged finalized epoch: %v", err)
+	}
+	if err := r.validateStatusUpdate(&pb.Status{FinalizedEpoch: 4}, p2.Host.ID()); err != errInconsistentStatus {
+		t.Errorf("Wanted error %v, received %v", errInconsistentStatus, err)
+	}
+	if !isInconsistentPeerError(errInconsistentStatus) {
+		t.Error("Expected a decreased finalized epoch to be an inconsistent peer error")
+	}
+}
+
+func TestHandleRevalidationFailure_KeepsTrustedAndStaticPeers(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	trusted := p2ptest.NewTestP2P(t)
+	static := p2ptest.NewTestP2P(t)
+	p1.Connect(trusted)
+	p1.Connect(static)
+	if len(p1.Host.Network().Peers()) != 2 {
+		t.Fatal("Expected peers to be connected")
+	}
+	p1.Peers().Add(new(enr.Record), trusted.Host.ID(), trusted.Host.Addrs()[0], network.DirOutbound)
+	p1.Peers().Add(new(enr.Record), static.Host.ID(), static.Host.Addrs()[0], network.DirOutbound)
+	p1.Peers().SetTrusted(trusted.Host.ID())
+	p1.Peers().SetStatic(static.Host.ID())
+
+	r := &Service{
+		ctx: context.Background(),
+		p2p: p1,
+	}
+	r.handleRevalidationFailure(trusted.Host.ID(), errInconsistentStatus, true)
+	r.handleRevalidationFailure(static.Host.ID(), errInconsistentStatus, true)
+
+	if len(p1.Host.Network().Peers()) != 2 {
+		t.Error("Trusted or static peer was disconnected")
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
-	"github.com/kevinms/leakybucket-go"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
@@ -44,18 +43,19 @@ const seenProposerSlashingSize = 100
 
 // Config to set up the regular sync service.
 type Config struct {
-	P2P                 p2p.P2P
-	DB                  db.NoHeadAccessDatabase
-	AttPool             attestations.Pool
-	ExitPool            *voluntaryexits.Pool
-	SlashingPool        *slashings.Pool
-	Chain               blockchainService
-	InitialSync         Checker
-	StateNotifier       statefeed.Notifier
-	BlockNotifier       blockfeed.Notifier
-	AttestationNotifier operation.Notifier
-	StateSummaryCache   *cache.StateSummaryCache
-	StateGen            *stategen.State
+	P2P                   p2p.P2P
+	DB                    db.NoHeadAccessDatabase
+	AttPool               attestations.Pool
+	ExitPool              *voluntaryexits.Pool
+	SlashingPool          *slashings.Pool
+	Chain                 blockchainService
+	InitialSync           Checker
+	StateNotifier         statefeed.Notifier
+	BlockNotifier         blockfeed.Notifier
+	AttestationNotifier   operation.Notifier
+	StateSummaryCache     *cache.StateSummaryCache
+	StateGen              *stategen.State
+	SeenMessagesCacheSize int
 }
 
 // This defines the interface for interacting with block chain service
@@ -80,17 +80,15 @@ type Service struct {
 	exitPool                  *voluntaryexits.Pool
 	slashingPool              *slashings.Pool
 	chain                     blockchainService
-	slotToPendingBlocks       map[uint64]*ethpb.SignedBeaconBlock
-	seenPendingBlocks         map[[32]byte]bool
+	pendingBlocks             *pendingBlocksBuffer
 	blkRootToPendingAtts      map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof
 	pendingAttsLock           sync.RWMutex
-	pendingQueueLock          sync.RWMutex
 	chainStarted              bool
 	initialSync               Checker
 	validateBlockLock         sync.RWMutex
 	stateNotifier             statefeed.Notifier
 	blockNotifier             blockfeed.Notifier
-	blocksRateLimiter         *leakybucket.Collector
+	rateLimiter               *limiter
 	attestationNotifier       operation.Notifier
 	seenBlockLock             sync.RWMutex
 	seenBlockCache            *lru.Cache
@@ -104,6 +102,14 @@ type Service struct {
 	seenAttesterSlashingCache *lru.Cache
 	stateSummaryCache         *cache.StateSummaryCache
 	stateGen                  *stategen.State
+	seenMessageCacheSize      int
+	seenMessageCache          *lru.Cache
+	pendingAttBatches         map[uint64][]*attVerificationRequest
+	pendingAttBatchLock       sync.Mutex
+	recoveringParents         map[[32]byte]bool
+	recoveringParentsLock     sync.Mutex
+	validationQueues          map[string]*validationQueue
+	validationQueuesLock      sync.Mutex
 }
 
 // NewRegularSync service.
@@ -124,14 +130,16 @@ func NewRegularSync(cfg *Config) *Service {
 		chain:                cfg.Chain,
 		initialSync:          cfg.InitialSync,
 		attestationNotifier:  cfg.AttestationNotifier,
-		slotToPendingBlocks:  make(map[uint64]*ethpb.SignedBeaconBlock),
-		seenPendingBlocks:    make(map[[32]byte]bool),
+		pendingBlocks:        newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		pendingAttBatches:    make(map[uint64][]*attVerificationRequest),
+		recoveringParents:    make(map[[32]byte]bool),
 		stateNotifier:        cfg.StateNotifier,
 		blockNotifier:        cfg.BlockNotifier,
 		stateSummaryCache:    cfg.StateSummaryCache,
 		stateGen:             cfg.StateGen,
-		blocksRateLimiter:    leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksBurst, false /* deleteEmptyBuckets */),
+		seenMessageCacheSize: cfg.SeenMessagesCacheSize,
+		rateLimiter:          newRateLimiter(cfg.P2P),
 	}
 
 	r.registerRPCHandlers()
@@ -160,6 +168,11 @@ func (r *Service) Start() {
 
 // Stop the regular sync service.
 func (r *Service) Stop() error {
+	defer func() {
+		if r.rateLimiter != nil {
+			r.rateLimiter.free()
+		}
+	}()
 	defer r.cancel()
 	return nil
 }
@@ -208,6 +221,13 @@ func (r *Service) initCaches() error {
 	r.seenExitCache = exitCache
 	r.seenAttesterSlashingCache = attesterSlashingCache
 	r.seenProposerSlashingCache = proposerSlashingCache
+	if r.seenMessageCacheSize > 0 {
+		messageCache, err := lru.New(r.seenMessageCacheSize)
+		if err != nil {
+			return err
+		}
+		r.seenMessageCache = messageCache
+	}
 
 	return nil
 }
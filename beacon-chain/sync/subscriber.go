@@ -14,6 +14,7 @@ import (
 	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/messagehandler"
@@ -128,11 +129,12 @@ func (r *Service) subscribeWithBase(base proto.Message, topic string, validator
 	topic += r.p2p.Encoding().ProtocolSuffix()
 	log := log.WithField("topic", topic)
 
-	if err := r.p2p.PubSub().RegisterTopicValidator(wrapAndReportValidation(topic, validator)); err != nil {
+	validator = r.validationQueue(topic, base).validator(validator)
+	if err := r.p2p.PubSub().RegisterTopicValidator(wrapAndReportValidation(topic, r.withSeenMessageCheck(validator))); err != nil {
 		log.WithError(err).Error("Failed to register validator")
 	}
 
-	sub, err := r.p2p.PubSub().Subscribe(topic)
+	sub, err := r.p2p.SubscribeToTopic(topic)
 	if err != nil {
 		// Any error subscribing to a PubSub topic would be the result of a misconfiguration of
 		// libp2p PubSub library. This should not happen at normal runtime, unless the config
@@ -194,15 +196,41 @@ func (r *Service) subscribeWithBase(base proto.Message, topic string, validator
 }
 
 // Wrap the pubsub validator with a metric monitoring function. This function increments the
-// appropriate counter if the particular message fails to validate.
+// appropriate counter if the particular message passes or fails to validate, labelling
+// failures with the reason recorded by the validator.
 func wrapAndReportValidation(topic string, v pubsub.Validator) (string, pubsub.Validator) {
 	return topic, func(ctx context.Context, pid peer.ID, msg *pubsub.Message) bool {
 		defer messagehandler.HandlePanic(ctx, msg)
 		ctx, _ = context.WithTimeout(ctx, pubsubMessageTimeout)
 		messageReceivedCounter.WithLabelValues(topic).Inc()
+		ctx, rej := withRejection(ctx)
 		b := v(ctx, pid, msg)
 		if !b {
 			messageFailedValidationCounter.WithLabelValues(topic).Inc()
+			messageRejectedCounter.WithLabelValues(topic, rej.reason()).Inc()
+			return b
+		}
+		messageValidatedCounter.WithLabelValues(topic).Inc()
+		return b
+	}
+}
+
+// Wrap the pubsub validator to reject messages whose message-id is in the seen message cache,
+// and add the message-ids of messages passing validation to it. The pubsub library already drops
+// duplicates within its seen messages TTL, this catches duplicates which arrive after it.
+func (r *Service) withSeenMessageCheck(v pubsub.Validator) pubsub.Validator {
+	return func(ctx context.Context, pid peer.ID, msg *pubsub.Message) bool {
+		if r.seenMessageCache == nil || msg.Message == nil {
+			return v(ctx, pid, msg)
+		}
+		id := p2p.MsgID(msg.Message)
+		if r.seenMessageCache.Contains(id) {
+			duplicateMessageCounter.Inc()
+			return reject(ctx, reasonDuplicate)
+		}
+		b := v(ctx, pid, msg)
+		if b {
+			r.seenMessageCache.Add(id, true)
 		}
 		return b
 	}
@@ -240,6 +268,9 @@ func (r *Service) subscribeDynamicWithSubnets(
 				}
 				// Update desired topic indices for aggregator
 				wantedSubs := r.aggregatorCommitteeIndices(currentSlot)
+				if flags.Get().SubscribeToAllSubnets {
+					wantedSubs = allSubnetIndices()
+				}
 				// Resize as appropriate.
 				r.reValidateSubscriptions(subscriptions, wantedSubs, topicFormat, digest)
 
@@ -247,6 +278,10 @@ func (r *Service) subscribeDynamicWithSubnets(
 				for _, idx := range wantedSubs {
 					r.subscribeAggregatorSubnet(subscriptions, idx, base, digest, validate, handle)
 				}
+				if flags.Get().SubscribeToAllSubnets {
+					// Peers are already searched for on every subnet.
+					continue
+				}
 				// find desired subs for attesters
 				attesterSubs := r.attesterCommitteeIndices(currentSlot)
 				for _, idx := range attesterSubs {
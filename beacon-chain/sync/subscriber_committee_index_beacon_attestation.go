@@ -73,3 +73,12 @@ func (r *Service) attesterCommitteeIndices(currentSlot uint64) []uint64 {
 	}
 	return sliceutil.SetUint64(commIds)
 }
+
+// allSubnetIndices returns the index of every attestation subnet.
+func allSubnetIndices() []uint64 {
+	subnets := make([]uint64, params.BeaconNetworkConfig().AttestationSubnetCount)
+	for i := range subnets {
+		subnets[i] = uint64(i)
+	}
+	return subnets
+}
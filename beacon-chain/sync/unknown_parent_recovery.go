@@ -0,0 +1,133 @@
+package sync
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// maxUnknownParentDepth is the maximum number of missing ancestors requested from the peer
+// which sent a block with an unknown parent. Blocks missing a longer chain of ancestors are
+// left in the pending queue for regular sync.
+const maxUnknownParentDepth = 32
+
+// recoverUnknownParent requests the missing ancestors of a gossiped block by root from the
+// peer which sent it, processes them in order and then processes the block itself. If the
+// ancestors cannot be recovered, the block stays in the pending blocks queue.
+func (r *Service) recoverUnknownParent(ctx context.Context, blk *ethpb.SignedBeaconBlock, blkRoot [32]byte, pid peer.ID) {
+	parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
+	if !r.startParentRecovery(parentRoot) {
+		return
+	}
+	defer r.finishParentRecovery(parentRoot)
+
+	ctx, span := trace.StartSpan(ctx, "sync.recoverUnknownParent")
+	defer span.End()
+	log := log.WithFields(logrus.Fields{
+		"slot":      blk.Block.Slot,
+		"blockRoot": hex.EncodeToString(bytesutil.Trunc(blkRoot[:])),
+		"peer":      pid.String(),
+	})
+
+	ancestors, err := r.requestMissingAncestors(ctx, parentRoot, pid)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		unknownParentRecoveryCounter.WithLabelValues("failed").Inc()
+		log.WithError(err).Debug("Could not recover unknown parent, leaving block to regular sync")
+		return
+	}
+	for _, b := range append(ancestors, blk) {
+		root, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			traceutil.AnnotateError(span, err)
+			return
+		}
+		// The pending blocks queue may have processed the block in the meantime.
+		if !r.db.HasBlock(ctx, root) {
+			if err := r.chain.ReceiveBlockNoPubsub(ctx, b); err != nil {
+				traceutil.AnnotateError(span, err)
+				unknownParentRecoveryCounter.WithLabelValues("failed").Inc()
+				log.WithError(err).WithField("ancestorSlot", b.Block.Slot).Debug("Could not process recovered block")
+				return
+			}
+		}
+		r.pendingBlocks.remove(root)
+	}
+
+	// Broadcasting the block again once the node is able to process it.
+	if err := r.p2p.Broadcast(ctx, blk); err != nil {
+		log.WithError(err).Error("Failed to broadcast block")
+	}
+	unknownParentRecoveryCounter.WithLabelValues("recovered").Inc()
+	log.WithField("ancestors", len(ancestors)).Debug("Recovered unknown parent of block")
+}
+
+// requestMissingAncestors requests the blocks from the given root back to the first block
+// known to the node, one root at a time, returning them ordered from oldest to newest.
+func (r *Service) requestMissingAncestors(ctx context.Context, root [32]byte, pid peer.ID) ([]*ethpb.SignedBeaconBlock, error) {
+	finalizedSlot := helpers.StartSlot(r.chain.FinalizedCheckpt().Epoch)
+	var ancestors []*ethpb.SignedBeaconBlock
+	for depth := 0; !r.db.HasBlock(ctx, root); depth++ {
+		if depth == maxUnknownParentDepth {
+			return nil, errors.Errorf("no known ancestor within %d blocks", maxUnknownParentDepth)
+		}
+		blks, err := r.requestBlocksByRoot(ctx, [][32]byte{root}, pid)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not request ancestor")
+		}
+		if len(blks) != 1 || blks[0] == nil || blks[0].Block == nil {
+			return nil, errors.Errorf("peer did not return ancestor %#x", root)
+		}
+		b := blks[0]
+		bRoot, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			return nil, err
+		}
+		if bRoot != root {
+			return nil, errors.Errorf("peer returned block %#x instead of ancestor %#x", bRoot, root)
+		}
+		if b.Block.Slot <= finalizedSlot {
+			return nil, errors.Errorf("ancestor at slot %d is not after the finalized checkpoint", b.Block.Slot)
+		}
+		ancestors = append([]*ethpb.SignedBeaconBlock{b}, ancestors...)
+		root = bytesutil.ToBytes32(b.Block.ParentRoot)
+	}
+	return ancestors, nil
+}
+
+// startParentRecovery marks the recovery of the parent root as in progress, returning false
+// if it already is.
+func (r *Service) startParentRecovery(parentRoot [32]byte) bool {
+	r.recoveringParentsLock.Lock()
+	defer r.recoveringParentsLock.Unlock()
+	if r.recoveringParents == nil {
+		r.recoveringParents = make(map[[32]byte]bool)
+	}
+	if r.recoveringParents[parentRoot] {
+		return false
+	}
+	r.recoveringParents[parentRoot] = true
+	return true
+}
+
+func (r *Service) finishParentRecovery(parentRoot [32]byte) {
+	r.recoveringParentsLock.Lock()
+	defer r.recoveringParentsLock.Unlock()
+	delete(r.recoveringParents, parentRoot)
+}
+
+// isRecoveringParent returns true if the ancestors of the parent root are being requested.
+func (r *Service) isRecoveringParent(parentRoot [32]byte) bool {
+	r.recoveringParentsLock.Lock()
+	defer r.recoveringParentsLock.Unlock()
+	return r.recoveringParents[parentRoot]
+}
@@ -0,0 +1,162 @@
+package sync
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+// b0 - b1 - b2 - b3
+// Test b3 is gossiped with only b0 known, and b2 and b1 are requested from the sender.
+func TestRecoverUnknownParent_RequestsAncestors(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, db)
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+
+	r := &Service{
+		p2p: p1,
+		db:  db,
+		chain: &mock.ChainService{
+			FinalizedCheckPoint: &ethpb.Checkpoint{
+				Epoch: 0,
+			},
+		},
+		pendingBlocks: newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
+	}
+
+	b0 := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{}}
+	if err := db.SaveBlock(context.Background(), b0); err != nil {
+		t.Fatal(err)
+	}
+	blocksByRoot := make(map[[32]byte]*ethpb.SignedBeaconBlock)
+	parentRoot, err := ssz.HashTreeRoot(b0.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blks []*ethpb.SignedBeaconBlock
+	var roots [][32]byte
+	for slot := uint64(1); slot <= 3; slot++ {
+		b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		root, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocksByRoot[root] = b
+		blks = append(blks, b)
+		roots = append(roots, root)
+		parentRoot = root
+	}
+	b3, b3Root := blks[2], roots[2]
+	r.pendingBlocks.add(b3, b3Root)
+
+	pcl := protocol.ID("/eth2/beacon_chain/req/beacon_blocks_by_root/1/ssz")
+	var wg sync.WaitGroup
+	wg.Add(2)
+	var requested [][32]byte
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		out := [][32]byte{}
+		if err := p2.Encoding().DecodeWithLength(stream, &out); err != nil {
+			t.Fatal(err)
+		}
+		requested = append(requested, out...)
+		for _, root := range out {
+			if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
+				t.Fatalf("Failed to write to stream: %v", err)
+			}
+			if _, err := p2.Encoding().EncodeWithLength(stream, blocksByRoot[root]); err != nil {
+				t.Errorf("Could not send response back: %v ", err)
+			}
+		}
+		if err := stream.Close(); err != nil {
+			t.Log(err)
+		}
+	})
+	p1.Connect(p2)
+
+	r.recoverUnknownParent(context.Background(), b3, b3Root, p2.PeerID())
+	if testutil.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	if len(requested) != 2 || requested[0] != roots[1] || requested[1] != roots[0] {
+		t.Errorf("Expected ancestors to be requested from newest to oldest, requested %#x", requested)
+	}
+	if r.pendingBlocks.len() != 0 {
+		t.Error("Expected recovered block to be removed from the pending queue")
+	}
+	if !p1.BroadcastCalled {
+		t.Error("Expected recovered block to be broadcast")
+	}
+	if r.isRecoveringParent(roots[1]) {
+		t.Error("Expected recovery to be finished")
+	}
+}
+
+func TestRecoverUnknownParent_BoundedDepth(t *testing.T) {
+	db := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, db)
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	r := &Service{
+		p2p: p1,
+		db:  db,
+		chain: &mock.ChainService{
+			FinalizedCheckPoint: &ethpb.Checkpoint{
+				Epoch: 0,
+			},
+		},
+	}
+
+	// The peer serves a chain of blocks longer than the depth limit, none of them known.
+	blocksByRoot := make(map[[32]byte]*ethpb.SignedBeaconBlock)
+	parentRoot := testutil.Random32Bytes(t)
+	var head [32]byte
+	for slot := uint64(1); slot <= maxUnknownParentDepth+1; slot++ {
+		b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot}}
+		root, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocksByRoot[root] = b
+		parentRoot = root[:]
+		head = root
+	}
+	pcl := protocol.ID("/eth2/beacon_chain/req/beacon_blocks_by_root/1/ssz")
+	requests := 0
+	p2.Host.SetStreamHandler(pcl, func(stream network.Stream) {
+		out := [][32]byte{}
+		if err := p2.Encoding().DecodeWithLength(stream, &out); err != nil {
+			t.Fatal(err)
+		}
+		requests++
+		if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
+			t.Fatalf("Failed to write to stream: %v", err)
+		}
+		if _, err := p2.Encoding().EncodeWithLength(stream, blocksByRoot[out[0]]); err != nil {
+			t.Errorf("Could not send response back: %v ", err)
+		}
+		if err := stream.Close(); err != nil {
+			t.Log(err)
+		}
+	})
+	p1.Connect(p2)
+
+	if _, err := r.requestMissingAncestors(context.Background(), head, p2.PeerID()); err == nil {
+		t.Error("Expected error for a missing chain longer than the depth limit")
+	}
+	if requests != maxUnknownParentDepth {
+		t.Errorf("Expected %d requests, received %d", maxUnknownParentDepth, requests)
+	}
+}
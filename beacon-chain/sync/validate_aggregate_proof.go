@@ -19,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"github.com/prysmaticlabs/prysm/shared/verifypool"
 	"go.opencensus.io/trace"
 )
 
@@ -35,36 +36,36 @@ func (r *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 	// To process the following it requires the recent blocks to be present in the database, so we'll skip
 	// validating or processing aggregated attestations until fully synced.
 	if r.initialSync.Syncing() {
-		return false
+		return reject(ctx, reasonSyncing)
 	}
 
 	raw, err := r.decodePubsubMessage(msg)
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonDecodeFailure)
 	}
 	m, ok := raw.(*ethpb.SignedAggregateAttestationAndProof)
 	if !ok {
-		return false
+		return reject(ctx, reasonWrongType)
 	}
 
 	if m.Message == nil || m.Message.Aggregate == nil || m.Message.Aggregate.Data == nil {
-		return false
+		return reject(ctx, reasonMalformed)
 	}
 	// Verify this is the first aggregate received from the aggregator with index and slot.
 	if r.hasSeenAggregatorIndexEpoch(m.Message.Aggregate.Data.Target.Epoch, m.Message.AggregatorIndex) {
-		return false
+		return reject(ctx, reasonDuplicate)
 	}
 
 	// Verify aggregate attestation has not already been seen via aggregate gossip, within a block, or through the creation locally.
 	seen, err := r.attPool.HasAggregatedAttestation(m.Message.Aggregate)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonInternalError)
 	}
 	if seen {
-		return false
+		return reject(ctx, reasonDuplicate)
 	}
 	if !r.validateBlockInAttestation(ctx, m) {
 		return false
@@ -88,13 +89,13 @@ func (r *Service) validateAggregatedAtt(ctx context.Context, signed *ethpb.Signe
 	attSlot := signed.Message.Aggregate.Data.Slot
 	if err := validateAggregateAttTime(attSlot, uint64(r.chain.GenesisTime().Unix())); err != nil {
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonInvalidTiming)
 	}
 
 	s, err := r.chain.HeadState(ctx)
 	if err != nil {
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonInternalError)
 	}
 
 	// Only advance state if different epoch as the committee can only change on an epoch transition.
@@ -102,34 +103,36 @@ func (r *Service) validateAggregatedAtt(ctx context.Context, signed *ethpb.Signe
 		s, err = state.ProcessSlots(ctx, s, helpers.StartSlot(helpers.SlotToEpoch(attSlot)))
 		if err != nil {
 			traceutil.AnnotateError(span, err)
-			return false
+			return reject(ctx, reasonInternalError)
 		}
 	}
 
 	// Verify validator index is within the aggregate's committee.
 	if err := validateIndexInCommittee(ctx, s, signed.Message.Aggregate, signed.Message.AggregatorIndex); err != nil {
 		traceutil.AnnotateError(span, errors.Wrapf(err, "Could not validate index in committee"))
-		return false
+		return reject(ctx, reasonInvalidCommittee)
 	}
 
-	// Verify selection proof reflects to the right validator and signature is valid.
-	if err := validateSelection(ctx, s, signed.Message.Aggregate.Data, signed.Message.AggregatorIndex, signed.Message.SelectionProof); err != nil {
-		traceutil.AnnotateError(span, errors.Wrapf(err, "Could not validate selection for validator %d", signed.Message.AggregatorIndex))
-		return false
-	}
+	// The signatures are verified by the shared signature verification workers.
+	if err := verifypool.Run(ctx, func() error {
+		// Verify selection proof reflects to the right validator and signature is valid.
+		if err := validateSelection(ctx, s, signed.Message.Aggregate.Data, signed.Message.AggregatorIndex, signed.Message.SelectionProof); err != nil {
+			return errors.Wrapf(err, "Could not validate selection for validator %d", signed.Message.AggregatorIndex)
+		}
 
-	// Verify the aggregator's signature is valid.
-	if err := validateAggregatorSignature(s, signed); err != nil {
-		traceutil.AnnotateError(span, errors.Wrapf(err, "Could not verify aggregator signature %d", signed.Message.AggregatorIndex))
-		return false
-	}
+		// Verify the aggregator's signature is valid.
+		if err := validateAggregatorSignature(s, signed); err != nil {
+			return errors.Wrapf(err, "Could not verify aggregator signature %d", signed.Message.AggregatorIndex)
+		}
 
-	// Verify aggregated attestation has a valid signature.
-	if !featureconfig.Get().DisableStrictAttestationPubsubVerification {
-		if err := blocks.VerifyAttestation(ctx, s, signed.Message.Aggregate); err != nil {
-			traceutil.AnnotateError(span, err)
-			return false
+		// Verify aggregated attestation has a valid signature.
+		if !featureconfig.Get().DisableStrictAttestationPubsubVerification {
+			return blocks.VerifyAttestation(ctx, s, signed.Message.Aggregate)
 		}
+		return nil
+	}); err != nil {
+		traceutil.AnnotateError(span, err)
+		return reject(ctx, reasonInvalidSignature)
 	}
 
 	return true
@@ -145,7 +148,7 @@ func (r *Service) validateBlockInAttestation(ctx context.Context, s *ethpb.Signe
 	if !(hasState && hasBlock) {
 		// A node doesn't have the block, it'll request from peer while saving the pending attestation to a queue.
 		r.savePendingAtt(s)
-		return false
+		return reject(ctx, reasonUnknownBlock)
 	}
 	return true
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"github.com/prysmaticlabs/prysm/shared/verifypool"
 	"go.opencensus.io/trace"
 )
 
@@ -27,7 +28,7 @@ func (r *Service) validateAttesterSlashing(ctx context.Context, pid peer.ID, msg
 
 	// The head state will be too far away to validate any slashing.
 	if r.initialSync.Syncing() {
-		return false
+		return reject(ctx, reasonSyncing)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateAttesterSlashing")
@@ -37,40 +38,42 @@ func (r *Service) validateAttesterSlashing(ctx context.Context, pid peer.ID, msg
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonDecodeFailure)
 	}
 	slashing, ok := m.(*ethpb.AttesterSlashing)
 	if !ok {
-		return false
+		return reject(ctx, reasonWrongType)
 	}
 
 	if slashing == nil || slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
-		return false
+		return reject(ctx, reasonMalformed)
 	}
 	if r.hasSeenAttesterSlashingIndices(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices) {
-		return false
+		return reject(ctx, reasonDuplicate)
 	}
 
 	// Retrieve head state, advance state to the epoch slot used specified in slashing message.
 	s, err := r.chain.HeadState(ctx)
 	if err != nil {
-		return false
+		return reject(ctx, reasonInternalError)
 	}
 	slashSlot := slashing.Attestation_1.Data.Target.Epoch * params.BeaconConfig().SlotsPerEpoch
 	if s.Slot() < slashSlot {
 		if ctx.Err() != nil {
-			return false
+			return reject(ctx, reasonInternalError)
 		}
 
 		var err error
 		s, err = state.ProcessSlots(ctx, s, slashSlot)
 		if err != nil {
-			return false
+			return reject(ctx, reasonInternalError)
 		}
 	}
 
-	if err := blocks.VerifyAttesterSlashing(ctx, s, slashing); err != nil {
-		return false
+	if err := verifypool.Run(ctx, func() error {
+		return blocks.VerifyAttesterSlashing(ctx, s, slashing)
+	}); err != nil {
+		return reject(ctx, reasonInvalid)
 	}
 
 	msg.ValidatorData = slashing // Used in downstream subscriber
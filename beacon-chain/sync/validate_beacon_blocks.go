@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"github.com/prysmaticlabs/prysm/shared/verifypool"
 	"go.opencensus.io/trace"
 )
 
@@ -29,7 +30,7 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 
 	// We should not attempt to process blocks until fully synced, but propagation is OK.
 	if r.initialSync.Syncing() {
-		return false
+		return reject(ctx, reasonSyncing)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateBeaconBlockPubSub")
@@ -39,7 +40,7 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonDecodeFailure)
 	}
 
 	r.validateBlockLock.Lock()
@@ -47,55 +48,54 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 
 	blk, ok := m.(*ethpb.SignedBeaconBlock)
 	if !ok {
-		return false
+		return reject(ctx, reasonWrongType)
 	}
 
 	if blk.Block == nil {
-		return false
+		return reject(ctx, reasonMalformed)
 	}
 
 	// Verify the block is the first block received for the proposer for the slot.
 	if r.hasSeenBlockIndexSlot(blk.Block.Slot, blk.Block.ProposerIndex) {
-		return false
+		return reject(ctx, reasonDuplicate)
 	}
 
 	blockRoot, err := ssz.HashTreeRoot(blk.Block)
 	if err != nil {
-		return false
+		return reject(ctx, reasonInternalError)
 	}
 	if r.db.HasBlock(ctx, blockRoot) {
-		return false
+		return reject(ctx, reasonDuplicate)
 	}
 
-	r.pendingQueueLock.RLock()
-	if r.seenPendingBlocks[blockRoot] {
-		r.pendingQueueLock.RUnlock()
-		return false
+	if r.pendingBlocks.has(blockRoot) {
+		return reject(ctx, reasonDuplicate)
 	}
-	r.pendingQueueLock.RUnlock()
 
 	// Add metrics for block arrival time subtracts slot start time.
 	if captureArrivalTimeMetric(uint64(r.chain.GenesisTime().Unix()), blk.Block.Slot) != nil {
-		return false
+		return reject(ctx, reasonInternalError)
 	}
 
 	if err := helpers.VerifySlotTime(uint64(r.chain.GenesisTime().Unix()), blk.Block.Slot, maximumGossipClockDisparity); err != nil {
 		log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Rejecting incoming block.")
-		return false
+		return reject(ctx, reasonInvalidTiming)
 	}
 
 	if helpers.StartSlot(r.chain.FinalizedCheckpt().Epoch) >= blk.Block.Slot {
 		log.Debug("Block slot older/equal than last finalized epoch start slot, rejecting it")
-		return false
+		return reject(ctx, reasonFinalized)
 	}
 
 	// Handle block when the parent is unknown.
 	if !r.db.HasBlock(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot)) {
-		r.pendingQueueLock.Lock()
-		r.slotToPendingBlocks[blk.Block.Slot] = blk
-		r.seenPendingBlocks[blockRoot] = true
-		r.pendingQueueLock.Unlock()
-		return false
+		r.pendingBlocks.add(blk, blockRoot)
+		// Request the missing ancestors from the sender right away rather than waiting for
+		// the pending blocks queue. An empty peer ID means there is no sender to ask.
+		if pid != "" {
+			go r.recoverUnknownParent(r.ctx, blk, blockRoot, pid)
+		}
+		return reject(ctx, reasonUnknownParent)
 	}
 
 	if featureconfig.Get().NewStateMgmt {
@@ -103,32 +103,34 @@ func (r *Service) validateBeaconBlockPubSub(ctx context.Context, pid peer.ID, ms
 		hasStateSummaryCache := r.stateSummaryCache.Has(bytesutil.ToBytes32(blk.Block.ParentRoot))
 		if !hasStateSummaryDB && !hasStateSummaryCache {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("No access to parent state")
-			return false
+			return reject(ctx, reasonUnknownParent)
 		}
 		parentState, err := r.stateGen.StateByRoot(ctx, bytesutil.ToBytes32(blk.Block.ParentRoot))
 		if err != nil {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Could not get parent state")
-			return false
+			return reject(ctx, reasonInternalError)
 		}
 
-		if err := blocks.VerifyBlockHeaderSignature(parentState, blk); err != nil {
+		if err := verifypool.Run(ctx, func() error {
+			return blocks.VerifyBlockHeaderSignature(parentState, blk)
+		}); err != nil {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Could not verify block signature")
-			return false
+			return reject(ctx, reasonInvalidSignature)
 		}
 
 		err = parentState.SetSlot(blk.Block.Slot)
 		if err != nil {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Could not set parent state slot")
-			return false
+			return reject(ctx, reasonInternalError)
 		}
 		idx, err := helpers.BeaconProposerIndex(parentState)
 		if err != nil {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Could not get proposer index using parent state")
-			return false
+			return reject(ctx, reasonInternalError)
 		}
 		if blk.Block.ProposerIndex != idx {
 			log.WithError(err).WithField("blockSlot", blk.Block.Slot).Warn("Incorrect proposer index")
-			return false
+			return reject(ctx, reasonInvalidProposer)
 		}
 	}
 
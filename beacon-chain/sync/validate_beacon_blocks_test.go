@@ -60,6 +60,7 @@ func TestValidateBeaconBlockPubSub_InvalidSignature(t *testing.T) {
 				Epoch: 0,
 			}},
 		seenBlockCache: c,
+		pendingBlocks:  newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
 	}
 
 	buf := new(bytes.Buffer)
@@ -195,11 +196,10 @@ func TestValidateBeaconBlockPubSub_ValidProposerSignature(t *testing.T) {
 			FinalizedCheckPoint: &ethpb.Checkpoint{
 				Epoch: 0,
 			}},
-		seenBlockCache:      c,
-		slotToPendingBlocks: make(map[uint64]*ethpb.SignedBeaconBlock),
-		seenPendingBlocks:   make(map[[32]byte]bool),
-		stateSummaryCache:   stateSummaryCache,
-		stateGen:            stateGen,
+		seenBlockCache:    c,
+		pendingBlocks:     newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
+		stateSummaryCache: stateSummaryCache,
+		stateGen:          stateGen,
 	}
 
 	buf := new(bytes.Buffer)
@@ -295,13 +295,12 @@ func TestValidateBeaconBlockPubSub_RejectBlocksFromFuture(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
-		p2p:                 p,
-		db:                  db,
-		initialSync:         &mockSync.Sync{IsSyncing: false},
-		chain:               &mock.ChainService{Genesis: time.Now()},
-		seenBlockCache:      c,
-		slotToPendingBlocks: make(map[uint64]*ethpb.SignedBeaconBlock),
-		seenPendingBlocks:   make(map[[32]byte]bool),
+		p2p:            p,
+		db:             db,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
+		chain:          &mock.ChainService{Genesis: time.Now()},
+		seenBlockCache: c,
+		pendingBlocks:  newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
 	}
 
 	buf := new(bytes.Buffer)
@@ -356,6 +355,7 @@ func TestValidateBeaconBlockPubSub_RejectBlocksFromThePast(t *testing.T) {
 				Epoch: 1,
 			}},
 		seenBlockCache: c,
+		pendingBlocks:  newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
 	}
 
 	buf := new(bytes.Buffer)
@@ -436,10 +436,9 @@ func TestValidateBeaconBlockPubSub_SeenProposerSlot(t *testing.T) {
 			FinalizedCheckPoint: &ethpb.Checkpoint{
 				Epoch: 0,
 			}},
-		seenBlockCache:      c,
-		slotToPendingBlocks: make(map[uint64]*ethpb.SignedBeaconBlock),
-		seenPendingBlocks:   make(map[[32]byte]bool),
-		stateSummaryCache:   cache.NewStateSummaryCache(),
+		seenBlockCache:    c,
+		pendingBlocks:     newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
+		stateSummaryCache: cache.NewStateSummaryCache(),
 	}
 
 	buf := new(bytes.Buffer)
@@ -492,6 +491,7 @@ func TestValidateBeaconBlockPubSub_FilterByFinalizedEpoch(t *testing.T) {
 		attPool:        attestations.NewPool(),
 		seenBlockCache: c,
 		initialSync:    &mockSync.Sync{IsSyncing: false},
+		pendingBlocks:  newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
 	}
 
 	b := &ethpb.SignedBeaconBlock{
@@ -29,7 +29,7 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	// Attestation processing requires the target block to be present in the database, so we'll skip
 	// validating or processing attestations until fully synced.
 	if s.initialSync.Syncing() {
-		return false
+		return reject(ctx, reasonSyncing)
 	}
 	ctx, span := trace.StartSpan(ctx, "sync.validateCommitteeIndexBeaconAttestation")
 	defer span.End()
@@ -43,22 +43,22 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonDecodeFailure)
 	}
 	// Restore topic.
 	msg.TopicIDs[0] = originalTopic
 
 	att, ok := m.(*eth.Attestation)
 	if !ok {
-		return false
+		return reject(ctx, reasonWrongType)
 	}
 
 	if att.Data == nil {
-		return false
+		return reject(ctx, reasonMalformed)
 	}
 	// Verify this the first attestation received for the participating validator for the slot.
 	if s.hasSeenCommitteeIndicesSlot(att.Data.Slot, att.Data.CommitteeIndex, att.AggregationBits) {
-		return false
+		return reject(ctx, reasonDuplicate)
 	}
 
 	// The attestation's committee index (attestation.data.index) is for the correct subnet.
@@ -66,21 +66,21 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if err != nil {
 		log.WithError(err).Error("Failed to compute fork digest")
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonInternalError)
 	}
 	if !strings.HasPrefix(originalTopic, fmt.Sprintf(format, digest, att.Data.CommitteeIndex)) {
-		return false
+		return reject(ctx, reasonWrongSubnet)
 	}
 
 	// Attestation must be unaggregated.
 	if att.AggregationBits == nil || att.AggregationBits.Count() != 1 {
-		return false
+		return reject(ctx, reasonMalformed)
 	}
 
 	// Attestation's slot is within ATTESTATION_PROPAGATION_SLOT_RANGE.
 	if err := validateAggregateAttTime(att.Data.Slot, uint64(s.chain.GenesisTime().Unix())); err != nil {
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonInvalidTiming)
 	}
 
 	// Verify the block being voted and the processed state is in DB and. The block should have passed validation if it's in the DB.
@@ -91,12 +91,13 @@ func (s *Service) validateCommitteeIndexBeaconAttestation(ctx context.Context, p
 	if !(hasState && hasBlock) {
 		// A node doesn't have the block, it'll request from peer while saving the pending attestation to a queue.
 		s.savePendingAtt(&eth.SignedAggregateAttestationAndProof{Message: &eth.AggregateAttestationAndProof{Aggregate: att}})
-		return false
+		return reject(ctx, reasonUnknownBlock)
 	}
 
-	// Attestation's signature is a valid BLS signature and belongs to correct public key..
-	if !featureconfig.Get().DisableStrictAttestationPubsubVerification && !s.chain.IsValidAttestation(ctx, att) {
-		return false
+	// Attestation's signature is a valid BLS signature and belongs to correct public key. Signatures
+	// are verified in batches with the other attestations received on the subnet.
+	if !featureconfig.Get().DisableStrictAttestationPubsubVerification && !s.verifyAttestationSignature(ctx, att) {
+		return reject(ctx, reasonInvalidSignature)
 	}
 
 	s.setSeenCommitteeIndicesSlot(att.Data.Slot, att.Data.CommitteeIndex, att.AggregationBits)
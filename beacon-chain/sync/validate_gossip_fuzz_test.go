@@ -0,0 +1,122 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	fuzz "github.com/google/gofuzz"
+	lru "github.com/hashicorp/golang-lru"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+// maxGossipFuzzHeapGrowth bounds how much the live heap may grow while feeding
+// arbitrary messages through the gossip validators.
+const maxGossipFuzzHeapGrowth = 64 << 20
+
+// TestFuzzGossipValidators_1000 feeds arbitrary bytes, and arbitrary messages which
+// decode successfully, through every gossip topic validator. The validators are called
+// directly rather than through wrapAndReportValidation, so panics are not recovered
+// and fail the test.
+func TestFuzzGossipValidators_1000(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, db)
+	p := p2ptest.NewTestP2P(t)
+	beaconState, _ := testutil.DeterministicGenesisState(t, 64)
+
+	newCache := func() *lru.Cache {
+		c, err := lru.New(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	stateSummaryCache := cache.NewStateSummaryCache()
+	r := &Service{
+		db:          db,
+		p2p:         p,
+		initialSync: &mockSync.Sync{IsSyncing: false},
+		chain: &mock.ChainService{
+			State:               beaconState,
+			Genesis:             time.Now(),
+			FinalizedCheckPoint: &ethpb.Checkpoint{Root: make([]byte, 32)},
+			ValidAttestation:    true,
+		},
+		attPool:                   attestations.NewPool(),
+		pendingBlocks:             newPendingBlocksBuffer(maxPendingBlocks, pendingBlockExpiry),
+		blkRootToPendingAtts:      make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
+		seenBlockCache:            newCache(),
+		seenAttestationCache:      newCache(),
+		seenExitCache:             newCache(),
+		seenProposerSlashingCache: newCache(),
+		seenAttesterSlashingCache: newCache(),
+		stateSummaryCache:         stateSummaryCache,
+		stateGen:                  stategen.New(db, stateSummaryCache),
+	}
+
+	validators := []struct {
+		msg      proto.Message
+		validate pubsub.Validator
+	}{
+		{msg: &ethpb.SignedBeaconBlock{}, validate: r.validateBeaconBlockPubSub},
+		{msg: &ethpb.Attestation{}, validate: r.validateCommitteeIndexBeaconAttestation},
+		{msg: &ethpb.SignedAggregateAttestationAndProof{}, validate: r.validateAggregateAndProof},
+		{msg: &ethpb.SignedVoluntaryExit{}, validate: r.validateVoluntaryExit},
+		{msg: &ethpb.ProposerSlashing{}, validate: r.validateProposerSlashing},
+		{msg: &ethpb.AttesterSlashing{}, validate: r.validateAttesterSlashing},
+	}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	fuzzer := fuzz.NewWithSeed(0)
+	for _, v := range validators {
+		topic := p2p.GossipTypeMapping[reflect.TypeOf(v.msg)]
+		for i := 0; i < 1000; i++ {
+			var data []byte
+			fuzzer.Fuzz(&data)
+			v.validate(ctx, "", gossipFuzzMessage(topic, data))
+
+			msg := proto.Clone(v.msg)
+			fuzzer.Fuzz(msg)
+			buf := new(bytes.Buffer)
+			// Fuzzed messages with fields of the wrong size can not be encoded.
+			if _, err := p.Encoding().EncodeGossip(buf, msg); err != nil {
+				continue
+			}
+			v.validate(ctx, "", gossipFuzzMessage(topic, buf.Bytes()))
+		}
+	}
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	if after.HeapAlloc > before.HeapAlloc && after.HeapAlloc-before.HeapAlloc > maxGossipFuzzHeapGrowth {
+		t.Errorf("Heap grew by %d bytes, more than the allowed %d bytes", after.HeapAlloc-before.HeapAlloc, maxGossipFuzzHeapGrowth)
+	}
+}
+
+func gossipFuzzMessage(topic string, data []byte) *pubsub.Message {
+	return &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data:     data,
+			TopicIDs: []string{topic},
+		},
+	}
+}
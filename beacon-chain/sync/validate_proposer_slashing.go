@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"github.com/prysmaticlabs/prysm/shared/verifypool"
 	"go.opencensus.io/trace"
 )
 
@@ -23,7 +24,7 @@ func (r *Service) validateProposerSlashing(ctx context.Context, pid peer.ID, msg
 
 	// The head state will be too far away to validate any slashing.
 	if r.initialSync.Syncing() {
-		return false
+		return reject(ctx, reasonSyncing)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateProposerSlashing")
@@ -33,40 +34,42 @@ func (r *Service) validateProposerSlashing(ctx context.Context, pid peer.ID, msg
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonDecodeFailure)
 	}
 
 	slashing, ok := m.(*ethpb.ProposerSlashing)
 	if !ok {
-		return false
+		return reject(ctx, reasonWrongType)
 	}
 
 	if slashing.Header_1 == nil || slashing.Header_1.Header == nil {
-		return false
+		return reject(ctx, reasonMalformed)
 	}
 	if r.hasSeenProposerSlashingIndex(slashing.Header_1.Header.ProposerIndex) {
-		return false
+		return reject(ctx, reasonDuplicate)
 	}
 
 	// Retrieve head state, advance state to the epoch slot used specified in slashing message.
 	s, err := r.chain.HeadState(ctx)
 	if err != nil {
-		return false
+		return reject(ctx, reasonInternalError)
 	}
 	slashSlot := slashing.Header_1.Header.Slot
 	if s.Slot() < slashSlot {
 		if ctx.Err() != nil {
-			return false
+			return reject(ctx, reasonInternalError)
 		}
 		var err error
 		s, err = state.ProcessSlots(ctx, s, slashSlot)
 		if err != nil {
-			return false
+			return reject(ctx, reasonInternalError)
 		}
 	}
 
-	if err := blocks.VerifyProposerSlashing(s, slashing); err != nil {
-		return false
+	if err := verifypool.Run(ctx, func() error {
+		return blocks.VerifyProposerSlashing(s, slashing)
+	}); err != nil {
+		return reject(ctx, reasonInvalid)
 	}
 
 	msg.ValidatorData = slashing // Used in downstream subscriber
@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
+	"github.com/prysmaticlabs/prysm/shared/verifypool"
 	"go.opencensus.io/trace"
 )
 
@@ -23,7 +24,7 @@ func (r *Service) validateVoluntaryExit(ctx context.Context, pid peer.ID, msg *p
 
 	// The head state will be too far away to validate any voluntary exit.
 	if r.initialSync.Syncing() {
-		return false
+		return reject(ctx, reasonSyncing)
 	}
 
 	ctx, span := trace.StartSpan(ctx, "sync.validateVoluntaryExit")
@@ -33,36 +34,38 @@ func (r *Service) validateVoluntaryExit(ctx context.Context, pid peer.ID, msg *p
 	if err != nil {
 		log.WithError(err).Error("Failed to decode message")
 		traceutil.AnnotateError(span, err)
-		return false
+		return reject(ctx, reasonDecodeFailure)
 	}
 
 	exit, ok := m.(*ethpb.SignedVoluntaryExit)
 	if !ok {
-		return false
+		return reject(ctx, reasonWrongType)
 	}
 
 	if exit.Exit == nil {
-		return false
+		return reject(ctx, reasonMalformed)
 	}
 	if r.hasSeenExitIndex(exit.Exit.ValidatorIndex) {
-		return false
+		return reject(ctx, reasonDuplicate)
 	}
 
 	s, err := r.chain.HeadState(ctx)
 	if err != nil {
-		return false
+		return reject(ctx, reasonInternalError)
 	}
 
 	exitedEpochSlot := exit.Exit.Epoch * params.BeaconConfig().SlotsPerEpoch
 	if int(exit.Exit.ValidatorIndex) >= s.NumValidators() {
-		return false
+		return reject(ctx, reasonInvalid)
 	}
 	val, err := s.ValidatorAtIndex(exit.Exit.ValidatorIndex)
 	if err != nil {
-		return false
+		return reject(ctx, reasonInvalid)
 	}
-	if err := blocks.VerifyExit(val, exitedEpochSlot, s.Fork(), exit, s.GenesisValidatorRoot()); err != nil {
-		return false
+	if err := verifypool.Run(ctx, func() error {
+		return blocks.VerifyExit(val, exitedEpochSlot, s.Fork(), exit, s.GenesisValidatorRoot())
+	}); err != nil {
+		return reject(ctx, reasonInvalid)
 	}
 
 	msg.ValidatorData = exit // Used in downstream subscriber
@@ -0,0 +1,196 @@
+package sync
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/messagehandler"
+)
+
+const (
+	// The number of messages validated concurrently for each block topic. Block validation is
+	// serialized by the block validation lock, so a few workers are enough.
+	blockValidationWorkers = 4
+	// The number of messages validated concurrently for each attestation topic. Attestation
+	// signatures are verified in batches, so enough workers are needed to fill a batch.
+	attestationValidationWorkers = attBatchLimit
+	// The queue size and number of workers of the other gossip topics.
+	defaultValidationQueueSize = 256
+	defaultValidationWorkers   = 4
+)
+
+// dropPolicy decides what happens to a gossip message arriving at a full validation queue.
+type dropPolicy int
+
+const (
+	// dropOldest drops the oldest queued message to make room for the new one.
+	dropOldest dropPolicy = iota
+	// neverDrop holds the new message until there is room in the queue.
+	neverDrop
+)
+
+// validationRequest is a gossip message waiting in a validation queue.
+type validationRequest struct {
+	ctx      context.Context
+	pid      peer.ID
+	msg      *pubsub.Message
+	validate pubsub.Validator
+	result   chan bool
+}
+
+// validationQueue is a bounded queue of the gossip messages of a topic waiting for
+// validation, validated by a fixed number of workers. Each topic having its own queue and
+// workers, a flood of messages on one topic cannot delay the validation of another.
+type validationQueue struct {
+	topic   string
+	policy  dropPolicy
+	lock    sync.Mutex
+	pending []*validationRequest
+	// capacity holds a token for each queued message, bounding the size of the queue.
+	capacity chan struct{}
+	// ready holds a token for each queued message the workers have not taken yet.
+	ready chan struct{}
+}
+
+func newValidationQueue(ctx context.Context, topic string, size int, workers int, policy dropPolicy) *validationQueue {
+	q := &validationQueue{
+		topic:    topic,
+		policy:   policy,
+		capacity: make(chan struct{}, size),
+		ready:    make(chan struct{}, size),
+	}
+	for i := 0; i < workers; i++ {
+		go q.run(ctx)
+	}
+	return q
+}
+
+// validator wraps the pubsub validator so that messages are validated by the workers of the
+// queue.
+func (q *validationQueue) validator(v pubsub.Validator) pubsub.Validator {
+	return func(ctx context.Context, pid peer.ID, msg *pubsub.Message) bool {
+		req := &validationRequest{
+			ctx:      ctx,
+			pid:      pid,
+			msg:      msg,
+			validate: v,
+			result:   make(chan bool, 1),
+		}
+		if !q.push(req) {
+			return reject(ctx, reasonQueueSaturated)
+		}
+		select {
+		case valid := <-req.result:
+			return valid
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// push adds the request to the queue. When the queue is full, the oldest request is dropped
+// or the request waits for room according to the drop policy of the queue. It returns false
+// if the request could not be queued.
+func (q *validationQueue) push(req *validationRequest) bool {
+	select {
+	case q.capacity <- struct{}{}:
+	default:
+		validationQueueSaturatedCounter.WithLabelValues(q.topic).Inc()
+		if q.policy == dropOldest {
+			return q.replaceOldest(req)
+		}
+		select {
+		case q.capacity <- struct{}{}:
+		case <-req.ctx.Done():
+			return false
+		}
+	}
+	q.lock.Lock()
+	q.pending = append(q.pending, req)
+	validationQueueLengthGauge.WithLabelValues(q.topic).Set(float64(len(q.pending)))
+	q.lock.Unlock()
+	q.ready <- struct{}{}
+	return true
+}
+
+// replaceOldest drops the oldest request of a full queue and queues the given request in
+// its place.
+func (q *validationQueue) replaceOldest(req *validationRequest) bool {
+	q.lock.Lock()
+	if len(q.pending) == 0 {
+		// Every queued request was taken by a worker while the queue was full.
+		q.lock.Unlock()
+		return false
+	}
+	oldest := q.pending[0]
+	q.pending[0] = nil
+	q.pending = append(q.pending[1:], req)
+	q.lock.Unlock()
+
+	validationQueueDroppedCounter.WithLabelValues(q.topic).Inc()
+	oldest.result <- reject(oldest.ctx, reasonQueueSaturated)
+	return true
+}
+
+// run validates queued requests until the context is closed.
+func (q *validationQueue) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.ready:
+		}
+		q.lock.Lock()
+		req := q.pending[0]
+		q.pending[0] = nil
+		q.pending = q.pending[1:]
+		validationQueueLengthGauge.WithLabelValues(q.topic).Set(float64(len(q.pending)))
+		q.lock.Unlock()
+		<-q.capacity
+
+		// The message may have timed out while waiting in the queue.
+		if req.ctx.Err() != nil {
+			req.result <- false
+			continue
+		}
+		req.result <- q.validate(req)
+	}
+}
+
+func (q *validationQueue) validate(req *validationRequest) (valid bool) {
+	defer messagehandler.HandlePanic(req.ctx, req.msg)
+	return req.validate(req.ctx, req.pid, req.msg)
+}
+
+// validationQueue returns the validation queue of the topic, creating it if the topic was
+// not subscribed to before. The size and drop policy of the queue depend on the type of the
+// messages of the topic.
+func (r *Service) validationQueue(topic string, base proto.Message) *validationQueue {
+	r.validationQueuesLock.Lock()
+	defer r.validationQueuesLock.Unlock()
+	if r.validationQueues == nil {
+		r.validationQueues = make(map[string]*validationQueue)
+	}
+	if q, ok := r.validationQueues[topic]; ok {
+		return q
+	}
+
+	size, workers, policy := defaultValidationQueueSize, defaultValidationWorkers, neverDrop
+	switch base.(type) {
+	case *pb.SignedBeaconBlock:
+		size, workers = flags.Get().BlockValidationQueueSize, blockValidationWorkers
+	case *pb.Attestation, *pb.SignedAggregateAttestationAndProof:
+		size, workers, policy = flags.Get().AttestationValidationQueueSize, attestationValidationWorkers, dropOldest
+	}
+	if size <= 0 {
+		size = defaultValidationQueueSize
+	}
+	q := newValidationQueue(r.ctx, topic, size, workers, policy)
+	r.validationQueues[topic] = q
+	return q
+}
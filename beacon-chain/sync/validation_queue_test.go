@@ -0,0 +1,141 @@
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// blockingValidator returns a validator which blocks until released, signalling each
+// message it starts validating.
+func blockingValidator(started chan<- struct{}, release <-chan struct{}) pubsub.Validator {
+	return func(ctx context.Context, _ peer.ID, _ *pubsub.Message) bool {
+		started <- struct{}{}
+		<-release
+		return true
+	}
+}
+
+func TestValidationQueue_DropOldest(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := newValidationQueue(ctx, "drop_oldest", 1, 1, dropOldest)
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	v := q.validator(blockingValidator(started, release))
+
+	results := make([]chan bool, 3)
+	for i := range results {
+		results[i] = make(chan bool, 1)
+		go func(res chan bool) {
+			res <- v(context.Background(), "", &pubsub.Message{})
+		}(results[i])
+		if i == 0 {
+			// Wait for the worker to be busy with the first message.
+			<-started
+		} else {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}
+
+	// The second message was queued while the worker was busy and dropped for the third.
+	select {
+	case valid := <-results[1]:
+		if valid {
+			t.Error("Expected dropped message to fail validation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected oldest queued message to be dropped")
+	}
+	close(release)
+	for _, i := range []int{0, 2} {
+		select {
+		case valid := <-results[i]:
+			if !valid {
+				t.Errorf("Expected message %d to pass validation", i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected message %d to be validated", i)
+		}
+	}
+}
+
+func TestValidationQueue_NeverDrop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q := newValidationQueue(ctx, "never_drop", 1, 1, neverDrop)
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	v := q.validator(blockingValidator(started, release))
+
+	results := make(chan bool, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			results <- v(context.Background(), "", &pubsub.Message{})
+		}()
+	}
+	<-started
+	select {
+	case <-results:
+		t.Fatal("Expected messages to wait for room in the queue")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	for i := 0; i < 3; i++ {
+		select {
+		case valid := <-results:
+			if !valid {
+				t.Error("Expected every message to pass validation")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected every message to be validated")
+		}
+	}
+}
+
+func TestValidationQueue_AttestationFloodDoesNotDelayBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &Service{ctx: ctx}
+	attQueue := r.validationQueue("attestation", &ethpb.Attestation{})
+	if attQueue.policy != dropOldest {
+		t.Error("Expected attestations to be dropped from a full queue")
+	}
+	blockQueue := r.validationQueue("block", &ethpb.SignedBeaconBlock{})
+	if blockQueue.policy != neverDrop {
+		t.Error("Expected blocks never to be dropped from a full queue")
+	}
+	if r.validationQueue("block", &ethpb.SignedBeaconBlock{}) != blockQueue {
+		t.Error("Expected the queue of a topic to be reused")
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	attValidator := attQueue.validator(func(ctx context.Context, _ peer.ID, _ *pubsub.Message) bool {
+		<-release
+		return true
+	})
+	for i := 0; i < 2*attestationValidationWorkers; i++ {
+		go attValidator(ctx, "", &pubsub.Message{})
+	}
+
+	blockValidator := blockQueue.validator(func(ctx context.Context, _ peer.ID, _ *pubsub.Message) bool {
+		return true
+	})
+	done := make(chan bool, 1)
+	go func() {
+		done <- blockValidator(context.Background(), "", &pubsub.Message{})
+	}()
+	select {
+	case valid := <-done:
+		if !valid {
+			t.Error("Expected block to pass validation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected block to be validated while attestations are pending")
+	}
+}
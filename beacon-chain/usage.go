@@ -49,18 +49,29 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RelayNode,
 			cmd.P2PUDPPort,
 			cmd.P2PTCPPort,
+			cmd.P2PQUICPort,
 			cmd.DataDirFlag,
 			cmd.VerbosityFlag,
+			cmd.LogLevelOverrideFlag,
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
 			cmd.TracingEndpointFlag,
+			cmd.TracingExporterFlag,
+			cmd.TracingTagsFlag,
 			cmd.TraceSampleFractionFlag,
 			flags.MonitoringPortFlag,
+			cmd.MonitoringHostFlag,
+			cmd.MonitoringCorsDomainFlag,
+			cmd.HTTPTLSCertFlag,
+			cmd.HTTPTLSKeyFlag,
+			cmd.HTTPDisabledEndpointsFlag,
 			cmd.DisableMonitoringFlag,
 			cmd.MaxGoroutines,
 			cmd.ForceClearDB,
 			cmd.ClearDB,
 			cmd.ConfigFileFlag,
+			cmd.ChainConfigFileFlag,
+			cmd.NetworkFlag,
 		},
 	},
 	{
@@ -72,6 +83,8 @@ var appHelpFlagGroups = []flagGroup{
 			debug.MemProfileRateFlag,
 			debug.CPUProfileFlag,
 			debug.TraceFlag,
+			debug.GCPercentFlag,
+			debug.GCBallastFlag,
 		},
 	},
 	{
@@ -85,16 +98,53 @@ var appHelpFlagGroups = []flagGroup{
 			flags.RPCHost,
 			flags.RPCPort,
 			flags.RPCMaxPageSize,
+			flags.RPCMaxRecvMsgSize,
+			flags.RPCMaxSendMsgSize,
+			flags.RPCMaxConcurrentStreams,
+			flags.RPCMaxConnections,
+			flags.RPCKeepaliveMinTime,
+			flags.RPCKeepalivePermitWithoutStream,
 			flags.CertFlag,
 			flags.KeyFlag,
+			flags.GRPCGatewayHost,
 			flags.GRPCGatewayPort,
+			flags.GPRCGatewayCorsDomain,
 			flags.HTTPWeb3ProviderFlag,
-			flags.SetGCPercent,
+			flags.Eth1ChainIDFlag,
+			flags.DepositSnapshotFlag,
+			flags.ExportDepositSnapshotFlag,
 			flags.UnsafeSync,
 			flags.SlotsPerArchivedPoint,
+			flags.SlasherFlag,
+			flags.EnableLightClientServer,
 			flags.DisableDiscv5,
+			flags.SubscribeToAllSubnets,
 			flags.BlockBatchLimit,
+			flags.BlocksByRangeMaxCount,
+			flags.BlocksByRangeTimeout,
+			flags.BlockValidationQueueSize,
+			flags.AttestationValidationQueueSize,
+			flags.SignatureVerificationWorkers,
+			flags.StateCacheSize,
+			flags.CommitteeCacheSize,
+			flags.CheckpointStateCacheSize,
+			flags.SkipSlotCacheSize,
+			flags.StateReplayBudget,
+			flags.GenesisStateFlag,
+			flags.WeakSubjectivityCheckpt,
+			flags.CompactDBFlag,
+			flags.DBBackupOutputDirFlag,
 			flags.EnableDebugRPCEndpoints,
+			flags.DisableGRPCReflection,
+			flags.BlockProposalBudget,
+			flags.MinAttestationInclusionReward,
+			flags.ExcludeAttestationCommittees,
+			flags.PreferOwnValidatorAttestations,
+			flags.BroadcastProtectionValidators,
+			flags.RPCSlowRequestThreshold,
+			flags.DeepReorgThreshold,
+			flags.HeadLagWatchdogSlots,
+			flags.HeadLagDumpDir,
 		},
 	},
 	{
@@ -107,10 +157,17 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PPrivKey,
 			cmd.P2PMetadata,
 			cmd.P2PWhitelist,
+			cmd.P2PAllowList,
+			cmd.P2PDenyList,
 			cmd.StaticPeers,
+			cmd.TrustedPeers,
 			cmd.EnableUPnPFlag,
 			cmd.P2PEncoding,
 			cmd.P2PPubsub,
+			cmd.P2PScoreSnapshotInterval,
+			cmd.P2PScoreSnapshotFile,
+			cmd.P2PSeenMessagesTTL,
+			cmd.P2PSeenMessagesCacheSize,
 			flags.MinSyncPeers,
 		},
 	},
@@ -140,6 +197,13 @@ var appHelpFlagGroups = []flagGroup{
 			flags.ArchiveValidatorSetChangesFlag,
 			flags.ArchiveBlocksFlag,
 			flags.ArchiveAttestationsFlag,
+			flags.ArchiveStateDiffsFlag,
+			flags.ArchiveBalancesRetentionFlag,
+			flags.ArchiveBalancesKeepEveryFlag,
+			flags.ArchiveCommitteesRetentionFlag,
+			flags.ArchiveCommitteesKeepEveryFlag,
+			flags.ArchiveParticipationRetentionFlag,
+			flags.ArchiveParticipationKeepEveryFlag,
 		},
 	},
 }
@@ -0,0 +1,153 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/pkg/errors"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+)
+
+// diagnosticDump is the state of the node when the head started stalling.
+type diagnosticDump struct {
+	Time          time.Time                `json:"time"`
+	CurrentSlot   uint64                   `json:"current_slot"`
+	HeadSlot      uint64                   `json:"head_slot"`
+	HeadRoot      string                   `json:"head_root"`
+	ForkChoice    *forkChoiceDump          `json:"fork_choice,omitempty"`
+	Peers         []*peerDump              `json:"peers"`
+	PendingQueues *prysmsync.PendingQueues `json:"pending_queues,omitempty"`
+}
+
+type forkChoiceDump struct {
+	JustifiedEpoch uint64      `json:"justified_epoch"`
+	FinalizedEpoch uint64      `json:"finalized_epoch"`
+	FinalizedRoot  string      `json:"finalized_root"`
+	Nodes          []*nodeDump `json:"nodes"`
+}
+
+type nodeDump struct {
+	Slot           uint64 `json:"slot"`
+	Root           string `json:"root"`
+	ParentRoot     string `json:"parent_root"`
+	JustifiedEpoch uint64 `json:"justified_epoch"`
+	FinalizedEpoch uint64 `json:"finalized_epoch"`
+	Weight         uint64 `json:"weight"`
+}
+
+type peerDump struct {
+	ID             string `json:"id"`
+	Address        string `json:"address"`
+	Direction      string `json:"direction"`
+	Agent          string `json:"agent"`
+	HeadSlot       uint64 `json:"head_slot"`
+	FinalizedEpoch uint64 `json:"finalized_epoch"`
+	BadResponses   int    `json:"bad_responses"`
+}
+
+// dump writes the fork choice store, the connected peers and the pending queues of regular
+// sync to a JSON file of the dump directory, and returns its path.
+func (s *Service) dump(ctx context.Context, currentSlot uint64, headSlot uint64) (string, error) {
+	d := &diagnosticDump{
+		Time:        roughtime.Now(),
+		CurrentSlot: currentSlot,
+		HeadSlot:    headSlot,
+		ForkChoice:  s.forkChoiceDump(),
+		Peers:       s.peersDump(),
+	}
+	headRoot, err := s.headFetcher.HeadRoot(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "could not get head root")
+	}
+	d.HeadRoot = fmt.Sprintf("%#x", headRoot)
+	if s.pendingQueuesFetcher != nil {
+		d.PendingQueues = s.pendingQueuesFetcher.PendingQueues()
+	}
+
+	enc, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "could not encode diagnostic dump")
+	}
+	if err := os.MkdirAll(s.dumpDir, 0700); err != nil {
+		return "", errors.Wrap(err, "could not create dump directory")
+	}
+	path := filepath.Join(s.dumpDir, fmt.Sprintf("head-stall-%d-%d.json", currentSlot, d.Time.Unix()))
+	if err := ioutil.WriteFile(path, enc, 0600); err != nil {
+		return "", errors.Wrap(err, "could not write diagnostic dump")
+	}
+	return path, nil
+}
+
+func (s *Service) forkChoiceDump() *forkChoiceDump {
+	if s.forkChoiceFetcher == nil || s.forkChoiceFetcher.ForkChoiceStore() == nil {
+		return nil
+	}
+	snapshot := s.forkChoiceFetcher.ForkChoiceStore().Snapshot()
+	d := &forkChoiceDump{
+		JustifiedEpoch: snapshot.JustifiedEpoch,
+		FinalizedEpoch: snapshot.FinalizedEpoch,
+		FinalizedRoot:  fmt.Sprintf("%#x", snapshot.FinalizedRoot),
+	}
+	nodes := snapshot.Nodes
+	for _, n := range nodes {
+		node := &nodeDump{
+			Slot:           n.Slot,
+			Root:           fmt.Sprintf("%#x", n.Root()),
+			JustifiedEpoch: n.JustifiedEpoch(),
+			FinalizedEpoch: n.FinalizedEpoch(),
+			Weight:         n.Weight,
+		}
+		if n.Parent < uint64(len(nodes)) {
+			node.ParentRoot = fmt.Sprintf("%#x", nodes[n.Parent].Root())
+		}
+		d.Nodes = append(d.Nodes, node)
+	}
+	return d
+}
+
+func (s *Service) peersDump() []*peerDump {
+	if s.peersProvider == nil {
+		return nil
+	}
+	status := s.peersProvider.Peers()
+	var peers []*peerDump
+	for _, pid := range status.Connected() {
+		p := &peerDump{ID: pid.Pretty()}
+		if address, err := status.Address(pid); err == nil && address != nil {
+			p.Address = address.String()
+		}
+		if direction, err := status.Direction(pid); err == nil {
+			p.Direction = directionName(direction)
+		}
+		if agent, err := status.Agent(pid); err == nil {
+			p.Agent = agent
+		}
+		if chainState, err := status.ChainState(pid); err == nil && chainState != nil {
+			p.HeadSlot = chainState.HeadSlot
+			p.FinalizedEpoch = chainState.FinalizedEpoch
+		}
+		if badResponses, err := status.BadResponses(pid); err == nil {
+			p.BadResponses = badResponses
+		}
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+func directionName(direction network.Direction) string {
+	switch direction {
+	case network.DirInbound:
+		return "inbound"
+	case network.DirOutbound:
+		return "outbound"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,174 @@
+// Package watchdog defines a service which detects when the head of the beacon node stops
+// advancing with the wall clock, and records the state of the node at that time to help
+// investigating the cause.
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/runutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "watchdog")
+
+var (
+	headLagSlots = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_head_lag_slots",
+		Help: "The number of slots the head is behind the current slot",
+	})
+	headStalled = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_head_stalled",
+		Help: "1 while the head is further behind the current slot than the watchdog allows, 0 otherwise",
+	})
+	headStallsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_head_stalls_total",
+		Help: "The number of times the head fell further behind the current slot than the watchdog allows",
+	})
+)
+
+// PendingQueuesFetcher returns the messages regular sync holds until they can be processed.
+type PendingQueuesFetcher interface {
+	PendingQueues() *prysmsync.PendingQueues
+}
+
+// Service watching the head of the beacon node. The head is stalled when it is more than
+// the configured number of slots behind the current slot, while the node is not syncing.
+type Service struct {
+	ctx                  context.Context
+	cancel               context.CancelFunc
+	headFetcher          blockchain.HeadFetcher
+	timeFetcher          blockchain.TimeFetcher
+	forkChoiceFetcher    blockchain.ForkChoiceFetcher
+	peersProvider        p2p.PeersProvider
+	syncChecker          prysmsync.Checker
+	pendingQueuesFetcher PendingQueuesFetcher
+	maxHeadLag           uint64
+	dumpDir              string
+	lock                 sync.RWMutex
+	stalled              bool
+}
+
+// Config options for the watchdog service. The fork choice, peers and pending queues are
+// only used for the diagnostic dump, and are left out of it if not set.
+type Config struct {
+	HeadFetcher          blockchain.HeadFetcher
+	TimeFetcher          blockchain.TimeFetcher
+	ForkChoiceFetcher    blockchain.ForkChoiceFetcher
+	PeersProvider        p2p.PeersProvider
+	SyncChecker          prysmsync.Checker
+	PendingQueuesFetcher PendingQueuesFetcher
+	// MaxHeadLag is the number of slots the head may be behind the current slot.
+	MaxHeadLag uint64
+	// DumpDir is the directory diagnostic dumps are written to when the head stalls. No dump
+	// is written if empty.
+	DumpDir string
+}
+
+// NewService initializes the service from configuration options.
+func NewService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Service{
+		ctx:                  ctx,
+		cancel:               cancel,
+		headFetcher:          cfg.HeadFetcher,
+		timeFetcher:          cfg.TimeFetcher,
+		forkChoiceFetcher:    cfg.ForkChoiceFetcher,
+		peersProvider:        cfg.PeersProvider,
+		syncChecker:          cfg.SyncChecker,
+		pendingQueuesFetcher: cfg.PendingQueuesFetcher,
+		maxHeadLag:           cfg.MaxHeadLag,
+		dumpDir:              cfg.DumpDir,
+	}
+}
+
+// Start checking the head every slot.
+func (s *Service) Start() {
+	log.WithField("maxHeadLag", s.maxHeadLag).Info("Starting head lag watchdog")
+	runutil.RunEvery(s.ctx, time.Duration(params.BeaconConfig().SecondsPerSlot)*time.Second, func() {
+		s.check(s.ctx)
+	})
+}
+
+// Stop the watchdog.
+func (s *Service) Stop() error {
+	defer s.cancel()
+	return nil
+}
+
+// Status reports an error while the head is stalled.
+func (s *Service) Status() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.stalled {
+		return errors.New("head is not advancing")
+	}
+	return nil
+}
+
+func (s *Service) check(ctx context.Context) {
+	genesis := s.timeFetcher.GenesisTime()
+	if genesis.IsZero() || roughtime.Now().Before(genesis) {
+		return
+	}
+	// The head is expected to be behind while syncing.
+	if s.syncChecker != nil && s.syncChecker.Syncing() {
+		s.update(ctx, 0, 0)
+		return
+	}
+	currentSlot := s.timeFetcher.CurrentSlot()
+	headSlot := s.headFetcher.HeadSlot()
+	s.update(ctx, currentSlot, headSlot)
+}
+
+// update the stalled status of the head, logging and dumping the state of the node when the
+// head starts stalling.
+func (s *Service) update(ctx context.Context, currentSlot uint64, headSlot uint64) {
+	var lag uint64
+	if currentSlot > headSlot {
+		lag = currentSlot - headSlot
+	}
+	headLagSlots.Set(float64(lag))
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fields := logrus.Fields{
+		"currentSlot": currentSlot,
+		"headSlot":    headSlot,
+		"lag":         lag,
+	}
+	if lag <= s.maxHeadLag {
+		if s.stalled {
+			s.stalled = false
+			headStalled.Set(0)
+			log.WithFields(fields).Info("Head is advancing again")
+		}
+		return
+	}
+	if s.stalled {
+		return
+	}
+	s.stalled = true
+	headStalled.Set(1)
+	headStallsTotal.Inc()
+	log.WithFields(fields).Error("Head is not advancing, the node may be stuck")
+	if s.dumpDir == "" {
+		return
+	}
+	path, err := s.dump(ctx, currentSlot, headSlot)
+	if err != nil {
+		log.WithError(err).Error("Could not write diagnostic dump")
+		return
+	}
+	log.WithField("path", path).Info("Wrote diagnostic dump")
+}
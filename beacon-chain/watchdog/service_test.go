@@ -0,0 +1,106 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	p2ptest "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+type mockPendingQueues struct{}
+
+func (m *mockPendingQueues) PendingQueues() *prysmsync.PendingQueues {
+	return &prysmsync.PendingQueues{
+		Blocks: []*prysmsync.PendingBlock{{Slot: 9, Root: "0x01", ParentRoot: "0x02"}},
+	}
+}
+
+func TestUpdate_DumpsOnceWhenStalled(t *testing.T) {
+	dumpDir := filepath.Join(testutil.TempDir(), "watchdog")
+	defer func() {
+		if err := os.RemoveAll(dumpDir); err != nil {
+			t.Error(err)
+		}
+	}()
+	ctx := context.Background()
+	s := NewService(ctx, &Config{
+		HeadFetcher:          &mock.ChainService{Root: []byte{'a'}},
+		PeersProvider:        &p2ptest.MockPeersProvider{},
+		PendingQueuesFetcher: &mockPendingQueues{},
+		MaxHeadLag:           4,
+		DumpDir:              dumpDir,
+	})
+
+	s.update(ctx, 12, 8)
+	if err := s.Status(); err != nil {
+		t.Errorf("Expected head within the allowed lag to be healthy, received %v", err)
+	}
+	s.update(ctx, 13, 8)
+	if err := s.Status(); err == nil {
+		t.Error("Expected head to be reported as stalled")
+	}
+	s.update(ctx, 14, 8)
+
+	files, err := ioutil.ReadDir(dumpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("Expected a single diagnostic dump per stall, received %d", len(files))
+	}
+	enc, err := ioutil.ReadFile(filepath.Join(dumpDir, files[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &diagnosticDump{}
+	if err := json.Unmarshal(enc, d); err != nil {
+		t.Fatal(err)
+	}
+	if d.CurrentSlot != 13 || d.HeadSlot != 8 || d.HeadRoot != "0x61" {
+		t.Errorf("Unexpected head in diagnostic dump: %+v", d)
+	}
+	if len(d.Peers) != 2 {
+		t.Errorf("Expected 2 peers in diagnostic dump, received %d", len(d.Peers))
+	}
+	if d.PendingQueues == nil || len(d.PendingQueues.Blocks) != 1 {
+		t.Errorf("Expected pending queues in diagnostic dump, received %+v", d.PendingQueues)
+	}
+
+	s.update(ctx, 14, 14)
+	if err := s.Status(); err != nil {
+		t.Errorf("Expected head to recover, received %v", err)
+	}
+}
+
+func TestCheck_IgnoresLagWhileSyncing(t *testing.T) {
+	ctx := context.Background()
+	genesis := time.Now().Add(-time.Duration(20*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	chain := &mock.ChainService{Genesis: genesis}
+	syncChecker := &mockSync.Sync{IsSyncing: true}
+	s := NewService(ctx, &Config{
+		HeadFetcher: chain,
+		TimeFetcher: chain,
+		SyncChecker: syncChecker,
+		MaxHeadLag:  4,
+	})
+
+	s.check(ctx)
+	if err := s.Status(); err != nil {
+		t.Errorf("Expected head lag to be ignored while syncing, received %v", err)
+	}
+	syncChecker.IsSyncing = false
+	s.check(ctx)
+	if err := s.Status(); err == nil {
+		t.Error("Expected head to be reported as stalled once synced")
+	}
+}
@@ -0,0 +1,194 @@
+package harness
+
+import (
+	beaconflags "github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/debug"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	validatorflags "github.com/prysmaticlabs/prysm/validator/flags"
+	"gopkg.in/urfave/cli.v2"
+)
+
+// beaconNodeFlags mirrors the flags of the beacon-chain binary, so the in-process beacon
+// nodes parse the same arguments and flags left unset take their default values.
+var beaconNodeFlags = append([]cli.Flag{
+	beaconflags.DepositContractFlag,
+	beaconflags.Web3ProviderFlag,
+	beaconflags.HTTPWeb3ProviderFlag,
+	beaconflags.Eth1ChainIDFlag,
+	beaconflags.DepositSnapshotFlag,
+	beaconflags.ExportDepositSnapshotFlag,
+	beaconflags.RPCHost,
+	beaconflags.RPCPort,
+	beaconflags.CertFlag,
+	beaconflags.KeyFlag,
+	beaconflags.GRPCGatewayHost,
+	beaconflags.GRPCGatewayPort,
+	beaconflags.GPRCGatewayCorsDomain,
+	beaconflags.MinSyncPeers,
+	beaconflags.RPCMaxPageSize,
+	beaconflags.RPCMaxRecvMsgSize,
+	beaconflags.RPCMaxSendMsgSize,
+	beaconflags.RPCMaxConcurrentStreams,
+	beaconflags.RPCMaxConnections,
+	beaconflags.RPCKeepaliveMinTime,
+	beaconflags.RPCKeepalivePermitWithoutStream,
+	beaconflags.ContractDeploymentBlock,
+	beaconflags.UnsafeSync,
+	beaconflags.DisableDiscv5,
+	beaconflags.SubscribeToAllSubnets,
+	beaconflags.BlockBatchLimit,
+	beaconflags.BlocksByRangeMaxCount,
+	beaconflags.BlocksByRangeTimeout,
+	beaconflags.BlockValidationQueueSize,
+	beaconflags.AttestationValidationQueueSize,
+	beaconflags.SignatureVerificationWorkers,
+	beaconflags.StateCacheSize,
+	beaconflags.CommitteeCacheSize,
+	beaconflags.CheckpointStateCacheSize,
+	beaconflags.SkipSlotCacheSize,
+	beaconflags.StateReplayBudget,
+	beaconflags.GenesisStateFlag,
+	beaconflags.WeakSubjectivityCheckpt,
+	beaconflags.CompactDBFlag,
+	beaconflags.DBBackupOutputDirFlag,
+	beaconflags.InteropMockEth1DataVotesFlag,
+	beaconflags.InteropGenesisStateFlag,
+	beaconflags.InteropNumValidatorsFlag,
+	beaconflags.InteropGenesisTimeFlag,
+	beaconflags.ArchiveEnableFlag,
+	beaconflags.ArchiveValidatorSetChangesFlag,
+	beaconflags.ArchiveBlocksFlag,
+	beaconflags.ArchiveAttestationsFlag,
+	beaconflags.ArchiveStateDiffsFlag,
+	beaconflags.ArchiveBalancesRetentionFlag,
+	beaconflags.ArchiveBalancesKeepEveryFlag,
+	beaconflags.ArchiveCommitteesRetentionFlag,
+	beaconflags.ArchiveCommitteesKeepEveryFlag,
+	beaconflags.ArchiveParticipationRetentionFlag,
+	beaconflags.ArchiveParticipationKeepEveryFlag,
+	beaconflags.SlotsPerArchivedPoint,
+	beaconflags.SlasherFlag,
+	beaconflags.EnableLightClientServer,
+	beaconflags.EnableDebugRPCEndpoints,
+	beaconflags.DisableGRPCReflection,
+	beaconflags.BlockProposalBudget,
+	beaconflags.MinAttestationInclusionReward,
+	beaconflags.ExcludeAttestationCommittees,
+	beaconflags.PreferOwnValidatorAttestations,
+	beaconflags.BroadcastProtectionValidators,
+	beaconflags.RPCSlowRequestThreshold,
+	beaconflags.DeepReorgThreshold,
+	beaconflags.HeadLagWatchdogSlots,
+	beaconflags.HeadLagDumpDir,
+	cmd.BootstrapNode,
+	cmd.NoDiscovery,
+	cmd.StaticPeers,
+	cmd.TrustedPeers,
+	cmd.RelayNode,
+	cmd.P2PUDPPort,
+	cmd.P2PTCPPort,
+	cmd.P2PQUICPort,
+	cmd.P2PIP,
+	cmd.P2PHost,
+	cmd.P2PHostDNS,
+	cmd.P2PMaxPeers,
+	cmd.P2PPrivKey,
+	cmd.P2PMetadata,
+	cmd.P2PWhitelist,
+	cmd.P2PAllowList,
+	cmd.P2PDenyList,
+	cmd.P2PEncoding,
+	cmd.P2PPubsub,
+	cmd.P2PScoreSnapshotInterval,
+	cmd.P2PScoreSnapshotFile,
+	cmd.P2PSeenMessagesTTL,
+	cmd.P2PSeenMessagesCacheSize,
+	cmd.DataDirFlag,
+	cmd.VerbosityFlag,
+	cmd.LogLevelOverrideFlag,
+	cmd.EnableTracingFlag,
+	cmd.TracingProcessNameFlag,
+	cmd.TracingEndpointFlag,
+	cmd.TracingExporterFlag,
+	cmd.TracingTagsFlag,
+	cmd.TraceSampleFractionFlag,
+	beaconflags.MonitoringPortFlag,
+	cmd.MonitoringHostFlag,
+	cmd.MonitoringCorsDomainFlag,
+	cmd.HTTPTLSCertFlag,
+	cmd.HTTPTLSKeyFlag,
+	cmd.HTTPDisabledEndpointsFlag,
+	cmd.DisableMonitoringFlag,
+	cmd.ClearDB,
+	cmd.ForceClearDB,
+	cmd.LogFormat,
+	cmd.MaxGoroutines,
+	debug.PProfFlag,
+	debug.PProfAddrFlag,
+	debug.PProfPortFlag,
+	debug.MemProfileRateFlag,
+	debug.CPUProfileFlag,
+	debug.TraceFlag,
+	debug.GCPercentFlag,
+	debug.GCBallastFlag,
+	cmd.LogFileName,
+	cmd.EnableUPnPFlag,
+	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.NetworkFlag,
+}, featureconfig.BeaconChainFlags...)
+
+// validatorClientFlags mirrors the flags of the validator binary.
+var validatorClientFlags = append([]cli.Flag{
+	validatorflags.BeaconRPCProviderFlag,
+	validatorflags.CertFlag,
+	validatorflags.GraffitiFlag,
+	validatorflags.KeystorePathFlag,
+	validatorflags.PasswordFlag,
+	validatorflags.DisablePenaltyRewardLogFlag,
+	validatorflags.UnencryptedKeysFlag,
+	validatorflags.InteropStartIndex,
+	validatorflags.InteropNumValidators,
+	validatorflags.GrpcMaxCallRecvMsgSizeFlag,
+	validatorflags.GrpcRetriesFlag,
+	validatorflags.GrpcHeadersFlag,
+	validatorflags.KeyManager,
+	validatorflags.KeyManagerOpts,
+	validatorflags.KeyManagers,
+	validatorflags.AccountMetricsFlag,
+	validatorflags.DryRunFlag,
+	validatorflags.DryRunOutputFlag,
+	validatorflags.EstimateRewardsFlag,
+	cmd.VerbosityFlag,
+	cmd.LogLevelOverrideFlag,
+	cmd.DataDirFlag,
+	cmd.ClearDB,
+	cmd.ForceClearDB,
+	cmd.EnableTracingFlag,
+	cmd.TracingProcessNameFlag,
+	cmd.TracingEndpointFlag,
+	cmd.TracingExporterFlag,
+	cmd.TracingTagsFlag,
+	cmd.TraceSampleFractionFlag,
+	validatorflags.MonitoringPortFlag,
+	validatorflags.EnableDutyTriggerFlag,
+	cmd.MonitoringHostFlag,
+	cmd.MonitoringCorsDomainFlag,
+	cmd.HTTPTLSCertFlag,
+	cmd.HTTPTLSKeyFlag,
+	cmd.HTTPDisabledEndpointsFlag,
+	cmd.LogFormat,
+	debug.PProfFlag,
+	debug.PProfAddrFlag,
+	debug.PProfPortFlag,
+	debug.MemProfileRateFlag,
+	debug.CPUProfileFlag,
+	debug.TraceFlag,
+	debug.GCPercentFlag,
+	debug.GCBallastFlag,
+	cmd.LogFileName,
+	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.NetworkFlag,
+}, featureconfig.ValidatorFlags...)
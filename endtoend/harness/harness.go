@@ -0,0 +1,337 @@
+// Package harness runs a local network of in-process beacon nodes and validator clients
+// starting from an interop genesis state, and checks the network finalizes, its validators
+// participate and none of them gets slashed. Unlike the end to end tests it needs no eth1
+// chain, bootnode or built binaries, so it is usable both in CI and by developers
+// benchmarking changes locally, where the Result reports how the network performed.
+package harness
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	beaconnode "github.com/prysmaticlabs/prysm/beacon-chain/node"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	validatornode "github.com/prysmaticlabs/prysm/validator/node"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"gopkg.in/urfave/cli.v2"
+)
+
+var log = logrus.WithField("prefix", "harness")
+
+// dialTimeout bounds the time a beacon node takes to start serving RPC requests.
+const dialTimeout = time.Minute
+
+// Ports used by each beacon node and its validator client, offset from the base port by
+// portsPerNode times the index of the node.
+const (
+	rpcPortOffset = iota
+	p2pTCPPortOffset
+	p2pUDPPortOffset
+	gatewayPortOffset
+	beaconMonitoringPortOffset
+	validatorMonitoringPortOffset
+	portsPerNode = 10
+)
+
+// Config of the network run by the harness.
+type Config struct {
+	// BeaconNodes is the number of beacon nodes, each with its own validator client.
+	BeaconNodes int
+	// Validators is the number of interop genesis validators, split evenly between the
+	// validator clients.
+	Validators uint64
+	// Epochs is the number of epochs the network runs for.
+	Epochs uint64
+	// MinParticipation is the lowest global participation rate accepted for the epochs
+	// following the first two.
+	MinParticipation float32
+	// MaxFinalityDistance is the highest number of epochs the finalized checkpoint may be
+	// behind the current epoch at the end of the run.
+	MaxFinalityDistance uint64
+	// GenesisDelay leaves the nodes time to start and peer before genesis.
+	GenesisDelay time.Duration
+	// BasePort is the first of the ports used by the nodes.
+	BasePort int
+	// DataDir is the directory of the node databases. A temporary directory, removed once
+	// the network stops, is used if empty.
+	DataDir string
+	// BeaconFlags and ValidatorFlags are passed to every beacon node and validator client.
+	BeaconFlags    []string
+	ValidatorFlags []string
+}
+
+// DefaultConfig returns the configuration of a small network using the minimal config,
+// with the feature flags tested in end to end tests.
+func DefaultConfig() *Config {
+	return &Config{
+		BeaconNodes:         2,
+		Validators:          64,
+		Epochs:              8,
+		MinParticipation:    0.85,
+		MaxFinalityDistance: 3,
+		GenesisDelay:        20 * time.Second,
+		BasePort:            19000,
+		BeaconFlags:         append([]string{"--minimal-config"}, featureconfig.E2EBeaconChainFlags...),
+		ValidatorFlags:      append([]string{"--minimal-config"}, featureconfig.E2EValidatorFlags...),
+	}
+}
+
+func (c *Config) validate() error {
+	if c.BeaconNodes <= 0 {
+		return errors.New("at least one beacon node is required")
+	}
+	if c.Validators == 0 || c.Validators%uint64(c.BeaconNodes) != 0 {
+		return fmt.Errorf("%d validators cannot be split evenly between %d validator clients", c.Validators, c.BeaconNodes)
+	}
+	if c.Epochs < 4 {
+		return fmt.Errorf("the network must run for at least 4 epochs to finalize, received %d", c.Epochs)
+	}
+	if c.MinParticipation < 0 || c.MinParticipation > 1 {
+		return fmt.Errorf("participation threshold %f is not between 0 and 1", c.MinParticipation)
+	}
+	return nil
+}
+
+// port returns the port at the offset for the node at the index.
+func (c *Config) port(index int, offset int) int {
+	return c.BasePort + index*portsPerNode + offset
+}
+
+// Network of in-process beacon nodes and validator clients.
+type Network struct {
+	cfg              *Config
+	genesisTime      time.Time
+	dataDir          string
+	removeDataDir    bool
+	beaconNodes      []*beaconnode.BeaconNode
+	validatorClients []*validatornode.ValidatorClient
+	conns            []*grpc.ClientConn
+	stopOnce         sync.Once
+}
+
+// Start the beacon nodes and validator clients of the network. The network must be stopped
+// by the caller, even if it fails to start.
+func Start(ctx context.Context, cfg *Config) (*Network, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	n := &Network{
+		cfg:         cfg,
+		genesisTime: time.Unix(time.Now().Add(cfg.GenesisDelay).Unix(), 0),
+		dataDir:     cfg.DataDir,
+	}
+	if n.dataDir == "" {
+		dir, err := ioutil.TempDir("", "harness")
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create data directory")
+		}
+		n.dataDir = dir
+		n.removeDataDir = true
+	}
+	log.WithFields(logrus.Fields{
+		"beaconNodes": cfg.BeaconNodes,
+		"validators":  cfg.Validators,
+		"epochs":      cfg.Epochs,
+		"genesisTime": n.genesisTime,
+		"dataDir":     n.dataDir,
+	}).Info("Starting interop network")
+
+	// The first beacon node is started on its own, the other nodes peer with it.
+	var peer string
+	for i := 0; i < cfg.BeaconNodes; i++ {
+		bn, err := newBeaconNode(n.beaconNodeArgs(i, peer))
+		if err != nil {
+			return n, errors.Wrapf(err, "could not create beacon node %d", i)
+		}
+		n.beaconNodes = append(n.beaconNodes, bn)
+		go bn.Start()
+
+		dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+		conn, err := grpc.DialContext(dialCtx, fmt.Sprintf("127.0.0.1:%d", cfg.port(i, rpcPortOffset)), grpc.WithInsecure(), grpc.WithBlock())
+		cancel()
+		if err != nil {
+			return n, errors.Wrapf(err, "could not connect to beacon node %d", i)
+		}
+		n.conns = append(n.conns, conn)
+		if i == 0 {
+			if peer, err = p2pAddress(ctx, conn); err != nil {
+				return n, err
+			}
+		}
+	}
+	beaconFeatures := featureconfig.Get().Copy()
+
+	for i := 0; i < cfg.BeaconNodes; i++ {
+		vc, err := newValidatorClient(n.validatorClientArgs(i))
+		if err != nil {
+			return n, errors.Wrapf(err, "could not create validator client %d", i)
+		}
+		n.validatorClients = append(n.validatorClients, vc)
+	}
+	validatorFeatures := featureconfig.Get()
+
+	// Feature flags are process wide and were last set by the validator clients. Keep the
+	// features of the beacon nodes along with the ones of the validator clients.
+	features := beaconFeatures.Copy()
+	features.ProtectProposer = validatorFeatures.ProtectProposer
+	features.ProtectAttester = validatorFeatures.ProtectAttester
+	features.EnableDomainDataCache = validatorFeatures.EnableDomainDataCache
+	features.WaitForSynced = beaconFeatures.WaitForSynced || validatorFeatures.WaitForSynced
+	featureconfig.Init(features)
+
+	for _, vc := range n.validatorClients {
+		go vc.Start()
+	}
+	return n, nil
+}
+
+// Stop the validator clients and beacon nodes of the network.
+func (n *Network) Stop() {
+	n.stopOnce.Do(func() {
+		for _, vc := range n.validatorClients {
+			vc.Close()
+		}
+		for _, conn := range n.conns {
+			if err := conn.Close(); err != nil {
+				log.WithError(err).Error("Could not close connection to beacon node")
+			}
+		}
+		for _, bn := range n.beaconNodes {
+			bn.Close()
+		}
+		if n.removeDataDir {
+			if err := os.RemoveAll(n.dataDir); err != nil {
+				log.WithError(err).Error("Could not remove data directory")
+			}
+		}
+	})
+}
+
+// GenesisTime of the network.
+func (n *Network) GenesisTime() time.Time {
+	return n.genesisTime
+}
+
+// Conns returns the gRPC connections to the beacon nodes, in order.
+func (n *Network) Conns() []*grpc.ClientConn {
+	return n.conns
+}
+
+// Run starts a network with the configuration, runs it for the configured number of
+// epochs and stops it. The result is returned along with the failed check, if any.
+func Run(ctx context.Context, cfg *Config) (*Result, error) {
+	n, err := Start(ctx, cfg)
+	if n != nil {
+		defer n.Stop()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return n.Run(ctx)
+}
+
+func (n *Network) beaconNodeArgs(index int, peer string) []string {
+	args := []string{
+		fmt.Sprintf("--datadir=%s", path.Join(n.dataDir, fmt.Sprintf("beacon-%d", index))),
+		"--force-clear-db",
+		fmt.Sprintf("--interop-num-validators=%d", n.cfg.Validators),
+		fmt.Sprintf("--interop-genesis-time=%d", n.genesisTime.Unix()),
+		"--interop-eth1data-votes",
+		"--deposit-contract=0x0000000000000000000000000000000000000000",
+		"--bootstrap-node=",
+		"--no-discovery",
+		fmt.Sprintf("--min-sync-peers=%d", n.cfg.BeaconNodes-1),
+		"--rpc-host=127.0.0.1",
+		fmt.Sprintf("--rpc-port=%d", n.cfg.port(index, rpcPortOffset)),
+		fmt.Sprintf("--grpc-gateway-port=%d", n.cfg.port(index, gatewayPortOffset)),
+		"--p2p-local-ip=127.0.0.1",
+		fmt.Sprintf("--p2p-tcp-port=%d", n.cfg.port(index, p2pTCPPortOffset)),
+		fmt.Sprintf("--p2p-udp-port=%d", n.cfg.port(index, p2pUDPPortOffset)),
+		fmt.Sprintf("--monitoring-port=%d", n.cfg.port(index, beaconMonitoringPortOffset)),
+	}
+	// Metrics are process wide, they are served by the first beacon node only.
+	if index > 0 {
+		args = append(args, "--disable-monitoring")
+	}
+	if peer != "" {
+		args = append(args, fmt.Sprintf("--peer=%s", peer))
+	}
+	return append(args, n.cfg.BeaconFlags...)
+}
+
+func (n *Network) validatorClientArgs(index int) []string {
+	keys := n.cfg.Validators / uint64(n.cfg.BeaconNodes)
+	args := []string{
+		fmt.Sprintf("--datadir=%s", path.Join(n.dataDir, fmt.Sprintf("validator-%d", index))),
+		"--force-clear-db",
+		"--keymanager=interop",
+		fmt.Sprintf(`--keymanageropts={"keys":%d,"offset":%d}`, keys, keys*uint64(index)),
+		fmt.Sprintf("--beacon-rpc-provider=127.0.0.1:%d", n.cfg.port(index, rpcPortOffset)),
+		fmt.Sprintf("--monitoring-port=%d", n.cfg.port(index, validatorMonitoringPortOffset)),
+	}
+	return append(args, n.cfg.ValidatorFlags...)
+}
+
+// newBeaconNode parses the arguments the way the beacon-chain binary does, and creates a
+// beacon node from them.
+func newBeaconNode(args []string) (*beaconnode.BeaconNode, error) {
+	var bn *beaconnode.BeaconNode
+	app := &cli.App{
+		Name:  "beacon-chain",
+		Flags: beaconNodeFlags,
+		Action: func(ctx *cli.Context) error {
+			var err error
+			bn, err = beaconnode.NewBeaconNode(ctx)
+			return err
+		},
+	}
+	if err := app.Run(append([]string{app.Name}, args...)); err != nil {
+		return nil, err
+	}
+	return bn, nil
+}
+
+// newValidatorClient parses the arguments the way the validator binary does, and creates
+// a validator client from them.
+func newValidatorClient(args []string) (*validatornode.ValidatorClient, error) {
+	var vc *validatornode.ValidatorClient
+	app := &cli.App{
+		Name:  "validator",
+		Flags: validatorClientFlags,
+		Action: func(ctx *cli.Context) error {
+			var err error
+			vc, err = validatornode.NewValidatorClient(ctx)
+			return err
+		},
+	}
+	if err := app.Run(append([]string{app.Name}, args...)); err != nil {
+		return nil, err
+	}
+	return vc, nil
+}
+
+// p2pAddress returns the loopback multiaddr of the beacon node, for other nodes to peer with.
+func p2pAddress(ctx context.Context, conn *grpc.ClientConn) (string, error) {
+	identity, err := pbrpc.NewNodeClient(conn).GetIdentity(ctx, &pbrpc.IdentityRequest{})
+	if err != nil {
+		return "", errors.Wrap(err, "could not get identity of beacon node")
+	}
+	for _, addr := range identity.P2PAddresses {
+		if strings.HasPrefix(addr, "/ip4/127.0.0.1/") {
+			return addr, nil
+		}
+	}
+	if len(identity.P2PAddresses) == 0 {
+		return "", errors.New("beacon node does not listen on any p2p address")
+	}
+	return identity.P2PAddresses[0], nil
+}
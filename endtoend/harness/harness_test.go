@@ -0,0 +1,134 @@
+package harness
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+)
+
+var (
+	beaconNodes = flag.Int("harness.beacon-nodes", 2, "Number of beacon nodes of the network")
+	validators  = flag.Uint64("harness.validators", 64, "Number of interop validators of the network")
+	epochs      = flag.Uint64("harness.epochs", 8, "Number of epochs to run the network for")
+	dataDir     = flag.String("harness.datadir", "", "Directory of the node databases, a temporary directory if empty")
+)
+
+func TestInteropNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping network run in short mode")
+	}
+	cfg := DefaultConfig()
+	cfg.BeaconNodes = *beaconNodes
+	cfg.Validators = *validators
+	cfg.Epochs = *epochs
+	cfg.DataDir = *dataDir
+
+	res, err := Run(context.Background(), cfg)
+	if res != nil {
+		for _, record := range res.Epochs {
+			t.Logf(
+				"Epoch %d: head slot %d, justified epoch %d, finalized epoch %d, participation %f",
+				record.Epoch,
+				record.HeadSlot,
+				record.JustifiedEpoch,
+				record.FinalizedEpoch,
+				record.Participation,
+			)
+		}
+		t.Logf("Finalized epoch %d after %v", res.FinalizedEpoch(), res.Duration)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "default", modify: func(*Config) {}},
+		{name: "no beacon node", modify: func(c *Config) { c.BeaconNodes = 0 }, wantErr: true},
+		{name: "uneven validators", modify: func(c *Config) { c.BeaconNodes = 3 }, wantErr: true},
+		{name: "too few epochs", modify: func(c *Config) { c.Epochs = 3 }, wantErr: true},
+		{name: "invalid participation", modify: func(c *Config) { c.MinParticipation = 1.5 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(cfg)
+			if err := cfg.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Check(t *testing.T) {
+	cfg := DefaultConfig()
+	healthy := func() *Result {
+		return &Result{
+			Epochs: []*EpochResult{
+				{Epoch: 1, Participation: 0},
+				{Epoch: 2, Participation: 0.5},
+				{Epoch: 3, Participation: 0.9},
+				{Epoch: 8, FinalizedEpoch: 6, Participation: 1},
+			},
+			Duration: time.Minute,
+		}
+	}
+	if err := cfg.check(healthy()); err != nil {
+		t.Errorf("Expected healthy run to pass, received %v", err)
+	}
+
+	res := healthy()
+	res.Epochs[3].FinalizedEpoch = 4
+	if err := cfg.check(res); err == nil {
+		t.Error("Expected error for finality lagging behind")
+	}
+
+	res = healthy()
+	res.Epochs[2].Participation = 0.5
+	if err := cfg.check(res); err == nil {
+		t.Error("Expected error for low participation")
+	}
+
+	res = healthy()
+	res.SlashedValidators = []uint64{3}
+	if err := cfg.check(res); err == nil {
+		t.Error("Expected error for slashed validators")
+	}
+}
+
+func TestNetwork_Args(t *testing.T) {
+	n := &Network{cfg: DefaultConfig(), dataDir: "/tmp/harness", genesisTime: time.Unix(100, 0)}
+	args := n.validatorClientArgs(1)
+	if want := `--keymanageropts={"keys":32,"offset":32}`; !contains(args, want) {
+		t.Errorf("Expected %s in validator client arguments %v", want, args)
+	}
+	if want := "--beacon-rpc-provider=127.0.0.1:19010"; !contains(args, want) {
+		t.Errorf("Expected %s in validator client arguments %v", want, args)
+	}
+	args = n.beaconNodeArgs(1, "/ip4/127.0.0.1/tcp/19001/p2p/peer")
+	for _, want := range []string{
+		"--interop-genesis-time=100",
+		"--rpc-port=19010",
+		"--peer=/ip4/127.0.0.1/tcp/19001/p2p/peer",
+		"--disable-monitoring",
+	} {
+		if !contains(args, want) {
+			t.Errorf("Expected %s in beacon node arguments %v", want, args)
+		}
+	}
+}
+
+func contains(args []string, arg string) bool {
+	for _, a := range args {
+		if a == arg {
+			return true
+		}
+	}
+	return false
+}
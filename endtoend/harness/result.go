@@ -0,0 +1,167 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/endtoend/helpers"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+// EpochResult is the state of the chain, as seen by the first beacon node, at the start
+// of an epoch.
+type EpochResult struct {
+	Epoch          uint64
+	HeadSlot       uint64
+	JustifiedEpoch uint64
+	FinalizedEpoch uint64
+	// Participation is the global participation rate of the previous epoch.
+	Participation float32
+}
+
+// Result of a network run.
+type Result struct {
+	Epochs []*EpochResult
+	// SlashedValidators are the indices of the validators slashed in the head state at the
+	// end of the run.
+	SlashedValidators []uint64
+	// Duration is the time the network ran for since genesis.
+	Duration time.Duration
+}
+
+// FinalizedEpoch returns the last finalized epoch of the run.
+func (r *Result) FinalizedEpoch() uint64 {
+	if len(r.Epochs) == 0 {
+		return 0
+	}
+	return r.Epochs[len(r.Epochs)-1].FinalizedEpoch
+}
+
+// Run the network until the configured number of epochs passed, recording the state of
+// the chain at every epoch. The result is returned along with the failed check, if any:
+// the network must finalize, its validators must participate above the configured
+// threshold and none of them must be slashed.
+func (n *Network) Run(ctx context.Context) (*Result, error) {
+	secondsPerEpoch := params.BeaconConfig().SecondsPerSlot * params.BeaconConfig().SlotsPerEpoch
+	ticker := helpers.GetEpochTicker(n.genesisTime, secondsPerEpoch)
+	defer ticker.Done()
+
+	res := &Result{}
+	for {
+		select {
+		case epoch := <-ticker.C():
+			if epoch == 0 {
+				continue
+			}
+			record, err := n.recordEpoch(ctx, epoch)
+			if err != nil {
+				return res, errors.Wrapf(err, "could not record epoch %d", epoch)
+			}
+			res.Epochs = append(res.Epochs, record)
+			log.WithFields(logrus.Fields{
+				"epoch":          record.Epoch,
+				"headSlot":       record.HeadSlot,
+				"justifiedEpoch": record.JustifiedEpoch,
+				"finalizedEpoch": record.FinalizedEpoch,
+				"participation":  record.Participation,
+			}).Info("Network progress")
+			if epoch < n.cfg.Epochs {
+				continue
+			}
+			res.Duration = time.Since(n.genesisTime)
+			slashed, err := n.slashedValidators(ctx)
+			if err != nil {
+				return res, err
+			}
+			res.SlashedValidators = slashed
+			return res, n.cfg.check(res)
+		case <-ctx.Done():
+			return res, ctx.Err()
+		}
+	}
+}
+
+func (n *Network) recordEpoch(ctx context.Context, epoch uint64) (*EpochResult, error) {
+	client := eth.NewBeaconChainClient(n.conns[0])
+	head, err := client.GetChainHead(ctx, &ptypes.Empty{})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get chain head")
+	}
+	participation, err := client.GetValidatorParticipation(ctx, &eth.GetValidatorParticipationRequest{
+		QueryFilter: &eth.GetValidatorParticipationRequest_Epoch{Epoch: epoch - 1},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get validator participation")
+	}
+	record := &EpochResult{
+		Epoch:          epoch,
+		HeadSlot:       head.HeadSlot,
+		JustifiedEpoch: head.JustifiedEpoch,
+		FinalizedEpoch: head.FinalizedEpoch,
+	}
+	if participation.Participation != nil {
+		record.Participation = participation.Participation.GlobalParticipationRate
+	}
+	return record, nil
+}
+
+// slashedValidators returns the indices of the slashed validators in the head state of
+// the first beacon node.
+func (n *Network) slashedValidators(ctx context.Context) ([]uint64, error) {
+	client := eth.NewBeaconChainClient(n.conns[0])
+	var slashed []uint64
+	req := &eth.ListValidatorsRequest{}
+	for {
+		validators, err := client.ListValidators(ctx, req)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not list validators")
+		}
+		for _, v := range validators.ValidatorList {
+			if v.Validator != nil && v.Validator.Slashed {
+				slashed = append(slashed, v.Index)
+			}
+		}
+		if validators.NextPageToken == "" {
+			return slashed, nil
+		}
+		req.PageToken = validators.NextPageToken
+	}
+}
+
+// check returns an error if the run did not meet the finality, participation or slashing
+// requirements of the configuration.
+func (c *Config) check(res *Result) error {
+	if len(res.Epochs) == 0 {
+		return errors.New("no epoch was recorded")
+	}
+	last := res.Epochs[len(res.Epochs)-1]
+	if last.FinalizedEpoch == 0 || last.FinalizedEpoch+c.MaxFinalityDistance < last.Epoch {
+		return fmt.Errorf(
+			"expected the finalized epoch to be at most %d epochs behind epoch %d, received %d",
+			c.MaxFinalityDistance,
+			last.Epoch,
+			last.FinalizedEpoch,
+		)
+	}
+	// Participation is not checked for the first two epochs, while validators start
+	// attesting.
+	for _, record := range res.Epochs {
+		if record.Epoch > 2 && record.Participation < c.MinParticipation {
+			return fmt.Errorf(
+				"validator participation was below %f for epoch %d, received %f",
+				c.MinParticipation,
+				record.Epoch-1,
+				record.Participation,
+			)
+		}
+	}
+	if len(res.SlashedValidators) > 0 {
+		return fmt.Errorf("expected no slashed validators, received %v", res.SlashedValidators)
+	}
+	return nil
+}
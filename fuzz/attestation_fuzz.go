@@ -0,0 +1,27 @@
+package fuzz
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+)
+
+// BeaconFuzzAttestation processes an attestation against the prefilled state of the
+// input without verifying its signature, as signatures are disabled in beacon-fuzz.
+func BeaconFuzzAttestation(b []byte) ([]byte, bool) {
+	setup()
+	input := &InputAttestation{}
+	if err := ssz.Unmarshal(b, input); err != nil {
+		return fail(err)
+	}
+	st, err := beaconFuzzState(input.StateID)
+	if err != nil {
+		return fail(err)
+	}
+	post, err := blocks.ProcessAttestationNoVerify(context.Background(), st, input.Attestation)
+	if err != nil {
+		return fail(err)
+	}
+	return success(post)
+}
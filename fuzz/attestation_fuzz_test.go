@@ -0,0 +1,76 @@
+package fuzz
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestBeaconFuzzAttestation_InvalidInput(t *testing.T) {
+	if _, ok := BeaconFuzzAttestation([]byte{1, 2, 3}); ok {
+		t.Error("Expected malformed input to fail")
+	}
+}
+
+func TestBeaconFuzzAttestation_ProcessesAttestation(t *testing.T) {
+	params.UseMainnetConfig()
+	dir, err := ioutil.TempDir("", "beaconfuzz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := os.Setenv(StatesPathEnv, dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.Unsetenv(StatesPathEnv); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	enc, err := ssz.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "1"), enc, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	input := &InputAttestation{
+		StateID: 1,
+		Attestation: &ethpb.Attestation{
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Root: make([]byte, 32)},
+				Target: &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+			},
+		},
+	}
+	b, err := ssz.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The target epoch is ahead of the state, so the attestation is rejected.
+	if _, ok := BeaconFuzzAttestation(b); ok {
+		t.Error("Expected attestation for a future epoch to fail")
+	}
+
+	input.StateID = 2
+	b, err = ssz.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := BeaconFuzzAttestation(b); ok {
+		t.Error("Expected missing state to fail")
+	}
+}
@@ -0,0 +1,95 @@
+package fuzz
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+)
+
+// BeaconFuzzDeposit processes a deposit against the prefilled state of the input.
+func BeaconFuzzDeposit(b []byte) ([]byte, bool) {
+	setup()
+	input := &InputDeposit{}
+	if err := ssz.Unmarshal(b, input); err != nil {
+		return fail(err)
+	}
+	return processBody(input.StateID, &ethpb.BeaconBlockBody{Deposits: []*ethpb.Deposit{input.Deposit}},
+		func(st *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) (*stateTrie.BeaconState, error) {
+			return blocks.ProcessDeposits(context.Background(), st, body)
+		})
+}
+
+// BeaconFuzzAttesterSlashing processes an attester slashing against the prefilled state
+// of the input. The signatures of the slashed attestations are verified.
+func BeaconFuzzAttesterSlashing(b []byte) ([]byte, bool) {
+	setup()
+	input := &InputAttesterSlashing{}
+	if err := ssz.Unmarshal(b, input); err != nil {
+		return fail(err)
+	}
+	return processBody(input.StateID, &ethpb.BeaconBlockBody{AttesterSlashings: []*ethpb.AttesterSlashing{input.AttesterSlashing}},
+		func(st *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) (*stateTrie.BeaconState, error) {
+			return blocks.ProcessAttesterSlashings(context.Background(), st, body)
+		})
+}
+
+// BeaconFuzzProposerSlashing processes a proposer slashing against the prefilled state
+// of the input. The signatures of the slashed block headers are verified.
+func BeaconFuzzProposerSlashing(b []byte) ([]byte, bool) {
+	setup()
+	input := &InputProposerSlashing{}
+	if err := ssz.Unmarshal(b, input); err != nil {
+		return fail(err)
+	}
+	return processBody(input.StateID, &ethpb.BeaconBlockBody{ProposerSlashings: []*ethpb.ProposerSlashing{input.ProposerSlashing}},
+		func(st *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) (*stateTrie.BeaconState, error) {
+			return blocks.ProcessProposerSlashings(context.Background(), st, body)
+		})
+}
+
+// BeaconFuzzVoluntaryExit processes a voluntary exit against the prefilled state of the
+// input without verifying its signature, as signatures are disabled in beacon-fuzz.
+func BeaconFuzzVoluntaryExit(b []byte) ([]byte, bool) {
+	setup()
+	input := &InputVoluntaryExit{}
+	if err := ssz.Unmarshal(b, input); err != nil {
+		return fail(err)
+	}
+	return processBody(input.StateID, &ethpb.BeaconBlockBody{VoluntaryExits: []*ethpb.SignedVoluntaryExit{input.VoluntaryExit}},
+		blocks.ProcessVoluntaryExitsNoVerify)
+}
+
+// BeaconFuzzEth1Data processes the eth1 data vote of a block against the prefilled state
+// of the input.
+func BeaconFuzzEth1Data(b []byte) ([]byte, bool) {
+	setup()
+	input := &InputEth1Data{}
+	if err := ssz.Unmarshal(b, input); err != nil {
+		return fail(err)
+	}
+	return processBody(input.StateID, &ethpb.BeaconBlockBody{Eth1Data: input.Eth1Data},
+		func(st *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) (*stateTrie.BeaconState, error) {
+			return blocks.ProcessEth1DataInBlock(st, &ethpb.BeaconBlock{Slot: st.Slot(), Body: body})
+		})
+}
+
+// processBody applies a block operation to the prefilled state with the given ID, the
+// operation being the only content of the block body.
+func processBody(
+	stateID uint16,
+	body *ethpb.BeaconBlockBody,
+	process func(*stateTrie.BeaconState, *ethpb.BeaconBlockBody) (*stateTrie.BeaconState, error),
+) ([]byte, bool) {
+	st, err := beaconFuzzState(stateID)
+	if err != nil {
+		return fail(err)
+	}
+	post, err := process(st, body)
+	if err != nil {
+		return fail(err)
+	}
+	return success(post)
+}
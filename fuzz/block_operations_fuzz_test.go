@@ -0,0 +1,103 @@
+package fuzz
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestBeaconFuzzBlockOperations_InvalidInput(t *testing.T) {
+	targets := map[string]Target{
+		"deposit":           BeaconFuzzDeposit,
+		"attester_slashing": BeaconFuzzAttesterSlashing,
+		"proposer_slashing": BeaconFuzzProposerSlashing,
+		"voluntary_exit":    BeaconFuzzVoluntaryExit,
+		"eth1_data":         BeaconFuzzEth1Data,
+	}
+	for name, target := range targets {
+		if _, ok := target([]byte{1, 2, 3}); ok {
+			t.Errorf("Expected malformed input to fail for %s", name)
+		}
+	}
+}
+
+func TestBeaconFuzzBlockOperations_ProcessesOperations(t *testing.T) {
+	params.UseMainnetConfig()
+	dir, err := ioutil.TempDir("", "beaconfuzz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := os.Setenv(StatesPathEnv, dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.Unsetenv(StatesPathEnv); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	enc, err := ssz.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "1"), enc, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	vote := &ethpb.Eth1Data{
+		DepositRoot: bytes.Repeat([]byte{'a'}, 32),
+		BlockHash:   bytes.Repeat([]byte{'b'}, 32),
+	}
+	b, err := ssz.Marshal(&InputEth1Data{StateID: 1, Eth1Data: vote})
+	if err != nil {
+		t.Fatal(err)
+	}
+	post, ok := BeaconFuzzEth1Data(b)
+	if !ok {
+		t.Fatal("Expected eth1 data vote to be processed")
+	}
+	postState := &pb.BeaconState{}
+	if err := ssz.Unmarshal(post, postState); err != nil {
+		t.Fatal(err)
+	}
+	if len(postState.Eth1DataVotes) != 1 || !bytes.Equal(postState.Eth1DataVotes[0].BlockHash, vote.BlockHash) {
+		t.Errorf("Expected the vote to be recorded, received %v", postState.Eth1DataVotes)
+	}
+
+	// Validators of the genesis state have not been active long enough to exit.
+	b, err = ssz.Marshal(&InputVoluntaryExit{
+		StateID: 1,
+		VoluntaryExit: &ethpb.SignedVoluntaryExit{
+			Exit:      &ethpb.VoluntaryExit{ValidatorIndex: 0},
+			Signature: make([]byte, 96),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := BeaconFuzzVoluntaryExit(b); ok {
+		t.Error("Expected exit of a recently activated validator to fail")
+	}
+
+	b, err = ssz.Marshal(&InputEth1Data{StateID: 2, Eth1Data: vote})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := BeaconFuzzEth1Data(b); ok {
+		t.Error("Expected missing state to fail")
+	}
+}
@@ -0,0 +1,71 @@
+// Package fuzz defines fuzz targets for the state transition functions of the beacon chain,
+// following the interface of sigp/beacon-fuzz. Every target decodes an SSZ encoded input,
+// which pairs the ID of a prefilled beacon state with the object to process, and returns
+// the SSZ encoded post-state when processing succeeded.
+package fuzz
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/prysmaticlabs/go-ssz"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// StatesPathEnv is the environment variable naming the directory of the beacon-fuzz
+// prefilled states. Each state is stored SSZ encoded in a file named after its ID.
+const StatesPathEnv = "BEACONFUZZ_STATES_PATH"
+
+var setupOnce sync.Once
+
+// setup applies the configuration shared by all targets once, so every input is
+// processed under the same mainnet config and default feature flags, and the
+// config is not rebuilt on every call of a target.
+func setup() {
+	setupOnce.Do(func() {
+		params.UseMainnetConfig()
+		featureconfig.Init(&featureconfig.Flags{})
+	})
+}
+
+// beaconFuzzState loads the prefilled state with the given ID.
+func beaconFuzzState(id uint16) (*stateTrie.BeaconState, error) {
+	dir := os.Getenv(StatesPathEnv)
+	if dir == "" {
+		return nil, fmt.Errorf("%s is not set", StatesPathEnv)
+	}
+	enc, err := ioutil.ReadFile(filepath.Join(dir, strconv.Itoa(int(id))))
+	if err != nil {
+		return nil, err
+	}
+	st := &pb.BeaconState{}
+	if err := ssz.Unmarshal(enc, st); err != nil {
+		return nil, err
+	}
+	return stateTrie.InitializeFromProtoUnsafe(st)
+}
+
+// fail reports an input which could not be processed.
+func fail(err error) ([]byte, bool) {
+	if os.Getenv("BEACONFUZZ_DEBUG") != "" {
+		fmt.Println(err)
+	}
+	return nil, false
+}
+
+// success returns the canonical SSZ encoding of the post-state, which other client
+// implementations produce for the same input, so results can be compared directly.
+func success(post *stateTrie.BeaconState) ([]byte, bool) {
+	enc, err := ssz.Marshal(post.InnerStateUnsafe())
+	if err != nil {
+		panic(err)
+	}
+	return enc, true
+}
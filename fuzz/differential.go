@@ -0,0 +1,108 @@
+package fuzz
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/prysmaticlabs/go-ssz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// Target is the signature shared by all fuzz targets. It returns the SSZ encoded
+// result of processing the input and whether processing succeeded.
+type Target func(b []byte) ([]byte, bool)
+
+// StateTargets are the targets returning a post-state, keyed by the name used to
+// select them in differential fuzzing.
+var StateTargets = map[string]Target{
+	"attestation":       BeaconFuzzAttestation,
+	"deposit":           BeaconFuzzDeposit,
+	"attester_slashing": BeaconFuzzAttesterSlashing,
+	"proposer_slashing": BeaconFuzzProposerSlashing,
+	"voluntary_exit":    BeaconFuzzVoluntaryExit,
+	"eth1_data":         BeaconFuzzEth1Data,
+	"state":             BeaconFuzzState,
+}
+
+// Targets are all fuzz targets, keyed by the same names as the binaries built by
+// scripts/build-fuzzers.sh.
+var Targets = map[string]Target{
+	"attestation":                BeaconFuzzAttestation,
+	"deposit":                    BeaconFuzzDeposit,
+	"attester_slashing":          BeaconFuzzAttesterSlashing,
+	"proposer_slashing":          BeaconFuzzProposerSlashing,
+	"voluntary_exit":             BeaconFuzzVoluntaryExit,
+	"eth1_data":                  BeaconFuzzEth1Data,
+	"forkchoice":                 BeaconFuzzForkChoice,
+	"state":                      BeaconFuzzState,
+	"status":                     BeaconFuzzStatus,
+	"goodbye":                    BeaconFuzzGoodbye,
+	"ping":                       BeaconFuzzPing,
+	"metadata":                   BeaconFuzzMetaData,
+	"blocks_by_range_request":    BeaconFuzzBlocksByRangeRequest,
+	"blocks_by_root_request":     BeaconFuzzBlocksByRootRequest,
+	"block_response":             BeaconFuzzBlockResponse,
+	"error_response":             BeaconFuzzErrorResponse,
+	"gossip_block":               BeaconFuzzGossipBlock,
+	"gossip_attestation":         BeaconFuzzGossipAttestation,
+	"gossip_aggregate_and_proof": BeaconFuzzGossipAggregateAndProof,
+	"gossip_voluntary_exit":      BeaconFuzzGossipVoluntaryExit,
+	"gossip_proposer_slashing":   BeaconFuzzGossipProposerSlashing,
+	"gossip_attester_slashing":   BeaconFuzzGossipAttesterSlashing,
+}
+
+// Mismatch is a differential fuzzing finding, where Prysm and another implementation
+// disagree on the result of processing the same input.
+type Mismatch struct {
+	Reason        string
+	PrysmRoot     [32]byte
+	ReferenceRoot [32]byte
+}
+
+func (m *Mismatch) Error() string {
+	if m.Reason != "" {
+		return m.Reason
+	}
+	return fmt.Sprintf("post-state root mismatch: prysm %#x, reference %#x", m.PrysmRoot, m.ReferenceRoot)
+}
+
+// Differential runs a state target on the input and compares the result against the
+// output of another implementation for the same input. The reference post-state is
+// the SSZ encoding of its state, and refOK reports whether processing succeeded there.
+// A *Mismatch is returned when only one implementation accepted the input, or when
+// the hash tree roots of the two post-states differ.
+// A panic in the target is reported as a *Mismatch as well.
+func Differential(target Target, input []byte, refPost []byte, refOK bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &Mismatch{Reason: fmt.Sprintf("prysm panicked: %v", r)}
+		}
+	}()
+	post, ok := target(input)
+	if ok != refOK {
+		return &Mismatch{Reason: fmt.Sprintf("processing result mismatch: prysm succeeded %t, reference succeeded %t", ok, refOK)}
+	}
+	if !ok || bytes.Equal(post, refPost) {
+		return nil
+	}
+	prysmRoot, err := postStateRoot(post)
+	if err != nil {
+		return err
+	}
+	refRoot, err := postStateRoot(refPost)
+	if err != nil {
+		return &Mismatch{Reason: fmt.Sprintf("could not decode reference post-state: %v", err)}
+	}
+	if prysmRoot != refRoot {
+		return &Mismatch{PrysmRoot: prysmRoot, ReferenceRoot: refRoot}
+	}
+	return nil
+}
+
+func postStateRoot(enc []byte) ([32]byte, error) {
+	st := &pb.BeaconState{}
+	if err := ssz.Unmarshal(enc, st); err != nil {
+		return [32]byte{}, err
+	}
+	return ssz.HashTreeRoot(st)
+}
@@ -0,0 +1,41 @@
+package fuzz
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/go-ssz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestDifferential(t *testing.T) {
+	encode := func(slot uint64) []byte {
+		enc, err := ssz.Marshal(&pb.BeaconState{Slot: slot})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return enc
+	}
+	post := encode(1)
+	succeeding := func([]byte) ([]byte, bool) { return post, true }
+	failing := func([]byte) ([]byte, bool) { return nil, false }
+	panicking := func([]byte) ([]byte, bool) { panic("boom") }
+
+	if err := Differential(succeeding, nil, post, true); err != nil {
+		t.Errorf("Expected matching post-states to agree, received %v", err)
+	}
+	if err := Differential(failing, nil, nil, false); err != nil {
+		t.Errorf("Expected both implementations rejecting the input to agree, received %v", err)
+	}
+	if _, ok := Differential(succeeding, nil, encode(2), true).(*Mismatch); !ok {
+		t.Error("Expected mismatch for different post-states")
+	}
+	if _, ok := Differential(failing, nil, post, true).(*Mismatch); !ok {
+		t.Error("Expected mismatch when only the reference accepts the input")
+	}
+	if _, ok := Differential(succeeding, nil, nil, false).(*Mismatch); !ok {
+		t.Error("Expected mismatch when only prysm accepts the input")
+	}
+	if _, ok := Differential(panicking, nil, nil, false).(*Mismatch); !ok {
+		t.Error("Expected mismatch when prysm panics")
+	}
+}
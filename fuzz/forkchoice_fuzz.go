@@ -0,0 +1,158 @@
+package fuzz
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// maxForkChoiceValidators bounds the validator indices of the fork choice target, so
+// inputs can not grow the vote list without limit.
+const maxForkChoiceValidators = 64
+
+// checkpoint is a justified or finalized checkpoint of the fork choice driver.
+type checkpoint struct {
+	epoch uint64
+	root  [32]byte
+}
+
+// BeaconFuzzForkChoice drives a fork choice store with the events of an InputForkChoice
+// and returns the final head root. Blocks from the future, blocks not after their parent
+// and attestations for future epochs are ignored. A block may justify its parent, if the
+// parent descends from the current justified block, and finalize the previous justified
+// checkpoint. The head is computed after every event and the target panics if the head
+// does not descend from the justified root, or if the finalized epoch of the store
+// decreases.
+func BeaconFuzzForkChoice(b []byte) ([]byte, bool) {
+	setup()
+	input := &InputForkChoice{}
+	if err := ssz.Unmarshal(b, input); err != nil {
+		return fail(err)
+	}
+	if len(input.Balances) > maxForkChoiceValidators || len(input.Events) > 1024 {
+		return fail(fmt.Errorf("input exceeds limits"))
+	}
+	ctx := context.Background()
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	balances := make([]uint64, len(input.Balances))
+	for i, bal := range input.Balances {
+		balances[i] = bal % (params.BeaconConfig().MaxEffectiveBalance + 1)
+	}
+
+	genesis := forkChoiceBlockRoot(0)
+	roots := [][32]byte{genesis}
+	slots := map[[32]byte]uint64{genesis: 0}
+	parents := make(map[[32]byte][32]byte)
+	justified := checkpoint{root: genesis}
+	finalized := checkpoint{root: genesis}
+
+	f := protoarray.New(0, 0, genesis)
+	if err := f.ProcessBlock(ctx, 0, genesis, [32]byte{}, 0, 0); err != nil {
+		return fail(err)
+	}
+	var head [32]byte
+	var clock uint64
+	for _, e := range input.Events {
+		if e == nil {
+			return fail(fmt.Errorf("nil event"))
+		}
+		if e.Time > clock {
+			clock = e.Time
+		}
+		if e.IsBlock {
+			parent := roots[int(e.Parent)%len(roots)]
+			if e.Slot > clock || e.Slot <= slots[parent] {
+				continue
+			}
+			root := forkChoiceBlockRoot(uint64(len(roots)))
+			roots = append(roots, root)
+			slots[root] = e.Slot
+			parents[root] = parent
+
+			epoch := e.Slot / slotsPerEpoch
+			if e.Justify && epoch > justified.epoch && descends(parents, parent, justified.root) {
+				if e.Finalize {
+					finalized = justified
+				}
+				justified = checkpoint{epoch: epoch, root: parent}
+			}
+			if err := f.ProcessBlock(ctx, e.Slot, root, parent, justified.epoch, finalized.epoch); err != nil {
+				return fail(err)
+			}
+			if err := f.Prune(ctx, finalized.root); err != nil {
+				return fail(err)
+			}
+		} else {
+			if e.TargetEpoch > clock/slotsPerEpoch || len(e.Validators) > maxForkChoiceValidators {
+				continue
+			}
+			indices := make([]uint64, len(e.Validators))
+			for i, v := range e.Validators {
+				indices[i] = v % maxForkChoiceValidators
+			}
+			f.ProcessAttestation(ctx, indices, roots[int(e.Block)%len(roots)], e.TargetEpoch)
+		}
+
+		previousFinalized := f.Snapshot().FinalizedEpoch
+		var err error
+		head, err = f.Head(ctx, justified.epoch, justified.root, balances, finalized.epoch)
+		if err != nil {
+			return fail(err)
+		}
+		if finalizedEpoch := f.Snapshot().FinalizedEpoch; finalizedEpoch < previousFinalized {
+			panic(fmt.Sprintf("finalized epoch decreased from %d to %d", previousFinalized, finalizedEpoch))
+		}
+		if !headDescendsFromJustified(f, head, justified.root) {
+			panic(fmt.Sprintf("head %#x does not descend from justified root %#x", head, justified.root))
+		}
+	}
+	return head[:], true
+}
+
+// forkChoiceBlockRoot returns the root used for the block received at the given index.
+func forkChoiceBlockRoot(index uint64) [32]byte {
+	var root [32]byte
+	binary.LittleEndian.PutUint64(root[:], index+1)
+	return root
+}
+
+// descends reports whether root is the ancestor or one of its descendants.
+func descends(parents map[[32]byte][32]byte, root [32]byte, ancestor [32]byte) bool {
+	for {
+		if root == ancestor {
+			return true
+		}
+		parent, ok := parents[root]
+		if !ok {
+			return false
+		}
+		root = parent
+	}
+}
+
+// headDescendsFromJustified walks the nodes of the fork choice store from head back
+// to the justified root.
+func headDescendsFromJustified(f *protoarray.ForkChoice, head [32]byte, justifiedRoot [32]byte) bool {
+	nodes := f.Nodes()
+	indices := make(map[[32]byte]int, len(nodes))
+	for i, n := range nodes {
+		indices[n.Root()] = i
+	}
+	i, ok := indices[head]
+	if !ok {
+		return false
+	}
+	for {
+		if nodes[i].Root() == justifiedRoot {
+			return true
+		}
+		if nodes[i].Parent >= uint64(len(nodes)) {
+			return false
+		}
+		i = int(nodes[i].Parent)
+	}
+}
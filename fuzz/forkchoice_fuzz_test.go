@@ -0,0 +1,55 @@
+package fuzz
+
+import (
+	"bytes"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/prysmaticlabs/go-ssz"
+)
+
+func TestBeaconFuzzForkChoice_InvalidInput(t *testing.T) {
+	if _, ok := BeaconFuzzForkChoice([]byte{1, 2, 3}); ok {
+		t.Error("Expected malformed input to fail")
+	}
+}
+
+func TestBeaconFuzzForkChoice_FollowsVotes(t *testing.T) {
+	input := &InputForkChoice{
+		Balances: []uint64{32, 32, 32},
+		Events: []*ForkChoiceEvent{
+			// Block 1 at slot 1 and block 2 at slot 2 build on genesis.
+			{Time: 1, IsBlock: true, Slot: 1, Parent: 0},
+			{Time: 2, IsBlock: true, Slot: 2, Parent: 0},
+			// Block from the future, ignored.
+			{Time: 2, IsBlock: true, Slot: 5, Parent: 1},
+			{Time: 2, Block: 1, Validators: []uint64{0, 1}},
+			{Time: 3, Block: 2, Validators: []uint64{2}},
+		},
+	}
+	b, err := ssz.Marshal(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, ok := BeaconFuzzForkChoice(b)
+	if !ok {
+		t.Fatal("Expected fork choice input to be processed")
+	}
+	want := forkChoiceBlockRoot(1)
+	if !bytes.Equal(head, want[:]) {
+		t.Errorf("Wanted head %#x, received %#x", want, head)
+	}
+}
+
+func TestFuzzForkChoice_1000(t *testing.T) {
+	fuzzer := fuzz.NewWithSeed(0).NilChance(0.05).NumElements(0, 32)
+	for i := 0; i < 1000; i++ {
+		input := &InputForkChoice{}
+		fuzzer.Fuzz(input)
+		b, err := ssz.Marshal(input)
+		if err != nil {
+			continue
+		}
+		BeaconFuzzForkChoice(b)
+	}
+}
@@ -0,0 +1,88 @@
+//go:build gofuzz
+// +build gofuzz
+
+package fuzz
+
+// Entry points for go-fuzz and, when built with go-fuzz-build -libfuzzer, for libFuzzer.
+// Inputs which are processed successfully return 1 so the fuzzer prefers them in its
+// corpus, rejected inputs return 0. A panic is not recovered and is reported as a crash.
+// See scripts/build-fuzzers.sh.
+
+func result(_ []byte, ok bool) int {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+// FuzzAttestation wraps BeaconFuzzAttestation.
+func FuzzAttestation(data []byte) int { return result(BeaconFuzzAttestation(data)) }
+
+// FuzzDeposit wraps BeaconFuzzDeposit.
+func FuzzDeposit(data []byte) int { return result(BeaconFuzzDeposit(data)) }
+
+// FuzzAttesterSlashing wraps BeaconFuzzAttesterSlashing.
+func FuzzAttesterSlashing(data []byte) int { return result(BeaconFuzzAttesterSlashing(data)) }
+
+// FuzzProposerSlashing wraps BeaconFuzzProposerSlashing.
+func FuzzProposerSlashing(data []byte) int { return result(BeaconFuzzProposerSlashing(data)) }
+
+// FuzzVoluntaryExit wraps BeaconFuzzVoluntaryExit.
+func FuzzVoluntaryExit(data []byte) int { return result(BeaconFuzzVoluntaryExit(data)) }
+
+// FuzzEth1Data wraps BeaconFuzzEth1Data.
+func FuzzEth1Data(data []byte) int { return result(BeaconFuzzEth1Data(data)) }
+
+// FuzzForkChoice wraps BeaconFuzzForkChoice.
+func FuzzForkChoice(data []byte) int { return result(BeaconFuzzForkChoice(data)) }
+
+// FuzzState wraps BeaconFuzzState.
+func FuzzState(data []byte) int { return result(BeaconFuzzState(data)) }
+
+// FuzzStatus wraps BeaconFuzzStatus.
+func FuzzStatus(data []byte) int { return result(BeaconFuzzStatus(data)) }
+
+// FuzzGoodbye wraps BeaconFuzzGoodbye.
+func FuzzGoodbye(data []byte) int { return result(BeaconFuzzGoodbye(data)) }
+
+// FuzzPing wraps BeaconFuzzPing.
+func FuzzPing(data []byte) int { return result(BeaconFuzzPing(data)) }
+
+// FuzzMetaData wraps BeaconFuzzMetaData.
+func FuzzMetaData(data []byte) int { return result(BeaconFuzzMetaData(data)) }
+
+// FuzzBlocksByRangeRequest wraps BeaconFuzzBlocksByRangeRequest.
+func FuzzBlocksByRangeRequest(data []byte) int { return result(BeaconFuzzBlocksByRangeRequest(data)) }
+
+// FuzzBlocksByRootRequest wraps BeaconFuzzBlocksByRootRequest.
+func FuzzBlocksByRootRequest(data []byte) int { return result(BeaconFuzzBlocksByRootRequest(data)) }
+
+// FuzzBlockResponse wraps BeaconFuzzBlockResponse.
+func FuzzBlockResponse(data []byte) int { return result(BeaconFuzzBlockResponse(data)) }
+
+// FuzzErrorResponse wraps BeaconFuzzErrorResponse.
+func FuzzErrorResponse(data []byte) int { return result(BeaconFuzzErrorResponse(data)) }
+
+// FuzzGossipBlock wraps BeaconFuzzGossipBlock.
+func FuzzGossipBlock(data []byte) int { return result(BeaconFuzzGossipBlock(data)) }
+
+// FuzzGossipAttestation wraps BeaconFuzzGossipAttestation.
+func FuzzGossipAttestation(data []byte) int { return result(BeaconFuzzGossipAttestation(data)) }
+
+// FuzzGossipAggregateAndProof wraps BeaconFuzzGossipAggregateAndProof.
+func FuzzGossipAggregateAndProof(data []byte) int {
+	return result(BeaconFuzzGossipAggregateAndProof(data))
+}
+
+// FuzzGossipVoluntaryExit wraps BeaconFuzzGossipVoluntaryExit.
+func FuzzGossipVoluntaryExit(data []byte) int { return result(BeaconFuzzGossipVoluntaryExit(data)) }
+
+// FuzzGossipProposerSlashing wraps BeaconFuzzGossipProposerSlashing.
+func FuzzGossipProposerSlashing(data []byte) int {
+	return result(BeaconFuzzGossipProposerSlashing(data))
+}
+
+// FuzzGossipAttesterSlashing wraps BeaconFuzzGossipAttesterSlashing.
+func FuzzGossipAttesterSlashing(data []byte) int {
+	return result(BeaconFuzzGossipAttesterSlashing(data))
+}
@@ -0,0 +1,63 @@
+package fuzz
+
+import (
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// InputAttestation is the SSZ encoded input of the attestation fuzz target.
+type InputAttestation struct {
+	StateID     uint16
+	Attestation *ethpb.Attestation
+}
+
+// InputDeposit is the SSZ encoded input of the deposit fuzz target.
+type InputDeposit struct {
+	StateID uint16
+	Deposit *ethpb.Deposit
+}
+
+// InputAttesterSlashing is the SSZ encoded input of the attester slashing fuzz target.
+type InputAttesterSlashing struct {
+	StateID          uint16
+	AttesterSlashing *ethpb.AttesterSlashing
+}
+
+// InputProposerSlashing is the SSZ encoded input of the proposer slashing fuzz target.
+type InputProposerSlashing struct {
+	StateID          uint16
+	ProposerSlashing *ethpb.ProposerSlashing
+}
+
+// InputVoluntaryExit is the SSZ encoded input of the voluntary exit fuzz target.
+type InputVoluntaryExit struct {
+	StateID       uint16
+	VoluntaryExit *ethpb.SignedVoluntaryExit
+}
+
+// InputEth1Data is the SSZ encoded input of the eth1 data fuzz target.
+type InputEth1Data struct {
+	StateID  uint16
+	Eth1Data *ethpb.Eth1Data
+}
+
+// InputForkChoice is the SSZ encoded input of the fork choice fuzz target, a sequence
+// of events applied in order to a fork choice store starting from a genesis block.
+type InputForkChoice struct {
+	Balances []uint64           `ssz-max:"64"`
+	Events   []*ForkChoiceEvent `ssz-max:"1024"`
+}
+
+// ForkChoiceEvent is a block or, if IsBlock is not set, an attestation arriving at
+// the given slot. Blocks refer to their parent and attestations to their target by
+// the index of the block in the order blocks were received, the genesis block being 0.
+type ForkChoiceEvent struct {
+	Time        uint64
+	IsBlock     bool
+	Slot        uint64
+	Parent      uint16
+	Justify     bool
+	Finalize    bool
+	Block       uint16
+	TargetEpoch uint64
+	Validators  []uint64 `ssz-max:"64"`
+}
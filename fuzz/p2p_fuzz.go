@@ -0,0 +1,112 @@
+package fuzz
+
+import (
+	"bytes"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// networkEncoder decodes messages the way the p2p service does for peers.
+var networkEncoder = &encoder.SszNetworkEncoder{UseSnappyCompression: true}
+
+// BeaconFuzzStatus decodes a status req/resp chunk.
+func BeaconFuzzStatus(b []byte) ([]byte, bool) {
+	return decodeChunk(b, &pb.Status{})
+}
+
+// BeaconFuzzGoodbye decodes a goodbye req/resp chunk.
+func BeaconFuzzGoodbye(b []byte) ([]byte, bool) {
+	return decodeChunk(b, new(uint64))
+}
+
+// BeaconFuzzPing decodes a ping req/resp chunk.
+func BeaconFuzzPing(b []byte) ([]byte, bool) {
+	return decodeChunk(b, new(uint64))
+}
+
+// BeaconFuzzMetaData decodes a metadata response chunk.
+func BeaconFuzzMetaData(b []byte) ([]byte, bool) {
+	return decodeChunk(b, &pb.MetaData{})
+}
+
+// BeaconFuzzBlocksByRangeRequest decodes a blocks by range request chunk.
+func BeaconFuzzBlocksByRangeRequest(b []byte) ([]byte, bool) {
+	return decodeChunk(b, &pb.BeaconBlocksByRangeRequest{})
+}
+
+// BeaconFuzzBlocksByRootRequest decodes a blocks by root request chunk.
+func BeaconFuzzBlocksByRootRequest(b []byte) ([]byte, bool) {
+	roots := [][32]byte{}
+	return decodeChunk(b, &roots)
+}
+
+// BeaconFuzzBlockResponse decodes a signed block chunk of a blocks by range or
+// blocks by root response.
+func BeaconFuzzBlockResponse(b []byte) ([]byte, bool) {
+	return decodeChunk(b, &ethpb.SignedBeaconBlock{})
+}
+
+// BeaconFuzzErrorResponse decodes the error message chunk of a failed response.
+func BeaconFuzzErrorResponse(b []byte) ([]byte, bool) {
+	msg := make([]byte, 0)
+	return decodeChunk(b, &msg)
+}
+
+// BeaconFuzzGossipBlock decodes a beacon block gossip message.
+func BeaconFuzzGossipBlock(b []byte) ([]byte, bool) {
+	return decodeGossip(b, &ethpb.SignedBeaconBlock{})
+}
+
+// BeaconFuzzGossipAttestation decodes a committee attestation gossip message.
+func BeaconFuzzGossipAttestation(b []byte) ([]byte, bool) {
+	return decodeGossip(b, &ethpb.Attestation{})
+}
+
+// BeaconFuzzGossipAggregateAndProof decodes an aggregate and proof gossip message.
+func BeaconFuzzGossipAggregateAndProof(b []byte) ([]byte, bool) {
+	return decodeGossip(b, &ethpb.SignedAggregateAttestationAndProof{})
+}
+
+// BeaconFuzzGossipVoluntaryExit decodes a voluntary exit gossip message.
+func BeaconFuzzGossipVoluntaryExit(b []byte) ([]byte, bool) {
+	return decodeGossip(b, &ethpb.SignedVoluntaryExit{})
+}
+
+// BeaconFuzzGossipProposerSlashing decodes a proposer slashing gossip message.
+func BeaconFuzzGossipProposerSlashing(b []byte) ([]byte, bool) {
+	return decodeGossip(b, &ethpb.ProposerSlashing{})
+}
+
+// BeaconFuzzGossipAttesterSlashing decodes an attester slashing gossip message.
+func BeaconFuzzGossipAttesterSlashing(b []byte) ([]byte, bool) {
+	return decodeGossip(b, &ethpb.AttesterSlashing{})
+}
+
+// decodeChunk decodes a length prefixed, snappy compressed req/resp chunk and
+// returns the SSZ encoding of the decoded message.
+func decodeChunk(b []byte, to interface{}) ([]byte, bool) {
+	if err := networkEncoder.DecodeWithLength(bytes.NewReader(b), to); err != nil {
+		return fail(err)
+	}
+	return reencode(to)
+}
+
+// decodeGossip decodes a snappy compressed gossip message and returns the SSZ
+// encoding of the decoded message.
+func decodeGossip(b []byte, to interface{}) ([]byte, bool) {
+	if err := networkEncoder.DecodeGossip(b, to); err != nil {
+		return fail(err)
+	}
+	return reencode(to)
+}
+
+func reencode(msg interface{}) ([]byte, bool) {
+	enc, err := ssz.Marshal(msg)
+	if err != nil {
+		return fail(err)
+	}
+	return enc, true
+}
@@ -0,0 +1,68 @@
+package fuzz
+
+import (
+	"bytes"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestBeaconFuzzStatus_RoundTrip(t *testing.T) {
+	msg := &pb.Status{
+		ForkDigest:     []byte{1, 2, 3, 4},
+		FinalizedRoot:  make([]byte, 32),
+		FinalizedEpoch: 3,
+		HeadRoot:       make([]byte, 32),
+		HeadSlot:       100,
+	}
+	buf := new(bytes.Buffer)
+	if _, err := networkEncoder.EncodeWithLength(buf, msg); err != nil {
+		t.Fatal(err)
+	}
+	enc, ok := BeaconFuzzStatus(buf.Bytes())
+	if !ok {
+		t.Fatal("Expected encoded status to decode")
+	}
+	want, err := ssz.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(enc, want) {
+		t.Errorf("Wanted %#x, received %#x", want, enc)
+	}
+}
+
+func TestBeaconFuzzGossipAttestation_RoundTrip(t *testing.T) {
+	msg := &ethpb.Attestation{
+		AggregationBits: []byte{0x03},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	buf := new(bytes.Buffer)
+	if _, err := networkEncoder.EncodeGossip(buf, msg); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := BeaconFuzzGossipAttestation(buf.Bytes()); !ok {
+		t.Error("Expected encoded attestation to decode")
+	}
+}
+
+func TestBeaconFuzzP2P_MalformedInput(t *testing.T) {
+	targets := map[string]func([]byte) ([]byte, bool){
+		"status":          BeaconFuzzStatus,
+		"blocks by range": BeaconFuzzBlocksByRangeRequest,
+		"block response":  BeaconFuzzBlockResponse,
+		"gossip block":    BeaconFuzzGossipBlock,
+	}
+	for name, target := range targets {
+		if _, ok := target([]byte{0xff, 0xff, 0xff}); ok {
+			t.Errorf("Expected %s target to reject malformed input", name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package fuzz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/prysmaticlabs/go-ssz"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// BeaconFuzzState decodes an SSZ encoded beacon state and returns its canonical
+// encoding. The target panics if the hash tree root of the state trie differs from
+// the SSZ hash tree root of the decoded state, if the input is not the canonical
+// encoding of the state, or if decoding the re-serialized state does not reproduce
+// the same root.
+func BeaconFuzzState(b []byte) ([]byte, bool) {
+	setup()
+	st := &pb.BeaconState{}
+	if err := ssz.Unmarshal(b, st); err != nil {
+		return fail(err)
+	}
+	s, err := stateTrie.InitializeFromProto(st)
+	if err != nil {
+		return fail(err)
+	}
+	root, err := s.HashTreeRoot(context.Background())
+	if err != nil {
+		return fail(err)
+	}
+	sszRoot, err := ssz.HashTreeRoot(st)
+	if err != nil {
+		return fail(err)
+	}
+	if root != sszRoot {
+		panic(fmt.Sprintf("state trie root %#x differs from ssz root %#x", root, sszRoot))
+	}
+
+	enc, err := ssz.Marshal(s.InnerStateUnsafe())
+	if err != nil {
+		panic(fmt.Sprintf("could not re-serialize decoded state: %v", err))
+	}
+	if !bytes.Equal(enc, b) {
+		panic("accepted a non-canonical state encoding")
+	}
+	decoded := &pb.BeaconState{}
+	if err := ssz.Unmarshal(enc, decoded); err != nil {
+		panic(fmt.Sprintf("could not decode re-serialized state: %v", err))
+	}
+	decodedRoot, err := ssz.HashTreeRoot(decoded)
+	if err != nil {
+		panic(fmt.Sprintf("could not compute root of re-serialized state: %v", err))
+	}
+	if decodedRoot != root {
+		panic(fmt.Sprintf("root changed from %#x to %#x after round trip", root, decodedRoot))
+	}
+	return enc, true
+}
@@ -0,0 +1,45 @@
+package fuzz
+
+import (
+	"bytes"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	"github.com/prysmaticlabs/go-ssz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestBeaconFuzzState_InvalidInput(t *testing.T) {
+	if _, ok := BeaconFuzzState([]byte{1, 2, 3}); ok {
+		t.Error("Expected malformed input to fail")
+	}
+}
+
+func TestBeaconFuzzState_RoundTrip(t *testing.T) {
+	st, _ := testutil.DeterministicGenesisState(t, 64)
+	enc, err := ssz.Marshal(st.InnerStateUnsafe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, ok := BeaconFuzzState(enc)
+	if !ok {
+		t.Fatal("Expected genesis state to be processed")
+	}
+	if !bytes.Equal(out, enc) {
+		t.Error("Expected re-serialized state to equal the input")
+	}
+}
+
+func TestFuzzBeaconState_1000(t *testing.T) {
+	fuzzer := fuzz.NewWithSeed(0).NilChance(0.1).NumElements(0, 16)
+	for i := 0; i < 1000; i++ {
+		st := &pb.BeaconState{}
+		fuzzer.Fuzz(st)
+		enc, err := ssz.Marshal(st)
+		if err != nil {
+			continue
+		}
+		BeaconFuzzState(enc)
+	}
+}
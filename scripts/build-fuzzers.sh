@@ -0,0 +1,46 @@
+#!/bin/bash
+
+# Script to build the targets in ./fuzz as libFuzzer binaries for continuous fuzzing.
+# Requires go-fuzz-build (github.com/dvyukov/go-fuzz) and clang with -fsanitize=fuzzer.
+# Binaries are written to $OUT, default ./fuzz-out. Run one with a corpus directory, e.g.
+#   ./fuzz-out/attestation corpus/attestation
+# BEACONFUZZ_STATES_PATH must point at the pre-states for the state transition targets.
+
+set -e
+
+OUT=${OUT:-./fuzz-out}
+mkdir -p "$OUT"
+
+# Use a space to separate the binary name from its go-fuzz entry point in fuzz/gofuzz.go.
+fuzzers=(
+    "attestation FuzzAttestation"
+    "deposit FuzzDeposit"
+    "attester_slashing FuzzAttesterSlashing"
+    "proposer_slashing FuzzProposerSlashing"
+    "voluntary_exit FuzzVoluntaryExit"
+    "eth1_data FuzzEth1Data"
+    "forkchoice FuzzForkChoice"
+    "state FuzzState"
+    "status FuzzStatus"
+    "goodbye FuzzGoodbye"
+    "ping FuzzPing"
+    "metadata FuzzMetaData"
+    "blocks_by_range_request FuzzBlocksByRangeRequest"
+    "blocks_by_root_request FuzzBlocksByRootRequest"
+    "block_response FuzzBlockResponse"
+    "error_response FuzzErrorResponse"
+    "gossip_block FuzzGossipBlock"
+    "gossip_attestation FuzzGossipAttestation"
+    "gossip_aggregate_and_proof FuzzGossipAggregateAndProof"
+    "gossip_voluntary_exit FuzzGossipVoluntaryExit"
+    "gossip_proposer_slashing FuzzGossipProposerSlashing"
+    "gossip_attester_slashing FuzzGossipAttesterSlashing")
+
+for ((i = 0; i < ${#fuzzers[@]}; i++)); do
+    name=${fuzzers[i]% *};
+    func=${fuzzers[i]#* };
+    echo "building $OUT/$name from $func";
+    go-fuzz-build -libfuzzer -func "$func" -o "$OUT/$name.a" ./fuzz
+    clang -fsanitize=fuzzer "$OUT/$name.a" -o "$OUT/$name"
+    rm "$OUT/$name.a"
+done
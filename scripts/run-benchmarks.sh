@@ -0,0 +1,33 @@
+#!/bin/bash
+
+# Script to run the state transition benchmarks of beacon-chain/core/state, covering
+# block processing, epoch processing and hash tree root computation.
+# Sizes are set with VALIDATORS and ATTESTATIONS, e.g. VALIDATORS=65536 for mainnet
+# sized states. Benchmark files missing for these sizes are generated first, which can
+# take a long time for large states; only the default sized files are checked in.
+# Results are written to $OUT in a format which can be compared with benchstat.
+
+set -e
+
+VALIDATORS=${VALIDATORS:-16384}
+ATTESTATIONS=${ATTESTATIONS:-128}
+COUNT=${COUNT:-1}
+OUT=${OUT:-./bench_output.txt}
+FILES_DIR=$(pwd)/shared/benchutil/benchmark_files
+
+if [[ ! -f "$FILES_DIR/fullBlock-${ATTESTATIONS}Atts-${VALIDATORS}Vals.ssz" ]]; then
+    echo "generating benchmark files for $VALIDATORS validators and $ATTESTATIONS attestations per epoch";
+    bazel run //tools/benchmark-files-gen -- --output-dir "$FILES_DIR" \
+        --validator-count "$VALIDATORS" --attestations-per-epoch "$ATTESTATIONS" --overwrite
+fi
+
+bazel test //beacon-chain/core/state:go_default_test \
+    --nocache_test_results \
+    --test_output=streamed \
+    --test_timeout=2000 \
+    --test_env=BENCHMARK_VALIDATOR_COUNT="$VALIDATORS" \
+    --test_env=BENCHMARK_ATTESTATIONS_PER_EPOCH="$ATTESTATIONS" \
+    --test_arg=-test.run=XXX \
+    --test_arg=-test.bench=. \
+    --test_arg=-test.benchmem \
+    --test_arg=-test.count="$COUNT" | tee "$OUT"
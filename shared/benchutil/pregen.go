@@ -5,6 +5,8 @@ package benchutil
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
+	"strconv"
 
 	"github.com/bazelbuild/rules_go/go/tools/bazel"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -25,16 +27,47 @@ var ValidatorCount = uint64(16384)
 var AttestationsPerEpoch = uint64(128)
 
 // GenesisFileName is the generated genesis beacon state file name.
-var GenesisFileName = fmt.Sprintf("bStateGenesis-%dAtts-%dVals.ssz", AttestationsPerEpoch, ValidatorCount)
+var GenesisFileName string
 
 // BState1EpochFileName is the generated beacon state after 1 skipped epoch file name.
-var BState1EpochFileName = fmt.Sprintf("bState1Epoch-%dAtts-%dVals.ssz", AttestationsPerEpoch, ValidatorCount)
+var BState1EpochFileName string
 
 // BState2EpochFileName is the generated beacon state after 2 full epochs file name.
-var BState2EpochFileName = fmt.Sprintf("bState2Epochs-%dAtts-%dVals.ssz", AttestationsPerEpoch, ValidatorCount)
+var BState2EpochFileName string
 
 // FullBlockFileName is the generated full block file name.
-var FullBlockFileName = fmt.Sprintf("fullBlock-%dAtts-%dVals.ssz", AttestationsPerEpoch, ValidatorCount)
+var FullBlockFileName string
+
+// ValidatorCountEnv and AttestationsPerEpochEnv are the environment variables overriding
+// the default benchmark sizes, so larger files such as mainnet sized states can be
+// generated and benchmarked without code changes.
+const (
+	ValidatorCountEnv       = "BENCHMARK_VALIDATOR_COUNT"
+	AttestationsPerEpochEnv = "BENCHMARK_ATTESTATIONS_PER_EPOCH"
+)
+
+func init() {
+	validatorCount := ValidatorCount
+	attestationsPerEpoch := AttestationsPerEpoch
+	if v, err := strconv.ParseUint(os.Getenv(ValidatorCountEnv), 10, 64); err == nil && v > 0 {
+		validatorCount = v
+	}
+	if v, err := strconv.ParseUint(os.Getenv(AttestationsPerEpochEnv), 10, 64); err == nil && v > 0 {
+		attestationsPerEpoch = v
+	}
+	Configure(validatorCount, attestationsPerEpoch)
+}
+
+// Configure sets the number of validators and attestations per epoch the benchmarks are
+// performed with, along with the names of the pregenerated files of that size.
+func Configure(validatorCount uint64, attestationsPerEpoch uint64) {
+	ValidatorCount = validatorCount
+	AttestationsPerEpoch = attestationsPerEpoch
+	GenesisFileName = fmt.Sprintf("bStateGenesis-%dAtts-%dVals.ssz", AttestationsPerEpoch, ValidatorCount)
+	BState1EpochFileName = fmt.Sprintf("bState1Epoch-%dAtts-%dVals.ssz", AttestationsPerEpoch, ValidatorCount)
+	BState2EpochFileName = fmt.Sprintf("bState2Epochs-%dAtts-%dVals.ssz", AttestationsPerEpoch, ValidatorCount)
+	FullBlockFileName = fmt.Sprintf("fullBlock-%dAtts-%dVals.ssz", AttestationsPerEpoch, ValidatorCount)
+}
 
 func filePath(path string) string {
 	return fmt.Sprintf("shared/benchutil/benchmark_files/%s", path)
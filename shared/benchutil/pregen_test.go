@@ -24,3 +24,16 @@ func TestPreGenState2FullEpochs(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestConfigure_FileNames(t *testing.T) {
+	validatorCount, attestationsPerEpoch := ValidatorCount, AttestationsPerEpoch
+	defer Configure(validatorCount, attestationsPerEpoch)
+
+	Configure(65536, 256)
+	if BState1EpochFileName != "bState1Epoch-256Atts-65536Vals.ssz" {
+		t.Errorf("Unexpected file name %s", BState1EpochFileName)
+	}
+	if FullBlockFileName != "fullBlock-256Atts-65536Vals.ssz" {
+		t.Errorf("Unexpected file name %s", FullBlockFileName)
+	}
+}
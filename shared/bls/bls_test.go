@@ -283,3 +283,37 @@ func TestSerialize(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestVerifyMultipleSignatures(t *testing.T) {
+	pubkeys := make([]*bls.PublicKey, 0, 10)
+	sigs := make([]*bls.Signature, 0, 10)
+	var msgs [][32]byte
+	for i := 0; i < 10; i++ {
+		msg := [32]byte{'h', 'e', 'l', 'l', 'o', byte(i)}
+		priv := bls.RandKey()
+		pubkeys = append(pubkeys, priv.PublicKey())
+		sigs = append(sigs, priv.Sign(msg[:]))
+		msgs = append(msgs, msg)
+	}
+	verified, err := bls.VerifyMultipleSignatures(sigs, msgs, pubkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verified {
+		t.Error("Signatures did not verify")
+	}
+
+	// Swapping two signatures must fail verification.
+	sigs[0], sigs[1] = sigs[1], sigs[0]
+	verified, err = bls.VerifyMultipleSignatures(sigs, msgs, pubkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified {
+		t.Error("Expected swapped signatures to fail verification")
+	}
+
+	if _, err := bls.VerifyMultipleSignatures(sigs, msgs[1:], pubkeys); err == nil {
+		t.Error("Expected error for differing lengths")
+	}
+}
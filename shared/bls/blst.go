@@ -0,0 +1,276 @@
+// +build blst_enabled
+
+package bls
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// dst is the domain separation tag of the proof of possession scheme used by Ethereum 2.0.
+var dst = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// randBitsEntropy is the number of random bits used to weight each signature in batch verification.
+const randBitsEntropy = 64
+
+// BatchVerificationSupported is true, as VerifyMultipleSignatures verifies all signatures
+// in a single randomly weighted multi-pairing.
+const BatchVerificationSupported = true
+
+// Signature used in the BLS signature scheme.
+type Signature struct {
+	s *blst.P2Affine
+}
+
+// PublicKey used in the BLS signature scheme.
+type PublicKey struct {
+	p *blst.P1Affine
+}
+
+// SecretKey used in the BLS signature scheme.
+type SecretKey struct {
+	p *blst.SecretKey
+}
+
+// RandKey creates a new private key using a random method provided as an io.Reader.
+func RandKey() *SecretKey {
+	var ikm [32]byte
+	if _, err := rand.Read(ikm[:]); err != nil {
+		panic(err)
+	}
+	return &SecretKey{p: blst.KeyGen(ikm[:])}
+}
+
+// SecretKeyFromBytes creates a BLS private key from a BigEndian byte slice.
+func SecretKeyFromBytes(priv []byte) (*SecretKey, error) {
+	if len(priv) != params.BeaconConfig().BLSSecretKeyLength {
+		return nil, fmt.Errorf("secret key must be %d bytes", params.BeaconConfig().BLSSecretKeyLength)
+	}
+	secKey := new(blst.SecretKey).Deserialize(priv)
+	if secKey == nil {
+		return nil, errors.New("could not unmarshal bytes into secret key")
+	}
+	return &SecretKey{p: secKey}, nil
+}
+
+// PublicKeyFromBytes creates a BLS public key from a  BigEndian byte slice.
+func PublicKeyFromBytes(pub []byte) (*PublicKey, error) {
+	if featureconfig.Get().SkipBLSVerify {
+		return &PublicKey{}, nil
+	}
+	if len(pub) != params.BeaconConfig().BLSPubkeyLength {
+		return nil, fmt.Errorf("public key must be %d bytes", params.BeaconConfig().BLSPubkeyLength)
+	}
+	cv, ok := pubkeyCache.Get(string(pub))
+	if ok {
+		return cv.(*PublicKey).Copy()
+	}
+	pubKey := new(blst.P1Affine).Uncompress(pub)
+	if pubKey == nil {
+		return nil, errors.New("could not unmarshal bytes into public key")
+	}
+	// Public keys are validated once here, so they are not checked again on every verification.
+	if !pubKey.KeyValidate() {
+		return nil, errors.New("public key is not a valid point in the group")
+	}
+	pubkeyObj := &PublicKey{p: pubKey}
+	copiedKey, err := pubkeyObj.Copy()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not copy pubkey")
+	}
+	pubkeyCache.Set(string(pub), copiedKey, 48)
+	return pubkeyObj, nil
+}
+
+// SignatureFromBytes creates a BLS signature from a LittleEndian byte slice.
+func SignatureFromBytes(sig []byte) (*Signature, error) {
+	if featureconfig.Get().SkipBLSVerify {
+		return &Signature{}, nil
+	}
+	if len(sig) != params.BeaconConfig().BLSSignatureLength {
+		return nil, fmt.Errorf("signature must be %d bytes", params.BeaconConfig().BLSSignatureLength)
+	}
+	signature := new(blst.P2Affine).Uncompress(sig)
+	if signature == nil {
+		return nil, errors.New("could not unmarshal bytes into signature")
+	}
+	return &Signature{s: signature}, nil
+}
+
+// PublicKey obtains the public key corresponding to the BLS secret key.
+func (s *SecretKey) PublicKey() *PublicKey {
+	return &PublicKey{p: new(blst.P1Affine).From(s.p)}
+}
+
+// Sign a message using a secret key - in a beacon/validator client.
+func (s *SecretKey) Sign(msg []byte) *Signature {
+	if featureconfig.Get().SkipBLSVerify {
+		return &Signature{}
+	}
+	return &Signature{s: new(blst.P2Affine).Sign(s.p, msg, dst)}
+}
+
+// Marshal a secret key into a LittleEndian byte slice.
+func (s *SecretKey) Marshal() []byte {
+	keyBytes := s.p.Serialize()
+	if len(keyBytes) < params.BeaconConfig().BLSSecretKeyLength {
+		emptyBytes := make([]byte, params.BeaconConfig().BLSSecretKeyLength-len(keyBytes))
+		keyBytes = append(emptyBytes, keyBytes...)
+	}
+	return keyBytes
+}
+
+// Marshal a public key into a LittleEndian byte slice.
+func (p *PublicKey) Marshal() []byte {
+	return p.p.Compress()
+}
+
+// Copy the public key to a new pointer reference.
+func (p *PublicKey) Copy() (*PublicKey, error) {
+	np := *p.p
+	return &PublicKey{p: &np}, nil
+}
+
+// Aggregate two public keys.
+func (p *PublicKey) Aggregate(p2 *PublicKey) *PublicKey {
+	if featureconfig.Get().SkipBLSVerify {
+		return p
+	}
+	agg := new(blst.P1Aggregate)
+	agg.Add(p.p, false)
+	agg.Add(p2.p, false)
+	p.p = agg.ToAffine()
+	return p
+}
+
+// Verify a bls signature given a public key, a message.
+func (s *Signature) Verify(msg []byte, pub *PublicKey) bool {
+	if featureconfig.Get().SkipBLSVerify {
+		return true
+	}
+	return s.s.Verify(true, pub.p, false, msg, dst)
+}
+
+// VerifyAggregate verifies each public key against its respective message.
+// This is vulnerable to rogue public-key attack. Each user must
+// provide a proof-of-knowledge of the public key.
+func (s *Signature) VerifyAggregate(pubKeys []*PublicKey, msg [][32]byte) bool {
+	return s.AggregateVerify(pubKeys, msg)
+}
+
+// AggregateVerify verifies each public key against its respective message.
+// This is vulnerable to rogue public-key attack. Each user must
+// provide a proof-of-knowledge of the public key.
+func (s *Signature) AggregateVerify(pubKeys []*PublicKey, msgs [][32]byte) bool {
+	if featureconfig.Get().SkipBLSVerify {
+		return true
+	}
+	size := len(pubKeys)
+	if size == 0 {
+		return false
+	}
+	if size != len(msgs) {
+		return false
+	}
+	rawMsgs := make([]blst.Message, size)
+	rawKeys := make([]*blst.P1Affine, size)
+	for i := 0; i < size; i++ {
+		rawMsgs[i] = msgs[i][:]
+		rawKeys[i] = pubKeys[i].p
+	}
+	return s.s.AggregateVerify(true, rawKeys, false, rawMsgs, dst)
+}
+
+// FastAggregateVerify verifies all the provided pubkeys with their aggregated signature.
+func (s *Signature) FastAggregateVerify(pubKeys []*PublicKey, msg [32]byte) bool {
+	if featureconfig.Get().SkipBLSVerify {
+		return true
+	}
+	if len(pubKeys) == 0 {
+		return false
+	}
+	rawKeys := make([]*blst.P1Affine, len(pubKeys))
+	for i := 0; i < len(pubKeys); i++ {
+		rawKeys[i] = pubKeys[i].p
+	}
+	return s.s.FastAggregateVerify(true, rawKeys, msg[:], dst)
+}
+
+// NewAggregateSignature creates a blank aggregate signature.
+func NewAggregateSignature() *Signature {
+	return &Signature{s: blst.HashToG2([]byte{'m', 'o', 'c', 'k'}, dst).ToAffine()}
+}
+
+// NewAggregatePubkey creates a blank public key.
+func NewAggregatePubkey() *PublicKey {
+	return &PublicKey{p: RandKey().PublicKey().p}
+}
+
+// AggregateSignatures converts a list of signatures into a single, aggregated sig.
+func AggregateSignatures(sigs []*Signature) *Signature {
+	if len(sigs) == 0 {
+		return nil
+	}
+	if featureconfig.Get().SkipBLSVerify {
+		return sigs[0]
+	}
+
+	rawSigs := make([]*blst.P2Affine, len(sigs))
+	for i := 0; i < len(sigs); i++ {
+		rawSigs[i] = sigs[i].s
+	}
+	agg := new(blst.P2Aggregate)
+	agg.Aggregate(rawSigs, false)
+	return &Signature{s: agg.ToAffine()}
+}
+
+// Marshal a signature into a LittleEndian byte slice.
+func (s *Signature) Marshal() []byte {
+	if featureconfig.Get().SkipBLSVerify {
+		return make([]byte, params.BeaconConfig().BLSSignatureLength)
+	}
+
+	return s.s.Compress()
+}
+
+// VerifyMultipleSignatures verifies each signature against its respective message and
+// public key in a single batch, which is much faster than verifying them one by one.
+// Each signature is weighted by a random scalar, so invalid signatures cannot cancel
+// each other out.
+func VerifyMultipleSignatures(sigs []*Signature, msgs [][32]byte, pubKeys []*PublicKey) (bool, error) {
+	if featureconfig.Get().SkipBLSVerify {
+		return true, nil
+	}
+	if len(sigs) == 0 || len(pubKeys) == 0 {
+		return false, nil
+	}
+	if len(sigs) != len(msgs) || len(msgs) != len(pubKeys) {
+		return false, errors.Errorf("provided signatures, messages and public keys have differing lengths: S: %d, M: %d, P: %d",
+			len(sigs), len(msgs), len(pubKeys))
+	}
+	rawSigs := make([]*blst.P2Affine, len(sigs))
+	rawKeys := make([]*blst.P1Affine, len(pubKeys))
+	rawMsgs := make([]blst.Message, len(msgs))
+	for i := range sigs {
+		rawSigs[i] = sigs[i].s
+		rawKeys[i] = pubKeys[i].p
+		rawMsgs[i] = msgs[i][:]
+	}
+	// The random scalars are drawn before verifying, as the callback cannot return an error.
+	randBytes := make([]byte, len(sigs)*randBitsEntropy/8)
+	if _, err := rand.Read(randBytes); err != nil {
+		return false, errors.Wrap(err, "could not generate random scalars")
+	}
+	var next uint32
+	randFn := func(scalar *blst.Scalar) {
+		i := atomic.AddUint32(&next, 1) - 1
+		scalar.FromBEndian(randBytes[i*8 : (i+1)*8])
+	}
+	return new(blst.P2Affine).MultipleAggregateVerify(rawSigs, true, rawKeys, false, rawMsgs, dst, randFn, randBitsEntropy), nil
+}
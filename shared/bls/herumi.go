@@ -0,0 +1,274 @@
+// +build !blst_enabled
+
+package bls
+
+import (
+	"fmt"
+
+	bls12 "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func init() {
+	if err := bls12.Init(bls12.BLS12_381); err != nil {
+		panic(err)
+	}
+	if err := bls12.SetETHmode(1); err != nil {
+		panic(err)
+	}
+}
+
+// BatchVerificationSupported is false, as herumi's library has no batch verification and
+// VerifyMultipleSignatures verifies signatures one by one.
+const BatchVerificationSupported = false
+
+// Signature used in the BLS signature scheme.
+type Signature struct {
+	s *bls12.Sign
+}
+
+// PublicKey used in the BLS signature scheme.
+type PublicKey struct {
+	p *bls12.PublicKey
+}
+
+// SecretKey used in the BLS signature scheme.
+type SecretKey struct {
+	p *bls12.SecretKey
+}
+
+// RandKey creates a new private key using a random method provided as an io.Reader.
+func RandKey() *SecretKey {
+	secKey := &bls12.SecretKey{}
+	secKey.SetByCSPRNG()
+	return &SecretKey{secKey}
+}
+
+// SecretKeyFromBytes creates a BLS private key from a BigEndian byte slice.
+func SecretKeyFromBytes(priv []byte) (*SecretKey, error) {
+	if len(priv) != params.BeaconConfig().BLSSecretKeyLength {
+		return nil, fmt.Errorf("secret key must be %d bytes", params.BeaconConfig().BLSSecretKeyLength)
+	}
+	secKey := &bls12.SecretKey{}
+	err := secKey.Deserialize(priv)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal bytes into secret key")
+	}
+	return &SecretKey{p: secKey}, err
+}
+
+// PublicKeyFromBytes creates a BLS public key from a  BigEndian byte slice.
+func PublicKeyFromBytes(pub []byte) (*PublicKey, error) {
+	if featureconfig.Get().SkipBLSVerify {
+		return &PublicKey{}, nil
+	}
+	if len(pub) != params.BeaconConfig().BLSPubkeyLength {
+		return nil, fmt.Errorf("public key must be %d bytes", params.BeaconConfig().BLSPubkeyLength)
+	}
+	cv, ok := pubkeyCache.Get(string(pub))
+	if ok {
+		return cv.(*PublicKey).Copy()
+	}
+	pubKey := &bls12.PublicKey{}
+	err := pubKey.Deserialize(pub)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal bytes into public key")
+	}
+	pubkeyObj := &PublicKey{p: pubKey}
+	copiedKey, err := pubkeyObj.Copy()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not copy pubkey")
+	}
+	pubkeyCache.Set(string(pub), copiedKey, 48)
+	return pubkeyObj, nil
+}
+
+// SignatureFromBytes creates a BLS signature from a LittleEndian byte slice.
+func SignatureFromBytes(sig []byte) (*Signature, error) {
+	if featureconfig.Get().SkipBLSVerify {
+		return &Signature{}, nil
+	}
+	if len(sig) != params.BeaconConfig().BLSSignatureLength {
+		return nil, fmt.Errorf("signature must be %d bytes", params.BeaconConfig().BLSSignatureLength)
+	}
+	signature := &bls12.Sign{}
+	err := signature.Deserialize(sig)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal bytes into signature")
+	}
+	return &Signature{s: signature}, nil
+}
+
+// PublicKey obtains the public key corresponding to the BLS secret key.
+func (s *SecretKey) PublicKey() *PublicKey {
+	return &PublicKey{p: s.p.GetPublicKey()}
+}
+
+// Sign a message using a secret key - in a beacon/validator client.
+func (s *SecretKey) Sign(msg []byte) *Signature {
+	if featureconfig.Get().SkipBLSVerify {
+		return &Signature{}
+	}
+	signature := s.p.SignByte(msg)
+	return &Signature{s: signature}
+}
+
+// Marshal a secret key into a LittleEndian byte slice.
+func (s *SecretKey) Marshal() []byte {
+	keyBytes := s.p.Serialize()
+	if len(keyBytes) < params.BeaconConfig().BLSSecretKeyLength {
+		emptyBytes := make([]byte, params.BeaconConfig().BLSSecretKeyLength-len(keyBytes))
+		keyBytes = append(emptyBytes, keyBytes...)
+	}
+	return keyBytes
+}
+
+// Marshal a public key into a LittleEndian byte slice.
+func (p *PublicKey) Marshal() []byte {
+	return p.p.Serialize()
+}
+
+// Copy the public key to a new pointer reference.
+func (p *PublicKey) Copy() (*PublicKey, error) {
+	np := *p.p
+	return &PublicKey{p: &np}, nil
+}
+
+// Aggregate two public keys.
+func (p *PublicKey) Aggregate(p2 *PublicKey) *PublicKey {
+	if featureconfig.Get().SkipBLSVerify {
+		return p
+	}
+	p.p.Add(p2.p)
+	return p
+}
+
+// Verify a bls signature given a public key, a message.
+func (s *Signature) Verify(msg []byte, pub *PublicKey) bool {
+	if featureconfig.Get().SkipBLSVerify {
+		return true
+	}
+	return s.s.VerifyByte(pub.p, msg)
+}
+
+// VerifyAggregate verifies each public key against its respective message.
+// This is vulnerable to rogue public-key attack. Each user must
+// provide a proof-of-knowledge of the public key.
+func (s *Signature) VerifyAggregate(pubKeys []*PublicKey, msg [][32]byte) bool {
+	if featureconfig.Get().SkipBLSVerify {
+		return true
+	}
+	size := len(pubKeys)
+	if size == 0 {
+		return false
+	}
+	if size != len(msg) {
+		return false
+	}
+	hashes := make([][]byte, 0, len(msg))
+	var rawKeys []bls12.PublicKey
+	for i := 0; i < size; i++ {
+		hashes = append(hashes, msg[i][:])
+		rawKeys = append(rawKeys, *pubKeys[i].p)
+	}
+	return s.s.VerifyAggregateHashes(rawKeys, hashes)
+}
+
+// AggregateVerify verifies each public key against its respective message.
+// This is vulnerable to rogue public-key attack. Each user must
+// provide a proof-of-knowledge of the public key.
+func (s *Signature) AggregateVerify(pubKeys []*PublicKey, msgs [][32]byte) bool {
+	if featureconfig.Get().SkipBLSVerify {
+		return true
+	}
+	size := len(pubKeys)
+	if size == 0 {
+		return false
+	}
+	if size != len(msgs) {
+		return false
+	}
+	msgSlices := []byte{}
+	var rawKeys []bls12.PublicKey
+	for i := 0; i < size; i++ {
+		msgSlices = append(msgSlices, msgs[i][:]...)
+		rawKeys = append(rawKeys, *pubKeys[i].p)
+	}
+	return s.s.AggregateVerify(rawKeys, msgSlices)
+}
+
+// FastAggregateVerify verifies all the provided pubkeys with their aggregated signature.
+func (s *Signature) FastAggregateVerify(pubKeys []*PublicKey, msg [32]byte) bool {
+	if featureconfig.Get().SkipBLSVerify {
+		return true
+	}
+	if len(pubKeys) == 0 {
+		return false
+	}
+	rawKeys := make([]bls12.PublicKey, len(pubKeys))
+	for i := 0; i < len(pubKeys); i++ {
+		rawKeys[i] = *pubKeys[i].p
+	}
+
+	return s.s.FastAggregateVerify(rawKeys, msg[:])
+}
+
+// NewAggregateSignature creates a blank aggregate signature.
+func NewAggregateSignature() *Signature {
+	return &Signature{s: bls12.HashAndMapToSignature([]byte{'m', 'o', 'c', 'k'})}
+}
+
+// NewAggregatePubkey creates a blank public key.
+func NewAggregatePubkey() *PublicKey {
+	return &PublicKey{p: RandKey().PublicKey().p}
+}
+
+// AggregateSignatures converts a list of signatures into a single, aggregated sig.
+func AggregateSignatures(sigs []*Signature) *Signature {
+	if len(sigs) == 0 {
+		return nil
+	}
+	if featureconfig.Get().SkipBLSVerify {
+		return sigs[0]
+	}
+
+	// Copy signature
+	signature := *sigs[0].s
+	for i := 1; i < len(sigs); i++ {
+		signature.Add(sigs[i].s)
+	}
+	return &Signature{s: &signature}
+}
+
+// Marshal a signature into a LittleEndian byte slice.
+func (s *Signature) Marshal() []byte {
+	if featureconfig.Get().SkipBLSVerify {
+		return make([]byte, params.BeaconConfig().BLSSignatureLength)
+	}
+
+	return s.s.Serialize()
+}
+
+// VerifyMultipleSignatures verifies each signature against its respective message and
+// public key. Herumi's library has no batch verification, so the signatures are
+// verified one by one.
+func VerifyMultipleSignatures(sigs []*Signature, msgs [][32]byte, pubKeys []*PublicKey) (bool, error) {
+	if featureconfig.Get().SkipBLSVerify {
+		return true, nil
+	}
+	if len(sigs) == 0 || len(pubKeys) == 0 {
+		return false, nil
+	}
+	if len(sigs) != len(msgs) || len(msgs) != len(pubKeys) {
+		return false, errors.Errorf("provided signatures, messages and public keys have differing lengths: S: %d, M: %d, P: %d",
+			len(sigs), len(msgs), len(pubKeys))
+	}
+	for i := range sigs {
+		if !sigs[i].Verify(msgs[i][:], pubKeys[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -12,6 +12,12 @@ var (
 		Usage: "Logging verbosity (trace, debug, info=default, warn, error, fatal, panic)",
 		Value: "info",
 	}
+	// LogLevelOverrideFlag defines the log level of individual packages.
+	LogLevelOverrideFlag = &cli.StringSliceFlag{
+		Name: "log-level-override",
+		Usage: "Overrides the logging verbosity of a package, given as prefix=level such as sync=debug. " +
+			"May be specified multiple times. Levels are reloaded from the config file on SIGHUP",
+	}
 	// DataDirFlag defines a path on disk.
 	DataDirFlag = &cli.StringFlag{
 		Name:  "datadir",
@@ -28,17 +34,28 @@ var (
 		Name:  "tracing-process-name",
 		Usage: "The name to apply to tracing tag \"process_name\"",
 	}
-	// TracingEndpointFlag flag defines the http endpoint for serving traces to Jaeger.
+	// TracingEndpointFlag flag defines the http endpoint for serving traces to the tracing exporter.
 	TracingEndpointFlag = &cli.StringFlag{
-		Name:  "tracing-endpoint",
-		Usage: "Tracing endpoint defines where beacon chain traces are exposed to Jaeger.",
-		Value: "http://127.0.0.1:14268/api/traces",
+		Name: "tracing-endpoint",
+		Usage: "Tracing endpoint defines where traces are sent to. Defaults to http://127.0.0.1:14268/api/traces for jaeger, " +
+			"http://127.0.0.1:9411/api/v2/spans for zipkin and http://127.0.0.1:4318/v1/traces for otlp",
+	}
+	// TracingExporterFlag defines a flag to specify the backend traces are exported to.
+	TracingExporterFlag = &cli.StringFlag{
+		Name:  "tracing-exporter",
+		Usage: "The tracing backend to export traces to, one of jaeger, zipkin or otlp",
+		Value: "jaeger",
+	}
+	// TracingTagsFlag defines a flag to specify additional process tags attached to every span.
+	TracingTagsFlag = &cli.StringSliceFlag{
+		Name:  "tracing-tag",
+		Usage: "A key=value tag attached to every exported span, may be specified multiple times",
 	}
 	// TraceSampleFractionFlag defines a flag to indicate what fraction of p2p
 	// messages are sampled for tracing.
 	TraceSampleFractionFlag = &cli.Float64Flag{
 		Name:  "trace-sample-fraction",
-		Usage: "Indicate what fraction of p2p messages are sampled for tracing.",
+		Usage: "Indicate what fraction of p2p messages are sampled for tracing, between 0 and 1.",
 		Value: 0.20,
 	}
 	// DisableMonitoringFlag defines a flag to disable the metrics collection.
@@ -46,6 +63,36 @@ var (
 		Name:  "disable-monitoring",
 		Usage: "Disable monitoring service.",
 	}
+	// MonitoringHostFlag defines the host interface the metrics endpoint listens on.
+	MonitoringHostFlag = &cli.StringFlag{
+		Name:  "monitoring-host",
+		Usage: "Host interface to serve prometheus metrics on, such as 127.0.0.1 to only serve local requests.",
+		Value: "0.0.0.0",
+	}
+	// MonitoringCorsDomainFlag defines the domains allowed to make cross origin requests to the metrics endpoint.
+	MonitoringCorsDomainFlag = &cli.StringFlag{
+		Name: "monitoring-corsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin requests " +
+			"(browser enforced) to the monitoring endpoints.",
+	}
+	// HTTPTLSCertFlag defines the TLS certificate of the HTTP endpoints.
+	HTTPTLSCertFlag = &cli.StringFlag{
+		Name: "http-tls-cert",
+		Usage: "Certificate for serving every HTTP endpoint, such as metrics and the gRPC gateway, over TLS. " +
+			"Pass this and the http-tls-key flag in order to serve HTTPS.",
+	}
+	// HTTPTLSKeyFlag defines the TLS key of the HTTP endpoints.
+	HTTPTLSKeyFlag = &cli.StringFlag{
+		Name: "http-tls-key",
+		Usage: "Key for serving every HTTP endpoint, such as metrics and the gRPC gateway, over TLS. " +
+			"Pass this and the http-tls-cert flag in order to serve HTTPS.",
+	}
+	// HTTPDisabledEndpointsFlag defines the HTTP paths which are not served.
+	HTTPDisabledEndpointsFlag = &cli.StringSliceFlag{
+		Name: "http-disabled-endpoint",
+		Usage: "An HTTP path, such as /goroutinez or /eth/v1alpha1/debug, which is not served by any HTTP " +
+			"endpoint along with the paths below it. This flag may be used multiple times.",
+	}
 	// NoDiscovery specifies whether we are running a local network and have no need for connecting
 	// to the bootstrap nodes in the cloud
 	NoDiscovery = &cli.BoolFlag{
@@ -55,12 +102,18 @@ var (
 	// StaticPeers specifies a set of peers to connect to explicitly.
 	StaticPeers = &cli.StringSliceFlag{
 		Name:  "peer",
-		Usage: "Connect with this peer. This flag may be used multiple times.",
+		Usage: "Connect with this peer and reconnect whenever it is dropped. This flag may be used multiple times.",
+	}
+	// TrustedPeers specifies a set of peers which are exempt from peer scoring and pruning.
+	TrustedPeers = &cli.StringSliceFlag{
+		Name:  "trusted-peer",
+		Usage: "Connect with this peer and never penalize or prune it. This flag may be used multiple times.",
 	}
 	// BootstrapNode tells the beacon node which bootstrap node to connect to
 	BootstrapNode = &cli.StringFlag{
-		Name:  "bootstrap-node",
-		Usage: "The address of bootstrap node. Beacon node will connect for peer discovery via DHT.  Multiple nodes can be separated with a comma",
+		Name: "bootstrap-node",
+		Usage: "The address of bootstrap node. Beacon node will connect for peer discovery via DHT.  Multiple nodes can be separated with a comma. " +
+			"EIP-1459 dns trees may be provided as enrtree:// or dns:// urls",
 		Value: "/dns4/prylabs.net/tcp/30001/p2p/16Uiu2HAm7Qwe19vz9WzD2Mxn7fXd1vgHHp4iccuyq7TxwRXoAGfc,enr:-Ku4QAGwOT9StqmwI5LHaIymIO4ooFKfNkEjWa0f1P8OsElgBh2Ijb-GrD_-b9W4kcPFcwmHQEy5RncqXNqdpVo1heoBh2F0dG5ldHOIAAAAAAAAAACEZXRoMpAAAAAAAAAAAP__________gmlkgnY0gmlwhBLf22SJc2VjcDI1NmsxoQJxCnE6v_x2ekgY_uoE1rtwzvGy40mq9eD66XfHPBWgIIN1ZHCCD6A",
 	}
 	// RelayNode tells the beacon node which relay node to connect to.
@@ -82,6 +135,11 @@ var (
 		Usage: "The port used by libp2p.",
 		Value: 13000,
 	}
+	// P2PQUICPort defines the UDP port to be used by libp2p for QUIC connections.
+	P2PQUICPort = &cli.IntFlag{
+		Name:  "p2p-quic-port",
+		Usage: "The UDP port used by libp2p for QUIC connections, alongside TCP. QUIC is disabled if not set",
+	}
 	// P2PIP defines the local IP to be used by libp2p.
 	P2PIP = &cli.StringFlag{
 		Name:  "p2p-local-ip",
@@ -125,6 +183,18 @@ var (
 			"would whitelist connections to peers on your local network only. The default " +
 			"is to accept all connections.",
 	}
+	// P2PAllowList defines a list of peer IDs and CIDR subnets to exclusively allow connections with.
+	P2PAllowList = &cli.StringSliceFlag{
+		Name: "p2p-allowlist",
+		Usage: "A peer ID or CIDR subnet to exclusively allow connections with. Example: 192.168.0.0/16 " +
+			"would only permit connections to peers on your local network. This flag may be used multiple times.",
+	}
+	// P2PDenyList defines a list of peer IDs and CIDR subnets to reject connections with.
+	P2PDenyList = &cli.StringSliceFlag{
+		Name: "p2p-denylist",
+		Usage: "A peer ID or CIDR subnet to reject connections with. Deny list entries take precedence " +
+			"over allowed entries. This flag may be used multiple times.",
+	}
 	// P2PEncoding defines the encoding format for p2p messages.
 	P2PEncoding = &cli.StringFlag{
 		Name:  "p2p-encoding",
@@ -137,6 +207,30 @@ var (
 		Usage: "The name of the pubsub router to use. Supported values are: gossip, flood, random",
 		Value: "gossip",
 	}
+	// P2PScoreSnapshotInterval defines how often snapshots of the gossip peer scores are taken.
+	P2PScoreSnapshotInterval = &cli.DurationFlag{
+		Name:  "p2p-score-snapshot-interval",
+		Usage: "How often to take a snapshot of the gossipsub peer scores, e.g. 1m. Snapshots are disabled by default",
+	}
+	// P2PScoreSnapshotFile defines the file gossip peer score snapshots are written to.
+	P2PScoreSnapshotFile = &cli.StringFlag{
+		Name: "p2p-score-snapshot-file",
+		Usage: "The file to write gossipsub peer score snapshots to as JSON. Snapshots are logged at debug " +
+			"level if no file is given",
+	}
+	// P2PSeenMessagesTTL defines how long the message-ids of received gossip messages are remembered.
+	P2PSeenMessagesTTL = &cli.DurationFlag{
+		Name: "p2p-seen-messages-ttl",
+		Usage: "How long the gossipsub seen messages cache remembers received messages, so duplicates are " +
+			"dropped before validation, e.g. 2m. Defaults to the pubsub library's duration",
+	}
+	// P2PSeenMessagesCacheSize defines the size of the cache of validated gossip message-ids.
+	P2PSeenMessagesCacheSize = &cli.IntFlag{
+		Name: "p2p-seen-messages-cache-size",
+		Usage: "The number of validated gossip message-ids kept to reject duplicates which arrive after " +
+			"the seen messages TTL, 0 to disable",
+		Value: 16384,
+	}
 	// ForceClearDB removes any previously stored data at the data directory.
 	ForceClearDB = &cli.BoolFlag{
 		Name:  "force-clear-db",
@@ -150,7 +244,7 @@ var (
 	// LogFormat specifies the log output format.
 	LogFormat = &cli.StringFlag{
 		Name:  "log-format",
-		Usage: "Specify log formatting. Supports: text, json, fluentd.",
+		Usage: "Specify log formatting. Supports: text, json, fluentd. Structured formats include the service, current slot and epoch of each entry.",
 		Value: "text",
 	}
 	// MaxGoroutines specifies the maximum amount of goroutines tolerated, before a status check fails.
@@ -166,12 +260,25 @@ var (
 	}
 	// EnableUPnPFlag specifies if UPnP should be enabled or not. The default value is false.
 	EnableUPnPFlag = &cli.BoolFlag{
-		Name:  "enable-upnp",
-		Usage: "Enable the service (Beacon chain or Validator) to use UPnP when possible.",
+		Name: "enable-upnp",
+		Usage: "Enable the service (Beacon chain or Validator) to use UPnP or NAT-PMP when possible. " +
+			"Maps the p2p tcp and udp ports on the local router and advertises the external IP in the ENR.",
 	}
 	// ConfigFileFlag specifies the filepath to load flag values.
 	ConfigFileFlag = &cli.StringFlag{
 		Name:  "config-file",
 		Usage: "The filepath to a yaml file with flag values",
 	}
+	// NetworkFlag selects a named network, which sets the chain config, bootstrap nodes,
+	// deposit contract and genesis state of the network at once.
+	NetworkFlag = &cli.StringFlag{
+		Name: "network",
+		Usage: "Join a named network (mainnet, testnet), using its chain config, bootstrap nodes, " +
+			"deposit contract and genesis state. Flags set explicitly take precedence over the network's values",
+	}
+	// ChainConfigFileFlag specifies the path to a chain config file.
+	ChainConfigFileFlag = &cli.StringFlag{
+		Name:  "chain-config-file",
+		Usage: "The path to a YAML file with chain config values, in the format of the spec configs. Used to run custom networks",
+	}
 )
@@ -1,11 +1,7 @@
 package cmd
 
 import (
-	"bufio"
-	"fmt"
-	"os"
-	"strings"
-
+	"github.com/prysmaticlabs/prysm/shared/promptutil"
 	"github.com/sirupsen/logrus"
 )
 
@@ -15,30 +11,5 @@ var log = logrus.WithField("prefix", "node")
 // The user must enter Y or N to indicate whether they confirm the action detailed in the warning text.
 // Returns a boolean representing the user's answer.
 func ConfirmAction(actionText string, deniedText string) (bool, error) {
-	var confirmed bool
-	reader := bufio.NewReader(os.Stdin)
-	log.Warn(actionText)
-
-	for {
-		fmt.Print(">> ")
-
-		line, _, err := reader.ReadLine()
-		if err != nil {
-			return false, err
-		}
-		trimmedLine := strings.TrimSpace(string(line))
-		lineInput := strings.ToUpper(trimmedLine)
-		if lineInput != "Y" && lineInput != "N" {
-			log.Errorf("Invalid option of %s chosen, please only enter Y/N", line)
-			continue
-		}
-		if lineInput == "Y" {
-			confirmed = true
-			break
-		}
-		log.Warn(deniedText)
-		break
-	}
-
-	return confirmed, nil
+	return promptutil.ConfirmPrompt(actionText, deniedText)
 }
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/prysmaticlabs/prysm/shared/httputil"
+	"gopkg.in/urfave/cli.v2"
+)
+
+// HTTPServerConfig returns the configuration of an HTTP server listening on the host and port,
+// accepting cross origin requests from the comma separated domains. The TLS certificate and the
+// disabled endpoints are shared by every HTTP server of the node.
+func HTTPServerConfig(cliCtx *cli.Context, host string, port int, corsDomains string) *httputil.ServerConfig {
+	return &httputil.ServerConfig{
+		Host:           host,
+		Port:           port,
+		AllowedOrigins: httputil.SplitList(corsDomains),
+		CertFile:       cliCtx.String(HTTPTLSCertFlag.Name),
+		KeyFile:        cliCtx.String(HTTPTLSKeyFlag.Name),
+		DisabledPaths:  cliCtx.StringSlice(HTTPDisabledEndpointsFlag.Name),
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/urfave/cli.v2"
+)
+
+// RenamedFlag declares a flag which was renamed. The old name still parses, so
+// existing startup scripts keep working, and its value is applied to the new flag.
+type RenamedFlag struct {
+	Old string
+	New cli.Flag
+}
+
+// RenamedFlags holds every renamed flag of the clients. When renaming a flag, keep
+// the old name working by adding an entry from the package declaring the flag, for
+// example in an init function:
+//
+//	cmd.RenamedFlags = append(cmd.RenamedFlags, &cmd.RenamedFlag{Old: "old-name", New: newNameFlag})
+var RenamedFlags []*RenamedFlag
+
+// AliasFlags returns hidden flags for the old names of the renamed flags whose new
+// flag is part of the given flags, to be registered with a client's flags.
+func AliasFlags(flags []cli.Flag) []cli.Flag {
+	aliases := make([]cli.Flag, 0)
+	for _, r := range RenamedFlags {
+		if !containsFlag(flags, r.New) {
+			continue
+		}
+		usage := fmt.Sprintf("DEPRECATED. Renamed to --%s.", r.New.Names()[0])
+		var alias cli.Flag
+		switch r.New.(type) {
+		case *cli.BoolFlag:
+			alias = &cli.BoolFlag{Name: r.Old, Usage: usage, Hidden: true}
+		case *cli.DurationFlag:
+			alias = &cli.DurationFlag{Name: r.Old, Usage: usage, Hidden: true}
+		case *cli.Float64Flag:
+			alias = &cli.Float64Flag{Name: r.Old, Usage: usage, Hidden: true}
+		case *cli.IntFlag:
+			alias = &cli.IntFlag{Name: r.Old, Usage: usage, Hidden: true}
+		case *cli.Int64Flag:
+			alias = &cli.Int64Flag{Name: r.Old, Usage: usage, Hidden: true}
+		case *cli.StringFlag:
+			alias = &cli.StringFlag{Name: r.Old, Usage: usage, Hidden: true}
+		case *cli.StringSliceFlag:
+			alias = &cli.StringSliceFlag{Name: r.Old, Usage: usage, Hidden: true}
+		case *cli.Uint64Flag:
+			alias = &cli.Uint64Flag{Name: r.Old, Usage: usage, Hidden: true}
+		case *cli.UintFlag:
+			alias = &cli.UintFlag{Name: r.Old, Usage: usage, Hidden: true}
+		default:
+			panic(fmt.Sprintf("cannot rename flag of type %T", r.New))
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
+
+// ApplyRenamedFlags warns about every renamed flag which is set under its old name and
+// assigns its value to the new flag. If both names are set, the new flag takes precedence.
+func ApplyRenamedFlags(ctx *cli.Context) error {
+	for _, r := range RenamedFlags {
+		if !ctx.IsSet(r.Old) {
+			continue
+		}
+		newName := r.New.Names()[0]
+		log.Warnf("--%s is deprecated, use --%s instead", r.Old, newName)
+		if ctx.IsSet(newName) {
+			continue
+		}
+		values, err := renamedFlagValues(ctx, r)
+		if err != nil {
+			return err
+		}
+		for _, v := range values {
+			if err := ctx.Set(newName, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renamedFlagValues returns the value set under the old name of a renamed flag, in the
+// form accepted by ctx.Set.
+func renamedFlagValues(ctx *cli.Context, r *RenamedFlag) ([]string, error) {
+	switch r.New.(type) {
+	case *cli.BoolFlag:
+		return []string{strconv.FormatBool(ctx.Bool(r.Old))}, nil
+	case *cli.DurationFlag:
+		return []string{ctx.Duration(r.Old).String()}, nil
+	case *cli.Float64Flag:
+		return []string{strconv.FormatFloat(ctx.Float64(r.Old), 'g', -1, 64)}, nil
+	case *cli.IntFlag:
+		return []string{strconv.Itoa(ctx.Int(r.Old))}, nil
+	case *cli.Int64Flag:
+		return []string{strconv.FormatInt(ctx.Int64(r.Old), 10)}, nil
+	case *cli.StringFlag:
+		return []string{ctx.String(r.Old)}, nil
+	case *cli.StringSliceFlag:
+		return ctx.StringSlice(r.Old), nil
+	case *cli.Uint64Flag:
+		return []string{strconv.FormatUint(ctx.Uint64(r.Old), 10)}, nil
+	case *cli.UintFlag:
+		return []string{strconv.FormatUint(uint64(ctx.Uint(r.Old)), 10)}, nil
+	default:
+		return nil, fmt.Errorf("cannot rename flag of type %T", r.New)
+	}
+}
+
+func containsFlag(flags []cli.Flag, f cli.Flag) bool {
+	for _, other := range flags {
+		if other.Names()[0] == f.Names()[0] {
+			return true
+		}
+	}
+	return false
+}
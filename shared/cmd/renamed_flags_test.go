@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"flag"
+	"testing"
+
+	"gopkg.in/urfave/cli.v2"
+)
+
+func TestApplyRenamedFlags(t *testing.T) {
+	newFlag := &cli.Uint64Flag{Name: "new-name"}
+	otherFlag := &cli.StringFlag{Name: "other-new-name"}
+	defer func(renamed []*RenamedFlag) { RenamedFlags = renamed }(RenamedFlags)
+	RenamedFlags = []*RenamedFlag{
+		{Old: "old-name", New: newFlag},
+		{Old: "other-old-name", New: otherFlag},
+	}
+
+	aliases := AliasFlags([]cli.Flag{newFlag})
+	if len(aliases) != 1 || aliases[0].Names()[0] != "old-name" {
+		t.Fatalf("Expected a single alias for old-name, received %v", aliases)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want uint64
+	}{
+		{
+			name: "old name applies to new flag",
+			args: []string{"--old-name", "5"},
+			want: 5,
+		},
+		{
+			name: "new name takes precedence",
+			args: []string{"--old-name", "5", "--new-name", "7"},
+			want: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := cli.App{}
+			set := flag.NewFlagSet("test", 0)
+			set.Uint64("old-name", 0, "")
+			set.Uint64("new-name", 0, "")
+			if err := set.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			ctx := cli.NewContext(&app, set, nil)
+			if err := ApplyRenamedFlags(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if got := ctx.Uint64("new-name"); got != tt.want {
+				t.Errorf("Expected --new-name=%d, received %d", tt.want, got)
+			}
+		})
+	}
+}
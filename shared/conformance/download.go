@@ -0,0 +1,98 @@
+package conformance
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultVersion is the release of the spec test vectors the beacon chain currently conforms to.
+const DefaultVersion = "v0.11.1"
+
+const releaseURL = "https://github.com/ethereum/eth2.0-spec-tests/releases/download/%s/%s.tar.gz"
+
+// Download fetches the spec test vectors of the given release and configuration preset, and
+// extracts them into the directory, which can then be used as the root directory of a Runner.
+func Download(ctx context.Context, version string, preset string, dir string) error {
+	url := fmt.Sprintf(releaseURL, version, preset)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "could not download %s", url)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("could not download %s: %s", url, resp.Status)
+	}
+	log.WithField("url", url).Info("Downloading spec test vectors")
+	return extractTarGz(resp.Body, dir)
+}
+
+// extractTarGz extracts the regular files and directories of a gzipped tarball into the
+// directory. Entries resolving outside of the directory are rejected.
+func extractTarGz(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Wrap(err, "could not read gzip stream")
+	}
+	defer func() {
+		if err := gz.Close(); err != nil {
+			log.WithError(err).Error("Could not close gzip stream")
+		}
+	}()
+	root := filepath.Clean(dir) + string(os.PathSeparator)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "could not read tar archive")
+		}
+		target := filepath.Join(dir, header.Name)
+		if !strings.HasPrefix(target+string(os.PathSeparator), root) {
+			return errors.Errorf("invalid path %s in archive", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return err
+			}
+			if err := writeFile(target, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFile(path string, r io.Reader) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,181 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	beaconstate "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+// blocksMeta is the metadata of a sanity blocks test case.
+type blocksMeta struct {
+	BlocksCount int `json:"blocks_count"`
+}
+
+// sszRoots are the expected roots of an ssz static test case.
+type sszRoots struct {
+	Root        string `json:"root"`
+	SigningRoot string `json:"signing_root"`
+}
+
+// runSlotsCase processes the pre state through the number of empty slots of the case and
+// compares the result with the post state.
+func runSlotsCase(caseDir string) error {
+	pre, err := readState(filepath.Join(caseDir, "pre.ssz"))
+	if err != nil {
+		return err
+	}
+	enc, err := ioutil.ReadFile(filepath.Join(caseDir, "slots.yaml"))
+	if err != nil {
+		return err
+	}
+	var slots uint64
+	if err := yaml.Unmarshal(enc, &slots); err != nil {
+		return errors.Wrap(err, "could not unmarshal slots")
+	}
+	post, err := state.ProcessSlots(context.Background(), pre, pre.Slot()+slots)
+	if err != nil {
+		return errors.Wrap(err, "could not process slots")
+	}
+	return comparePostState(caseDir, post)
+}
+
+// runBlocksCase applies the blocks of the case to the pre state, and compares the result with
+// the post state. Cases without a post state expect the transition to fail.
+func runBlocksCase(caseDir string) error {
+	st, err := readState(filepath.Join(caseDir, "pre.ssz"))
+	if err != nil {
+		return err
+	}
+	enc, err := ioutil.ReadFile(filepath.Join(caseDir, "meta.yaml"))
+	if err != nil {
+		return err
+	}
+	meta := &blocksMeta{}
+	if err := yaml.Unmarshal(enc, meta); err != nil {
+		return errors.Wrap(err, "could not unmarshal meta")
+	}
+	var transitionErr error
+	for i := 0; i < meta.BlocksCount; i++ {
+		enc, err := ioutil.ReadFile(filepath.Join(caseDir, fmt.Sprintf("blocks_%d.ssz", i)))
+		if err != nil {
+			return err
+		}
+		blk := &ethpb.SignedBeaconBlock{}
+		if err := ssz.Unmarshal(enc, blk); err != nil {
+			return errors.Wrapf(err, "could not unmarshal block %d", i)
+		}
+		st, transitionErr = state.ExecuteStateTransition(context.Background(), st, blk)
+		if transitionErr != nil {
+			break
+		}
+	}
+	if _, err := os.Stat(filepath.Join(caseDir, "post.ssz")); os.IsNotExist(err) {
+		if transitionErr == nil {
+			return errors.New("state transition did not fail when expected")
+		}
+		return nil
+	}
+	if transitionErr != nil {
+		return errors.Wrap(transitionErr, "could not execute state transition")
+	}
+	return comparePostState(caseDir, st)
+}
+
+// runSSZStaticCase decodes the serialized object of the case, and compares its roots with the
+// expected roots. The type of the object is the name of the directory containing the suite.
+func runSSZStaticCase(caseDir string) error {
+	typeName := filepath.Base(filepath.Dir(filepath.Dir(caseDir)))
+	obj, err := sszObject(typeName)
+	if err != nil {
+		return err
+	}
+	enc, err := ioutil.ReadFile(filepath.Join(caseDir, "serialized.ssz"))
+	if err != nil {
+		return err
+	}
+	if err := ssz.Unmarshal(enc, obj); err != nil {
+		return errors.Wrap(err, "could not unmarshal serialized object")
+	}
+	rootsEnc, err := ioutil.ReadFile(filepath.Join(caseDir, "roots.yaml"))
+	if err != nil {
+		return err
+	}
+	roots := &sszRoots{}
+	if err := yaml.Unmarshal(rootsEnc, roots); err != nil {
+		return errors.Wrap(err, "could not unmarshal roots")
+	}
+
+	var root [32]byte
+	if st, ok := obj.(*pb.BeaconState); ok {
+		root, err = stateutil.HashTreeRootState(st)
+	} else {
+		root, err = ssz.HashTreeRoot(obj)
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not compute hash tree root")
+	}
+	if err := compareRoot("hash tree root", root, roots.Root); err != nil {
+		return err
+	}
+	if roots.SigningRoot == "" {
+		return nil
+	}
+	signingRoot, err := ssz.HashTreeRoot(obj)
+	if err != nil {
+		return errors.Wrap(err, "could not compute signing root")
+	}
+	return compareRoot("signing root", signingRoot, roots.SigningRoot)
+}
+
+func readState(path string) (*beaconstate.BeaconState, error) {
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	base := &pb.BeaconState{}
+	if err := ssz.Unmarshal(enc, base); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal %s", filepath.Base(path))
+	}
+	return beaconstate.InitializeFromProto(base)
+}
+
+func comparePostState(caseDir string, st *beaconstate.BeaconState) error {
+	enc, err := ioutil.ReadFile(filepath.Join(caseDir, "post.ssz"))
+	if err != nil {
+		return err
+	}
+	post := &pb.BeaconState{}
+	if err := ssz.Unmarshal(enc, post); err != nil {
+		return errors.Wrap(err, "could not unmarshal post state")
+	}
+	if !proto.Equal(st.InnerStateUnsafe(), post) {
+		return errors.New("post state does not match expected")
+	}
+	return nil
+}
+
+func compareRoot(name string, root [32]byte, expected string) error {
+	expectedRoot, err := hex.DecodeString(strings.TrimPrefix(expected, "0x"))
+	if err != nil {
+		return errors.Wrapf(err, "could not decode expected %s", name)
+	}
+	if !bytes.Equal(root[:], expectedRoot) {
+		return errors.Errorf("did not receive expected %s, received: %#x, expected: %#x", name, root, expectedRoot)
+	}
+	return nil
+}
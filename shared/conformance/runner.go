@@ -0,0 +1,133 @@
+// Package conformance runs the official eth2 spec test vectors against the state transition
+// and ssz encoding of the beacon chain, outside of the bazel test environment.
+package conformance
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/params/spectest"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "conformance")
+
+// handler runs the test vectors of one spec test handler. Cases are the directories found
+// depth levels below the handler directory.
+type handler struct {
+	name  string
+	depth int
+	run   func(caseDir string) error
+}
+
+var handlers = []*handler{
+	{name: "sanity/slots", depth: 2, run: runSlotsCase},
+	{name: "sanity/blocks", depth: 2, run: runBlocksCase},
+	{name: "finality/finality", depth: 2, run: runBlocksCase},
+	{name: "ssz_static", depth: 3, run: runSSZStaticCase},
+}
+
+// Handlers returns the names of the spec test handlers supported by the runner.
+func Handlers() []string {
+	names := make([]string, len(handlers))
+	for i, h := range handlers {
+		names[i] = h.name
+	}
+	return names
+}
+
+// Result of running a single spec test case. Skipped cases test spec types which are not used
+// by the beacon chain.
+type Result struct {
+	Handler string
+	Case    string
+	Skipped bool
+	Err     error
+}
+
+// Runner runs the spec test vectors found in a directory, laid out as in the spec tests
+// releases, against a configuration preset.
+type Runner struct {
+	dir    string
+	preset string
+}
+
+// NewRunner creates a runner for the test vectors of the preset in the directory.
+func NewRunner(dir string, preset string) (*Runner, error) {
+	if err := spectest.SetConfig(preset); err != nil {
+		return nil, err
+	}
+	return &Runner{
+		dir:    dir,
+		preset: preset,
+	}, nil
+}
+
+// Run the test cases of every supported handler whose name starts with the filter, returning
+// the result of every case in handler and case order. An empty filter runs every handler.
+func (r *Runner) Run(filter string) ([]*Result, error) {
+	state.SkipSlotCache.Disable()
+	defer state.SkipSlotCache.Enable()
+
+	var results []*Result
+	for _, h := range handlers {
+		if !strings.HasPrefix(h.name, filter) {
+			continue
+		}
+		handlerDir := filepath.Join(r.dir, "tests", r.preset, "phase0", h.name)
+		if _, err := os.Stat(handlerDir); os.IsNotExist(err) {
+			log.WithField("handler", h.name).Warn("No test vectors found for handler")
+			continue
+		}
+		cases, err := caseDirs(handlerDir, h.depth)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not list test cases of %s", h.name)
+		}
+		for _, caseDir := range cases {
+			name, err := filepath.Rel(handlerDir, caseDir)
+			if err != nil {
+				return nil, err
+			}
+			helpers.ClearCache()
+			res := &Result{
+				Handler: h.name,
+				Case:    name,
+				Err:     h.run(caseDir),
+			}
+			if res.Err == errUnusedType {
+				res.Skipped = true
+				res.Err = nil
+			}
+			results = append(results, res)
+		}
+	}
+	return results, nil
+}
+
+// caseDirs returns the directories found depth levels below the directory, sorted by path.
+func caseDirs(dir string, depth int) ([]string, error) {
+	dirs := []string{dir}
+	for i := 0; i < depth; i++ {
+		var next []string
+		for _, d := range dirs {
+			entries, err := ioutil.ReadDir(d)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range entries {
+				if entry.IsDir() {
+					next = append(next, filepath.Join(d, entry.Name()))
+				}
+			}
+		}
+		dirs = next
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
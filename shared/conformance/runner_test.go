@@ -0,0 +1,128 @@
+package conformance
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func writeSSZStaticCase(t *testing.T, dir string, typeName string, obj interface{}, root []byte) {
+	caseDir := filepath.Join(dir, "tests", "minimal", "phase0", "ssz_static", typeName, "ssz_random", "case_0")
+	if err := os.MkdirAll(caseDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := ssz.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(caseDir, "serialized.ssz"), enc, 0600); err != nil {
+		t.Fatal(err)
+	}
+	roots := []byte(fmt.Sprintf("{root: '%#x'}\n", root))
+	if err := ioutil.WriteFile(filepath.Join(caseDir, "roots.yaml"), roots, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunner_SSZStatic(t *testing.T) {
+	defer params.OverrideBeaconConfig(params.MainnetConfig())
+	dir, err := ioutil.TempDir(testutil.TempDir(), "conformance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	checkpoint := &ethpb.Checkpoint{Epoch: 5, Root: bytes.Repeat([]byte{'a'}, 32)}
+	root, err := ssz.HashTreeRoot(checkpoint)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeSSZStaticCase(t, dir, "Checkpoint", checkpoint, root[:])
+	writeSSZStaticCase(t, dir, "Fork", checkpoint, root[:])
+	writeSSZStaticCase(t, dir, "Eth1Block", checkpoint, root[:])
+
+	runner, err := NewRunner(dir, "minimal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := runner.Run("ssz_static")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, received %d", len(results))
+	}
+	if results[0].Case != filepath.Join("Checkpoint", "ssz_random", "case_0") || results[0].Err != nil {
+		t.Errorf("Expected checkpoint case to pass, received %v", results[0].Err)
+	}
+	if !results[1].Skipped {
+		t.Error("Expected unused type to be skipped")
+	}
+	if results[2].Err == nil {
+		t.Error("Expected fork case with a mismatched object to fail")
+	}
+}
+
+func TestRunner_UnknownPreset(t *testing.T) {
+	if _, err := NewRunner("", "unknown"); err == nil {
+		t.Error("Expected error for unknown preset")
+	}
+}
+
+func TestExtractTarGz(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	content := []byte("1\n")
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     "tests/minimal/phase0/sanity/slots/pyspec_tests/slots_1/slots.yaml",
+		Typeflag: tar.TypeReg,
+		Mode:     0600,
+		Size:     int64(len(content)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir(testutil.TempDir(), "conformance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := extractTarGz(bytes.NewReader(buf.Bytes()), dir); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(dir, "tests/minimal/phase0/sanity/slots/pyspec_tests/slots_1/slots.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("Expected %q, received %q", content, got)
+	}
+}
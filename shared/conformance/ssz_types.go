@@ -0,0 +1,71 @@
+package conformance
+
+import (
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+var errUnusedType = errors.New("spec type is not used by the beacon chain")
+
+// sszObject returns an empty object of the spec type with the given name, to decode the
+// serialized objects of the ssz static tests into. Spec types which are not used by the
+// beacon chain return errUnusedType.
+func sszObject(typeName string) (interface{}, error) {
+	switch typeName {
+	case "Attestation":
+		return &ethpb.Attestation{}, nil
+	case "AttestationData":
+		return &ethpb.AttestationData{}, nil
+	case "AttesterSlashing":
+		return &ethpb.AttesterSlashing{}, nil
+	case "AggregateAndProof":
+		return &ethpb.AggregateAttestationAndProof{}, nil
+	case "BeaconBlock":
+		return &ethpb.BeaconBlock{}, nil
+	case "BeaconBlockBody":
+		return &ethpb.BeaconBlockBody{}, nil
+	case "BeaconBlockHeader":
+		return &ethpb.BeaconBlockHeader{}, nil
+	case "BeaconState":
+		return &pb.BeaconState{}, nil
+	case "Checkpoint":
+		return &ethpb.Checkpoint{}, nil
+	case "Deposit":
+		return &ethpb.Deposit{}, nil
+	case "DepositData":
+		return &ethpb.Deposit_Data{}, nil
+	case "DepositMessage", "Eth1Block":
+		return nil, errUnusedType
+	case "Eth1Data":
+		return &ethpb.Eth1Data{}, nil
+	case "Fork":
+		return &pb.Fork{}, nil
+	case "ForkData":
+		return &pb.ForkData{}, nil
+	case "HistoricalBatch":
+		return &pb.HistoricalBatch{}, nil
+	case "IndexedAttestation":
+		return &ethpb.IndexedAttestation{}, nil
+	case "PendingAttestation":
+		return &pb.PendingAttestation{}, nil
+	case "ProposerSlashing":
+		return &ethpb.ProposerSlashing{}, nil
+	case "SignedAggregateAndProof":
+		return &pb.SignedAggregateAndProof{}, nil
+	case "SignedBeaconBlock":
+		return &ethpb.SignedBeaconBlock{}, nil
+	case "SignedBeaconBlockHeader":
+		return &ethpb.SignedBeaconBlockHeader{}, nil
+	case "SignedVoluntaryExit":
+		return &ethpb.SignedVoluntaryExit{}, nil
+	case "SigningRoot":
+		return &pb.SigningRoot{}, nil
+	case "Validator":
+		return &ethpb.Validator{}, nil
+	case "VoluntaryExit":
+		return &ethpb.VoluntaryExit{}, nil
+	default:
+		return nil, errors.Errorf("unsupported ssz type %s", typeName)
+	}
+}
@@ -78,6 +78,17 @@ var (
 		Name:  "trace",
 		Usage: "Write execution trace to the given file",
 	}
+	// GCPercentFlag is the percentage of current live allocations at which the garbage collector is to run.
+	GCPercentFlag = &cli.IntFlag{
+		Name:  "gc-percent",
+		Usage: "The percentage of freshly allocated data to live data on which the gc will be run again.",
+		Value: 100,
+	}
+	// GCBallastFlag to allocate an unused heap region which delays garbage collection of small heaps.
+	GCBallastFlag = &cli.Uint64Flag{
+		Name:  "gc-ballast",
+		Usage: "Size in megabytes of an unused allocation reducing how often the gc runs while the heap is small",
+	}
 )
 
 // HandlerT implements the debugging API.
@@ -336,6 +347,8 @@ func MigrateFlags(action func(ctx *cli.Context) error) func(*cli.Context) error
 // Setup initializes profiling based on the CLI flags.
 // It should be called as early as possible in the program.
 func Setup(ctx *cli.Context) error {
+	setupRuntime(ctx)
+
 	// profiling, tracing
 	runtime.MemProfileRate = ctx.Int(MemProfileRateFlag.Name)
 	if traceFile := ctx.String(TraceFlag.Name); traceFile != "" {
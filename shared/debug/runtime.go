@@ -0,0 +1,30 @@
+package debug
+
+import (
+	"runtime/debug"
+
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/automaxprocs/maxprocs"
+	"gopkg.in/urfave/cli.v2"
+)
+
+// gcBallast is a large allocation which is never read or written. It raises the heap
+// size at which the garbage collector is triggered, so a node with a small live heap
+// is not collected over and over again.
+var gcBallast []byte
+
+// setupRuntime tunes the Go runtime for the machine or container the process runs in.
+// GOMAXPROCS is matched to the cgroup CPU quota instead of the number of host CPUs,
+// which would otherwise make a CPU limited container thrash the scheduler.
+func setupRuntime(ctx *cli.Context) {
+	if _, err := maxprocs.Set(maxprocs.Logger(log.Infof)); err != nil {
+		log.WithError(err).Error("Could not set GOMAXPROCS from CPU quota")
+	}
+	if ctx.IsSet(GCPercentFlag.Name) {
+		debug.SetGCPercent(ctx.Int(GCPercentFlag.Name))
+	}
+	if size := ctx.Uint64(GCBallastFlag.Name); size > 0 {
+		gcBallast = make([]byte, size<<20)
+		log.WithField("sizeMB", size).Info("Allocated gc ballast")
+	}
+}
@@ -16,10 +16,18 @@ The process for implementing new features using this package is as follows:
 	defer resetCfg()
 	6. Add the string for the flags that should be running within E2E to E2EValidatorFlags
 	and E2EBeaconChainFlags.
+
+Feature flags may also be set in a features section of the file given by --config-file,
+keyed by flag name. A flag set on the command line takes precedence over the config file,
+which takes precedence over the flag default:
+
+	features:
+	  enable-state-ref-copy: true
 */
 package featureconfig
 
 import (
+	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/urfave/cli.v2"
@@ -54,6 +62,7 @@ type Flags struct {
 	NoInitSyncBatchSaveBlocks                  bool // NoInitSyncBatchSaveBlocks disables batch save blocks mode during initial syncing.
 	EnableStateRefCopy                         bool // EnableStateRefCopy copies the references to objects instead of the objects themselves when copying state fields.
 	WaitForSynced                              bool // WaitForSynced uses WaitForSynced in validator startup to ensure it can communicate with the beacon node as soon as possible.
+	EnablePebbleDB                             bool // EnablePebbleDB stores the beacon chain database in Pebble instead of BoltDB.
 	// DisableForkChoice disables using LMD-GHOST fork choice to update
 	// the head of the chain based on attestations and instead accepts any valid received block
 	// as the chain head. UNSAFE, use with caution.
@@ -124,6 +133,7 @@ func (c *Flags) Copy() *Flags {
 		NoInitSyncBatchSaveBlocks:                  c.NoInitSyncBatchSaveBlocks,
 		EnableStateRefCopy:                         c.EnableStateRefCopy,
 		WaitForSynced:                              c.WaitForSynced,
+		EnablePebbleDB:                             c.EnablePebbleDB,
 		DisableForkChoice:                          c.DisableForkChoice,
 		BroadcastSlashings:                         c.BroadcastSlashings,
 		EnableSSZCache:                             c.EnableSSZCache,
@@ -138,11 +148,14 @@ func (c *Flags) Copy() *Flags {
 // ConfigureBeaconChain sets the global config based
 // on what flags are enabled for the beacon-chain client.
 func ConfigureBeaconChain(ctx *cli.Context) {
+	if err := applyConfigFileFeatures(ctx, BeaconChainFlags); err != nil {
+		log.WithError(err).Fatal("Could not load feature flags from config file")
+	}
 	complainOnDeprecatedFlags(ctx)
 	cfg := &Flags{}
 	cfg = configureConfig(ctx, cfg)
-	if ctx.Bool(devModeFlag.Name) {
-		enableDevModeFlags(ctx)
+	if err := applyPresets(ctx, beaconChainPresets); err != nil {
+		log.WithError(err).Fatal("Could not apply feature flag presets")
 	}
 	delay := params.BeaconConfig().MinGenesisDelay
 	if ctx.IsSet(customGenesisDelayFlag.Name) {
@@ -253,18 +266,29 @@ func ConfigureBeaconChain(ctx *cli.Context) {
 		log.Warn("Enabling broadcast slashing to p2p network")
 		cfg.BroadcastSlashings = true
 	}
+	if ctx.Bool(enablePebbleDBFlag.Name) {
+		log.Warn("Enabling experimental Pebble database")
+		cfg.EnablePebbleDB = true
+	}
+	logEffectiveFlags(cfg)
 	Init(cfg)
 }
 
 // ConfigureSlasher sets the global config based
 // on what flags are enabled for the slasher client.
 func ConfigureSlasher(ctx *cli.Context) {
+	if err := applyConfigFileFeatures(ctx, SlasherFlags); err != nil {
+		log.WithError(err).Fatal("Could not load feature flags from config file")
+	}
 	complainOnDeprecatedFlags(ctx)
 }
 
 // ConfigureValidator sets the global config based
 // on what flags are enabled for the validator client.
 func ConfigureValidator(ctx *cli.Context) {
+	if err := applyConfigFileFeatures(ctx, ValidatorFlags); err != nil {
+		log.WithError(err).Fatal("Could not load feature flags from config file")
+	}
 	complainOnDeprecatedFlags(ctx)
 	cfg := &Flags{}
 	cfg = configureConfig(ctx, cfg)
@@ -282,21 +306,10 @@ func ConfigureValidator(ctx *cli.Context) {
 		log.Warn("Enabled domain data cache.")
 		cfg.EnableDomainDataCache = true
 	}
+	logEffectiveFlags(cfg)
 	Init(cfg)
 }
 
-// enableDevModeFlags switches development mode features on.
-func enableDevModeFlags(ctx *cli.Context) {
-	log.Warn("Enabling development mode flags")
-	for _, f := range devModeFlags {
-		if !ctx.IsSet(f.Names()[0]) {
-			if err := ctx.Set(f.Names()[0], "true"); err != nil {
-				log.WithError(err).Debug("Error enabling development mode flag")
-			}
-		}
-	}
-}
-
 func complainOnDeprecatedFlags(ctx *cli.Context) {
 	for _, f := range deprecatedFlags {
 		if ctx.IsSet(f.Names()[0]) {
@@ -307,11 +320,28 @@ func complainOnDeprecatedFlags(ctx *cli.Context) {
 
 func configureConfig(ctx *cli.Context, cfg *Flags) *Flags {
 	if ctx.Bool(minimalConfigFlag.Name) {
+		if ctx.IsSet(cmd.NetworkFlag.Name) {
+			log.Fatalf("--%s cannot be used together with --%s", minimalConfigFlag.Name, cmd.NetworkFlag.Name)
+		}
 		log.Warn("Using minimal config")
 		cfg.MinimalConfig = true
 		params.UseMinimalConfig()
+	} else if ctx.IsSet(cmd.NetworkFlag.Name) {
+		network, err := params.NetworkByName(ctx.String(cmd.NetworkFlag.Name))
+		if err != nil {
+			log.WithError(err).Fatal("Could not select network")
+		}
+		params.UseNetwork(network)
+		log.WithField("network", network.Name).Warn("Using network config")
 	} else {
 		log.Warn("Using default mainnet config")
 	}
+	if ctx.IsSet(cmd.ChainConfigFileFlag.Name) {
+		chainConfigFileName := ctx.String(cmd.ChainConfigFileFlag.Name)
+		if err := params.LoadChainConfigFile(chainConfigFileName); err != nil {
+			log.WithError(err).Fatal("Could not load chain config file")
+		}
+		log.WithField("path", chainConfigFileName).Warn("Using custom chain config")
+	}
 	return cfg
 }
@@ -0,0 +1,69 @@
+package featureconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/urfave/cli.v2"
+	"gopkg.in/yaml.v2"
+)
+
+// configFileFeatures is the section of the config file given by --config-file
+// which holds feature flag values, keyed by flag name.
+type configFileFeatures struct {
+	Features map[string]interface{} `yaml:"features"`
+}
+
+// applyConfigFileFeatures sets the feature flags listed in the features section of the
+// config file. Flags set on the command line take precedence over the config file, which
+// in turn takes precedence over the flag defaults.
+func applyConfigFileFeatures(ctx *cli.Context, flags []cli.Flag) error {
+	if !ctx.IsSet(cmd.ConfigFileFlag.Name) {
+		return nil
+	}
+	path := ctx.String(cmd.ConfigFileFlag.Name)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "could not read config file")
+	}
+	cfgFile := &configFileFeatures{}
+	if err := yaml.Unmarshal(data, cfgFile); err != nil {
+		return errors.Wrap(err, "could not parse config file")
+	}
+	known := make(map[string]bool, len(flags))
+	for _, f := range flags {
+		for _, name := range f.Names() {
+			known[name] = true
+		}
+	}
+	for name, value := range cfgFile.Features {
+		if !known[name] {
+			return fmt.Errorf("unknown feature flag %q in config file %s", name, path)
+		}
+		if ctx.IsSet(name) {
+			log.WithField("flag", name).Debug("Feature flag set on the command line, ignoring config file value")
+			continue
+		}
+		if err := ctx.Set(name, fmt.Sprint(value)); err != nil {
+			return errors.Wrapf(err, "could not set feature flag %q from config file", name)
+		}
+	}
+	return nil
+}
+
+// logEffectiveFlags logs the features which are enabled once all sources of
+// feature flags were applied.
+func logEffectiveFlags(cfg *Flags) {
+	fields := logrus.Fields{}
+	v := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if field := v.Field(i); !field.IsZero() {
+			fields[v.Type().Field(i).Name] = field.Interface()
+		}
+	}
+	log.WithFields(fields).Info("Effective feature flags")
+}
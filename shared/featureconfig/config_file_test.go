@@ -0,0 +1,80 @@
+package featureconfig
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func TestApplyConfigFileFeatures_Precedence(t *testing.T) {
+	dir, err := ioutil.TempDir("", "featureconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	path := filepath.Join(dir, "config.yaml")
+	content := []byte("features:\n  enable-state-ref-copy: true\n  enable-field-trie: true\n")
+	if err := ioutil.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.ConfigFileFlag.Name, "", "")
+	set.Bool(enableStateRefCopy.Name, false, "")
+	set.Bool(enableFieldTrie.Name, false, "")
+	set.Bool(checkHeadState.Name, false, "")
+	// The command line value takes precedence over the config file.
+	if err := set.Parse([]string{"--" + cmd.ConfigFileFlag.Name, path, "--" + enableFieldTrie.Name + "=false"}); err != nil {
+		t.Fatal(err)
+	}
+	context := cli.NewContext(&app, set, nil)
+	if err := applyConfigFileFeatures(context, BeaconChainFlags); err != nil {
+		t.Fatal(err)
+	}
+	if !context.Bool(enableStateRefCopy.Name) {
+		t.Error("Expected feature flag from config file to be enabled")
+	}
+	if context.Bool(enableFieldTrie.Name) {
+		t.Error("Expected command line value to take precedence over config file")
+	}
+	if context.Bool(checkHeadState.Name) {
+		t.Error("Expected feature flag missing from config file to keep its default")
+	}
+}
+
+func TestApplyConfigFileFeatures_UnknownFlag(t *testing.T) {
+	dir, err := ioutil.TempDir("", "featureconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	path := filepath.Join(dir, "config.yaml")
+	if err := ioutil.WriteFile(path, []byte("features:\n  not-a-feature: true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String(cmd.ConfigFileFlag.Name, "", "")
+	if err := set.Parse([]string{"--" + cmd.ConfigFileFlag.Name, path}); err != nil {
+		t.Fatal(err)
+	}
+	context := cli.NewContext(&app, set, nil)
+	if err := applyConfigFileFeatures(context, ValidatorFlags); err == nil {
+		t.Error("Expected error for unknown feature flag")
+	}
+}
@@ -6,8 +6,15 @@ import (
 
 var (
 	devModeFlag = &cli.BoolFlag{
-		Name:  "dev",
-		Usage: "Enable experimental features still in development. These features may not be stable.",
+		Name: "dev",
+		Usage: "Enable experimental features still in development. These features may not be stable. " +
+			"Enables --enable-byte-mempool, --enable-state-ref-copy and --enable-state-field-trie.",
+	}
+	conservativeModeFlag = &cli.BoolFlag{
+		Name: "conservative",
+		Usage: "Run with the most conservative feature set, disabling unsafe and experimental features. " +
+			"Enables --initial-sync-verify-all-signatures and requires that no unsafe or experimental " +
+			"feature flag is set.",
 	}
 	broadcastSlashingFlag = &cli.BoolFlag{
 		Name:  "broadcast-slashing",
@@ -150,15 +157,13 @@ var (
 		Name:  "wait-for-synced",
 		Usage: "Uses WaitForSynced for validator startup, to ensure a validator is able to communicate with the beacon node as quick as possible",
 	}
+	enablePebbleDBFlag = &cli.BoolFlag{
+		Name: "enable-pebble-db",
+		Usage: "Stores the beacon chain database in Pebble, an LSM-tree key-value store, instead of BoltDB. " +
+			"Existing BoltDB databases are not converted",
+	}
 )
 
-// devModeFlags holds list of flags that are set when development mode is on.
-var devModeFlags = []cli.Flag{
-	enableByteMempool,
-	enableStateRefCopy,
-	enableFieldTrie,
-}
-
 // Deprecated flags list.
 const deprecatedUsage = "DEPRECATED. DO NOT USE."
 
@@ -370,6 +375,7 @@ var E2EValidatorFlags = []string{
 // BeaconChainFlags contains a list of all the feature flags that apply to the beacon-chain client.
 var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	devModeFlag,
+	conservativeModeFlag,
 	customGenesisDelayFlag,
 	minimalConfigFlag,
 	writeSSZStateTransitionsFlag,
@@ -398,6 +404,7 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	disableInitSyncBatchSaveBlocks,
 	enableStateRefCopy,
 	waitForSyncedFlag,
+	enablePebbleDBFlag,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
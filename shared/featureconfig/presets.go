@@ -0,0 +1,82 @@
+package featureconfig
+
+import (
+	"fmt"
+	"strconv"
+
+	"gopkg.in/urfave/cli.v2"
+)
+
+// preset is a documented combination of feature flags which is enabled by a single flag.
+type preset struct {
+	flag     *cli.BoolFlag
+	settings []presetSetting
+}
+
+// presetSetting is the value a preset assigns to a feature flag.
+type presetSetting struct {
+	flag  *cli.BoolFlag
+	value bool
+}
+
+var (
+	// devModePreset switches on experimental features still in development.
+	devModePreset = &preset{
+		flag: devModeFlag,
+		settings: []presetSetting{
+			{flag: enableByteMempool, value: true},
+			{flag: enableStateRefCopy, value: true},
+			{flag: enableFieldTrie, value: true},
+		},
+	}
+	// conservativeModePreset verifies everything and switches off unsafe and
+	// experimental features.
+	conservativeModePreset = &preset{
+		flag: conservativeModeFlag,
+		settings: []presetSetting{
+			{flag: initSyncVerifyEverythingFlag, value: true},
+			{flag: skipBLSVerifyFlag, value: false},
+			{flag: disableForkChoiceUnsafeFlag, value: false},
+			{flag: enableEth1DataVoteCacheFlag, value: false},
+			{flag: disableStrictAttestationPubsubVerificationFlag, value: false},
+			{flag: enableByteMempool, value: false},
+			{flag: enableStateRefCopy, value: false},
+			{flag: enableFieldTrie, value: false},
+		},
+	}
+)
+
+// beaconChainPresets contains the presets which apply to the beacon-chain client.
+var beaconChainPresets = []*preset{
+	devModePreset,
+	conservativeModePreset,
+}
+
+// applyPresets sets the feature flags of every enabled preset. It returns an error if a
+// feature flag set individually, or by another preset, contradicts an enabled preset.
+func applyPresets(ctx *cli.Context, presets []*preset) error {
+	setBy := make(map[string]string)
+	for _, p := range presets {
+		if !ctx.Bool(p.flag.Name) {
+			continue
+		}
+		log.WithField("preset", p.flag.Name).Warn("Enabling feature flag preset")
+		for _, s := range p.settings {
+			name := s.flag.Name
+			if ctx.IsSet(name) {
+				if ctx.Bool(name) == s.value {
+					continue
+				}
+				if other, ok := setBy[name]; ok {
+					return fmt.Errorf("--%s and --%s presets conflict on --%s", other, p.flag.Name, name)
+				}
+				return fmt.Errorf("--%s=%t conflicts with the --%s preset", name, ctx.Bool(name), p.flag.Name)
+			}
+			if err := ctx.Set(name, strconv.FormatBool(s.value)); err != nil {
+				return err
+			}
+			setBy[name] = p.flag.Name
+		}
+	}
+	return nil
+}
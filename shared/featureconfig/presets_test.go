@@ -0,0 +1,69 @@
+package featureconfig
+
+import (
+	"flag"
+	"testing"
+
+	"gopkg.in/urfave/cli.v2"
+)
+
+func presetTestContext(t *testing.T, args []string) *cli.Context {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	for _, f := range BeaconChainFlags {
+		if bf, ok := f.(*cli.BoolFlag); ok {
+			set.Bool(bf.Name, false, "")
+		}
+	}
+	if err := set.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cli.NewContext(&app, set, nil)
+}
+
+func TestApplyPresets_DevMode(t *testing.T) {
+	ctx := presetTestContext(t, []string{"--" + devModeFlag.Name})
+	if err := applyPresets(ctx, beaconChainPresets); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range devModePreset.settings {
+		if ctx.Bool(s.flag.Name) != s.value {
+			t.Errorf("Expected --%s=%t", s.flag.Name, s.value)
+		}
+	}
+}
+
+func TestApplyPresets_Conflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "individual flag contradicts preset",
+			args: []string{"--" + devModeFlag.Name, "--" + enableFieldTrie.Name + "=false"},
+		},
+		{
+			name: "unsafe flag with conservative preset",
+			args: []string{"--" + conservativeModeFlag.Name, "--" + skipBLSVerifyFlag.Name},
+		},
+		{
+			name: "contradicting presets",
+			args: []string{"--" + devModeFlag.Name, "--" + conservativeModeFlag.Name},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := presetTestContext(t, tt.args)
+			if err := applyPresets(ctx, beaconChainPresets); err == nil {
+				t.Error("Expected conflict error")
+			}
+		})
+	}
+}
+
+func TestApplyPresets_ConsistentFlagAllowed(t *testing.T) {
+	ctx := presetTestContext(t, []string{"--" + devModeFlag.Name, "--" + enableFieldTrie.Name})
+	if err := applyPresets(ctx, beaconChainPresets); err != nil {
+		t.Fatal(err)
+	}
+}
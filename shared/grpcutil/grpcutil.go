@@ -0,0 +1,154 @@
+// Package grpcutil defines gRPC client interceptors shared by the validator
+// and slasher clients. They retry failed requests with exponential backoff,
+// bound the deadline of requests which have none and tag every outgoing
+// request with a request id, so it can be traced in the beacon node logs.
+package grpcutil
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the metadata key holding the id of an outgoing request.
+const RequestIDKey = "x-request-id"
+
+// retryableCodes are the status codes of transient failures for which
+// a request is retried.
+var retryableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.ResourceExhausted,
+	codes.Aborted,
+}
+
+// Config defines how failed requests are retried and how long requests may take.
+type Config struct {
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries uint
+	// BackoffBase is the wait before the first retry, it doubles on every further retry.
+	BackoffBase time.Duration
+	// MaxBackoff caps the wait between two retries.
+	MaxBackoff time.Duration
+	// RequestTimeout bounds unary requests whose context has no deadline, zero disables it.
+	RequestTimeout time.Duration
+}
+
+// DefaultConfig returns the configuration used by clients which do not
+// specify their own.
+func DefaultConfig() *Config {
+	return &Config{
+		MaxRetries:  5,
+		BackoffBase: 100 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+	}
+}
+
+// UnaryClientInterceptor returns a chain of the request id, deadline and retry
+// interceptors for unary requests. Retries happen within the request deadline.
+func UnaryClientInterceptor(cfg *Config) grpc.UnaryClientInterceptor {
+	return middleware.ChainUnaryClient(
+		RequestIDUnaryClientInterceptor(),
+		DeadlineUnaryClientInterceptor(cfg.RequestTimeout),
+		grpc_retry.UnaryClientInterceptor(RetryCallOptions(cfg)...),
+	)
+}
+
+// StreamClientInterceptor returns a chain of the request id and retry interceptors
+// for streams. Streams are long lived, so no deadline is applied to them.
+func StreamClientInterceptor(cfg *Config) grpc.StreamClientInterceptor {
+	return middleware.ChainStreamClient(
+		RequestIDStreamClientInterceptor(),
+		grpc_retry.StreamClientInterceptor(RetryCallOptions(cfg)...),
+	)
+}
+
+// RetryCallOptions returns the retry options for the given configuration.
+func RetryCallOptions(cfg *Config) []grpc.CallOption {
+	return []grpc.CallOption{
+		grpc_retry.WithMax(cfg.MaxRetries),
+		grpc_retry.WithBackoff(ExponentialBackoff(cfg.BackoffBase, cfg.MaxBackoff)),
+		grpc_retry.WithCodes(retryableCodes...),
+	}
+}
+
+// ExponentialBackoff returns a backoff function waiting base before the first
+// retry and doubling the wait on every further attempt, up to max.
+func ExponentialBackoff(base time.Duration, max time.Duration) grpc_retry.BackoffFunc {
+	return func(attempt uint) time.Duration {
+		backoff := base
+		for i := uint(1); i < attempt; i++ {
+			backoff *= 2
+			if max > 0 && backoff >= max {
+				return max
+			}
+		}
+		if max > 0 && backoff > max {
+			return max
+		}
+		return backoff
+	}
+}
+
+// IsRetryable returns true if the error is a transient gRPC failure after which
+// the request, or a broken stream, can be attempted again.
+func IsRetryable(err error) bool {
+	code := status.Code(err)
+	for _, c := range retryableCodes {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+// DeadlineUnaryClientInterceptor bounds requests whose context has no deadline
+// with the given timeout. Existing deadlines are propagated as they are.
+func DeadlineUnaryClientInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); ok || timeout == 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// RequestIDUnaryClientInterceptor adds a request id to the metadata of unary
+// requests which do not carry one yet.
+func RequestIDUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// RequestIDStreamClientInterceptor adds a request id to the metadata of streams
+// which do not carry one yet.
+func RequestIDStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(RequestIDKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, RequestIDKey, newRequestID())
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
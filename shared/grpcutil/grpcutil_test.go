@@ -0,0 +1,100 @@
+package grpcutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+	wanted := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	}
+	for i, want := range wanted {
+		if got := backoff(uint(i + 1)); got != want {
+			t.Errorf("Wrong backoff for attempt %d, wanted %v, received %v", i+1, want, got)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: status.Error(codes.Unavailable, "unavailable"), want: true},
+		{err: status.Error(codes.ResourceExhausted, "exhausted"), want: true},
+		{err: status.Error(codes.InvalidArgument, "bad request"), want: false},
+		{err: errors.New("not a status"), want: false},
+		{err: nil, want: false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %v, wanted %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRequestIDUnaryClientInterceptor(t *testing.T) {
+	var ids []string
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		ids = md.Get(RequestIDKey)
+		return nil
+	}
+	interceptor := RequestIDUnaryClientInterceptor()
+
+	if err := interceptor(context.Background(), "method", nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] == "" {
+		t.Fatalf("Expected a single request id, received %v", ids)
+	}
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "existing")
+	if err := interceptor(ctx, "method", nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "existing" {
+		t.Errorf("Expected existing request id to be kept, received %v", ids)
+	}
+}
+
+func TestDeadlineUnaryClientInterceptor(t *testing.T) {
+	var deadline time.Time
+	var hasDeadline bool
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		deadline, hasDeadline = ctx.Deadline()
+		return nil
+	}
+	interceptor := DeadlineUnaryClientInterceptor(time.Minute)
+
+	if err := interceptor(context.Background(), "method", nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	if !hasDeadline {
+		t.Fatal("Expected deadline to be set on request without deadline")
+	}
+
+	want := time.Now().Add(time.Second)
+	ctx, cancel := context.WithDeadline(context.Background(), want)
+	defer cancel()
+	if err := interceptor(ctx, "method", nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	if !deadline.Equal(want) {
+		t.Errorf("Expected existing deadline %v to be propagated, received %v", want, deadline)
+	}
+}
@@ -1,4 +1,4 @@
-package gateway
+package httputil
 
 import (
 	"net/http"
@@ -6,7 +6,7 @@ import (
 	"github.com/rs/cors"
 )
 
-func newCorsHandler(srv http.Handler, allowedOrigins []string) http.Handler {
+func corsHandler(srv http.Handler, allowedOrigins []string) http.Handler {
 	if len(allowedOrigins) == 0 {
 		return srv
 	}
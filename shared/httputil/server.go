@@ -0,0 +1,153 @@
+// Package httputil defines the HTTP server shared by the HTTP surfaces of Prysm, such as the
+// metrics and gRPC gateway endpoints, so that each is configured consistently with a listen
+// address, allowed cross origin domains, TLS and disabled endpoints.
+package httputil
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "http")
+
+// ServerConfig options of an HTTP server.
+type ServerConfig struct {
+	Host           string
+	Port           int
+	AllowedOrigins []string
+	CertFile       string
+	KeyFile        string
+	DisabledPaths  []string
+}
+
+// Addr returns the host:port address the server listens on.
+func (c *ServerConfig) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// TLSEnabled returns true if the server is configured to serve over TLS.
+func (c *ServerConfig) TLSEnabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// Server serves an HTTP handler according to its configuration, rejecting requests to disabled
+// paths and answering cross origin requests from the allowed domains.
+type Server struct {
+	name     string
+	cfg      *ServerConfig
+	server   *http.Server
+	listener net.Listener
+	lock     sync.RWMutex
+	failure  error
+}
+
+// NewServer creates a server for the handler. The name identifies the server in logs.
+func NewServer(name string, cfg *ServerConfig, handler http.Handler) *Server {
+	handler = disabledPathsHandler(handler, cfg.DisabledPaths)
+	handler = corsHandler(handler, cfg.AllowedOrigins)
+	return &Server{
+		name: name,
+		cfg:  cfg,
+		server: &http.Server{
+			Addr:    cfg.Addr(),
+			Handler: handler,
+		},
+	}
+}
+
+// Start listening on the configured address, serving requests in the background.
+func (s *Server) Start() {
+	lis, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		log.WithError(err).WithField("address", s.server.Addr).Errorf("Could not listen for %s", s.name)
+		s.setFailure(err)
+		return
+	}
+	s.lock.Lock()
+	s.listener = lis
+	s.lock.Unlock()
+
+	log.WithFields(logrus.Fields{
+		"address": lis.Addr().String(),
+		"tls":     s.cfg.TLSEnabled(),
+	}).Infof("Serving %s", s.name)
+	go func() {
+		var err error
+		if s.cfg.TLSEnabled() {
+			err = s.server.ServeTLS(lis, s.cfg.CertFile, s.cfg.KeyFile)
+		} else {
+			err = s.server.Serve(lis)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Errorf("Could not serve %s", s.name)
+			s.setFailure(err)
+		}
+	}()
+}
+
+// Stop the server gracefully, waiting a short time for the active requests to finish.
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+// Status returns the error which prevented the server from serving requests, if any.
+func (s *Server) Status() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.failure
+}
+
+// Addr returns the address the server is listening on, or the configured address if the
+// server is not listening.
+func (s *Server) Addr() string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if s.listener == nil {
+		return s.server.Addr
+	}
+	return s.listener.Addr().String()
+}
+
+func (s *Server) setFailure(err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.failure = err
+}
+
+// disabledPathsHandler responds with not found to requests for the disabled paths, or any path
+// below them.
+func disabledPathsHandler(h http.Handler, paths []string) http.Handler {
+	if len(paths) == 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, p := range paths {
+			p = strings.TrimSuffix(p, "/")
+			if r.URL.Path == p || strings.HasPrefix(r.URL.Path, p+"/") {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// SplitList splits a comma separated list, such as the value of a flag, dropping empty items.
+func SplitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,105 @@
+package httputil
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	s := NewServer("test server", &ServerConfig{Host: "127.0.0.1", Port: 0}, okHandler())
+	s.Start()
+	if err := s.Status(); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.Get(fmt.Sprintf("http://%s/", s.Addr()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resp.Body.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Wanted status %d, received %d", http.StatusOK, resp.StatusCode)
+	}
+	if err := s.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := http.Get(fmt.Sprintf("http://%s/", s.Addr())); err == nil {
+		t.Error("Server still running after Stop()")
+	}
+}
+
+func TestServer_AddressInUse(t *testing.T) {
+	first := NewServer("first", &ServerConfig{Host: "127.0.0.1", Port: 0}, okHandler())
+	first.Start()
+	defer func() {
+		if err := first.Stop(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	second := NewServer("second", &ServerConfig{}, okHandler())
+	second.server.Addr = first.Addr()
+	second.Start()
+	if second.Status() == nil {
+		t.Error("Expected listening on an address in use to fail")
+	}
+}
+
+func TestDisabledPathsHandler(t *testing.T) {
+	h := disabledPathsHandler(okHandler(), []string{"/goroutinez", "/eth/v1alpha1/debug/"})
+	tests := []struct {
+		path string
+		code int
+	}{
+		{path: "/metrics", code: http.StatusOK},
+		{path: "/goroutinez", code: http.StatusNotFound},
+		{path: "/goroutinez2", code: http.StatusOK},
+		{path: "/eth/v1alpha1/debug/logging", code: http.StatusNotFound},
+		{path: "/eth/v1alpha1/beacon/chainhead", code: http.StatusOK},
+	}
+	for _, tt := range tests {
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rr.Code != tt.code {
+			t.Errorf("%s: wanted status %d, received %d", tt.path, tt.code, rr.Code)
+		}
+	}
+}
+
+func TestCorsHandler_AllowedOrigins(t *testing.T) {
+	h := corsHandler(okHandler(), []string{"http://localhost:3000"})
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Errorf("Expected origin to be allowed, received %q", got)
+	}
+
+	req.Header.Set("Origin", "http://example.com")
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected origin not to be allowed, received %q", got)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	if got := SplitList(""); len(got) != 0 {
+		t.Errorf("Expected empty list, received %v", got)
+	}
+	want := []string{"http://a.com", "http://b.com"}
+	if got := SplitList("http://a.com, http://b.com,"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Wanted %v, received %v", want, got)
+	}
+}
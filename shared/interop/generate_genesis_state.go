@@ -36,6 +36,17 @@ func GenerateGenesisState(genesisTime, numValidators uint64) (*pb.BeaconState, [
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "could not generate deposit data from keys")
 	}
+	return GenerateGenesisStateFromDepositData(genesisTime, depositDataItems, depositDataRoots)
+}
+
+// GenerateGenesisStateFromDepositData creates a genesis state from a list of deposit data
+// items and their hash tree roots, such as deposits made to a test network's contract.
+// If a genesis time of 0 is supplied it is set to the current time.
+func GenerateGenesisStateFromDepositData(
+	genesisTime uint64,
+	depositDataItems []*ethpb.Deposit_Data,
+	depositDataRoots [][]byte,
+) (*pb.BeaconState, []*ethpb.Deposit, error) {
 	trie, err := trieutil.GenerateTrieFromItems(
 		depositDataRoots,
 		int(params.BeaconConfig().DepositContractTreeDepth),
@@ -50,3 +50,31 @@ func TestGenerateGenesisState(t *testing.T) {
 		t.Errorf("Wanted genesis time 0, received %d", genesisState.GenesisTime())
 	}
 }
+
+func TestGenerateGenesisStateFromDepositData(t *testing.T) {
+	numValidators := uint64(8)
+	privKeys, pubKeys, err := interop.DeterministicallyGenerateKeys(0 /*startIndex*/, numValidators)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositDataItems, depositDataRoots, err := interop.DepositDataFromKeys(privKeys, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesisState, deposits, err := interop.GenerateGenesisStateFromDepositData(10, depositDataItems, depositDataRoots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != int(numValidators) {
+		t.Errorf("Wanted %d deposits, received %d", numValidators, len(deposits))
+	}
+	if len(genesisState.Validators) != int(numValidators) {
+		t.Errorf("Wanted %d validators, received %d", numValidators, len(genesisState.Validators))
+	}
+	if genesisState.GenesisTime != 10 {
+		t.Errorf("Wanted genesis time 10, received %d", genesisState.GenesisTime)
+	}
+	if genesisState.Eth1Data.DepositCount != numValidators {
+		t.Errorf("Wanted deposit count %d, received %d", numValidators, genesisState.Eth1Data.DepositCount)
+	}
+}
@@ -0,0 +1,57 @@
+package logutil
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/sirupsen/logrus"
+)
+
+// genesisTime is the unix time of the chain genesis, or 0 while it is unknown.
+var genesisTime int64
+
+// SetGenesisTime sets the genesis time the context hook uses to compute the
+// current slot and epoch of log entries.
+func SetGenesisTime(t time.Time) {
+	atomic.StoreInt64(&genesisTime, t.Unix())
+}
+
+// ContextHook is a logrus hook attaching the service which logged an entry and,
+// once the genesis time is known, the current slot and epoch to every log entry.
+// It allows structured log output to be indexed without parsing messages.
+type ContextHook struct{}
+
+// NewContextHook creates a new context hook.
+func NewContextHook() *ContextHook {
+	return &ContextHook{}
+}
+
+// Levels returns the levels the hook fires for, which is all of them.
+func (h *ContextHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire attaches the service, currentSlot and currentEpoch fields to the entry.
+func (h *ContextHook) Fire(entry *logrus.Entry) error {
+	// The data map may be shared with the entry the log call was made on, so the
+	// fields are added to a copy rather than written to the shared map.
+	data := make(logrus.Fields, len(entry.Data)+3)
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	if prefix, ok := entry.Data["prefix"]; ok {
+		data["service"] = prefix
+	}
+	if genesis := atomic.LoadInt64(&genesisTime); genesis != 0 {
+		now := roughtime.Now().Unix()
+		if now >= genesis {
+			slot := uint64(now-genesis) / params.BeaconConfig().SecondsPerSlot
+			data["currentSlot"] = slot
+			data["currentEpoch"] = slot / params.BeaconConfig().SlotsPerEpoch
+		}
+	}
+	entry.Data = data
+	return nil
+}
@@ -0,0 +1,44 @@
+package logutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+func TestContextHook_AttachesServiceSlotAndEpoch(t *testing.T) {
+	defer SetGenesisTime(time.Unix(0, 0))
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	SetGenesisTime(time.Now().Add(-time.Duration(secondsPerSlot*(slotsPerEpoch+1)) * time.Second))
+
+	buf := new(bytes.Buffer)
+	logger := logrus.New()
+	logger.SetOutput(buf)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.AddHook(NewContextHook())
+	entry := logger.WithField("prefix", "sync")
+
+	entry.Info("Processed block")
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(buf.Bytes(), &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["service"] != "sync" {
+		t.Errorf("Wanted service sync, received %v", fields["service"])
+	}
+	if slot, ok := fields["currentSlot"].(float64); !ok || uint64(slot) < slotsPerEpoch+1 {
+		t.Errorf("Wanted current slot of at least %d, received %v", slotsPerEpoch+1, fields["currentSlot"])
+	}
+	if epoch, ok := fields["currentEpoch"].(float64); !ok || uint64(epoch) < 1 {
+		t.Errorf("Wanted current epoch of at least 1, received %v", fields["currentEpoch"])
+	}
+	if _, ok := entry.Data["service"]; ok {
+		t.Error("Expected the hook not to modify the data of the logging entry")
+	}
+}
@@ -0,0 +1,159 @@
+package logutil
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	levelLock sync.RWMutex
+	// Until SetLevels is called every entry passes the level formatter, leaving
+	// the filtering to the level of logrus itself.
+	globalLevel    = logrus.TraceLevel
+	prefixOverride = make(map[string]logrus.Level)
+)
+
+// SetLevels sets the log level of the process along with per-package overrides, keyed by
+// the logging prefix of the package such as "sync" or "p2p". Changes apply immediately to
+// every logger, allowing levels to be adjusted without restarting the process.
+func SetLevels(level logrus.Level, overrides map[string]logrus.Level) {
+	levelLock.Lock()
+	defer levelLock.Unlock()
+
+	globalLevel = level
+	prefixOverride = make(map[string]logrus.Level, len(overrides))
+	// Logrus discards entries above its level before they reach the formatter, so
+	// it must allow the most verbose level of any package.
+	maxLevel := level
+	for prefix, l := range overrides {
+		prefixOverride[prefix] = l
+		if l > maxLevel {
+			maxLevel = l
+		}
+	}
+	logrus.SetLevel(maxLevel)
+}
+
+// Levels returns the current log level and per-package overrides.
+func Levels() (logrus.Level, map[string]logrus.Level) {
+	levelLock.RLock()
+	defer levelLock.RUnlock()
+
+	overrides := make(map[string]logrus.Level, len(prefixOverride))
+	for prefix, l := range prefixOverride {
+		overrides[prefix] = l
+	}
+	return globalLevel, overrides
+}
+
+// ParseLevelOverrides parses per-package log levels given as prefix=level pairs.
+func ParseLevelOverrides(overrides []string) (map[string]logrus.Level, error) {
+	parsed := make(map[string]logrus.Level, len(overrides))
+	for _, override := range overrides {
+		kv := strings.SplitN(override, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("log level override %q is not in the form prefix=level", override)
+		}
+		level, err := logrus.ParseLevel(kv[1])
+		if err != nil {
+			return nil, err
+		}
+		parsed[kv[0]] = level
+	}
+	return parsed, nil
+}
+
+// levelFormatter drops entries above the level configured for their package before
+// delegating to the wrapped formatter.
+type levelFormatter struct {
+	logrus.Formatter
+}
+
+// WrapFormatter wraps the formatter so that per-package level overrides are applied
+// to every entry. It must be installed for overrides to take effect.
+func WrapFormatter(f logrus.Formatter) logrus.Formatter {
+	return &levelFormatter{Formatter: f}
+}
+
+// Format formats the entry, or returns nothing if its package does not log at the entry's level.
+func (f *levelFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !levelEnabled(entry) {
+		return nil, nil
+	}
+	return f.Formatter.Format(entry)
+}
+
+func levelEnabled(entry *logrus.Entry) bool {
+	levelLock.RLock()
+	defer levelLock.RUnlock()
+
+	level := globalLevel
+	if prefix, ok := entry.Data["prefix"].(string); ok {
+		if l, ok := prefixOverride[prefix]; ok {
+			level = l
+		}
+	}
+	return entry.Level <= level
+}
+
+// levelConfig holds the log level settings read from a YAML config file.
+type levelConfig struct {
+	Verbosity string   `yaml:"verbosity"`
+	Overrides []string `yaml:"log-level-override"`
+}
+
+// ReloadLevelsOnSIGHUP re-reads the verbosity and log-level-override settings from the
+// given YAML config file whenever the process receives SIGHUP, and applies them.
+func ReloadLevelsOnSIGHUP(configFile string) {
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGHUP)
+	go func() {
+		for range sigc {
+			if configFile == "" {
+				logrus.Warn("Received SIGHUP but no config file is set, log levels are unchanged")
+				continue
+			}
+			if err := reloadLevels(configFile); err != nil {
+				logrus.WithError(err).Error("Could not reload log levels")
+				continue
+			}
+			level, overrides := Levels()
+			logrus.WithFields(logrus.Fields{
+				"level":     level,
+				"overrides": overrides,
+			}).Info("Reloaded log levels")
+		}
+	}()
+}
+
+func reloadLevels(configFile string) error {
+	enc, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+	cfg := &levelConfig{}
+	if err := yaml.Unmarshal(enc, cfg); err != nil {
+		return err
+	}
+	level, _ := Levels()
+	if cfg.Verbosity != "" {
+		level, err = logrus.ParseLevel(cfg.Verbosity)
+		if err != nil {
+			return err
+		}
+	}
+	overrides, err := ParseLevelOverrides(cfg.Overrides)
+	if err != nil {
+		return err
+	}
+	SetLevels(level, overrides)
+	return nil
+}
@@ -0,0 +1,89 @@
+package logutil
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestLevelFormatter_AppliesPackageOverrides(t *testing.T) {
+	level, overrides := Levels()
+	defer SetLevels(level, overrides)
+	defer logrus.SetOutput(logrus.StandardLogger().Out)
+	defer logrus.SetFormatter(logrus.StandardLogger().Formatter)
+
+	buf := new(bytes.Buffer)
+	logrus.SetOutput(buf)
+	logrus.SetFormatter(WrapFormatter(&logrus.TextFormatter{DisableTimestamp: true}))
+	SetLevels(logrus.InfoLevel, map[string]logrus.Level{"sync": logrus.DebugLevel, "p2p": logrus.ErrorLevel})
+
+	logrus.WithField("prefix", "sync").Debug("sync debug")
+	logrus.WithField("prefix", "p2p").Warn("p2p warn")
+	logrus.WithField("prefix", "p2p").Error("p2p error")
+	logrus.WithField("prefix", "rpc").Debug("rpc debug")
+	logrus.WithField("prefix", "rpc").Info("rpc info")
+
+	out := buf.String()
+	for _, msg := range []string{"sync debug", "p2p error", "rpc info"} {
+		if !strings.Contains(out, msg) {
+			t.Errorf("Expected %q to be logged, received %s", msg, out)
+		}
+	}
+	for _, msg := range []string{"p2p warn", "rpc debug"} {
+		if strings.Contains(out, msg) {
+			t.Errorf("Expected %q not to be logged, received %s", msg, out)
+		}
+	}
+}
+
+func TestParseLevelOverrides(t *testing.T) {
+	overrides, err := ParseLevelOverrides([]string{"sync=debug", "p2p=warn"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overrides["sync"] != logrus.DebugLevel || overrides["p2p"] != logrus.WarnLevel {
+		t.Errorf("Unexpected overrides %v", overrides)
+	}
+	if _, err := ParseLevelOverrides([]string{"sync"}); err == nil {
+		t.Error("Expected error for override without level")
+	}
+	if _, err := ParseLevelOverrides([]string{"sync=loud"}); err == nil {
+		t.Error("Expected error for unknown level")
+	}
+}
+
+func TestReloadLevels(t *testing.T) {
+	level, overrides := Levels()
+	defer SetLevels(level, overrides)
+
+	dir, err := ioutil.TempDir("", "logutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Error(err)
+		}
+	}()
+	configFile := filepath.Join(dir, "config.yaml")
+	config := "verbosity: warn\nlog-level-override:\n  - sync=trace\n"
+	if err := ioutil.WriteFile(configFile, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reloadLevels(configFile); err != nil {
+		t.Fatal(err)
+	}
+	newLevel, newOverrides := Levels()
+	if newLevel != logrus.WarnLevel {
+		t.Errorf("Wanted level warn, received %v", newLevel)
+	}
+	if newOverrides["sync"] != logrus.TraceLevel {
+		t.Errorf("Wanted sync level trace, received %v", newOverrides["sync"])
+	}
+}
@@ -24,6 +24,10 @@ var RandaoMixesMemoryPool = new(sync.Pool)
 // for 3d byte slices.
 var ValidatorsMemoryPool = new(sync.Pool)
 
+// MerkleLayersMemoryPool represents the memory pool
+// for the merkle layers of the beacon state.
+var MerkleLayersMemoryPool = new(sync.Pool)
+
 // GetDoubleByteSlice retrieves the 2d byte slice of
 // the desired size from the memory pool.
 func GetDoubleByteSlice(size int) [][]byte {
@@ -138,3 +142,31 @@ func PutValidatorsTrie(data [][]*[32]byte) {
 		ValidatorsMemoryPool.Put(data)
 	}
 }
+
+// GetMerkleLayers retrieves the 3d byte slice of
+// the desired size from the memory pool.
+func GetMerkleLayers(size int) [][][]byte {
+	if !featureconfig.Get().EnableByteMempool {
+		return make([][][]byte, size)
+	}
+	rawObj := MerkleLayersMemoryPool.Get()
+	if rawObj == nil {
+		return make([][][]byte, size)
+	}
+	byteSlice, ok := rawObj.([][][]byte)
+	if !ok {
+		return nil
+	}
+	if len(byteSlice) >= size {
+		return byteSlice[:size]
+	}
+	return append(byteSlice, make([][][]byte, size-len(byteSlice))...)
+}
+
+// PutMerkleLayers places the provided 3d byte slice
+// in the memory pool.
+func PutMerkleLayers(data [][][]byte) {
+	if featureconfig.Get().EnableByteMempool {
+		MerkleLayersMemoryPool.Put(data)
+	}
+}
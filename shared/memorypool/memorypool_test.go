@@ -14,3 +14,13 @@ func TestRoundTripMemoryRetrieval(t *testing.T) {
 			"Wanted  slice with length %d but got length %d", 1000, len(newSlice))
 	}
 }
+
+func TestRoundTripMerkleLayersRetrieval(t *testing.T) {
+	layers := make([][][]byte, 6)
+	PutMerkleLayers(layers)
+	newLayers := GetMerkleLayers(4)
+
+	if len(newLayers) != 4 {
+		t.Errorf("Wanted merkle layers with length %d but got length %d", 4, len(newLayers))
+	}
+}
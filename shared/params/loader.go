@@ -0,0 +1,134 @@
+package params
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// hexValueRegex matches YAML lines with a hex encoded value, such as fork versions and
+// domain types in the spec configuration files.
+var hexValueRegex = regexp.MustCompile(`^(\s*([A-Z0-9_]+):\s*)0x([0-9a-fA-F]*)\s*(#.*)?$`)
+
+// optionalSpecKeys are keys of the chain config which are not part of the spec YAML
+// configuration files, so they may be omitted from a full spec config.
+var optionalSpecKeys = map[string]bool{
+	"CONFIG_NAME":                 true,
+	"FAR_FUTURE_EPOCH":            true,
+	"BASE_REWARDS_PER_EPOCH":      true,
+	"DEPOSIT_CONTRACT_TREE_DEPTH": true,
+	"NEXT_FORK_VERSION":           true,
+	"NEXT_FORK_EPOCH":             true,
+}
+
+// ignoredSpecKeys are keys of the spec YAML configuration files which have no
+// counterpart in the chain config and are skipped when loading a file.
+var ignoredSpecKeys = map[string]bool{
+	"RANDOM_SUBNETS_PER_VALIDATOR":          true,
+	"EPOCHS_PER_RANDOM_SUBNET_SUBSCRIPTION": true,
+}
+
+// LoadChainConfigFile loads a spec-style YAML chain configuration file on top of the
+// current beacon chain config, which allows running private networks with custom presets
+// without recompiling. Values not present in the file are left unchanged, so the file may
+// also extend the minimal config. Keys which are not part of the chain config are rejected.
+func LoadChainConfigFile(chainConfigFileName string) error {
+	return loadChainConfigFile(chainConfigFileName, false /* requireAllKeys */)
+}
+
+// LoadSpecConfigFile loads a full spec YAML configuration file, such as the official
+// mainnet or minimal configs, and applies it as the beacon chain config. Unlike
+// LoadChainConfigFile, every spec key must be present in the file.
+func LoadSpecConfigFile(specConfigFileName string) error {
+	return loadChainConfigFile(specConfigFileName, true /* requireAllKeys */)
+}
+
+func loadChainConfigFile(chainConfigFileName string, requireAllKeys bool) error {
+	yamlFile, err := ioutil.ReadFile(chainConfigFileName)
+	if err != nil {
+		return fmt.Errorf("could not read chain config file: %v", err)
+	}
+	yamlFile = replaceHexStringsWithYAMLSequences(yamlFile)
+	keys := make(map[string]interface{})
+	if err := yaml.Unmarshal(yamlFile, &keys); err != nil {
+		return fmt.Errorf("could not parse chain config file: %v", err)
+	}
+	if err := validateChainConfigKeys(keys, requireAllKeys); err != nil {
+		return err
+	}
+	conf := BeaconConfig().Copy()
+	if err := yaml.Unmarshal(yamlFile, conf); err != nil {
+		return fmt.Errorf("could not parse chain config file: %v", err)
+	}
+	OverrideBeaconConfig(conf)
+	return nil
+}
+
+// validateChainConfigKeys returns an error listing the keys which are not part of the
+// chain config and, if all keys are required, the spec keys missing from the file.
+func validateChainConfigKeys(keys map[string]interface{}, requireAllKeys bool) error {
+	known := chainConfigKeys()
+	var unknown, missing []string
+	for key := range keys {
+		if !known[key] && !ignoredSpecKeys[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if requireAllKeys {
+		for key := range known {
+			if _, ok := keys[key]; !ok && !optionalSpecKeys[key] {
+				missing = append(missing, key)
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown keys in chain config file: %s", strings.Join(unknown, ", "))
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("missing keys in chain config file: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// chainConfigKeys returns the YAML keys of the beacon chain config fields.
+func chainConfigKeys() map[string]bool {
+	keys := make(map[string]bool)
+	t := reflect.TypeOf(BeaconChainConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("yaml"); key != "" {
+			keys[key] = true
+		}
+	}
+	return keys
+}
+
+// replaceHexStringsWithYAMLSequences converts hex encoded values of more than one byte into
+// YAML sequences of bytes, which can be decoded into byte slices and arrays. Single byte
+// values are already parsed as integers and the deposit contract address is kept as a string.
+func replaceHexStringsWithYAMLSequences(yamlFile []byte) []byte {
+	lines := strings.Split(string(yamlFile), "\n")
+	for i, line := range lines {
+		match := hexValueRegex.FindStringSubmatch(line)
+		if match == nil || match[2] == "DEPOSIT_CONTRACT_ADDRESS" || len(match[3]) <= 2 {
+			continue
+		}
+		b, err := hex.DecodeString(match[3])
+		if err != nil {
+			continue
+		}
+		values := make([]string, len(b))
+		for j, v := range b {
+			values[j] = fmt.Sprintf("%d", v)
+		}
+		lines[i] = match[1] + "[" + strings.Join(values, ", ") + "]"
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
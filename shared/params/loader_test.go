@@ -0,0 +1,164 @@
+package params_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"gopkg.in/yaml.v2"
+)
+
+func TestLoadChainConfigFile(t *testing.T) {
+	resetFunc := params.OverrideBeaconConfigWithReset(params.MinimalSpecConfig())
+	defer resetFunc()
+
+	yamlFile := []byte(`# Custom devnet configuration.
+CONFIG_NAME: "devnet"
+SLOTS_PER_EPOCH: 4
+MIN_GENESIS_DELAY: 300
+ETH1_FOLLOW_DISTANCE: 16
+GENESIS_FORK_VERSION: 0x00000042
+DOMAIN_RANDAO: 0x02000000 # randao domain
+BLS_WITHDRAWAL_PREFIX: 0x00
+NEXT_FORK_EPOCH: 100
+DEPOSIT_CONTRACT_ADDRESS: 0x4242424242424242424242424242424242424242
+`)
+	f, err := ioutil.TempFile("", "chain_config*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.Remove(f.Name()); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if _, err := f.Write(yamlFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := params.LoadChainConfigFile(f.Name()); err != nil {
+		t.Fatal(err)
+	}
+	c := params.BeaconConfig()
+	if c.SlotsPerEpoch != 4 {
+		t.Errorf("Wanted slots per epoch 4, got %d", c.SlotsPerEpoch)
+	}
+	if c.MinGenesisDelay != 300 {
+		t.Errorf("Wanted genesis delay 300, got %d", c.MinGenesisDelay)
+	}
+	if c.Eth1FollowDistance != 16 {
+		t.Errorf("Wanted eth1 follow distance 16, got %d", c.Eth1FollowDistance)
+	}
+	if !bytes.Equal(c.GenesisForkVersion, []byte{0, 0, 0, 0x42}) {
+		t.Errorf("Wanted genesis fork version 0x00000042, got %#x", c.GenesisForkVersion)
+	}
+	if c.DomainRandao != [4]byte{2, 0, 0, 0} {
+		t.Errorf("Wanted randao domain 0x02000000, got %#x", c.DomainRandao)
+	}
+	if c.NextForkEpoch != 100 {
+		t.Errorf("Wanted next fork epoch 100, got %d", c.NextForkEpoch)
+	}
+	if c.DepositContractAddress != "0x4242424242424242424242424242424242424242" {
+		t.Errorf("Unexpected deposit contract address %s", c.DepositContractAddress)
+	}
+	// Values missing from the file are kept from the minimal config.
+	if c.TargetCommitteeSize != params.MinimalSpecConfig().TargetCommitteeSize {
+		t.Errorf("Wanted target committee size %d, got %d", params.MinimalSpecConfig().TargetCommitteeSize, c.TargetCommitteeSize)
+	}
+}
+
+func TestLoadChainConfigFile_MissingFile(t *testing.T) {
+	if err := params.LoadChainConfigFile("/path/does/not/exist.yaml"); err == nil {
+		t.Error("Expected error loading a missing chain config file")
+	}
+}
+
+func TestLoadChainConfigFile_UnknownKey(t *testing.T) {
+	resetFunc := params.OverrideBeaconConfigWithReset(params.MinimalSpecConfig())
+	defer resetFunc()
+
+	fileName := writeChainConfigFile(t, []byte("SLOTS_PER_EPOCH: 4\nSLOTS_PER_EPOCHS: 8\n"))
+	defer func() {
+		if err := os.Remove(fileName); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	err := params.LoadChainConfigFile(fileName)
+	if err == nil || !strings.Contains(err.Error(), "SLOTS_PER_EPOCHS") {
+		t.Errorf("Expected unknown key error, received %v", err)
+	}
+	if params.BeaconConfig().SlotsPerEpoch != params.MinimalSpecConfig().SlotsPerEpoch {
+		t.Error("Expected config to be unchanged after a failed load")
+	}
+}
+
+func TestLoadSpecConfigFile(t *testing.T) {
+	resetFunc := params.OverrideBeaconConfigWithReset(params.MainnetConfig())
+	defer resetFunc()
+
+	// Write the minimal config with only the spec keys, which are upper case.
+	out, err := yaml.Marshal(params.MinimalSpecConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := make(map[string]interface{})
+	if err := yaml.Unmarshal(out, &all); err != nil {
+		t.Fatal(err)
+	}
+	spec := make(map[string]interface{})
+	for k, v := range all {
+		if k == strings.ToUpper(k) {
+			spec[k] = v
+		}
+	}
+	out, err = yaml.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileName := writeChainConfigFile(t, out)
+	defer func() {
+		if err := os.Remove(fileName); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := params.LoadSpecConfigFile(fileName); err != nil {
+		t.Fatal(err)
+	}
+	if params.BeaconConfig().SlotsPerEpoch != params.MinimalSpecConfig().SlotsPerEpoch {
+		t.Errorf("Wanted slots per epoch %d, got %d", params.MinimalSpecConfig().SlotsPerEpoch, params.BeaconConfig().SlotsPerEpoch)
+	}
+
+	// A full spec config must define every key.
+	delete(spec, "SHUFFLE_ROUND_COUNT")
+	out, err = yaml.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(fileName, out, 0600); err != nil {
+		t.Fatal(err)
+	}
+	err = params.LoadSpecConfigFile(fileName)
+	if err == nil || !strings.Contains(err.Error(), "SHUFFLE_ROUND_COUNT") {
+		t.Errorf("Expected missing key error, received %v", err)
+	}
+}
+
+func writeChainConfigFile(t *testing.T, content []byte) string {
+	f, err := ioutil.TempFile("", "chain_config*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
@@ -0,0 +1,61 @@
+package params
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Network bundles the chain config and connection details of a named network, so
+// a node can join it with a single flag.
+type Network struct {
+	Name                   string                    // Name of the network as given to the --network flag.
+	ChainConfig            func() *BeaconChainConfig // ChainConfig returns the chain config of the network.
+	BootstrapNodes         []string                  // BootstrapNodes used for peer discovery on the network.
+	DepositContractAddress string                    // DepositContractAddress of the network on the eth1 chain.
+	GenesisState           string                    // GenesisState is a file path or URL to the SSZ encoded genesis state, if the network was not started from the deposit contract.
+}
+
+var networks = map[string]*Network{
+	"mainnet": {
+		Name:        "mainnet",
+		ChainConfig: MainnetConfig,
+	},
+	"testnet": {
+		Name:        "testnet",
+		ChainConfig: MainnetConfig,
+		BootstrapNodes: []string{
+			"/dns4/prylabs.net/tcp/30001/p2p/16Uiu2HAm7Qwe19vz9WzD2Mxn7fXd1vgHHp4iccuyq7TxwRXoAGfc",
+			"enr:-Ku4QAGwOT9StqmwI5LHaIymIO4ooFKfNkEjWa0f1P8OsElgBh2Ijb-GrD_-b9W4kcPFcwmHQEy5RncqXNqdpVo1heoBh2F0dG5ldHOIAAAAAAAAAACEZXRoMpAAAAAAAAAAAP__________gmlkgnY0gmlwhBLf22SJc2VjcDI1NmsxoQJxCnE6v_x2ekgY_uoE1rtwzvGy40mq9eD66XfHPBWgIIN1ZHCCD6A",
+		},
+		DepositContractAddress: "0x5cA1e00004366Ac85f492887AAab12d0e6418876",
+	},
+}
+
+// NetworkByName returns the named network, or an error listing the known networks.
+func NetworkByName(name string) (*Network, error) {
+	network, ok := networks[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %q, known networks are %v", name, NetworkNames())
+	}
+	return network, nil
+}
+
+// NetworkNames returns the names of the known networks in sorted order.
+func NetworkNames() []string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseNetwork sets the beacon chain config to the chain config of the network,
+// including its deposit contract address.
+func UseNetwork(network *Network) {
+	cfg := network.ChainConfig().Copy()
+	if network.DepositContractAddress != "" {
+		cfg.DepositContractAddress = network.DepositContractAddress
+	}
+	OverrideBeaconConfig(cfg)
+}
@@ -0,0 +1,44 @@
+package params_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestNetworkByName(t *testing.T) {
+	for _, name := range params.NetworkNames() {
+		network, err := params.NetworkByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if network.Name != name {
+			t.Errorf("Wanted network %s, got %s", name, network.Name)
+		}
+	}
+	if _, err := params.NetworkByName("unknown"); err == nil {
+		t.Error("Expected error for unknown network")
+	}
+}
+
+func TestUseNetwork(t *testing.T) {
+	resetFunc := params.OverrideBeaconConfigWithReset(params.MinimalSpecConfig())
+	defer resetFunc()
+
+	network, err := params.NetworkByName("testnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	params.UseNetwork(network)
+	c := params.BeaconConfig()
+	if c.SlotsPerEpoch != network.ChainConfig().SlotsPerEpoch {
+		t.Errorf("Wanted slots per epoch %d, got %d", network.ChainConfig().SlotsPerEpoch, c.SlotsPerEpoch)
+	}
+	if c.DepositContractAddress != network.DepositContractAddress {
+		t.Errorf("Wanted deposit contract %s, got %s", network.DepositContractAddress, c.DepositContractAddress)
+	}
+	// The network's chain config itself must not be modified.
+	if network.ChainConfig().DepositContractAddress == network.DepositContractAddress {
+		t.Error("Expected the network chain config to be left unchanged")
+	}
+}
@@ -4,17 +4,14 @@ package prometheus
 
 import (
 	"bytes"
-	"context"
 	"fmt"
-	"net"
 	"net/http"
 	"runtime/debug"
 	"runtime/pprof"
-	"strings"
-	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/httputil"
 	"github.com/sirupsen/logrus"
 )
 
@@ -23,9 +20,8 @@ var log = logrus.WithField("prefix", "prometheus")
 // Service provides Prometheus metrics via the /metrics route. This route will
 // show all the metrics registered with the Prometheus DefaultRegisterer.
 type Service struct {
-	server      *http.Server
+	server      *httputil.Server
 	svcRegistry *shared.ServiceRegistry
-	failStatus  error
 }
 
 // Handler represents a path and handler func to serve on the same port as /metrics, /healthz, /goroutinez, etc.
@@ -34,9 +30,9 @@ type Handler struct {
 	Handler func(http.ResponseWriter, *http.Request)
 }
 
-// NewPrometheusService sets up a new instance for a given address host:port.
-// An empty host will match with any IP so an address like ":2121" is perfectly acceptable.
-func NewPrometheusService(addr string, svcRegistry *shared.ServiceRegistry, additionalHandlers ...Handler) *Service {
+// NewPrometheusService sets up a new instance serving on the host and port of the server configuration.
+// An empty host will match with any IP.
+func NewPrometheusService(cfg *httputil.ServerConfig, svcRegistry *shared.ServiceRegistry, additionalHandlers ...Handler) *Service {
 	s := &Service{svcRegistry: svcRegistry}
 
 	mux := http.NewServeMux()
@@ -49,7 +45,7 @@ func NewPrometheusService(addr string, svcRegistry *shared.ServiceRegistry, addi
 		mux.HandleFunc(h.Path, h.Handler)
 	}
 
-	s.server = &http.Server{Addr: addr, Handler: mux}
+	s.server = httputil.NewServer("prometheus metrics", cfg, mux)
 
 	return s
 }
@@ -112,39 +108,15 @@ func (s *Service) goroutinezHandler(w http.ResponseWriter, _ *http.Request) {
 
 // Start the prometheus service.
 func (s *Service) Start() {
-	go func() {
-		// See if the port is already used.
-		addrParts := strings.Split(s.server.Addr, ":")
-		conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%s", addrParts[1]), time.Second)
-		if err == nil {
-			if err := conn.Close(); err != nil {
-				log.WithError(err).Error("Failed to close connection")
-			}
-			// Something on the port; we cannot use it.
-			log.WithField("address", s.server.Addr).Warn("Port already in use; cannot start prometheus service")
-		} else {
-			// Nothing on that port; we can use it.
-			log.WithField("address", s.server.Addr).Debug("Starting prometheus service")
-			err := s.server.ListenAndServe()
-			if err != nil && err != http.ErrServerClosed {
-				log.Errorf("Could not listen to host:port :%s: %v", s.server.Addr, err)
-				s.failStatus = err
-			}
-		}
-	}()
+	s.server.Start()
 }
 
 // Stop the service gracefully.
 func (s *Service) Stop() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-	return s.server.Shutdown(ctx)
+	return s.server.Stop()
 }
 
 // Status checks for any service failure conditions.
 func (s *Service) Status() error {
-	if s.failStatus != nil {
-		return s.failStatus
-	}
-	return nil
+	return s.server.Status()
 }
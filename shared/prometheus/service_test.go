@@ -3,6 +3,7 @@ package prometheus
 import (
 	"errors"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/httputil"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,7 +21,7 @@ func init() {
 }
 
 func TestLifecycle(t *testing.T) {
-	prometheusService := NewPrometheusService(":2112", nil)
+	prometheusService := NewPrometheusService(&httputil.ServerConfig{Port: 2112}, nil)
 	prometheusService.Start()
 	// Give service time to start.
 	time.Sleep(time.Second)
@@ -68,7 +70,7 @@ func TestHealthz(t *testing.T) {
 	if err := registry.RegisterService(m); err != nil {
 		t.Fatalf("failed to registry service %v", err)
 	}
-	s := NewPrometheusService("" /*addr*/, registry)
+	s := NewPrometheusService(&httputil.ServerConfig{}, registry)
 
 	req, err := http.NewRequest("GET", "/healthz", nil /*reader*/)
 	if err != nil {
@@ -109,11 +111,21 @@ func TestHealthz(t *testing.T) {
 }
 
 func TestStatus(t *testing.T) {
-	failError := errors.New("failure")
-	s := &Service{failStatus: failError}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := lis.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	port := lis.Addr().(*net.TCPAddr).Port
 
-	if err := s.Status(); err != s.failStatus {
-		t.Errorf("Wanted: %v, got: %v", s.failStatus, s.Status())
+	s := NewPrometheusService(&httputil.ServerConfig{Host: "127.0.0.1", Port: port}, nil)
+	s.Start()
+	if err := s.Status(); err == nil {
+		t.Error("Expected a failure status when the port is already in use")
 	}
 }
 
@@ -124,7 +136,7 @@ func TestContentNegotiation(t *testing.T) {
 		if err := registry.RegisterService(m); err != nil {
 			t.Fatalf("failed to registry service %v", err)
 		}
-		s := NewPrometheusService("", registry)
+		s := NewPrometheusService(&httputil.ServerConfig{}, registry)
 
 		req, err := http.NewRequest("GET", "/healthz", nil /* body */)
 		if err != nil {
@@ -159,7 +171,7 @@ func TestContentNegotiation(t *testing.T) {
 		if err := registry.RegisterService(m); err != nil {
 			t.Fatalf("failed to registry service %v", err)
 		}
-		s := NewPrometheusService("", registry)
+		s := NewPrometheusService(&httputil.ServerConfig{}, registry)
 
 		req, err := http.NewRequest("GET", "/healthz", nil /* body */)
 		if err != nil {
@@ -0,0 +1,125 @@
+// Package promptutil defines helpers for prompting the user in the terminal,
+// including hidden password entry and yes/no confirmations. Passwords can also
+// be provided non-interactively through an environment variable or a file,
+// so the commands using these prompts work in scripts and containers.
+package promptutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var log = logrus.WithField("prefix", "prompt")
+
+// errNotTerminal is returned when input is required but stdin is not a terminal,
+// instead of blocking forever on a non-interactive run.
+var errNotTerminal = errors.New("stdin is not a terminal, provide the value non-interactively")
+
+// Password returns a password from the first available source: the envVar environment
+// variable, the passwordFile, or else a hidden prompt in the terminal. If confirm is set,
+// a prompted password has to be entered twice.
+func Password(promptText string, envVar string, passwordFile string, confirm bool) (string, error) {
+	if envVar != "" {
+		if password, ok := os.LookupEnv(envVar); ok {
+			return password, nil
+		}
+	}
+	if passwordFile != "" {
+		return PasswordFromFile(passwordFile)
+	}
+	return PasswordPrompt(promptText, confirm)
+}
+
+// PasswordFromFile reads a password from a file, ignoring trailing newlines.
+func PasswordFromFile(passwordFile string) (string, error) {
+	data, err := ioutil.ReadFile(passwordFile)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read password file")
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// PasswordPrompt reads a password from the terminal without echoing it. If confirm
+// is set, the password has to be entered a second time and both entries must match.
+func PasswordPrompt(promptText string, confirm bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return "", errNotTerminal
+	}
+	for {
+		fmt.Printf("%s: ", promptText)
+		password, err := terminal.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			return "", errors.Wrap(err, "could not read password")
+		}
+		if !confirm {
+			return string(password), nil
+		}
+		fmt.Print("Confirm password: ")
+		confirmation, err := terminal.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			return "", errors.Wrap(err, "could not read password")
+		}
+		if string(password) == string(confirmation) {
+			return string(password), nil
+		}
+		log.Error("Passwords do not match, please try again")
+	}
+}
+
+// DefaultPrompt reads a line of input from the terminal, returning defaultValue
+// if the line is empty.
+func DefaultPrompt(promptText string, defaultValue string) (string, error) {
+	return defaultPrompt(os.Stdin, promptText, defaultValue)
+}
+
+// ConfirmPrompt uses the passed in actionText as the confirmation text displayed in the terminal.
+// The user must enter Y or N to indicate whether they confirm the action detailed in the warning text.
+// Returns a boolean representing the user's answer.
+func ConfirmPrompt(actionText string, deniedText string) (bool, error) {
+	return confirmPrompt(os.Stdin, actionText, deniedText)
+}
+
+func defaultPrompt(r io.Reader, promptText string, defaultValue string) (string, error) {
+	fmt.Printf("%s (default: %q): ", promptText, defaultValue)
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", errors.Wrap(err, "could not read input")
+	}
+	if line = strings.TrimSpace(line); line != "" {
+		return line, nil
+	}
+	return defaultValue, nil
+}
+
+func confirmPrompt(r io.Reader, actionText string, deniedText string) (bool, error) {
+	reader := bufio.NewReader(r)
+	log.Warn(actionText)
+	for {
+		fmt.Print(">> ")
+		line, _, err := reader.ReadLine()
+		if err != nil {
+			return false, err
+		}
+		lineInput := strings.ToUpper(strings.TrimSpace(string(line)))
+		switch lineInput {
+		case "Y":
+			return true, nil
+		case "N":
+			log.Warn(deniedText)
+			return false, nil
+		default:
+			log.Errorf("Invalid option of %s chosen, please only enter Y/N", line)
+		}
+	}
+}
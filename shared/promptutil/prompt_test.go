@@ -0,0 +1,95 @@
+package promptutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPassword_FromEnv(t *testing.T) {
+	envVar := "PROMPTUTIL_TEST_PASSWORD"
+	if err := os.Setenv(envVar, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.Unsetenv(envVar); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	password, err := Password("Enter password", envVar, "/does/not/exist", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password != "secret" {
+		t.Errorf("Wanted password %q, received %q", "secret", password)
+	}
+}
+
+func TestPassword_FromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "promptutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	passwordFile := filepath.Join(dir, "password.txt")
+	if err := ioutil.WriteFile(passwordFile, []byte("secret password\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	password, err := Password("Enter password", "", passwordFile, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if password != "secret password" {
+		t.Errorf("Wanted password %q, received %q", "secret password", password)
+	}
+
+	if _, err := PasswordFromFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("Expected error reading missing password file")
+	}
+}
+
+func TestDefaultPrompt(t *testing.T) {
+	value, err := defaultPrompt(strings.NewReader("\n"), "Path", "/default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "/default" {
+		t.Errorf("Wanted default value, received %q", value)
+	}
+	value, err = defaultPrompt(strings.NewReader("/custom\n"), "Path", "/default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "/custom" {
+		t.Errorf("Wanted %q, received %q", "/custom", value)
+	}
+}
+
+func TestConfirmPrompt(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: "y\n", want: true},
+		{input: "N\n", want: false},
+		{input: "maybe\nY\n", want: true},
+	}
+	for _, tt := range tests {
+		confirmed, err := confirmPrompt(strings.NewReader(tt.input), "Are you sure?", "Denied")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if confirmed != tt.want {
+			t.Errorf("Input %q: wanted %v, received %v", tt.input, tt.want, confirmed)
+		}
+	}
+	if _, err := confirmPrompt(strings.NewReader(""), "Are you sure?", "Denied"); err == nil {
+		t.Error("Expected error when input ends without an answer")
+	}
+}
@@ -0,0 +1,252 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/trace"
+	"google.golang.org/api/support/bundler"
+)
+
+const (
+	// JaegerExporter sends spans to a Jaeger collector.
+	JaegerExporter = "jaeger"
+	// ZipkinExporter sends spans to a Zipkin collector using the v2 JSON API.
+	ZipkinExporter = "zipkin"
+	// OTLPExporter sends spans to an OpenTelemetry collector using OTLP over HTTP with JSON encoding.
+	OTLPExporter = "otlp"
+)
+
+// defaultEndpoints are the collector endpoints used when no tracing endpoint is given.
+var defaultEndpoints = map[string]string{
+	JaegerExporter: "http://127.0.0.1:14268/api/traces",
+	ZipkinExporter: "http://127.0.0.1:9411/api/v2/spans",
+	OTLPExporter:   "http://127.0.0.1:4318/v1/traces",
+}
+
+// httpExporter buffers spans and posts them in batches to a collector endpoint,
+// encoding each batch with the given function.
+type httpExporter struct {
+	endpoint string
+	encode   func(spans []*trace.SpanData) ([]byte, error)
+	client   *http.Client
+	bundler  *bundler.Bundler
+}
+
+func newHTTPExporter(endpoint string, encode func(spans []*trace.SpanData) ([]byte, error)) *httpExporter {
+	e := &httpExporter{
+		endpoint: endpoint,
+		encode:   encode,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+	e.bundler = bundler.NewBundler((*trace.SpanData)(nil), func(bundle interface{}) {
+		if err := e.upload(bundle.([]*trace.SpanData)); err != nil {
+			log.WithError(err).Error("Failed to process span")
+		}
+	})
+	e.bundler.BufferedByteLimit = 10000 * 1024
+	return e
+}
+
+// ExportSpan queues the span for upload to the collector.
+func (e *httpExporter) ExportSpan(s *trace.SpanData) {
+	if err := e.bundler.Add(s, 1); err != nil {
+		log.WithError(err).Error("Failed to process span")
+	}
+}
+
+func (e *httpExporter) upload(spans []*trace.SpanData) error {
+	body, err := e.encode(spans)
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector at %s responded with status %s", e.endpoint, resp.Status)
+	}
+	return nil
+}
+
+type zipkinEndpoint struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type zipkinSpan struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name"`
+	Kind          string            `json:"kind,omitempty"`
+	Timestamp     int64             `json:"timestamp"`
+	Duration      int64             `json:"duration"`
+	LocalEndpoint zipkinEndpoint    `json:"localEndpoint"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// zipkinEncoder encodes spans as a Zipkin v2 JSON span list, attaching the
+// process tags to every span.
+func zipkinEncoder(serviceName string, tags map[string]string) func(spans []*trace.SpanData) ([]byte, error) {
+	return func(spans []*trace.SpanData) ([]byte, error) {
+		zspans := make([]*zipkinSpan, len(spans))
+		for i, s := range spans {
+			spanTags := make(map[string]string, len(tags)+len(s.Attributes))
+			for k, v := range tags {
+				spanTags[k] = v
+			}
+			for k, v := range s.Attributes {
+				spanTags[k] = fmt.Sprint(v)
+			}
+			if s.Status.Code != trace.StatusCodeOK {
+				spanTags["error"] = s.Status.Message
+			}
+			zs := &zipkinSpan{
+				TraceID:       hex.EncodeToString(s.TraceID[:]),
+				ID:            hex.EncodeToString(s.SpanID[:]),
+				Name:          s.Name,
+				Timestamp:     s.StartTime.UnixNano() / int64(time.Microsecond),
+				Duration:      int64(s.EndTime.Sub(s.StartTime) / time.Microsecond),
+				LocalEndpoint: zipkinEndpoint{ServiceName: serviceName},
+				Tags:          spanTags,
+			}
+			if s.ParentSpanID != (trace.SpanID{}) {
+				zs.ParentID = hex.EncodeToString(s.ParentSpanID[:])
+			}
+			switch s.SpanKind {
+			case trace.SpanKindServer:
+				zs.Kind = "SERVER"
+			case trace.SpanKindClient:
+				zs.Kind = "CLIENT"
+			}
+			zspans[i] = zs
+		}
+		return json.Marshal(zspans)
+	}
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string           `json:"traceId"`
+	SpanID            string           `json:"spanId"`
+	ParentSpanID      string           `json:"parentSpanId,omitempty"`
+	Name              string           `json:"name"`
+	Kind              int              `json:"kind"`
+	StartTimeUnixNano string           `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string           `json:"endTimeUnixNano"`
+	Attributes        []*otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus       `json:"status"`
+}
+
+type otlpScopeSpans struct {
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpAttribute `json:"attributes"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource      `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+// otlpEncoder encodes spans as an OTLP trace export request, carrying the
+// service name and process tags as resource attributes.
+func otlpEncoder(serviceName string, tags map[string]string) func(spans []*trace.SpanData) ([]byte, error) {
+	resourceAttrs := []*otlpAttribute{otlpAttributeFrom("service.name", serviceName)}
+	for k, v := range tags {
+		resourceAttrs = append(resourceAttrs, otlpAttributeFrom(k, v))
+	}
+	return func(spans []*trace.SpanData) ([]byte, error) {
+		ospans := make([]*otlpSpan, len(spans))
+		for i, s := range spans {
+			ospan := &otlpSpan{
+				TraceID:           hex.EncodeToString(s.TraceID[:]),
+				SpanID:            hex.EncodeToString(s.SpanID[:]),
+				Name:              s.Name,
+				Kind:              otlpSpanKind(s.SpanKind),
+				StartTimeUnixNano: strconv.FormatInt(s.StartTime.UnixNano(), 10),
+				EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+			}
+			if s.ParentSpanID != (trace.SpanID{}) {
+				ospan.ParentSpanID = hex.EncodeToString(s.ParentSpanID[:])
+			}
+			for k, v := range s.Attributes {
+				ospan.Attributes = append(ospan.Attributes, otlpAttributeFrom(k, v))
+			}
+			// OTLP status codes are UNSET (0), OK (1) and ERROR (2).
+			if s.Status.Code != trace.StatusCodeOK {
+				ospan.Status = otlpStatus{Code: 2, Message: s.Status.Message}
+			}
+			ospans[i] = ospan
+		}
+		return json.Marshal(&otlpRequest{
+			ResourceSpans: []*otlpResourceSpans{{
+				Resource:   otlpResource{Attributes: resourceAttrs},
+				ScopeSpans: []*otlpScopeSpans{{Spans: ospans}},
+			}},
+		})
+	}
+}
+
+func otlpAttributeFrom(key string, value interface{}) *otlpAttribute {
+	attr := &otlpAttribute{Key: key}
+	switch v := value.(type) {
+	case bool:
+		attr.Value.BoolValue = &v
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		attr.Value.IntValue = &s
+	case float64:
+		attr.Value.DoubleValue = &v
+	default:
+		s := fmt.Sprint(v)
+		attr.Value.StringValue = &s
+	}
+	return attr
+}
+
+// otlpSpanKind maps opencensus span kinds to OTLP, where 1 is internal,
+// 2 is server and 3 is client.
+func otlpSpanKind(kind int) int {
+	switch kind {
+	case trace.SpanKindServer:
+		return 2
+	case trace.SpanKindClient:
+		return 3
+	default:
+		return 1
+	}
+}
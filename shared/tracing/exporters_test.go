@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+func TestParseTags(t *testing.T) {
+	tags, err := parseTags([]string{"region=eu", "empty=", "url=http://a=b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"region": "eu", "empty": "", "url": "http://a=b"}
+	for k, v := range want {
+		if tags[k] != v {
+			t.Errorf("Wanted tag %s=%s, received %s", k, v, tags[k])
+		}
+	}
+	if _, err := parseTags([]string{"region"}); err == nil {
+		t.Error("Expected error for tag without value")
+	}
+	if _, err := parseTags([]string{"=eu"}); err == nil {
+		t.Error("Expected error for tag without key")
+	}
+}
+
+func TestZipkinEncoder(t *testing.T) {
+	start := time.Unix(1000, 0)
+	span := &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{2},
+		},
+		ParentSpanID: trace.SpanID{3},
+		Name:         "validator.SubmitAttestation",
+		SpanKind:     trace.SpanKindServer,
+		StartTime:    start,
+		EndTime:      start.Add(5 * time.Millisecond),
+		Attributes:   map[string]interface{}{"slot": int64(10)},
+	}
+	enc, err := zipkinEncoder("beacon-chain", map[string]string{"region": "eu"})([]*trace.SpanData{span})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []*zipkinSpan
+	if err := json.Unmarshal(enc, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 1 {
+		t.Fatalf("Wanted 1 span, received %d", len(decoded))
+	}
+	zs := decoded[0]
+	if zs.TraceID != "01000000000000000000000000000000" || zs.ParentID != "0300000000000000" {
+		t.Errorf("Unexpected trace or parent ID %s/%s", zs.TraceID, zs.ParentID)
+	}
+	if zs.Duration != 5000 || zs.Kind != "SERVER" {
+		t.Errorf("Wanted 5000us SERVER span, received %dus %s", zs.Duration, zs.Kind)
+	}
+	if zs.Tags["region"] != "eu" || zs.Tags["slot"] != "10" {
+		t.Errorf("Unexpected tags %v", zs.Tags)
+	}
+	if zs.LocalEndpoint.ServiceName != "beacon-chain" {
+		t.Errorf("Wanted service name beacon-chain, received %s", zs.LocalEndpoint.ServiceName)
+	}
+}
+
+func TestOTLPEncoder(t *testing.T) {
+	span := &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1},
+			SpanID:  trace.SpanID{2},
+		},
+		Name:      "validator.SubmitAttestation",
+		StartTime: time.Unix(1000, 0),
+		EndTime:   time.Unix(1001, 0),
+		Status:    trace.Status{Code: trace.StatusCodeInternal, Message: "failed"},
+	}
+	enc, err := otlpEncoder("beacon-chain", map[string]string{"region": "eu"})([]*trace.SpanData{span})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &otlpRequest{}
+	if err := json.Unmarshal(enc, req); err != nil {
+		t.Fatal(err)
+	}
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("Unexpected request layout %s", enc)
+	}
+	attrs := req.ResourceSpans[0].Resource.Attributes
+	if len(attrs) != 2 || *attrs[0].Value.StringValue != "beacon-chain" {
+		t.Errorf("Unexpected resource attributes %s", enc)
+	}
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 1 {
+		t.Fatalf("Wanted 1 span, received %d", len(spans))
+	}
+	if spans[0].StartTimeUnixNano != "1000000000000" || spans[0].Kind != 1 {
+		t.Errorf("Unexpected span %s", enc)
+	}
+	if spans[0].Status.Code != 2 || spans[0].Status.Message != "failed" {
+		t.Errorf("Wanted error status, received %+v", spans[0].Status)
+	}
+}
@@ -1,9 +1,11 @@
-// Package tracing sets up jaeger as an opentracing tool
-// for services in Prysm.
+// Package tracing sets up jaeger, zipkin or an OpenTelemetry collector
+// as an opentracing tool for services in Prysm.
 package tracing
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/prysmaticlabs/prysm/shared/version"
@@ -13,8 +15,10 @@ import (
 
 var log = logrus.WithField("prefix", "tracing")
 
-// Setup creates and initializes a new tracing configuration..
-func Setup(serviceName, processName, endpoint string, sampleFraction float64, enable bool) error {
+// Setup creates and initializes a new tracing configuration. Spans are sampled with the
+// given probability and sent to the endpoint using the named exporter, or to the exporter's
+// default endpoint if none is given. Tags are key=value pairs attached to every span.
+func Setup(serviceName, processName, exporterName, endpoint string, tags []string, sampleFraction float64, enable bool) error {
 	if !enable {
 		trace.ApplyConfig(trace.Config{DefaultSampler: trace.NeverSample()})
 		return nil
@@ -23,31 +27,69 @@ func Setup(serviceName, processName, endpoint string, sampleFraction float64, en
 	if serviceName == "" {
 		return errors.New("tracing service name cannot be empty")
 	}
+	if sampleFraction < 0 || sampleFraction > 1 {
+		return fmt.Errorf("tracing sample fraction must be between 0 and 1, received %f", sampleFraction)
+	}
+	if exporterName == "" {
+		exporterName = JaegerExporter
+	}
+	if endpoint == "" {
+		endpoint = defaultEndpoints[exporterName]
+	}
+	processTags, err := parseTags(tags)
+	if err != nil {
+		return err
+	}
+	processTags["process_name"] = processName
+	processTags["version"] = version.GetVersion()
 
 	trace.ApplyConfig(trace.Config{
 		DefaultSampler:          trace.ProbabilitySampler(sampleFraction),
 		MaxMessageEventsPerSpan: 500,
 	})
 
-	log.Infof("Starting Jaeger exporter endpoint at address = %s", endpoint)
-	exporter, err := jaeger.NewExporter(jaeger.Options{
-		CollectorEndpoint: endpoint,
-		Process: jaeger.Process{
-			ServiceName: serviceName,
-			Tags: []jaeger.Tag{
-				jaeger.StringTag("process_name", processName),
-				jaeger.StringTag("version", version.GetVersion()),
+	log.Infof("Starting %s exporter endpoint at address = %s", exporterName, endpoint)
+	switch exporterName {
+	case JaegerExporter:
+		jaegerTags := make([]jaeger.Tag, 0, len(processTags))
+		for k, v := range processTags {
+			jaegerTags = append(jaegerTags, jaeger.StringTag(k, v))
+		}
+		exporter, err := jaeger.NewExporter(jaeger.Options{
+			CollectorEndpoint: endpoint,
+			Process: jaeger.Process{
+				ServiceName: serviceName,
+				Tags:        jaegerTags,
 			},
-		},
-		BufferMaxCount: 10000,
-		OnError: func(err error) {
-			log.WithError(err).Error("Failed to process span")
-		},
-	})
-	if err != nil {
-		return err
+			BufferMaxCount: 10000,
+			OnError: func(err error) {
+				log.WithError(err).Error("Failed to process span")
+			},
+		})
+		if err != nil {
+			return err
+		}
+		trace.RegisterExporter(exporter)
+	case ZipkinExporter:
+		trace.RegisterExporter(newHTTPExporter(endpoint, zipkinEncoder(serviceName, processTags)))
+	case OTLPExporter:
+		trace.RegisterExporter(newHTTPExporter(endpoint, otlpEncoder(serviceName, processTags)))
+	default:
+		return fmt.Errorf("unknown tracing exporter %q, expected one of %s, %s or %s", exporterName, JaegerExporter, ZipkinExporter, OTLPExporter)
 	}
-	trace.RegisterExporter(exporter)
 
 	return nil
 }
+
+// parseTags parses key=value pairs into a map of process tags.
+func parseTags(tags []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		kv := strings.SplitN(tag, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("tracing tag %q is not in the form key=value", tag)
+		}
+		parsed[kv[0]] = kv[1]
+	}
+	return parsed, nil
+}
@@ -0,0 +1,140 @@
+package trieutil
+
+import (
+	"errors"
+	"fmt"
+
+	protodb "github.com/prysmaticlabs/prysm/proto/beacon/db"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+// Finalized returns the roots of the complete subtrees covering the first count leaves of
+// the trie, ordered from the largest to the smallest subtree. These are the only nodes
+// required to compute the trie root and to keep inserting leaves after the first count
+// leaves have been finalized, as described in EIP-4881.
+func (m *SparseMerkleTrie) Finalized(count uint64) ([][]byte, error) {
+	if count > uint64(len(m.originalItems)) {
+		return nil, fmt.Errorf("cannot finalize %d leaves of trie with %d leaves", count, len(m.originalItems))
+	}
+	finalized := make([][]byte, 0)
+	var offset uint64
+	for i := int(m.depth); i >= 0; i-- {
+		if count&(1<<uint(i)) == 0 {
+			continue
+		}
+		node := bytesutil.ToBytes32(m.branches[i][offset>>uint(i)])
+		finalized = append(finalized, node[:])
+		offset += 1 << uint(i)
+	}
+	return finalized, nil
+}
+
+// Snapshot returns the EIP-4881 deposit snapshot of the trie with its first count leaves
+// finalized.
+func (m *SparseMerkleTrie) Snapshot(count uint64) (*protodb.DepositSnapshot, error) {
+	finalized, err := m.Finalized(count)
+	if err != nil {
+		return nil, err
+	}
+	root := SnapshotRoot(finalized, count, m.depth)
+	return &protodb.DepositSnapshot{
+		Finalized:    finalized,
+		DepositRoot:  root[:],
+		DepositCount: count,
+	}, nil
+}
+
+// SnapshotRoot computes the deposit root, as defined in the deposit contract, of a trie
+// of the given depth containing count leaves from its finalized subtree roots.
+func SnapshotRoot(finalized [][]byte, count uint64, depth uint) [32]byte {
+	var zeroBytes [32]byte
+	node := rightEdge(finalized, count, depth)[depth]
+	newNode := append(node[:], bytesutil.Bytes8(count)...)
+	newNode = append(newNode, zeroBytes[:24]...)
+	return hashutil.Hash(newNode)
+}
+
+// TrieFromSnapshot reconstructs a trie from an EIP-4881 deposit snapshot. Merkle proofs
+// can only be generated for leaves inserted after the snapshot was taken, as the leaves
+// covered by the finalized subtrees have been pruned.
+func TrieFromSnapshot(snapshot *protodb.DepositSnapshot, depth int) (*SparseMerkleTrie, error) {
+	count := snapshot.DepositCount
+	if count == 0 {
+		return NewTrie(depth)
+	}
+	if count >= 1<<uint(depth) {
+		return nil, fmt.Errorf("deposit count %d exceeds the capacity of a trie of depth %d", count, depth)
+	}
+	if len(snapshot.Finalized) != bitCount(count) {
+		return nil, errors.New("number of finalized roots does not match deposit count")
+	}
+	root := SnapshotRoot(snapshot.Finalized, count, uint(depth))
+	if len(snapshot.DepositRoot) > 0 && root != bytesutil.ToBytes32(snapshot.DepositRoot) {
+		return nil, fmt.Errorf("snapshot deposit root %#x does not match computed root %#x", snapshot.DepositRoot, root)
+	}
+
+	// Pruned nodes are filled in with zero hashes, only the finalized subtree roots and
+	// the partially filled nodes on the right edge of the trie are kept.
+	edge := rightEdge(snapshot.Finalized, count, uint(depth))
+	layers := make([][][]byte, depth+1)
+	finalizedIdx := 0
+	for i := depth; i >= 0; i-- {
+		pos := count >> uint(i)
+		length := pos
+		if pos<<uint(i) < count {
+			length++
+		}
+		layer := make([][]byte, length)
+		for j := range layer {
+			layer[j] = ZeroHashes[0][:]
+		}
+		if pos&1 == 1 {
+			node := bytesutil.ToBytes32(snapshot.Finalized[finalizedIdx])
+			layer[pos-1] = node[:]
+			finalizedIdx++
+		}
+		if length > pos {
+			node := edge[i]
+			layer[pos] = node[:]
+		}
+		layers[i] = layer
+	}
+	// The last finalized leaf is kept if it is not part of a larger subtree, which keeps
+	// a trie with a single deposit distinguishable from an empty trie.
+	items := make([][]byte, count)
+	copy(items, layers[0])
+	return &SparseMerkleTrie{
+		branches:      layers,
+		originalItems: items,
+		depth:         uint(depth),
+	}, nil
+}
+
+// rightEdge computes the node at index count >> i for every layer i of the trie, which
+// are the nodes partially covering the finalized leaves.
+func rightEdge(finalized [][]byte, count uint64, depth uint) [][32]byte {
+	edge := make([][32]byte, depth+1)
+	node := ZeroHashes[0]
+	idx := len(finalized)
+	for i := uint(0); i < depth; i++ {
+		edge[i] = node
+		if (count>>i)&1 == 1 {
+			idx--
+			sibling := bytesutil.ToBytes32(finalized[idx])
+			node = hashutil.Hash(append(sibling[:], node[:]...))
+		} else {
+			node = hashutil.Hash(append(node[:], ZeroHashes[i][:]...))
+		}
+	}
+	edge[depth] = node
+	return edge
+}
+
+func bitCount(n uint64) int {
+	count := 0
+	for ; n > 0; n &= n - 1 {
+		count++
+	}
+	return count
+}
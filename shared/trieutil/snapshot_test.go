@@ -0,0 +1,105 @@
+package trieutil
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+)
+
+func snapshotTestItems(n int) [][]byte {
+	items := make([][]byte, n)
+	for i := range items {
+		h := hashutil.Hash([]byte(strconv.Itoa(i)))
+		items[i] = h[:]
+	}
+	return items
+}
+
+func TestSnapshot_RootMatchesTrie(t *testing.T) {
+	items := snapshotTestItems(37)
+	full, err := GenerateTrieFromItems(items, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, count := range []int{1, 2, 3, 16, 31, 37} {
+		partial, err := GenerateTrieFromItems(items[:count], 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		snapshot, err := full.Snapshot(uint64(count))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := partial.HashTreeRoot()
+		if !reflect.DeepEqual(snapshot.DepositRoot, want[:]) {
+			t.Errorf("Snapshot root of %d leaves %#x does not match trie root %#x", count, snapshot.DepositRoot, want)
+		}
+		if len(snapshot.Finalized) != bitCount(uint64(count)) {
+			t.Errorf("Expected %d finalized roots, received %d", bitCount(uint64(count)), len(snapshot.Finalized))
+		}
+	}
+	if _, err := full.Snapshot(38); err == nil {
+		t.Error("Expected error when finalizing more leaves than the trie holds")
+	}
+}
+
+func TestTrieFromSnapshot_InsertAfterRestore(t *testing.T) {
+	items := snapshotTestItems(45)
+	for _, count := range []int{1, 5, 8, 21} {
+		partial, err := GenerateTrieFromItems(items[:count], 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		snapshot, err := partial.Snapshot(uint64(count))
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored, err := TrieFromSnapshot(snapshot, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if restored.HashTreeRoot() != partial.HashTreeRoot() {
+			t.Fatalf("Restored trie root does not match for %d leaves", count)
+		}
+		for i := count; i < len(items); i++ {
+			partial.Insert(items[i], i)
+			restored.Insert(items[i], i)
+			if restored.HashTreeRoot() != partial.HashTreeRoot() {
+				t.Fatalf("Restored trie root does not match after inserting leaf %d", i)
+			}
+			want, err := partial.MerkleProof(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proof, err := restored.MerkleProof(i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(proof, want) {
+				t.Fatalf("Merkle proof of leaf %d does not match", i)
+			}
+		}
+	}
+}
+
+func TestTrieFromSnapshot_InvalidSnapshot(t *testing.T) {
+	items := snapshotTestItems(6)
+	trie, err := GenerateTrieFromItems(items, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := trie.Snapshot(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot.DepositRoot = make([]byte, 32)
+	if _, err := TrieFromSnapshot(snapshot, 32); err == nil {
+		t.Error("Expected error with a mismatching deposit root")
+	}
+	snapshot.Finalized = snapshot.Finalized[:1]
+	if _, err := TrieFromSnapshot(snapshot, 32); err == nil {
+		t.Error("Expected error with missing finalized roots")
+	}
+}
@@ -0,0 +1,104 @@
+// Package verifypool provides a pool of workers running signature verifications, bounding
+// the CPU used for cryptography by the callers sharing the pool.
+package verifypool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	queueDepthGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "signature_verification_queue_depth",
+		Help: "The number of signature verifications waiting for a worker.",
+	})
+	workersGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "signature_verification_workers",
+		Help: "The number of workers running signature verifications.",
+	})
+	verificationsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "signature_verifications_total",
+		Help: "The number of signature verifications run by the workers.",
+	})
+)
+
+// Pool runs signature verifications on a fixed number of workers. Verifications submitted
+// while every worker is busy wait for a worker to become available.
+type Pool struct {
+	jobs chan *job
+}
+
+type job struct {
+	verify func() error
+	result chan error
+}
+
+// New creates a pool of the given number of workers. A non positive number of workers
+// creates a worker per CPU.
+func New(workers int) *Pool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	p := &Pool{jobs: make(chan *job)}
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	workersGauge.Set(float64(workers))
+	return p
+}
+
+// Run runs the verification on a worker of the pool and returns its result. It returns the
+// error of the context if the context is done before the verification completes.
+func (p *Pool) Run(ctx context.Context, verify func() error) error {
+	j := &job{verify: verify, result: make(chan error, 1)}
+	queueDepthGauge.Inc()
+	select {
+	case p.jobs <- j:
+	case <-ctx.Done():
+		queueDepthGauge.Dec()
+		return ctx.Err()
+	}
+	select {
+	case err := <-j.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pool) work() {
+	for j := range p.jobs {
+		queueDepthGauge.Dec()
+		j.result <- j.verify()
+		verificationsCounter.Inc()
+	}
+}
+
+var (
+	defaultPool     *Pool
+	defaultPoolLock sync.Mutex
+)
+
+// Init sets the number of workers of the pool shared through Run. It must be called before
+// the shared pool is first used, as the workers of a previous pool are not stopped.
+func Init(workers int) {
+	defaultPoolLock.Lock()
+	defer defaultPoolLock.Unlock()
+	defaultPool = New(workers)
+}
+
+// Run runs the verification on a worker of the shared pool, which has a worker per CPU
+// unless configured otherwise with Init.
+func Run(ctx context.Context, verify func() error) error {
+	defaultPoolLock.Lock()
+	if defaultPool == nil {
+		defaultPool = New(0)
+	}
+	p := defaultPool
+	defaultPoolLock.Unlock()
+	return p.Run(ctx, verify)
+}
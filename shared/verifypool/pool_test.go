@@ -0,0 +1,76 @@
+package verifypool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPool_BoundsConcurrentVerifications(t *testing.T) {
+	p := New(2)
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := p.Run(context.Background(), func() error {
+				n := atomic.AddInt32(&running, 1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&running, -1)
+				return nil
+			}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxRunning != 2 {
+		t.Errorf("Expected 2 verifications to run at once, received %d", maxRunning)
+	}
+}
+
+func TestPool_ReturnsVerificationError(t *testing.T) {
+	p := New(1)
+	wanted := errors.New("invalid signature")
+	if err := p.Run(context.Background(), func() error { return wanted }); err != wanted {
+		t.Errorf("Expected %v, received %v", wanted, err)
+	}
+}
+
+func TestPool_ContextDoneWhileQueued(t *testing.T) {
+	p := New(1)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	go func() {
+		_ = p.Run(context.Background(), func() error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	called := false
+	if err := p.Run(ctx, func() error {
+		called = true
+		return nil
+	}); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, received %v", err)
+	}
+	if called {
+		t.Error("Expected queued verification not to run")
+	}
+}
@@ -0,0 +1,17 @@
+package version
+
+import (
+	"runtime"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var versionGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "prysm_version",
+	Help: "Always 1, labeled with the git commit, build date and Go version of the running binary",
+}, []string{"commit", "build_date", "go_version"})
+
+func init() {
+	versionGauge.WithLabelValues(GitCommit(), BuildDate(), runtime.Version()).Set(1)
+}
@@ -16,6 +16,11 @@ var buildDate = "Moments ago"
 
 // GetVersion returns the version string of this build.
 func GetVersion() string {
+	return fmt.Sprintf("Prysm/Git commit: %s. Built at: %s", GitCommit(), BuildDate())
+}
+
+// GitCommit returns the git commit this build was made from.
+func GitCommit() string {
 	// if doing a local build, these values are not interpolated
 	if gitCommit == "{STABLE_GIT_COMMIT}" {
 		commit, err := exec.Command("git", "rev-parse", "HEAD").Output()
@@ -25,9 +30,14 @@ func GetVersion() string {
 			gitCommit = strings.TrimRight(string(commit), "\r\n")
 		}
 	}
+	return gitCommit
+}
+
+// BuildDate returns the date at which this build was made.
+func BuildDate() string {
 	if buildDate == "{DATE}" {
 		now := time.Now().Format(time.RFC3339)
 		buildDate = now
 	}
-	return fmt.Sprintf("Prysm/Git commit: %s. Built at: %s", gitCommit, buildDate)
+	return buildDate
 }
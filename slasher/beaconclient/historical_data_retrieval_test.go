@@ -87,3 +87,61 @@ func TestService_RequestHistoricalAttestations(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Retrieved 500/1000 indexed attestations for epoch 0")
 	testutil.AssertLogsContain(t, hook, "Retrieved 1000/1000 indexed attestations for epoch 0")
 }
+
+func TestService_RequestHistoricalBlocks(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+
+	bs := Service{
+		beaconClient: client,
+	}
+
+	numBlocks := 64
+	wanted := make([]*ethpb.SignedBeaconBlock, numBlocks)
+	containers := make([]*ethpb.BeaconBlockContainer, numBlocks)
+	for i := 0; i < numBlocks; i++ {
+		wanted[i] = &ethpb.SignedBeaconBlock{
+			Block: &ethpb.BeaconBlock{
+				Slot:          uint64(i),
+				ProposerIndex: uint64(i),
+			},
+		}
+		containers[i] = &ethpb.BeaconBlockContainer{Block: wanted[i]}
+	}
+
+	// We override the page size in the requests to 32 so we will
+	// obtain 2 pages of blocks from the server.
+	perPage := 32
+	cfg := params.BeaconConfig()
+	cfg.DefaultPageSize = perPage
+	params.OverrideBeaconConfig(cfg)
+
+	client.EXPECT().ListBlocks(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: containers[:perPage],
+		NextPageToken:   "1",
+		TotalSize:       int32(numBlocks),
+	}, nil)
+	client.EXPECT().ListBlocks(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: containers[perPage:],
+		NextPageToken:   "",
+		TotalSize:       int32(numBlocks),
+	}, nil)
+
+	res, err := bs.RequestHistoricalBlocks(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, wanted) {
+		t.Errorf("Wanted %v, received %v", wanted, res)
+	}
+	testutil.AssertLogsContain(t, hook, "Retrieved 32/64 blocks for epoch 1")
+	testutil.AssertLogsContain(t, hook, "Retrieved 64/64 blocks for epoch 1")
+}
@@ -10,4 +10,20 @@ var (
 		Name: "slasher_attestations_received_total",
 		Help: "The # of attestations received by slasher",
 	})
+	slasherAttestationsBacklog = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "slasher_attestations_backlog",
+		Help: "The # of received attestations waiting to be saved and sent to detection",
+	})
+	slashingsSubmitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slasher_slashings_submitted_total",
+		Help: "The # of slashings accepted by a beacon node",
+	}, []string{"type"})
+	slashingSubmissionFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slasher_slashing_submission_failures_total",
+		Help: "The # of slashings which could not be submitted to a beacon node after all retries",
+	}, []string{"type"})
+	slashingsDeduplicated = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slasher_slashings_deduplicated_total",
+		Help: "The # of detected slashings skipped because they were already submitted",
+	}, []string{"type"})
 )
@@ -8,6 +8,7 @@ import (
 
 	ptypes "github.com/gogo/protobuf/types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/grpcutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
@@ -42,8 +43,8 @@ func (bs *Service) receiveBlocks(ctx context.Context) {
 		}
 		if err != nil {
 			if e, ok := status.FromError(err); ok {
-				switch e.Code() {
-				case codes.Canceled:
+				switch {
+				case e.Code() == codes.Canceled || grpcutil.IsRetryable(err):
 					stream, err = bs.restartBlockStream(ctx)
 					if err != nil {
 						log.WithError(err).Error("Could not restart stream")
@@ -94,8 +95,8 @@ func (bs *Service) receiveAttestations(ctx context.Context) {
 		}
 		if err != nil {
 			if e, ok := status.FromError(err); ok {
-				switch e.Code() {
-				case codes.Canceled:
+				switch {
+				case e.Code() == codes.Canceled || grpcutil.IsRetryable(err):
 					stream, err = bs.restartIndexedAttestationStream(ctx)
 					if err != nil {
 						log.WithError(err).Error("Could not restart stream")
@@ -130,9 +131,11 @@ func (bs *Service) collectReceivedAttestations(ctx context.Context) {
 			if len(atts) > 0 {
 				bs.collectedAttestationsBuffer <- atts
 				atts = []*ethpb.IndexedAttestation{}
+				slasherAttestationsBacklog.Set(0)
 			}
 		case att := <-bs.receivedAttestationsBuffer:
 			atts = append(atts, att)
+			slasherAttestationsBacklog.Set(float64(len(atts)))
 		case collectedAtts := <-bs.collectedAttestationsBuffer:
 			if err := bs.slasherDB.SaveIndexedAttestations(ctx, collectedAtts); err != nil {
 				log.WithError(err).Error("Could not save indexed attestation")
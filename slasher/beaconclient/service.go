@@ -11,9 +11,11 @@ import (
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/grpcutil"
 	"github.com/prysmaticlabs/prysm/slasher/cache"
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/sirupsen/logrus"
@@ -24,6 +26,10 @@ import (
 
 var log = logrus.WithField("prefix", "beaconclient")
 
+// submittedSlashingsCacheSize is the number of submitted slashing roots kept
+// to avoid submitting the same slashing twice.
+const submittedSlashingsCacheSize = 4096
+
 // Notifier defines a struct which exposes event feeds regarding beacon blocks,
 // attestations, and more information received from a beacon node.
 type Notifier interface {
@@ -58,6 +64,10 @@ type Service struct {
 	receivedAttestationsBuffer  chan *ethpb.IndexedAttestation
 	collectedAttestationsBuffer chan []*ethpb.IndexedAttestation
 	publicKeyCache              *cache.PublicKeyCache
+	submissionEndpoints         []string
+	submissionConns             []*grpc.ClientConn
+	submissionClients           map[string]ethpb.BeaconChainClient
+	submittedSlashings          *lru.Cache
 }
 
 // Config options for the beaconclient service.
@@ -67,6 +77,8 @@ type Config struct {
 	SlasherDB             db.Database
 	ProposerSlashingsFeed *event.Feed
 	AttesterSlashingsFeed *event.Feed
+	// SubmissionEndpoints are additional beacon nodes detected slashings are submitted to.
+	SubmissionEndpoints []string
 }
 
 // NewBeaconClientService instantiation.
@@ -76,6 +88,10 @@ func NewBeaconClientService(ctx context.Context, cfg *Config) (*Service, error)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create new cache")
 	}
+	submittedSlashings, err := lru.New(submittedSlashingsCacheSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create submitted slashings cache")
+	}
 
 	return &Service{
 		cert:                        cfg.BeaconCert,
@@ -93,6 +109,8 @@ func NewBeaconClientService(ctx context.Context, cfg *Config) (*Service, error)
 		receivedAttestationsBuffer:  make(chan *ethpb.IndexedAttestation, 1),
 		collectedAttestationsBuffer: make(chan []*ethpb.IndexedAttestation, 1),
 		publicKeyCache:              publicKeyCache,
+		submissionEndpoints:         cfg.SubmissionEndpoints,
+		submittedSlashings:          submittedSlashings,
 	}, nil
 }
 
@@ -118,6 +136,11 @@ func (bs *Service) ClientReadyFeed() *event.Feed {
 func (bs *Service) Stop() error {
 	bs.cancel()
 	log.Info("Stopping service")
+	for _, conn := range bs.submissionConns {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).Error("Could not close slashing submission connection")
+		}
+	}
 	if bs.conn != nil {
 		return bs.conn.Close()
 	}
@@ -157,10 +180,12 @@ func (bs *Service) Start() {
 		grpc.WithStreamInterceptor(middleware.ChainStreamClient(
 			grpc_opentracing.StreamClientInterceptor(),
 			grpc_prometheus.StreamClientInterceptor,
+			grpcutil.StreamClientInterceptor(grpcutil.DefaultConfig()),
 		)),
 		grpc.WithUnaryInterceptor(middleware.ChainUnaryClient(
 			grpc_opentracing.UnaryClientInterceptor(),
 			grpc_prometheus.UnaryClientInterceptor,
+			grpcutil.UnaryClientInterceptor(grpcutil.DefaultConfig()),
 		)),
 	}
 	conn, err := grpc.DialContext(bs.ctx, bs.provider, beaconOpts...)
@@ -172,6 +197,22 @@ func (bs *Service) Start() {
 	bs.beaconClient = ethpb.NewBeaconChainClient(bs.conn)
 	bs.nodeClient = ethpb.NewNodeClient(bs.conn)
 
+	// Detected slashings are also submitted to any additional beacon nodes,
+	// so they are included and broadcast even if the main beacon node is down.
+	bs.submissionClients = make(map[string]ethpb.BeaconChainClient, len(bs.submissionEndpoints))
+	for _, endpoint := range bs.submissionEndpoints {
+		if endpoint == bs.provider {
+			continue
+		}
+		submissionConn, err := grpc.DialContext(bs.ctx, endpoint, beaconOpts...)
+		if err != nil {
+			log.WithError(err).Errorf("Could not dial slashing submission endpoint: %s", endpoint)
+			continue
+		}
+		bs.submissionConns = append(bs.submissionConns, submissionConn)
+		bs.submissionClients[endpoint] = ethpb.NewBeaconChainClient(submissionConn)
+	}
+
 	// We poll for the sync status of the beacon node until it is fully synced.
 	bs.querySyncStatus(bs.ctx)
 
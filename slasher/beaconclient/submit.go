@@ -2,15 +2,27 @@ package beaconclient
 
 import (
 	"context"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
+var (
+	// maxSubmissionAttempts is the number of times a slashing is submitted
+	// to a single beacon node before giving up on that node.
+	maxSubmissionAttempts = 3
+	// submissionRetryDelay is the time waited between two submission attempts.
+	submissionRetryDelay = time.Second
+)
+
 // subscribeDetectedProposerSlashings subscribes to an event feed for
 // slashing objects from the slasher runtime. Upon receiving
 // a proposer slashing from the feed, we submit the object to the
-// connected beacon node via a client RPC.
+// connected beacon nodes via a client RPC.
 func (bs *Service) subscribeDetectedProposerSlashings(ctx context.Context, ch chan *ethpb.ProposerSlashing) {
 	ctx, span := trace.StartSpan(ctx, "beaconclient.submitProposerSlashing")
 	defer span.End()
@@ -19,9 +31,10 @@ func (bs *Service) subscribeDetectedProposerSlashings(ctx context.Context, ch ch
 	for {
 		select {
 		case slashing := <-ch:
-			if _, err := bs.beaconClient.SubmitProposerSlashing(ctx, slashing); err != nil {
-				log.Error(err)
-			}
+			bs.submitSlashing(ctx, "proposer", slashing, func(client ethpb.BeaconChainClient) error {
+				_, err := client.SubmitProposerSlashing(ctx, slashing)
+				return err
+			})
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
 			return
@@ -35,7 +48,7 @@ func (bs *Service) subscribeDetectedProposerSlashings(ctx context.Context, ch ch
 // subscribeDetectedAttesterSlashings subscribes to an event feed for
 // slashing objects from the slasher runtime. Upon receiving an
 // attester slashing from the feed, we submit the object to the
-// connected beacon node via a client RPC.
+// connected beacon nodes via a client RPC.
 func (bs *Service) subscribeDetectedAttesterSlashings(ctx context.Context, ch chan *ethpb.AttesterSlashing) {
 	ctx, span := trace.StartSpan(ctx, "beaconclient.submitAttesterSlashing")
 	defer span.End()
@@ -44,9 +57,10 @@ func (bs *Service) subscribeDetectedAttesterSlashings(ctx context.Context, ch ch
 	for {
 		select {
 		case slashing := <-ch:
-			if _, err := bs.beaconClient.SubmitAttesterSlashing(ctx, slashing); err != nil {
-				log.Error(err)
-			}
+			bs.submitSlashing(ctx, "attester", slashing, func(client ethpb.BeaconChainClient) error {
+				_, err := client.SubmitAttesterSlashing(ctx, slashing)
+				return err
+			})
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
 			return
@@ -56,3 +70,71 @@ func (bs *Service) subscribeDetectedAttesterSlashings(ctx context.Context, ch ch
 		}
 	}
 }
+
+// submitSlashing submits a slashing to every configured beacon node, retrying
+// failed submissions. Slashings which were already accepted by a beacon node
+// are not submitted again.
+func (bs *Service) submitSlashing(
+	ctx context.Context,
+	kind string,
+	slashing proto.Message,
+	submit func(client ethpb.BeaconChainClient) error,
+) {
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		log.WithError(err).Errorf("Could not hash %s slashing", kind)
+		return
+	}
+	if bs.submittedSlashings != nil && bs.submittedSlashings.Contains(root) {
+		log.Debugf("Skipping already submitted %s slashing", kind)
+		slashingsDeduplicated.WithLabelValues(kind).Inc()
+		return
+	}
+
+	submitted := false
+	for endpoint, client := range bs.slashingClients() {
+		if err := submitWithRetries(ctx, func() error { return submit(client) }); err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"endpoint": endpoint,
+				"type":     kind,
+			}).Error("Could not submit slashing to beacon node")
+			slashingSubmissionFailures.WithLabelValues(kind).Inc()
+			continue
+		}
+		submitted = true
+		slashingsSubmitted.WithLabelValues(kind).Inc()
+	}
+	if submitted && bs.submittedSlashings != nil {
+		bs.submittedSlashings.Add(root, true)
+	}
+}
+
+// slashingClients returns the beacon node clients slashings are submitted to,
+// keyed by their endpoint.
+func (bs *Service) slashingClients() map[string]ethpb.BeaconChainClient {
+	clients := make(map[string]ethpb.BeaconChainClient, len(bs.submissionClients)+1)
+	for endpoint, client := range bs.submissionClients {
+		clients[endpoint] = client
+	}
+	clients[bs.provider] = bs.beaconClient
+	return clients
+}
+
+// submitWithRetries calls submit until it succeeds, up to maxSubmissionAttempts times.
+func submitWithRetries(ctx context.Context, submit func() error) error {
+	var err error
+	for attempt := 1; attempt <= maxSubmissionAttempts; attempt++ {
+		if err = submit(); err == nil {
+			return nil
+		}
+		if attempt == maxSubmissionAttempts {
+			break
+		}
+		select {
+		case <-time.After(submissionRetryDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
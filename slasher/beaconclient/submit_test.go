@@ -2,9 +2,12 @@ package beaconclient
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	lru "github.com/hashicorp/golang-lru"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/mock"
@@ -89,3 +92,45 @@ func TestService_SubscribeDetectedAttesterSlashings(t *testing.T) {
 	exitRoutine <- true
 	testutil.AssertLogsContain(t, hook, "Context canceled")
 }
+
+func TestService_SubmitSlashing_RetriesAndDeduplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	secondClient := mock.NewMockBeaconChainClient(ctrl)
+	submittedSlashings, err := lru.New(submittedSlashingsCacheSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(delay time.Duration) {
+		submissionRetryDelay = delay
+	}(submissionRetryDelay)
+	submissionRetryDelay = 0
+
+	bs := Service{
+		provider:           "localhost:4000",
+		beaconClient:       client,
+		submissionClients:  map[string]ethpb.BeaconChainClient{"localhost:4001": secondClient},
+		submittedSlashings: submittedSlashings,
+	}
+	slashing := &ethpb.AttesterSlashing{
+		Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2, 3}},
+		Attestation_2: &ethpb.IndexedAttestation{AttestingIndices: []uint64{3, 4, 5}},
+	}
+	submit := func(c ethpb.BeaconChainClient) error {
+		_, err := c.SubmitAttesterSlashing(context.Background(), slashing)
+		return err
+	}
+
+	// The first beacon node fails once before accepting the slashing, the
+	// second one keeps failing until the submission is given up.
+	gomock.InOrder(
+		client.EXPECT().SubmitAttesterSlashing(gomock.Any(), slashing).Return(nil, errors.New("unavailable")),
+		client.EXPECT().SubmitAttesterSlashing(gomock.Any(), slashing).Return(&ethpb.SubmitSlashingResponse{}, nil),
+	)
+	secondClient.EXPECT().SubmitAttesterSlashing(gomock.Any(), slashing).Return(nil, errors.New("unavailable")).Times(maxSubmissionAttempts)
+	bs.submitSlashing(context.Background(), "attester", slashing, submit)
+
+	// A slashing which was accepted before is not submitted again.
+	bs.submitSlashing(context.Background(), "attester", slashing, submit)
+}
@@ -58,3 +58,31 @@ func (bs *Service) FindOrGetPublicKeys(
 	)
 	return validators, nil
 }
+
+// FindValidatorIndices requests the indices of the validators with the given public
+// keys from a beacon node via gRPC. Public keys unknown to the beacon node, such as
+// those of validators whose deposit was not processed yet, are left out of the result.
+func (bs *Service) FindValidatorIndices(
+	ctx context.Context,
+	publicKeys [][]byte,
+) (map[uint64][]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "beaconclient.FindValidatorIndices")
+	defer span.End()
+
+	validators := make(map[uint64][]byte, len(publicKeys))
+	req := &ethpb.ListValidatorsRequest{PublicKeys: publicKeys}
+	for {
+		vc, err := bs.beaconClient.ListValidators(ctx, req)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not request validators by public key")
+		}
+		for _, v := range vc.ValidatorList {
+			validators[v.Index] = v.Validator.PublicKey
+			bs.publicKeyCache.Set(v.Index, v.Validator.PublicKey)
+		}
+		if vc.NextPageToken == "" || len(vc.ValidatorList) == 0 {
+			return validators, nil
+		}
+		req.PageToken = vc.NextPageToken
+	}
+}
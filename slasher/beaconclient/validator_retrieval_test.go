@@ -81,3 +81,49 @@ func TestService_RequestValidator(t *testing.T) {
 	}
 	testutil.AssertLogsContain(t, hook, "Retrieved validators public keys from cache: map[0:[1 2 3]]")
 }
+
+func TestService_FindValidatorIndices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	validatorCache, err := cache.NewPublicKeyCache(0, nil)
+	if err != nil {
+		t.Fatalf("could not create new cache: %v", err)
+	}
+	bs := Service{
+		beaconClient:   client,
+		publicKeyCache: validatorCache,
+	}
+	client.EXPECT().ListValidators(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&ethpb.Validators{
+		ValidatorList: []*ethpb.Validators_ValidatorContainer{
+			{
+				Index: 4, Validator: &ethpb.Validator{PublicKey: []byte{1, 2, 3}},
+			},
+		},
+		NextPageToken: "1",
+	}, nil)
+	client.EXPECT().ListValidators(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&ethpb.Validators{
+		ValidatorList: []*ethpb.Validators_ValidatorContainer{
+			{
+				Index: 7, Validator: &ethpb.Validator{PublicKey: []byte{2, 4, 5}},
+			},
+		},
+	}, nil)
+
+	res, err := bs.FindValidatorIndices(context.Background(), [][]byte{{1, 2, 3}, {2, 4, 5}, {9, 9, 9}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 || !bytes.Equal(res[4], []byte{1, 2, 3}) || !bytes.Equal(res[7], []byte{2, 4, 5}) {
+		t.Errorf("Unexpected validator indices %v", res)
+	}
+	if pub, ok := validatorCache.Get(7); !ok || !bytes.Equal(pub, []byte{2, 4, 5}) {
+		t.Error("Expected public key of validator 7 to be cached")
+	}
+}
@@ -71,6 +71,7 @@ type WriteAccessDatabase interface {
 	SaveEpochsSpanByValidatorsIndices(ctx context.Context, epochsSpans map[uint64]map[uint64]detectionTypes.Span) error
 	DeleteEpochSpans(ctx context.Context, validatorIdx uint64) error
 	DeleteValidatorSpanByEpoch(ctx context.Context, validatorIdx uint64, epoch uint64) error
+	PruneSpans(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error
 
 	// ProposerSlashing related methods.
 	DeleteProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error
@@ -83,6 +84,9 @@ type WriteAccessDatabase interface {
 
 	// Chain data related methods.
 	SaveChainHead(ctx context.Context, head *ethpb.ChainHead) error
+
+	// Pruning related methods.
+	PruneHistory(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error
 }
 
 // FullAccessDatabase represents a full access database with only DB interaction functions.
@@ -123,8 +123,18 @@ func (db *Store) PruneBlockHistory(ctx context.Context, currentEpoch uint64, pru
 	pruneTillSlot := uint64(pruneTill) * params.BeaconConfig().SlotsPerEpoch
 	return db.update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(historicBlockHeadersBucket)
-		c := tx.Bucket(historicBlockHeadersBucket).Cursor()
-		for k, _ := c.First(); k != nil && bytesutil.FromBytes8(k[:8]) <= pruneTillSlot; k, _ = c.Next() {
+		// Keys are prefixed with the little endian slot, so they are not
+		// sorted by slot and the whole bucket has to be scanned.
+		var keys [][]byte
+		if err := bucket.ForEach(func(k, _ []byte) error {
+			if bytesutil.FromBytes8(k[:8]) < pruneTillSlot {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
 			if err := bucket.Delete(k); err != nil {
 				return errors.Wrap(err, "failed to delete the block header from historical bucket")
 			}
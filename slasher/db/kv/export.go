@@ -0,0 +1,114 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// historyRecord is a single entry of an exported slasher history. Exactly one
+// of its fields is set. The history is written as a stream of JSON encoded
+// records, so it can be exported and imported without holding it in memory.
+type historyRecord struct {
+	ChainHead          *ethpb.ChainHead          `json:"chain_head,omitempty"`
+	EpochSpans         *epochSpans               `json:"epoch_spans,omitempty"`
+	IndexedAttestation *ethpb.IndexedAttestation `json:"indexed_attestation,omitempty"`
+}
+
+// epochSpans holds the min-max spans of all validators for an epoch.
+type epochSpans struct {
+	Epoch uint64                `json:"epoch"`
+	Spans map[uint64]types.Span `json:"spans"`
+}
+
+// ExportHistory writes the chain head, the min-max spans and the indexed attestations
+// stored in the DB to the writer, in a format which can be read by ImportHistory.
+func (db *Store) ExportHistory(ctx context.Context, w io.Writer) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.ExportHistory")
+	defer span.End()
+	if err := db.SaveCachedSpansMaps(ctx); err != nil {
+		return errors.Wrap(err, "could not persist cached span maps")
+	}
+	head, err := db.ChainHead(ctx)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	if head != nil {
+		if err := enc.Encode(&historyRecord{ChainHead: head}); err != nil {
+			return errors.Wrap(err, "could not write chain head")
+		}
+	}
+	return db.view(func(tx *bolt.Tx) error {
+		spansBucket := tx.Bucket(validatorsMinMaxSpanBucket)
+		if err := spansBucket.ForEach(func(k, _ []byte) error {
+			epochBucket := spansBucket.Bucket(k)
+			if epochBucket == nil {
+				return nil
+			}
+			spans := make(map[uint64]types.Span, epochBucket.Stats().KeyN)
+			if err := epochBucket.ForEach(func(idx, v []byte) error {
+				value, err := unmarshalSpan(ctx, v)
+				if err != nil {
+					return err
+				}
+				spans[bytesutil.FromBytes8(idx)] = value
+				return nil
+			}); err != nil {
+				return err
+			}
+			record := &historyRecord{EpochSpans: &epochSpans{Epoch: bytesutil.FromBytes8(k), Spans: spans}}
+			return errors.Wrap(enc.Encode(record), "could not write epoch spans")
+		}); err != nil {
+			return err
+		}
+		return tx.Bucket(historicIndexedAttestationsBucket).ForEach(func(_, v []byte) error {
+			att, err := unmarshalIndexedAttestation(ctx, v)
+			if err != nil {
+				return err
+			}
+			return errors.Wrap(enc.Encode(&historyRecord{IndexedAttestation: att}), "could not write indexed attestation")
+		})
+	})
+}
+
+// ImportHistory reads a history written by ExportHistory from the reader and saves
+// it into the DB. Importing is meant for a fresh DB, existing entries for the same
+// epochs and attestations are overwritten.
+func (db *Store) ImportHistory(ctx context.Context, r io.Reader) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.ImportHistory")
+	defer span.End()
+	dec := json.NewDecoder(r)
+	for {
+		record := &historyRecord{}
+		if err := dec.Decode(record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Wrap(err, "could not read history record")
+		}
+		switch {
+		case record.ChainHead != nil:
+			if err := db.SaveChainHead(ctx, record.ChainHead); err != nil {
+				return err
+			}
+		case record.EpochSpans != nil:
+			spans := map[uint64]map[uint64]types.Span{record.EpochSpans.Epoch: record.EpochSpans.Spans}
+			if err := db.SaveEpochsSpanByValidatorsIndices(ctx, spans); err != nil {
+				return err
+			}
+		case record.IndexedAttestation != nil:
+			if err := db.SaveIndexedAttestation(ctx, record.IndexedAttestation); err != nil {
+				return err
+			}
+		default:
+			return errors.New("empty history record")
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func TestStore_ExportImportHistory(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	head := &ethpb.ChainHead{HeadEpoch: 300}
+	if err := db.SaveChainHead(ctx, head); err != nil {
+		t.Fatal(err)
+	}
+	spans := map[uint64]map[uint64]types.Span{
+		1:   {1: {MinSpan: 2, MaxSpan: 3, SigBytes: [2]byte{4, 5}, HasAttested: true}},
+		256: {1: {MinSpan: 6}, 7: {MaxSpan: 8}},
+	}
+	for epoch, spanMap := range spans {
+		if err := db.SaveEpochSpansMap(ctx, epoch, spanMap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	att := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 7},
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: []byte("root"),
+			Source:          &ethpb.Checkpoint{Epoch: 255},
+			Target:          &ethpb.Checkpoint{Epoch: 256},
+		},
+		Signature: []byte{1, 2},
+	}
+	if err := db.SaveIndexedAttestation(ctx, att); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := db.ExportHistory(ctx, buf); err != nil {
+		t.Fatalf("Failed to export history: %v", err)
+	}
+
+	newDB := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, newDB)
+	if err := newDB.ImportHistory(ctx, buf); err != nil {
+		t.Fatalf("Failed to import history: %v", err)
+	}
+
+	importedHead, err := newDB.ChainHead(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(importedHead, head) {
+		t.Errorf("Wanted chain head %v, received %v", head, importedHead)
+	}
+	for epoch, spanMap := range spans {
+		imported, _, err := newDB.EpochSpansMap(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(imported, spanMap) {
+			t.Errorf("Epoch %d: wanted spans %v, received %v", epoch, spanMap, imported)
+		}
+	}
+	atts, err := newDB.IndexedAttestationsForTarget(ctx, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(atts) != 1 || !proto.Equal(atts[0], att) {
+		t.Errorf("Wanted indexed attestation %v, received %v", att, atts)
+	}
+}
@@ -172,9 +172,18 @@ func (db *Store) PruneAttHistory(ctx context.Context, currentEpoch uint64, pruni
 
 	return db.update(func(tx *bolt.Tx) error {
 		attBucket := tx.Bucket(historicIndexedAttestationsBucket)
-		c := tx.Bucket(historicIndexedAttestationsBucket).Cursor()
-		max := bytesutil.Bytes8(uint64(pruneFromEpoch))
-		for k, _ := c.First(); k != nil && bytes.Compare(k[:8], max) <= 0; k, _ = c.Next() {
+		// Keys are prefixed with the little endian target epoch, so they are not
+		// sorted by epoch and the whole bucket has to be scanned.
+		var keys [][]byte
+		if err := attBucket.ForEach(func(k, _ []byte) error {
+			if bytesutil.FromBytes8(k[:8]) <= uint64(pruneFromEpoch) {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
 			if err := attBucket.Delete(k); err != nil {
 				return errors.Wrap(err, "failed to delete indexed attestation from historical bucket")
 			}
@@ -0,0 +1,25 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+)
+
+// PruneHistory removes all span maps, indexed attestations and block headers
+// older than the pruning epoch age, which keeps the size of the slasher DB bounded.
+func (db *Store) PruneHistory(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.PruneHistory")
+	defer span.End()
+	if err := db.PruneSpans(ctx, currentEpoch, pruningEpochAge); err != nil {
+		return errors.Wrap(err, "could not prune span maps")
+	}
+	if err := db.PruneAttHistory(ctx, currentEpoch, pruningEpochAge); err != nil {
+		return errors.Wrap(err, "could not prune indexed attestations")
+	}
+	if err := db.PruneBlockHistory(ctx, currentEpoch, pruningEpochAge); err != nil {
+		return errors.Wrap(err, "could not prune block headers")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package kv
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func TestStore_PruneHistory(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	// Epochs above 255 make sure pruning does not rely on the key order of the
+	// little endian encoded epochs and slots.
+	epochs := []uint64{1, 255, 256, 301, 511, 512}
+	for _, epoch := range epochs {
+		if err := db.SaveEpochSpansMap(ctx, epoch, map[uint64]types.Span{1: {MinSpan: 1}}); err != nil {
+			t.Fatal(err)
+		}
+		att := &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: epoch - 1},
+				Target: &ethpb.Checkpoint{Epoch: epoch},
+			},
+			Signature: []byte{1, 2},
+		}
+		if err := db.SaveIndexedAttestation(ctx, att); err != nil {
+			t.Fatal(err)
+		}
+		header := &ethpb.SignedBeaconBlockHeader{
+			Header:    &ethpb.BeaconBlockHeader{Slot: epoch*params.BeaconConfig().SlotsPerEpoch + 1, ProposerIndex: 1},
+			Signature: []byte{1, 2},
+		}
+		if err := db.SaveBlockHeader(ctx, header); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	currentEpoch := uint64(512)
+	historyToKeep := uint64(212)
+	if err := db.PruneHistory(ctx, currentEpoch, historyToKeep); err != nil {
+		t.Fatalf("Failed to prune history: %v", err)
+	}
+
+	for _, epoch := range epochs {
+		pruned := epoch < currentEpoch-historyToKeep
+		spanMap, _, err := db.EpochSpansMap(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pruned == (len(spanMap) > 0) {
+			t.Errorf("Epoch %d: expected span map pruned %v, received %v", epoch, pruned, spanMap)
+		}
+		atts, err := db.IndexedAttestationsForTarget(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pruned == (len(atts) > 0) {
+			t.Errorf("Epoch %d: expected indexed attestations pruned %v, received %d", epoch, pruned, len(atts))
+		}
+		headers, err := db.BlockHeaders(ctx, epoch*params.BeaconConfig().SlotsPerEpoch+1, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pruned == (len(headers) > 0) {
+			t.Errorf("Epoch %d: expected block headers pruned %v, received %d", epoch, pruned, len(headers))
+		}
+	}
+}
@@ -355,6 +355,44 @@ func (db *Store) DeleteEpochSpans(ctx context.Context, epoch uint64) error {
 	})
 }
 
+// PruneSpans removes the span maps of all epochs older than the pruning epoch age
+// from the cache and the DB.
+func (db *Store) PruneSpans(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.PruneSpans")
+	defer span.End()
+	pruneTill := int64(currentEpoch) - int64(pruningEpochAge)
+	if pruneTill <= 0 {
+		return nil
+	}
+	if db.spanCacheEnabled {
+		// Removing an epoch from the cache persists it, so the cache
+		// is pruned before the epochs are removed from the DB.
+		for _, epoch := range db.spanCache.Epochs() {
+			if epoch < uint64(pruneTill) {
+				_ = db.spanCache.Delete(epoch)
+			}
+		}
+	}
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(validatorsMinMaxSpanBucket)
+		var keys [][]byte
+		if err := bucket.ForEach(func(k, _ []byte) error {
+			if bytesutil.FromBytes8(k) < uint64(pruneTill) {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := bucket.DeleteBucket(k); err != nil {
+				return errors.Wrap(err, "failed to delete span map from min-max span bucket")
+			}
+		}
+		return nil
+	})
+}
+
 // DeleteValidatorSpanByEpoch deletes a validator span for a certain epoch
 // deletes spans from cache if caching is enabled.
 // using a validator index as bucket key.
@@ -0,0 +1,122 @@
+package attestations
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"go.opencensus.io/trace"
+)
+
+// Batch returns a span detector for processing a batch of attestations. Its span
+// updates are kept in memory, where they are visible to the detection of the
+// following attestations of the batch, and are written to the DB by Commit in a
+// single transaction instead of one transaction per attestation.
+func (s *SpanDetector) Batch() iface.BatchSpanDetector {
+	return &SpanDetector{
+		slasherDB:    s.slasherDB,
+		pendingSpans: make(map[uint64]map[uint64]types.Span),
+	}
+}
+
+// Commit writes the span updates of a batch. Epochs which are held in the span
+// cache are updated in the cache, all other epochs are written to the DB at once.
+func (s *SpanDetector) Commit(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "spanner.Commit")
+	defer span.End()
+	if s.pendingSpans == nil {
+		return nil
+	}
+	toDB := make(map[uint64]map[uint64]types.Span)
+	for epoch, pending := range s.pendingSpans {
+		spanMap, fromCache, err := s.slasherDB.EpochSpansMap(ctx, epoch)
+		if err != nil {
+			return err
+		}
+		if !fromCache {
+			toDB[epoch] = pending
+			continue
+		}
+		for idx, sp := range pending {
+			spanMap[idx] = sp
+		}
+		if err := s.slasherDB.SaveEpochSpansMap(ctx, epoch, spanMap); err != nil {
+			return err
+		}
+	}
+	if len(toDB) > 0 {
+		if err := s.slasherDB.SaveEpochsSpanByValidatorsIndices(ctx, toDB); err != nil {
+			return err
+		}
+	}
+	s.pendingSpans = make(map[uint64]map[uint64]types.Span)
+	return nil
+}
+
+func (s *SpanDetector) epochSpansMap(ctx context.Context, epoch uint64) (map[uint64]types.Span, bool, error) {
+	spanMap, fromCache, err := s.slasherDB.EpochSpansMap(ctx, epoch)
+	if err != nil || s.pendingSpans == nil {
+		return spanMap, fromCache, err
+	}
+	for idx, sp := range s.pendingSpans[epoch] {
+		spanMap[idx] = sp
+	}
+	return spanMap, fromCache, nil
+}
+
+func (s *SpanDetector) epochSpanByValidatorIndex(ctx context.Context, validatorIdx uint64, epoch uint64) (types.Span, error) {
+	if sp, ok := s.pendingSpans[epoch][validatorIdx]; ok {
+		return sp, nil
+	}
+	return s.slasherDB.EpochSpanByValidatorIndex(ctx, validatorIdx, epoch)
+}
+
+func (s *SpanDetector) epochsSpanByValidatorsIndices(
+	ctx context.Context,
+	validatorIndices []uint64,
+	maxEpoch uint64,
+) (map[uint64]map[uint64]types.Span, error) {
+	epochsSpans, err := s.slasherDB.EpochsSpanByValidatorsIndices(ctx, validatorIndices, maxEpoch)
+	if err != nil || s.pendingSpans == nil {
+		return epochsSpans, err
+	}
+	for epoch, pending := range s.pendingSpans {
+		if epoch > maxEpoch {
+			continue
+		}
+		for _, idx := range validatorIndices {
+			sp, ok := pending[idx]
+			if !ok {
+				continue
+			}
+			if epochsSpans[epoch] == nil {
+				epochsSpans[epoch] = make(map[uint64]types.Span)
+			}
+			epochsSpans[epoch][idx] = sp
+		}
+	}
+	return epochsSpans, nil
+}
+
+func (s *SpanDetector) saveEpochSpansMap(ctx context.Context, epoch uint64, spanMap map[uint64]types.Span) error {
+	if s.pendingSpans == nil {
+		return s.slasherDB.SaveEpochSpansMap(ctx, epoch, spanMap)
+	}
+	s.pendingSpans[epoch] = spanMap
+	return nil
+}
+
+func (s *SpanDetector) saveEpochsSpanByValidatorsIndices(ctx context.Context, epochsSpans map[uint64]map[uint64]types.Span) error {
+	if s.pendingSpans == nil {
+		return s.slasherDB.SaveEpochsSpanByValidatorsIndices(ctx, epochsSpans)
+	}
+	for epoch, spans := range epochsSpans {
+		if s.pendingSpans[epoch] == nil {
+			s.pendingSpans[epoch] = make(map[uint64]types.Span, len(spans))
+		}
+		for idx, sp := range spans {
+			s.pendingSpans[epoch][idx] = sp
+		}
+	}
+	return nil
+}
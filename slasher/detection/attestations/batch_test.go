@@ -0,0 +1,58 @@
+package attestations
+
+import (
+	"context"
+	"testing"
+
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+)
+
+func TestSpanDetector_Batch(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+
+	sd := NewSpanDetector(db)
+	batch := sd.Batch()
+	if err := batch.UpdateSpans(ctx, indexedAttestation(2, 4, []uint64{1})); err != nil {
+		t.Fatal(err)
+	}
+
+	// The span updates are not written before the batch is committed.
+	spanMap, _, err := db.EpochSpansMap(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := spanMap[1]; ok {
+		t.Fatalf("Expected no span for validator 1 before commit, received %v", spanMap[1])
+	}
+
+	// Detection within the batch sees the pending span updates.
+	results, err := batch.DetectSlashingsForAttestation(ctx, indexedAttestation(3, 4, []uint64{1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Kind != types.DoubleVote {
+		t.Fatalf("Expected a double vote to be detected within the batch, received %v", results)
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	wanted := map[uint64]types.Span{
+		0: {MinSpan: 4},
+		1: {MinSpan: 3},
+		3: {MaxSpan: 1},
+		4: {SigBytes: [2]byte{1, 2}, HasAttested: true},
+	}
+	for epoch, span := range wanted {
+		spanMap, _, err := db.EpochSpansMap(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spanMap[1] != span {
+			t.Errorf("Epoch %d: wanted span %v after commit, received %v", epoch, span, spanMap[1])
+		}
+	}
+}
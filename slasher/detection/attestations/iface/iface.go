@@ -18,4 +18,14 @@ type SpanDetector interface {
 
 	// Write functions.
 	UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error
+
+	// Batch functions.
+	Batch() BatchSpanDetector
+}
+
+// BatchSpanDetector defines a SpanDetector which keeps its span updates in memory
+// until they are committed to the DB at once.
+type BatchSpanDetector interface {
+	SpanDetector
+	Commit(ctx context.Context) error
 }
@@ -81,3 +81,13 @@ func (s *MockSpanDetector) DeleteValidatorSpansByEpoch(ctx context.Context, vali
 func (s *MockSpanDetector) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error {
 	return nil
 }
+
+// Batch is a mock which returns the mock itself, as it does not write any spans.
+func (s *MockSpanDetector) Batch() iface.BatchSpanDetector {
+	return s
+}
+
+// Commit is a mock for committing batched span updates.
+func (s *MockSpanDetector) Commit(ctx context.Context) error {
+	return nil
+}
@@ -27,11 +27,6 @@ var (
 	})
 )
 
-// We look back 128 epochs when updating min/max spans
-// for incoming attestations.
-// TODO(#5040): Remove lookback and handle min spans properly.
-const epochLookback = 128
-
 var _ = iface.SpanDetector(&SpanDetector{})
 
 // SpanDetector defines a struct which can detect slashable
@@ -39,6 +34,9 @@ var _ = iface.SpanDetector(&SpanDetector{})
 // spans from validators and attestation data roots.
 type SpanDetector struct {
 	slasherDB db.Database
+	// pendingSpans holds the span updates of a batch by epoch and validator
+	// index until they are committed. It is nil outside of a batch.
+	pendingSpans map[uint64]map[uint64]types.Span
 }
 
 // NewSpanDetector creates a new instance of a struct tracking
@@ -69,11 +67,11 @@ func (s *SpanDetector) DetectSlashingsForAttestation(
 		)
 	}
 
-	spanMap, _, err := s.slasherDB.EpochSpansMap(ctx, sourceEpoch)
+	spanMap, _, err := s.epochSpansMap(ctx, sourceEpoch)
 	if err != nil {
 		return nil, err
 	}
-	targetSpanMap, _, err := s.slasherDB.EpochSpansMap(ctx, targetEpoch)
+	targetSpanMap, _, err := s.epochSpansMap(ctx, targetEpoch)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +83,7 @@ func (s *SpanDetector) DetectSlashingsForAttestation(
 		minSpan := span.MinSpan
 		if minSpan > 0 && minSpan < distance {
 			slashableEpoch := sourceEpoch + uint64(minSpan)
-			targetSpan, err := s.slasherDB.EpochSpanByValidatorIndex(ctx, idx, slashableEpoch)
+			targetSpan, err := s.epochSpanByValidatorIndex(ctx, idx, slashableEpoch)
 			if err != nil {
 				return nil, err
 			}
@@ -101,7 +99,7 @@ func (s *SpanDetector) DetectSlashingsForAttestation(
 		maxSpan := span.MaxSpan
 		if maxSpan > distance {
 			slashableEpoch := sourceEpoch + uint64(maxSpan)
-			targetSpan, err := s.slasherDB.EpochSpanByValidatorIndex(ctx, idx, slashableEpoch)
+			targetSpan, err := s.epochSpanByValidatorIndex(ctx, idx, slashableEpoch)
 			if err != nil {
 				return nil, err
 			}
@@ -154,7 +152,7 @@ func (s *SpanDetector) saveSigBytes(ctx context.Context, att *ethpb.IndexedAttes
 	ctx, traceSpan := trace.StartSpan(ctx, "spanner.saveSigBytes")
 	defer traceSpan.End()
 	target := att.Data.Target.Epoch
-	spanMap, _, err := s.slasherDB.EpochSpansMap(ctx, target)
+	spanMap, _, err := s.epochSpansMap(ctx, target)
 	if err != nil {
 		return err
 	}
@@ -165,7 +163,7 @@ func (s *SpanDetector) saveSigBytes(ctx context.Context, att *ethpb.IndexedAttes
 		// If the validator has already attested for this target epoch,
 		// then we do not need to update the values of the span sig bytes.
 		if span.HasAttested {
-			return nil
+			continue
 		}
 
 		sigBytes := [2]byte{0, 0}
@@ -180,11 +178,15 @@ func (s *SpanDetector) saveSigBytes(ctx context.Context, att *ethpb.IndexedAttes
 			SigBytes:    sigBytes,
 		}
 	}
-	return s.slasherDB.SaveEpochSpansMap(ctx, target, spanMap)
+	return s.saveEpochSpansMap(ctx, target, spanMap)
 }
 
 // Updates a min span for a validator index given a source and target epoch
 // for an attestation produced by the validator. Used for catching surrounding votes.
+// Epochs are walked backwards from source - 1, and a validator is dropped from the
+// walk as soon as its min span for an epoch is left unchanged, since the min span of
+// every earlier epoch is then already at least as tight. Epochs older than the weak
+// subjectivity period are never updated.
 func (s *SpanDetector) updateMinSpan(ctx context.Context, att *ethpb.IndexedAttestation) error {
 	ctx, traceSpan := trace.StartSpan(ctx, "spanner.updateMinSpan")
 	defer traceSpan.End()
@@ -196,6 +198,10 @@ func (s *SpanDetector) updateMinSpan(ctx context.Context, att *ethpb.IndexedAtte
 	valIndices := make([]uint64, len(att.AttestingIndices))
 	copy(valIndices, att.AttestingIndices)
 	latestMinSpanDistanceObserved.Set(float64(att.Data.Target.Epoch - att.Data.Source.Epoch))
+	lowestEpoch := uint64(0)
+	if wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod; source > wsPeriod {
+		lowestEpoch = source - wsPeriod
+	}
 
 	// the for loop tries to update min span using cache for as long as there
 	// is a relevant cached epoch. when there is no such epoch in cache batch
@@ -206,13 +212,13 @@ func (s *SpanDetector) updateMinSpan(ctx context.Context, att *ethpb.IndexedAtte
 	useCache := true
 	useDb := false
 	var err error
-	for ; epoch >= 0; epoch-- {
+	for epoch >= lowestEpoch {
 		if useCache {
-			spanMap, useCache, err = s.slasherDB.EpochSpansMap(ctx, epoch)
+			spanMap, useCache, err = s.epochSpansMap(ctx, epoch)
 		}
 		// Should happen once when cache is exhausted.
 		if !useCache && !useDb {
-			epochsSpansMap, err = s.slasherDB.EpochsSpanByValidatorsIndices(ctx, valIndices, epoch)
+			epochsSpansMap, err = s.epochsSpanByValidatorsIndices(ctx, valIndices, epoch)
 			useDb = true
 		}
 		if err != nil {
@@ -241,27 +247,31 @@ func (s *SpanDetector) updateMinSpan(ctx context.Context, att *ethpb.IndexedAtte
 				indices = append(indices, idx)
 			}
 		}
-		copy(valIndices, indices)
+		valIndices = indices
 		if useCache {
-			if err := s.slasherDB.SaveEpochSpansMap(ctx, epoch, spanMap); err != nil {
+			if err := s.saveEpochSpansMap(ctx, epoch, spanMap); err != nil {
 				return err
 			}
 		}
-		if len(indices) == 0 || epoch == 0 {
-			if useDb {
-				// should happen once when finishing update to all epochs and all indices.
-				if err := s.slasherDB.SaveEpochsSpanByValidatorsIndices(ctx, epochsSpansMap); err != nil {
-					return err
-				}
-			}
+		if len(valIndices) == 0 || epoch == 0 {
 			break
 		}
+		epoch--
+	}
+	if useDb {
+		// should happen once when finishing update to all epochs and all indices.
+		if err := s.saveEpochsSpanByValidatorsIndices(ctx, epochsSpansMap); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 // Updates a max span for a validator index given a source and target epoch
 // for an attestation produced by the validator. Used for catching surrounded votes.
+// Epochs are walked forwards from source + 1, and a validator is dropped from the
+// walk as soon as its max span for an epoch is left unchanged, since the max span of
+// every later epoch up to the target is then already at least as wide.
 func (s *SpanDetector) updateMaxSpan(ctx context.Context, att *ethpb.IndexedAttestation) error {
 	ctx, traceSpan := trace.StartSpan(ctx, "spanner.updateMaxSpan")
 	defer traceSpan.End()
@@ -271,7 +281,7 @@ func (s *SpanDetector) updateMaxSpan(ctx context.Context, att *ethpb.IndexedAtte
 	valIndices := make([]uint64, len(att.AttestingIndices))
 	copy(valIndices, att.AttestingIndices)
 	for epoch := source + 1; epoch < target; epoch++ {
-		spanMap, _, err := s.slasherDB.EpochSpansMap(ctx, epoch)
+		spanMap, _, err := s.epochSpansMap(ctx, epoch)
 		if err != nil {
 			return err
 		}
@@ -290,10 +300,11 @@ func (s *SpanDetector) updateMaxSpan(ctx context.Context, att *ethpb.IndexedAtte
 				indices = append(indices, idx)
 			}
 		}
-		if err := s.slasherDB.SaveEpochSpansMap(ctx, epoch, spanMap); err != nil {
+		valIndices = indices
+		if err := s.saveEpochSpansMap(ctx, epoch, spanMap); err != nil {
 			return err
 		}
-		if len(indices) == 0 {
+		if len(valIndices) == 0 {
 			break
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
@@ -836,3 +837,140 @@ func TestNewSpanDetector_UpdateSpans(t *testing.T) {
 		})
 	}
 }
+
+func TestSpanDetector_UpdateSpans_StopsWhenSpansUnchanged(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	defer func() {
+		if err := db.ClearDB(); err != nil {
+			t.Log(err)
+		}
+	}()
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Log(err)
+		}
+	}()
+
+	sd := &SpanDetector{
+		slasherDB: db,
+	}
+	// Validator 0 has a wide prior vote, validator 2 a narrow one and validator 1 none.
+	if err := sd.UpdateSpans(ctx, indexedAttestation(1, 8, []uint64{0})); err != nil {
+		t.Fatal(err)
+	}
+	if err := sd.UpdateSpans(ctx, indexedAttestation(3, 4, []uint64{2})); err != nil {
+		t.Fatal(err)
+	}
+	// Overwrite the max span at epoch 4 with a marker value, which must stay untouched
+	// as the walk for validator 0 ends at epoch 3 where its max span is already wider.
+	spanMap, _, err := sd.slasherDB.EpochSpansMap(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spanMap[0] = types.Span{MaxSpan: 100}
+	if err := sd.slasherDB.SaveEpochSpansMap(ctx, 4, spanMap); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sd.UpdateSpans(ctx, indexedAttestation(2, 5, []uint64{0, 1, 2})); err != nil {
+		t.Fatal(err)
+	}
+	spanMap, _, err = sd.slasherDB.EpochSpansMap(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spanMap[0].MaxSpan != 100 {
+		t.Errorf("Expected max span of validator 0 to be left untouched, received %d", spanMap[0].MaxSpan)
+	}
+	if spanMap[1].MaxSpan != 1 {
+		t.Errorf("Expected max span of validator 1 to be 1, received %d", spanMap[1].MaxSpan)
+	}
+
+	// The walk for validator 2 ends at epoch 1 where its min span is already tighter,
+	// which leaves the tighter min span at epoch 0 in place.
+	spanMap, _, err = sd.slasherDB.EpochSpansMap(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted := map[uint64]uint16{0: 5, 1: 5, 2: 4}
+	for idx, minSpan := range wanted {
+		if spanMap[idx].MinSpan != minSpan {
+			t.Errorf("Expected min span of validator %d to be %d, received %d", idx, minSpan, spanMap[idx].MinSpan)
+		}
+	}
+}
+
+func TestSpanDetector_UpdateSpans_SavesSigBytesForAllIndices(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	defer func() {
+		if err := db.ClearDB(); err != nil {
+			t.Log(err)
+		}
+	}()
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Log(err)
+		}
+	}()
+
+	sd := &SpanDetector{
+		slasherDB: db,
+	}
+	if err := sd.UpdateSpans(ctx, indexedAttestation(0, 2, []uint64{0})); err != nil {
+		t.Fatal(err)
+	}
+	att := indexedAttestation(1, 2, []uint64{0, 1})
+	att.Signature = []byte{3, 4}
+	if err := sd.UpdateSpans(ctx, att); err != nil {
+		t.Fatal(err)
+	}
+	spanMap, _, err := sd.slasherDB.EpochSpansMap(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spanMap[0].SigBytes != [2]byte{1, 2} {
+		t.Errorf("Expected sig bytes of validator 0 to be kept, received %v", spanMap[0].SigBytes)
+	}
+	if !spanMap[1].HasAttested || spanMap[1].SigBytes != [2]byte{3, 4} {
+		t.Errorf("Expected sig bytes of validator 1 to be saved, received %v", spanMap[1])
+	}
+}
+
+func TestSpanDetector_UpdateSpans_ZeroWeakSubjectivityPeriod(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	ctx := context.Background()
+	defer func() {
+		if err := db.ClearDB(); err != nil {
+			t.Log(err)
+		}
+	}()
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Log(err)
+		}
+	}()
+	defer params.OverrideBeaconConfig(params.BeaconConfig())
+	cfg := params.BeaconConfig().Copy()
+	cfg.WeakSubjectivityPeriod = 0
+	params.OverrideBeaconConfig(cfg)
+
+	sd := &SpanDetector{
+		slasherDB: db,
+	}
+	// With a weak subjectivity period of 0 there are no epochs before the source
+	// to walk, so the min span update must return without touching any epoch.
+	if err := sd.UpdateSpans(ctx, indexedAttestation(2, 5, []uint64{0})); err != nil {
+		t.Fatal(err)
+	}
+	for epoch := uint64(0); epoch < 2; epoch++ {
+		spanMap, _, err := sd.slasherDB.EpochSpansMap(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spanMap[0].MinSpan != 0 {
+			t.Errorf("Expected min span at epoch %d to be left unset, received %d", epoch, spanMap[0].MinSpan)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	status "github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
 	"go.opencensus.io/trace"
 )
@@ -18,10 +19,18 @@ import (
 func (ds *Service) DetectAttesterSlashings(
 	ctx context.Context,
 	att *ethpb.IndexedAttestation,
+) ([]*ethpb.AttesterSlashing, error) {
+	return ds.detectAttesterSlashings(ctx, ds.minMaxSpanDetector, att)
+}
+
+func (ds *Service) detectAttesterSlashings(
+	ctx context.Context,
+	spanDetector iface.SpanDetector,
+	att *ethpb.IndexedAttestation,
 ) ([]*ethpb.AttesterSlashing, error) {
 	ctx, span := trace.StartSpan(ctx, "detection.DetectAttesterSlashings")
 	defer span.End()
-	results, err := ds.minMaxSpanDetector.DetectSlashingsForAttestation(ctx, att)
+	results, err := spanDetector.DetectSlashingsForAttestation(ctx, att)
 	if err != nil {
 		return nil, err
 	}
@@ -8,10 +8,12 @@ package detection
 
 import (
 	"context"
+	"sort"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"go.opencensus.io/trace"
 )
 
@@ -28,12 +30,15 @@ func (ds *Service) detectIncomingBlocks(ctx context.Context, ch chan *ethpb.Sign
 		select {
 		case sblk := <-ch:
 			log.Debug("Running detection on block...")
-			sbh, err := signedBeaconBlockHeaderFromBlock(sblk)
-			if err != nil {
-				log.WithError(err)
+			ds.observeEpoch(helpers.SlotToEpoch(sblk.Block.Slot))
+			ds.detectBlock(ctx, sblk)
+			// Watched public keys of validators whose deposits were not processed
+			// when the slasher started are resolved again at each epoch.
+			if ds.watchlist != nil && helpers.IsEpochStart(sblk.Block.Slot) {
+				if err := ds.watchlist.Resolve(ctx, ds.beaconClient); err != nil {
+					log.WithError(err).Error("Could not resolve watched validators")
+				}
 			}
-			slashing, err := ds.proposalsDetector.DetectDoublePropose(ctx, sbh)
-			ds.submitProposerSlashing(ctx, slashing)
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
 			return
@@ -44,10 +49,33 @@ func (ds *Service) detectIncomingBlocks(ctx context.Context, ch chan *ethpb.Sign
 	}
 }
 
+// detectBlock runs double proposal detection on a block and saves its header,
+// so conflicting proposals received later for the same slot can be detected.
+func (ds *Service) detectBlock(ctx context.Context, sblk *ethpb.SignedBeaconBlock) {
+	if ds.watchlist != nil {
+		ds.watchlist.ObserveBlock(sblk)
+	}
+	sbh, err := signedBeaconBlockHeaderFromBlock(sblk)
+	if err != nil {
+		log.WithError(err).Error("Could not convert block to block header")
+		return
+	}
+	slashing, err := ds.proposalsDetector.DetectDoublePropose(ctx, sbh)
+	if err != nil {
+		log.WithError(err).Error("Could not detect proposer slashings")
+		return
+	}
+	if err := ds.slasherDB.SaveBlockHeader(ctx, sbh); err != nil {
+		log.WithError(err).Error("Could not save block header")
+	}
+	ds.submitProposerSlashing(ctx, slashing)
+}
+
 // detectIncomingAttestations subscribes to an event feed for
 // attestation objects from a notifier interface. Upon receiving
 // an attestation from the feed, we run surround vote and double vote
-// detection on the attestation.
+// detection on the attestation, together with any other attestations
+// already waiting in the channel.
 func (ds *Service) detectIncomingAttestations(ctx context.Context, ch chan *ethpb.IndexedAttestation) {
 	ctx, span := trace.StartSpan(ctx, "detection.detectIncomingAttestations")
 	defer span.End()
@@ -56,24 +84,65 @@ func (ds *Service) detectIncomingAttestations(ctx context.Context, ch chan *ethp
 	for {
 		select {
 		case indexedAtt := <-ch:
-			slashings, err := ds.DetectAttesterSlashings(ctx, indexedAtt)
+			batch := []*ethpb.IndexedAttestation{indexedAtt}
+		drain:
+			for len(batch) < maxAttestationBatchSize {
+				select {
+				case att := <-ch:
+					batch = append(batch, att)
+				default:
+					break drain
+				}
+			}
+			ds.detectAttestationBatch(ctx, batch)
+		case <-sub.Err():
+			log.Error("Subscriber closed, exiting goroutine")
+			return
+		case <-ctx.Done():
+			log.Error("Context canceled")
+			return
+		}
+	}
+}
+
+// detectAttestationBatch runs detection on a batch of attestations grouped by
+// target epoch. The span updates of each group are written to the DB at once
+// after all its attestations were processed.
+func (ds *Service) detectAttestationBatch(ctx context.Context, atts []*ethpb.IndexedAttestation) {
+	ctx, span := trace.StartSpan(ctx, "detection.detectAttestationBatch")
+	defer span.End()
+	sort.SliceStable(atts, func(i, j int) bool {
+		return atts[i].Data.Target.Epoch < atts[j].Data.Target.Epoch
+	})
+	for start := 0; start < len(atts); {
+		end := start + 1
+		for end < len(atts) && atts[end].Data.Target.Epoch == atts[start].Data.Target.Epoch {
+			end++
+		}
+		spanDetector := ds.minMaxSpanDetector.Batch()
+		for _, att := range atts[start:end] {
+			ds.observeEpoch(att.Data.Target.Epoch)
+			if ds.watchlist != nil {
+				ds.watchlist.ObserveAttestation(att)
+			}
+			slashings, err := ds.detectAttesterSlashings(ctx, spanDetector, att)
 			if err != nil {
 				log.WithError(err).Error("Could not detect attester slashings")
 				continue
 			}
 			if len(slashings) < 1 {
-				if err := ds.minMaxSpanDetector.UpdateSpans(ctx, indexedAtt); err != nil {
+				if err := spanDetector.UpdateSpans(ctx, att); err != nil {
 					log.WithError(err).Error("Could not update spans")
 				}
 			}
+			attestationsProcessed.Inc()
 			ds.submitAttesterSlashings(ctx, slashings)
-		case <-sub.Err():
-			log.Error("Subscriber closed, exiting goroutine")
-			return
-		case <-ctx.Done():
-			log.Error("Context canceled")
-			return
 		}
+		if err := spanDetector.Commit(ctx); err != nil {
+			log.WithError(err).Error("Could not save spans")
+		}
+		ds.pruneHistory(ctx, atts[end-1].Data.Target.Epoch)
+		start = end
 	}
 }
 
@@ -40,6 +40,7 @@ func TestService_DetectIncomingBlocks(t *testing.T) {
 	defer testDB.TeardownSlasherDB(t, db)
 	ds := Service{
 		notifier:          &mockNotifier{},
+		slasherDB:         db,
 		proposalsDetector: proposals.NewProposeDetector(db),
 	}
 	blk := &ethpb.SignedBeaconBlock{
@@ -90,3 +91,59 @@ func TestService_DetectIncomingAttestations(t *testing.T) {
 	exitRoutine <- true
 	testutil.AssertLogsContain(t, hook, "Context canceled")
 }
+
+func TestService_PruneHistory(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := Service{
+		slasherDB:        db,
+		historyRetention: 5,
+	}
+	att := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1},
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 0},
+			Target: &ethpb.Checkpoint{Epoch: 1},
+		},
+		Signature: []byte{1, 2},
+	}
+	if err := db.SaveIndexedAttestation(ctx, att); err != nil {
+		t.Fatal(err)
+	}
+
+	// Pruning only runs once every PruneSlasherStoragePeriod epochs.
+	ds.pruneHistory(ctx, 8)
+	exists, err := db.HasIndexedAttestation(ctx, att)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Fatal("Expected attestation to be kept before the pruning period has passed")
+	}
+
+	ds.pruneHistory(ctx, 20)
+	exists, err = db.HasIndexedAttestation(ctx, att)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Error("Expected attestation older than the history retention to be pruned")
+	}
+	if ds.lastPrunedEpoch != 20 {
+		t.Errorf("Expected last pruned epoch 20, received %d", ds.lastPrunedEpoch)
+	}
+}
+
+func TestService_ObserveEpoch(t *testing.T) {
+	ds := Service{}
+	ds.observeEpoch(5)
+	ds.observeEpoch(3)
+	if ds.highestObservedEpoch != 5 {
+		t.Errorf("Expected highest observed epoch 5, received %d", ds.highestObservedEpoch)
+	}
+	ds.observeEpoch(7)
+	if ds.highestObservedEpoch != 7 {
+		t.Errorf("Expected highest observed epoch 7, received %d", ds.highestObservedEpoch)
+	}
+}
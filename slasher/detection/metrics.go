@@ -22,4 +22,17 @@ var (
 		Name: "surrounded_votes_detected_total",
 		Help: "The # of surrounded slashable events detected",
 	})
+	attestationsProcessed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_attestations_processed_total",
+		Help: "The # of attestations run through slashing detection",
+	})
+	detectionLatencyEpochs = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "slasher_detection_latency_epochs",
+		Help:    "The # of epochs between a slashable offense and its detection",
+		Buckets: []float64{0, 1, 2, 4, 8, 16, 32, 64, 128, 256},
+	})
+	historicalEpochsBacklog = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "slasher_historical_epochs_backlog",
+		Help: "The # of historical epochs left to run slashing detection on",
+	})
 )
@@ -2,9 +2,12 @@ package detection
 
 import (
 	"context"
+	"sync/atomic"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
 	"github.com/prysmaticlabs/prysm/slasher/db"
@@ -12,12 +15,17 @@ import (
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
 	"github.com/prysmaticlabs/prysm/slasher/detection/proposals"
 	proposerIface "github.com/prysmaticlabs/prysm/slasher/detection/proposals/iface"
+	"github.com/prysmaticlabs/prysm/slasher/watchlist"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
 var log = logrus.WithField("prefix", "detection")
 
+// maxAttestationBatchSize is the maximum number of incoming attestations
+// which are run through detection as a single batch.
+const maxAttestationBatchSize = 1024
+
 // Service struct for the detection service of the slasher.
 type Service struct {
 	ctx                   context.Context
@@ -32,6 +40,11 @@ type Service struct {
 	proposerSlashingsFeed *event.Feed
 	minMaxSpanDetector    iface.SpanDetector
 	proposalsDetector     proposerIface.ProposalsDetector
+	historyRetention      uint64
+	historicalEpochs      uint64
+	lastPrunedEpoch       uint64
+	highestObservedEpoch  uint64
+	watchlist             *watchlist.Watchlist
 }
 
 // Config options for the detection service.
@@ -42,11 +55,25 @@ type Config struct {
 	BeaconClient          *beaconclient.Service
 	AttesterSlashingsFeed *event.Feed
 	ProposerSlashingsFeed *event.Feed
+	// HistoryRetentionEpochs is the number of epochs of slasher data kept in the DB.
+	// Defaults to the weak subjectivity period when zero.
+	HistoryRetentionEpochs uint64
+	// HistoricalEpochs is the number of past epochs processed on startup before
+	// live detection begins. When zero, the slasher catches up from the last
+	// epoch it processed.
+	HistoricalEpochs uint64
+	// Watchlist notifies operators of the slashings and near slashable behavior
+	// of the validators they watch, when set.
+	Watchlist *watchlist.Watchlist
 }
 
 // NewDetectionService instantiation.
 func NewDetectionService(ctx context.Context, cfg *Config) *Service {
 	ctx, cancel := context.WithCancel(ctx)
+	historyRetention := cfg.HistoryRetentionEpochs
+	if historyRetention == 0 {
+		historyRetention = params.BeaconConfig().WeakSubjectivityPeriod
+	}
 	return &Service{
 		ctx:                   ctx,
 		cancel:                cancel,
@@ -55,11 +82,14 @@ func NewDetectionService(ctx context.Context, cfg *Config) *Service {
 		slasherDB:             cfg.SlasherDB,
 		beaconClient:          cfg.BeaconClient,
 		blocksChan:            make(chan *ethpb.SignedBeaconBlock, 1),
-		attsChan:              make(chan *ethpb.IndexedAttestation, 1),
+		attsChan:              make(chan *ethpb.IndexedAttestation, maxAttestationBatchSize),
 		attesterSlashingsFeed: cfg.AttesterSlashingsFeed,
 		proposerSlashingsFeed: cfg.ProposerSlashingsFeed,
 		minMaxSpanDetector:    attestations.NewSpanDetector(cfg.SlasherDB),
 		proposalsDetector:     proposals.NewProposeDetector(cfg.SlasherDB),
+		historyRetention:      historyRetention,
+		historicalEpochs:      cfg.HistoricalEpochs,
+		watchlist:             cfg.Watchlist,
 	}
 }
 
@@ -85,64 +115,132 @@ func (ds *Service) Start() {
 	<-ch
 	sub.Unsubscribe()
 
-	// The detection service runs detection on all historical
-	// chain data since genesis.
-	// TODO(#5030): Re-enable after issue is resolved.
+	if ds.watchlist != nil {
+		if err := ds.watchlist.Resolve(ds.ctx, ds.beaconClient); err != nil {
+			log.WithError(err).Error("Could not resolve watched validators")
+		}
+	}
+
+	// The detection service catches up on historical chain data before
+	// switching to live detection, so it is not blind to offenses which
+	// happened while the slasher was down.
+	ds.detectHistoricalChainData(ds.ctx)
 
 	// We subscribe to incoming blocks from the beacon node via
 	// our gRPC client to keep detecting slashable offenses.
 	go ds.detectIncomingBlocks(ds.ctx, ds.blocksChan)
 	go ds.detectIncomingAttestations(ds.ctx, ds.attsChan)
-	go ds.detectHistoricalChainData(ds.ctx)
 }
 
 func (ds *Service) detectHistoricalChainData(ctx context.Context) {
 	ctx, span := trace.StartSpan(ctx, "detection.detectHistoricalChainData")
 	defer span.End()
-	// We fetch both the latest persisted chain head in our DB as well
-	// as the current chain head from the beacon node via gRPC.
+	// We fetch the latest persisted chain head in our DB to resume
+	// from the last epoch processed in previous sessions.
 	latestStoredHead, err := ds.slasherDB.ChainHead(ctx)
 	if err != nil {
 		log.WithError(err).Fatal("Could not retrieve chain head from DB")
 	}
-	currentChainHead, err := ds.chainFetcher.ChainHead(ctx)
-	if err != nil {
-		log.WithError(err).Fatal("Cannot retrieve chain head from beacon node")
-	}
-	var latestStoredEpoch uint64
+	var nextEpoch uint64
 	if latestStoredHead != nil {
-		latestStoredEpoch = latestStoredHead.HeadEpoch
+		nextEpoch = latestStoredHead.HeadEpoch
 	}
 
-	// We retrieve historical chain data from the last persisted chain head in the
-	// slasher DB up to the current beacon node's head epoch we retrieved via gRPC.
-	// If no data was persisted from previous sessions, we request data starting from
-	// the genesis epoch.
-	for epoch := latestStoredEpoch; epoch < currentChainHead.HeadEpoch; epoch++ {
-		indexedAtts, err := ds.beaconClient.RequestHistoricalAttestations(ctx, epoch)
+	// We retrieve historical chain data up to the current beacon node's head epoch
+	// we retrieved via gRPC. As the beacon node keeps advancing while we catch up,
+	// we request its head again until no epochs are left to process.
+	for {
+		currentChainHead, err := ds.chainFetcher.ChainHead(ctx)
 		if err != nil {
-			log.WithError(err).Errorf("Could not fetch attestations for epoch: %d", epoch)
+			log.WithError(err).Fatal("Cannot retrieve chain head from beacon node")
+		}
+		headEpoch := currentChainHead.HeadEpoch
+		ds.observeEpoch(headEpoch)
+		// If a number of historical epochs is configured, epochs older than
+		// that are skipped, otherwise we resume from the last persisted chain
+		// head or from genesis if no data was persisted.
+		if ds.historicalEpochs > 0 && headEpoch > ds.historicalEpochs && headEpoch-ds.historicalEpochs > nextEpoch {
+			nextEpoch = headEpoch - ds.historicalEpochs
+		}
+		if nextEpoch >= headEpoch {
+			break
 		}
-		log.Debugf(
-			"Running slashing detection on %d attestations in epoch %d...",
-			len(indexedAtts),
-			epoch,
-		)
-
-		for _, att := range indexedAtts {
-			slashings, err := ds.DetectAttesterSlashings(ctx, att)
-			if err != nil {
-				log.WithError(err).Error("Could not detect attester slashings")
-				continue
+		for ; nextEpoch < headEpoch; nextEpoch++ {
+			if ctx.Err() != nil {
+				return
 			}
-			ds.submitAttesterSlashings(ctx, slashings)
+			historicalEpochsBacklog.Set(float64(headEpoch - nextEpoch))
+			ds.detectHistoricalEpoch(ctx, nextEpoch)
 		}
-		latestStoredHead = &ethpb.ChainHead{HeadEpoch: epoch}
-		if err := ds.slasherDB.SaveChainHead(ctx, latestStoredHead); err != nil {
-			log.WithError(err).Error("Could not persist chain head to disk")
+	}
+	historicalEpochsBacklog.Set(0)
+	log.Infof("Completed slashing detection on historical chain data up to epoch %d", nextEpoch)
+}
+
+// detectHistoricalEpoch runs slashing detection on the blocks and attestations
+// of an epoch requested from the beacon node, and persists the epoch as the
+// latest processed chain head.
+func (ds *Service) detectHistoricalEpoch(ctx context.Context, epoch uint64) {
+	blocks, err := ds.beaconClient.RequestHistoricalBlocks(ctx, epoch)
+	if err != nil {
+		log.WithError(err).Errorf("Could not fetch blocks for epoch: %d", epoch)
+	}
+	log.Debugf("Running slashing detection on %d blocks in epoch %d...", len(blocks), epoch)
+	for _, blk := range blocks {
+		ds.detectBlock(ctx, blk)
+	}
+
+	indexedAtts, err := ds.beaconClient.RequestHistoricalAttestations(ctx, epoch)
+	if err != nil {
+		log.WithError(err).Errorf("Could not fetch attestations for epoch: %d", epoch)
+	}
+	log.Debugf(
+		"Running slashing detection on %d attestations in epoch %d...",
+		len(indexedAtts),
+		epoch,
+	)
+	ds.detectAttestationBatch(ctx, indexedAtts)
+
+	if err := ds.slasherDB.SaveChainHead(ctx, &ethpb.ChainHead{HeadEpoch: epoch}); err != nil {
+		log.WithError(err).Error("Could not persist chain head to disk")
+	}
+}
+
+// observeEpoch records the highest epoch seen by the slasher, which is used
+// as the current epoch when measuring the detection latency.
+func (ds *Service) observeEpoch(epoch uint64) {
+	for {
+		highest := atomic.LoadUint64(&ds.highestObservedEpoch)
+		if epoch <= highest || atomic.CompareAndSwapUint64(&ds.highestObservedEpoch, highest, epoch) {
+			return
 		}
 	}
-	log.Infof("Completed slashing detection on historical chain data up to epoch %d", currentChainHead.HeadEpoch)
+}
+
+// recordDetectionLatency observes the number of epochs between an offense
+// committed at the given epoch and its detection.
+func (ds *Service) recordDetectionLatency(offenseEpoch uint64) {
+	highest := atomic.LoadUint64(&ds.highestObservedEpoch)
+	if offenseEpoch > highest {
+		return
+	}
+	detectionLatencyEpochs.Observe(float64(highest - offenseEpoch))
+}
+
+// pruneHistory removes the slasher data older than the history retention period
+// from the DB, at most once every PruneSlasherStoragePeriod epochs.
+func (ds *Service) pruneHistory(ctx context.Context, currentEpoch uint64) {
+	ctx, span := trace.StartSpan(ctx, "detection.pruneHistory")
+	defer span.End()
+	if currentEpoch < ds.lastPrunedEpoch+params.BeaconConfig().PruneSlasherStoragePeriod {
+		return
+	}
+	ds.lastPrunedEpoch = currentEpoch
+	if err := ds.slasherDB.PruneHistory(ctx, currentEpoch, ds.historyRetention); err != nil {
+		log.WithError(err).Error("Could not prune slasher history")
+		return
+	}
+	log.WithField("epoch", currentEpoch).Debug("Pruned slasher history")
 }
 
 func (ds *Service) submitAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) {
@@ -158,6 +256,14 @@ func (ds *Service) submitAttesterSlashings(ctx context.Context, slashings []*eth
 				"surroundVote": isSurrounding(slash.Attestation_1, slash.Attestation_2),
 				"indices":      slashableIndices,
 			}).Info("Found an attester slashing! Submitting to beacon node")
+			offenseEpoch := slash.Attestation_1.Data.Target.Epoch
+			if slash.Attestation_2.Data.Target.Epoch > offenseEpoch {
+				offenseEpoch = slash.Attestation_2.Data.Target.Epoch
+			}
+			ds.recordDetectionLatency(offenseEpoch)
+			if ds.watchlist != nil {
+				ds.watchlist.ObserveAttesterSlashing(slash)
+			}
 			ds.attesterSlashingsFeed.Send(slashings[i])
 		}
 	}
@@ -173,6 +279,10 @@ func (ds *Service) submitProposerSlashing(ctx context.Context, slashing *ethpb.P
 			"proposerIdxHeader1": slashing.Header_1.Header.ProposerIndex,
 			"proposerIdxHeader2": slashing.Header_2.Header.ProposerIndex,
 		}).Info("Found a proposer slashing! Submitting to beacon node")
+		ds.recordDetectionLatency(helpers.SlotToEpoch(slashing.Header_1.Header.Slot))
+		if ds.watchlist != nil {
+			ds.watchlist.ObserveProposerSlashing(slashing)
+		}
 		ds.proposerSlashingsFeed.Send(slashing)
 	}
 }
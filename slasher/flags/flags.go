@@ -23,6 +23,22 @@ var (
 		Name:  "tls-cert",
 		Usage: "Certificate for secure gRPC. Pass this and the tls-key flag in order to use gRPC securely.",
 	}
+	// HistoricalEpochsFlag defines the number of past epochs processed on startup before live detection begins.
+	HistoricalEpochsFlag = &cli.Uint64Flag{
+		Name:  "historical-epochs",
+		Usage: "Number of past epochs of blocks and attestations requested from the beacon node and processed on startup before switching to live detection. Defaults to catching up from the last processed epoch",
+	}
+	// HistoryFileFlag defines the file the slasher history is exported to or imported from.
+	HistoryFileFlag = &cli.StringFlag{
+		Name:  "history-file",
+		Usage: "File the slasher spans and indexed attestation history is exported to or imported from",
+		Value: "slasher-history.json",
+	}
+	// HistoryRetentionEpochsFlag defines the number of epochs of slasher data kept before it is pruned.
+	HistoryRetentionEpochsFlag = &cli.Uint64Flag{
+		Name:  "history-retention-epochs",
+		Usage: "Number of epochs of spans, indexed attestations and block headers kept before they are pruned. Defaults to the weak subjectivity period",
+	}
 	// KeyFlag defines a flag for the node's TLS key.
 	KeyFlag = &cli.StringFlag{
 		Name:  "tls-key",
@@ -34,6 +50,11 @@ var (
 		Usage: "Port used to listening and respond metrics for prometheus.",
 		Value: 8082,
 	}
+	// SlashingSubmissionEndpointsFlag defines additional beacon nodes detected slashings are submitted to.
+	SlashingSubmissionEndpointsFlag = &cli.StringSliceFlag{
+		Name:  "slashing-submission-endpoints",
+		Usage: "Additional beacon node RPC endpoints detected slashings are submitted to, besides the beacon RPC provider",
+	}
 	// RPCPort defines a slasher node RPC port to open.
 	RPCPort = &cli.IntFlag{
 		Name:  "rpc-port",
@@ -45,4 +66,39 @@ var (
 		Name:  "rebuild-span-maps",
 		Usage: "Rebuild span maps from indexed attestations in db",
 	}
+	// WatchValidatorsFlag defines the validators the slasher notifies operators about.
+	WatchValidatorsFlag = &cli.StringSliceFlag{
+		Name:  "watch-validators",
+		Usage: "Validator indices or hex encoded public keys to watch. Alerts are raised when they are slashed or attest in a way typical of a key run by two validator clients",
+	}
+	// WatchWebhookURLsFlag defines the URLs alerts about watched validators are posted to.
+	WatchWebhookURLsFlag = &cli.StringSliceFlag{
+		Name:  "watch-webhook-urls",
+		Usage: "URLs alerts about watched validators are posted to as JSON",
+	}
+	// WatchSMTPServerFlag defines the SMTP server alerts about watched validators are emailed through.
+	WatchSMTPServerFlag = &cli.StringFlag{
+		Name:  "watch-smtp-server",
+		Usage: "host:port address of the SMTP server alerts about watched validators are emailed through",
+	}
+	// WatchSMTPUsernameFlag defines the username authenticating with the SMTP server.
+	WatchSMTPUsernameFlag = &cli.StringFlag{
+		Name:  "watch-smtp-username",
+		Usage: "Username authenticating with the SMTP server",
+	}
+	// WatchSMTPPasswordFileFlag defines the file containing the password authenticating with the SMTP server.
+	WatchSMTPPasswordFileFlag = &cli.StringFlag{
+		Name:  "watch-smtp-password-file",
+		Usage: "File containing the password authenticating with the SMTP server",
+	}
+	// WatchEmailFromFlag defines the sender of the emails about watched validators.
+	WatchEmailFromFlag = &cli.StringFlag{
+		Name:  "watch-email-from",
+		Usage: "Sender address of the alerts about watched validators",
+	}
+	// WatchEmailToFlag defines the recipients of the emails about watched validators.
+	WatchEmailToFlag = &cli.StringSliceFlag{
+		Name:  "watch-email-to",
+		Usage: "Addresses alerts about watched validators are emailed to",
+	}
 )
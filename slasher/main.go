@@ -6,7 +6,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"runtime"
 
 	joonix "github.com/joonix/log"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -30,7 +29,12 @@ func startSlasher(cliCtx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	logrus.SetLevel(level)
+	overrides, err := logutil.ParseLevelOverrides(cliCtx.StringSlice(cmd.LogLevelOverrideFlag.Name))
+	if err != nil {
+		return err
+	}
+	logutil.SetLevels(level, overrides)
+	logutil.ReloadLevelsOnSIGHUP(cliCtx.String(cmd.ConfigFileFlag.Name))
 	slasher, err := node.NewSlasherNode(cliCtx)
 	if err != nil {
 		return err
@@ -41,12 +45,20 @@ func startSlasher(cliCtx *cli.Context) error {
 
 var appFlags = []cli.Flag{
 	cmd.VerbosityFlag,
+	cmd.LogLevelOverrideFlag,
 	cmd.DataDirFlag,
 	cmd.EnableTracingFlag,
 	cmd.TracingProcessNameFlag,
 	cmd.TracingEndpointFlag,
+	cmd.TracingExporterFlag,
+	cmd.TracingTagsFlag,
 	cmd.TraceSampleFractionFlag,
 	flags.MonitoringPortFlag,
+	cmd.MonitoringHostFlag,
+	cmd.MonitoringCorsDomainFlag,
+	cmd.HTTPTLSCertFlag,
+	cmd.HTTPTLSKeyFlag,
+	cmd.HTTPDisabledEndpointsFlag,
 	cmd.LogFileName,
 	cmd.LogFormat,
 	cmd.ClearDB,
@@ -58,15 +70,28 @@ var appFlags = []cli.Flag{
 	debug.MemProfileRateFlag,
 	debug.CPUProfileFlag,
 	debug.TraceFlag,
+	debug.GCPercentFlag,
+	debug.GCBallastFlag,
 	flags.RPCPort,
 	flags.KeyFlag,
 	flags.RebuildSpanMapsFlag,
+	flags.HistoryRetentionEpochsFlag,
+	flags.HistoricalEpochsFlag,
 	flags.BeaconCertFlag,
 	flags.BeaconRPCProviderFlag,
+	flags.SlashingSubmissionEndpointsFlag,
+	flags.WatchValidatorsFlag,
+	flags.WatchWebhookURLsFlag,
+	flags.WatchSMTPServerFlag,
+	flags.WatchSMTPUsernameFlag,
+	flags.WatchSMTPPasswordFileFlag,
+	flags.WatchEmailFromFlag,
+	flags.WatchEmailToFlag,
 }
 
 func init() {
-	appFlags = cmd.WrapFlags(append(appFlags, featureconfig.SlasherFlags...))
+	appFlags = append(appFlags, featureconfig.SlasherFlags...)
+	appFlags = cmd.WrapFlags(append(appFlags, cmd.AliasFlags(appFlags)...))
 }
 
 func main() {
@@ -76,6 +101,42 @@ func main() {
 	app.Version = version.GetVersion()
 	app.Flags = appFlags
 	app.Action = startSlasher
+	app.Commands = []*cli.Command{
+		{
+			Name:     "db",
+			Category: "db",
+			Usage:    "defines commands for maintaining the slasher database",
+			Subcommands: []*cli.Command{
+				{
+					Name:        "prune",
+					Description: "removes the spans, indexed attestations and block headers older than the history retention period from the slasher database. The slasher must be stopped",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.HistoryRetentionEpochsFlag,
+					},
+					Action: node.PruneDB,
+				},
+				{
+					Name:        "export",
+					Description: "writes the spans and indexed attestation history of the slasher database to the history file, to be imported by another slasher. The slasher must be stopped",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.HistoryFileFlag,
+					},
+					Action: node.ExportDB,
+				},
+				{
+					Name:        "import",
+					Description: "reads a history file written by the export command into the slasher database of a fresh slasher. The slasher must be stopped",
+					Flags: []cli.Flag{
+						cmd.DataDirFlag,
+						flags.HistoryFileFlag,
+					},
+					Action: node.ImportDB,
+				},
+			},
+		},
+	}
 	app.Before = func(ctx *cli.Context) error {
 		// Load any flags from file, if specified.
 		if ctx.IsSet(cmd.ConfigFileFlag.Name) {
@@ -86,6 +147,9 @@ func main() {
 				return err
 			}
 		}
+		if err := cmd.ApplyRenamedFlags(ctx); err != nil {
+			return err
+		}
 
 		format := ctx.String(cmd.LogFormat.Name)
 		switch format {
@@ -107,6 +171,11 @@ func main() {
 		default:
 			return fmt.Errorf("unknown log format %s", format)
 		}
+		if format != "text" {
+			// Structured log output carries the service, current slot and epoch as fields.
+			logrus.AddHook(logutil.NewContextHook())
+		}
+		logrus.SetFormatter(logutil.WrapFormatter(logrus.StandardLogger().Formatter))
 
 		logFileName := ctx.String(cmd.LogFileName.Name)
 		if logFileName != "" {
@@ -115,7 +184,6 @@ func main() {
 			}
 		}
 
-		runtime.GOMAXPROCS(runtime.NumCPU())
 		return debug.Setup(ctx)
 	}
 
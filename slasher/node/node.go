@@ -4,11 +4,14 @@
 package node
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"os/signal"
 	"path"
+	"strings"
 	"sync"
 	"syscall"
 
@@ -18,6 +21,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/debug"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/prometheus"
 	"github.com/prysmaticlabs/prysm/shared/tracing"
 	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
@@ -26,6 +30,7 @@ import (
 	"github.com/prysmaticlabs/prysm/slasher/detection"
 	"github.com/prysmaticlabs/prysm/slasher/flags"
 	"github.com/prysmaticlabs/prysm/slasher/rpc"
+	"github.com/prysmaticlabs/prysm/slasher/watchlist"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/urfave/cli.v2"
 )
@@ -55,7 +60,9 @@ func NewSlasherNode(cliCtx *cli.Context) (*SlasherNode, error) {
 	if err := tracing.Setup(
 		"slasher", // Service name.
 		cliCtx.String(cmd.TracingProcessNameFlag.Name),
+		cliCtx.String(cmd.TracingExporterFlag.Name),
 		cliCtx.String(cmd.TracingEndpointFlag.Name),
+		cliCtx.StringSlice(cmd.TracingTagsFlag.Name),
 		cliCtx.Float64(cmd.TraceSampleFractionFlag.Name),
 		cliCtx.Bool(cmd.EnableTracingFlag.Name),
 	); err != nil {
@@ -142,7 +149,12 @@ func (s *SlasherNode) Close() {
 
 func (s *SlasherNode) registerPrometheusService() error {
 	service := prometheus.NewPrometheusService(
-		fmt.Sprintf(":%d", s.cliCtx.Int64(flags.MonitoringPortFlag.Name)),
+		cmd.HTTPServerConfig(
+			s.cliCtx,
+			s.cliCtx.String(cmd.MonitoringHostFlag.Name),
+			int(s.cliCtx.Int64(flags.MonitoringPortFlag.Name)),
+			s.cliCtx.String(cmd.MonitoringCorsDomainFlag.Name),
+		),
 		s.services,
 	)
 	logrus.AddHook(prometheus.NewLogrusCollector())
@@ -197,6 +209,7 @@ func (s *SlasherNode) registerBeaconClientService() error {
 		BeaconProvider:        beaconProvider,
 		AttesterSlashingsFeed: s.attesterSlashingsFeed,
 		ProposerSlashingsFeed: s.proposerSlashingsFeed,
+		SubmissionEndpoints:   s.cliCtx.StringSlice(flags.SlashingSubmissionEndpointsFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to initialize beacon client")
@@ -209,17 +222,63 @@ func (s *SlasherNode) registerDetectionService() error {
 	if err := s.services.FetchService(&bs); err != nil {
 		panic(err)
 	}
+	wl, err := s.watchlist()
+	if err != nil {
+		return errors.Wrap(err, "could not configure watch list")
+	}
 	ds := detection.NewDetectionService(s.ctx, &detection.Config{
-		Notifier:              bs,
-		SlasherDB:             s.db,
-		BeaconClient:          bs,
-		ChainFetcher:          bs,
-		AttesterSlashingsFeed: s.attesterSlashingsFeed,
-		ProposerSlashingsFeed: s.proposerSlashingsFeed,
+		Notifier:               bs,
+		SlasherDB:              s.db,
+		BeaconClient:           bs,
+		ChainFetcher:           bs,
+		AttesterSlashingsFeed:  s.attesterSlashingsFeed,
+		ProposerSlashingsFeed:  s.proposerSlashingsFeed,
+		HistoryRetentionEpochs: s.cliCtx.Uint64(flags.HistoryRetentionEpochsFlag.Name),
+		HistoricalEpochs:       s.cliCtx.Uint64(flags.HistoricalEpochsFlag.Name),
+		Watchlist:              wl,
 	})
 	return s.services.RegisterService(ds)
 }
 
+// watchlist creates the watch list of the validators given by the watch flags, along
+// with the notifiers its alerts are delivered through. It returns nil when no validators
+// are watched.
+func (s *SlasherNode) watchlist() (*watchlist.Watchlist, error) {
+	validators := s.cliCtx.StringSlice(flags.WatchValidatorsFlag.Name)
+	if len(validators) == 0 {
+		return nil, nil
+	}
+	var notifiers []watchlist.Notifier
+	for _, url := range s.cliCtx.StringSlice(flags.WatchWebhookURLsFlag.Name) {
+		notifiers = append(notifiers, watchlist.NewWebhookNotifier(url))
+	}
+	if to := s.cliCtx.StringSlice(flags.WatchEmailToFlag.Name); len(to) > 0 {
+		var password string
+		if passwordFile := s.cliCtx.String(flags.WatchSMTPPasswordFileFlag.Name); passwordFile != "" {
+			data, err := ioutil.ReadFile(passwordFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not read SMTP password file")
+			}
+			password = strings.TrimSpace(string(data))
+		}
+		email, err := watchlist.NewEmailNotifier(&watchlist.EmailConfig{
+			SMTPServer: s.cliCtx.String(flags.WatchSMTPServerFlag.Name),
+			Username:   s.cliCtx.String(flags.WatchSMTPUsernameFlag.Name),
+			Password:   password,
+			From:       s.cliCtx.String(flags.WatchEmailFromFlag.Name),
+			To:         to,
+		})
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, email)
+	}
+	if len(notifiers) == 0 {
+		log.Warn("No webhook or email configured for the watch list, alerts about watched validators are only logged")
+	}
+	return watchlist.New(validators, notifiers)
+}
+
 func (s *SlasherNode) registerRPCService() error {
 	var detectionService *detection.Service
 	if err := s.services.FetchService(&detectionService); err != nil {
@@ -239,3 +298,118 @@ func (s *SlasherNode) registerRPCService() error {
 
 	return s.services.RegisterService(rpcService)
 }
+
+// PruneDB removes the spans, indexed attestations and block headers older than
+// the history retention period from the slasher database in the data directory
+// specified by the cli context. The slasher must not be running.
+func PruneDB(cliCtx *cli.Context) error {
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), slasherDBName)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return fmt.Errorf("no slasher database found at %s", dbPath)
+	}
+	d, err := db.NewDB(dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	ctx := context.Background()
+	// The current epoch is the latest epoch the slasher has seen data for.
+	currentEpoch, err := d.LatestIndexedAttestationsTargetEpoch(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve latest indexed attestation epoch")
+	}
+	head, err := d.ChainHead(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve chain head")
+	}
+	if head != nil && head.HeadEpoch > currentEpoch {
+		currentEpoch = head.HeadEpoch
+	}
+	retention := cliCtx.Uint64(flags.HistoryRetentionEpochsFlag.Name)
+	if retention == 0 {
+		retention = params.BeaconConfig().WeakSubjectivityPeriod
+	}
+	if err := d.PruneHistory(ctx, currentEpoch, retention); err != nil {
+		return errors.Wrap(err, "could not prune slasher database")
+	}
+	log.WithFields(logrus.Fields{
+		"currentEpoch": currentEpoch,
+		"retention":    retention,
+	}).Info("Pruned slasher database")
+	return nil
+}
+
+// ExportDB writes the spans and indexed attestation history of the slasher database
+// in the data directory specified by the cli context to the history file. The slasher
+// must not be running.
+func ExportDB(cliCtx *cli.Context) error {
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), slasherDBName)
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return fmt.Errorf("no slasher database found at %s", dbPath)
+	}
+	d, err := db.NewDB(dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	historyFile := cliCtx.String(flags.HistoryFileFlag.Name)
+	f, err := os.Create(historyFile)
+	if err != nil {
+		return errors.Wrap(err, "could not create history file")
+	}
+	w := bufio.NewWriter(f)
+	if err := d.ExportHistory(context.Background(), w); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "could not export slasher history")
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "could not write history file")
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "could not close history file")
+	}
+	log.WithField("file", historyFile).Info("Exported slasher history")
+	return nil
+}
+
+// ImportDB reads a history file written by ExportDB into the slasher database in
+// the data directory specified by the cli context. The slasher must not be running.
+func ImportDB(cliCtx *cli.Context) error {
+	historyFile := cliCtx.String(flags.HistoryFileFlag.Name)
+	f, err := os.Open(historyFile)
+	if err != nil {
+		return errors.Wrap(err, "could not open history file")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Failed to close history file")
+		}
+	}()
+	dbPath := path.Join(cliCtx.String(cmd.DataDirFlag.Name), slasherDBName)
+	d, err := db.NewDB(dbPath, &kv.Config{})
+	if err != nil {
+		return errors.Wrap(err, "could not open database")
+	}
+	defer func() {
+		if err := d.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	if err := d.ImportHistory(context.Background(), bufio.NewReader(f)); err != nil {
+		return errors.Wrap(err, "could not import slasher history")
+	}
+	log.WithField("file", historyFile).Info("Imported slasher history")
+	return nil
+}
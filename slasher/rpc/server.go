@@ -3,9 +3,10 @@ package rpc
 import (
 	"context"
 
-	"github.com/pkg/errors"
+	ptypes "github.com/gogo/protobuf/types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/version"
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
 	log "github.com/sirupsen/logrus"
@@ -27,6 +28,9 @@ type Server struct {
 func (ss *Server) IsSlashableAttestation(ctx context.Context, req *ethpb.IndexedAttestation) (*slashpb.AttesterSlashingResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "detection.IsSlashableAttestation")
 	defer span.End()
+	if req == nil || req.Data == nil || req.Data.Source == nil || req.Data.Target == nil {
+		return nil, status.Error(codes.InvalidArgument, "Nil attestation data in request")
+	}
 	//TODO(#5189) add signature validation to prevent DOS attack on the endpoint.
 	if err := ss.slasherDB.SaveIndexedAttestation(ctx, req); err != nil {
 		log.WithError(err).Error("Could not save indexed attestation")
@@ -49,5 +53,31 @@ func (ss *Server) IsSlashableAttestation(ctx context.Context, req *ethpb.Indexed
 // IsSlashableBlock returns an proposer slashing if the block submitted
 // is a double proposal.
 func (ss *Server) IsSlashableBlock(ctx context.Context, req *ethpb.SignedBeaconBlockHeader) (*slashpb.ProposerSlashingResponse, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "detection.IsSlashableBlock")
+	defer span.End()
+	if req == nil || req.Header == nil {
+		return nil, status.Error(codes.InvalidArgument, "Nil block header in request")
+	}
+	//TODO(#5189) add signature validation to prevent DOS attack on the endpoint.
+	if err := ss.slasherDB.SaveBlockHeader(ctx, req); err != nil {
+		log.WithError(err).Error("Could not save block header")
+		return nil, status.Errorf(codes.Internal, "Could not save block header: %v: %v", req, err)
+	}
+	slashing, err := ss.detector.DetectDoubleProposals(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not detect proposer slashings for block: %v: %v", req, err)
+	}
+	if slashing == nil {
+		return &slashpb.ProposerSlashingResponse{}, nil
+	}
+	return &slashpb.ProposerSlashingResponse{
+		ProposerSlashing: []*ethpb.ProposerSlashing{slashing},
+	}, nil
+}
+
+// GetVersion returns the version information of the slasher.
+func (ss *Server) GetVersion(ctx context.Context, _ *ptypes.Empty) (*ethpb.Version, error) {
+	return &ethpb.Version{
+		Version: version.GetVersion(),
+	}, nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"testing"
 
+	ptypes "github.com/gogo/protobuf/types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/version"
 	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
 )
@@ -52,3 +54,61 @@ func Test_DetectionFlow(t *testing.T) {
 		t.Fatalf("only one slashing should have been found. got: %v", len(slashing.AttesterSlashing))
 	}
 }
+
+func Test_DetectionFlowBlocks(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+
+	savedBlock := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          1,
+			ProposerIndex: 1,
+		},
+		Signature: bytesutil.PadTo([]byte{1, 2}, 96),
+	}
+	incomingBlock := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          1,
+			ProposerIndex: 1,
+			BodyRoot:      bytesutil.PadTo([]byte{1}, 32),
+		},
+		Signature: bytesutil.PadTo([]byte{1, 3}, 96),
+	}
+	cfg := &detection.Config{
+		SlasherDB: db,
+	}
+	ctx := context.Background()
+	ds := detection.NewDetectionService(ctx, cfg)
+	server := Server{ctx: ctx, detector: ds, slasherDB: db}
+	slashings, err := server.IsSlashableBlock(ctx, savedBlock)
+	if err != nil {
+		t.Fatalf("got error while trying to detect slashing: %v", err)
+	}
+	if len(slashings.ProposerSlashing) != 0 {
+		t.Fatalf("Found slashings while no slashing should have been found on first block: %v slashing found: %v", savedBlock, slashings)
+	}
+
+	slashing, err := server.IsSlashableBlock(ctx, incomingBlock)
+	if err != nil {
+		t.Fatalf("got error while trying to detect slashing: %v", err)
+	}
+	if len(slashing.ProposerSlashing) != 1 {
+		t.Fatalf("only one slashing should have been found. got: %v", len(slashing.ProposerSlashing))
+	}
+
+	if _, err := server.IsSlashableBlock(ctx, &ethpb.SignedBeaconBlockHeader{}); err == nil {
+		t.Error("Expected an error for a block without header")
+	}
+}
+
+func TestServer_GetVersion(t *testing.T) {
+	v := version.GetVersion()
+	ss := &Server{}
+	res, err := ss.GetVersion(context.Background(), &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Version != v {
+		t.Errorf("Wanted GetVersion() = %s, received %s", v, res.Version)
+	}
+}
@@ -44,12 +44,20 @@ var appHelpFlagGroups = []flagGroup{
 		Name: "cmd",
 		Flags: []cli.Flag{
 			cmd.VerbosityFlag,
+			cmd.LogLevelOverrideFlag,
 			cmd.DataDirFlag,
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
 			cmd.TracingEndpointFlag,
+			cmd.TracingExporterFlag,
+			cmd.TracingTagsFlag,
 			cmd.TraceSampleFractionFlag,
 			flags.MonitoringPortFlag,
+			cmd.MonitoringHostFlag,
+			cmd.MonitoringCorsDomainFlag,
+			cmd.HTTPTLSCertFlag,
+			cmd.HTTPTLSKeyFlag,
+			cmd.HTTPDisabledEndpointsFlag,
 			cmd.LogFormat,
 			cmd.LogFileName,
 			cmd.ForceClearDB,
@@ -66,6 +74,8 @@ var appHelpFlagGroups = []flagGroup{
 			debug.MemProfileRateFlag,
 			debug.CPUProfileFlag,
 			debug.TraceFlag,
+			debug.GCPercentFlag,
+			debug.GCBallastFlag,
 		},
 	},
 	{
@@ -75,7 +85,17 @@ var appHelpFlagGroups = []flagGroup{
 			flags.KeyFlag,
 			flags.RPCPort,
 			flags.RebuildSpanMapsFlag,
+			flags.HistoryRetentionEpochsFlag,
+			flags.HistoricalEpochsFlag,
 			flags.BeaconRPCProviderFlag,
+			flags.SlashingSubmissionEndpointsFlag,
+			flags.WatchValidatorsFlag,
+			flags.WatchWebhookURLsFlag,
+			flags.WatchSMTPServerFlag,
+			flags.WatchSMTPUsernameFlag,
+			flags.WatchSMTPPasswordFileFlag,
+			flags.WatchEmailFromFlag,
+			flags.WatchEmailToFlag,
 		},
 	},
 }
@@ -0,0 +1,17 @@
+package watchlist
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	alertsRaised = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slasher_watchlist_alerts_total",
+		Help: "The # of alerts raised for watched validators",
+	}, []string{"kind"})
+	alertNotificationFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_watchlist_notification_failures_total",
+		Help: "The # of watch list alerts a notifier failed to deliver",
+	})
+)
@@ -0,0 +1,111 @@
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WebhookNotifier delivers alerts as JSON in the body of a POST request to a URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting alerts to the given URL.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{}}
+}
+
+// Notify posts the alert to the webhook URL.
+func (n *WebhookNotifier) Notify(ctx context.Context, alert *Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal alert")
+	}
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "could not create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrap(err, "could not post alert to webhook")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close webhook response body")
+		}
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+// EmailConfig options for the email notifier.
+type EmailConfig struct {
+	// SMTPServer is the host:port address of the SMTP server sending the emails.
+	SMTPServer string
+	// Username and Password authenticate with the SMTP server when a username is set.
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// EmailNotifier delivers alerts by email through an SMTP server.
+type EmailNotifier struct {
+	cfg  *EmailConfig
+	auth smtp.Auth
+}
+
+// NewEmailNotifier creates a notifier emailing alerts with the given configuration.
+func NewEmailNotifier(cfg *EmailConfig) (*EmailNotifier, error) {
+	if cfg.SMTPServer == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return nil, errors.New("an SMTP server, a sender and at least one recipient are required to email alerts")
+	}
+	n := &EmailNotifier{cfg: cfg}
+	if cfg.Username != "" {
+		host := cfg.SMTPServer
+		if i := strings.LastIndex(host, ":"); i >= 0 {
+			host = host[:i]
+		}
+		n.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+	return n, nil
+}
+
+// Notify emails the alert to the recipients. The SMTP exchange does not support
+// cancellation, so the context is only checked before sending.
+func (n *EmailNotifier) Notify(ctx context.Context, alert *Alert) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := smtp.SendMail(n.cfg.SMTPServer, n.auth, n.cfg.From, n.cfg.To, emailMessage(n.cfg.From, n.cfg.To, alert)); err != nil {
+		return errors.Wrap(err, "could not email alert")
+	}
+	return nil
+}
+
+func emailMessage(from string, to []string, alert *Alert) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: [slasher] %s: validator %d\r\n", alert.Kind, alert.ValidatorIndex)
+	fmt.Fprintf(&buf, "Date: %s\r\n", alert.Time.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&buf, "%s\r\n\r\n", alert.Message)
+	fmt.Fprintf(&buf, "Validator index: %d\r\n", alert.ValidatorIndex)
+	if alert.PublicKey != "" {
+		fmt.Fprintf(&buf, "Public key: %s\r\n", alert.PublicKey)
+	}
+	fmt.Fprintf(&buf, "Epoch: %d\r\n", alert.Epoch)
+	return buf.Bytes()
+}
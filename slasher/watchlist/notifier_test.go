@@ -0,0 +1,73 @@
+package watchlist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	received := make(chan *Alert, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, received %s", r.Method)
+		}
+		a := &Alert{}
+		if err := json.NewDecoder(r.Body).Decode(a); err != nil {
+			t.Error(err)
+		}
+		received <- a
+	}))
+	defer srv.Close()
+
+	alert := &Alert{Kind: ProposerSlashingDetected, ValidatorIndex: 3, Epoch: 2, Message: "Double proposal"}
+	if err := NewWebhookNotifier(srv.URL).Notify(context.Background(), alert); err != nil {
+		t.Fatal(err)
+	}
+	a := <-received
+	if a.Kind != alert.Kind || a.ValidatorIndex != 3 || a.Epoch != 2 || a.Message != alert.Message {
+		t.Errorf("Wanted %+v, received %+v", alert, a)
+	}
+}
+
+func TestWebhookNotifier_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := NewWebhookNotifier(srv.URL).Notify(context.Background(), &Alert{}); err == nil {
+		t.Error("Expected error for unsuccessful webhook response")
+	}
+}
+
+func TestNewEmailNotifier_RequiresRecipients(t *testing.T) {
+	if _, err := NewEmailNotifier(&EmailConfig{SMTPServer: "localhost:25", From: "slasher@example.com"}); err == nil {
+		t.Error("Expected error without recipients")
+	}
+}
+
+func TestEmailMessage(t *testing.T) {
+	msg := string(emailMessage("slasher@example.com", []string{"a@example.com", "b@example.com"}, &Alert{
+		Kind:           SlashingIncluded,
+		ValidatorIndex: 5,
+		PublicKey:      "0xab",
+		Epoch:          9,
+		Message:        "Validator 5 was slashed",
+		Time:           time.Unix(0, 0).UTC(),
+	}))
+	for _, want := range []string{
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: [slasher] slashing_included: validator 5\r\n",
+		"\r\n\r\nValidator 5 was slashed\r\n",
+		"Public key: 0xab\r\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected email to contain %q, received %q", want, msg)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+// Package watchlist notifies operators when the validators they watch are slashed or
+// behave in a way close to being slashable, so staking providers can react before
+// more of their keys are affected.
+package watchlist
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "watchlist")
+
+// notifyTimeout is the time given to a notifier to deliver an alert.
+const notifyTimeout = 30 * time.Second
+
+// AlertKind is the kind of behavior of a watched validator an alert is raised for.
+type AlertKind string
+
+const (
+	// AttesterSlashingDetected is raised when the slasher detects a double or
+	// surround vote of a watched validator.
+	AttesterSlashingDetected AlertKind = "attester_slashing_detected"
+	// ProposerSlashingDetected is raised when the slasher detects a double
+	// proposal of a watched validator.
+	ProposerSlashingDetected AlertKind = "proposer_slashing_detected"
+	// SlashingIncluded is raised when a block includes a slashing of a watched
+	// validator, whether it was detected by this slasher or not.
+	SlashingIncluded AlertKind = "slashing_included"
+	// AttestationTargetRewind is raised when a watched validator attests to a
+	// target epoch older than one it already attested to by more than the
+	// inclusion delay allows. While not slashable by itself, it is typical of a
+	// validator key run by two validator clients or of lost slashing protection.
+	AttestationTargetRewind AlertKind = "attestation_target_rewind"
+)
+
+// Alert describes the behavior of a watched validator operators are notified of.
+type Alert struct {
+	Kind           AlertKind `json:"kind"`
+	ValidatorIndex uint64    `json:"validator_index"`
+	PublicKey      string    `json:"public_key,omitempty"`
+	Epoch          uint64    `json:"epoch"`
+	Message        string    `json:"message"`
+	Time           time.Time `json:"time"`
+}
+
+// Notifier delivers alerts to operators.
+type Notifier interface {
+	Notify(ctx context.Context, alert *Alert) error
+}
+
+// IndexResolver finds the indices of validators given their public keys.
+type IndexResolver interface {
+	FindValidatorIndices(ctx context.Context, publicKeys [][]byte) (map[uint64][]byte, error)
+}
+
+type alertKey struct {
+	kind  AlertKind
+	index uint64
+	epoch uint64
+}
+
+// Watchlist checks the attestations, blocks and slashings seen by the slasher for
+// the watched validators and notifies operators of their slashings and of near
+// slashable behavior.
+type Watchlist struct {
+	lock           sync.Mutex
+	watched        map[uint64][]byte
+	unresolvedKeys [][]byte
+	highestTargets map[uint64]uint64
+	alerted        map[alertKey]bool
+	notifiers      []Notifier
+}
+
+// New creates a watch list of the given validators, each either a validator index or
+// a hex encoded public key. Public keys are only watched once resolved to indices.
+func New(validators []string, notifiers []Notifier) (*Watchlist, error) {
+	w := &Watchlist{
+		watched:        make(map[uint64][]byte),
+		highestTargets: make(map[uint64]uint64),
+		alerted:        make(map[alertKey]bool),
+		notifiers:      notifiers,
+	}
+	for _, v := range validators {
+		v = strings.TrimSpace(v)
+		if strings.HasPrefix(v, "0x") {
+			pubKey, err := hex.DecodeString(strings.TrimPrefix(v, "0x"))
+			if err != nil || len(pubKey) != 48 {
+				return nil, fmt.Errorf("invalid public key %s, expected 48 hex encoded bytes", v)
+			}
+			w.unresolvedKeys = append(w.unresolvedKeys, pubKey)
+			continue
+		}
+		index, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid validator index %s", v)
+		}
+		w.watched[index] = nil
+	}
+	return w, nil
+}
+
+// Resolve finds the indices of the watched public keys. Public keys unknown to the
+// beacon node are kept to be resolved by a later call.
+func (w *Watchlist) Resolve(ctx context.Context, resolver IndexResolver) error {
+	w.lock.Lock()
+	keys := w.unresolvedKeys
+	w.lock.Unlock()
+	if len(keys) == 0 {
+		return nil
+	}
+	indices, err := resolver.FindValidatorIndices(ctx, keys)
+	if err != nil {
+		return errors.Wrap(err, "could not find indices of watched public keys")
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	resolved := make(map[string]bool, len(indices))
+	for index, pubKey := range indices {
+		w.watched[index] = pubKey
+		resolved[string(pubKey)] = true
+	}
+	var unresolved [][]byte
+	for _, pubKey := range keys {
+		if !resolved[string(pubKey)] {
+			unresolved = append(unresolved, pubKey)
+		}
+	}
+	w.unresolvedKeys = unresolved
+	if len(unresolved) > 0 {
+		log.WithField("count", len(unresolved)).Warn("Could not find the indices of some watched public keys, they are not watched yet")
+	}
+	return nil
+}
+
+// Watching returns true if the validator with the given index is watched.
+func (w *Watchlist) Watching(index uint64) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, ok := w.watched[index]
+	return ok
+}
+
+// ObserveAttestation raises an alert when a watched validator attests to a target
+// epoch older than its latest attested target by more than the inclusion delay.
+func (w *Watchlist) ObserveAttestation(att *ethpb.IndexedAttestation) {
+	if att == nil || att.Data == nil || att.Data.Target == nil {
+		return
+	}
+	target := att.Data.Target.Epoch
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, index := range att.AttestingIndices {
+		if _, ok := w.watched[index]; !ok {
+			continue
+		}
+		highest, seen := w.highestTargets[index]
+		if !seen || target > highest {
+			w.highestTargets[index] = target
+			continue
+		}
+		// Attestations may be included up to an epoch after their target epoch,
+		// so an attestation to the previous target is seen late rather than rewound.
+		if target+1 < highest {
+			w.alert(AttestationTargetRewind, index, target, fmt.Sprintf(
+				"Validator %d attested to target epoch %d after attesting to target epoch %d",
+				index,
+				target,
+				highest,
+			))
+		}
+	}
+}
+
+// ObserveAttesterSlashing raises an alert for each watched validator slashed by an
+// attester slashing detected by the slasher.
+func (w *Watchlist) ObserveAttesterSlashing(slashing *ethpb.AttesterSlashing) {
+	w.observeAttesterSlashing(slashing, AttesterSlashingDetected, "Detected a double or surround vote of validator %d at target epoch %d")
+}
+
+// ObserveProposerSlashing raises an alert when a proposer slashing detected by the
+// slasher slashes a watched validator.
+func (w *Watchlist) ObserveProposerSlashing(slashing *ethpb.ProposerSlashing) {
+	w.observeProposerSlashing(slashing, ProposerSlashingDetected, "Detected a double proposal of validator %d at slot %d")
+}
+
+// ObserveBlock raises an alert for each watched validator slashed by a slashing
+// included in the block.
+func (w *Watchlist) ObserveBlock(blk *ethpb.SignedBeaconBlock) {
+	if blk == nil || blk.Block == nil || blk.Block.Body == nil {
+		return
+	}
+	for _, slashing := range blk.Block.Body.AttesterSlashings {
+		w.observeAttesterSlashing(slashing, SlashingIncluded, "Validator %d was slashed for a double or surround vote at target epoch %d")
+	}
+	for _, slashing := range blk.Block.Body.ProposerSlashings {
+		w.observeProposerSlashing(slashing, SlashingIncluded, "Validator %d was slashed for a double proposal at slot %d")
+	}
+}
+
+func (w *Watchlist) observeAttesterSlashing(slashing *ethpb.AttesterSlashing, kind AlertKind, format string) {
+	if slashing == nil || slashing.Attestation_1 == nil || slashing.Attestation_2 == nil ||
+		slashing.Attestation_1.Data == nil || slashing.Attestation_1.Data.Target == nil {
+		return
+	}
+	target := slashing.Attestation_1.Data.Target.Epoch
+	slashed := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, index := range slashed {
+		if _, ok := w.watched[index]; ok {
+			w.alert(kind, index, target, fmt.Sprintf(format, index, target))
+		}
+	}
+}
+
+func (w *Watchlist) observeProposerSlashing(slashing *ethpb.ProposerSlashing, kind AlertKind, format string) {
+	if slashing == nil || slashing.Header_1 == nil || slashing.Header_1.Header == nil {
+		return
+	}
+	header := slashing.Header_1.Header
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, ok := w.watched[header.ProposerIndex]; ok {
+		w.alert(kind, header.ProposerIndex, helpers.SlotToEpoch(header.Slot), fmt.Sprintf(format, header.ProposerIndex, header.Slot))
+	}
+}
+
+// alert logs the alert and delivers it through the notifiers in the background, unless
+// the same alert was already raised for the validator at the epoch. The caller must
+// hold the lock.
+func (w *Watchlist) alert(kind AlertKind, index uint64, epoch uint64, message string) {
+	key := alertKey{kind: kind, index: index, epoch: epoch}
+	if w.alerted[key] {
+		return
+	}
+	w.alerted[key] = true
+
+	a := &Alert{
+		Kind:           kind,
+		ValidatorIndex: index,
+		Epoch:          epoch,
+		Message:        message,
+		Time:           roughtime.Now().UTC(),
+	}
+	if pubKey := w.watched[index]; pubKey != nil {
+		a.PublicKey = fmt.Sprintf("%#x", pubKey)
+	}
+	log.WithFields(logrus.Fields{
+		"kind":           kind,
+		"validatorIndex": index,
+		"epoch":          epoch,
+	}).Warn(message)
+	alertsRaised.WithLabelValues(string(kind)).Inc()
+	for _, n := range w.notifiers {
+		go func(n Notifier) {
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			defer cancel()
+			if err := n.Notify(ctx, a); err != nil {
+				log.WithError(err).Error("Could not deliver watch list alert")
+				alertNotificationFailures.Inc()
+			}
+		}(n)
+	}
+}
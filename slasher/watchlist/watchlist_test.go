@@ -0,0 +1,152 @@
+package watchlist
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+type mockNotifier struct {
+	alerts chan *Alert
+}
+
+func (m *mockNotifier) Notify(_ context.Context, alert *Alert) error {
+	m.alerts <- alert
+	return nil
+}
+
+func (m *mockNotifier) expect(t *testing.T, kind AlertKind, index uint64) *Alert {
+	select {
+	case a := <-m.alerts:
+		if a.Kind != kind || a.ValidatorIndex != index {
+			t.Fatalf("Expected %s alert for validator %d, received %+v", kind, index, a)
+		}
+		return a
+	case <-time.After(time.Second):
+		t.Fatalf("Expected %s alert for validator %d", kind, index)
+	}
+	return nil
+}
+
+func (m *mockNotifier) expectNone(t *testing.T) {
+	select {
+	case a := <-m.alerts:
+		t.Fatalf("Expected no alert, received %+v", a)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+type mockResolver struct {
+	indices map[uint64][]byte
+}
+
+func (m *mockResolver) FindValidatorIndices(_ context.Context, _ [][]byte) (map[uint64][]byte, error) {
+	return m.indices, nil
+}
+
+func attestation(source uint64, target uint64, indices ...uint64) *ethpb.IndexedAttestation {
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &ethpb.AttestationData{
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+		},
+	}
+}
+
+func TestNew_InvalidValidators(t *testing.T) {
+	if _, err := New([]string{"abc"}, nil); err == nil {
+		t.Error("Expected error for invalid validator index")
+	}
+	if _, err := New([]string{"0x1234"}, nil); err == nil {
+		t.Error("Expected error for public key of invalid length")
+	}
+}
+
+func TestWatchlist_Resolve(t *testing.T) {
+	pubKey := bytes.Repeat([]byte{1}, 48)
+	unknownKey := bytes.Repeat([]byte{2}, 48)
+	w, err := New([]string{"3", fmt.Sprintf("%#x", pubKey), fmt.Sprintf("%#x", unknownKey)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !w.Watching(3) || w.Watching(5) {
+		t.Error("Expected only validator 3 to be watched before resolving public keys")
+	}
+	if err := w.Resolve(context.Background(), &mockResolver{indices: map[uint64][]byte{5: pubKey}}); err != nil {
+		t.Fatal(err)
+	}
+	if !w.Watching(5) {
+		t.Error("Expected validator 5 to be watched after resolving its public key")
+	}
+	if len(w.unresolvedKeys) != 1 || !bytes.Equal(w.unresolvedKeys[0], unknownKey) {
+		t.Errorf("Expected unknown public key to remain unresolved, received %v", w.unresolvedKeys)
+	}
+}
+
+func TestWatchlist_AttesterSlashing(t *testing.T) {
+	n := &mockNotifier{alerts: make(chan *Alert, 10)}
+	w, err := New([]string{"2"}, []Notifier{n})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slashing := &ethpb.AttesterSlashing{
+		Attestation_1: attestation(1, 4, 1, 2, 3),
+		Attestation_2: attestation(2, 3, 2, 3),
+	}
+	w.ObserveAttesterSlashing(slashing)
+	a := n.expect(t, AttesterSlashingDetected, 2)
+	if a.Epoch != 4 {
+		t.Errorf("Expected alert at epoch 4, received %d", a.Epoch)
+	}
+
+	// The same slashing included in a block raises a different alert, once.
+	w.ObserveAttesterSlashing(slashing)
+	w.ObserveBlock(&ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Body: &ethpb.BeaconBlockBody{
+		AttesterSlashings: []*ethpb.AttesterSlashing{slashing},
+	}}})
+	n.expect(t, SlashingIncluded, 2)
+	n.expectNone(t)
+}
+
+func TestWatchlist_ProposerSlashing(t *testing.T) {
+	n := &mockNotifier{alerts: make(chan *Alert, 10)}
+	w, err := New([]string{"7"}, []Notifier{n})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := func(proposer uint64) *ethpb.SignedBeaconBlockHeader {
+		return &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{ProposerIndex: proposer, Slot: 70}}
+	}
+	w.ObserveProposerSlashing(&ethpb.ProposerSlashing{Header_1: header(6), Header_2: header(6)})
+	n.expectNone(t)
+	w.ObserveProposerSlashing(&ethpb.ProposerSlashing{Header_1: header(7), Header_2: header(7)})
+	n.expect(t, ProposerSlashingDetected, 7)
+}
+
+func TestWatchlist_AttestationTargetRewind(t *testing.T) {
+	n := &mockNotifier{alerts: make(chan *Alert, 10)}
+	w, err := New([]string{"1"}, []Notifier{n})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.ObserveAttestation(attestation(8, 10, 1, 2))
+	// An attestation to the previous target included late is expected.
+	w.ObserveAttestation(attestation(8, 9, 1))
+	n.expectNone(t)
+
+	// Validator 2 is not watched.
+	w.ObserveAttestation(attestation(7, 8, 2))
+	n.expectNone(t)
+
+	w.ObserveAttestation(attestation(7, 8, 1))
+	a := n.expect(t, AttestationTargetRewind, 1)
+	if a.Epoch != 8 {
+		t.Errorf("Expected alert at epoch 8, received %d", a.Epoch)
+	}
+}
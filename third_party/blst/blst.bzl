@@ -0,0 +1,22 @@
+load("@bazel_tools//tools/build_defs/repo:http.bzl", "http_archive")
+
+"""
+Supranational's blst library, used as the BLS backend when building with --config=blst.
+"""
+
+def blst_dependencies():
+    _maybe(
+        http_archive,
+        name = "supranational_blst",
+        sha256 = "3320ec3224c725bcd64efc4d243bb8984c6d57f6020473ace83af29188a9e996",
+        strip_prefix = "github.com/supranational/blst@v0.3.11",
+        type = "zip",
+        urls = [
+            "https://proxy.golang.org/github.com/supranational/blst/@v/v0.3.11.zip",
+        ],
+        build_file = "@prysm//third_party/blst:blst.BUILD",
+    )
+
+def _maybe(repo_rule, name, **kwargs):
+    if name not in native.existing_rules():
+        repo_rule(name = name, **kwargs)
@@ -25,10 +25,15 @@ import (
 var (
 	outputDir = flag.String("output-dir", "", "Directory to write SSZ files to")
 	overwrite = flag.Bool("overwrite", false, "If SSZ files exist in the output directory, they will be overwritten")
+	// Sizes default to the environment overrides read by benchutil, so the files
+	// match the names the benchmarks look up.
+	validatorCount       = flag.Uint64("validator-count", benchutil.ValidatorCount, "Number of validators in the generated states, e.g. 65536 for mainnet sized states")
+	attestationsPerEpoch = flag.Uint64("attestations-per-epoch", benchutil.AttestationsPerEpoch, "Number of attestations per epoch in the generated block and states")
 )
 
 func main() {
 	flag.Parse()
+	benchutil.Configure(*validatorCount, *attestationsPerEpoch)
 	if *outputDir == "" {
 		log.Fatal("Please specify --output-dir to write SSZ files to")
 	}
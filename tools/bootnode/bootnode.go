@@ -17,9 +17,11 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -38,6 +40,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/go-ssz"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
@@ -51,22 +54,31 @@ import (
 )
 
 var (
-	debug         = flag.Bool("debug", false, "Enable debug logging")
-	logFileName   = flag.String("log-file", "", "Specify log filename, relative or absolute")
-	privateKey    = flag.String("private", "", "Private key to use for peer ID")
-	discv5port    = flag.Int("discv5-port", 4000, "Port to listen for discv5 connections")
-	kademliaPort  = flag.Int("kad-port", 4500, "Port to listen for connections to kad DHT")
-	metricsPort   = flag.Int("metrics-port", 5000, "Port to listen for connections")
-	externalIP    = flag.String("external-ip", "", "External IP for the bootnode")
-	disableKad    = flag.Bool("disable-kad", false, "Disables the bootnode from running kademlia dht")
-	log           = logrus.WithField("prefix", "bootnode")
-	kadPeersCount = promauto.NewGauge(prometheus.GaugeOpts{
+	debug          = flag.Bool("debug", false, "Enable debug logging")
+	logFileName    = flag.String("log-file", "", "Specify log filename, relative or absolute")
+	privateKey     = flag.String("private", "", "Private key to use for peer ID")
+	privateKeyFile = flag.String("private-key-file", "", "File with the hex encoded private key to use for peer ID, generated if it does not exist")
+	discv5port     = flag.Int("discv5-port", 4000, "Port to listen for discv5 connections")
+	kademliaPort   = flag.Int("kad-port", 4500, "Port to listen for connections to kad DHT")
+	metricsPort    = flag.Int("metrics-port", 5000, "Port to listen for connections")
+	externalIP     = flag.String("external-ip", "", "External IP for the bootnode")
+	disableKad     = flag.Bool("disable-kad", false, "Disables the bootnode from running kademlia dht")
+	log            = logrus.WithField("prefix", "bootnode")
+	kadPeersCount  = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "bootstrap_node_kaddht_peers",
 		Help: "The current number of kaddht peers of the bootstrap node",
 	})
 	discv5PeersCount = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "bootstrap_node_discv5_peers",
-		Help: "The current number of discv5 peers of the bootstrap node",
+		Help: "The current number of discv5 peers in the table of the bootstrap node",
+	})
+	discoveredPeersCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bootstrap_node_discovered_peers_total",
+		Help: "The number of distinct discv5 peers seen by the bootstrap node",
+	})
+	pingFailuresCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "bootstrap_node_ping_failures_total",
+		Help: "The number of pings to discv5 peers in the table which failed",
 	})
 )
 
@@ -77,6 +89,9 @@ type handler struct {
 	listener *discover.UDPv5
 }
 
+// seenNodes are the discv5 peers already counted in discoveredPeersCount.
+var seenNodes = make(map[enode.ID]bool)
+
 func main() {
 	flag.Parse()
 
@@ -121,10 +136,14 @@ func main() {
 	}
 	mux := http.NewServeMux()
 	mux.HandleFunc("/p2p", handler.httpHandler)
+	mux.HandleFunc("/enr", handler.enrHandler)
+	mux.Handle("/metrics", promhttp.Handler())
 
-	if err := http.ListenAndServe(fmt.Sprintf(":%d", *metricsPort), mux); err != nil {
-		log.Fatalf("Failed to start server %v", err)
-	}
+	go func() {
+		if err := http.ListenAndServe(fmt.Sprintf(":%d", *metricsPort), mux); err != nil {
+			log.Fatalf("Failed to start server %v", err)
+		}
+	}()
 
 	// Update metrics once per slot.
 	slotDuration := time.Duration(params.BeaconConfig().SecondsPerSlot)
@@ -223,6 +242,16 @@ func (h *handler) httpHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// enrHandler serves the current ENR of the bootnode, so that other tooling can use it as
+// a bootstrap record.
+func (h *handler) enrHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte(h.listener.Self().String() + "\n")); err != nil {
+		log.WithError(err).Error("Failed to write to http response")
+	}
+}
+
 func createLocalNode(privKey *ecdsa.PrivateKey, ipAddr net.IP, port int) (*enode.LocalNode, error) {
 	db, err := enode.OpenDB("")
 	if err != nil {
@@ -253,43 +282,84 @@ func createLocalNode(privKey *ecdsa.PrivateKey, ipAddr net.IP, port int) (*enode
 }
 
 func extractPrivateKey() (*ecdsa.PrivateKey, crypto.PrivKey) {
-	var privKey *ecdsa.PrivateKey
 	var interfaceKey crypto.PrivKey
 	if *privateKey != "" {
-		dst, err := hex.DecodeString(*privateKey)
-		if err != nil {
-			panic(err)
-		}
-		unmarshalledKey, err := crypto.UnmarshalSecp256k1PrivateKey(dst)
+		unmarshalledKey, err := privateKeyFromHex(*privateKey)
 		if err != nil {
 			panic(err)
 		}
 		interfaceKey = unmarshalledKey
-		privKey = (*ecdsa.PrivateKey)((*btcec.PrivateKey)(unmarshalledKey.(*crypto.Secp256k1PrivateKey)))
-
+	} else if key, err := loadPrivateKeyFile(*privateKeyFile); err != nil {
+		panic(err)
+	} else if key != nil {
+		interfaceKey = key
+		log.WithField("file", *privateKeyFile).Info("Loaded private key from file")
 	} else {
 		privInterfaceKey, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
 		if err != nil {
 			panic(err)
 		}
 		interfaceKey = privInterfaceKey
-		privKey = (*ecdsa.PrivateKey)((*btcec.PrivateKey)(privInterfaceKey.(*crypto.Secp256k1PrivateKey)))
-		log.Warning("No private key was provided. Using default/random private key")
 		b, err := privInterfaceKey.Raw()
 		if err != nil {
 			panic(err)
 		}
+		if *privateKeyFile != "" {
+			if err := ioutil.WriteFile(*privateKeyFile, []byte(hex.EncodeToString(b)), 0600); err != nil {
+				panic(err)
+			}
+			log.WithField("file", *privateKeyFile).Info("Saved new private key to file")
+		} else {
+			log.Warning("No private key was provided. Using default/random private key")
+		}
 		log.Debugf("Private key %x", b)
 	}
+	privKey := (*ecdsa.PrivateKey)((*btcec.PrivateKey)(interfaceKey.(*crypto.Secp256k1PrivateKey)))
 
 	return privKey, interfaceKey
 }
 
+// loadPrivateKeyFile reads the hex encoded private key from the given file. It returns
+// a nil key when no file is given or the file does not exist yet.
+func loadPrivateKeyFile(fileName string) (crypto.PrivKey, error) {
+	if fileName == "" {
+		return nil, nil
+	}
+	// #nosec G304
+	enc, err := ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read private key file")
+	}
+	return privateKeyFromHex(strings.TrimSpace(string(enc)))
+}
+
+func privateKeyFromHex(key string) (crypto.PrivKey, error) {
+	dst, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.UnmarshalSecp256k1PrivateKey(dst)
+}
+
 func updateMetrics(listener *discover.UDPv5, dht *kaddht.IpfsDHT) {
 	if dht != nil {
 		kadPeersCount.Set(float64(len(dht.Host().Peerstore().Peers())))
 	}
 	if listener != nil {
-		discv5PeersCount.Set(float64(len(listener.AllNodes())))
+		nodes := listener.AllNodes()
+		discv5PeersCount.Set(float64(len(nodes)))
+		for _, n := range nodes {
+			if !seenNodes[n.ID()] {
+				seenNodes[n.ID()] = true
+				discoveredPeersCount.Inc()
+			}
+			if err := listener.Ping(n); err != nil {
+				log.WithError(err).WithField("node", n.ID()).Debug("Failed to ping peer")
+				pingFailuresCount.Inc()
+			}
+		}
 	}
 }
@@ -4,6 +4,12 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,3 +94,44 @@ func TestPrivateKey_ParsesCorrectly(t *testing.T) {
 	}
 	*privateKey = ""
 }
+
+func TestPrivateKeyFile_PersistsGeneratedKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bootnode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Log(err)
+		}
+	}()
+	*privateKeyFile = filepath.Join(dir, "key")
+	defer func() { *privateKeyFile = "" }()
+
+	generated, _ := extractPrivateKey()
+	loaded, _ := extractPrivateKey()
+	if generated.D.Cmp(loaded.D) != 0 {
+		t.Error("Expected the generated private key to be loaded from the key file")
+	}
+}
+
+func TestENRHandler(t *testing.T) {
+	ipAddr, err := iputils.ExternalIPv4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey, _ := extractPrivateKey()
+	listener := createListener(ipAddr, 4002, discover.Config{PrivateKey: privKey})
+	defer listener.Close()
+
+	h := &handler{listener: listener}
+	rec := httptest.NewRecorder()
+	h.enrHandler(rec, httptest.NewRequest(http.MethodGet, "/enr", nil))
+	node, err := enode.Parse(enode.ValidSchemes, strings.TrimSpace(rec.Body.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.ID() != listener.Self().ID() {
+		t.Errorf("Wanted node ID %s, received %s", listener.Self().ID(), node.ID())
+	}
+}
@@ -0,0 +1,237 @@
+/**
+ * Eth1 data vote analyzer
+ *
+ * A gRPC client that scans the eth1_data votes of the blocks in recent eth1 voting
+ * periods, reports the distribution of the votes and whether the blocks proposed by
+ * the given validators follow the majority, and flags voting periods which stalled.
+ *
+ * Example: eth1-vote-analyzer --endpoint 127.0.0.1:4000 --periods 2 --proposer-index 5 --proposer-index 12
+ */
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+var log = logrus.WithField("prefix", "eth1_vote_analyzer")
+
+type indices map[uint64]bool
+
+func (i indices) String() string {
+	return "validator indices"
+}
+
+func (i indices) Set(value string) error {
+	idx, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	i[idx] = true
+	return nil
+}
+
+// voteCount is the number of blocks in a voting period voting for the same eth1 data.
+type voteCount struct {
+	data      *ethpb.Eth1Data
+	votes     uint64
+	ownVotes  uint64
+	firstSlot uint64
+}
+
+// periodReport summarizes the eth1 data votes of one eth1 voting period.
+type periodReport struct {
+	startSlot uint64
+	endSlot   uint64
+	blocks    uint64
+	// votes are sorted by the number of votes, the leading vote first.
+	votes []*voteCount
+	// majority is the vote with more than half of the period's slots, if any.
+	majority *voteCount
+	// ownBlocks and ownOffMajority count the blocks proposed by the given validators,
+	// and those of them not voting for the leading eth1 data.
+	ownBlocks      uint64
+	ownOffMajority uint64
+	// finished reports whether the head is past the end of the period.
+	finished bool
+	// unreachable reports whether no vote can reach the majority anymore in the
+	// remaining slots of the period.
+	unreachable bool
+}
+
+func main() {
+	own := make(indices)
+	endpoint := flag.String("endpoint", "127.0.0.1:4000", "gRPC endpoint of the beacon node")
+	periods := flag.Uint64("periods", 1, "Number of eth1 voting periods to analyze, ending with the current one")
+	flag.Var(own, "proposer-index", "Index of a validator run by this node, whose votes are checked against the majority. May be repeated")
+	flag.Parse()
+
+	conn, err := grpc.Dial(*endpoint, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("Failed to dial: %v", err)
+	}
+	client := ethpb.NewBeaconChainClient(conn)
+	ctx := context.Background()
+	head, err := client.GetChainHead(ctx, &ptypes.Empty{})
+	if err != nil {
+		log.Fatalf("Could not get chain head: %v", err)
+	}
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	periodSlots := params.BeaconConfig().EpochsPerEth1VotingPeriod * slotsPerEpoch
+	currentStart := head.HeadSlot - head.HeadSlot%periodSlots
+	var previous *periodReport
+	for i := *periods; i > 0; i-- {
+		offset := (i - 1) * periodSlots
+		if offset > currentStart {
+			continue
+		}
+		start := currentStart - offset
+		var blocks []*ethpb.SignedBeaconBlock
+		for epoch := start / slotsPerEpoch; epoch < (start+periodSlots)/slotsPerEpoch && epoch <= head.HeadEpoch; epoch++ {
+			epochBlocks, err := blocksInEpoch(ctx, client, epoch)
+			if err != nil {
+				log.Fatalf("Could not list blocks: %v", err)
+			}
+			blocks = append(blocks, epochBlocks...)
+		}
+		report := analyzePeriod(start, periodSlots, head.HeadSlot, blocks, own)
+		logReport(report, previous)
+		previous = report
+	}
+}
+
+// blocksInEpoch requests all canonical and non canonical blocks of the epoch.
+func blocksInEpoch(ctx context.Context, client ethpb.BeaconChainClient, epoch uint64) ([]*ethpb.SignedBeaconBlock, error) {
+	blocks := make([]*ethpb.SignedBeaconBlock, 0)
+	res := &ethpb.ListBlocksResponse{}
+	var err error
+	for {
+		res, err = client.ListBlocks(ctx, &ethpb.ListBlocksRequest{
+			QueryFilter: &ethpb.ListBlocksRequest_Epoch{
+				Epoch: epoch,
+			},
+			PageSize:  int32(params.BeaconConfig().DefaultPageSize),
+			PageToken: res.NextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, container := range res.BlockContainers {
+			blocks = append(blocks, container.Block)
+		}
+		if res.NextPageToken == "" || res.TotalSize == 0 || len(blocks) == int(res.TotalSize) {
+			break
+		}
+	}
+	return blocks, nil
+}
+
+// analyzePeriod tallies the eth1 data votes of the blocks of the voting period starting
+// at startSlot, as seen with the chain head at headSlot.
+func analyzePeriod(startSlot uint64, periodSlots uint64, headSlot uint64, blocks []*ethpb.SignedBeaconBlock, own indices) *periodReport {
+	report := &periodReport{
+		startSlot: startSlot,
+		endSlot:   startSlot + periodSlots - 1,
+		finished:  headSlot > startSlot+periodSlots-1,
+	}
+	counts := make(map[string]*voteCount)
+	for _, b := range blocks {
+		if b == nil || b.Block == nil || b.Block.Body == nil || b.Block.Body.Eth1Data == nil {
+			continue
+		}
+		if b.Block.Slot < report.startSlot || b.Block.Slot > report.endSlot {
+			continue
+		}
+		report.blocks++
+		data := b.Block.Body.Eth1Data
+		key := voteKey(data)
+		count, ok := counts[key]
+		if !ok {
+			count = &voteCount{data: data, firstSlot: b.Block.Slot}
+			counts[key] = count
+			report.votes = append(report.votes, count)
+		}
+		count.votes++
+		if b.Block.Slot < count.firstSlot {
+			count.firstSlot = b.Block.Slot
+		}
+		if own[b.Block.ProposerIndex] {
+			count.ownVotes++
+			report.ownBlocks++
+		}
+	}
+	sort.SliceStable(report.votes, func(i, j int) bool {
+		return report.votes[i].votes > report.votes[j].votes
+	})
+	if len(report.votes) > 0 {
+		leading := report.votes[0]
+		report.ownOffMajority = report.ownBlocks - leading.ownVotes
+		if leading.votes*2 > periodSlots {
+			report.majority = leading
+		}
+	}
+	if report.majority == nil {
+		var leadingVotes uint64
+		if len(report.votes) > 0 {
+			leadingVotes = report.votes[0].votes
+		}
+		var remaining uint64
+		if !report.finished && headSlot >= startSlot {
+			remaining = report.endSlot - headSlot
+		}
+		report.unreachable = (leadingVotes+remaining)*2 <= periodSlots
+	}
+	return report
+}
+
+func voteKey(data *ethpb.Eth1Data) string {
+	return fmt.Sprintf("%#x/%d/%#x", data.DepositRoot, data.DepositCount, data.BlockHash)
+}
+
+// logReport logs the vote distribution of the period and warns about votes off the
+// majority and stalls, comparing the result with the previous period if given.
+func logReport(report *periodReport, previous *periodReport) {
+	log.WithFields(logrus.Fields{
+		"startSlot":     report.startSlot,
+		"endSlot":       report.endSlot,
+		"blocks":        report.blocks,
+		"distinctVotes": len(report.votes),
+		"finished":      report.finished,
+	}).Info("Eth1 voting period")
+	for _, v := range report.votes {
+		log.WithFields(logrus.Fields{
+			"depositRoot":  fmt.Sprintf("%#x", v.data.DepositRoot),
+			"depositCount": v.data.DepositCount,
+			"blockHash":    fmt.Sprintf("%#x", v.data.BlockHash),
+			"votes":        v.votes,
+			"ownVotes":     v.ownVotes,
+			"firstSlot":    v.firstSlot,
+		}).Info("Eth1 data vote")
+	}
+	if report.ownOffMajority > 0 {
+		log.WithFields(logrus.Fields{
+			"ownBlocks":      report.ownBlocks,
+			"ownOffMajority": report.ownOffMajority,
+		}).Warn("Blocks proposed by the given validators voted against the leading eth1 data")
+	}
+	if report.majority == nil {
+		if report.unreachable {
+			log.WithField("startSlot", report.startSlot).Warn("Stall: no eth1 data vote can reach the majority in this period")
+		}
+		return
+	}
+	log.WithField("depositCount", report.majority.data.DepositCount).Info("Eth1 data majority reached")
+	if previous != nil && previous.majority != nil && voteKey(previous.majority.data) == voteKey(report.majority.data) {
+		log.WithField("startSlot", report.startSlot).Warn("Stall: the eth1 data majority did not change since the previous period")
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+func voteBlock(slot uint64, proposer uint64, depositCount uint64) *ethpb.SignedBeaconBlock {
+	return &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:          slot,
+			ProposerIndex: proposer,
+			Body: &ethpb.BeaconBlockBody{
+				Eth1Data: &ethpb.Eth1Data{
+					DepositRoot:  make([]byte, 32),
+					DepositCount: depositCount,
+					BlockHash:    make([]byte, 32),
+				},
+			},
+		},
+	}
+}
+
+func TestAnalyzePeriod_Majority(t *testing.T) {
+	blocks := []*ethpb.SignedBeaconBlock{
+		voteBlock(0, 1, 10),
+		voteBlock(1, 2, 10),
+		voteBlock(2, 3, 10),
+		voteBlock(3, 4, 11),
+		// Outside of the period.
+		voteBlock(4, 5, 11),
+	}
+	own := indices{3: true, 4: true}
+	report := analyzePeriod(0, 4, 5, blocks, own)
+	if report.blocks != 4 {
+		t.Errorf("Wanted 4 blocks, received %d", report.blocks)
+	}
+	if len(report.votes) != 2 {
+		t.Fatalf("Wanted 2 distinct votes, received %d", len(report.votes))
+	}
+	if report.majority == nil || report.majority.data.DepositCount != 10 {
+		t.Fatal("Expected the vote for 10 deposits to have the majority")
+	}
+	if report.ownBlocks != 2 || report.ownOffMajority != 1 {
+		t.Errorf("Wanted 2 own blocks with 1 off the majority, received %d and %d", report.ownBlocks, report.ownOffMajority)
+	}
+	if !report.finished || report.unreachable {
+		t.Error("Expected a finished period with a majority")
+	}
+}
+
+func TestAnalyzePeriod_Stall(t *testing.T) {
+	blocks := []*ethpb.SignedBeaconBlock{
+		voteBlock(0, 1, 10),
+		voteBlock(1, 2, 11),
+		voteBlock(2, 3, 12),
+	}
+	// With one slot left, no vote can reach more than half of the 8 slots.
+	report := analyzePeriod(0, 8, 6, blocks, indices{})
+	if report.majority != nil {
+		t.Error("Expected no majority")
+	}
+	if report.finished {
+		t.Error("Expected the period to be in progress")
+	}
+	if !report.unreachable {
+		t.Error("Expected the majority to be unreachable")
+	}
+
+	report = analyzePeriod(0, 8, 2, blocks, indices{})
+	if report.unreachable {
+		t.Error("Expected the majority to still be reachable early in the period")
+	}
+}
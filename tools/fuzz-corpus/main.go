@@ -0,0 +1,268 @@
+// Command fuzz-corpus manages the corpora and prefilled states of the targets in the
+// fuzz package. It supports the following subcommands:
+//
+//	import   -target <name> -from <dir> -corpus <dir>
+//	         Copies the inputs of a corpus, such as one from sigp/beacon-fuzz, into the
+//	         corpus directory, naming each file after the SHA-1 of its content as go-fuzz
+//	         does, so duplicate inputs are dropped.
+//	minimize -target <name> -corpus <dir>
+//	         Runs every input through the target and keeps only the smallest input for
+//	         each distinct result, removing the others.
+//	states   -from <dir> -out <dir>
+//	         Regenerates the prefilled states read through BEACONFUZZ_STATES_PATH from
+//	         arbitrary SSZ encoded beacon states, numbering them in file name order.
+//	check    -target <name> -corpus <dir>
+//	         Validates corpus health, failing on empty, misnamed or duplicate inputs,
+//	         inputs which crash the target, or a corpus with no accepted inputs.
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/fuzz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+var (
+	target    string
+	fromDir   string
+	corpusDir string
+	outDir    string
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	fs.StringVar(&target, "target", "", "Name of the fuzz target, one of: "+strings.Join(targetNames(), ", "))
+	fs.StringVar(&fromDir, "from", "", "Directory to import inputs or states from.")
+	fs.StringVar(&corpusDir, "corpus", "", "Corpus directory of the target.")
+	fs.StringVar(&outDir, "out", "", "Directory to write the prefilled states to.")
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		panic(err)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = importCorpus()
+	case "minimize":
+		err = minimizeCorpus()
+	case "states":
+		err = generateStates()
+	case "check":
+		err = checkCorpus()
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Println("Usage: fuzz-corpus <import|minimize|states|check> [flags]")
+	os.Exit(2)
+}
+
+// importCorpus copies the inputs in fromDir into corpusDir, named after their SHA-1.
+func importCorpus() error {
+	inputs, err := readDir(fromDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(corpusDir, 0755); err != nil {
+		return err
+	}
+	imported := 0
+	for _, input := range inputs {
+		dst := filepath.Join(corpusDir, inputName(input.data))
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+		if err := ioutil.WriteFile(dst, input.data, 0644); err != nil {
+			return err
+		}
+		imported++
+	}
+	fmt.Printf("Imported %d new inputs out of %d\n", imported, len(inputs))
+	return nil
+}
+
+// minimizeCorpus keeps the smallest input for each distinct result of the target.
+// Inputs are compared by whether they were accepted and by their output, as the
+// targets are not built with coverage instrumentation here.
+func minimizeCorpus() error {
+	t, err := lookupTarget()
+	if err != nil {
+		return err
+	}
+	inputs, err := readDir(corpusDir)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(inputs, func(i, j int) bool {
+		return len(inputs[i].data) < len(inputs[j].data)
+	})
+	seen := make(map[string]bool)
+	removed := 0
+	for _, input := range inputs {
+		out, ok := t(input.data)
+		key := strconv.FormatBool(ok) + inputName(out)
+		if !seen[key] {
+			seen[key] = true
+			continue
+		}
+		if err := os.Remove(filepath.Join(corpusDir, input.name)); err != nil {
+			return err
+		}
+		removed++
+	}
+	fmt.Printf("Kept %d inputs, removed %d\n", len(inputs)-removed, removed)
+	return nil
+}
+
+// generateStates writes the states in fromDir to outDir as 0, 1, 2, ... after
+// checking that each one decodes, re-encoding them in canonical SSZ.
+func generateStates() error {
+	states, err := readDir(fromDir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+	for i, s := range states {
+		st := &pb.BeaconState{}
+		if err := ssz.Unmarshal(s.data, st); err != nil {
+			return fmt.Errorf("could not decode state %s: %v", s.name, err)
+		}
+		enc, err := ssz.Marshal(st)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(outDir, strconv.Itoa(i)), enc, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("State %d: %s, slot %d, %d validators\n", i, s.name, st.Slot, len(st.Validators))
+	}
+	return nil
+}
+
+// checkCorpus validates the health of a corpus for the target.
+func checkCorpus() error {
+	t, err := lookupTarget()
+	if err != nil {
+		return err
+	}
+	inputs, err := readDir(corpusDir)
+	if err != nil {
+		return err
+	}
+	problems := 0
+	accepted := 0
+	seen := make(map[string]string)
+	for _, input := range inputs {
+		name := inputName(input.data)
+		if len(input.data) == 0 {
+			problems++
+			fmt.Printf("%s: empty input\n", input.name)
+		}
+		if input.name != name {
+			problems++
+			fmt.Printf("%s: name does not match content hash %s\n", input.name, name)
+		}
+		if other, ok := seen[name]; ok {
+			problems++
+			fmt.Printf("%s: duplicate of %s\n", input.name, other)
+		}
+		seen[name] = input.name
+		ok, err := runTarget(t, input.data)
+		if err != nil {
+			problems++
+			fmt.Printf("%s: %v\n", input.name, err)
+		}
+		if ok {
+			accepted++
+		}
+	}
+	fmt.Printf("Checked %d inputs, %d accepted by the target\n", len(inputs), accepted)
+	if accepted == 0 {
+		problems++
+		fmt.Println("No inputs accepted by the target, check " + fuzz.StatesPathEnv)
+	}
+	if problems > 0 {
+		return fmt.Errorf("found %d problems", problems)
+	}
+	return nil
+}
+
+// runTarget reports a panic in the target as an error.
+func runTarget(t fuzz.Target, input []byte) (ok bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("target panicked: %v", r)
+		}
+	}()
+	_, ok = t(input)
+	return ok, nil
+}
+
+type file struct {
+	name string
+	data []byte
+}
+
+// readDir returns the regular files in dir, sorted by name.
+func readDir(dir string) ([]file, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]file, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file{name: info.Name(), data: data})
+	}
+	return files, nil
+}
+
+func inputName(data []byte) string {
+	h := sha1.Sum(data)
+	return hex.EncodeToString(h[:])
+}
+
+func lookupTarget() (fuzz.Target, error) {
+	t, ok := fuzz.Targets[target]
+	if !ok {
+		return nil, fmt.Errorf("unknown target %q", target)
+	}
+	return t, nil
+}
+
+func targetNames() []string {
+	names := make([]string, 0, len(fuzz.Targets))
+	for name := range fuzz.Targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,71 @@
+// Command fuzz-differential replays fuzz inputs through a Prysm state target and
+// compares the post-states against the outputs another implementation produced
+// for the same inputs, reporting every input on which the two disagree.
+//
+// For every file in the inputs directory, the reference directory holds a file of
+// the same name with the SSZ encoded reference post-state, or no file if the
+// reference implementation rejected the input.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/fuzz"
+)
+
+var (
+	target       = flag.String("target", "", "Name of the state target to run, one of: "+strings.Join(targetNames(), ", "))
+	inputsDir    = flag.String("inputs", "", "Directory of fuzz inputs.")
+	referenceDir = flag.String("reference", "", "Directory of reference post-states, named after their inputs.")
+)
+
+func main() {
+	flag.Parse()
+	t, ok := fuzz.StateTargets[*target]
+	if !ok {
+		fmt.Printf("Unknown target %q\n", *target)
+		os.Exit(2)
+	}
+	files, err := ioutil.ReadDir(*inputsDir)
+	if err != nil {
+		panic(err)
+	}
+	findings := 0
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		input, err := ioutil.ReadFile(filepath.Join(*inputsDir, f.Name()))
+		if err != nil {
+			panic(err)
+		}
+		refPost, err := ioutil.ReadFile(filepath.Join(*referenceDir, f.Name()))
+		refOK := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			panic(err)
+		}
+		if err := fuzz.Differential(t, input, refPost, refOK); err != nil {
+			findings++
+			fmt.Printf("%s: %v\n", f.Name(), err)
+		}
+	}
+	fmt.Printf("Compared %d inputs, found %d mismatches\n", len(files), findings)
+	if findings > 0 {
+		os.Exit(1)
+	}
+}
+
+func targetNames() []string {
+	names := make([]string, 0, len(fuzz.StateTargets))
+	for name := range fuzz.StateTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,30 +1,51 @@
 package main
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"flag"
+	"fmt"
 	"io/ioutil"
 	"log"
+	"strings"
 
 	"github.com/ghodss/yaml"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/interop"
 	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
+// depositDataJSON is the YAML/JSON representation of a deposit data item, with
+// hex encoded byte fields as produced by the deposit CLI tools.
+type depositDataJSON struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositDataRoot       string `json:"deposit_data_root,omitempty"`
+}
+
 var (
-	numValidators    = flag.Int("num-validators", 0, "Number of validators to deterministically include in the generated genesis state")
-	useMainnetConfig = flag.Bool("mainnet-config", false, "Select whether genesis state should be generated with mainnet or minimal (default) params")
-	genesisTime      = flag.Uint64("genesis-time", 0, "Unix timestamp used as the genesis time in the generated genesis state (defaults to now)")
-	sszOutputFile    = flag.String("output-ssz", "", "Output filename of the SSZ marshaling of the generated genesis state")
-	yamlOutputFile   = flag.String("output-yaml", "", "Output filename of the YAML marshaling of the generated genesis state")
-	jsonOutputFile   = flag.String("output-json", "", "Output filename of the JSON marshaling of the generated genesis state")
+	numValidators      = flag.Int("num-validators", 0, "Number of validators to deterministically include in the generated genesis state")
+	depositsYamlFile   = flag.String("deposits-yaml", "", "YAML file with a list of deposit data items to include in the generated genesis state, instead of deterministic validators")
+	useMainnetConfig   = flag.Bool("mainnet-config", false, "Select whether genesis state should be generated with mainnet or minimal (default) params")
+	chainConfigFile    = flag.String("chain-config-file", "", "YAML file with chain config values overriding the selected params")
+	genesisTime        = flag.Uint64("genesis-time", 0, "Unix timestamp used as the genesis time in the generated genesis state (defaults to now)")
+	sszOutputFile      = flag.String("output-ssz", "", "Output filename of the SSZ marshaling of the generated genesis state")
+	yamlOutputFile     = flag.String("output-yaml", "", "Output filename of the YAML marshaling of the generated genesis state")
+	jsonOutputFile     = flag.String("output-json", "", "Output filename of the JSON marshaling of the generated genesis state")
+	depositsOutputFile = flag.String("output-deposits", "", "Output filename of the YAML list of deposit data items included in the generated genesis state")
 )
 
 func main() {
 	flag.Parse()
-	if *numValidators == 0 {
-		log.Fatal("Expected --num-validators to have been provided, received 0")
+	if *numValidators == 0 && *depositsYamlFile == "" {
+		log.Fatal("Expected --num-validators or --deposits-yaml to have been provided, received neither")
+	}
+	if *numValidators != 0 && *depositsYamlFile != "" {
+		log.Fatal("Expected only one of --num-validators or --deposits-yaml to have been provided, received both")
 	}
 	if *genesisTime == 0 {
 		log.Print("No --genesis-time specified, defaulting to now")
@@ -35,10 +56,29 @@ func main() {
 	if !*useMainnetConfig {
 		params.OverrideBeaconConfig(params.MinimalSpecConfig())
 	}
+	if *chainConfigFile != "" {
+		if err := params.LoadChainConfigFile(*chainConfigFile); err != nil {
+			log.Fatalf("Could not load chain config file: %v", err)
+		}
+	}
 
-	genesisState, _, err := interop.GenerateGenesisState(*genesisTime, uint64(*numValidators))
-	if err != nil {
-		log.Fatalf("Could not generate genesis beacon state: %v", err)
+	var genesisState *pb.BeaconState
+	var deposits []*ethpb.Deposit
+	var err error
+	if *depositsYamlFile != "" {
+		depositDataItems, depositDataRoots, err := readDepositData(*depositsYamlFile)
+		if err != nil {
+			log.Fatalf("Could not read deposit data: %v", err)
+		}
+		genesisState, deposits, err = interop.GenerateGenesisStateFromDepositData(*genesisTime, depositDataItems, depositDataRoots)
+		if err != nil {
+			log.Fatalf("Could not generate genesis beacon state: %v", err)
+		}
+	} else {
+		genesisState, deposits, err = interop.GenerateGenesisState(*genesisTime, uint64(*numValidators))
+		if err != nil {
+			log.Fatalf("Could not generate genesis beacon state: %v", err)
+		}
 	}
 	if *sszOutputFile != "" {
 		encodedState, err := ssz.Marshal(genesisState)
@@ -70,4 +110,71 @@ func main() {
 		}
 		log.Printf("Done writing to %s", *jsonOutputFile)
 	}
+	if *depositsOutputFile != "" {
+		if err := writeDepositData(*depositsOutputFile, deposits); err != nil {
+			log.Fatalf("Could not write deposit data to file: %v", err)
+		}
+		log.Printf("Done writing to %s", *depositsOutputFile)
+	}
+}
+
+// readDepositData reads a YAML list of deposit data items and computes their roots.
+func readDepositData(fileName string) ([]*ethpb.Deposit_Data, [][]byte, error) {
+	enc, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var items []*depositDataJSON
+	if err := yaml.Unmarshal(enc, &items); err != nil {
+		return nil, nil, err
+	}
+	depositDataItems := make([]*ethpb.Deposit_Data, len(items))
+	depositDataRoots := make([][]byte, len(items))
+	for i, item := range items {
+		data := &ethpb.Deposit_Data{Amount: item.Amount}
+		if data.PublicKey, err = decodeHex(item.PubKey); err != nil {
+			return nil, nil, fmt.Errorf("deposit %d: invalid pubkey: %v", i, err)
+		}
+		if data.WithdrawalCredentials, err = decodeHex(item.WithdrawalCredentials); err != nil {
+			return nil, nil, fmt.Errorf("deposit %d: invalid withdrawal credentials: %v", i, err)
+		}
+		if data.Signature, err = decodeHex(item.Signature); err != nil {
+			return nil, nil, fmt.Errorf("deposit %d: invalid signature: %v", i, err)
+		}
+		root, err := ssz.HashTreeRoot(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("deposit %d: could not compute root: %v", i, err)
+		}
+		depositDataItems[i] = data
+		depositDataRoots[i] = root[:]
+	}
+	return depositDataItems, depositDataRoots, nil
+}
+
+// writeDepositData writes the deposit data of the deposits as a YAML list, in the
+// format read by --deposits-yaml.
+func writeDepositData(fileName string, deposits []*ethpb.Deposit) error {
+	items := make([]*depositDataJSON, len(deposits))
+	for i, d := range deposits {
+		root, err := ssz.HashTreeRoot(d.Data)
+		if err != nil {
+			return err
+		}
+		items[i] = &depositDataJSON{
+			PubKey:                fmt.Sprintf("%#x", d.Data.PublicKey),
+			WithdrawalCredentials: fmt.Sprintf("%#x", d.Data.WithdrawalCredentials),
+			Amount:                d.Data.Amount,
+			Signature:             fmt.Sprintf("%#x", d.Data.Signature),
+			DepositDataRoot:       fmt.Sprintf("%#x", root),
+		}
+	}
+	enc, err := yaml.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, enc, 0644)
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
 }
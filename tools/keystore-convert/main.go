@@ -0,0 +1,260 @@
+// Converts validator keys between Prysm's keystore directory, the unencrypted keys JSON
+// file used for interop, deterministic interop keys and EIP-2335 keystores.
+//
+// A directory given as an EIP-2335 input is converted in bulk, and EIP-2335 output is
+// always written as one keystore file per key into the output directory.
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pborman/uuid"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/keystore"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	keygen "github.com/prysmaticlabs/prysm/tools/unencrypted-keys-gen"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+const (
+	formatPrysm       = "prysm"
+	formatUnencrypted = "unencrypted"
+	formatInterop     = "interop"
+	formatEIP2335     = "eip2335"
+)
+
+var (
+	from           = flag.String("from", "", "Format of the input keys, one of: prysm, unencrypted, interop, eip2335")
+	to             = flag.String("to", "", "Format of the output keys, one of: prysm, unencrypted, eip2335")
+	input          = flag.String("input", "", "Keystore directory, unencrypted keys JSON file, or EIP-2335 keystore file or directory to read")
+	output         = flag.String("output", "", "Keystore directory, unencrypted keys JSON file or EIP-2335 keystore directory to write")
+	inputPassword  = flag.String("input-password", "", "Password decrypting the input keys")
+	outputPassword = flag.String("output-password", "", "Password encrypting the output keys")
+	startIndex     = flag.Uint64("interop-start-index", 0, "Start index of the deterministic interop keys")
+	numKeys        = flag.Uint64("interop-num-keys", 0, "Number of deterministic interop keys to convert")
+)
+
+// eip2335Keystore is the JSON layout of an EIP-2335 keystore.
+type eip2335Keystore struct {
+	Crypto  map[string]interface{} `json:"crypto"`
+	Pubkey  string                 `json:"pubkey"`
+	Path    string                 `json:"path"`
+	UUID    string                 `json:"uuid"`
+	Version uint                   `json:"version"`
+}
+
+func main() {
+	flag.Parse()
+	if *from == "" || *to == "" {
+		log.Fatal("Please specify the --from and --to key formats")
+	}
+	if *from != formatInterop && *input == "" {
+		log.Fatal("Please specify the --input to read keys from")
+	}
+	if *output == "" {
+		log.Fatal("Please specify the --output to write keys to")
+	}
+
+	keys, err := readKeys(*from, *input, *inputPassword)
+	if err != nil {
+		log.Fatalf("Could not read keys: %v", err)
+	}
+	if len(keys) == 0 {
+		log.Fatal("No keys found in the input")
+	}
+	if err := writeKeys(*to, *output, *outputPassword, keys); err != nil {
+		log.Fatalf("Could not write keys: %v", err)
+	}
+	log.Printf("Converted %d keys from %s to %s", len(keys), *from, *to)
+}
+
+// readKeys loads the secret keys stored in the given format.
+func readKeys(format string, path string, password string) ([]*bls.SecretKey, error) {
+	switch format {
+	case formatPrysm:
+		return readPrysmKeys(path, password)
+	case formatUnencrypted:
+		return readUnencryptedKeys(path)
+	case formatInterop:
+		if *numKeys == 0 {
+			return nil, errors.New("please specify --interop-num-keys")
+		}
+		keys, _, err := interop.DeterministicallyGenerateKeys(*startIndex, *numKeys)
+		return keys, err
+	case formatEIP2335:
+		return readEIP2335Keys(path, password)
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+}
+
+// writeKeys stores the secret keys in the given format.
+func writeKeys(format string, path string, password string, keys []*bls.SecretKey) error {
+	switch format {
+	case formatPrysm:
+		return writePrysmKeys(path, password, keys)
+	case formatUnencrypted:
+		return writeUnencryptedKeys(path, keys)
+	case formatEIP2335:
+		return writeEIP2335Keys(path, password, keys)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+func readPrysmKeys(dir string, password string) ([]*bls.SecretKey, error) {
+	ks := keystore.NewKeystore(dir)
+	prefix := params.BeaconConfig().ValidatorPrivkeyFileName
+	stored, err := ks.GetKeys(dir, prefix, password, true /* warnOnFail */)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*bls.SecretKey, 0, len(stored))
+	for _, key := range stored {
+		keys = append(keys, key.SecretKey)
+	}
+	return keys, nil
+}
+
+func writePrysmKeys(dir string, password string, keys []*bls.SecretKey) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	ks := keystore.NewKeystore(dir)
+	for _, sk := range keys {
+		key, err := keystore.NewKeyFromBLS(sk)
+		if err != nil {
+			return err
+		}
+		pubkey := hex.EncodeToString(sk.PublicKey().Marshal())
+		filename := dir + params.BeaconConfig().ValidatorPrivkeyFileName + pubkey[:12]
+		if err := ks.StoreKey(filename, key, password); err != nil {
+			return fmt.Errorf("could not store key %s: %v", pubkey, err)
+		}
+	}
+	return nil
+}
+
+func readUnencryptedKeys(path string) ([]*bls.SecretKey, error) {
+	// #nosec G304
+	enc, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ctnr := &keygen.UnencryptedKeysContainer{}
+	if err := json.Unmarshal(enc, ctnr); err != nil {
+		return nil, err
+	}
+	keys := make([]*bls.SecretKey, len(ctnr.Keys))
+	for i, item := range ctnr.Keys {
+		keys[i], err = bls.SecretKeyFromBytes(item.ValidatorKey)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse key %d: %v", i, err)
+		}
+	}
+	return keys, nil
+}
+
+func writeUnencryptedKeys(path string, keys []*bls.SecretKey) error {
+	ctnr := &keygen.UnencryptedKeysContainer{
+		Keys: make([]*keygen.UnencryptedKeys, len(keys)),
+	}
+	for i, sk := range keys {
+		ctnr.Keys[i] = &keygen.UnencryptedKeys{
+			ValidatorKey:  sk.Marshal(),
+			WithdrawalKey: sk.Marshal(),
+		}
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := keygen.SaveUnencryptedKeysToFile(file, ctnr); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readEIP2335Keys decrypts a single keystore file, or every JSON file of a directory.
+func readEIP2335Keys(path string, password string) ([]*bls.SecretKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			if entry.Mode().IsRegular() && strings.HasSuffix(entry.Name(), ".json") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	encryptor := keystorev4.New()
+	keys := make([]*bls.SecretKey, 0, len(files))
+	for _, file := range files {
+		// #nosec G304
+		enc, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		ksJSON := &eip2335Keystore{}
+		if err := json.Unmarshal(enc, ksJSON); err != nil {
+			return nil, fmt.Errorf("could not parse keystore %s: %v", file, err)
+		}
+		secret, err := encryptor.Decrypt(ksJSON.Crypto, password)
+		if err != nil {
+			return nil, fmt.Errorf("could not decrypt keystore %s: %v", file, err)
+		}
+		sk, err := bls.SecretKeyFromBytes(secret)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse key in keystore %s: %v", file, err)
+		}
+		keys = append(keys, sk)
+	}
+	return keys, nil
+}
+
+// writeEIP2335Keys writes one keystore file per key, named after the public key.
+func writeEIP2335Keys(dir string, password string, keys []*bls.SecretKey) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	encryptor := keystorev4.New()
+	for _, sk := range keys {
+		cryptoFields, err := encryptor.Encrypt(sk.Marshal(), password)
+		if err != nil {
+			return err
+		}
+		pubkey := hex.EncodeToString(sk.PublicKey().Marshal())
+		enc, err := json.MarshalIndent(&eip2335Keystore{
+			Crypto:  cryptoFields,
+			Pubkey:  pubkey,
+			UUID:    uuid.NewRandom().String(),
+			Version: encryptor.Version(),
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		filename := filepath.Join(dir, fmt.Sprintf("keystore-%s.json", pubkey))
+		if err := ioutil.WriteFile(filename, enc, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+func TestConvert_RoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keystore-convert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Log(err)
+		}
+	}()
+	n := uint64(2)
+	numKeys = &n
+	want, err := readKeys(formatInterop, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eip2335Dir := filepath.Join(dir, "eip2335")
+	prysmDir := filepath.Join(dir, "prysm")
+	unencryptedFile := filepath.Join(dir, "keys.json")
+	steps := []struct {
+		format   string
+		path     string
+		password string
+	}{
+		{format: formatEIP2335, path: eip2335Dir, password: "foo"},
+		{format: formatPrysm, path: prysmDir, password: "bar"},
+		{format: formatUnencrypted, path: unencryptedFile},
+	}
+	keys := want
+	for _, step := range steps {
+		if err := writeKeys(step.format, step.path, step.password, keys); err != nil {
+			t.Fatalf("Could not write %s keys: %v", step.format, err)
+		}
+		keys, err = readKeys(step.format, step.path, step.password)
+		if err != nil {
+			t.Fatalf("Could not read %s keys: %v", step.format, err)
+		}
+		if !sameKeys(want, keys) {
+			t.Errorf("Keys changed after converting to %s", step.format)
+		}
+	}
+
+	if _, err := readKeys(formatEIP2335, eip2335Dir, "wrong"); err == nil {
+		t.Error("Expected decrypting EIP-2335 keystores with the wrong password to fail")
+	}
+}
+
+func sameKeys(a []*bls.SecretKey, b []*bls.SecretKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	encode := func(keys []*bls.SecretKey) [][]byte {
+		enc := make([][]byte, len(keys))
+		for i, k := range keys {
+			enc[i] = k.Marshal()
+		}
+		sort.Slice(enc, func(i, j int) bool { return bytes.Compare(enc[i], enc[j]) < 0 })
+		return enc
+	}
+	encA, encB := encode(a), encode(b)
+	for i := range encA {
+		if !bytes.Equal(encA[i], encB[i]) {
+			return false
+		}
+	}
+	return true
+}
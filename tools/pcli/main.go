@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/davecgh/go-spew/spew"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
@@ -21,10 +23,27 @@ import (
 	"gopkg.in/urfave/cli.v2"
 )
 
+// sszTypes are the objects which can be decoded by the pretty subcommand, keyed by
+// the value of the --data-type flag.
+var sszTypes = map[string]func() interface{}{
+	"block":               func() interface{} { return &ethpb.BeaconBlock{} },
+	"signed_block":        func() interface{} { return &ethpb.SignedBeaconBlock{} },
+	"block_header":        func() interface{} { return &ethpb.BeaconBlockHeader{} },
+	"attestation":         func() interface{} { return &ethpb.Attestation{} },
+	"aggregate_and_proof": func() interface{} { return &ethpb.SignedAggregateAttestationAndProof{} },
+	"deposit":             func() interface{} { return &ethpb.Deposit{} },
+	"voluntary_exit":      func() interface{} { return &ethpb.SignedVoluntaryExit{} },
+	"proposer_slashing":   func() interface{} { return &ethpb.ProposerSlashing{} },
+	"attester_slashing":   func() interface{} { return &ethpb.AttesterSlashing{} },
+	"state":               func() interface{} { return &pb.BeaconState{} },
+}
+
 func main() {
 	var blockPath string
 	var preStatePath string
 	var expectedPostStatePath string
+	var sszPath string
+	var sszType string
 
 	customFormatter := new(prefixed.TextFormatter)
 	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
@@ -36,6 +55,48 @@ func main() {
 	app.Usage = "A command line utility to run eth2 specific commands"
 	app.Version = version.GetVersion()
 	app.Commands = []*cli.Command{{
+		Name:     "pretty",
+		Aliases:  []string{"p"},
+		Category: "ssz",
+		Usage:    "Subcommand to pretty print an SSZ encoded object and its hash tree root",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "ssz-path",
+				Usage:       "Path to file(ssz)",
+				Required:    true,
+				Destination: &sszPath,
+			},
+			&cli.StringFlag{
+				Name:        "data-type",
+				Usage:       "Type of the SSZ object, one of: " + strings.Join(sszTypeNames(), ", "),
+				Required:    true,
+				Destination: &sszType,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			newObj, ok := sszTypes[sszType]
+			if !ok {
+				return fmt.Errorf("unknown data type %q", sszType)
+			}
+			obj := newObj()
+			if err := dataFetcher(sszPath, obj); err != nil {
+				return err
+			}
+			root, err := ssz.HashTreeRoot(obj)
+			if err != nil {
+				return err
+			}
+			printer := spew.ConfigState{
+				Indent:                  "  ",
+				DisableMethods:          true,
+				DisablePointerAddresses: true,
+				DisableCapacities:       true,
+			}
+			printer.Dump(obj)
+			fmt.Printf("Hash tree root: %#x\n", root)
+			return nil
+		},
+	}, {
 		Name:     "state-transition",
 		Category: "state-transition",
 		Usage:    "Subcommand to run manual state transitions",
@@ -116,6 +177,9 @@ func main() {
 				log.Fatal(err)
 			}
 			postRoot, err := postState.HashTreeRoot(context.Background())
+			if err != nil {
+				log.Fatal(err)
+			}
 			log.Infof("Finished state transition with post state root of %#x", postRoot)
 
 			// Diff the state if a post state is provided.
@@ -139,6 +203,15 @@ func main() {
 	}
 }
 
+func sszTypeNames() []string {
+	names := make([]string, 0, len(sszTypes))
+	for name := range sszTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // dataFetcher fetches and unmarshals data from file to provided data structure.
 func dataFetcher(fPath string, data interface{}) error {
 	rawFile, err := ioutil.ReadFile(fPath)
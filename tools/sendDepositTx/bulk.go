@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
+	"github.com/sirupsen/logrus"
+)
+
+// depositDataJSON is a deposit data item as written by the deposit CLI tools, in JSON or
+// YAML files holding a list of items. Byte fields are hex encoded, with or without 0x.
+type depositDataJSON struct {
+	PubKey                string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+}
+
+// depositItem is a decoded deposit to send to the deposit contract.
+type depositItem struct {
+	pubKey                []byte
+	withdrawalCredentials []byte
+	amount                uint64
+	signature             []byte
+	root                  [32]byte
+}
+
+// depositBackend is the eth1 backend deposits are sent through and waited on.
+type depositBackend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+}
+
+// bulkSender sends deposits to the deposit contract in batches, waiting for every batch
+// to be mined before sending the next one. Nonces are assigned locally, so the
+// transactions of a batch can be pending at the same time. The root of every mined
+// deposit is appended to the progress file, and deposits found in it are skipped, so
+// an interrupted run can be resumed.
+type bulkSender struct {
+	backend      depositBackend
+	contract     *contracts.DepositContract
+	txOpts       *bind.TransactOpts
+	batchSize    int
+	gasLimit     uint64
+	maxGasPrice  *big.Int
+	pollInterval time.Duration
+	progressFile string
+	sent         map[[32]byte]bool
+}
+
+// readDepositDataDir reads the deposit data items of every JSON and YAML file in the
+// directory, in file name order.
+func readDepositDataDir(dir string) ([]*depositItem, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		ext := filepath.Ext(f.Name())
+		if !f.IsDir() && (ext == ".json" || ext == ".yaml" || ext == ".yml") {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	var items []*depositItem
+	for _, name := range names {
+		// #nosec - Inclusion of file via variable is OK for this tool.
+		enc, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		var data []*depositDataJSON
+		if err := yaml.Unmarshal(enc, &data); err != nil {
+			return nil, errors.Wrapf(err, "could not parse deposit data file %s", name)
+		}
+		for i, d := range data {
+			item, err := decodeDepositData(d)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid deposit %d in file %s", i, name)
+			}
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func decodeDepositData(d *depositDataJSON) (*depositItem, error) {
+	item := &depositItem{amount: d.Amount}
+	var err error
+	if item.pubKey, err = decodeHex(d.PubKey); err != nil {
+		return nil, errors.Wrap(err, "invalid pubkey")
+	}
+	if item.withdrawalCredentials, err = decodeHex(d.WithdrawalCredentials); err != nil {
+		return nil, errors.Wrap(err, "invalid withdrawal credentials")
+	}
+	if item.signature, err = decodeHex(d.Signature); err != nil {
+		return nil, errors.Wrap(err, "invalid signature")
+	}
+	root, err := decodeHex(d.DepositDataRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid deposit data root")
+	}
+	if len(root) != 32 {
+		return nil, fmt.Errorf("deposit data root has length %d, wanted 32", len(root))
+	}
+	copy(item.root[:], root)
+	return item, nil
+}
+
+func decodeHex(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+// loadProgress reads the roots of the deposits sent by previous runs.
+func (s *bulkSender) loadProgress() error {
+	s.sent = make(map[[32]byte]bool)
+	// #nosec - Inclusion of file via variable is OK for this tool.
+	file, err := os.Open(s.progressFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.WithError(err).Error("Could not close progress file")
+		}
+	}()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		root, err := decodeHex(fields[0])
+		if err != nil || len(root) != 32 {
+			return fmt.Errorf("invalid line in progress file: %q", scanner.Text())
+		}
+		var r [32]byte
+		copy(r[:], root)
+		s.sent[r] = true
+	}
+	return scanner.Err()
+}
+
+// recordProgress appends a mined deposit to the progress file.
+func (s *bulkSender) recordProgress(item *depositItem, tx *types.Transaction) error {
+	file, err := os.OpenFile(s.progressFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(file, "%#x %#x\n", item.root, tx.Hash()); err != nil {
+		_ = file.Close()
+		return err
+	}
+	s.sent[item.root] = true
+	return file.Close()
+}
+
+// sendAll sends the deposits which have not been sent yet.
+func (s *bulkSender) sendAll(ctx context.Context, items []*depositItem) error {
+	if err := s.loadProgress(); err != nil {
+		return errors.Wrap(err, "could not load progress file")
+	}
+	pending := make([]*depositItem, 0, len(items))
+	for _, item := range items {
+		if !s.sent[item.root] {
+			pending = append(pending, item)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"total":   len(items),
+		"pending": len(pending),
+	}).Info("Sending deposits")
+
+	nonce, err := s.backend.PendingNonceAt(ctx, s.txOpts.From)
+	if err != nil {
+		return errors.Wrap(err, "could not get account nonce")
+	}
+	for start := 0; start < len(pending); start += s.batchSize {
+		end := start + s.batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		gasPrice, err := s.gasPrice(ctx)
+		if err != nil {
+			return err
+		}
+		batch := pending[start:end]
+		txs := make([]*types.Transaction, len(batch))
+		for i, item := range batch {
+			txs[i], err = s.send(ctx, item, nonce, gasPrice)
+			if err != nil && strings.Contains(err.Error(), "nonce too low") {
+				// Transactions were sent from this account by someone else, resync the nonce.
+				if nonce, err = s.backend.PendingNonceAt(ctx, s.txOpts.From); err != nil {
+					return errors.Wrap(err, "could not get account nonce")
+				}
+				txs[i], err = s.send(ctx, item, nonce, gasPrice)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "could not send deposit for validator %#x", item.pubKey)
+			}
+			nonce++
+			log.WithFields(logrus.Fields{
+				"txHash": fmt.Sprintf("%#x", txs[i].Hash()),
+				"nonce":  txs[i].Nonce(),
+			}).Infof("Sent deposit for validator with a public key %#x", item.pubKey)
+		}
+		for i, tx := range txs {
+			receipt, err := bind.WaitMined(ctx, s.backend, tx)
+			if err != nil {
+				return errors.Wrapf(err, "could not wait for transaction %#x", tx.Hash())
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return fmt.Errorf("deposit transaction %#x for validator %#x failed", tx.Hash(), batch[i].pubKey)
+			}
+			if err := s.recordProgress(batch[i], tx); err != nil {
+				return errors.Wrap(err, "could not record progress")
+			}
+		}
+		log.WithField("sent", end).Infof("Batch of %d deposits mined", len(batch))
+	}
+	return nil
+}
+
+func (s *bulkSender) send(ctx context.Context, item *depositItem, nonce uint64, gasPrice *big.Int) (*types.Transaction, error) {
+	opts := &bind.TransactOpts{
+		From:     s.txOpts.From,
+		Signer:   s.txOpts.Signer,
+		Nonce:    new(big.Int).SetUint64(nonce),
+		Value:    new(big.Int).Mul(new(big.Int).SetUint64(item.amount), big.NewInt(1e9)),
+		GasPrice: gasPrice,
+		GasLimit: s.gasLimit,
+		Context:  ctx,
+	}
+	return s.contract.Deposit(opts, item.pubKey, item.withdrawalCredentials, item.signature, item.root)
+}
+
+// gasPrice returns the suggested gas price, waiting for it to drop to the maximum gas
+// price if one is set.
+func (s *bulkSender) gasPrice(ctx context.Context) (*big.Int, error) {
+	for {
+		price, err := s.backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get gas price")
+		}
+		if s.maxGasPrice == nil || price.Cmp(s.maxGasPrice) <= 0 {
+			return price, nil
+		}
+		log.WithFields(logrus.Fields{
+			"gasPrice":    price,
+			"maxGasPrice": s.maxGasPrice,
+		}).Info("Gas price above maximum, waiting")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	contracts "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
+	"github.com/prysmaticlabs/prysm/shared/interop"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestBulkSender_SendsBatchesAndResumes(t *testing.T) {
+	testutil.ResetCache()
+	testAcc, err := contracts.Setup()
+	if err != nil {
+		t.Fatalf("Unable to set up simulated backend %v", err)
+	}
+	testAcc.Backend.Commit()
+
+	dir, err := ioutil.TempDir("", "deposits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	privKeys, pubKeys, err := interop.DeterministicallyGenerateKeys(0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositData, depositDataRoots, err := interop.DepositDataFromKeys(privKeys, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := make([]*depositDataJSON, len(depositData))
+	for i, d := range depositData {
+		items[i] = &depositDataJSON{
+			PubKey:                fmt.Sprintf("%x", d.PublicKey),
+			WithdrawalCredentials: fmt.Sprintf("%x", d.WithdrawalCredentials),
+			Amount:                d.Amount,
+			Signature:             fmt.Sprintf("%x", d.Signature),
+			DepositDataRoot:       fmt.Sprintf("%x", depositDataRoots[i]),
+		}
+	}
+	enc, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "deposit_data.json"), enc, 0600); err != nil {
+		t.Fatal(err)
+	}
+	deposits, err := readDepositDataDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deposits) != 3 {
+		t.Fatalf("Wanted 3 deposits, received %d", len(deposits))
+	}
+
+	// Mine the sent transactions while the sender waits for them.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(100 * time.Millisecond):
+				testAcc.Backend.Commit()
+			}
+		}
+	}()
+
+	sender := &bulkSender{
+		backend:      testAcc.Backend,
+		contract:     testAcc.Contract,
+		txOpts:       testAcc.TxOpts,
+		batchSize:    2,
+		gasLimit:     1000000,
+		pollInterval: 10 * time.Millisecond,
+		progressFile: filepath.Join(dir, "progress.txt"),
+	}
+	if err := sender.sendAll(context.Background(), deposits); err != nil {
+		t.Fatal(err)
+	}
+	// All deposits are recorded, so a second run sends nothing.
+	if err := sender.sendAll(context.Background(), deposits); err != nil {
+		t.Fatal(err)
+	}
+
+	logs, err := testAcc.Backend.FilterLogs(context.Background(), ethereum.FilterQuery{
+		Addresses: []common.Address{testAcc.ContractAddr},
+	})
+	if err != nil {
+		t.Fatalf("Unable to retrieve logs %v", err)
+	}
+	if len(logs) != 3 {
+		t.Errorf("Wanted 3 deposit logs, received %d", len(logs))
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
@@ -41,6 +42,11 @@ func main() {
 	var depositAmount int64
 	var depositDelay int64
 	var randomKey bool
+	var depositDataDir string
+	var batchSize int
+	var gasLimit uint64
+	var maxGasPrice int64
+	var progressFile string
 
 	customFormatter := new(prefixed.TextFormatter)
 	customFormatter.TimestampFormat = "2006-01-02 15:04:05"
@@ -112,6 +118,34 @@ func main() {
 			Usage:       "Use a randomly generated keystore key",
 			Destination: &randomKey,
 		},
+		&cli.StringFlag{
+			Name:        "deposit-data-dir",
+			Usage:       "Directory of deposit data JSON or YAML files to send, instead of deposits for keystore keys",
+			Destination: &depositDataDir,
+		},
+		&cli.IntFlag{
+			Name:        "batch-size",
+			Value:       10,
+			Usage:       "Number of deposits from --deposit-data-dir sent before waiting for them to be mined",
+			Destination: &batchSize,
+		},
+		&cli.Uint64Flag{
+			Name:        "gas-limit",
+			Value:       500000,
+			Usage:       "Gas limit of the deposits sent from --deposit-data-dir",
+			Destination: &gasLimit,
+		},
+		&cli.Int64Flag{
+			Name:        "max-gas-price",
+			Usage:       "Maximum gas price in gwei for the deposits sent from --deposit-data-dir, waiting while the suggested price is higher. 0 for no maximum",
+			Destination: &maxGasPrice,
+		},
+		&cli.StringFlag{
+			Name:        "progress-file",
+			Value:       "./deposit-progress.txt",
+			Usage:       "File recording the deposits sent from --deposit-data-dir, so an interrupted run can be resumed",
+			Destination: &progressFile,
+		},
 	}
 
 	app.Action = func(c *cli.Context) error {
@@ -165,6 +199,29 @@ func main() {
 			return err
 		}
 
+		if depositDataDir != "" {
+			items, err := readDepositDataDir(depositDataDir)
+			if err != nil {
+				return err
+			}
+			if batchSize <= 0 {
+				return errors.New("batch size must be positive")
+			}
+			sender := &bulkSender{
+				backend:      client,
+				contract:     depositContract,
+				txOpts:       txOps,
+				batchSize:    batchSize,
+				gasLimit:     gasLimit,
+				pollInterval: time.Duration(depositDelay) * time.Second,
+				progressFile: progressFile,
+			}
+			if maxGasPrice > 0 {
+				sender.maxGasPrice = new(big.Int).Mul(big.NewInt(maxGasPrice), big.NewInt(1e9))
+			}
+			return sender.sendAll(context.Background(), items)
+		}
+
 		validatorKeys := make(map[string]*prysmKeyStore.Key)
 		if randomKey {
 			validatorKey, err := prysmKeyStore.NewKey()
@@ -0,0 +1,79 @@
+/**
+ * Spec test runner
+ *
+ * Runs the official eth2 spec test vectors against the state transition and ssz encoding,
+ * without bazel. The test vectors are downloaded when a release version is given.
+ *
+ * Example: spectest-runner --dir /tmp/spectests --preset minimal --download v0.11.1
+ * Running a single handler: spectest-runner --dir /tmp/spectests --preset minimal --handler sanity/blocks
+ */
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/shared/conformance"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "spectest-runner")
+
+func main() {
+	dir := flag.String("dir", "", "Directory containing the spec test vectors, or to download them into")
+	preset := flag.String("preset", "minimal", "Configuration preset of the test vectors (minimal, mainnet)")
+	download := flag.String("download", "", "Release of the spec test vectors to download before running, e.g. "+conformance.DefaultVersion)
+	handler := flag.String("handler", "", "Only run the handlers starting with this name, one of: "+strings.Join(conformance.Handlers(), ", "))
+	verbose := flag.Bool("verbose", false, "Log the result of every test case")
+	flag.Parse()
+
+	if *dir == "" {
+		log.Fatal("Expected a directory for the spec test vectors")
+	}
+	if *download != "" {
+		if err := conformance.Download(context.Background(), *download, *preset, *dir); err != nil {
+			log.Fatalf("Could not download spec test vectors: %v", err)
+		}
+	}
+	runner, err := conformance.NewRunner(*dir, *preset)
+	if err != nil {
+		log.Fatalf("Could not create runner: %v", err)
+	}
+	results, err := runner.Run(*handler)
+	if err != nil {
+		log.Fatalf("Could not run spec tests: %v", err)
+	}
+
+	var passed, failed, skipped int
+	for _, res := range results {
+		fields := logrus.Fields{
+			"handler": res.Handler,
+			"case":    res.Case,
+		}
+		switch {
+		case res.Skipped:
+			skipped++
+			if *verbose {
+				log.WithFields(fields).Info("Skipped")
+			}
+		case res.Err != nil:
+			failed++
+			log.WithFields(fields).WithError(res.Err).Error("Failed")
+		default:
+			passed++
+			if *verbose {
+				log.WithFields(fields).Info("Passed")
+			}
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"passed":  passed,
+		"failed":  failed,
+		"skipped": skipped,
+	}).Info("Finished running spec tests")
+	if failed > 0 || passed == 0 {
+		os.Exit(1)
+	}
+}
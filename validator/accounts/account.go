@@ -2,10 +2,9 @@
 package accounts
 
 import (
-	"bufio"
-	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/user"
 	"path/filepath"
@@ -14,14 +13,19 @@ import (
 
 	"github.com/pkg/errors"
 	contract "github.com/prysmaticlabs/prysm/contracts/deposit-contract"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/keystore"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/promptutil"
 	"github.com/sirupsen/logrus"
-	"golang.org/x/crypto/ssh/terminal"
 )
 
 var log = logrus.WithField("prefix", "accounts")
 
+// PasswordEnvVar is the environment variable from which the keystore password
+// is read when it is not given as a flag, before prompting for it.
+const PasswordEnvVar = "VALIDATOR_PASSWORD"
+
 // DecryptKeysFromKeystore extracts a set of validator private keys from
 // an encrypted keystore directory and a password string.
 func DecryptKeysFromKeystore(directory string, password string) (map[string]*keystore.Key, error) {
@@ -59,30 +63,20 @@ func VerifyAccountNotExists(directory string, password string) error {
 // generates a BLS private and public key, and then logs the serialized deposit input hex string
 // to be used in an ETH1.0 transaction by the validator.
 func NewValidatorAccount(directory string, password string) error {
-	shardWithdrawalKeyFile := directory + params.BeaconConfig().WithdrawalPrivkeyFileName
-	validatorKeyFile := directory + params.BeaconConfig().ValidatorPrivkeyFileName
 	ks := keystore.NewKeystore(directory)
 	// If the keystore does not exists at the path, we create a new one for the validator.
-	shardWithdrawalKey, err := keystore.NewKey()
+	shardWithdrawalKey, shardWithdrawalKeyFile, err := storeNewKey(ks, directory+params.BeaconConfig().WithdrawalPrivkeyFileName, password)
 	if err != nil {
 		return err
 	}
-	shardWithdrawalKeyFile = shardWithdrawalKeyFile + hex.EncodeToString(shardWithdrawalKey.PublicKey.Marshal())[:12]
-	if err := ks.StoreKey(shardWithdrawalKeyFile, shardWithdrawalKey, password); err != nil {
-		return errors.Wrap(err, "unable to store key")
-	}
 	log.WithField(
 		"path",
 		shardWithdrawalKeyFile,
 	).Info("Keystore generated for shard withdrawals at path")
-	validatorKey, err := keystore.NewKey()
+	validatorKey, validatorKeyFile, err := storeNewKey(ks, directory+params.BeaconConfig().ValidatorPrivkeyFileName, password)
 	if err != nil {
 		return err
 	}
-	validatorKeyFile = validatorKeyFile + hex.EncodeToString(validatorKey.PublicKey.Marshal())[:12]
-	if err := ks.StoreKey(validatorKeyFile, validatorKey, password); err != nil {
-		return errors.Wrap(err, "unable to store key")
-	}
 	log.WithField(
 		"path",
 		validatorKeyFile,
@@ -113,6 +107,49 @@ func NewValidatorAccount(directory string, password string) error {
 	return nil
 }
 
+// DeleteValidatorKeys removes the keystore files holding the validator keys of the given public
+// keys from the keystore directory. It returns an error if any of the keys could not be found.
+func DeleteValidatorKeys(directory string, password string, pubKeys [][48]byte) error {
+	remaining := make(map[[48]byte]bool, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		remaining[pubKey] = true
+	}
+	validatorPrefix := strings.TrimPrefix(params.BeaconConfig().ValidatorPrivkeyFileName, "/")
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return errors.Wrap(err, "could not read keystore directory")
+	}
+	for _, f := range files {
+		if !f.Mode().IsRegular() || !strings.Contains(f.Name(), validatorPrefix) {
+			continue
+		}
+		filePath := filepath.Join(directory, f.Name())
+		// #nosec G304
+		keyJSON, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		key, err := keystore.DecryptKey(keyJSON, password)
+		if err != nil {
+			log.WithError(err).WithField("path", filePath).Warn("Failed to decrypt key")
+			continue
+		}
+		pubKey := bytesutil.ToBytes48(key.PublicKey.Marshal())
+		if !remaining[pubKey] {
+			continue
+		}
+		if err := os.Remove(filePath); err != nil {
+			return errors.Wrapf(err, "could not delete keystore file of %#x", pubKey)
+		}
+		delete(remaining, pubKey)
+		log.WithField("path", filePath).Info("Deleted validator keystore file")
+	}
+	for pubKey := range remaining {
+		return fmt.Errorf("no keystore file found for public key %#x", pubKey)
+	}
+	return nil
+}
+
 // Exists checks if a validator account at a given keystore path exists.
 func Exists(keystorePath string) (bool, error) {
 	/* #nosec */
@@ -135,31 +172,34 @@ func Exists(keystorePath string) (bool, error) {
 
 // CreateValidatorAccount creates a validator account from the given cli context.
 func CreateValidatorAccount(path string, passphrase string) (string, string, error) {
+	path, passphrase, err := promptAccountPathAndPassword(path, passphrase)
+	if err != nil {
+		return path, passphrase, err
+	}
+	if err := NewValidatorAccount(path, passphrase); err != nil {
+		return "", "", errors.Wrapf(err, "could not initialize validator account")
+	}
+	return path, passphrase, nil
+}
+
+// promptAccountPathAndPassword prompts for the keystore path and password of new accounts when
+// they are not given, and checks that a non-default keystore path exists.
+func promptAccountPathAndPassword(path string, passphrase string) (string, string, error) {
 	if passphrase == "" {
 		log.Info("Create a new validator account for eth2")
-		log.Info("Enter a password:")
-		bytePassword, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+		text, err := promptutil.Password("Enter a password", PasswordEnvVar, "", true /* confirm */)
 		if err != nil {
-			log.Fatalf("Could not read account password: %v", err)
-			return path, passphrase, err
+			return path, passphrase, errors.Wrap(err, "could not read account password")
 		}
-		text := string(bytePassword)
-		passphrase = strings.Replace(text, "\n", "", -1)
-
+		passphrase = text
 	}
 
 	if path == "" {
-		path = DefaultValidatorDir()
-		log.Infof("Please specify a keystore path to save your private keys (default: %q):", path)
-		reader := bufio.NewReader(os.Stdin)
-		text, err := reader.ReadString('\n')
+		text, err := promptutil.DefaultPrompt("Please specify a keystore path to save your private keys", DefaultValidatorDir())
 		if err != nil {
-			log.Fatal(err)
 			return path, passphrase, err
 		}
-		if text = strings.Replace(text, "\n", "", -1); text != "" {
-			path = text
-		}
+		path = text
 	}
 	// Forces user to create directory if using non-default path.
 	if path != DefaultValidatorDir() {
@@ -171,9 +211,6 @@ func CreateValidatorAccount(path string, passphrase string) (string, string, err
 			return path, passphrase, fmt.Errorf("path %q does not exist", path)
 		}
 	}
-	if err := NewValidatorAccount(path, passphrase); err != nil {
-		return "", "", errors.Wrapf(err, "could not initialize validator account")
-	}
 	return path, passphrase, nil
 }
 
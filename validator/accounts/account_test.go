@@ -6,6 +6,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/keystore"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
@@ -49,3 +50,88 @@ func TestNewValidatorAccount_CreateValidatorAccount(t *testing.T) {
 		t.Errorf("expected error not thrown, want: %v, got: %v", wantErrString, err)
 	}
 }
+
+func TestDeleteValidatorKeys(t *testing.T) {
+	directory := testutil.TempDir() + "/testkeystore"
+	defer func() {
+		if err := os.RemoveAll(directory); err != nil {
+			t.Logf("Could not remove directory: %v", err)
+		}
+	}()
+	ks := keystore.NewKeystore(directory)
+	var pubKeys [][48]byte
+	for i := 0; i < 2; i++ {
+		validatorKey, err := keystore.NewKey()
+		if err != nil {
+			t.Fatalf("Cannot create new key: %v", err)
+		}
+		filePath := fmt.Sprintf("%s%s%d", directory, params.BeaconConfig().ValidatorPrivkeyFileName, i)
+		if err := ks.StoreKey(filePath, validatorKey, "password"); err != nil {
+			t.Fatalf("Unable to store key %v", err)
+		}
+		pubKeys = append(pubKeys, bytesutil.ToBytes48(validatorKey.PublicKey.Marshal()))
+	}
+
+	if err := DeleteValidatorKeys(directory, "password", pubKeys[:1]); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := DecryptKeysFromKeystore(directory, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("Expected a single key to be left, received %d", len(keys))
+	}
+	for _, key := range keys {
+		if bytesutil.ToBytes48(key.PublicKey.Marshal()) != pubKeys[1] {
+			t.Error("Expected the key which was not deleted to be left")
+		}
+	}
+
+	if err := DeleteValidatorKeys(directory, "password", pubKeys[:1]); err == nil {
+		t.Error("Expected error deleting a key which does not exist")
+	}
+}
+
+func TestNewValidatorAccounts(t *testing.T) {
+	directory := testutil.TempDir() + "/testkeystore"
+	defer func() {
+		if err := os.RemoveAll(directory); err != nil {
+			t.Logf("Could not remove directory: %v", err)
+		}
+	}()
+	manifest, err := NewValidatorAccounts(directory, "", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest) != 3 {
+		t.Fatalf("Wanted 3 manifest entries, received %d", len(manifest))
+	}
+	pubKeys := make(map[string]bool)
+	for i, entry := range manifest {
+		if entry.Index != i {
+			t.Errorf("Wanted entry %d to have index %d, received %d", i, i, entry.Index)
+		}
+		if entry.Amount != params.BeaconConfig().MaxEffectiveBalance {
+			t.Errorf("Wanted deposit amount %d, received %d", params.BeaconConfig().MaxEffectiveBalance, entry.Amount)
+		}
+		pubKeys[entry.PublicKey] = true
+	}
+	if len(pubKeys) != 3 {
+		t.Errorf("Wanted 3 distinct public keys, received %d", len(pubKeys))
+	}
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 6 {
+		t.Errorf("Wanted a validator and withdrawal keystore per account, received %d files", len(files))
+	}
+	keys, err := DecryptKeysFromKeystore(directory, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 {
+		t.Errorf("Wanted 3 validator keys in the keystore, received %d", len(keys))
+	}
+}
@@ -0,0 +1,148 @@
+package accounts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/keystore"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// DepositManifestEntry describes an account created in a batch, with the deposit data needed to
+// activate it. The deposit fields are hex encoded without prefix, as expected by deposit tooling.
+type DepositManifestEntry struct {
+	Index                 int    `json:"index"`
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                uint64 `json:"amount"`
+	Signature             string `json:"signature"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	ValidatorKeystore     string `json:"validator_keystore"`
+	WithdrawalKeystore    string `json:"withdrawal_keystore"`
+}
+
+// CreateValidatorAccounts creates count validator accounts from the given cli context, and writes
+// the manifest of their public keys and deposit data to manifestPath.
+func CreateValidatorAccounts(path string, passphrase string, count int, manifestPath string) (string, string, error) {
+	if count < 1 {
+		return path, passphrase, errors.Errorf("expected at least 1 account to create, received %d", count)
+	}
+	path, passphrase, err := promptAccountPathAndPassword(path, passphrase)
+	if err != nil {
+		return path, passphrase, err
+	}
+	manifest, err := NewValidatorAccounts(path, passphrase, count)
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not initialize validator accounts")
+	}
+	enc, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", "", errors.Wrap(err, "could not encode deposit manifest")
+	}
+	if err := ioutil.WriteFile(manifestPath, enc, 0600); err != nil {
+		return "", "", errors.Wrap(err, "could not write deposit manifest")
+	}
+	log.WithField("path", manifestPath).Info("Wrote public keys and deposit data of the created accounts")
+	return path, passphrase, nil
+}
+
+// NewValidatorAccounts generates count validator accounts in the keystore directory, encrypting
+// their keystores in parallel, and returns the deposit data of every account in creation order.
+// Keystores written before an error are left in place.
+func NewValidatorAccounts(directory string, password string, count int) ([]*DepositManifestEntry, error) {
+	ks := keystore.NewKeystore(directory)
+	manifest := make([]*DepositManifestEntry, count)
+	indices := make(chan int, count)
+	for i := 0; i < count; i++ {
+		indices <- i
+	}
+	close(indices)
+
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		created  int
+		firstErr error
+	)
+	workers := runtime.NumCPU()
+	if workers > count {
+		workers = count
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				lock.Lock()
+				failed := firstErr != nil
+				lock.Unlock()
+				if failed {
+					return
+				}
+				entry, err := newManifestAccount(ks, directory, password, i)
+				lock.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = errors.Wrapf(err, "could not create account %d", i)
+					}
+					lock.Unlock()
+					return
+				}
+				manifest[i] = entry
+				created++
+				log.WithField("pubKey", "0x"+entry.PublicKey[:12]).Infof("Created account %d/%d", created, count)
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return manifest, nil
+}
+
+// newManifestAccount generates and stores the validator and withdrawal keys of an account, and
+// returns its deposit data.
+func newManifestAccount(ks keystore.Store, directory string, password string, index int) (*DepositManifestEntry, error) {
+	withdrawalKey, withdrawalKeyFile, err := storeNewKey(ks, directory+params.BeaconConfig().WithdrawalPrivkeyFileName, password)
+	if err != nil {
+		return nil, err
+	}
+	validatorKey, validatorKeyFile, err := storeNewKey(ks, directory+params.BeaconConfig().ValidatorPrivkeyFileName, password)
+	if err != nil {
+		return nil, err
+	}
+	data, depositRoot, err := keystore.DepositInput(validatorKey, withdrawalKey, params.BeaconConfig().MaxEffectiveBalance)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to generate deposit data")
+	}
+	return &DepositManifestEntry{
+		Index:                 index,
+		PublicKey:             hex.EncodeToString(data.PublicKey),
+		WithdrawalCredentials: hex.EncodeToString(data.WithdrawalCredentials),
+		Amount:                data.Amount,
+		Signature:             hex.EncodeToString(data.Signature),
+		DepositDataRoot:       hex.EncodeToString(depositRoot[:]),
+		ValidatorKeystore:     validatorKeyFile,
+		WithdrawalKeystore:    withdrawalKeyFile,
+	}, nil
+}
+
+// storeNewKey generates a new key and stores it encrypted in a keystore file named after the
+// prefix and the start of the public key.
+func storeNewKey(ks keystore.Store, filePrefix string, password string) (*keystore.Key, string, error) {
+	key, err := keystore.NewKey()
+	if err != nil {
+		return nil, "", err
+	}
+	keyFile := filePrefix + hex.EncodeToString(key.PublicKey.Marshal())[:12]
+	if err := ks.StoreKey(keyFile, key, password); err != nil {
+		return nil, "", errors.Wrap(err, "unable to store key")
+	}
+	return key, keyFile, nil
+}
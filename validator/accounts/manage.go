@@ -0,0 +1,101 @@
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/validator/db"
+)
+
+// ParsePublicKeys decodes a list of hex encoded validator public keys.
+func ParsePublicKeys(encoded []string) ([][48]byte, error) {
+	pubKeys := make([][48]byte, 0, len(encoded))
+	for _, enc := range encoded {
+		pubKey, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(enc), "0x"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode public key %s", enc)
+		}
+		if len(pubKey) != 48 {
+			return nil, fmt.Errorf("public key %s is %d bytes long, expected 48", enc, len(pubKey))
+		}
+		pubKeys = append(pubKeys, bytesutil.ToBytes48(pubKey))
+	}
+	return pubKeys, nil
+}
+
+// DeleteAccounts removes the validator accounts of the given public keys. Their slashing
+// protection records are written to protectionFile before the keystore files and the records
+// are deleted, so the keys can be safely imported into another validator client.
+func DeleteAccounts(ctx context.Context, keystorePath, password, dataDir, protectionFile string, pubKeys [][48]byte) error {
+	valDB, err := db.NewKVStore(dataDir, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "could not open validator db")
+	}
+	defer func() {
+		if err := valDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator db")
+		}
+	}()
+
+	interchange, err := valDB.ExportSlashingProtection(ctx, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "could not export slashing protection records")
+	}
+	enc, err := json.MarshalIndent(interchange, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "could not encode slashing protection records")
+	}
+	if err := ioutil.WriteFile(protectionFile, enc, 0600); err != nil {
+		return errors.Wrap(err, "could not write slashing protection records")
+	}
+	log.WithField("path", protectionFile).Info("Exported slashing protection records of the deleted accounts")
+
+	if err := DeleteValidatorKeys(keystorePath, password, pubKeys); err != nil {
+		return err
+	}
+	for _, pubKey := range pubKeys {
+		if err := valDB.DeleteProposalHistory(ctx, pubKey[:]); err != nil {
+			return err
+		}
+		if err := valDB.DeleteAttestationHistory(ctx, pubKey[:]); err != nil {
+			return err
+		}
+		if err := valDB.EnableAccount(ctx, pubKey[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetAccountsDisabled disables or re-enables the validator accounts of the given public keys.
+// Disabled accounts keep their keys and slashing protection records, but are excluded from duty
+// scheduling by the validator client.
+func SetAccountsDisabled(ctx context.Context, dataDir string, pubKeys [][48]byte, disabled bool) error {
+	valDB, err := db.NewKVStore(dataDir, pubKeys)
+	if err != nil {
+		return errors.Wrap(err, "could not open validator db")
+	}
+	defer func() {
+		if err := valDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator db")
+		}
+	}()
+
+	for _, pubKey := range pubKeys {
+		if disabled {
+			err = valDB.DisableAccount(ctx, pubKey[:])
+		} else {
+			err = valDB.EnableAccount(ctx, pubKey[:])
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not update account %#x", pubKey)
+		}
+	}
+	return nil
+}
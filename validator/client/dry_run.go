@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// dryRunRecord is a message the validator client would have sent to the beacon node.
+type dryRunRecord struct {
+	Time    time.Time   `json:"time"`
+	Type    string      `json:"type"`
+	Slot    uint64      `json:"slot"`
+	Root    string      `json:"root"`
+	Message interface{} `json:"message"`
+}
+
+// dryRunValidatorClient forwards the requests of the validator client to the beacon node,
+// except the blocks, attestations, aggregates and exits to broadcast. Those are recorded
+// instead, and acknowledged as if the beacon node accepted them.
+type dryRunValidatorClient struct {
+	ethpb.BeaconNodeValidatorClient
+	lock   sync.Mutex
+	output *os.File
+}
+
+// newDryRunValidatorClient records the messages not sent to the beacon node as JSON lines
+// in the output file, if any, in addition to logging them.
+func newDryRunValidatorClient(client ethpb.BeaconNodeValidatorClient, output string) (*dryRunValidatorClient, error) {
+	c := &dryRunValidatorClient{BeaconNodeValidatorClient: client}
+	if output != "" {
+		f, err := os.OpenFile(output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not open dry run output")
+		}
+		c.output = f
+	}
+	return c, nil
+}
+
+// ProposeBlock records the block instead of sending it to the beacon node.
+func (c *dryRunValidatorClient) ProposeBlock(_ context.Context, blk *ethpb.SignedBeaconBlock, _ ...grpc.CallOption) (*ethpb.ProposeResponse, error) {
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not tree hash block")
+	}
+	if err := c.record("block", blk.Block.Slot, root, blk); err != nil {
+		return nil, err
+	}
+	return &ethpb.ProposeResponse{BlockRoot: root[:]}, nil
+}
+
+// ProposeAttestation records the attestation instead of sending it to the beacon node.
+func (c *dryRunValidatorClient) ProposeAttestation(_ context.Context, att *ethpb.Attestation, _ ...grpc.CallOption) (*ethpb.AttestResponse, error) {
+	root, err := ssz.HashTreeRoot(att.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not tree hash attestation data")
+	}
+	if err := c.record("attestation", att.Data.Slot, root, att); err != nil {
+		return nil, err
+	}
+	return &ethpb.AttestResponse{AttestationDataRoot: root[:]}, nil
+}
+
+// SubmitSignedAggregateSelectionProof records the aggregate instead of sending it to the
+// beacon node.
+func (c *dryRunValidatorClient) SubmitSignedAggregateSelectionProof(
+	_ context.Context,
+	req *ethpb.SignedAggregateSubmitRequest,
+	_ ...grpc.CallOption,
+) (*ethpb.SignedAggregateSubmitResponse, error) {
+	if req.SignedAggregateAndProof == nil || req.SignedAggregateAndProof.Message == nil ||
+		req.SignedAggregateAndProof.Message.Aggregate == nil {
+		return nil, errors.New("no aggregate to submit")
+	}
+	data := req.SignedAggregateAndProof.Message.Aggregate.Data
+	root, err := ssz.HashTreeRoot(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not tree hash attestation data")
+	}
+	if err := c.record("aggregate", data.Slot, root, req.SignedAggregateAndProof); err != nil {
+		return nil, err
+	}
+	return &ethpb.SignedAggregateSubmitResponse{AttestationDataRoot: root[:]}, nil
+}
+
+// ProposeExit records the voluntary exit instead of sending it to the beacon node.
+func (c *dryRunValidatorClient) ProposeExit(_ context.Context, exit *ethpb.SignedVoluntaryExit, _ ...grpc.CallOption) (*ptypes.Empty, error) {
+	root, err := ssz.HashTreeRoot(exit.Exit)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not tree hash voluntary exit")
+	}
+	if err := c.record("exit", 0, root, exit); err != nil {
+		return nil, err
+	}
+	return &ptypes.Empty{}, nil
+}
+
+func (c *dryRunValidatorClient) record(kind string, slot uint64, root [32]byte, msg interface{}) error {
+	log.WithFields(logrus.Fields{
+		"type": kind,
+		"slot": slot,
+		"root": fmt.Sprintf("%#x", root),
+	}).Info("Dry run, not sending to beacon node")
+	if c.output == nil {
+		return nil
+	}
+	enc, err := json.Marshal(&dryRunRecord{
+		Time:    time.Now(),
+		Type:    kind,
+		Slot:    slot,
+		Root:    fmt.Sprintf("%#x", root),
+		Message: msg,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not encode dry run record")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, err := c.output.Write(append(enc, '\n')); err != nil {
+		return errors.Wrap(err, "could not write dry run record")
+	}
+	return nil
+}
+
+// Close the dry run output.
+func (c *dryRunValidatorClient) Close() error {
+	if c.output == nil {
+		return nil
+	}
+	return c.output.Close()
+}
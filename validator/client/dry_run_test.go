@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+func TestProposeBlock_DryRunRecordsBlock(t *testing.T) {
+	validator, m, finish := setup(t)
+	defer finish()
+
+	output := filepath.Join(testutil.TempDir(), "dry-run-blocks.json")
+	defer func() {
+		if err := os.Remove(output); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	dryRunClient, err := newDryRunValidatorClient(m.validatorClient, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validator.validatorClient = dryRunClient
+	km := keymanager.NewDryRun(testKeyManager)
+	if _, err := km.FetchValidatingKeys(); err != nil {
+		t.Fatal(err)
+	}
+	validator.keyManager = km
+
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), //epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/).Times(2)
+	m.validatorClient.EXPECT().GetBlock(
+		gomock.Any(), // ctx
+		gomock.Any(),
+	).Return(&ethpb.BeaconBlock{Slot: 1, Body: &ethpb.BeaconBlockBody{}}, nil /*err*/)
+	// The block is not sent to the beacon node, ProposeBlock of the mock is not expected.
+
+	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
+	if err := dryRunClient.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	scanner := bufio.NewScanner(f)
+	var records []*dryRunRecord
+	for scanner.Scan() {
+		record := &dryRunRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 recorded message, received %d", len(records))
+	}
+	if records[0].Type != "block" || records[0].Slot != 1 {
+		t.Errorf("Expected block of slot 1 to be recorded, received %s of slot %d", records[0].Type, records[0].Slot)
+	}
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// DutyCalendar is the duty schedule of the validating keys of the validator client for an epoch.
+type DutyCalendar struct {
+	Epoch       uint64          `json:"epoch"`
+	GenesisTime uint64          `json:"genesis_time"`
+	Duties      []*CalendarDuty `json:"duties"`
+}
+
+// CalendarDuty is the duty schedule of a validating key for an epoch.
+type CalendarDuty struct {
+	PublicKey      string   `json:"public_key"`
+	ValidatorIndex uint64   `json:"validator_index"`
+	Status         string   `json:"status"`
+	AttesterSlot   uint64   `json:"attester_slot"`
+	CommitteeIndex uint64   `json:"committee_index"`
+	Aggregator     bool     `json:"aggregator"`
+	ProposerSlots  []uint64 `json:"proposer_slots"`
+}
+
+// DutyCalendar requests the duties of the validating keys at the given epoch from the beacon node,
+// along with whether the keys are selected to aggregate the attestations of their committees.
+func (v *ValidatorService) DutyCalendar(ctx context.Context, epoch uint64) (*DutyCalendar, error) {
+	if v.validator == nil {
+		return nil, errors.New("validator is not running")
+	}
+	return v.validator.DutyCalendar(ctx, epoch)
+}
+
+// UpcomingEpoch returns the epoch after the epoch of the canonical head of the beacon node, the
+// furthest epoch whose duties are known in advance.
+func (v *ValidatorService) UpcomingEpoch(ctx context.Context) (uint64, error) {
+	if v.validator == nil {
+		return 0, errors.New("validator is not running")
+	}
+	headSlot, err := v.validator.CanonicalHeadSlot(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get canonical head slot")
+	}
+	return helpers.SlotToEpoch(headSlot) + 1, nil
+}
+
+// DutyCalendar returns the duty schedule of the enabled validating keys at the given epoch.
+func (v *validator) DutyCalendar(ctx context.Context, epoch uint64) (*DutyCalendar, error) {
+	validatingKeys, err := v.keyManager.FetchValidatingKeys()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not fetch validating keys")
+	}
+	resp, err := v.validatorClient.GetDuties(ctx, &ethpb.DutiesRequest{
+		Epoch:      epoch,
+		PublicKeys: bytesutil.FromBytes48Array(v.enabledKeys(validatingKeys)),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not request duties")
+	}
+
+	calendar := &DutyCalendar{
+		Epoch:       epoch,
+		GenesisTime: v.genesisTime,
+		Duties:      make([]*CalendarDuty, 0, len(resp.Duties)),
+	}
+	for _, duty := range resp.Duties {
+		if duty == nil {
+			continue
+		}
+		calendarDuty := &CalendarDuty{
+			PublicKey:      fmt.Sprintf("%#x", duty.PublicKey),
+			ValidatorIndex: duty.ValidatorIndex,
+			Status:         duty.Status.String(),
+			AttesterSlot:   duty.AttesterSlot,
+			CommitteeIndex: duty.CommitteeIndex,
+			ProposerSlots:  duty.ProposerSlots,
+		}
+		if duty.Status == ethpb.ValidatorStatus_ACTIVE || duty.Status == ethpb.ValidatorStatus_EXITING {
+			calendarDuty.Aggregator, err = v.isAggregator(ctx, duty.Committee, duty.AttesterSlot, bytesutil.ToBytes48(duty.PublicKey))
+			if err != nil {
+				return nil, errors.Wrap(err, "could not check if a validator is an aggregator")
+			}
+		}
+		calendar.Duties = append(calendar.Duties, calendarDuty)
+	}
+	return calendar, nil
+}
+
+// ICS renders the duty calendar in the iCalendar format, with an event for each attestation and
+// proposal lasting the duration of its slot.
+func (c *DutyCalendar) ICS() []byte {
+	type event struct {
+		slot    uint64
+		uid     string
+		summary string
+	}
+	var events []event
+	for _, duty := range c.Duties {
+		if duty.Status != ethpb.ValidatorStatus_ACTIVE.String() && duty.Status != ethpb.ValidatorStatus_EXITING.String() {
+			continue
+		}
+		for _, slot := range duty.ProposerSlots {
+			events = append(events, event{
+				slot:    slot,
+				uid:     fmt.Sprintf("propose-%d-%d", duty.ValidatorIndex, slot),
+				summary: fmt.Sprintf("Validator %d proposes a block", duty.ValidatorIndex),
+			})
+		}
+		summary := fmt.Sprintf("Validator %d attests in committee %d", duty.ValidatorIndex, duty.CommitteeIndex)
+		if duty.Aggregator {
+			summary = fmt.Sprintf("Validator %d attests and aggregates in committee %d", duty.ValidatorIndex, duty.CommitteeIndex)
+		}
+		events = append(events, event{
+			slot:    duty.AttesterSlot,
+			uid:     fmt.Sprintf("attest-%d-%d", duty.ValidatorIndex, duty.AttesterSlot),
+			summary: summary,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].slot < events[j].slot
+	})
+
+	const timeFormat = "20060102T150405Z"
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	slotTime := func(slot uint64) string {
+		return time.Unix(int64(c.GenesisTime+slot*secondsPerSlot), 0).UTC().Format(timeFormat)
+	}
+	now := time.Now().UTC().Format(timeFormat)
+
+	// Lines of iCalendar files are terminated by CRLF, see RFC 5545.
+	var buf bytes.Buffer
+	writeLine := func(format string, args ...interface{}) {
+		fmt.Fprintf(&buf, format+"\r\n", args...)
+	}
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//Prysmatic Labs//Prysm Validator Duties//EN")
+	writeLine("X-WR-CALNAME:Validator duties at epoch %d", c.Epoch)
+	for _, e := range events {
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:%s@prysm", e.uid)
+		writeLine("DTSTAMP:%s", now)
+		writeLine("DTSTART:%s", slotTime(e.slot))
+		writeLine("DTEND:%s", slotTime(e.slot+1))
+		writeLine("SUMMARY:%s", e.summary)
+		writeLine("DESCRIPTION:Slot %d", e.slot)
+		writeLine("END:VEVENT")
+	}
+	writeLine("END:VCALENDAR")
+	return buf.Bytes()
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+func TestDutyCalendar_OK(t *testing.T) {
+	v, m, finish := setup(t)
+	defer finish()
+
+	sks := []*bls.SecretKey{bls.RandKey(), bls.RandKey()}
+	v.keyManager = keymanager.NewDirect(sks)
+	v.genesisTime = 1000
+	m.validatorClient.EXPECT().GetDuties(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).Return(&ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				Committee:      []uint64{5},
+				CommitteeIndex: 1,
+				AttesterSlot:   2*params.BeaconConfig().SlotsPerEpoch + 3,
+				ProposerSlots:  []uint64{2*params.BeaconConfig().SlotsPerEpoch + 1},
+				PublicKey:      sks[0].PublicKey().Marshal(),
+				ValidatorIndex: 5,
+				Status:         ethpb.ValidatorStatus_ACTIVE,
+			},
+			{
+				PublicKey:      sks[1].PublicKey().Marshal(),
+				ValidatorIndex: 6,
+				Status:         ethpb.ValidatorStatus_PENDING,
+			},
+		},
+	}, nil)
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/)
+
+	calendar, err := v.DutyCalendar(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calendar.Epoch != 2 || calendar.GenesisTime != 1000 || len(calendar.Duties) != 2 {
+		t.Fatalf("Unexpected duty calendar %+v", calendar)
+	}
+	// A committee of a single validator is always aggregated by it.
+	if !calendar.Duties[0].Aggregator || calendar.Duties[0].Status != "ACTIVE" {
+		t.Errorf("Expected active aggregator duty, received %+v", calendar.Duties[0])
+	}
+	if calendar.Duties[1].Aggregator || calendar.Duties[1].Status != "PENDING" {
+		t.Errorf("Expected pending duty without aggregation, received %+v", calendar.Duties[1])
+	}
+}
+
+func TestDutyCalendar_ICS(t *testing.T) {
+	calendar := &DutyCalendar{
+		Epoch:       1,
+		GenesisTime: 1000,
+		Duties: []*CalendarDuty{
+			{
+				ValidatorIndex: 5,
+				Status:         "ACTIVE",
+				AttesterSlot:   10,
+				CommitteeIndex: 2,
+				Aggregator:     true,
+				ProposerSlots:  []uint64{9},
+			},
+			{
+				ValidatorIndex: 6,
+				Status:         "PENDING",
+			},
+		},
+	}
+	ics := calendar.ICS()
+	if !bytes.HasPrefix(ics, []byte("BEGIN:VCALENDAR\r\n")) || !bytes.HasSuffix(ics, []byte("END:VCALENDAR\r\n")) {
+		t.Fatalf("Expected a calendar, received %s", ics)
+	}
+	if n := bytes.Count(ics, []byte("BEGIN:VEVENT")); n != 2 {
+		t.Errorf("Expected events for the proposal and attestation of the active validator, received %d", n)
+	}
+	proposal := bytes.Index(ics, []byte("SUMMARY:Validator 5 proposes a block"))
+	attestation := bytes.Index(ics, []byte("SUMMARY:Validator 5 attests and aggregates in committee 2"))
+	if proposal < 0 || attestation < 0 || proposal > attestation {
+		t.Errorf("Expected proposal at slot 9 to be listed before attestation at slot 10, received %s", ics)
+	}
+	start := time.Unix(int64(1000+10*params.BeaconConfig().SecondsPerSlot), 0).UTC().Format("20060102T150405Z")
+	if !bytes.Contains(ics, []byte(fmt.Sprintf("DTSTART:%s\r\n", start))) {
+		t.Errorf("Expected attestation to start at %s, received %s", start, ics)
+	}
+}
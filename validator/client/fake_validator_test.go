@@ -13,6 +13,9 @@ type fakeValidator struct {
 	WaitForChainStartCalled          bool
 	WaitForSyncCalled                bool
 	WaitForSyncedCalled              bool
+	ReceiveBlocksCalled              bool
+	ReceiveDutiesChangesCalled       bool
+	ReceiveStatusChangesCalled       bool
 	NextSlotCalled                   bool
 	CanonicalHeadSlotCalled          bool
 	UpdateDutiesCalled               bool
@@ -45,6 +48,18 @@ func (fv *fakeValidator) WaitForActivation(_ context.Context) error {
 	return nil
 }
 
+func (fv *fakeValidator) ReceiveBlocks(_ context.Context) {
+	fv.ReceiveBlocksCalled = true
+}
+
+func (fv *fakeValidator) ReceiveDutiesChanges(_ context.Context) {
+	fv.ReceiveDutiesChangesCalled = true
+}
+
+func (fv *fakeValidator) ReceiveValidatorStatusChanges(_ context.Context) {
+	fv.ReceiveStatusChangesCalled = true
+}
+
 func (fv *fakeValidator) WaitForSync(_ context.Context) error {
 	fv.WaitForSyncCalled = true
 	return nil
@@ -104,3 +119,15 @@ func (fv *fakeValidator) SubmitAggregateAndProof(_ context.Context, slot uint64,
 func (fv *fakeValidator) LogAttestationsSubmitted() {}
 
 func (fv *fakeValidator) UpdateDomainDataCaches(context.Context, uint64) {}
+
+func (fv *fakeValidator) DutyCalendar(_ context.Context, epoch uint64) (*DutyCalendar, error) {
+	return &DutyCalendar{Epoch: epoch}, nil
+}
+
+func (fv *fakeValidator) EstimateRewards(_ context.Context, slot uint64) error {
+	return nil
+}
+
+func (fv *fakeValidator) RewardEstimates() []*RewardEstimate {
+	return nil
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+// rewardEstimatesEpochs is the number of most recent epochs whose reward estimates are kept.
+const rewardEstimatesEpochs = 16
+
+var (
+	expectedRewardGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "expected_reward_gwei",
+			Help:      "estimated reward in gwei of the epoch transition ending the current epoch.",
+		},
+		[]string{
+			// validator pubkey
+			"pubkey",
+		},
+	)
+	realizedRewardGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "realized_reward_gwei",
+			Help:      "balance change in gwei of the latest epoch transition.",
+		},
+		[]string{
+			// validator pubkey
+			"pubkey",
+		},
+	)
+	rewardDeltaGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "reward_delta_gwei",
+			Help:      "difference in gwei between the realized and the estimated reward of the latest epoch transition.",
+		},
+		[]string{
+			// validator pubkey
+			"pubkey",
+		},
+	)
+)
+
+// RewardEstimate compares the reward expected for a validating key in an epoch with the balance
+// change of the epoch transition ending it. Rewards for the attestations and proposals of an
+// epoch are paid in a later epoch transition, so in a steady state the transition ending an
+// epoch pays about an epoch worth of duties, which the duties of the epoch stand in for.
+type RewardEstimate struct {
+	Epoch             uint64 `json:"epoch"`
+	PublicKey         string `json:"public_key"`
+	ValidatorIndex    uint64 `json:"validator_index"`
+	AssignedProposals uint64 `json:"assigned_proposals"`
+	ExpectedReward    int64  `json:"expected_reward_gwei"`
+	// Realized is false until the epoch transition ending the epoch is reported.
+	Realized       bool  `json:"realized"`
+	RealizedReward int64 `json:"realized_reward_gwei"`
+	Delta          int64 `json:"delta_gwei"`
+}
+
+// RewardEstimates returns the reward estimates of the validating keys in the most recent epochs.
+func (v *ValidatorService) RewardEstimates() ([]*RewardEstimate, error) {
+	if v.validator == nil {
+		return nil, errors.New("validator is not running")
+	}
+	return v.validator.RewardEstimates(), nil
+}
+
+// EstimateRewards compares the rewards expected in the previous epoch with the balance changes
+// reported for its epoch transition, and estimates the rewards of the current epoch from the
+// base reward, the participation in the previous epoch and the duties of the current epoch.
+func (v *validator) EstimateRewards(ctx context.Context, slot uint64) error {
+	if !v.estimateRewards || slot%params.BeaconConfig().SlotsPerEpoch != 0 || slot < params.BeaconConfig().SlotsPerEpoch {
+		// Do nothing unless we are at the start of the epoch, and not in the first epoch.
+		return nil
+	}
+	epoch := helpers.SlotToEpoch(slot)
+
+	validatingKeys, err := v.keyManager.FetchValidatingKeys()
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating keys")
+	}
+	pubKeys := bytesutil.FromBytes48Array(v.enabledKeys(validatingKeys))
+	performance, err := v.beaconClient.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
+		PublicKeys: pubKeys,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not get validator performance")
+	}
+	// Without a query filter, the participation of the previous epoch is returned.
+	participation, err := v.beaconClient.GetValidatorParticipation(ctx, &ethpb.GetValidatorParticipationRequest{})
+	if err != nil {
+		return errors.Wrap(err, "could not get validator participation")
+	}
+
+	proposals := make(map[[48]byte]uint64)
+	indices := make(map[[48]byte]uint64)
+	if v.duties != nil {
+		for _, duty := range v.duties.Duties {
+			pubKey := bytesutil.ToBytes48(duty.PublicKey)
+			indices[pubKey] = duty.ValidatorIndex
+			for _, proposerSlot := range duty.ProposerSlots {
+				if helpers.SlotToEpoch(proposerSlot) == epoch {
+					proposals[pubKey]++
+				}
+			}
+		}
+	}
+
+	missingValidators := make(map[[48]byte]bool)
+	for _, key := range performance.MissingValidators {
+		missingValidators[bytesutil.ToBytes48(key)] = true
+	}
+
+	v.rewardEstimatesLock.Lock()
+	defer v.rewardEstimatesLock.Unlock()
+	previous := v.rewardEstimates[epoch-1]
+	current := make(map[[48]byte]*RewardEstimate)
+	reported := 0
+	for _, key := range pubKeys {
+		pubKey := bytesutil.ToBytes48(key)
+		if missingValidators[pubKey] {
+			continue
+		}
+		if reported >= len(performance.BalancesAfterEpochTransition) || reported >= len(performance.CurrentEffectiveBalances) {
+			break
+		}
+		fmtKey := fmt.Sprintf("%#x", key)
+		if estimate, ok := previous[pubKey]; ok {
+			estimate.Realized = true
+			estimate.RealizedReward = int64(performance.BalancesAfterEpochTransition[reported]) - int64(performance.BalancesBeforeEpochTransition[reported])
+			estimate.Delta = estimate.RealizedReward - estimate.ExpectedReward
+			log.WithFields(logrus.Fields{
+				"pubKey":         fmt.Sprintf("%#x", bytesutil.Trunc(key)),
+				"epoch":          estimate.Epoch,
+				"expectedReward": estimate.ExpectedReward,
+				"realizedReward": estimate.RealizedReward,
+				"delta":          estimate.Delta,
+			}).Info("Previous epoch reward compared to estimate")
+			if v.emitAccountMetrics {
+				realizedRewardGaugeVec.WithLabelValues(fmtKey).Set(float64(estimate.RealizedReward))
+				rewardDeltaGaugeVec.WithLabelValues(fmtKey).Set(float64(estimate.Delta))
+			}
+		}
+		if participation.Participation != nil && participation.Participation.EligibleEther > 0 {
+			estimate := &RewardEstimate{
+				Epoch:             epoch,
+				PublicKey:         fmtKey,
+				ValidatorIndex:    indices[pubKey],
+				AssignedProposals: proposals[pubKey],
+				ExpectedReward: int64(expectedReward(
+					performance.CurrentEffectiveBalances[reported],
+					participation.Participation.EligibleEther,
+					float64(participation.Participation.GlobalParticipationRate),
+					proposals[pubKey],
+				)),
+			}
+			current[pubKey] = estimate
+			if v.emitAccountMetrics {
+				expectedRewardGaugeVec.WithLabelValues(fmtKey).Set(float64(estimate.ExpectedReward))
+			}
+		}
+		reported++
+	}
+
+	v.rewardEstimates[epoch] = current
+	for e := range v.rewardEstimates {
+		if e+rewardEstimatesEpochs <= epoch {
+			delete(v.rewardEstimates, e)
+		}
+	}
+	return nil
+}
+
+// RewardEstimates returns the kept reward estimates, ordered by epoch and validator index.
+func (v *validator) RewardEstimates() []*RewardEstimate {
+	v.rewardEstimatesLock.RLock()
+	defer v.rewardEstimatesLock.RUnlock()
+	estimates := make([]*RewardEstimate, 0)
+	for _, epochEstimates := range v.rewardEstimates {
+		for _, estimate := range epochEstimates {
+			e := *estimate
+			estimates = append(estimates, &e)
+		}
+	}
+	sort.Slice(estimates, func(i, j int) bool {
+		if estimates[i].Epoch != estimates[j].Epoch {
+			return estimates[i].Epoch < estimates[j].Epoch
+		}
+		return estimates[i].ValidatorIndex < estimates[j].ValidatorIndex
+	})
+	return estimates
+}
+
+// expectedReward estimates the reward in gwei of an active validator over an epoch from its
+// effective balance, the total active balance, the share of it which voted, and the number of
+// blocks the validator is to propose. Timely inclusion of the attestations is assumed, and the
+// penalties of a chain which is not finalizing are left out.
+func expectedReward(effectiveBalance uint64, totalBalance uint64, participation float64, proposals uint64) uint64 {
+	cfg := params.BeaconConfig()
+	sqrtTotalBalance := mathutil.IntegerSquareRoot(totalBalance)
+	if sqrtTotalBalance == 0 {
+		return 0
+	}
+	baseReward := effectiveBalance * cfg.BaseRewardFactor / sqrtTotalBalance / cfg.BaseRewardsPerEpoch
+	proposerReward := baseReward / cfg.ProposerRewardQuotient
+	// The source, target and head votes each earn the base reward scaled by the participation,
+	// and the inclusion earns what is left of it after the share of the including proposer.
+	attesterReward := uint64(3*float64(baseReward)*participation) + baseReward - proposerReward
+	// A block includes the attestations of a slot worth of validators, and earns the proposer's
+	// share of their base rewards.
+	totalBaseReward := cfg.BaseRewardFactor * sqrtTotalBalance / cfg.BaseRewardsPerEpoch
+	blockReward := uint64(float64(totalBaseReward/cfg.ProposerRewardQuotient/cfg.SlotsPerEpoch) * participation)
+	return attesterReward + proposals*blockReward
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+func TestExpectedReward(t *testing.T) {
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	totalBalance := 1000 * maxBalance
+	full := expectedReward(maxBalance, totalBalance, 1, 0)
+	if full == 0 {
+		t.Fatal("Expected a reward for full participation")
+	}
+	if idle := expectedReward(maxBalance, totalBalance, 0, 0); idle >= full {
+		t.Errorf("Expected lower reward without participation, received %d and %d", idle, full)
+	}
+	if proposing := expectedReward(maxBalance, totalBalance, 1, 1); proposing <= full {
+		t.Errorf("Expected a block proposal to add to the reward, received %d and %d", proposing, full)
+	}
+	if reward := expectedReward(maxBalance, 0, 1, 0); reward != 0 {
+		t.Errorf("Expected no reward without active balance, received %d", reward)
+	}
+}
+
+func TestEstimateRewards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+
+	sks := []*bls.SecretKey{bls.RandKey(), bls.RandKey()}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	totalBalance := 1000 * maxBalance
+	v := &validator{
+		keyManager:      keymanager.NewDirect(sks),
+		beaconClient:    client,
+		estimateRewards: true,
+		rewardEstimates: make(map[uint64]map[[48]byte]*RewardEstimate),
+		duties: &ethpb.DutiesResponse{
+			Duties: []*ethpb.DutiesResponse_Duty{
+				{
+					PublicKey:      sks[0].PublicKey().Marshal(),
+					ValidatorIndex: 3,
+					ProposerSlots:  []uint64{2*slotsPerEpoch + 5},
+				},
+			},
+		},
+	}
+	participation := &ethpb.ValidatorParticipationResponse{
+		Participation: &ethpb.ValidatorParticipation{
+			GlobalParticipationRate: 1,
+			EligibleEther:           totalBalance,
+		},
+	}
+	client.EXPECT().GetValidatorPerformance(gomock.Any(), gomock.Any()).Return(&ethpb.ValidatorPerformanceResponse{
+		CurrentEffectiveBalances:      []uint64{maxBalance},
+		BalancesBeforeEpochTransition: []uint64{maxBalance},
+		BalancesAfterEpochTransition:  []uint64{maxBalance},
+		MissingValidators:             [][]byte{sks[1].PublicKey().Marshal()},
+	}, nil)
+	client.EXPECT().GetValidatorParticipation(gomock.Any(), gomock.Any()).Return(participation, nil)
+
+	// Nothing is estimated in the middle of an epoch.
+	if err := v.EstimateRewards(context.Background(), 2*slotsPerEpoch+1); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.EstimateRewards(context.Background(), 2*slotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	estimates := v.RewardEstimates()
+	if len(estimates) != 1 {
+		t.Fatalf("Expected an estimate for the active key only, received %d", len(estimates))
+	}
+	expected := int64(expectedReward(maxBalance, totalBalance, 1, 1))
+	if estimates[0].Epoch != 2 || estimates[0].ValidatorIndex != 3 || estimates[0].AssignedProposals != 1 || estimates[0].ExpectedReward != expected {
+		t.Errorf("Unexpected estimate %+v", estimates[0])
+	}
+	if estimates[0].Realized {
+		t.Error("Expected estimate not to be realized before the epoch transition")
+	}
+
+	realized := int64(expected - 1000)
+	client.EXPECT().GetValidatorPerformance(gomock.Any(), gomock.Any()).Return(&ethpb.ValidatorPerformanceResponse{
+		CurrentEffectiveBalances:      []uint64{maxBalance},
+		BalancesBeforeEpochTransition: []uint64{maxBalance},
+		BalancesAfterEpochTransition:  []uint64{maxBalance + uint64(realized)},
+		MissingValidators:             [][]byte{sks[1].PublicKey().Marshal()},
+	}, nil)
+	client.EXPECT().GetValidatorParticipation(gomock.Any(), gomock.Any()).Return(participation, nil)
+	if err := v.EstimateRewards(context.Background(), 3*slotsPerEpoch); err != nil {
+		t.Fatal(err)
+	}
+	estimates = v.RewardEstimates()
+	if len(estimates) != 2 {
+		t.Fatalf("Expected estimates of 2 epochs, received %d", len(estimates))
+	}
+	if !estimates[0].Realized || estimates[0].RealizedReward != realized || estimates[0].Delta != -1000 {
+		t.Errorf("Unexpected realized estimate %+v", estimates[0])
+	}
+	// The proposal was assigned in the previous epoch.
+	if estimates[1].Epoch != 3 || estimates[1].AssignedProposals != 0 || estimates[1].Realized {
+		t.Errorf("Unexpected estimate %+v", estimates[1])
+	}
+}
@@ -22,6 +22,9 @@ type Validator interface {
 	WaitForSync(ctx context.Context) error
 	WaitForSynced(ctx context.Context) error
 	WaitForActivation(ctx context.Context) error
+	ReceiveBlocks(ctx context.Context)
+	ReceiveDutiesChanges(ctx context.Context)
+	ReceiveValidatorStatusChanges(ctx context.Context)
 	CanonicalHeadSlot(ctx context.Context) (uint64, error)
 	NextSlot() <-chan uint64
 	SlotDeadline(slot uint64) time.Time
@@ -33,6 +36,9 @@ type Validator interface {
 	SubmitAggregateAndProof(ctx context.Context, slot uint64, pubKey [48]byte)
 	LogAttestationsSubmitted()
 	UpdateDomainDataCaches(ctx context.Context, slot uint64)
+	DutyCalendar(ctx context.Context, epoch uint64) (*DutyCalendar, error)
+	EstimateRewards(ctx context.Context, slot uint64) error
+	RewardEstimates() []*RewardEstimate
 }
 
 // Run the main validator routine. This routine exits if the context is
@@ -59,9 +65,12 @@ func run(ctx context.Context, v Validator) {
 			log.Fatalf("Could not determine if beacon node synced: %v", err)
 		}
 	}
+	go v.ReceiveValidatorStatusChanges(ctx)
 	if err := v.WaitForActivation(ctx); err != nil {
 		log.Fatalf("Could not wait for validator activation: %v", err)
 	}
+	go v.ReceiveBlocks(ctx)
+	go v.ReceiveDutiesChanges(ctx)
 	headSlot, err := v.CanonicalHeadSlot(ctx)
 	if err != nil {
 		log.Fatalf("Could not get current canonical head slot: %v", err)
@@ -87,8 +96,9 @@ func run(ctx context.Context, v Validator) {
 				log.WithError(err).Error("Could not report validator's rewards/penalties")
 			}
 
-			// Keep trying to update assignments if they are nil or if we are past an
-			// epoch transition in the beacon node's state.
+			// Keep trying to update assignments if they are nil, if we are past an
+			// epoch transition in the beacon node's state or if the beacon node
+			// notified us of changed duties.
 			if err := v.UpdateDuties(ctx, slot); err != nil {
 				handleAssignmentError(err, slot)
 				cancel()
@@ -96,6 +106,12 @@ func run(ctx context.Context, v Validator) {
 				continue
 			}
 
+			// Compare the rewards of the previous epoch with their estimate, and estimate the
+			// rewards of the new epoch from its duties.
+			if err := v.EstimateRewards(slotCtx, slot); err != nil {
+				log.WithError(err).Error("Could not estimate validator rewards")
+			}
+
 			// Start fetching domain data for the next epoch.
 			if helpers.IsEpochEnd(slot) {
 				go v.UpdateDomainDataCaches(ctx, slot+1)
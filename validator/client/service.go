@@ -4,11 +4,12 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/dgraph-io/ristretto"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
-	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	lru "github.com/hashicorp/golang-lru"
@@ -16,8 +17,11 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/grpcutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
@@ -30,6 +34,9 @@ import (
 
 var log = logrus.WithField("prefix", "validator")
 
+// dryRunDir is the directory, within the data directory, of the database of dry runs.
+const dryRunDir = "dry-run"
+
 // ValidatorService represents a service to manage the validator client
 // routine.
 type ValidatorService struct {
@@ -47,6 +54,10 @@ type ValidatorService struct {
 	maxCallRecvMsgSize   int
 	grpcRetries          uint
 	grpcHeaders          []string
+	dryRun               bool
+	dryRunOutput         string
+	dryRunClient         *dryRunValidatorClient
+	estimateRewards      bool
 }
 
 // Config for the validator service.
@@ -61,12 +72,20 @@ type Config struct {
 	GrpcMaxCallRecvMsgSizeFlag int
 	GrpcRetriesFlag            uint
 	GrpcHeadersFlag            string
+	DryRun                     bool
+	DryRunOutput               string
+	EstimateRewards            bool
 }
 
 // NewValidatorService creates a new validator service for the service
 // registry.
 func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, error) {
 	ctx, cancel := context.WithCancel(ctx)
+	keyManager := cfg.KeyManager
+	if cfg.DryRun {
+		// Dry runs sign with throwaway keys, so their signatures are never valid for the validators.
+		keyManager = keymanager.NewDryRun(keyManager)
+	}
 	return &ValidatorService{
 		ctx:                  ctx,
 		cancel:               cancel,
@@ -74,12 +93,15 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		withCert:             cfg.CertFlag,
 		dataDir:              cfg.DataDir,
 		graffiti:             []byte(cfg.GraffitiFlag),
-		keyManager:           cfg.KeyManager,
+		keyManager:           keyManager,
 		logValidatorBalances: cfg.LogValidatorBalances,
 		emitAccountMetrics:   cfg.EmitAccountMetrics,
 		maxCallRecvMsgSize:   cfg.GrpcMaxCallRecvMsgSizeFlag,
 		grpcRetries:          cfg.GrpcRetriesFlag,
 		grpcHeaders:          strings.Split(cfg.GrpcHeadersFlag, ","),
+		dryRun:               cfg.DryRun,
+		dryRunOutput:         cfg.DryRunOutput,
+		estimateRewards:      cfg.EstimateRewards,
 	}, nil
 }
 
@@ -119,23 +141,24 @@ func (v *ValidatorService) Start() {
 		}
 	}
 
+	grpcCfg := grpcutil.DefaultConfig()
+	grpcCfg.MaxRetries = v.grpcRetries
 	opts := []grpc.DialOption{
 		dialOpt,
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallRecvMsgSize(maxCallRecvMsgSize),
-			grpc_retry.WithMax(v.grpcRetries),
 			grpc.Header(&md),
 		),
 		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
 		grpc.WithStreamInterceptor(middleware.ChainStreamClient(
 			grpc_opentracing.StreamClientInterceptor(),
 			grpc_prometheus.StreamClientInterceptor,
-			grpc_retry.StreamClientInterceptor(),
+			grpcutil.StreamClientInterceptor(grpcCfg),
 		)),
 		grpc.WithUnaryInterceptor(middleware.ChainUnaryClient(
 			grpc_opentracing.UnaryClientInterceptor(),
 			grpc_prometheus.UnaryClientInterceptor,
-			grpc_retry.UnaryClientInterceptor(),
+			grpcutil.UnaryClientInterceptor(grpcCfg),
 			logDebugRequestInfoUnaryInterceptor,
 		)),
 	}
@@ -152,11 +175,42 @@ func (v *ValidatorService) Start() {
 		return
 	}
 
-	valDB, err := db.NewKVStore(v.dataDir, pubkeys)
+	dataDir := v.dataDir
+	if v.dryRun {
+		// The slashing protection history of a dry run is kept in a copy of the database, so
+		// the history of the validators is left untouched.
+		dataDir = filepath.Join(v.dataDir, dryRunDir)
+		if err := db.CopyKVStore(v.dataDir, dataDir); err != nil {
+			log.WithError(err).Warn("Could not copy slashing protection history, starting the dry run without it")
+		}
+	}
+	valDB, err := db.NewKVStore(dataDir, pubkeys)
 	if err != nil {
 		log.Errorf("Could not initialize db: %v", err)
 		return
 	}
+	disabledKeys := make(map[[48]byte]bool)
+	disabled, err := valDB.DisabledAccounts(v.ctx)
+	if err != nil {
+		log.Errorf("Could not get disabled accounts: %v", err)
+		return
+	}
+	for _, pubKey := range disabled {
+		log.WithField("pubKey", fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:]))).Info("Account is disabled, excluding it from duties")
+		disabledKeys[pubKey] = true
+	}
+
+	var validatorClient ethpb.BeaconNodeValidatorClient = ethpb.NewBeaconNodeValidatorClient(conn)
+	if v.dryRun {
+		dryRunClient, err := newDryRunValidatorClient(validatorClient, v.dryRunOutput)
+		if err != nil {
+			log.Errorf("Could not initialize dry run: %v", err)
+			return
+		}
+		log.Warn("Dry run: blocks, attestations, aggregates and exits are signed with throwaway keys and not sent to the beacon node")
+		v.dryRunClient = dryRunClient
+		validatorClient = dryRunClient
+	}
 
 	v.conn = conn
 	cache, err := ristretto.NewCache(&ristretto.Config{
@@ -175,17 +229,24 @@ func (v *ValidatorService) Start() {
 	}
 	v.validator = &validator{
 		db:                             valDB,
-		validatorClient:                ethpb.NewBeaconNodeValidatorClient(v.conn),
+		validatorClient:                validatorClient,
 		beaconClient:                   ethpb.NewBeaconChainClient(v.conn),
 		node:                           ethpb.NewNodeClient(v.conn),
+		prysmNode:                      pbrpc.NewNodeClient(v.conn),
+		dutiesClient:                   pbrpc.NewDutiesClient(v.conn),
+		validatorsClient:               pbrpc.NewValidatorsClient(v.conn),
 		keyManager:                     v.keyManager,
 		graffiti:                       v.graffiti,
 		logValidatorBalances:           v.logValidatorBalances,
 		emitAccountMetrics:             v.emitAccountMetrics,
 		prevBalance:                    make(map[[48]byte]uint64),
-		attLogs:                        make(map[[32]byte]*attSubmitted),
+		attLogs:                        make(map[uint64]map[[32]byte]*attSubmitted),
 		domainDataCache:                cache,
 		aggregatedSlotCommitteeIDCache: aggregatedSlotCommitteeIDCache,
+		blockFeed:                      new(event.Feed),
+		disabledKeys:                   disabledKeys,
+		estimateRewards:                v.estimateRewards,
+		rewardEstimates:                make(map[uint64]map[[48]byte]*RewardEstimate),
 	}
 	go run(v.ctx, v.validator)
 }
@@ -194,6 +255,11 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	if v.dryRunClient != nil {
+		if err := v.dryRunClient.Close(); err != nil {
+			log.WithError(err).Error("Could not close dry run output")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.Close()
 	}
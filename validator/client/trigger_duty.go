@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/sirupsen/logrus"
+)
+
+// Duties which can be triggered with TriggerDuty.
+const (
+	DutyAttest  = "attest"
+	DutyPropose = "propose"
+)
+
+// TriggerDuty performs the duty for the validating key at the given slot right away, rather
+// than waiting for the beacon node to assign it. It is meant for integration tests against
+// test beacon nodes and for diagnosing failing duties. Slashing protection applies to the
+// triggered duty as it does to assigned duties, and the outcome of the duty is logged.
+func (v *ValidatorService) TriggerDuty(ctx context.Context, duty string, slot uint64, pubKey [48]byte) error {
+	if v.validator == nil {
+		return errors.New("validator is not running")
+	}
+	keys, err := v.keyManager.FetchValidatingKeys()
+	if err != nil {
+		return errors.Wrap(err, "could not fetch validating keys")
+	}
+	found := false
+	for _, key := range keys {
+		if key == pubKey {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("no validating key %#x", pubKey)
+	}
+
+	log.WithFields(logrus.Fields{
+		"duty":   duty,
+		"slot":   slot,
+		"pubKey": fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+	}).Warn("Triggering validator duty manually")
+	switch duty {
+	case DutyAttest:
+		v.validator.SubmitAttestation(ctx, slot, pubKey)
+	case DutyPropose:
+		v.validator.ProposeBlock(ctx, slot, pubKey)
+	default:
+		return fmt.Errorf("unknown duty %q", duty)
+	}
+	return nil
+}
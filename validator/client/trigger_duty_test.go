@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTriggerDuty(t *testing.T) {
+	fv := &fakeValidator{}
+	v := &ValidatorService{
+		validator:  fv,
+		keyManager: testKeyManager,
+	}
+	if err := v.TriggerDuty(context.Background(), DutyAttest, 5, validatorPubKey); err != nil {
+		t.Fatal(err)
+	}
+	if !fv.AttestToBlockHeadCalled || fv.AttestToBlockHeadArg1 != 5 {
+		t.Error("Expected attestation to be submitted at slot 5")
+	}
+	if err := v.TriggerDuty(context.Background(), DutyPropose, 6, validatorPubKey); err != nil {
+		t.Fatal(err)
+	}
+	if !fv.ProposeBlockCalled || fv.ProposeBlockArg1 != 6 {
+		t.Error("Expected block to be proposed at slot 6")
+	}
+}
+
+func TestTriggerDuty_Errors(t *testing.T) {
+	v := &ValidatorService{keyManager: testKeyManager}
+	if err := v.TriggerDuty(context.Background(), DutyAttest, 1, validatorPubKey); err == nil || !strings.Contains(err.Error(), "not running") {
+		t.Errorf("Expected error for validator which is not running, received %v", err)
+	}
+
+	fv := &fakeValidator{}
+	v.validator = fv
+	if err := v.TriggerDuty(context.Background(), DutyAttest, 1, [48]byte{'a'}); err == nil || !strings.Contains(err.Error(), "no validating key") {
+		t.Errorf("Expected error for unknown key, received %v", err)
+	}
+	if err := v.TriggerDuty(context.Background(), "aggregate", 1, validatorPubKey); err == nil || !strings.Contains(err.Error(), "unknown duty") {
+		t.Errorf("Expected error for unknown duty, received %v", err)
+	}
+	if fv.AttestToBlockHeadCalled || fv.ProposeBlockCalled {
+		t.Error("Expected no duty to be performed")
+	}
+}
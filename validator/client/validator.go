@@ -2,6 +2,7 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"encoding/hex"
@@ -21,15 +22,21 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/grpcutil"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type validatorRole int8
@@ -50,18 +57,38 @@ type validator struct {
 	beaconClient                       ethpb.BeaconChainClient
 	graffiti                           []byte
 	node                               ethpb.NodeClient
+	prysmNode                          pbrpc.NodeClient
+	dutiesClient                       pbrpc.DutiesClient
+	validatorsClient                   pbrpc.ValidatorsClient
 	keyManager                         keymanager.KeyManager
 	prevBalance                        map[[48]byte]uint64
 	logValidatorBalances               bool
 	emitAccountMetrics                 bool
-	attLogs                            map[[32]byte]*attSubmitted
+	attLogs                            map[uint64]map[[32]byte]*attSubmitted
 	attLogsLock                        sync.Mutex
 	domainDataLock                     sync.Mutex
 	domainDataCache                    *ristretto.Cache
 	aggregatedSlotCommitteeIDCache     *lru.Cache
 	aggregatedSlotCommitteeIDCacheLock sync.Mutex
+	blockFeed                          *event.Feed
+	highestValidSlot                   uint64
+	highestValidSlotLock               sync.Mutex
+	dutiesChanged                      bool
+	dutiesChangedLock                  sync.Mutex
+	disabledKeys                       map[[48]byte]bool
+	estimateRewards                    bool
+	rewardEstimates                    map[uint64]map[[48]byte]*RewardEstimate
+	rewardEstimatesLock                sync.RWMutex
 }
 
+var (
+	// blockStreamBackoffBase is the wait before reopening a failed blocks stream, it doubles
+	// on every further failure.
+	blockStreamBackoffBase = time.Second
+	// blockStreamMaxBackoff caps the wait before reopening a failed blocks stream.
+	blockStreamMaxBackoff = time.Minute
+)
+
 var validatorStatusesGaugeVec = promauto.NewGaugeVec(
 	prometheus.GaugeOpts{
 		Namespace: "validator",
@@ -106,8 +133,12 @@ func (v *validator) WaitForChainStart(ctx context.Context) error {
 			return errors.Wrap(err, "could not receive ChainStart from stream")
 		}
 		v.genesisTime = chainStartRes.GenesisTime
+		logutil.SetGenesisTime(time.Unix(int64(v.genesisTime), 0))
 		break
 	}
+	if err := v.checkBeaconConfig(ctx); err != nil {
+		return err
+	}
 	// Once the ChainStart log is received, we update the genesis time of the validator client
 	// and begin a slot ticker used to track the current slot the beacon node is in.
 	v.ticker = slotutil.GetSlotTicker(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot)
@@ -171,8 +202,12 @@ func (v *validator) WaitForSynced(ctx context.Context) error {
 			return errors.Wrap(err, "could not receive Synced from stream")
 		}
 		v.genesisTime = syncedRes.GenesisTime
+		logutil.SetGenesisTime(time.Unix(int64(v.genesisTime), 0))
 		break
 	}
+	if err := v.checkBeaconConfig(ctx); err != nil {
+		return err
+	}
 	// Once the Synced log is received, we update the genesis time of the validator client
 	// and begin a slot ticker used to track the current slot the beacon node is in.
 	v.ticker = slotutil.GetSlotTicker(time.Unix(int64(v.genesisTime), 0), params.BeaconConfig().SecondsPerSlot)
@@ -180,6 +215,51 @@ func (v *validator) WaitForSynced(ctx context.Context) error {
 	return nil
 }
 
+// checkBeaconConfig verifies the beacon node runs with the same chain configuration as the
+// validator client, as mismatched timing or fork parameters lead to missed duties and
+// invalid signatures.
+func (v *validator) checkBeaconConfig(ctx context.Context) error {
+	if v.prysmNode == nil {
+		return nil
+	}
+	cfg, err := v.prysmNode.GetBeaconConfig(ctx, &pbrpc.BeaconConfigRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			log.Warn("Beacon node does not report its chain configuration, assuming it matches the validator client")
+			return nil
+		}
+		return errors.Wrap(err, "could not get beacon node chain configuration")
+	}
+	if err := verifyBeaconConfig(cfg, v.genesisTime); err != nil {
+		return errors.Wrap(err, "beacon node chain configuration does not match the validator client")
+	}
+	log.WithFields(logrus.Fields{
+		"configName":     cfg.ConfigName,
+		"forkVersion":    fmt.Sprintf("%#x", cfg.CurrentForkVersion),
+		"secondsPerSlot": cfg.SecondsPerSlot,
+	}).Info("Verified beacon node chain configuration")
+	return nil
+}
+
+// verifyBeaconConfig compares the chain configuration reported by the beacon node with the
+// local configuration and the genesis time received from the beacon node.
+func verifyBeaconConfig(cfg *pbrpc.BeaconConfig, genesisTime uint64) error {
+	local := params.BeaconConfig()
+	if cfg.SecondsPerSlot != local.SecondsPerSlot {
+		return fmt.Errorf("seconds per slot is %d on the beacon node, but %d locally", cfg.SecondsPerSlot, local.SecondsPerSlot)
+	}
+	if cfg.SlotsPerEpoch != local.SlotsPerEpoch {
+		return fmt.Errorf("slots per epoch is %d on the beacon node, but %d locally", cfg.SlotsPerEpoch, local.SlotsPerEpoch)
+	}
+	if !bytes.Equal(cfg.GenesisForkVersion, local.GenesisForkVersion) {
+		return fmt.Errorf("genesis fork version is %#x on the beacon node, but %#x locally", cfg.GenesisForkVersion, local.GenesisForkVersion)
+	}
+	if cfg.GenesisTime != 0 && genesisTime != 0 && cfg.GenesisTime != genesisTime {
+		return fmt.Errorf("genesis time is %d in the beacon node configuration, but %d was received at chain start", cfg.GenesisTime, genesisTime)
+	}
+	return nil
+}
+
 // WaitForActivation checks whether the validator pubkey is in the active
 // validator set. If not, this operation will block until an activation message is
 // received.
@@ -274,6 +354,192 @@ func (v *validator) checkAndLogValidatorStatus(validatorStatuses []*ethpb.Valida
 	return activatedKeys
 }
 
+// ReceiveBlocks starts a gRPC client stream listener to obtain the blocks which become the
+// head of the chain of the beacon node. Upon receiving a block, the highest valid slot is updated and the block is sent over the
+// validator's block feed, so attesters waiting on the block of their slot can proceed.
+// Whenever the stream fails, or the beacon node closes it, it is reopened with an exponential
+// backoff until the context is canceled.
+func (v *validator) ReceiveBlocks(ctx context.Context) {
+	backoff := grpcutil.ExponentialBackoff(blockStreamBackoffBase, blockStreamMaxBackoff)
+	attempt := uint(0)
+	for {
+		stream, err := v.beaconClient.StreamBlocks(ctx, &ptypes.Empty{})
+		if err == nil {
+			var received bool
+			received, err = v.receiveBlocks(stream)
+			// The backoff starts over once the stream delivered blocks again.
+			if received {
+				attempt = 0
+			}
+		}
+		// If context is canceled we stop the loop.
+		if ctx.Err() != nil {
+			log.Debug("Context closed, exiting blocks stream")
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			log.Warn("Beacon node does not support streaming blocks, attestations wait until a third of their slot")
+			return
+		}
+		attempt++
+		wait := backoff(attempt)
+		log.WithError(err).WithField("retryIn", wait).Warn("Could not receive blocks from beacon node, reconnecting")
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			log.Debug("Context closed, exiting blocks stream")
+			return
+		}
+	}
+}
+
+// receiveBlocks handles the blocks received over the stream until the stream fails or is
+// closed, and returns whether any block was received along with the error ending the stream.
+func (v *validator) receiveBlocks(stream ethpb.BeaconChain_StreamBlocksClient) (bool, error) {
+	received := false
+	for {
+		res, err := stream.Recv()
+		if err != nil {
+			return received, err
+		}
+		received = true
+		if res == nil || res.Block == nil {
+			continue
+		}
+		v.highestValidSlotLock.Lock()
+		if res.Block.Slot > v.highestValidSlot {
+			v.highestValidSlot = res.Block.Slot
+		}
+		v.highestValidSlotLock.Unlock()
+		v.blockFeed.Send(res)
+	}
+}
+
+// ReceiveDutiesChanges starts a gRPC client stream listener to be notified by the beacon node
+// when the duties of the validating keys change within the current or next epoch, due to reorgs
+// or late activations. Upon notification, the duties are re-fetched at the start of the next slot.
+func (v *validator) ReceiveDutiesChanges(ctx context.Context) {
+	validatingKeys, err := v.keyManager.FetchValidatingKeys()
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch validating keys")
+		return
+	}
+	stream, err := v.dutiesClient.StreamDutiesChanges(ctx, &pbrpc.DutiesChangesRequest{
+		PublicKeys: bytesutil.FromBytes48Array(validatingKeys),
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve duties changes stream")
+		return
+	}
+
+	for {
+		res, err := stream.Recv()
+		// If the stream is closed, we stop the loop.
+		if err == io.EOF {
+			return
+		}
+		// If context is canceled we stop the loop.
+		if ctx.Err() == context.Canceled {
+			log.Debug("Context closed, exiting duties changes stream")
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			log.Warn("Beacon node does not support duties changes notifications, duties are only updated at epoch start")
+			return
+		}
+		if err != nil {
+			log.WithError(err).Error("Could not receive duties changes from beacon node")
+			return
+		}
+		if res == nil {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"epoch":         res.Epoch,
+			"numValidators": len(res.PublicKeys),
+		}).Info("Validator duties changed, updating duties")
+		v.dutiesChangedLock.Lock()
+		v.dutiesChanged = true
+		v.dutiesChangedLock.Unlock()
+	}
+}
+
+// ReceiveValidatorStatusChanges starts a gRPC client stream listener to be notified by the beacon
+// node of the status transitions of the validating keys, from deposited to active and from
+// exiting to slashed or exited, and logs every transition with the estimated activation and
+// exit times.
+func (v *validator) ReceiveValidatorStatusChanges(ctx context.Context) {
+	validatingKeys, err := v.keyManager.FetchValidatingKeys()
+	if err != nil {
+		log.WithError(err).Error("Failed to fetch validating keys")
+		return
+	}
+	stream, err := v.validatorsClient.StreamValidatorStatusChanges(ctx, &pbrpc.ValidatorStatusChangesRequest{
+		PublicKeys: bytesutil.FromBytes48Array(validatingKeys),
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve validator status changes stream")
+		return
+	}
+
+	for {
+		res, err := stream.Recv()
+		// If the stream is closed, we stop the loop.
+		if err == io.EOF {
+			return
+		}
+		// If context is canceled we stop the loop.
+		if ctx.Err() == context.Canceled {
+			log.Debug("Context closed, exiting validator status changes stream")
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			log.Warn("Beacon node does not support validator status changes notifications")
+			return
+		}
+		if err != nil {
+			log.WithError(err).Error("Could not receive validator status changes from beacon node")
+			return
+		}
+		if res == nil {
+			continue
+		}
+		logValidatorStatusChange(res)
+		if v.emitAccountMetrics {
+			validatorStatusesGaugeVec.WithLabelValues(fmt.Sprintf("%#x", bytesutil.Trunc(res.PublicKey))).Set(float64(res.Status))
+		}
+	}
+}
+
+// logValidatorStatusChange logs a status transition of a validating key, along with its
+// estimated activation or exit time when one is known.
+func logValidatorStatusChange(change *pbrpc.ValidatorStatusChange) {
+	log := log.WithFields(logrus.Fields{
+		"pubKey": fmt.Sprintf("%#x", bytesutil.Trunc(change.PublicKey)),
+		"status": change.Status.String(),
+		"epoch":  change.Epoch,
+	})
+	switch change.Status {
+	case ethpb.ValidatorStatus_DEPOSITED, ethpb.ValidatorStatus_PENDING:
+		if change.EstimatedActivationTime != 0 {
+			log = log.WithFields(logrus.Fields{
+				"activationEpoch":         change.ActivationEpoch,
+				"estimatedActivationTime": time.Unix(int64(change.EstimatedActivationTime), 0),
+			})
+		}
+	case ethpb.ValidatorStatus_EXITING, ethpb.ValidatorStatus_SLASHING:
+		log = log.WithFields(logrus.Fields{
+			"exitEpoch":         change.ExitEpoch,
+			"estimatedExitTime": time.Unix(int64(change.EstimatedExitTime), 0),
+		})
+	}
+	if change.PreviousStatus == ethpb.ValidatorStatus_UNKNOWN_STATUS {
+		log.Info("Validator status")
+		return
+	}
+	log.WithField("previousStatus", change.PreviousStatus.String()).Info("Validator status changed")
+}
+
 // CanonicalHeadSlot returns the slot of canonical block currently found in the
 // beacon chain via RPC.
 func (v *validator) CanonicalHeadSlot(ctx context.Context) (uint64, error) {
@@ -299,10 +565,14 @@ func (v *validator) SlotDeadline(slot uint64) time.Time {
 
 // UpdateDuties checks the slot number to determine if the validator's
 // list of upcoming assignments needs to be updated. For example, at the
-// beginning of a new epoch.
+// beginning of a new epoch or after the beacon node notified of changed duties.
 func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
-	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.duties != nil {
-		// Do nothing if not epoch start AND assignments already exist.
+	v.dutiesChangedLock.Lock()
+	dutiesChanged := v.dutiesChanged
+	v.dutiesChanged = false
+	v.dutiesChangedLock.Unlock()
+	if slot%params.BeaconConfig().SlotsPerEpoch != 0 && v.duties != nil && !dutiesChanged {
+		// Do nothing if not epoch start AND assignments already exist AND they haven't changed.
 		return nil
 	}
 	// Set deadline to end of epoch.
@@ -315,6 +585,7 @@ func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
 	if err != nil {
 		return err
 	}
+	validatingKeys = v.enabledKeys(validatingKeys)
 	req := &ethpb.DutiesRequest{
 		Epoch:      slot / params.BeaconConfig().SlotsPerEpoch,
 		PublicKeys: bytesutil.FromBytes48Array(validatingKeys),
@@ -399,6 +670,20 @@ func (v *validator) UpdateDuties(ctx context.Context, slot uint64) error {
 	return err
 }
 
+// enabledKeys filters out the keys of the accounts which are excluded from duty scheduling.
+func (v *validator) enabledKeys(keys [][48]byte) [][48]byte {
+	if len(v.disabledKeys) == 0 {
+		return keys
+	}
+	enabled := make([][48]byte, 0, len(keys))
+	for _, key := range keys {
+		if !v.disabledKeys[key] {
+			enabled = append(enabled, key)
+		}
+	}
+	return enabled
+}
+
 // RolesAt slot returns the validator roles at the given slot. Returns nil if the
 // validator is known to not have a roles at the at slot. Returns UNKNOWN if the
 // validator assignments are unknown. Otherwise returns a valid validatorRole map.
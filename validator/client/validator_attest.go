@@ -3,10 +3,10 @@ package client
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -16,7 +16,6 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
-	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
@@ -72,7 +71,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot uint64, pubKey [
 		return
 	}
 
-	v.waitToSlotOneThird(ctx, slot)
+	v.waitOneThirdOrValidBlock(ctx, slot)
 
 	req := &ethpb.AttestationDataRequest{
 		Slot:           slot,
@@ -97,6 +96,14 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot uint64, pubKey [
 			}
 			return
 		}
+		history, err = v.recoverSigningJournal(ctx, pubKey, history)
+		if err != nil {
+			log.WithError(err).Error("Could not recover attestations from the signing journal")
+			if v.emitAccountMetrics {
+				validatorAttestFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return
+		}
 		if isNewAttSlashable(history, data.Source.Epoch, data.Target.Epoch) {
 			log.WithFields(logrus.Fields{
 				"sourceEpoch": data.Source.Epoch,
@@ -107,11 +114,26 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot uint64, pubKey [
 			}
 			return
 		}
+		// Record the intent to sign first, so that a crash before the attestation history is
+		// saved can not leave a signature behind which is unknown to the slashing protection.
+		if err := v.db.SaveAttestationSigningIntent(ctx, pubKey[:], data.Source.Epoch, data.Target.Epoch); err != nil {
+			log.WithError(err).Error("Could not save signing intent to DB")
+			if v.emitAccountMetrics {
+				validatorAttestFailVec.WithLabelValues(fmtKey).Inc()
+			}
+			return
+		}
 	}
 
 	sig, err := v.signAtt(ctx, pubKey, data)
 	if err != nil {
 		log.WithError(err).Error("Could not sign attestation")
+		if featureconfig.Get().ProtectAttester {
+			// Nothing was signed, so the intent can safely be dropped.
+			if err := v.db.DeleteAttestationSigningIntent(ctx, pubKey[:], data.Target.Epoch); err != nil {
+				log.WithError(err).Error("Could not delete signing intent from DB")
+			}
+		}
 		if v.emitAccountMetrics {
 			validatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -161,6 +183,9 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot uint64, pubKey [
 			}
 			return
 		}
+		if err := v.db.DeleteAttestationSigningIntent(ctx, pubKey[:], data.Target.Epoch); err != nil {
+			log.WithError(err).Error("Could not mark signing intent as completed in DB")
+		}
 	}
 
 	if err := v.saveAttesterIndexToData(data, duty.ValidatorIndex); err != nil {
@@ -226,23 +251,29 @@ func (v *validator) signAtt(ctx context.Context, pubKey [48]byte, data *ethpb.At
 	return sig.Marshal(), nil
 }
 
-// For logging, this saves the last submitted attester index to its attestation data. The purpose of this
-// is to enhance attesting logs to be readable when multiple validator keys ran in a single client.
-func (v *validator) saveAttesterIndexToData(data *ethpb.AttestationData, index uint64) error {
-	v.attLogsLock.Lock()
-	defer v.attLogsLock.Unlock()
-
-	h, err := hashutil.HashProto(data)
+// recoverSigningJournal marks the attestations which were about to be signed, but never recorded
+// in the attestation history, as attested. These are left behind if the client stopped between
+// signing and saving the history, and are conservatively assumed to have been signed.
+func (v *validator) recoverSigningJournal(ctx context.Context, pubKey [48]byte, history *slashpb.AttestationHistory) (*slashpb.AttestationHistory, error) {
+	intents, err := v.db.AttestationSigningIntents(ctx, pubKey[:])
 	if err != nil {
-		return err
+		return nil, errors.Wrap(err, "could not get signing intents from DB")
 	}
-
-	if v.attLogs[h] == nil {
-		v.attLogs[h] = &attSubmitted{data, []uint64{}, []uint64{}}
+	if len(intents) == 0 {
+		return history, nil
 	}
-	v.attLogs[h] = &attSubmitted{data, append(v.attLogs[h].attesterIndices, index), []uint64{}}
-
-	return nil
+	for targetEpoch, sourceEpoch := range intents {
+		history = markAttestationForTargetEpoch(history, sourceEpoch, targetEpoch)
+	}
+	if err := v.db.SaveAttestationHistory(ctx, pubKey[:], history); err != nil {
+		return nil, errors.Wrap(err, "could not save attestation history to DB")
+	}
+	for targetEpoch := range intents {
+		if err := v.db.DeleteAttestationSigningIntent(ctx, pubKey[:], targetEpoch); err != nil {
+			return nil, errors.Wrap(err, "could not delete signing intent from DB")
+		}
+	}
+	return history, nil
 }
 
 // isNewAttSlashable uses the attestation history to determine if an attestation of sourceEpoch
@@ -310,16 +341,51 @@ func safeTargetToSource(history *slashpb.AttestationHistory, targetEpoch uint64)
 	return history.TargetToSource[targetEpoch%wsPeriod]
 }
 
-// waitToSlotOneThird waits until one third through the current slot period
-// such that head block for beacon node can get updated.
-func (v *validator) waitToSlotOneThird(ctx context.Context, slot uint64) {
-	_, span := trace.StartSpan(ctx, "validator.waitToSlotOneThird")
+// waitOneThirdOrValidBlock waits until a valid block of the current slot is received from
+// the beacon node, or until one third through the current slot period, such that the head
+// block for the beacon node can get updated. The beacon node only streams blocks which became
+// the head of its chain, so the wait is not cut short by a block of a fork.
+func (v *validator) waitOneThirdOrValidBlock(ctx context.Context, slot uint64) {
+	ctx, span := trace.StartSpan(ctx, "validator.waitOneThirdOrValidBlock")
 	defer span.End()
 
-	twoThird := params.BeaconConfig().SecondsPerSlot * 1 / 3
-	delay := time.Duration(twoThird) * time.Second
+	// Don't need to wait if the block of the requested slot has already been received.
+	v.highestValidSlotLock.Lock()
+	highestValidSlot := v.highestValidSlot
+	v.highestValidSlotLock.Unlock()
+	if slot <= highestValidSlot {
+		return
+	}
+
+	oneThird := params.BeaconConfig().SecondsPerSlot * 1 / 3
+	delay := time.Duration(oneThird) * time.Second
 
 	startTime := slotutil.SlotStartTime(v.genesisTime, slot)
 	finalTime := startTime.Add(delay)
-	time.Sleep(roughtime.Until(finalTime))
+	t := time.NewTimer(roughtime.Until(finalTime))
+	defer t.Stop()
+	if v.blockFeed == nil {
+		<-t.C
+		return
+	}
+
+	bChannel := make(chan *ethpb.SignedBeaconBlock, 1)
+	sub := v.blockFeed.Subscribe(bChannel)
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case b := <-bChannel:
+			if b.Block.Slot == slot {
+				return
+			}
+		case <-ctx.Done():
+			return
+		case <-sub.Err():
+			log.Error("Subscriber closed, exiting goroutine")
+			return
+		case <-t.C:
+			return
+		}
+	}
 }
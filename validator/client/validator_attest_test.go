@@ -13,6 +13,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
@@ -186,6 +187,51 @@ func TestAttestToBlockHead_BlocksDoubleAtt(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Attempted to make a slashable attestation, rejected")
 }
 
+func TestAttestToBlockHead_BlocksDoubleAttFromSigningJournal(t *testing.T) {
+	config := &featureconfig.Flags{
+		ProtectAttester: true,
+	}
+	reset := featureconfig.InitWithReset(config)
+	defer reset()
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+	validatorIndex := uint64(7)
+	committee := []uint64{0, 3, 4, 2, validatorIndex, 6, 8, 9, 10}
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      validatorKey.PublicKey.Marshal(),
+			CommitteeIndex: 5,
+			Committee:      committee,
+			ValidatorIndex: validatorIndex,
+		}}}
+	m.validatorClient.EXPECT().GetAttestationData(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AttestationDataRequest{}),
+	).Return(&ethpb.AttestationData{
+		BeaconBlockRoot: []byte("A"),
+		Target:          &ethpb.Checkpoint{Root: []byte("B"), Epoch: 4},
+		Source:          &ethpb.Checkpoint{Root: []byte("C"), Epoch: 3},
+	}, nil)
+
+	// A signature for the same target epoch was produced right before a crash, so it never
+	// made it into the attestation history.
+	if err := validator.db.SaveAttestationSigningIntent(context.Background(), validatorPubKey[:], 2, 4); err != nil {
+		t.Fatal(err)
+	}
+
+	validator.SubmitAttestation(context.Background(), 30, validatorPubKey)
+	testutil.AssertLogsContain(t, hook, "Attempted to make a slashable attestation, rejected")
+
+	intents, err := validator.db.AttestationSigningIntents(context.Background(), validatorPubKey[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(intents) != 0 {
+		t.Errorf("Expected signing journal to be cleared after recovery, received %v", intents)
+	}
+}
+
 func TestAttestToBlockHead_BlocksSurroundAtt(t *testing.T) {
 	config := &featureconfig.Flags{
 		ProtectAttester: true,
@@ -359,6 +405,48 @@ func TestAttestToBlockHead_DoesAttestAfterDelay(t *testing.T) {
 	validator.SubmitAttestation(context.Background(), 0, validatorPubKey)
 }
 
+func TestWaitOneThirdOrValidBlock_ReturnsOnValidBlock(t *testing.T) {
+	validator, _, finish := setup(t)
+	defer finish()
+	validator.genesisTime = uint64(roughtime.Now().Unix())
+	validator.blockFeed = new(event.Feed)
+
+	done := make(chan struct{})
+	go func() {
+		validator.waitOneThirdOrValidBlock(context.Background(), 1)
+		close(done)
+	}()
+
+	// Send in a loop to ensure it is delivered (busy wait for the validator to subscribe to the block feed).
+	for sent := 0; sent == 0; {
+		sent = validator.blockFeed.Send(&ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1}})
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Did not stop waiting after receiving the block of the slot")
+	}
+}
+
+func TestWaitOneThirdOrValidBlock_NoWaitIfBlockAlreadyReceived(t *testing.T) {
+	validator, _, finish := setup(t)
+	defer finish()
+	validator.genesisTime = uint64(roughtime.Now().Unix())
+	validator.blockFeed = new(event.Feed)
+	validator.highestValidSlot = 2
+
+	done := make(chan struct{})
+	go func() {
+		validator.waitOneThirdOrValidBlock(context.Background(), 2)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Waited although the block of the slot was already received")
+	}
+}
+
 func TestAttestToBlockHead_CorrectBitfieldLength(t *testing.T) {
 	validator, m, finish := setup(t)
 	defer finish()
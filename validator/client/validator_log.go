@@ -4,33 +4,118 @@ import (
 	"fmt"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/sirupsen/logrus"
 )
 
+// attLogsRetainedEpochs is the number of epochs submitted attestations are kept for, so the
+// previous epoch can still be summarized once its performance is reported.
+const attLogsRetainedEpochs = 4
+
 type attSubmitted struct {
 	data              *ethpb.AttestationData
 	attesterIndices   []uint64
 	aggregatorIndices []uint64
+	logged            bool
+}
+
+// attSubmissionSummary aggregates the attestations submitted by the validator client in a slot.
+type attSubmissionSummary struct {
+	attestations int
+	aggregates   int
 }
 
 func (v *validator) LogAttestationsSubmitted() {
 	v.attLogsLock.Lock()
 	defer v.attLogsLock.Unlock()
 
-	for _, attLog := range v.attLogs {
-		log.WithFields(logrus.Fields{
-			"Slot":              attLog.data.Slot,
-			"CommitteeIndex":    attLog.data.CommitteeIndex,
-			"BeaconBlockRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(attLog.data.BeaconBlockRoot)),
-			"SourceEpoch":       attLog.data.Source.Epoch,
-			"SourceRoot":        fmt.Sprintf("%#x", bytesutil.Trunc(attLog.data.Source.Root)),
-			"TargetEpoch":       attLog.data.Target.Epoch,
-			"TargetRoot":        fmt.Sprintf("%#x", bytesutil.Trunc(attLog.data.Target.Root)),
-			"AttesterIndices":   attLog.attesterIndices,
-			"AggregatorIndices": attLog.aggregatorIndices,
-		}).Info("Submitted new attestations")
+	for _, epochLogs := range v.attLogs {
+		for _, attLog := range epochLogs {
+			if attLog.logged {
+				continue
+			}
+			log.WithFields(logrus.Fields{
+				"Slot":              attLog.data.Slot,
+				"CommitteeIndex":    attLog.data.CommitteeIndex,
+				"BeaconBlockRoot":   fmt.Sprintf("%#x", bytesutil.Trunc(attLog.data.BeaconBlockRoot)),
+				"SourceEpoch":       attLog.data.Source.Epoch,
+				"SourceRoot":        fmt.Sprintf("%#x", bytesutil.Trunc(attLog.data.Source.Root)),
+				"TargetEpoch":       attLog.data.Target.Epoch,
+				"TargetRoot":        fmt.Sprintf("%#x", bytesutil.Trunc(attLog.data.Target.Root)),
+				"AttesterIndices":   attLog.attesterIndices,
+				"AggregatorIndices": attLog.aggregatorIndices,
+			}).Info("Submitted new attestations")
+			attLog.logged = true
+		}
+	}
+}
+
+// For logging, this saves the last submitted attester index to its attestation data. The purpose of this
+// is to enhance attesting logs to be readable when multiple validator keys ran in a single client.
+func (v *validator) saveAttesterIndexToData(data *ethpb.AttestationData, index uint64) error {
+	v.attLogsLock.Lock()
+	defer v.attLogsLock.Unlock()
+
+	h, err := hashutil.HashProto(data)
+	if err != nil {
+		return err
+	}
+
+	epochLogs := v.epochAttLogs(helpers.SlotToEpoch(data.Slot))
+	if epochLogs[h] == nil {
+		epochLogs[h] = &attSubmitted{data: data, attesterIndices: []uint64{}, aggregatorIndices: []uint64{}}
 	}
+	epochLogs[h].attesterIndices = append(epochLogs[h].attesterIndices, index)
+	epochLogs[h].logged = false
 
-	v.attLogs = make(map[[32]byte]*attSubmitted)
+	return nil
+}
+
+func (v *validator) addIndicesToLog(duty *ethpb.DutiesResponse_Duty) error {
+	v.attLogsLock.Lock()
+	defer v.attLogsLock.Unlock()
+
+	for _, log := range v.attLogs[helpers.SlotToEpoch(duty.AttesterSlot)] {
+		if duty.AttesterSlot == log.data.Slot && duty.CommitteeIndex == log.data.CommitteeIndex {
+			log.aggregatorIndices = append(log.aggregatorIndices, duty.ValidatorIndex)
+			log.logged = false
+		}
+	}
+
+	return nil
+}
+
+// epochAttLogs returns the attestation logs of the epoch, creating them if needed and pruning
+// the logs of epochs which are no longer retained. The caller must hold attLogsLock.
+func (v *validator) epochAttLogs(epoch uint64) map[[32]byte]*attSubmitted {
+	if v.attLogs[epoch] == nil {
+		v.attLogs[epoch] = make(map[[32]byte]*attSubmitted)
+		for e := range v.attLogs {
+			if e+attLogsRetainedEpochs <= epoch {
+				delete(v.attLogs, e)
+			}
+		}
+	}
+	return v.attLogs[epoch]
+}
+
+// attestationsSubmittedSummary returns, per slot, the number of attestations and aggregates
+// submitted by the validator client during the epoch.
+func (v *validator) attestationsSubmittedSummary(epoch uint64) map[uint64]*attSubmissionSummary {
+	v.attLogsLock.Lock()
+	defer v.attLogsLock.Unlock()
+
+	summary := make(map[uint64]*attSubmissionSummary)
+	for _, attLog := range v.attLogs[epoch] {
+		s, ok := summary[attLog.data.Slot]
+		if !ok {
+			s = &attSubmissionSummary{}
+			summary[attLog.data.Slot] = s
+		}
+		s.attestations += len(attLog.attesterIndices)
+		s.aggregates += len(attLog.aggregatorIndices)
+	}
+	return summary
 }
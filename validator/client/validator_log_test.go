@@ -0,0 +1,57 @@
+package client
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestAttestationLogs_SummarizedAndPruned(t *testing.T) {
+	v := &validator{attLogs: make(map[uint64]map[[32]byte]*attSubmitted)}
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	attData := func(slot uint64) *ethpb.AttestationData {
+		return &ethpb.AttestationData{
+			Slot:            slot,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+		}
+	}
+
+	for _, index := range []uint64{1, 2} {
+		if err := v.saveAttesterIndexToData(attData(1), index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := v.saveAttesterIndexToData(attData(2), 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.addIndicesToLog(&ethpb.DutiesResponse_Duty{AttesterSlot: 1, ValidatorIndex: 2}); err != nil {
+		t.Fatal(err)
+	}
+	summary := v.attestationsSubmittedSummary(0)
+	if summary[1].attestations != 2 || summary[1].aggregates != 1 {
+		t.Errorf("Unexpected summary of slot 1: %+v", summary[1])
+	}
+	if summary[2].attestations != 1 || summary[2].aggregates != 0 {
+		t.Errorf("Unexpected summary of slot 2: %+v", summary[2])
+	}
+
+	v.LogAttestationsSubmitted()
+	for _, attLog := range v.attLogs[0] {
+		if !attLog.logged {
+			t.Error("Expected attestation to be marked as logged")
+		}
+	}
+
+	if err := v.saveAttesterIndexToData(attData(attLogsRetainedEpochs*slotsPerEpoch), 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := v.attLogs[0]; ok {
+		t.Error("Expected attestation logs of epoch 0 to be pruned")
+	}
+	if len(v.attLogs) != 1 {
+		t.Errorf("Expected logs of a single epoch to be retained, got %d", len(v.attLogs))
+	}
+}
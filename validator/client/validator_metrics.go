@@ -116,8 +116,17 @@ func (v *validator) LogValidatorGainsAndLosses(ctx context.Context, slot uint64)
 		reported++
 	}
 
+	prevEpoch := (slot / params.BeaconConfig().SlotsPerEpoch) - 1
+	attestationsSubmitted, aggregatesSubmitted := 0, 0
+	for _, s := range v.attestationsSubmittedSummary(prevEpoch) {
+		attestationsSubmitted += s.attestations
+		aggregatesSubmitted += s.aggregates
+	}
+
 	log.WithFields(logrus.Fields{
-		"epoch":                          (slot / params.BeaconConfig().SlotsPerEpoch) - 1,
+		"epoch":                          prevEpoch,
+		"attestationsSubmitted":          attestationsSubmitted,
+		"aggregatesSubmitted":            aggregatesSubmitted,
 		"attestationInclusionPercentage": fmt.Sprintf("%.0f%%", (float64(included)/float64(len(resp.InclusionSlots)))*100),
 		"correctlyVotedSourcePercentage": fmt.Sprintf("%.0f%%", (float64(votedSource)/float64(len(resp.CorrectlyVotedSource)))*100),
 		"correctlyVotedTargetPercentage": fmt.Sprintf("%.0f%%", (float64(votedTarget)/float64(len(resp.CorrectlyVotedTarget)))*100),
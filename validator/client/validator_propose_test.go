@@ -37,7 +37,7 @@ func setup(t *testing.T) (*validator, *mocks, func()) {
 		validatorClient: m.validatorClient,
 		keyManager:      testKeyManager,
 		graffiti:        []byte{},
-		attLogs:         make(map[[32]byte]*attSubmitted),
+		attLogs:         make(map[uint64]map[[32]byte]*attSubmitted),
 		aggregatedSlotCommitteeIDCache: aggregatedSlotCommitteeIDCache,
 	}
 
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"errors"
+	"io"
 	"io/ioutil"
 	"reflect"
 	"strings"
@@ -12,8 +13,10 @@ import (
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/mock"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
@@ -21,6 +24,8 @@ import (
 	"github.com/prysmaticlabs/prysm/validator/keymanager"
 	"github.com/sirupsen/logrus"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func init() {
@@ -488,6 +493,65 @@ func TestWaitActivation_NotAllValidatorsActivatedOK(t *testing.T) {
 	}
 }
 
+func TestVerifyBeaconConfig(t *testing.T) {
+	local := params.BeaconConfig()
+	matching := func() *pbrpc.BeaconConfig {
+		return &pbrpc.BeaconConfig{
+			GenesisTime:        100,
+			SecondsPerSlot:     local.SecondsPerSlot,
+			SlotsPerEpoch:      local.SlotsPerEpoch,
+			GenesisForkVersion: local.GenesisForkVersion,
+		}
+	}
+	tests := []struct {
+		name    string
+		modify  func(cfg *pbrpc.BeaconConfig)
+		wantErr string
+	}{
+		{
+			name:   "matching config",
+			modify: func(cfg *pbrpc.BeaconConfig) {},
+		},
+		{
+			name:   "genesis time unknown to the beacon node",
+			modify: func(cfg *pbrpc.BeaconConfig) { cfg.GenesisTime = 0 },
+		},
+		{
+			name:    "seconds per slot mismatch",
+			modify:  func(cfg *pbrpc.BeaconConfig) { cfg.SecondsPerSlot++ },
+			wantErr: "seconds per slot",
+		},
+		{
+			name:    "slots per epoch mismatch",
+			modify:  func(cfg *pbrpc.BeaconConfig) { cfg.SlotsPerEpoch++ },
+			wantErr: "slots per epoch",
+		},
+		{
+			name:    "genesis fork version mismatch",
+			modify:  func(cfg *pbrpc.BeaconConfig) { cfg.GenesisForkVersion = []byte{9, 9, 9, 9} },
+			wantErr: "genesis fork version",
+		},
+		{
+			name:    "genesis time mismatch",
+			modify:  func(cfg *pbrpc.BeaconConfig) { cfg.GenesisTime = 200 },
+			wantErr: "genesis time",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := matching()
+			tt.modify(cfg)
+			err := verifyBeaconConfig(cfg, 100)
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected error containing %q, received %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestWaitSync_ContextCanceled(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -586,6 +650,84 @@ func TestUpdateDuties_DoesNothingWhenNotEpochStart_AlreadyExistingAssignments(t
 	}
 }
 
+func TestUpdateDuties_RefetchesChangedDutiesMidEpoch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := internal.NewMockBeaconNodeValidatorClient(ctrl)
+
+	slot := uint64(1)
+	resp := &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{
+				AttesterSlot:   2,
+				CommitteeIndex: 1,
+			},
+		},
+	}
+	v := validator{
+		keyManager:      testKeyManager,
+		validatorClient: client,
+		duties: &ethpb.DutiesResponse{
+			Duties: []*ethpb.DutiesResponse_Duty{
+				{
+					AttesterSlot:   10,
+					CommitteeIndex: 20,
+				},
+			},
+		},
+		dutiesChanged: true,
+	}
+	client.EXPECT().GetDuties(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(resp, nil).Times(2)
+
+	client.EXPECT().SubscribeCommitteeSubnets(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(nil, nil)
+
+	if err := v.UpdateDuties(context.Background(), slot); err != nil {
+		t.Fatalf("Could not update assignments: %v", err)
+	}
+	if v.duties.Duties[0].AttesterSlot != 2 {
+		t.Errorf("Wanted the changed duties, received attester slot %d", v.duties.Duties[0].AttesterSlot)
+	}
+	if v.dutiesChanged {
+		t.Error("Wanted the changed duties notification to be cleared")
+	}
+}
+
+func TestUpdateDuties_ExcludesDisabledAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := internal.NewMockBeaconNodeValidatorClient(ctrl)
+
+	v := validator{
+		keyManager:      testKeyManager,
+		validatorClient: client,
+		disabledKeys:    map[[48]byte]bool{validatorPubKey: true},
+	}
+	client.EXPECT().GetDuties(
+		gomock.Any(),
+		gomock.Any(),
+	).DoAndReturn(func(_ context.Context, req *ethpb.DutiesRequest) (*ethpb.DutiesResponse, error) {
+		if len(req.PublicKeys) != 0 {
+			t.Errorf("Expected disabled account to be excluded, requested duties of %d keys", len(req.PublicKeys))
+		}
+		return &ethpb.DutiesResponse{}, nil
+	}).Times(2)
+
+	client.EXPECT().SubscribeCommitteeSubnets(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(nil, nil)
+
+	if err := v.UpdateDuties(context.Background(), params.BeaconConfig().SlotsPerEpoch); err != nil {
+		t.Fatalf("Could not update assignments: %v", err)
+	}
+}
+
 func TestUpdateDuties_ReturnsError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -921,3 +1063,50 @@ func TestCheckAndLogValidatorStatus_OK(t *testing.T) {
 		})
 	}
 }
+
+func TestReceiveBlocks_ReconnectsOnStreamFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	defer func(base, max time.Duration) {
+		blockStreamBackoffBase, blockStreamMaxBackoff = base, max
+	}(blockStreamBackoffBase, blockStreamMaxBackoff)
+	blockStreamBackoffBase, blockStreamMaxBackoff = time.Millisecond, 10*time.Millisecond
+
+	v := validator{
+		beaconClient: client,
+		blockFeed:    new(event.Feed),
+	}
+	blocks := make(chan *ethpb.SignedBeaconBlock, 1)
+	sub := v.blockFeed.Subscribe(blocks)
+	defer sub.Unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	unavailable := status.Error(codes.Unavailable, "connection lost")
+	failing := mock.NewMockBeaconChain_StreamBlocksClient(ctrl)
+	closing := mock.NewMockBeaconChain_StreamBlocksClient(ctrl)
+	gomock.InOrder(
+		client.EXPECT().StreamBlocks(gomock.Any(), &ptypes.Empty{}).Return(nil, unavailable),
+		client.EXPECT().StreamBlocks(gomock.Any(), &ptypes.Empty{}).Return(failing, nil),
+		failing.EXPECT().Recv().Return(&ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 5}}, nil),
+		failing.EXPECT().Recv().Return(nil, unavailable),
+		client.EXPECT().StreamBlocks(gomock.Any(), &ptypes.Empty{}).Return(closing, nil),
+		closing.EXPECT().Recv().Return(nil, io.EOF).Do(func() {
+			cancel()
+		}),
+	)
+	v.ReceiveBlocks(ctx)
+
+	if v.highestValidSlot != 5 {
+		t.Errorf("Expected highest valid slot 5, received %d", v.highestValidSlot)
+	}
+	select {
+	case blk := <-blocks:
+		if blk.Block.Slot != 5 {
+			t.Errorf("Expected block of slot 5, received slot %d", blk.Block.Slot)
+		}
+	default:
+		t.Error("Expected block to be sent over the block feed")
+	}
+}
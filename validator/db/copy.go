@@ -0,0 +1,42 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+)
+
+// CopyKVStore writes a consistent copy of the database at the directory path to the
+// destination directory, replacing the database found there. Nothing is copied if there
+// is no database at the directory path.
+func CopyKVStore(dirPath string, destDirPath string) error {
+	destFile := filepath.Join(destDirPath, databaseFileName)
+	if err := os.Remove(destFile); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "could not remove destination database")
+	}
+	datafile := filepath.Join(dirPath, databaseFileName)
+	if _, err := os.Stat(datafile); os.IsNotExist(err) {
+		return nil
+	}
+	boltDB, err := bolt.Open(datafile, 0600, &bolt.Options{Timeout: 1 * time.Second, ReadOnly: true})
+	if err != nil {
+		if err == bolt.ErrTimeout {
+			return errors.New("cannot obtain database lock, database may be in use by another process")
+		}
+		return err
+	}
+	defer func() {
+		if err := boltDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close database")
+		}
+	}()
+	if err := os.MkdirAll(destDirPath, 0700); err != nil {
+		return err
+	}
+	return boltDB.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(destFile, 0600)
+	})
+}
@@ -0,0 +1,61 @@
+package db
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+func TestCopyKVStore(t *testing.T) {
+	pubkey := [48]byte{1}
+	db := SetupDB(t, [][48]byte{pubkey})
+	defer func() {
+		if err := os.RemoveAll(db.DatabasePath()); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	ctx := context.Background()
+
+	slotBits := bitfield.Bitlist{0x04, 0x00, 0x00, 0x00, 0x04}
+	if err := db.SaveProposalHistoryForEpoch(ctx, pubkey[:], 2, slotBits); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dest := filepath.Join(db.DatabasePath(), "copy")
+	if err := CopyKVStore(db.DatabasePath(), dest); err != nil {
+		t.Fatal(err)
+	}
+	copied, err := NewKVStore(dest, [][48]byte{pubkey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer TeardownDB(t, copied)
+	received, err := copied.ProposalHistoryForEpoch(ctx, pubkey[:], 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !received.BitAt(2) {
+		t.Errorf("Expected copied proposal history %#x, received %#x", slotBits, received)
+	}
+}
+
+func TestCopyKVStore_NoDatabase(t *testing.T) {
+	dir := filepath.Join(TempDir(), "copy-no-database")
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if err := CopyKVStore(filepath.Join(dir, "missing"), filepath.Join(dir, "dest")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dest", databaseFileName)); !os.IsNotExist(err) {
+		t.Errorf("Expected no database to be copied, received %v", err)
+	}
+}
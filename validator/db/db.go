@@ -85,6 +85,8 @@ func NewKVStore(dirPath string, pubKeys [][48]byte) (*Store, error) {
 			tx,
 			historicProposalsBucket,
 			historicAttestationsBucket,
+			signingJournalBucket,
+			disabledAccountsBucket,
 		)
 	}); err != nil {
 		return nil, err
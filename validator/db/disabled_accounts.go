@@ -0,0 +1,48 @@
+package db
+
+import (
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// DisableAccount excludes the validator public key from duty scheduling, while its keys and
+// slashing protection history are kept.
+func (db *Store) DisableAccount(ctx context.Context, publicKey []byte) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.DisableAccount")
+	defer span.End()
+
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(disabledAccountsBucket)
+		return bucket.Put(publicKey, []byte{1})
+	})
+}
+
+// EnableAccount includes a previously disabled validator public key in duty scheduling again.
+func (db *Store) EnableAccount(ctx context.Context, publicKey []byte) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.EnableAccount")
+	defer span.End()
+
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(disabledAccountsBucket)
+		return bucket.Delete(publicKey)
+	})
+}
+
+// DisabledAccounts returns the validator public keys which are excluded from duty scheduling.
+func (db *Store) DisabledAccounts(ctx context.Context) ([][48]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.DisabledAccounts")
+	defer span.End()
+
+	var pubKeys [][48]byte
+	err := db.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(disabledAccountsBucket).ForEach(func(k, _ []byte) error {
+			var pubKey [48]byte
+			copy(pubKey[:], k)
+			pubKeys = append(pubKeys, pubKey)
+			return nil
+		})
+	})
+	return pubKeys, err
+}
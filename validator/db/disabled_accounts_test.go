@@ -0,0 +1,30 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDisabledAccounts(t *testing.T) {
+	pubkeys := [][48]byte{{1}, {2}}
+	db := SetupDB(t, pubkeys)
+	defer TeardownDB(t, db)
+	ctx := context.Background()
+
+	for _, pubkey := range pubkeys {
+		if err := db.DisableAccount(ctx, pubkey[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.EnableAccount(ctx, pubkeys[0][:]); err != nil {
+		t.Fatal(err)
+	}
+	disabled, err := db.DisabledAccounts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][48]byte{pubkeys[1]}; !reflect.DeepEqual(disabled, want) {
+		t.Errorf("Wanted disabled accounts %v, received %v", want, disabled)
+	}
+}
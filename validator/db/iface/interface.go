@@ -22,4 +22,11 @@ type ValidatorDB interface {
 	AttestationHistory(ctx context.Context, publicKey []byte) (*slashpb.AttestationHistory, error)
 	SaveAttestationHistory(ctx context.Context, publicKey []byte, history *slashpb.AttestationHistory) error
 	DeleteAttestationHistory(ctx context.Context, publicKey []byte) error
+	SaveAttestationSigningIntent(ctx context.Context, publicKey []byte, sourceEpoch, targetEpoch uint64) error
+	AttestationSigningIntents(ctx context.Context, publicKey []byte) (map[uint64]uint64, error)
+	DeleteAttestationSigningIntent(ctx context.Context, publicKey []byte, targetEpoch uint64) error
+	// Account related methods.
+	DisableAccount(ctx context.Context, publicKey []byte) error
+	EnableAccount(ctx context.Context, publicKey []byte) error
+	DisabledAccounts(ctx context.Context) ([][48]byte, error)
 }
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// interchangeFormatVersion is the version of the slashing protection interchange format.
+const interchangeFormatVersion = "5"
+
+// ProtectionInterchange holds the slashing protection records of validators in the
+// interchange format of EIP-3076, so they can be imported by another validator client.
+type ProtectionInterchange struct {
+	Metadata *InterchangeMetadata          `json:"metadata"`
+	Data     []*InterchangeValidatorRecord `json:"data"`
+}
+
+// InterchangeMetadata describes the chain the slashing protection records belong to. The
+// validator database does not store the genesis validators root, so it is left zeroed.
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+// InterchangeValidatorRecord holds the signed blocks and attestations of a single validator.
+type InterchangeValidatorRecord struct {
+	PublicKey          string                    `json:"pubkey"`
+	SignedBlocks       []*InterchangeBlock       `json:"signed_blocks"`
+	SignedAttestations []*InterchangeAttestation `json:"signed_attestations"`
+}
+
+// InterchangeBlock is a block proposal signed by a validator.
+type InterchangeBlock struct {
+	Slot string `json:"slot"`
+}
+
+// InterchangeAttestation is an attestation signed by a validator.
+type InterchangeAttestation struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+}
+
+// ExportSlashingProtection returns the proposal and attestation history of the given validator
+// public keys in the slashing protection interchange format.
+func (db *Store) ExportSlashingProtection(ctx context.Context, publicKeys [][48]byte) (*ProtectionInterchange, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.ExportSlashingProtection")
+	defer span.End()
+
+	interchange := &ProtectionInterchange{
+		Metadata: &InterchangeMetadata{
+			InterchangeFormatVersion: interchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", make([]byte, 32)),
+		},
+		Data: make([]*InterchangeValidatorRecord, 0, len(publicKeys)),
+	}
+	for _, pubKey := range publicKeys {
+		blocks, err := db.signedBlocks(pubKey[:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not export proposal history of %#x", pubKey)
+		}
+		atts, err := db.signedAttestations(ctx, pubKey[:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not export attestation history of %#x", pubKey)
+		}
+		interchange.Data = append(interchange.Data, &InterchangeValidatorRecord{
+			PublicKey:          fmt.Sprintf("%#x", pubKey),
+			SignedBlocks:       blocks,
+			SignedAttestations: atts,
+		})
+	}
+	return interchange, nil
+}
+
+// signedBlocks returns the slots of the proposal history of the validator.
+func (db *Store) signedBlocks(publicKey []byte) ([]*InterchangeBlock, error) {
+	blocks := make([]*InterchangeBlock, 0)
+	err := db.view(func(tx *bolt.Tx) error {
+		valBucket := tx.Bucket(historicProposalsBucket).Bucket(publicKey)
+		if valBucket == nil {
+			return nil
+		}
+		return valBucket.ForEach(func(k, v []byte) error {
+			epoch := binary.LittleEndian.Uint64(k)
+			slotBits := bitfield.Bitlist(v)
+			for i := uint64(0); i < slotBits.Len(); i++ {
+				if slotBits.BitAt(i) {
+					slot := epoch*params.BeaconConfig().SlotsPerEpoch + i
+					blocks = append(blocks, &InterchangeBlock{Slot: strconv.FormatUint(slot, 10)})
+				}
+			}
+			return nil
+		})
+	})
+	return blocks, err
+}
+
+// signedAttestations returns the source and target epochs of the attestation history of the validator.
+func (db *Store) signedAttestations(ctx context.Context, publicKey []byte) ([]*InterchangeAttestation, error) {
+	history, err := db.AttestationHistory(ctx, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	oldest := uint64(0)
+	if history.LatestEpochWritten >= wsPeriod {
+		oldest = history.LatestEpochWritten - wsPeriod + 1
+	}
+	atts := make([]*InterchangeAttestation, 0)
+	for target := oldest; target <= history.LatestEpochWritten; target++ {
+		source, ok := history.TargetToSource[target%wsPeriod]
+		if !ok || source == farFuture {
+			continue
+		}
+		atts = append(atts, &InterchangeAttestation{
+			SourceEpoch: strconv.FormatUint(source, 10),
+			TargetEpoch: strconv.FormatUint(target, 10),
+		})
+	}
+	return atts, nil
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func TestExportSlashingProtection(t *testing.T) {
+	pubkey := [48]byte{1}
+	db := SetupDB(t, [][48]byte{pubkey})
+	defer TeardownDB(t, db)
+	ctx := context.Background()
+
+	slotBits := bitfield.NewBitlist(params.BeaconConfig().SlotsPerEpoch)
+	slotBits.SetBitAt(3, true)
+	if err := db.SaveProposalHistoryForEpoch(ctx, pubkey[:], 2, slotBits); err != nil {
+		t.Fatal(err)
+	}
+	farFuture := params.BeaconConfig().FarFutureEpoch
+	history := &slashpb.AttestationHistory{
+		TargetToSource:     map[uint64]uint64{0: farFuture, 1: 0, 2: farFuture, 3: 1},
+		LatestEpochWritten: 3,
+	}
+	if err := db.SaveAttestationHistory(ctx, pubkey[:], history); err != nil {
+		t.Fatal(err)
+	}
+
+	interchange, err := db.ExportSlashingProtection(ctx, [][48]byte{pubkey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(interchange.Data) != 1 {
+		t.Fatalf("Expected records of a single validator, received %d", len(interchange.Data))
+	}
+	record := interchange.Data[0]
+	wantBlocks := []*InterchangeBlock{{Slot: strconv.FormatUint(2*params.BeaconConfig().SlotsPerEpoch+3, 10)}}
+	if !reflect.DeepEqual(record.SignedBlocks, wantBlocks) {
+		t.Errorf("Wanted signed blocks %v, received %v", wantBlocks, record.SignedBlocks)
+	}
+	wantAtts := []*InterchangeAttestation{
+		{SourceEpoch: "0", TargetEpoch: "1"},
+		{SourceEpoch: "1", TargetEpoch: "3"},
+	}
+	if !reflect.DeepEqual(record.SignedAttestations, wantAtts) {
+		t.Errorf("Wanted signed attestations %v, received %v", wantAtts, record.SignedAttestations)
+	}
+}
@@ -5,4 +5,8 @@ var (
 	historicProposalsBucket = []byte("proposal-history-bucket")
 	// Validator slashing protection from slashable attestations.
 	historicAttestationsBucket = []byte("attestation-history-bucket")
+	// Attestations about to be signed, which are not yet recorded in the attestation history.
+	signingJournalBucket = []byte("signing-journal-bucket")
+	// Validator accounts which are kept, but excluded from duty scheduling.
+	disabledAccountsBucket = []byte("disabled-accounts-bucket")
 )
@@ -0,0 +1,73 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// signingJournalKey is the public key of the validator followed by the big endian target
+// epoch, so the intents of a validator are stored next to each other.
+func signingJournalKey(publicKey []byte, targetEpoch uint64) []byte {
+	key := make([]byte, len(publicKey)+8)
+	copy(key, publicKey)
+	binary.BigEndian.PutUint64(key[len(publicKey):], targetEpoch)
+	return key
+}
+
+// SaveAttestationSigningIntent records that an attestation with the given source and target
+// epochs is about to be signed. It must be written before signing, so that a signature produced
+// right before a crash can be recovered into the attestation history on the next run.
+func (db *Store) SaveAttestationSigningIntent(ctx context.Context, publicKey []byte, sourceEpoch, targetEpoch uint64) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.SaveAttestationSigningIntent")
+	defer span.End()
+
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, sourceEpoch)
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(signingJournalBucket)
+		return bucket.Put(signingJournalKey(publicKey, targetEpoch), enc)
+	})
+}
+
+// AttestationSigningIntents returns the source epochs of the attestations of the validator which
+// were about to be signed, but not marked as completed, keyed by target epoch.
+func (db *Store) AttestationSigningIntents(ctx context.Context, publicKey []byte) (map[uint64]uint64, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.AttestationSigningIntents")
+	defer span.End()
+
+	intents := make(map[uint64]uint64)
+	err := db.view(func(tx *bolt.Tx) error {
+		c := tx.Bucket(signingJournalBucket).Cursor()
+		for k, v := c.Seek(publicKey); k != nil && bytes.HasPrefix(k, publicKey); k, v = c.Next() {
+			if len(k) != len(publicKey)+8 {
+				continue
+			}
+			if len(v) != 8 {
+				return errors.Errorf("invalid signing journal entry of length %d", len(v))
+			}
+			intents[binary.BigEndian.Uint64(k[len(publicKey):])] = binary.BigEndian.Uint64(v)
+		}
+		return nil
+	})
+	return intents, err
+}
+
+// DeleteAttestationSigningIntent marks the signing of the attestation with the given target epoch
+// as completed, once it has been recorded in the attestation history.
+func (db *Store) DeleteAttestationSigningIntent(ctx context.Context, publicKey []byte, targetEpoch uint64) error {
+	ctx, span := trace.StartSpan(ctx, "Validator.DeleteAttestationSigningIntent")
+	defer span.End()
+
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(signingJournalBucket)
+		if err := bucket.Delete(signingJournalKey(publicKey, targetEpoch)); err != nil {
+			return errors.Wrap(err, "failed to delete the signing journal entry")
+		}
+		return nil
+	})
+}
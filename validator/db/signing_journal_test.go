@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAttestationSigningIntents(t *testing.T) {
+	pubkeys := [][48]byte{{1}, {2}}
+	db := SetupDB(t, pubkeys)
+	defer TeardownDB(t, db)
+	ctx := context.Background()
+
+	if err := db.SaveAttestationSigningIntent(ctx, pubkeys[0][:], 3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttestationSigningIntent(ctx, pubkeys[0][:], 4, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttestationSigningIntent(ctx, pubkeys[1][:], 1, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	intents, err := db.AttestationSigningIntents(ctx, pubkeys[0][:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[uint64]uint64{4: 3, 5: 4}; !reflect.DeepEqual(intents, want) {
+		t.Errorf("Wanted intents %v, received %v", want, intents)
+	}
+
+	if err := db.DeleteAttestationSigningIntent(ctx, pubkeys[0][:], 4); err != nil {
+		t.Fatal(err)
+	}
+	intents, err = db.AttestationSigningIntents(ctx, pubkeys[0][:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[uint64]uint64{5: 4}; !reflect.DeepEqual(intents, want) {
+		t.Errorf("Wanted intents %v after completion, received %v", want, intents)
+	}
+}
@@ -0,0 +1,433 @@
+package db
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// Kinds of inconsistencies found in the slashing protection records.
+const (
+	// InvalidPublicKey is a record stored under a key which is not a validator public key,
+	// so it can not protect any validator.
+	InvalidPublicKey = "invalid public key"
+	// CorruptedAttestationHistory is an attestation history which can not be decoded.
+	CorruptedAttestationHistory = "corrupted attestation history"
+	// InvalidTargetEntry is an attestation history entry outside of the weak subjectivity
+	// period the history wraps around.
+	InvalidTargetEntry = "invalid target entry"
+	// LatestEpochBehind is an attestation history whose latest epoch written is behind the
+	// target of one of its attestations.
+	LatestEpochBehind = "latest epoch behind"
+	// CorruptedProposalHistory is a proposal history entry which is not the bitlist of the
+	// slots of an epoch.
+	CorruptedProposalHistory = "corrupted proposal history"
+	// OrphanedSigningIntent is a signing journal entry left behind by a validator client
+	// which stopped between signing an attestation and recording it.
+	OrphanedSigningIntent = "orphaned signing intent"
+)
+
+// Inconsistency is an invalid record found in the slashing protection database.
+type Inconsistency struct {
+	// PublicKey of the validator the record belongs to, if any.
+	PublicKey   []byte
+	Kind        string
+	Description string
+	// Repaired is true if the record was repaired.
+	Repaired bool
+}
+
+func (i *Inconsistency) String() string {
+	return fmt.Sprintf("%s of %#x: %s", i.Kind, i.PublicKey, i.Description)
+}
+
+// VerifySlashingProtection scans the proposal and attestation histories, and the signing
+// journal, for records the validator client could not have written. If repair is set, the
+// inconsistencies are repaired in a way which only ever widens the protection: unreadable
+// records are replaced with records refusing everything they could have covered, and
+// ambiguous records are interpreted as the most restrictive of their meanings.
+func (db *Store) VerifySlashingProtection(ctx context.Context, repair bool) ([]*Inconsistency, error) {
+	ctx, span := trace.StartSpan(ctx, "Validator.VerifySlashingProtection")
+	defer span.End()
+
+	var found []*Inconsistency
+	verify := func(tx *bolt.Tx) error {
+		v := &verifier{
+			tx:        tx,
+			repair:    repair,
+			histories: make(map[string]*slashpb.AttestationHistory),
+			modified:  make(map[string]bool),
+		}
+		if err := v.verifyProposals(); err != nil {
+			return errors.Wrap(err, "could not verify proposal history")
+		}
+		if err := v.verifyAttestations(); err != nil {
+			return errors.Wrap(err, "could not verify attestation history")
+		}
+		if err := v.verifySigningJournal(); err != nil {
+			return errors.Wrap(err, "could not verify signing journal")
+		}
+		if repair {
+			if err := v.saveHistories(); err != nil {
+				return errors.Wrap(err, "could not save repaired attestation history")
+			}
+		}
+		found = v.found
+		return nil
+	}
+	var err error
+	if repair {
+		err = db.update(verify)
+	} else {
+		err = db.view(verify)
+	}
+	return found, err
+}
+
+// verifier holds the state of a single verification pass over the database.
+type verifier struct {
+	tx     *bolt.Tx
+	repair bool
+	found  []*Inconsistency
+	// highWater is the latest epoch found anywhere in the database, used as a stand-in for
+	// the current epoch when a history has to be rebuilt.
+	highWater uint64
+	// histories are the decoded, and repaired, attestation histories keyed by public key.
+	histories map[string]*slashpb.AttestationHistory
+	// corrupted are the public keys of the attestation histories which could not be decoded.
+	corrupted [][]byte
+	modified  map[string]bool
+}
+
+func (v *verifier) report(publicKey []byte, kind string, format string, args ...interface{}) {
+	v.found = append(v.found, &Inconsistency{
+		PublicKey:   copyBytes(publicKey),
+		Kind:        kind,
+		Description: fmt.Sprintf(format, args...),
+		Repaired:    v.repair,
+	})
+}
+
+func (v *verifier) observeEpoch(epoch uint64) {
+	if epoch > v.highWater && epoch != params.BeaconConfig().FarFutureEpoch {
+		v.highWater = epoch
+	}
+}
+
+func (v *verifier) verifyProposals() error {
+	bucket := v.tx.Bucket(historicProposalsBucket)
+	var invalidKeys, invalidBuckets [][]byte
+	err := bucket.ForEach(func(k, val []byte) error {
+		if val != nil {
+			v.report(nil, CorruptedProposalHistory, "entry %#x is not the proposal history of a validator", k)
+			invalidKeys = append(invalidKeys, copyBytes(k))
+			return nil
+		}
+		if len(k) != 48 {
+			v.report(k, InvalidPublicKey, "proposal history stored under a key of length %d", len(k))
+			invalidBuckets = append(invalidBuckets, copyBytes(k))
+			return nil
+		}
+		return v.verifyProposalHistory(k, bucket.Bucket(k))
+	})
+	if err != nil || !v.repair {
+		return err
+	}
+	for _, k := range invalidKeys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	for _, k := range invalidBuckets {
+		if err := bucket.DeleteBucket(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *verifier) verifyProposalHistory(publicKey []byte, valBucket *bolt.Bucket) error {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	var invalidKeys, invalidEpochs [][]byte
+	err := valBucket.ForEach(func(k, val []byte) error {
+		if len(k) != 8 {
+			v.report(publicKey, CorruptedProposalHistory, "entry %#x is not an epoch", k)
+			invalidKeys = append(invalidKeys, copyBytes(k))
+			return nil
+		}
+		epoch := binary.LittleEndian.Uint64(k)
+		v.observeEpoch(epoch)
+		if len(val) == 0 || bitfield.Bitlist(val).Len() != slotsPerEpoch {
+			v.report(publicKey, CorruptedProposalHistory, "proposals of epoch %d are not a bitlist of %d slots", epoch, slotsPerEpoch)
+			invalidEpochs = append(invalidEpochs, copyBytes(k))
+		}
+		return nil
+	})
+	if err != nil || !v.repair {
+		return err
+	}
+	for _, k := range invalidKeys {
+		if err := deleteKeyOrBucket(valBucket, k); err != nil {
+			return err
+		}
+	}
+	// The slots proposed in a corrupted epoch are unknown, so all of them are marked as proposed.
+	for _, k := range invalidEpochs {
+		if err := deleteKeyOrBucket(valBucket, k); err != nil {
+			return err
+		}
+		proposed := bitfield.NewBitlist(slotsPerEpoch)
+		for i := uint64(0); i < slotsPerEpoch; i++ {
+			proposed.SetBitAt(i, true)
+		}
+		if err := valBucket.Put(k, proposed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *verifier) verifyAttestations() error {
+	bucket := v.tx.Bucket(historicAttestationsBucket)
+	var invalidKeys [][]byte
+	err := bucket.ForEach(func(k, enc []byte) error {
+		if len(k) != 48 {
+			v.report(k, InvalidPublicKey, "attestation history stored under a key of length %d", len(k))
+			invalidKeys = append(invalidKeys, copyBytes(k))
+			return nil
+		}
+		history, err := unmarshalAttestationHistory(enc)
+		if err != nil {
+			v.report(k, CorruptedAttestationHistory, "could not decode attestation history: %v", err)
+			v.corrupted = append(v.corrupted, copyBytes(k))
+			return nil
+		}
+		if history.TargetToSource == nil {
+			history.TargetToSource = make(map[uint64]uint64)
+		}
+		v.verifyAttestationHistory(k, history)
+		v.histories[string(k)] = history
+		v.observeEpoch(history.LatestEpochWritten)
+		return nil
+	})
+	if err != nil || !v.repair {
+		return err
+	}
+	for _, k := range invalidKeys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyAttestationHistory checks the entries of the history are within the weak subjectivity
+// period, and that the latest epoch written is at least the target of every recorded attestation.
+// The history is repaired in place.
+func (v *verifier) verifyAttestationHistory(publicKey []byte, history *slashpb.AttestationHistory) {
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	farFuture := params.BeaconConfig().FarFutureEpoch
+
+	for _, key := range sortedTargets(history) {
+		if key < wsPeriod {
+			continue
+		}
+		source := history.TargetToSource[key]
+		v.report(publicKey, InvalidTargetEntry, "target entry %d is outside of the weak subjectivity period of %d epochs", key, wsPeriod)
+		delete(history.TargetToSource, key)
+		v.modified[string(publicKey)] = true
+		if source == farFuture {
+			continue
+		}
+		// Keep the lowest of the sources, which surrounds more attestations.
+		if existing, ok := history.TargetToSource[key%wsPeriod]; !ok || existing == farFuture || source < existing {
+			history.TargetToSource[key%wsPeriod] = source
+		}
+	}
+
+	latest := history.LatestEpochWritten
+	for _, key := range sortedTargets(history) {
+		source := history.TargetToSource[key]
+		if source == farFuture {
+			continue
+		}
+		// The target of the attestation is the first epoch of its entry not before its source.
+		target := source + (key+wsPeriod-source%wsPeriod)%wsPeriod
+		if target < source {
+			target = farFuture - 1
+		}
+		if target <= history.LatestEpochWritten {
+			continue
+		}
+		v.report(
+			publicKey,
+			LatestEpochBehind,
+			"latest epoch written %d is behind the target epoch %d of the attestation with source %d",
+			history.LatestEpochWritten,
+			target,
+			source,
+		)
+		if target > latest {
+			latest = target
+		}
+	}
+	if latest > history.LatestEpochWritten {
+		history.LatestEpochWritten = latest
+		v.modified[string(publicKey)] = true
+	}
+}
+
+func (v *verifier) verifySigningJournal() error {
+	bucket := v.tx.Bucket(signingJournalBucket)
+	type intent struct {
+		key, publicKey []byte
+		source, target uint64
+	}
+	var intents []*intent
+	var invalidKeys [][]byte
+	err := bucket.ForEach(func(k, val []byte) error {
+		if len(k) != 48+8 {
+			v.report(nil, OrphanedSigningIntent, "signing journal entry %#x is not the intent of a validator", k)
+			invalidKeys = append(invalidKeys, copyBytes(k))
+			return nil
+		}
+		i := &intent{key: copyBytes(k), publicKey: copyBytes(k[:48]), target: binary.BigEndian.Uint64(k[48:])}
+		v.observeEpoch(i.target)
+		if len(val) == 8 {
+			i.source = binary.BigEndian.Uint64(val)
+			v.report(i.publicKey, OrphanedSigningIntent, "attestation with source %d and target %d may have been signed", i.source, i.target)
+		} else {
+			// The source is unknown, so the most restrictive one is assumed.
+			i.source = i.target
+			v.report(i.publicKey, OrphanedSigningIntent, "attestation with target %d and an unreadable source may have been signed", i.target)
+		}
+		intents = append(intents, i)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Corrupted histories are rebuilt once the latest epoch found in the database is known.
+	for _, publicKey := range v.corrupted {
+		v.histories[string(publicKey)] = protectiveHistory(v.highWater)
+		v.modified[string(publicKey)] = true
+	}
+	if !v.repair {
+		return nil
+	}
+	for _, k := range invalidKeys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	// Intents are recovered the way the validator client does, assuming the attestation was signed.
+	for _, i := range intents {
+		history, ok := v.histories[string(i.publicKey)]
+		if !ok {
+			history = &slashpb.AttestationHistory{
+				TargetToSource: map[uint64]uint64{0: params.BeaconConfig().FarFutureEpoch},
+			}
+			v.histories[string(i.publicKey)] = history
+		}
+		markTarget(history, i.source, i.target)
+		v.modified[string(i.publicKey)] = true
+		if err := bucket.Delete(i.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *verifier) saveHistories() error {
+	publicKeys := make([]string, 0, len(v.modified))
+	for publicKey := range v.modified {
+		publicKeys = append(publicKeys, publicKey)
+	}
+	sort.Strings(publicKeys)
+	bucket := v.tx.Bucket(historicAttestationsBucket)
+	for _, publicKey := range publicKeys {
+		enc, err := proto.Marshal(v.histories[publicKey])
+		if err != nil {
+			return errors.Wrap(err, "failed to encode attestation history")
+		}
+		if err := bucket.Put([]byte(publicKey), enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protectiveHistory returns an attestation history marking every target of the weak subjectivity
+// period up to the epoch as attested with a source equal to its target. It refuses any attestation
+// which could conflict with an attestation up to the epoch, so it can replace a history whose
+// content is unknown.
+func protectiveHistory(epoch uint64) *slashpb.AttestationHistory {
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	history := &slashpb.AttestationHistory{
+		TargetToSource:     make(map[uint64]uint64),
+		LatestEpochWritten: epoch,
+	}
+	start := uint64(0)
+	if epoch >= wsPeriod {
+		start = epoch - wsPeriod + 1
+	}
+	for target := start; target <= epoch; target++ {
+		history.TargetToSource[target%wsPeriod] = target
+	}
+	return history
+}
+
+// markTarget records an attestation in the history the way the validator client does, except
+// that the lowest source is kept if the target was already recorded.
+func markTarget(history *slashpb.AttestationHistory, source uint64, target uint64) {
+	wsPeriod := params.BeaconConfig().WeakSubjectivityPeriod
+	farFuture := params.BeaconConfig().FarFutureEpoch
+
+	if target > history.LatestEpochWritten {
+		for i := history.LatestEpochWritten + 1; i < target && i <= history.LatestEpochWritten+wsPeriod; i++ {
+			history.TargetToSource[i%wsPeriod] = farFuture
+		}
+		history.LatestEpochWritten = target
+	} else if target+wsPeriod <= history.LatestEpochWritten {
+		// Older than the weak subjectivity period, the history does not protect the target anymore.
+		return
+	} else if existing, ok := history.TargetToSource[target%wsPeriod]; ok && existing != farFuture && existing <= source {
+		return
+	}
+	history.TargetToSource[target%wsPeriod] = source
+}
+
+func sortedTargets(history *slashpb.AttestationHistory) []uint64 {
+	targets := make([]uint64, 0, len(history.TargetToSource))
+	for target := range history.TargetToSource {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+	return targets
+}
+
+func deleteKeyOrBucket(bucket *bolt.Bucket, k []byte) error {
+	if bucket.Bucket(k) != nil {
+		return bucket.DeleteBucket(k)
+	}
+	return bucket.Delete(k)
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	cpy := make([]byte, len(b))
+	copy(cpy, b)
+	return cpy
+}
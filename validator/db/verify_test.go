@@ -0,0 +1,143 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestVerifySlashingProtection_Consistent(t *testing.T) {
+	pubkeys := [][48]byte{{1}}
+	db := SetupDB(t, pubkeys)
+	defer TeardownDB(t, db)
+	ctx := context.Background()
+
+	history := &slashpb.AttestationHistory{
+		TargetToSource:     map[uint64]uint64{0: 0, 1: 0, 2: 1},
+		LatestEpochWritten: 2,
+	}
+	if err := db.SaveAttestationHistory(ctx, pubkeys[0][:], history); err != nil {
+		t.Fatal(err)
+	}
+	proposed := bitfield.NewBitlist(params.BeaconConfig().SlotsPerEpoch)
+	proposed.SetBitAt(3, true)
+	if err := db.SaveProposalHistoryForEpoch(ctx, pubkeys[0][:], 1, proposed); err != nil {
+		t.Fatal(err)
+	}
+	found, err := db.VerifySlashingProtection(ctx, false /* repair */)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 0 {
+		t.Errorf("Expected no inconsistency, received %v", found)
+	}
+}
+
+func TestVerifySlashingProtection_Repair(t *testing.T) {
+	pubkeys := [][48]byte{{1}, {2}, {3}}
+	db := SetupDB(t, pubkeys)
+	defer TeardownDB(t, db)
+	ctx := context.Background()
+
+	// The latest epoch written is behind the attestation of target 10.
+	behind := &slashpb.AttestationHistory{
+		TargetToSource:     map[uint64]uint64{5: 4, 10: 9},
+		LatestEpochWritten: 5,
+	}
+	if err := db.SaveAttestationHistory(ctx, pubkeys[0][:], behind); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttestationSigningIntent(ctx, pubkeys[1][:], 3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(historicAttestationsBucket).Put(pubkeys[2][:], []byte{0xff, 0xff})
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveProposalHistoryForEpoch(ctx, pubkeys[2][:], 2, bitfield.NewBitlist(8)); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := db.VerifySlashingProtection(ctx, false /* repair */)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := make(map[string]int)
+	for _, inconsistency := range found {
+		if inconsistency.Repaired {
+			t.Errorf("Expected %v not to be repaired", inconsistency)
+		}
+		kinds[inconsistency.Kind]++
+	}
+	for _, kind := range []string{LatestEpochBehind, OrphanedSigningIntent, CorruptedAttestationHistory, CorruptedProposalHistory} {
+		if kinds[kind] != 1 {
+			t.Errorf("Expected one %s, received %d in %v", kind, kinds[kind], found)
+		}
+	}
+	history, err := db.AttestationHistory(ctx, pubkeys[0][:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.LatestEpochWritten != 5 {
+		t.Error("Expected attestation history not to be modified without repair")
+	}
+
+	if _, err := db.VerifySlashingProtection(ctx, true /* repair */); err != nil {
+		t.Fatal(err)
+	}
+	found, err = db.VerifySlashingProtection(ctx, false /* repair */)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 0 {
+		t.Errorf("Expected no inconsistency after repair, received %v", found)
+	}
+
+	history, err = db.AttestationHistory(ctx, pubkeys[0][:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.LatestEpochWritten != 10 || history.TargetToSource[10] != 9 || history.TargetToSource[5] != 4 {
+		t.Errorf("Expected latest epoch written to be raised to 10, received %v", history)
+	}
+	history, err = db.AttestationHistory(ctx, pubkeys[1][:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.LatestEpochWritten != 4 || history.TargetToSource[4] != 3 {
+		t.Errorf("Expected signing intent to be recovered, received %v", history)
+	}
+	intents, err := db.AttestationSigningIntents(ctx, pubkeys[1][:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(intents) != 0 {
+		t.Errorf("Expected recovered intents to be deleted, received %v", intents)
+	}
+	// The corrupted history is replaced with one refusing every target up to the latest epoch
+	// found in the database.
+	history, err = db.AttestationHistory(ctx, pubkeys[2][:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history.LatestEpochWritten != 10 {
+		t.Errorf("Expected rebuilt history to be written up to epoch 10, received %d", history.LatestEpochWritten)
+	}
+	for target := uint64(0); target <= 10; target++ {
+		if history.TargetToSource[target] != target {
+			t.Errorf("Expected target %d to be marked with source %d, received %d", target, target, history.TargetToSource[target])
+		}
+	}
+	proposals, err := db.ProposalHistoryForEpoch(ctx, pubkeys[2][:], 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proposals.Count() != params.BeaconConfig().SlotsPerEpoch {
+		t.Errorf("Expected every slot of the corrupted epoch to be marked as proposed, received %d", proposals.Count())
+	}
+}
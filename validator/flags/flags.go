@@ -62,6 +62,14 @@ var (
 		Usage: "The options for the keymanger, either a JSON string or path to same",
 		Value: "",
 	}
+	// KeyManagers specifies several key managers to load validating keys from simultaneously.
+	KeyManagers = &cli.StringFlag{
+		Name: "keymanagers",
+		Usage: "A JSON list of key managers to load validating keys from simultaneously, or path to same. " +
+			"Each entry has a name, a keymanager and its opts, either as a JSON object or path to same. " +
+			`Example: --keymanagers='[{"name":"hd","keymanager":"wallet","opts":"/path/to/opts.json"}]'`,
+		Value: "",
+	}
 	// KeystorePathFlag defines the location of the keystore directory for a validator's account.
 	KeystorePathFlag = &cli.StringFlag{
 		Name:  "keystore-path",
@@ -73,6 +81,12 @@ var (
 		Usage: "Port used to listening and respond metrics for prometheus.",
 		Value: 8081,
 	}
+	// EnableDutyTriggerFlag enables the debug endpoint triggering validator duties manually.
+	EnableDutyTriggerFlag = &cli.BoolFlag{
+		Name: "enable-duty-trigger",
+		Usage: "Serves /debug/duties on the monitoring port to perform an attestation or proposal for a key and slot right away, " +
+			"e.g. curl -X POST 'localhost:8081/debug/duties?duty=attest&pubkey=0x...&slot=100'. Only meant for test networks and diagnosis",
+	}
 	// NoCustomConfigFlag determines whether to launch a beacon chain using real parameters or demo parameters.
 	NoCustomConfigFlag = &cli.BoolFlag{
 		Name:  "no-custom-config",
@@ -83,6 +97,72 @@ var (
 		Name:  "password",
 		Usage: "String value of the password for your validator private keys",
 	}
+	// PasswordFileFlag defines a file from which the password for the validator private keys is read.
+	PasswordFileFlag = &cli.StringFlag{
+		Name:  "password-file",
+		Usage: "Path to a file containing the password for your validator private keys",
+	}
+	// AccountPublicKeysFlag defines the validator accounts the accounts commands operate on.
+	AccountPublicKeysFlag = &cli.StringSliceFlag{
+		Name:  "public-keys",
+		Usage: "Comma separated list of hex encoded public keys of the validator accounts to operate on",
+	}
+	// SlashingProtectionFileFlag defines the file deleted accounts' slashing protection records are written to.
+	SlashingProtectionFileFlag = &cli.StringFlag{
+		Name:  "slashing-protection-file",
+		Usage: "Path to write the slashing protection records of the deleted accounts to, in the EIP-3076 interchange format",
+		Value: "slashing_protection.json",
+	}
+	// NumAccountsFlag defines the number of validator accounts created by the accounts create command.
+	NumAccountsFlag = &cli.IntFlag{
+		Name:  "num-accounts",
+		Usage: "Number of validator accounts to create at once",
+		Value: 1,
+	}
+	// DepositManifestFlag defines the file the public keys and deposit data of accounts created in a batch are written to.
+	DepositManifestFlag = &cli.StringFlag{
+		Name:  "deposit-manifest",
+		Usage: "Path to write the public keys and deposit data of the accounts created with --num-accounts to",
+		Value: "deposit_manifest.json",
+	}
+	// DutiesFormatFlag defines the format the duties command exports the duty schedule in.
+	DutiesFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "Format to export the duty schedule in, either json or ics for calendar applications",
+		Value: "json",
+	}
+	// DutiesOutputFlag defines the file the duties command writes the duty schedule to.
+	DutiesOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "Path to write the duty schedule to, instead of the standard output",
+	}
+	// DutiesEpochFlag defines the epoch of the duty schedule exported by the duties command.
+	DutiesEpochFlag = &cli.Uint64Flag{
+		Name:  "epoch",
+		Usage: "Epoch of the exported duty schedule. Defaults to the upcoming epoch",
+	}
+	// RepairFlag makes the db verify command repair the inconsistencies it finds.
+	RepairFlag = &cli.BoolFlag{
+		Name:  "repair",
+		Usage: "Repairs the inconsistencies found in the slashing protection records, only ever by widening the protection",
+	}
+	// DryRunFlag enables the dry run mode of the validator client.
+	DryRunFlag = &cli.BoolFlag{
+		Name: "dry-run",
+		Usage: "Performs the validator duties, including slashing protection checks, but signs with throwaway keys and does not send " +
+			"blocks, attestations, aggregates or exits to the beacon node. Meant to rehearse new configurations and key managers safely",
+	}
+	// DryRunOutputFlag defines the file the messages not sent in a dry run are recorded to.
+	DryRunOutputFlag = &cli.StringFlag{
+		Name:  "dry-run-output",
+		Usage: "Path to record the messages not sent to the beacon node in a dry run to, as JSON lines",
+	}
+	// EstimateRewardsFlag enables the estimation of the rewards of the validating keys.
+	EstimateRewardsFlag = &cli.BoolFlag{
+		Name: "estimate-rewards",
+		Usage: "Estimates the reward of every validating key for each epoch from the base reward, the participation and the duties, " +
+			"and compares it with the balance change of the epoch transition. Served on the /performance endpoint of the monitoring server",
+	}
 	// UnencryptedKeysFlag specifies a file path of a JSON file of unencrypted validator keys as an
 	// alternative from launching the validator client from decrypting a keystore directory.
 	UnencryptedKeysFlag = &cli.StringFlag{
@@ -2,13 +2,12 @@ package keymanager
 
 import (
 	"encoding/json"
-	"os"
 	"strings"
 
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/promptutil"
 	"github.com/prysmaticlabs/prysm/validator/accounts"
-	"golang.org/x/crypto/ssh/terminal"
 )
 
 // Keystore is a key manager that loads keys from a standard keystore.
@@ -59,13 +58,11 @@ func NewKeystore(input string) (KeyManager, string, error) {
 		}
 	} else {
 		if opts.Passphrase == "" {
-			log.Info("Enter your validator account password:")
-			bytePassword, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+			passphrase, err := promptutil.Password("Enter your validator account password", accounts.PasswordEnvVar, "", false /* confirm */)
 			if err != nil {
 				return nil, keystoreOptsHelp, err
 			}
-			text := string(bytePassword)
-			opts.Passphrase = strings.Replace(text, "\n", "", -1)
+			opts.Passphrase = passphrase
 		}
 
 		if err := accounts.VerifyAccountNotExists(opts.Path, opts.Passphrase); err == nil {
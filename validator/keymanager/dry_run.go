@@ -0,0 +1,52 @@
+package keymanager
+
+import (
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+// DryRun is a key manager which validates with the keys of another key manager, but signs
+// with throwaway secret keys. None of its signatures are valid for the validating keys, so
+// they can never be used against the validators.
+type DryRun struct {
+	keyManager KeyManager
+	lock       sync.Mutex
+	// Key to the map is the bytes of the public key.
+	throwawayKeys map[[48]byte]*bls.SecretKey
+}
+
+// NewDryRun creates a new dry run key manager validating with the keys of the key manager.
+func NewDryRun(keyManager KeyManager) *DryRun {
+	return &DryRun{
+		keyManager:    keyManager,
+		throwawayKeys: make(map[[48]byte]*bls.SecretKey),
+	}
+}
+
+// FetchValidatingKeys fetches the validating keys of the underlying key manager.
+func (km *DryRun) FetchValidatingKeys() ([][48]byte, error) {
+	keys, err := km.keyManager.FetchValidatingKeys()
+	if err != nil {
+		return nil, err
+	}
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	for _, key := range keys {
+		if _, ok := km.throwawayKeys[key]; !ok {
+			km.throwawayKeys[key] = bls.RandKey()
+		}
+	}
+	return keys, nil
+}
+
+// Sign signs a message with the throwaway secret key of the validating key.
+func (km *DryRun) Sign(pubKey [48]byte, root [32]byte) (*bls.Signature, error) {
+	km.lock.Lock()
+	secretKey, exists := km.throwawayKeys[pubKey]
+	km.lock.Unlock()
+	if !exists {
+		return nil, ErrNoSuchKey
+	}
+	return secretKey.Sign(root[:]), nil
+}
@@ -0,0 +1,41 @@
+package keymanager_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+func TestDryRun_SignsWithThrowawayKeys(t *testing.T) {
+	sk := bls.RandKey()
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	km := keymanager.NewDryRun(keymanager.NewDirect([]*bls.SecretKey{sk}))
+
+	root := [32]byte{'A'}
+	if _, err := km.Sign(pubKey, root); err != keymanager.ErrNoSuchKey {
+		t.Errorf("Expected key unknown until validating keys are fetched, received %v", err)
+	}
+	keys, err := km.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0] != pubKey {
+		t.Fatalf("Expected validating keys of the underlying key manager, received %#x", keys)
+	}
+	sig, err := km.Sign(pubKey, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sig.Verify(root[:], sk.PublicKey()) {
+		t.Error("Expected signature not to be valid for the validating key")
+	}
+	again, err := km.Sign(pubKey, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again.Marshal()) != string(sig.Marshal()) {
+		t.Error("Expected the same throwaway key to be used for every signature")
+	}
+}
@@ -0,0 +1,201 @@
+package keymanager
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	sourceKeysGaugeVec = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "keymanager_keys",
+			Help:      "The number of validating keys loaded from a key manager source.",
+		},
+		[]string{"source"},
+	)
+	sourceSignaturesCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "keymanager_signatures_total",
+			Help:      "The number of signatures made by a key manager source.",
+		},
+		[]string{"source"},
+	)
+	sourceSignFailuresCounterVec = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "keymanager_sign_failures_total",
+			Help:      "The number of failed signing attempts of a key manager source.",
+		},
+		[]string{"source"},
+	)
+)
+
+// Source is a named key manager whose keys are merged into a multi key manager.
+type Source struct {
+	Name       string
+	KeyManager KeyManager
+}
+
+// Multi is a key manager that merges the keys of several key managers, such as a wallet,
+// imported keystores and a remote signer, routing each signing request to the key manager
+// holding the key. When a key is held by more than one key manager, the first one listed
+// signs with it.
+type Multi struct {
+	sources []*Source
+	lock    sync.RWMutex
+	// Key to the map is the bytes of the public key.
+	owners map[[48]byte]*Source
+}
+
+// NewMulti creates a key manager merging the keys of the given sources.
+func NewMulti(sources []*Source) (*Multi, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("at least one key manager is required")
+	}
+	names := make(map[string]bool)
+	for _, s := range sources {
+		if names[s.Name] {
+			return nil, fmt.Errorf("duplicate key manager name %q", s.Name)
+		}
+		names[s.Name] = true
+	}
+	km := &Multi{
+		sources: sources,
+		owners:  make(map[[48]byte]*Source),
+	}
+	if _, err := km.FetchValidatingKeys(); err != nil {
+		return nil, err
+	}
+	return km, nil
+}
+
+// FetchValidatingKeys fetches the keys of every source. A source failing to return its keys
+// does not prevent the keys of the other sources from being used, unless every source fails.
+func (km *Multi) FetchValidatingKeys() ([][48]byte, error) {
+	owners := make(map[[48]byte]*Source)
+	keys := make([][48]byte, 0)
+	var failed int
+	for _, s := range km.sources {
+		sourceKeys, err := s.KeyManager.FetchValidatingKeys()
+		if err != nil {
+			failed++
+			log.WithError(err).WithField("source", s.Name).Error("Could not fetch validating keys")
+			continue
+		}
+		sourceKeysGaugeVec.WithLabelValues(s.Name).Set(float64(len(sourceKeys)))
+		for _, key := range sourceKeys {
+			if owner, ok := owners[key]; ok {
+				log.WithFields(logrus.Fields{
+					"pubKey":   fmt.Sprintf("%#x", key),
+					"source":   s.Name,
+					"signedBy": owner.Name,
+				}).Warn("Validating key is held by more than one key manager")
+				continue
+			}
+			owners[key] = s
+			keys = append(keys, key)
+		}
+	}
+	if failed == len(km.sources) {
+		return nil, errors.New("could not fetch validating keys from any key manager")
+	}
+
+	km.lock.Lock()
+	km.owners = owners
+	km.lock.Unlock()
+	return keys, nil
+}
+
+// Sign signs a message with the key manager holding the key.
+func (km *Multi) Sign(pubKey [48]byte, root [32]byte) (*bls.Signature, error) {
+	s, err := km.owner(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return km.record(s)(s.KeyManager.Sign(pubKey, root))
+}
+
+// SignGeneric signs a generic root with the key manager holding the key. The signing root
+// is computed for key managers which do not protect their signatures.
+func (km *Multi) SignGeneric(pubKey [48]byte, root [32]byte, domain [32]byte) (*bls.Signature, error) {
+	s, err := km.owner(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if protecting, ok := s.KeyManager.(ProtectingKeyManager); ok {
+		return km.record(s)(protecting.SignGeneric(pubKey, root, domain))
+	}
+	// The object root is already known, so the signing root is the root of the container
+	// of the object root and the domain.
+	signingRoot, err := ssz.HashTreeRoot(&pb.SigningRoot{ObjectRoot: root[:], Domain: domain[:]})
+	if err != nil {
+		return nil, err
+	}
+	return km.record(s)(s.KeyManager.Sign(pubKey, signingRoot))
+}
+
+// SignProposal signs a block proposal with the key manager holding the key.
+func (km *Multi) SignProposal(pubKey [48]byte, domain [32]byte, data *ethpb.BeaconBlockHeader) (*bls.Signature, error) {
+	s, err := km.owner(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if protecting, ok := s.KeyManager.(ProtectingKeyManager); ok {
+		return km.record(s)(protecting.SignProposal(pubKey, domain, data))
+	}
+	signingRoot, err := helpers.ComputeSigningRoot(data, domain[:])
+	if err != nil {
+		return nil, err
+	}
+	return km.record(s)(s.KeyManager.Sign(pubKey, signingRoot))
+}
+
+// SignAttestation signs an attestation with the key manager holding the key.
+func (km *Multi) SignAttestation(pubKey [48]byte, domain [32]byte, data *ethpb.AttestationData) (*bls.Signature, error) {
+	s, err := km.owner(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	if protecting, ok := s.KeyManager.(ProtectingKeyManager); ok {
+		return km.record(s)(protecting.SignAttestation(pubKey, domain, data))
+	}
+	signingRoot, err := helpers.ComputeSigningRoot(data, domain[:])
+	if err != nil {
+		return nil, err
+	}
+	return km.record(s)(s.KeyManager.Sign(pubKey, signingRoot))
+}
+
+func (km *Multi) owner(pubKey [48]byte) (*Source, error) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	s, ok := km.owners[pubKey]
+	if !ok {
+		return nil, ErrNoSuchKey
+	}
+	return s, nil
+}
+
+// record returns a function counting the outcome of a signing attempt of the source.
+func (km *Multi) record(s *Source) func(*bls.Signature, error) (*bls.Signature, error) {
+	return func(sig *bls.Signature, err error) (*bls.Signature, error) {
+		if err != nil {
+			sourceSignFailuresCounterVec.WithLabelValues(s.Name).Inc()
+			return nil, err
+		}
+		sourceSignaturesCounterVec.WithLabelValues(s.Name).Inc()
+		return sig, nil
+	}
+}
@@ -0,0 +1,71 @@
+package keymanager_test
+
+import (
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/validator/keymanager"
+)
+
+func TestMulti_MergesKeys(t *testing.T) {
+	shared := bls.RandKey()
+	first := keymanager.NewDirect([]*bls.SecretKey{bls.RandKey(), shared})
+	second := keymanager.NewDirect([]*bls.SecretKey{bls.RandKey(), shared})
+	km, err := keymanager.NewMulti([]*keymanager.Source{
+		{Name: "first", KeyManager: first},
+		{Name: "second", KeyManager: second},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := km.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 3 {
+		t.Errorf("Incorrect number of keys returned; expected 3, received %d", len(keys))
+	}
+}
+
+func TestMulti_DuplicateName(t *testing.T) {
+	direct := keymanager.NewDirect(nil)
+	if _, err := keymanager.NewMulti([]*keymanager.Source{
+		{Name: "direct", KeyManager: direct},
+		{Name: "direct", KeyManager: direct},
+	}); err == nil {
+		t.Error("Expected error for duplicate key manager names")
+	}
+}
+
+func TestMulti_RoutesSigning(t *testing.T) {
+	sk := bls.RandKey()
+	pubKey := bytesutil.ToBytes48(sk.PublicKey().Marshal())
+	km, err := keymanager.NewMulti([]*keymanager.Source{
+		{Name: "empty", KeyManager: keymanager.NewDirect(nil)},
+		{Name: "direct", KeyManager: keymanager.NewDirect([]*bls.SecretKey{sk})},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domain := bytesutil.ToBytes32([]byte("domain"))
+	data := &ethpb.AttestationData{Slot: 5, CommitteeIndex: 2}
+	sig, err := km.SignAttestation(pubKey, domain, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := helpers.ComputeSigningRoot(data, domain[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sig.Verify(root[:], sk.PublicKey()) {
+		t.Error("Expected attestation signature over the signing root")
+	}
+
+	if _, err := km.Sign(bytesutil.ToBytes48([]byte("unknown")), root); err != keymanager.ErrNoSuchKey {
+		t.Errorf("Expected %v for an unknown key, received %v", keymanager.ErrNoSuchKey, err)
+	}
+}
@@ -4,10 +4,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
-	"runtime"
 	runtimeDebug "runtime/debug"
+	"strconv"
+	"strings"
 
 	joonix "github.com/joonix/log"
 	"github.com/prysmaticlabs/prysm/shared/cmd"
@@ -15,13 +21,14 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/promptutil"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"github.com/prysmaticlabs/prysm/validator/accounts"
+	"github.com/prysmaticlabs/prysm/validator/db"
 	"github.com/prysmaticlabs/prysm/validator/flags"
 	"github.com/prysmaticlabs/prysm/validator/node"
 	"github.com/sirupsen/logrus"
 	prefixed "github.com/x-cray/logrus-prefixed-formatter"
-	_ "go.uber.org/automaxprocs"
 	"gopkg.in/urfave/cli.v2"
 	"gopkg.in/urfave/cli.v2/altsrc"
 )
@@ -37,6 +44,96 @@ func startNode(ctx *cli.Context) error {
 	return nil
 }
 
+func setAccountsDisabled(ctx *cli.Context, disabled bool) error {
+	pubKeys, err := accounts.ParsePublicKeys(ctx.StringSlice(flags.AccountPublicKeysFlag.Name))
+	if err != nil || len(pubKeys) == 0 {
+		log.WithError(err).Fatalf("%s is required", flags.AccountPublicKeysFlag.Name)
+	}
+	if err := accounts.SetAccountsDisabled(context.Background(), ctx.String(cmd.DataDirFlag.Name), pubKeys, disabled); err != nil {
+		log.WithError(err).Fatal("Could not update validator accounts")
+	}
+	return nil
+}
+
+// exportDuties requests the duty schedule from the running validator client, through the duties
+// endpoint on its monitoring port, and writes it to the output file or the standard output.
+func exportDuties(ctx *cli.Context) error {
+	host := ctx.String(cmd.MonitoringHostFlag.Name)
+	if host == "" || host == "0.0.0.0" {
+		host = "127.0.0.1"
+	}
+	query := url.Values{}
+	query.Set("format", ctx.String(flags.DutiesFormatFlag.Name))
+	if ctx.IsSet(flags.DutiesEpochFlag.Name) {
+		query.Set("epoch", strconv.FormatUint(ctx.Uint64(flags.DutiesEpochFlag.Name), 10))
+	}
+	endpoint := fmt.Sprintf("http://%s/duties?%s", net.JoinHostPort(host, strconv.FormatInt(ctx.Int64(flags.MonitoringPortFlag.Name), 10)), query.Encode())
+
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		log.WithError(err).Fatal("Could not reach the validator client, is it running with the same --monitoring-host and --monitoring-port?")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.WithError(err).Fatal("Could not read duty schedule")
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Fatalf("Could not export duty schedule: %s", strings.TrimSpace(string(body)))
+	}
+
+	output := ctx.String(flags.DutiesOutputFlag.Name)
+	if output == "" {
+		_, err = os.Stdout.Write(body)
+		return err
+	}
+	if err := ioutil.WriteFile(output, body, 0600); err != nil {
+		log.WithError(err).Fatalf("Could not write duty schedule to %s", output)
+	}
+	log.WithField("path", output).Info("Exported duty schedule")
+	return nil
+}
+
+// verifyDB checks the slashing protection records of the validator database for inconsistencies,
+// and repairs them if requested.
+func verifyDB(ctx *cli.Context) error {
+	valDB, err := db.NewKVStore(ctx.String(cmd.DataDirFlag.Name), nil)
+	if err != nil {
+		log.WithError(err).Fatal("Could not open validator db")
+	}
+	defer func() {
+		if err := valDB.Close(); err != nil {
+			log.WithError(err).Error("Could not close validator db")
+		}
+	}()
+
+	repair := ctx.Bool(flags.RepairFlag.Name)
+	found, err := valDB.VerifySlashingProtection(context.Background(), repair)
+	if err != nil {
+		log.WithError(err).Fatal("Could not verify slashing protection records")
+	}
+	for _, inconsistency := range found {
+		log.WithFields(logrus.Fields{
+			"publicKey": fmt.Sprintf("%#x", inconsistency.PublicKey),
+			"kind":      inconsistency.Kind,
+			"repaired":  inconsistency.Repaired,
+		}).Warn(inconsistency.Description)
+	}
+	if len(found) == 0 {
+		log.Info("No inconsistency found in the slashing protection records")
+		return nil
+	}
+	if !repair {
+		log.Fatalf("Found %d inconsistencies in the slashing protection records, run with --%s to repair them", len(found), flags.RepairFlag.Name)
+	}
+	log.Infof("Repaired %d inconsistencies in the slashing protection records", len(found))
+	return nil
+}
+
 var appFlags = []cli.Flag{
 	flags.BeaconRPCProviderFlag,
 	flags.CertFlag,
@@ -52,16 +149,29 @@ var appFlags = []cli.Flag{
 	flags.GrpcHeadersFlag,
 	flags.KeyManager,
 	flags.KeyManagerOpts,
+	flags.KeyManagers,
 	flags.AccountMetricsFlag,
+	flags.DryRunFlag,
+	flags.DryRunOutputFlag,
+	flags.EstimateRewardsFlag,
 	cmd.VerbosityFlag,
+	cmd.LogLevelOverrideFlag,
 	cmd.DataDirFlag,
 	cmd.ClearDB,
 	cmd.ForceClearDB,
 	cmd.EnableTracingFlag,
 	cmd.TracingProcessNameFlag,
 	cmd.TracingEndpointFlag,
+	cmd.TracingExporterFlag,
+	cmd.TracingTagsFlag,
 	cmd.TraceSampleFractionFlag,
 	flags.MonitoringPortFlag,
+	flags.EnableDutyTriggerFlag,
+	cmd.MonitoringHostFlag,
+	cmd.MonitoringCorsDomainFlag,
+	cmd.HTTPTLSCertFlag,
+	cmd.HTTPTLSKeyFlag,
+	cmd.HTTPDisabledEndpointsFlag,
 	cmd.LogFormat,
 	debug.PProfFlag,
 	debug.PProfAddrFlag,
@@ -69,12 +179,17 @@ var appFlags = []cli.Flag{
 	debug.MemProfileRateFlag,
 	debug.CPUProfileFlag,
 	debug.TraceFlag,
+	debug.GCPercentFlag,
+	debug.GCBallastFlag,
 	cmd.LogFileName,
 	cmd.ConfigFileFlag,
+	cmd.ChainConfigFileFlag,
+	cmd.NetworkFlag,
 }
 
 func init() {
-	appFlags = cmd.WrapFlags(append(appFlags, featureconfig.ValidatorFlags...))
+	appFlags = append(appFlags, featureconfig.ValidatorFlags...)
+	appFlags = cmd.WrapFlags(append(appFlags, cmd.AliasFlags(appFlags)...))
 }
 
 func main() {
@@ -94,10 +209,14 @@ func main() {
 					Name: "create",
 					Description: `creates a new validator account keystore containing private keys for Ethereum Serenity -
 this command outputs a deposit data string which can be used to deposit Ether into the ETH1.0 deposit
-contract in order to activate the validator client`,
+contract in order to activate the validator client. With --num-accounts, several accounts are created at
+once and their deposit data is written to the --deposit-manifest file instead`,
 					Flags: []cli.Flag{
 						flags.KeystorePathFlag,
 						flags.PasswordFlag,
+						flags.PasswordFileFlag,
+						flags.NumAccountsFlag,
+						flags.DepositManifestFlag,
 					},
 					Action: func(ctx *cli.Context) error {
 						featureconfig.ConfigureValidator(ctx)
@@ -106,7 +225,26 @@ contract in order to activate the validator client`,
 							params.UseMinimalConfig()
 						}
 
-						if keystoreDir, _, err := accounts.CreateValidatorAccount(ctx.String(flags.KeystorePathFlag.Name), ctx.String(flags.PasswordFlag.Name)); err != nil {
+						password := ctx.String(flags.PasswordFlag.Name)
+						if password == "" && ctx.IsSet(flags.PasswordFileFlag.Name) {
+							var err error
+							password, err = promptutil.PasswordFromFile(ctx.String(flags.PasswordFileFlag.Name))
+							if err != nil {
+								log.WithError(err).Fatal("Could not read password file")
+							}
+						}
+						if numAccounts := ctx.Int(flags.NumAccountsFlag.Name); numAccounts > 1 {
+							if keystoreDir, _, err := accounts.CreateValidatorAccounts(
+								ctx.String(flags.KeystorePathFlag.Name),
+								password,
+								numAccounts,
+								ctx.String(flags.DepositManifestFlag.Name),
+							); err != nil {
+								log.WithError(err).Fatalf("Could not create validators at path: %s", keystoreDir)
+							}
+							return nil
+						}
+						if keystoreDir, _, err := accounts.CreateValidatorAccount(ctx.String(flags.KeystorePathFlag.Name), password); err != nil {
 							log.WithError(err).Fatalf("Could not create validator at path: %s", keystoreDir)
 						}
 						return nil
@@ -118,15 +256,21 @@ contract in order to activate the validator client`,
 					Flags: []cli.Flag{
 						flags.KeystorePathFlag,
 						flags.PasswordFlag,
+						flags.PasswordFileFlag,
 					},
 					Action: func(ctx *cli.Context) error {
 						if ctx.String(flags.KeystorePathFlag.Name) == "" {
 							log.Fatalf("%s is required", flags.KeystorePathFlag.Name)
 						}
-						if ctx.String(flags.PasswordFlag.Name) == "" {
-							log.Fatalf("%s is required", flags.PasswordFlag.Name)
+						password := ctx.String(flags.PasswordFlag.Name)
+						if password == "" {
+							var err error
+							password, err = promptutil.Password("Enter your validator account password", accounts.PasswordEnvVar, ctx.String(flags.PasswordFileFlag.Name), false /* confirm */)
+							if err != nil {
+								log.WithError(err).Fatalf("%s is required", flags.PasswordFlag.Name)
+							}
 						}
-						keystores, err := accounts.DecryptKeysFromKeystore(ctx.String(flags.KeystorePathFlag.Name), ctx.String(flags.PasswordFlag.Name))
+						keystores, err := accounts.DecryptKeysFromKeystore(ctx.String(flags.KeystorePathFlag.Name), password)
 						if err != nil {
 							log.WithError(err).Fatalf("Failed to decrypt keystore keys at path %s", ctx.String(flags.KeystorePathFlag.Name))
 						}
@@ -136,6 +280,103 @@ contract in order to activate the validator client`,
 						return nil
 					},
 				},
+				{
+					Name: "delete",
+					Description: `deletes the keystore files of the given validator accounts, after exporting their slashing
+protection records as an interchange file which can be imported by the validator client taking over the keys`,
+					Flags: []cli.Flag{
+						flags.KeystorePathFlag,
+						flags.PasswordFlag,
+						flags.PasswordFileFlag,
+						flags.AccountPublicKeysFlag,
+						flags.SlashingProtectionFileFlag,
+						cmd.DataDirFlag,
+					},
+					Action: func(ctx *cli.Context) error {
+						if ctx.String(flags.KeystorePathFlag.Name) == "" {
+							log.Fatalf("%s is required", flags.KeystorePathFlag.Name)
+						}
+						pubKeys, err := accounts.ParsePublicKeys(ctx.StringSlice(flags.AccountPublicKeysFlag.Name))
+						if err != nil || len(pubKeys) == 0 {
+							log.WithError(err).Fatalf("%s is required", flags.AccountPublicKeysFlag.Name)
+						}
+						password := ctx.String(flags.PasswordFlag.Name)
+						if password == "" {
+							password, err = promptutil.Password("Enter your validator account password", accounts.PasswordEnvVar, ctx.String(flags.PasswordFileFlag.Name), false /* confirm */)
+							if err != nil {
+								log.WithError(err).Fatalf("%s is required", flags.PasswordFlag.Name)
+							}
+						}
+						if err := accounts.DeleteAccounts(
+							context.Background(),
+							ctx.String(flags.KeystorePathFlag.Name),
+							password,
+							ctx.String(cmd.DataDirFlag.Name),
+							ctx.String(flags.SlashingProtectionFileFlag.Name),
+							pubKeys,
+						); err != nil {
+							log.WithError(err).Fatal("Could not delete validator accounts")
+						}
+						return nil
+					},
+				},
+				{
+					Name:        "disable",
+					Description: `keeps the keys of the given validator accounts, but excludes them from duty scheduling`,
+					Flags: []cli.Flag{
+						flags.AccountPublicKeysFlag,
+						cmd.DataDirFlag,
+					},
+					Action: func(ctx *cli.Context) error {
+						return setAccountsDisabled(ctx, true /* disabled */)
+					},
+				},
+				{
+					Name:        "enable",
+					Description: `includes previously disabled validator accounts in duty scheduling again`,
+					Flags: []cli.Flag{
+						flags.AccountPublicKeysFlag,
+						cmd.DataDirFlag,
+					},
+					Action: func(ctx *cli.Context) error {
+						return setAccountsDisabled(ctx, false /* disabled */)
+					},
+				},
+			},
+		},
+		{
+			Name:     "duties",
+			Category: "duties",
+			Usage:    "exports the duty schedule of the validating keys of a running validator client",
+			Description: `exports the attestation slots, committee indices, aggregation assignments and proposal slots of
+the validating keys of the validator client running on this machine for the upcoming epoch, as JSON or as an
+iCalendar file which can be imported in calendar applications to plan maintenance windows around proposals`,
+			Flags: []cli.Flag{
+				flags.DutiesFormatFlag,
+				flags.DutiesOutputFlag,
+				flags.DutiesEpochFlag,
+				cmd.MonitoringHostFlag,
+				flags.MonitoringPortFlag,
+			},
+			Action: exportDuties,
+		},
+		{
+			Name:     "db",
+			Category: "db",
+			Usage:    "defines commands for maintaining the validator database",
+			Subcommands: []*cli.Command{
+				{
+					Name: "verify",
+					Description: `scans the slashing protection records for inconsistencies, such as an attestation history
+written behind its own targets, corrupted entries or signing intents left behind by a crash. With --repair,
+the records are repaired conservatively: protection is only ever widened, never narrowed. The validator
+client must be stopped while running this command`,
+					Flags: []cli.Flag{
+						flags.RepairFlag,
+						cmd.DataDirFlag,
+					},
+					Action: verifyDB,
+				},
 			},
 		},
 	}
@@ -147,6 +388,9 @@ contract in order to activate the validator client`,
 				return err
 			}
 		}
+		if err := cmd.ApplyRenamedFlags(ctx); err != nil {
+			return err
+		}
 
 		format := ctx.String(cmd.LogFormat.Name)
 		switch format {
@@ -172,6 +416,11 @@ contract in order to activate the validator client`,
 		default:
 			return fmt.Errorf("unknown log format %s", format)
 		}
+		if format != "text" {
+			// Structured log output carries the service, current slot and epoch as fields.
+			logrus.AddHook(logutil.NewContextHook())
+		}
+		logrus.SetFormatter(logutil.WrapFormatter(logrus.StandardLogger().Formatter))
 
 		logFileName := ctx.String(cmd.LogFileName.Name)
 		if logFileName != "" {
@@ -180,7 +429,6 @@ contract in order to activate the validator client`,
 			}
 		}
 
-		runtime.GOMAXPROCS(runtime.NumCPU())
 		return debug.Setup(ctx)
 	}
 
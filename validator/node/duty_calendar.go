@@ -0,0 +1,71 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/validator/client"
+)
+
+// dutyCalendarHandler exports the duty schedule of the validating keys at the epoch given by the
+// epoch query parameter, or the upcoming epoch if it is not given, as JSON or, when the format
+// query parameter is ics, in the iCalendar format.
+func (s *ValidatorClient) dutyCalendarHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "ics" {
+		http.Error(w, fmt.Sprintf("Invalid format %q, expected json or ics", format), http.StatusBadRequest)
+		return
+	}
+
+	var epoch uint64
+	var err error
+	if query.Get("epoch") != "" {
+		epoch, err = strconv.ParseUint(query.Get("epoch"), 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid epoch: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var validatorService *client.ValidatorService
+	if err := s.services.FetchService(&validatorService); err != nil {
+		http.Error(w, fmt.Sprintf("Validator service is unavailable: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	if query.Get("epoch") == "" {
+		epoch, err = validatorService.UpcomingEpoch(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not determine upcoming epoch: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	calendar, err := validatorService.DutyCalendar(r.Context(), epoch)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not get duties: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+
+	var body []byte
+	if format == "ics" {
+		w.Header().Set("Content-Type", "text/calendar")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=duties-epoch-%d.ics", epoch))
+		body = calendar.ICS()
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		body, err = json.MarshalIndent(calendar, "", "  ")
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not encode duties: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	if _, err := w.Write(body); err != nil {
+		log.WithError(err).Error("Failed to write response")
+	}
+}
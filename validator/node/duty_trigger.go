@@ -0,0 +1,48 @@
+package node
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/validator/client"
+)
+
+// dutyTriggerHandler performs the duty given by the duty query parameter, either attest or
+// propose, for the validating key given by the pubkey query parameter at the slot given by
+// the slot query parameter right away.
+func (s *ValidatorClient) dutyTriggerHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	duty := query.Get("duty")
+	slot, err := strconv.ParseUint(query.Get("slot"), 10, 64)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid slot: %v", err), http.StatusBadRequest)
+		return
+	}
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(query.Get("pubkey"), "0x"))
+	if err != nil || len(pubKeyBytes) != 48 {
+		http.Error(w, "Invalid pubkey, expected 48 hex encoded bytes", http.StatusBadRequest)
+		return
+	}
+	var pubKey [48]byte
+	copy(pubKey[:], pubKeyBytes)
+
+	var validatorService *client.ValidatorService
+	if err := s.services.FetchService(&validatorService); err != nil {
+		http.Error(w, fmt.Sprintf("Validator service is unavailable: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	if err := validatorService.TriggerDuty(r.Context(), duty, slot, pubKey); err != nil {
+		http.Error(w, fmt.Sprintf("Could not trigger duty: %v", err), http.StatusBadRequest)
+		return
+	}
+	if _, err := fmt.Fprintf(w, "Performed %s duty at slot %d, see the validator logs for its outcome\n", duty, slot); err != nil {
+		log.WithError(err).Error("Failed to write response")
+	}
+}
@@ -5,6 +5,7 @@ package node
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/cmd"
 	"github.com/prysmaticlabs/prysm/shared/debug"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/logutil"
 	"github.com/prysmaticlabs/prysm/shared/prometheus"
 	"github.com/prysmaticlabs/prysm/shared/tracing"
 	"github.com/prysmaticlabs/prysm/shared/version"
@@ -46,7 +48,9 @@ func NewValidatorClient(ctx *cli.Context) (*ValidatorClient, error) {
 	if err := tracing.Setup(
 		"validator", // service name
 		ctx.String(cmd.TracingProcessNameFlag.Name),
+		ctx.String(cmd.TracingExporterFlag.Name),
 		ctx.String(cmd.TracingEndpointFlag.Name),
+		ctx.StringSlice(cmd.TracingTagsFlag.Name),
 		ctx.Float64(cmd.TraceSampleFractionFlag.Name),
 		ctx.Bool(cmd.EnableTracingFlag.Name),
 	); err != nil {
@@ -58,7 +62,12 @@ func NewValidatorClient(ctx *cli.Context) (*ValidatorClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	logrus.SetLevel(level)
+	overrides, err := logutil.ParseLevelOverrides(ctx.StringSlice(cmd.LogLevelOverrideFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	logutil.SetLevels(level, overrides)
+	logutil.ReloadLevelsOnSIGHUP(ctx.String(cmd.ConfigFileFlag.Name))
 
 	registry := shared.NewServiceRegistry()
 	ValidatorClient := &ValidatorClient{
@@ -162,9 +171,34 @@ func (s *ValidatorClient) Close() {
 }
 
 func (s *ValidatorClient) registerPrometheusService(ctx *cli.Context) error {
+	additionalHandlers := []prometheus.Handler{
+		{
+			Path:    "/duties",
+			Handler: s.dutyCalendarHandler,
+		},
+	}
+	if ctx.Bool(flags.EnableDutyTriggerFlag.Name) {
+		log.Warn("Enabled the debug endpoint triggering validator duties")
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{
+			Path:    "/debug/duties",
+			Handler: s.dutyTriggerHandler,
+		})
+	}
+	if ctx.Bool(flags.EstimateRewardsFlag.Name) {
+		additionalHandlers = append(additionalHandlers, prometheus.Handler{
+			Path:    "/performance",
+			Handler: s.performanceHandler,
+		})
+	}
 	service := prometheus.NewPrometheusService(
-		fmt.Sprintf(":%d", ctx.Int64(flags.MonitoringPortFlag.Name)),
+		cmd.HTTPServerConfig(
+			ctx,
+			ctx.String(cmd.MonitoringHostFlag.Name),
+			int(ctx.Int64(flags.MonitoringPortFlag.Name)),
+			ctx.String(cmd.MonitoringCorsDomainFlag.Name),
+		),
 		s.services,
+		additionalHandlers...,
 	)
 	logrus.AddHook(prometheus.NewLogrusCollector())
 	return s.services.RegisterService(service)
@@ -190,6 +224,9 @@ func (s *ValidatorClient) registerClientService(ctx *cli.Context, keyManager key
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcHeadersFlag:            ctx.String(flags.GrpcHeadersFlag.Name),
+		DryRun:                     ctx.Bool(flags.DryRunFlag.Name),
+		DryRunOutput:               ctx.String(flags.DryRunOutputFlag.Name),
+		EstimateRewards:            ctx.Bool(flags.EstimateRewardsFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize client service")
@@ -200,6 +237,12 @@ func (s *ValidatorClient) registerClientService(ctx *cli.Context, keyManager key
 // selectKeyManager selects the key manager depending on the options provided by the user.
 func selectKeyManager(ctx *cli.Context) (keymanager.KeyManager, error) {
 	manager := strings.ToLower(ctx.String(flags.KeyManager.Name))
+	if keyManagers := ctx.String(flags.KeyManagers.Name); keyManagers != "" {
+		if manager != "" {
+			return nil, errors.New("--keymanager and --keymanagers cannot be used together")
+		}
+		return selectKeyManagers(keyManagers)
+	}
 	opts := ctx.String(flags.KeyManagerOpts.Name)
 	if opts == "" {
 		opts = "{}"
@@ -238,6 +281,56 @@ func selectKeyManager(ctx *cli.Context) (keymanager.KeyManager, error) {
 		}
 	}
 
+	return newKeyManager(manager, opts)
+}
+
+// keyManagerSource is an entry of the key managers flag.
+type keyManagerSource struct {
+	Name       string          `json:"name"`
+	KeyManager string          `json:"keymanager"`
+	Opts       json.RawMessage `json:"opts"`
+}
+
+// selectKeyManagers creates a key manager merging the key managers listed in the input, which
+// can be either JSON data or a path to a file containing JSON.
+func selectKeyManagers(input string) (keymanager.KeyManager, error) {
+	data := []byte(input)
+	if !strings.HasPrefix(input, "[") {
+		fileData, err := ioutil.ReadFile(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to read keymanagers file")
+		}
+		data = fileData
+	}
+	var entries []*keyManagerSource
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrap(err, "Failed to decode keymanagers")
+	}
+	sources := make([]*keymanager.Source, 0, len(entries))
+	for i, entry := range entries {
+		if entry.Name == "" {
+			entry.Name = fmt.Sprintf("%s-%d", entry.KeyManager, i)
+		}
+		// Options are either a JSON object or a JSON string holding the path to one.
+		opts := string(entry.Opts)
+		var path string
+		if err := json.Unmarshal(entry.Opts, &path); err == nil {
+			opts = path
+		}
+		if opts == "" || opts == "null" {
+			opts = "{}"
+		}
+		km, err := newKeyManager(strings.ToLower(entry.KeyManager), opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not create keymanager %s", entry.Name)
+		}
+		sources = append(sources, &keymanager.Source{Name: entry.Name, KeyManager: km})
+	}
+	return keymanager.NewMulti(sources)
+}
+
+// newKeyManager creates a key manager of the given type from its options.
+func newKeyManager(manager string, opts string) (keymanager.KeyManager, error) {
 	var km keymanager.KeyManager
 	var help string
 	var err error
@@ -2,9 +2,13 @@ package node
 
 import (
 	"flag"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
 	"github.com/prysmaticlabs/prysm/validator/accounts"
 	"gopkg.in/urfave/cli.v2"
@@ -39,3 +43,68 @@ func TestNode_Builds(t *testing.T) {
 		t.Fatalf("Failed to create ValidatorClient: %v", err)
 	}
 }
+
+func TestSelectKeyManagers(t *testing.T) {
+	km, err := selectKeyManagers(`[
+		{"name":"first","keymanager":"interop","opts":{"keys":2,"offset":0}},
+		{"name":"second","keymanager":"interop","opts":{"keys":2,"offset":1}}
+	]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := km.FetchValidatingKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The key at offset 1 is held by both key managers.
+	if len(keys) != 3 {
+		t.Errorf("Incorrect number of keys returned; expected 3, received %d", len(keys))
+	}
+
+	if _, err := selectKeyManagers(`[{"name":"bad","keymanager":"unknown"}]`); err == nil {
+		t.Error("Expected error for an unknown keymanager")
+	}
+}
+
+func TestDutyTriggerHandler(t *testing.T) {
+	s := &ValidatorClient{services: shared.NewServiceRegistry()}
+	pubKey := "0x" + strings.Repeat("ab", 48)
+	tests := []struct {
+		method string
+		query  string
+		code   int
+	}{
+		{method: http.MethodGet, query: "duty=attest&slot=1&pubkey=" + pubKey, code: http.StatusMethodNotAllowed},
+		{method: http.MethodPost, query: "duty=attest&slot=x&pubkey=" + pubKey, code: http.StatusBadRequest},
+		{method: http.MethodPost, query: "duty=attest&slot=1&pubkey=0xabcd", code: http.StatusBadRequest},
+		{method: http.MethodPost, query: "duty=attest&slot=1&pubkey=" + pubKey, code: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		s.dutyTriggerHandler(rec, httptest.NewRequest(tt.method, "/debug/duties?"+tt.query, nil))
+		if rec.Code != tt.code {
+			t.Errorf("%s %s: expected status %d, received %d", tt.method, tt.query, tt.code, rec.Code)
+		}
+	}
+}
+
+func TestDutyCalendarHandler(t *testing.T) {
+	s := &ValidatorClient{services: shared.NewServiceRegistry()}
+	tests := []struct {
+		method string
+		query  string
+		code   int
+	}{
+		{method: http.MethodPost, query: "format=json", code: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, query: "format=csv", code: http.StatusBadRequest},
+		{method: http.MethodGet, query: "epoch=x", code: http.StatusBadRequest},
+		{method: http.MethodGet, query: "format=ics&epoch=1", code: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		s.dutyCalendarHandler(rec, httptest.NewRequest(tt.method, "/duties?"+tt.query, nil))
+		if rec.Code != tt.code {
+			t.Errorf("%s %s: expected status %d, received %d", tt.method, tt.query, tt.code, rec.Code)
+		}
+	}
+}
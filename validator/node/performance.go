@@ -0,0 +1,60 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/prysmaticlabs/prysm/validator/client"
+)
+
+// performanceHandler exports the reward estimates of the validating keys in the most recent
+// epochs as JSON, along with the rewards realized in the epoch transitions and the difference
+// between the two. The epoch query parameter restricts them to a single epoch.
+func (s *ValidatorClient) performanceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	var epoch uint64
+	var err error
+	if query.Get("epoch") != "" {
+		epoch, err = strconv.ParseUint(query.Get("epoch"), 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid epoch: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var validatorService *client.ValidatorService
+	if err := s.services.FetchService(&validatorService); err != nil {
+		http.Error(w, fmt.Sprintf("Validator service is unavailable: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	estimates, err := validatorService.RewardEstimates()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not get reward estimates: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	if query.Get("epoch") != "" {
+		filtered := make([]*client.RewardEstimate, 0, len(estimates))
+		for _, estimate := range estimates {
+			if estimate.Epoch == epoch {
+				filtered = append(filtered, estimate)
+			}
+		}
+		estimates = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	body, err := json.MarshalIndent(estimates, "", "  ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not encode reward estimates: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if _, err := w.Write(body); err != nil {
+		log.WithError(err).Error("Failed to write response")
+	}
+}
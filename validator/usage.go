@@ -45,17 +45,28 @@ var appHelpFlagGroups = []flagGroup{
 		Name: "cmd",
 		Flags: []cli.Flag{
 			cmd.VerbosityFlag,
+			cmd.LogLevelOverrideFlag,
 			cmd.DataDirFlag,
 			cmd.ClearDB,
 			cmd.ForceClearDB,
 			cmd.EnableTracingFlag,
 			cmd.TracingProcessNameFlag,
 			cmd.TracingEndpointFlag,
+			cmd.TracingExporterFlag,
+			cmd.TracingTagsFlag,
 			cmd.TraceSampleFractionFlag,
 			flags.MonitoringPortFlag,
+			flags.EnableDutyTriggerFlag,
+			cmd.MonitoringHostFlag,
+			cmd.MonitoringCorsDomainFlag,
+			cmd.HTTPTLSCertFlag,
+			cmd.HTTPTLSKeyFlag,
+			cmd.HTTPDisabledEndpointsFlag,
 			cmd.LogFormat,
 			cmd.LogFileName,
 			cmd.ConfigFileFlag,
+			cmd.ChainConfigFileFlag,
+			cmd.NetworkFlag,
 		},
 	},
 	{
@@ -67,6 +78,8 @@ var appHelpFlagGroups = []flagGroup{
 			debug.MemProfileRateFlag,
 			debug.CPUProfileFlag,
 			debug.TraceFlag,
+			debug.GCPercentFlag,
+			debug.GCBallastFlag,
 		},
 	},
 	{
@@ -76,6 +89,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.CertFlag,
 			flags.KeyManager,
 			flags.KeyManagerOpts,
+			flags.KeyManagers,
 			flags.KeystorePathFlag,
 			flags.PasswordFlag,
 			flags.DisablePenaltyRewardLogFlag,
@@ -85,6 +99,9 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GrpcRetriesFlag,
 			flags.GrpcHeadersFlag,
 			flags.AccountMetricsFlag,
+			flags.DryRunFlag,
+			flags.DryRunOutputFlag,
+			flags.EstimateRewardsFlag,
 		},
 	},
 	{